@@ -65,10 +65,16 @@ export function AuthModal({
             ? `${API_BASE}/api/login`
             : `${API_BASE}/api/signup`;
 
+        // The API rejects unknown fields, so login must not send the name
+        const payload =
+          mode === 'login'
+            ? { email: data.email, password: data.password }
+            : data;
+
         const response = await fetchWithCsrf(endpoint, {
           method: 'POST',
           headers: { 'Content-Type': 'application/json' },
-          body: JSON.stringify(data),
+          body: JSON.stringify(payload),
         });
 
         // If response is not OK
@@ -83,8 +89,8 @@ export function AuthModal({
           // For other error responses, try to parse the error message
           try {
             const errorData = await response.json();
-            if (errorData.error) {
-              throw new Error(errorData.error);
+            if (errorData.error?.message) {
+              throw new Error(errorData.error.message);
             }
           } catch (jsonError) {
             // If parsing fails, use a generic error message
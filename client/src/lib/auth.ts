@@ -102,7 +102,7 @@ export const useAuthCheck = () => {
     if (response.status === 401) {
       try {
         const data = await response.json();
-        if (data.code === 'token_expired') {
+        if (data.error?.code === 'token_expired') {
           // Handle token refresh
           if (!isRefreshing) {
             isRefreshing = true;
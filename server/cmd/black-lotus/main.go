@@ -1,26 +1,43 @@
 package main
 
 import (
-	"log"
+	"context"
+	"log/slog"
 	"os"
 	"time"
 
 	"black-lotus/internal/api"
+	"black-lotus/internal/common/logging"
+	"black-lotus/internal/features/trips"
+	"black-lotus/internal/infrastructure/repositories"
 	"black-lotus/pkg/db"
 )
 
 func main() {
+	// Configure the default logger from LOG_LEVEL
+	logging.Setup()
+
 	// Initialize database connection
 	if err := db.Initialize(); err != nil {
-		log.Fatalf("Failed to initialize database: %v", err)
+		slog.Error("Failed to initialize database", "error", err)
+		os.Exit(1)
 	}
 
 	defer db.Close()
-	log.Println("Successfully connected to PostgreSQL")
+	slog.Info("Successfully connected to PostgreSQL")
 
 	// Start the cleanup job for expired records
 	db.StartCleanupJob(1 * time.Hour) // Run cleanup every hour
-	log.Println("Started database cleanup job")
+	slog.Info("Started database cleanup job")
+
+	// Background jobs stop when main returns
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Remind owners the day before their trips start (REMINDER_INTERVAL, default 1h)
+	reminders := trips.NewReminderJob(repositories.NewTripRepository(db.DB), trips.LogNotifier{}, nil)
+	reminders.Start(ctx, trips.ReminderIntervalFromEnv())
+	slog.Info("Started trip reminder job")
 
 	// Create and configure the server
 	server := api.NewServer()
@@ -35,6 +52,9 @@ func main() {
 	}
 
 	// Start server
-	log.Printf("Server starting on port %s", port)
-	log.Fatal(server.Start(port))
+	slog.Info("Server starting", "port", port)
+	if err := server.Start(port); err != nil {
+		slog.Error("Server stopped", "error", err)
+		os.Exit(1)
+	}
 }
@@ -5,13 +5,23 @@ import (
 	"github.com/labstack/echo/v4"
 
 	"black-lotus/internal/api/routes"
+	"black-lotus/internal/common/middleware"
 	validation "black-lotus/internal/common/validations"
 )
 
 func SetupRouter(e *echo.Echo) *echo.Echo {
 	v := validator.New()
 	validation.RegisterPasswordValidators(v)
+
+	// Cap request bodies before any handler binds them (BODY_LIMIT, default 1M)
+	e.Use(middleware.BodyLimit(middleware.BodyLimitFromEnv()))
+
 	routes.RegisterAuthRoutes(e, v)
+	routes.RegisterTripRoutes(e)
+	routes.RegisterReminderRoutes(e)
+	routes.RegisterAdminRoutes(e, v)
+	routes.RegisterHealthRoutes(e)
+	routes.RegisterDocsRoutes(e)
 
 	// Test Routes
 	e.GET("/oauth-test", func(c echo.Context) error {
@@ -22,11 +32,5 @@ func SetupRouter(e *echo.Echo) *echo.Echo {
 		return c.File("public/oauth-test.html")
 	})
 
-	e.GET("/health", func(c echo.Context) error {
-		return c.JSON(200, map[string]string{
-			"status": "healthy",
-		})
-	})
-
 	return e
 }
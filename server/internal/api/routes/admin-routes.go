@@ -0,0 +1,37 @@
+// server/internal/api/routes/admin_routes.go
+package routes
+
+import (
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+
+	"black-lotus/internal/common/middleware"
+	"black-lotus/internal/domain/models"
+	"black-lotus/internal/features/admin"
+	"black-lotus/internal/features/auth/session"
+	"black-lotus/internal/features/auth/user"
+	"black-lotus/internal/infrastructure/repositories"
+	"black-lotus/pkg/db"
+)
+
+// RegisterAdminRoutes registers the support routes, which only admins may use
+func RegisterAdminRoutes(e *echo.Echo, validator *validator.Validate) {
+	// Create repositories
+	userRepo := repositories.NewUserRepository(db.DB)
+	tripRepo := repositories.NewTripRepository(db.DB)
+	sessionRepo := repositories.NewSessionRepository(db.DB)
+
+	// Create services
+	sessionService := session.NewService(sessionRepo)
+	userService := user.NewService(userRepo)
+	adminService := admin.NewService(userRepo, tripRepo)
+
+	// Create handler and the middleware that keeps non-admins out
+	adminHandler := admin.NewHandler(adminService, validator)
+	authMiddleware := middleware.NewAuthMiddleware(sessionService, userService)
+
+	// Admin Routes
+	adminRoutes := e.Group("/api/admin", authMiddleware.Authenticate, authMiddleware.RequireRole(models.UserRoleAdmin))
+	adminRoutes.GET("/trips", adminHandler.GetUserTrips)
+	adminRoutes.PUT("/users/:id/role", adminHandler.SetUserRole)
+}
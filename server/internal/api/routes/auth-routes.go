@@ -6,16 +6,21 @@ import (
 	"github.com/labstack/echo/v4"
 
 	"black-lotus/internal/common/middleware"
+	"black-lotus/internal/features/auth/apikeys"
 	"black-lotus/internal/features/auth/login"
 	"black-lotus/internal/features/auth/oauth"
 	"black-lotus/internal/features/auth/oauth/github"
 	"black-lotus/internal/features/auth/oauth/google"
+	"black-lotus/internal/features/auth/password"
 	"black-lotus/internal/features/auth/register"
 	"black-lotus/internal/features/auth/session"
 	"black-lotus/internal/features/auth/user"
+	"black-lotus/internal/features/auth/verification"
+	"black-lotus/internal/features/profiles/edit"
 	"black-lotus/internal/features/profiles/view"
 	"black-lotus/internal/infrastructure/repositories"
 	"black-lotus/pkg/db"
+	"black-lotus/pkg/mail"
 )
 
 // RegisterAuthRoutes registers all authentication-related routes
@@ -24,6 +29,7 @@ func RegisterAuthRoutes(e *echo.Echo, validator *validator.Validate) {
 	userRepo := repositories.NewUserRepository(db.DB)
 	sessionRepo := repositories.NewSessionRepository(db.DB)
 	oauthRepo := repositories.NewOAuthRepository(db.DB)
+	apiKeyRepo := repositories.NewAPIKeyRepository(db.DB)
 
 	// Create session service (used by multiple features)
 	sessionService := session.NewService(sessionRepo)
@@ -32,7 +38,11 @@ func RegisterAuthRoutes(e *echo.Echo, validator *validator.Validate) {
 	loginService := login.NewService(userRepo)
 	registerService := register.NewService(userRepo)
 	userService := user.NewService(userRepo)
+	passwordService := password.NewService(userRepo)
+	verificationService := verification.NewService(userRepo, mail.FromEnv())
 	profileService := view.NewService(userRepo)
+	profileEditService := edit.NewService(userRepo)
+	apiKeyService := apikeys.NewService(apiKeyRepo)
 
 	// Create OAuth provider services
 	githubService := github.NewService(oauthRepo, userRepo)
@@ -46,8 +56,12 @@ func RegisterAuthRoutes(e *echo.Echo, validator *validator.Validate) {
 	loginHandler := login.NewHandler(loginService, sessionService, validator)
 	registerHandler := register.NewHandler(registerService, sessionService, validator)
 	userHandler := user.NewHandler(userService)
+	passwordHandler := password.NewHandler(passwordService, sessionService, validator)
+	verificationHandler := verification.NewHandler(verificationService, sessionService)
 	sessionHandler := session.NewHandler(sessionService)
 	profileHandler := view.NewHandler(profileService, sessionService)
+	profileEditHandler := edit.NewHandler(profileEditService, sessionService, validator)
+	apiKeyHandler := apikeys.NewHandler(apiKeyService, validator)
 
 	// Create OAuth main handler that composes provider handlers
 	oauthHandler := oauth.NewHandler(githubHandler, googleHandler)
@@ -55,10 +69,15 @@ func RegisterAuthRoutes(e *echo.Echo, validator *validator.Validate) {
 	// Create auth middleware
 	authMiddleware := middleware.NewAuthMiddleware(sessionService, userService)
 
+	// Accept Authorization: Bearer API keys on every route as an alternative
+	// to the access token cookie
+	e.Use(middleware.APIKeyAuth(apiKeyService))
+
 	// Public Routes
 	e.POST("/api/signup", registerHandler.Register)
 	e.POST("/api/login", loginHandler.Login)
 	e.POST("/api/logout", sessionHandler.LogoutUser)
+	e.POST("/api/auth/refresh", sessionHandler.RefreshToken)
 	e.GET("/api/csrf-token", sessionHandler.GetCSRFToken)
 
 	// OAuth Routes
@@ -72,4 +91,12 @@ func RegisterAuthRoutes(e *echo.Echo, validator *validator.Validate) {
 	protected.Use(authMiddleware.Authenticate)
 	protected.GET("/user/:id", userHandler.GetUserByID)
 	protected.GET("/profile", profileHandler.GetUserProfile)
+	protected.GET("/auth/me/stats", profileHandler.GetUserStats)
+	protected.PATCH("/auth/profile", profileEditHandler.UpdateProfile)
+	protected.POST("/auth/change-password", passwordHandler.ChangePassword)
+	protected.POST("/auth/sessions/revoke", sessionHandler.RevokeSessions)
+	protected.GET("/auth/verify/status", verificationHandler.GetVerificationStatus)
+	protected.POST("/auth/api-keys", apiKeyHandler.CreateAPIKey)
+	protected.GET("/auth/api-keys", apiKeyHandler.ListAPIKeys)
+	protected.DELETE("/auth/api-keys/:id", apiKeyHandler.RevokeAPIKey)
 }
@@ -0,0 +1,16 @@
+// server/internal/api/routes/docs_routes.go
+package routes
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"black-lotus/internal/features/docs"
+)
+
+// RegisterDocsRoutes serves the OpenAPI document and Swagger UI
+func RegisterDocsRoutes(e *echo.Echo) {
+	docsHandler := docs.NewHandler()
+
+	e.GET("/openapi.json", docsHandler.Spec)
+	e.GET("/docs", docsHandler.UI)
+}
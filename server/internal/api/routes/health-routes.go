@@ -0,0 +1,20 @@
+// server/internal/api/routes/health_routes.go
+package routes
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"black-lotus/internal/features/health"
+	"black-lotus/pkg/db"
+)
+
+// RegisterHealthRoutes registers the root document, version and the liveness
+// and readiness probes. These are intentionally registered without auth middleware.
+func RegisterHealthRoutes(e *echo.Echo) {
+	healthHandler := health.NewHandler(db.Ping)
+
+	e.GET("/", healthHandler.Root)
+	e.GET("/version", healthHandler.Version)
+	e.GET("/health", healthHandler.Liveness)
+	e.GET("/ready", healthHandler.Readiness)
+}
@@ -0,0 +1,20 @@
+// server/internal/api/routes/reminder_routes.go
+package routes
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"black-lotus/internal/features/reminders"
+	"black-lotus/internal/infrastructure/repositories"
+	"black-lotus/pkg/db"
+)
+
+// RegisterReminderRoutes registers the links sent in trip reminders. The
+// reminder's token is their only credential, so no session is needed.
+func RegisterReminderRoutes(e *echo.Echo) {
+	reminderService := reminders.NewService(repositories.NewTripRepository(db.DB), nil)
+	reminderHandler := reminders.NewHandler(reminderService)
+
+	e.GET("/api/reminders/:token/ack", reminderHandler.AcknowledgeReminder)
+	e.GET("/api/reminders/:token/snooze", reminderHandler.SnoozeReminder)
+}
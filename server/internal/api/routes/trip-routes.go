@@ -0,0 +1,102 @@
+// server/internal/api/routes/trip_routes.go
+package routes
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"black-lotus/internal/features/activities"
+	"black-lotus/internal/features/auth/session"
+	"black-lotus/internal/features/expenses"
+	"black-lotus/internal/features/forecasts"
+	"black-lotus/internal/features/photos"
+	"black-lotus/internal/features/profiles/view"
+	"black-lotus/internal/features/trips"
+	"black-lotus/internal/infrastructure/repositories"
+	"black-lotus/pkg/db"
+	"black-lotus/pkg/storage"
+	"black-lotus/pkg/weather"
+)
+
+// RegisterTripRoutes registers all trip-related routes
+func RegisterTripRoutes(e *echo.Echo) {
+	// Create repositories
+	tripRepo := repositories.NewTripRepository(db.DB)
+	activityRepo := repositories.NewActivityRepository(db.DB)
+	expenseRepo := repositories.NewExpenseRepository(db.DB)
+	photoRepo := repositories.NewPhotoRepository(db.DB)
+	userRepo := repositories.NewUserRepository(db.DB)
+	sessionRepo := repositories.NewSessionRepository(db.DB)
+
+	// Create services
+	sessionService := session.NewService(sessionRepo)
+	profileService := view.NewService(userRepo)
+	tripService := trips.NewService(tripRepo, profileService, trips.CoverSourceFromEnv())
+	activityService := activities.NewService(activityRepo, tripRepo)
+	expenseService := expenses.NewService(expenseRepo, tripRepo)
+	photoService := photos.NewService(photoRepo, tripRepo, storage.FromEnv())
+	forecastService := forecasts.NewService(tripRepo, weather.NewCachedProvider(weather.FromEnv(), weather.DefaultCacheTTL))
+
+	// Create handler - trip handlers validate the access token themselves
+	tripHandler := trips.NewHandler(tripService, sessionService)
+	activityHandler := activities.NewHandler(activityService, sessionService)
+	expenseHandler := expenses.NewHandler(expenseService, sessionService)
+	photoHandler := photos.NewHandler(photoService, sessionService)
+	forecastHandler := forecasts.NewHandler(forecastService, sessionService)
+
+	// Trip Routes
+	tripRoutes := e.Group("/api/trips")
+	tripRoutes.POST("", tripHandler.CreateTrip)
+	tripRoutes.GET("", tripHandler.GetUserTrips)
+	tripRoutes.POST("/bulk", tripHandler.CreateTrips)
+	tripRoutes.POST("/bulk-tag", tripHandler.BulkTagTrips)
+	tripRoutes.POST("/import", tripHandler.ImportTripsCSV)
+	tripRoutes.POST("/import-one", tripHandler.ImportTrip)
+	tripRoutes.PUT("/order", tripHandler.ReorderTrips)
+	tripRoutes.GET("/current", tripHandler.GetCurrentTrips)
+	tripRoutes.GET("/upcoming", tripHandler.GetUpcomingTrips)
+	tripRoutes.GET("/preview-name", tripHandler.PreviewTripName)
+	tripRoutes.GET("/export", tripHandler.ExportTrips)
+	tripRoutes.GET("/export.ics", tripHandler.ExportCalendar)
+	tripRoutes.GET("/stats/cadence", tripHandler.GetTripCadence)
+	tripRoutes.GET("/by-location/:location", tripHandler.GetLocationHistory)
+	tripRoutes.GET("/:id", tripHandler.GetTrip)
+	tripRoutes.GET("/:id/with-user", tripHandler.GetTripWithUser)
+	tripRoutes.GET("/:id/export.json", tripHandler.ExportTrip)
+	tripRoutes.GET("/:id/export.ics", tripHandler.ExportTripCalendar)
+	tripRoutes.GET("/:id/print", tripHandler.PrintTrip)
+	tripRoutes.GET("/:id/days-breakdown", tripHandler.GetTripDaysBreakdown)
+	tripRoutes.GET("/:id/weather", forecastHandler.GetTripWeather)
+	tripRoutes.PUT("/:id", tripHandler.UpdateTrip)
+	tripRoutes.DELETE("/:id", tripHandler.DeleteTrip)
+	tripRoutes.POST("/:id/restore", tripHandler.RestoreTrip)
+	tripRoutes.POST("/:id/plan", tripHandler.PlanTrip)
+	tripRoutes.POST("/:id/share", tripHandler.ShareTrip)
+	tripRoutes.DELETE("/:id/share", tripHandler.UnshareTrip)
+	tripRoutes.POST("/:id/tags", tripHandler.AddTripTag)
+	tripRoutes.DELETE("/:id/tags/:tag", tripHandler.RemoveTripTag)
+
+	// Activity Routes - nested under their trip
+	tripRoutes.POST("/:id/activities", activityHandler.CreateActivity)
+	tripRoutes.GET("/:id/activities", activityHandler.GetActivities)
+	tripRoutes.GET("/:id/activities/:activityId", activityHandler.GetActivity)
+	tripRoutes.PUT("/:id/activities/:activityId", activityHandler.UpdateActivity)
+	tripRoutes.DELETE("/:id/activities/:activityId", activityHandler.DeleteActivity)
+
+	// Expense Routes - amounts are integer cents
+	tripRoutes.POST("/:id/expenses", expenseHandler.CreateExpense)
+	tripRoutes.GET("/:id/expenses", expenseHandler.GetExpenses)
+	tripRoutes.GET("/:id/expenses/timeline", expenseHandler.GetExpenseTimeline)
+	tripRoutes.GET("/:id/expenses/settlement", expenseHandler.GetSettlement)
+	tripRoutes.DELETE("/:id/expenses/:expenseId", expenseHandler.DeleteExpense)
+	tripRoutes.GET("/:id/budget", expenseHandler.GetBudget)
+
+	// Photo Routes - metadata only, the images are hosted externally or
+	// uploaded straight to object storage through a presigned URL
+	tripRoutes.POST("/:id/photos", photoHandler.AddPhoto)
+	tripRoutes.POST("/:id/photos/upload-url", photoHandler.CreateUpload)
+	tripRoutes.GET("/:id/photos", photoHandler.GetPhotos)
+	tripRoutes.DELETE("/:id/photos/:photoId", photoHandler.DeletePhoto)
+
+	// Public Routes - read-only shared trips, no session needed
+	e.GET("/api/public/trips/:token", tripHandler.GetSharedTrip)
+}
@@ -1,12 +1,22 @@
 package api
 
 import (
+	"log/slog"
 	"net/http"
 
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
+
+	"black-lotus/internal/common/binding"
+	appmiddleware "black-lotus/internal/common/middleware"
+	"black-lotus/internal/common/response"
 )
 
+// streamingRoutes are exempt from the request timeout
+var streamingRoutes = map[string]bool{
+	"/api/trips/export": true,
+}
+
 type Server struct {
 	echo *echo.Echo
 }
@@ -16,27 +26,42 @@ func NewServer() *Server {
 	// Initialize Echo
 	e := echo.New()
 
+	// Render framework errors with the same envelope as handlers
+	e.HTTPErrorHandler = response.HTTPErrorHandler
+
+	// Take the client IP from the connection unless it came through a proxy in
+	// TRUSTED_PROXIES, so X-Forwarded-For can't be used to dodge rate limits
+	e.IPExtractor = appmiddleware.IPExtractorFromEnv()
+
+	// Reject unknown JSON fields in request bodies unless STRICT_JSON=false
+	e.Binder = &binding.StrictBinder{AllowUnknownFields: !binding.StrictJSONFromEnv()}
+
 	// Add middleware
-	e.Use(middleware.Logger())
+	e.Use(appmiddleware.RequestLogger(slog.Default(), appmiddleware.BodyLoggingFromEnv()))
 	e.Use(middleware.Recover())
 	e.Use(middleware.CORSWithConfig(middleware.CORSConfig{
 		AllowOrigins:     []string{"http://localhost:3000"},
-		AllowMethods:     []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete, http.MethodOptions},
-		AllowHeaders:     []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept, "X-CSRF-TOKEN"},
-		ExposeHeaders:    []string{"Set-Cookie"},
+		AllowMethods:     []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete, http.MethodOptions},
+		AllowHeaders:     []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept, appmiddleware.CSRFHeader, "If-None-Match"},
+		ExposeHeaders:    []string{"Set-Cookie", echo.HeaderXRequestID, "ETag"},
 		AllowCredentials: true,  // This is crucial for sending cookies
 		MaxAge:           86400, // 1 day to cache preflight requests
 	}))
-	e.Use(middleware.CSRFWithConfig(middleware.CSRFConfig{
-		TokenLookup:    "header:X-CSRF-Token",
-		CookieName:     "csrf_token",
-		CookiePath:     "/",
-		CookieHTTPOnly: false,
-		CookieMaxAge:   3600, // 1 hour
-	}))
+	// Double-submit cookie CSRF protection for POST/PUT/PATCH/DELETE - clients send X-CSRF-Token
+	e.Use(appmiddleware.CSRF())
 
 	// Rate limiting to prevent abuse
-	e.Use(middleware.RateLimiter(middleware.NewRateLimiterMemoryStore(20))) // 20 requests per second
+	e.Use(appmiddleware.RateLimit(appmiddleware.NewRateLimiterStore(20, 20))) // 20 requests per second
+
+	// Bound how long a request's database work may run (REQUEST_TIMEOUT, default 5s).
+	// Streamed downloads run for as long as the client reads and bound each
+	// query themselves instead.
+	e.Use(appmiddleware.RequestTimeoutWithConfig(appmiddleware.RequestTimeoutConfig{
+		Timeout: appmiddleware.RequestTimeoutFromEnv(),
+		Skipper: func(c echo.Context) bool {
+			return streamingRoutes[c.Path()]
+		},
+	}))
 
 	return &Server{
 		echo: e,
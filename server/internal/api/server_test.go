@@ -0,0 +1,124 @@
+package api_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"black-lotus/internal/api"
+)
+
+func TestServerCORSPreflight(t *testing.T) {
+	testCases := []struct {
+		name   string
+		method string
+		path   string
+	}{
+		{name: "Get", method: http.MethodGet, path: "/api/trips"},
+		{name: "Post", method: http.MethodPost, path: "/api/trips"},
+		{name: "Put", method: http.MethodPut, path: "/api/trips/abc"},
+		{name: "Patch", method: http.MethodPatch, path: "/api/auth/profile"},
+		{name: "Delete", method: http.MethodDelete, path: "/api/trips/abc"},
+	}
+
+	e := api.NewServer().Echo()
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodOptions, tc.path, nil)
+			req.Header.Set("Origin", "http://localhost:3000")
+			req.Header.Set("Access-Control-Request-Method", tc.method)
+			rec := httptest.NewRecorder()
+
+			e.ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusNoContent {
+				t.Fatalf("Expected status %d, got %d", http.StatusNoContent, rec.Code)
+			}
+			if origin := rec.Header().Get("Access-Control-Allow-Origin"); origin != "http://localhost:3000" {
+				t.Errorf("Expected the origin to be allowed, got %q", origin)
+			}
+			allowed := strings.Split(rec.Header().Get("Access-Control-Allow-Methods"), ",")
+			found := false
+			for _, method := range allowed {
+				if method == tc.method {
+					found = true
+				}
+			}
+			if !found {
+				t.Errorf("Expected %s in the allowed methods, got %v", tc.method, allowed)
+			}
+		})
+	}
+}
+
+func TestServerRequestTimeoutSkipsStreamingRoutes(t *testing.T) {
+	testCases := []struct {
+		name     string
+		path     string
+		expected string
+	}{
+		{name: "Streaming", path: "/api/trips/export", expected: "abc"},
+		{name: "Regular", path: "/api/trips", expected: "a"},
+	}
+
+	t.Setenv("REQUEST_TIMEOUT", "50ms")
+	e := api.NewServer().Echo()
+
+	// Writes a chunk, then waits past the request timeout before the next one,
+	// stopping as the export does once the request's context is done
+	stream := func(c echo.Context) error {
+		res := c.Response()
+		res.WriteHeader(http.StatusOK)
+		for i, chunk := range []string{"a", "b", "c"} {
+			if i > 0 {
+				select {
+				case <-time.After(60 * time.Millisecond):
+				case <-c.Request().Context().Done():
+					return nil
+				}
+			}
+			res.Write([]byte(chunk))
+			res.Flush()
+		}
+		return nil
+	}
+	e.GET("/api/trips/export", stream)
+	e.GET("/api/trips", stream)
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tc.path, nil)
+			rec := httptest.NewRecorder()
+
+			e.ServeHTTP(rec, req)
+
+			if body := rec.Body.String(); body != tc.expected {
+				t.Errorf("Expected body %q, got %q", tc.expected, body)
+			}
+		})
+	}
+}
+
+func TestServerIgnoresUntrustedForwardedFor(t *testing.T) {
+	t.Setenv("TRUSTED_PROXIES", "")
+	e := api.NewServer().Echo()
+	e.GET("/ip", func(c echo.Context) error {
+		return c.String(http.StatusOK, c.RealIP())
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/ip", nil)
+	req.RemoteAddr = "198.51.100.7:1234"
+	req.Header.Set(echo.HeaderXForwardedFor, "203.0.113.9")
+	rec := httptest.NewRecorder()
+
+	e.ServeHTTP(rec, req)
+
+	if ip := rec.Body.String(); ip != "198.51.100.7" {
+		t.Errorf("Expected the connection's IP, got %q", ip)
+	}
+}
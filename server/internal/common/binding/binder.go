@@ -0,0 +1,145 @@
+package binding
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// UnknownFieldError is returned when a JSON body contains a field the target
+// struct doesn't declare, which is usually a typo in the client's payload
+type UnknownFieldError struct {
+	Field string
+}
+
+func (e *UnknownFieldError) Error() string {
+	return fmt.Sprintf("unknown field %q", e.Field)
+}
+
+// StrictBinder behaves like echo.DefaultBinder except that JSON bodies are
+// decoded with unknown fields rejected instead of silently ignored
+type StrictBinder struct {
+	echo.DefaultBinder
+
+	// AllowUnknownFields turns strict mode off, ignoring unknown JSON fields
+	// the way encoding/json does by default
+	AllowUnknownFields bool
+}
+
+// NewStrictBinder returns a binder suitable for echo.Echo.Binder
+func NewStrictBinder() *StrictBinder {
+	return &StrictBinder{}
+}
+
+// StrictJSONFromEnv reads STRICT_JSON, which defaults to true. Deployments
+// whose clients still send extra fields can set it to false while they're fixed.
+func StrictJSONFromEnv() bool {
+	strict, err := strconv.ParseBool(os.Getenv("STRICT_JSON"))
+	if err != nil {
+		return true
+	}
+	return strict
+}
+
+// DecodeJSON decodes data into v for inputs that implement UnmarshalJSON
+// themselves. The binder's decoder setting doesn't reach a custom UnmarshalJSON,
+// which is handed the raw object, so these follow STRICT_JSON directly: unknown
+// fields are rejected unless it is false.
+func DecodeJSON(data []byte, v any) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if StrictJSONFromEnv() {
+		decoder.DisallowUnknownFields()
+	}
+	return decoder.Decode(v)
+}
+
+var strictBinder = NewStrictBinder()
+
+// Bind binds a request into i. It uses the echo instance's binder when that is
+// a StrictBinder, so its strict mode setting applies, and a strict one
+// otherwise. Handlers use it for every request body.
+func Bind(c echo.Context, i interface{}) error {
+	if b, ok := c.Echo().Binder.(*StrictBinder); ok {
+		return b.Bind(i, c)
+	}
+	return strictBinder.Bind(i, c)
+}
+
+// Normalizer is implemented by inputs that tidy their own values once bound,
+// such as bringing timestamps to the precision they are stored at
+type Normalizer interface {
+	Normalize()
+}
+
+// Bind binds path params, query params (for GET, DELETE and HEAD) and the body,
+// in that order, then normalizes the result
+func (b *StrictBinder) Bind(i interface{}, c echo.Context) error {
+	if err := b.bind(i, c); err != nil {
+		return err
+	}
+
+	normalize(i)
+	return nil
+}
+
+func (b *StrictBinder) bind(i interface{}, c echo.Context) error {
+	if err := b.BindPathParams(c, i); err != nil {
+		return err
+	}
+
+	req := c.Request()
+	method := req.Method
+	if method == http.MethodGet || method == http.MethodDelete || method == http.MethodHead {
+		if err := b.BindQueryParams(c, i); err != nil {
+			return err
+		}
+	}
+
+	base, _, _ := strings.Cut(req.Header.Get(echo.HeaderContentType), ";")
+	if req.ContentLength == 0 || strings.TrimSpace(base) != echo.MIMEApplicationJSON {
+		return b.BindBody(c, i)
+	}
+
+	decoder := json.NewDecoder(req.Body)
+	if !b.AllowUnknownFields {
+		decoder.DisallowUnknownFields()
+	}
+
+	if err := decoder.Decode(i); err != nil {
+		// encoding/json doesn't export a type for this, only the message
+		if field, ok := strings.CutPrefix(err.Error(), "json: unknown field "); ok {
+			return &UnknownFieldError{Field: strings.Trim(field, `"`)}
+		}
+		return err
+	}
+
+	return nil
+}
+
+// normalize calls Normalize on i, or on each element when i points to a
+// slice, as bulk endpoints bind into one
+func normalize(i interface{}) {
+	if n, ok := i.(Normalizer); ok {
+		n.Normalize()
+		return
+	}
+
+	v := reflect.ValueOf(i)
+	if v.Kind() != reflect.Pointer || v.Elem().Kind() != reflect.Slice {
+		return
+	}
+
+	items := v.Elem()
+	for j := 0; j < items.Len(); j++ {
+		if n, ok := items.Index(j).Addr().Interface().(Normalizer); ok {
+			n.Normalize()
+		}
+	}
+}
@@ -0,0 +1,169 @@
+package binding_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+
+	"black-lotus/internal/common/binding"
+)
+
+type tripInput struct {
+	ID   string `param:"id"`
+	Name string `json:"name"`
+}
+
+// Normalize trims the name, standing in for inputs that tidy themselves once bound
+func (i *tripInput) Normalize() {
+	i.Name = strings.TrimSpace(i.Name)
+}
+
+func TestStrictBinder(t *testing.T) {
+	testCases := []struct {
+		name          string
+		contentType   string
+		body          string
+		expectedName  string
+		expectedField string
+		expectError   bool
+	}{
+		{name: "KnownFields", contentType: echo.MIMEApplicationJSON, body: `{"name": "Lisbon"}`, expectedName: "Lisbon"},
+		{name: "JSONWithCharset", contentType: echo.MIMEApplicationJSONCharsetUTF8, body: `{"name": "Porto"}`, expectedName: "Porto"},
+		{name: "UnknownField", contentType: echo.MIMEApplicationJSON, body: `{"nmae": "Lisbon"}`, expectedField: "nmae", expectError: true},
+		{name: "MalformedJSON", contentType: echo.MIMEApplicationJSON, body: `{"name": `, expectError: true},
+		{name: "EmptyBody", contentType: echo.MIMEApplicationJSON, body: ``},
+		{name: "FormBody", contentType: echo.MIMEApplicationForm, body: `name=Madrid&extra=1`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			e.Binder = binding.NewStrictBinder()
+
+			req := httptest.NewRequest(http.MethodPost, "/trips/abc", strings.NewReader(tc.body))
+			req.Header.Set(echo.HeaderContentType, tc.contentType)
+			c := e.NewContext(req, httptest.NewRecorder())
+			c.SetParamNames("id")
+			c.SetParamValues("abc")
+
+			var input tripInput
+			err := c.Bind(&input)
+
+			if tc.expectError {
+				if err == nil {
+					t.Fatal("Expected error, got nil")
+				}
+				var unknownField *binding.UnknownFieldError
+				if tc.expectedField != "" && (!errors.As(err, &unknownField) || unknownField.Field != tc.expectedField) {
+					t.Errorf("Expected unknown field %q, got %v", tc.expectedField, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if input.ID != "abc" {
+				t.Errorf("Expected path param to bind, got %q", input.ID)
+			}
+			if input.Name != tc.expectedName {
+				t.Errorf("Expected name %q, got %q", tc.expectedName, input.Name)
+			}
+		})
+	}
+}
+
+func TestBindNormalizes(t *testing.T) {
+	e := echo.New()
+
+	newContext := func(body string) echo.Context {
+		req := httptest.NewRequest(http.MethodPost, "/trips", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		return e.NewContext(req, httptest.NewRecorder())
+	}
+
+	var input tripInput
+	if err := binding.Bind(newContext(`{"name": "  Lisbon "}`), &input); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if input.Name != "Lisbon" {
+		t.Errorf("Expected normalized name %q, got %q", "Lisbon", input.Name)
+	}
+
+	// Bulk bodies bind into slices, whose elements are normalized one by one
+	var inputs []tripInput
+	if err := binding.Bind(newContext(`[{"name": " Porto"}, {"name": "Madrid "}]`), &inputs); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(inputs) != 2 || inputs[0].Name != "Porto" || inputs[1].Name != "Madrid" {
+		t.Errorf("Expected normalized names [Porto Madrid], got %+v", inputs)
+	}
+}
+
+func TestBindStrictMode(t *testing.T) {
+	testCases := []struct {
+		name          string
+		binder        echo.Binder
+		expectedField string
+	}{
+		{name: "Strict", binder: binding.NewStrictBinder(), expectedField: "nmae"},
+		{name: "Lenient", binder: &binding.StrictBinder{AllowUnknownFields: true}},
+		{name: "OtherBinderStaysStrict", binder: &echo.DefaultBinder{}, expectedField: "nmae"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			e.Binder = tc.binder
+
+			req := httptest.NewRequest(http.MethodPost, "/trips", strings.NewReader(`{"name": "Lisbon", "nmae": "Lisbon"}`))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			c := e.NewContext(req, httptest.NewRecorder())
+
+			var input tripInput
+			err := binding.Bind(c, &input)
+
+			if tc.expectedField != "" {
+				var unknownField *binding.UnknownFieldError
+				if !errors.As(err, &unknownField) || unknownField.Field != tc.expectedField {
+					t.Errorf("Expected unknown field %q, got %v", tc.expectedField, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Expected unknown fields to be ignored, got: %v", err)
+			}
+			if input.Name != "Lisbon" {
+				t.Errorf("Expected name %q, got %q", "Lisbon", input.Name)
+			}
+		})
+	}
+}
+
+func TestStrictJSONFromEnv(t *testing.T) {
+	testCases := []struct {
+		name     string
+		value    string
+		expected bool
+	}{
+		{name: "Unset", value: "", expected: true},
+		{name: "Disabled", value: "false", expected: false},
+		{name: "Enabled", value: "true", expected: true},
+		{name: "Invalid", value: "sometimes", expected: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("STRICT_JSON", tc.value)
+
+			if strict := binding.StrictJSONFromEnv(); strict != tc.expected {
+				t.Errorf("Expected %v, got %v", tc.expected, strict)
+			}
+		})
+	}
+}
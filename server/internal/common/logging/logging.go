@@ -0,0 +1,73 @@
+package logging
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+)
+
+// Format selects how log records are rendered
+type Format string
+
+const (
+	FormatJSON Format = "json"
+	FormatText Format = "text"
+)
+
+type contextKey struct{}
+
+var requestIDKey = contextKey{}
+
+// ParseLevel converts a LOG_LEVEL value (debug, info, warn, error) into a slog level.
+// Unknown or empty values fall back to info.
+func ParseLevel(value string) slog.Level {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "debug":
+		return slog.LevelDebug
+	case "warn", "warning":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// ParseFormat converts a LOG_FORMAT value (json, text) into a Format.
+// Unknown or empty values fall back to JSON.
+func ParseFormat(value string) Format {
+	if strings.ToLower(strings.TrimSpace(value)) == string(FormatText) {
+		return FormatText
+	}
+	return FormatJSON
+}
+
+// NewLogger creates a logger writing to w in the given format that drops records below level
+func NewLogger(w io.Writer, level slog.Level, format Format) *slog.Logger {
+	opts := &slog.HandlerOptions{Level: level}
+	if format == FormatText {
+		return slog.New(slog.NewTextHandler(w, opts))
+	}
+	return slog.New(slog.NewJSONHandler(w, opts))
+}
+
+// Setup configures the default slog logger from the LOG_LEVEL and LOG_FORMAT environment variables.
+// The standard library log package is routed through it as well.
+func Setup() *slog.Logger {
+	logger := NewLogger(os.Stdout, ParseLevel(os.Getenv("LOG_LEVEL")), ParseFormat(os.Getenv("LOG_FORMAT")))
+	slog.SetDefault(logger)
+	return logger
+}
+
+// WithRequestID returns a copy of ctx carrying the request ID
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey, requestID)
+}
+
+// RequestIDFromContext returns the request ID stored in ctx, or an empty string
+func RequestIDFromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey).(string)
+	return requestID
+}
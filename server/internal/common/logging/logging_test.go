@@ -0,0 +1,98 @@
+package logging_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"black-lotus/internal/common/logging"
+)
+
+func TestParseLevel(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected slog.Level
+	}{
+		{"debug", slog.LevelDebug},
+		{"DEBUG", slog.LevelDebug},
+		{"info", slog.LevelInfo},
+		{"warn", slog.LevelWarn},
+		{"warning", slog.LevelWarn},
+		{"error", slog.LevelError},
+		{"", slog.LevelInfo},
+		{"verbose", slog.LevelInfo},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			if level := logging.ParseLevel(tc.input); level != tc.expected {
+				t.Errorf("Expected level %v for %q, got %v", tc.expected, tc.input, level)
+			}
+		})
+	}
+}
+
+func TestNewLoggerSuppressesDebugAtInfo(t *testing.T) {
+	var buf bytes.Buffer
+	logger := logging.NewLogger(&buf, slog.LevelInfo, logging.FormatText)
+
+	logger.Debug("debug message")
+	logger.Info("info message")
+
+	output := buf.String()
+	if strings.Contains(output, "debug message") {
+		t.Errorf("Expected debug message to be suppressed, got: %s", output)
+	}
+	if !strings.Contains(output, "info message") {
+		t.Errorf("Expected info message to be logged, got: %s", output)
+	}
+}
+
+func TestNewLoggerEmitsDebugAtDebug(t *testing.T) {
+	var buf bytes.Buffer
+	logger := logging.NewLogger(&buf, slog.LevelDebug, logging.FormatText)
+
+	logger.Debug("debug message")
+
+	if !strings.Contains(buf.String(), "debug message") {
+		t.Errorf("Expected debug message to be logged, got: %s", buf.String())
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	if format := logging.ParseFormat("text"); format != logging.FormatText {
+		t.Errorf("Expected text format, got %s", format)
+	}
+	if format := logging.ParseFormat(""); format != logging.FormatJSON {
+		t.Errorf("Expected JSON format by default, got %s", format)
+	}
+}
+
+func TestNewLoggerJSONFormat(t *testing.T) {
+	var buf bytes.Buffer
+	logger := logging.NewLogger(&buf, slog.LevelInfo, logging.FormatJSON)
+
+	logger.Info("structured", "key", "value")
+
+	var record map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("Expected JSON output, got %q: %v", buf.String(), err)
+	}
+	if record["key"] != "value" {
+		t.Errorf("Expected key=value in record, got %v", record)
+	}
+}
+
+func TestRequestIDContext(t *testing.T) {
+	ctx := logging.WithRequestID(context.Background(), "req-123")
+
+	if requestID := logging.RequestIDFromContext(ctx); requestID != "req-123" {
+		t.Errorf("Expected request ID 'req-123', got '%s'", requestID)
+	}
+	if requestID := logging.RequestIDFromContext(context.Background()); requestID != "" {
+		t.Errorf("Expected empty request ID, got '%s'", requestID)
+	}
+}
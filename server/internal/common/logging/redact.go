@@ -0,0 +1,90 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"strings"
+)
+
+// RedactedValue replaces the value of every sensitive key
+const RedactedValue = "***"
+
+// DefaultRedactedKeys are the JSON keys hidden when LOG_REDACT_KEYS isn't set
+var DefaultRedactedKeys = []string{
+	"password",
+	"current_password",
+	"new_password",
+	"access_token",
+	"refresh_token",
+	"csrf_token",
+	"share_token",
+	"key", // The raw API key in models.CreatedAPIKey
+}
+
+// RedactedKeysFromEnv reads LOG_REDACT_KEYS, a comma-separated list of JSON
+// keys that replaces DefaultRedactedKeys. An empty value uses the defaults.
+func RedactedKeysFromEnv() []string {
+	var keys []string
+	for _, key := range strings.Split(os.Getenv("LOG_REDACT_KEYS"), ",") {
+		if key = strings.TrimSpace(key); key != "" {
+			keys = append(keys, key)
+		}
+	}
+
+	if len(keys) == 0 {
+		return DefaultRedactedKeys
+	}
+	return keys
+}
+
+// Redactor hides the values of sensitive keys in JSON bodies before they are logged
+type Redactor struct {
+	keys map[string]bool
+}
+
+// NewRedactor creates a Redactor for keys, which are matched case-insensitively
+// at any depth
+func NewRedactor(keys []string) Redactor {
+	r := Redactor{keys: make(map[string]bool, len(keys))}
+	for _, key := range keys {
+		r.keys[strings.ToLower(key)] = true
+	}
+	return r
+}
+
+// Redact returns body with the value of every sensitive key replaced by
+// RedactedValue. Bodies that aren't JSON are returned unchanged.
+func (r Redactor) Redact(body []byte) string {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber() // Keep numbers exactly as they were sent
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil || decoder.More() {
+		return string(body)
+	}
+
+	redacted, err := json.Marshal(r.redactValue(value))
+	if err != nil {
+		return string(body)
+	}
+	return string(redacted)
+}
+
+func (r Redactor) redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			if r.keys[strings.ToLower(key)] {
+				v[key] = RedactedValue
+			} else {
+				v[key] = r.redactValue(child)
+			}
+		}
+	case []interface{}:
+		for i, child := range v {
+			v[i] = r.redactValue(child)
+		}
+	}
+	return value
+}
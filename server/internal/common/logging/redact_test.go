@@ -0,0 +1,94 @@
+package logging_test
+
+import (
+	"reflect"
+	"testing"
+
+	"black-lotus/internal/common/logging"
+)
+
+func TestRedactorRedact(t *testing.T) {
+	redactor := logging.NewRedactor(logging.DefaultRedactedKeys)
+
+	testCases := []struct {
+		name     string
+		body     string
+		expected string
+	}{
+		{
+			name:     "TopLevelKeys",
+			body:     `{"email":"user@example.com","password":"Secret1!"}`,
+			expected: `{"email":"user@example.com","password":"***"}`,
+		},
+		{
+			name:     "NestedAndInArrays",
+			body:     `{"session":{"access_token":"a","refresh_token":"r"},"items":[{"csrf_token":"c","id":1}]}`,
+			expected: `{"items":[{"csrf_token":"***","id":1}],"session":{"access_token":"***","refresh_token":"***"}}`,
+		},
+		{
+			name:     "ShareLinks",
+			body:     `{"trip_id":"t1","share_token":"s","visibility":"unlisted"}`,
+			expected: `{"share_token":"***","trip_id":"t1","visibility":"unlisted"}`,
+		},
+		{
+			name:     "KeysMatchAnyCase",
+			body:     `{"Password":"Secret1!"}`,
+			expected: `{"Password":"***"}`,
+		},
+		{
+			name:     "NonStringValuesRedacted",
+			body:     `{"password":{"plain":"Secret1!"}}`,
+			expected: `{"password":"***"}`,
+		},
+		{
+			name:     "NumbersKeptExactly",
+			body:     `{"amount":12345678901234567890,"password":"x"}`,
+			expected: `{"amount":12345678901234567890,"password":"***"}`,
+		},
+		{
+			name:     "NotJSON",
+			body:     `plain text body`,
+			expected: `plain text body`,
+		},
+		{
+			name:     "TruncatedJSON",
+			body:     `{"password":"Sec`,
+			expected: `{"password":"Sec`,
+		},
+		{
+			name:     "TopLevelArray",
+			body:     `[{"password":"x"}]`,
+			expected: `[{"password":"***"}]`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if redacted := redactor.Redact([]byte(tc.body)); redacted != tc.expected {
+				t.Errorf("Expected %s, got %s", tc.expected, redacted)
+			}
+		})
+	}
+}
+
+func TestRedactedKeysFromEnv(t *testing.T) {
+	testCases := []struct {
+		name     string
+		value    string
+		expected []string
+	}{
+		{name: "Unset", value: "", expected: logging.DefaultRedactedKeys},
+		{name: "Configured", value: "password, api_key", expected: []string{"password", "api_key"}},
+		{name: "OnlySeparators", value: " , ", expected: logging.DefaultRedactedKeys},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("LOG_REDACT_KEYS", tc.value)
+
+			if keys := logging.RedactedKeysFromEnv(); !reflect.DeepEqual(keys, tc.expected) {
+				t.Errorf("Expected keys %v, got %v", tc.expected, keys)
+			}
+		})
+	}
+}
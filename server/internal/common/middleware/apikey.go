@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"black-lotus/internal/common/response"
+	"black-lotus/internal/domain/models"
+	"black-lotus/internal/features/auth/session"
+)
+
+// APIKeyValidator resolves a raw API key to the key it belongs to
+type APIKeyValidator interface {
+	ValidateAPIKey(ctx context.Context, key string) (*models.APIKey, error)
+}
+
+// BearerToken returns the token from an "Authorization: Bearer <token>" header
+func BearerToken(req *http.Request) (string, bool) {
+	scheme, token, found := strings.Cut(req.Header.Get(echo.HeaderAuthorization), " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
+
+// APIKeyAuth accepts an API key in an Authorization: Bearer header as an
+// alternative to the access token cookie. A valid key is stored under
+// session.APIKeyContextKey for the auth checks further on; an invalid one is
+// rejected outright rather than falling back to cookies. Requests without
+// the header pass through untouched.
+func APIKeyAuth(keys APIKeyValidator) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			token, ok := BearerToken(c.Request())
+			if !ok {
+				return next(c)
+			}
+
+			apiKey, err := keys.ValidateAPIKey(c.Request().Context(), token)
+			if err != nil {
+				if err.Error() != "invalid api key" {
+					return response.ErrorResponse(c, http.StatusInternalServerError,
+						response.CodeInternal, "Failed to check API key", nil)
+				}
+				return response.ErrorResponse(c, http.StatusUnauthorized,
+					response.CodeTokenInvalid, "Invalid or expired API key", nil)
+			}
+
+			c.Set(session.APIKeyContextKey, apiKey)
+			c.Set("user_id", apiKey.UserID)
+			return next(c)
+		}
+	}
+}
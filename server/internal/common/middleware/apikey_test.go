@@ -0,0 +1,167 @@
+package middleware_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"black-lotus/internal/common/middleware"
+	"black-lotus/internal/common/response"
+	"black-lotus/internal/domain/models"
+)
+
+// MockAPIKeyValidator implements middleware.APIKeyValidator
+type MockAPIKeyValidator struct {
+	validateAPIKeyFunc func(ctx context.Context, key string) (*models.APIKey, error)
+}
+
+func (m *MockAPIKeyValidator) ValidateAPIKey(ctx context.Context, key string) (*models.APIKey, error) {
+	if m.validateAPIKeyFunc != nil {
+		return m.validateAPIKeyFunc(ctx, key)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func TestBearerToken(t *testing.T) {
+	testCases := []struct {
+		name          string
+		header        string
+		expectedToken string
+		expectedOK    bool
+	}{
+		{name: "Bearer", header: "Bearer bl_key", expectedToken: "bl_key", expectedOK: true},
+		{name: "LowercaseScheme", header: "bearer bl_key", expectedToken: "bl_key", expectedOK: true},
+		{name: "NoHeader", header: "", expectedOK: false},
+		{name: "OtherScheme", header: "Basic dXNlcjpwYXNz", expectedOK: false},
+		{name: "EmptyToken", header: "Bearer ", expectedOK: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tc.header != "" {
+				req.Header.Set(echo.HeaderAuthorization, tc.header)
+			}
+
+			token, ok := middleware.BearerToken(req)
+
+			if ok != tc.expectedOK || token != tc.expectedToken {
+				t.Errorf("Expected (%q, %v), got (%q, %v)", tc.expectedToken, tc.expectedOK, token, ok)
+			}
+		})
+	}
+}
+
+func TestAPIKeyAuth(t *testing.T) {
+	userID := uuid.New()
+
+	testCases := []struct {
+		name           string
+		header         string
+		validateErr    error
+		expectedStatus int
+		expectedUser   bool
+	}{
+		{name: "ValidKey", header: "Bearer bl_valid", expectedStatus: http.StatusOK, expectedUser: true},
+		{name: "InvalidKey", header: "Bearer bl_revoked", validateErr: errors.New("invalid api key"), expectedStatus: http.StatusUnauthorized},
+		{name: "LookupFails", header: "Bearer bl_valid", validateErr: errors.New("database error"), expectedStatus: http.StatusInternalServerError},
+		{name: "NoHeader", expectedStatus: http.StatusOK},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			keys := &MockAPIKeyValidator{
+				validateAPIKeyFunc: func(ctx context.Context, key string) (*models.APIKey, error) {
+					if tc.validateErr != nil {
+						return nil, tc.validateErr
+					}
+					return &models.APIKey{ID: uuid.New(), UserID: userID}, nil
+				},
+			}
+
+			e := echo.New()
+			e.Use(middleware.APIKeyAuth(keys))
+			e.GET("/", func(c echo.Context) error {
+				id, _ := c.Get("user_id").(uuid.UUID)
+				return c.String(http.StatusOK, id.String())
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tc.header != "" {
+				req.Header.Set(echo.HeaderAuthorization, tc.header)
+			}
+			rec := httptest.NewRecorder()
+
+			// Execute
+			e.ServeHTTP(rec, req)
+
+			// Verify
+			if rec.Code != tc.expectedStatus {
+				t.Fatalf("Expected status %d, got %d", tc.expectedStatus, rec.Code)
+			}
+
+			if tc.expectedStatus == http.StatusUnauthorized {
+				var envelope response.ErrorEnvelope
+				if err := json.Unmarshal(rec.Body.Bytes(), &envelope); err != nil {
+					t.Fatalf("Failed to unmarshal response: %v", err)
+				}
+				if envelope.Error.Code != response.CodeTokenInvalid {
+					t.Errorf("Expected code '%s', got '%s'", response.CodeTokenInvalid, envelope.Error.Code)
+				}
+			}
+
+			if tc.expectedStatus == http.StatusOK {
+				if tc.expectedUser && rec.Body.String() != userID.String() {
+					t.Errorf("Expected user_id %s, got %q", userID, rec.Body.String())
+				}
+				if !tc.expectedUser && rec.Body.String() != uuid.Nil.String() {
+					t.Errorf("Expected no user_id, got %q", rec.Body.String())
+				}
+			}
+		})
+	}
+}
+
+func TestAuthenticateWithAPIKey(t *testing.T) {
+	// Setup: no cookie, only a key, so the session service must not be consulted
+	userID := uuid.New()
+	keys := &MockAPIKeyValidator{
+		validateAPIKeyFunc: func(ctx context.Context, key string) (*models.APIKey, error) {
+			return &models.APIKey{ID: uuid.New(), UserID: userID}, nil
+		},
+	}
+	userService := &MockUserService{
+		getUserByIDFunc: func(ctx context.Context, id uuid.UUID) (*models.User, error) {
+			return &models.User{ID: id}, nil
+		},
+	}
+	auth := middleware.NewAuthMiddleware(&MockSessionService{}, userService)
+
+	e := echo.New()
+	e.Use(middleware.APIKeyAuth(keys))
+	e.GET("/me", func(c echo.Context) error {
+		return c.String(http.StatusOK, c.Get("user").(*models.User).ID.String())
+	}, auth.Authenticate)
+
+	req := httptest.NewRequest(http.MethodGet, "/me", nil)
+	req.Header.Set(echo.HeaderAuthorization, "Bearer bl_valid")
+	rec := httptest.NewRecorder()
+
+	// Execute
+	e.ServeHTTP(rec, req)
+
+	// Verify
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, rec.Code)
+	}
+	if rec.Body.String() != userID.String() {
+		t.Errorf("Expected user %s, got %q", userID, rec.Body.String())
+	}
+}
@@ -3,8 +3,11 @@ package middleware
 import (
 	"net/http"
 
+	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 
+	"black-lotus/internal/common/response"
+	"black-lotus/internal/domain/models"
 	"black-lotus/internal/features/auth/session"
 	"black-lotus/internal/features/auth/user"
 )
@@ -26,53 +29,65 @@ func NewAuthMiddleware(
 	}
 }
 
-// Authenticate checks for a valid access token before allowing access to protected routes
+// Authenticate checks for a valid access token, or an API key already
+// accepted by APIKeyAuth, before allowing access to protected routes
 func (m *AuthMiddleware) Authenticate(next echo.HandlerFunc) echo.HandlerFunc {
 	return func(c echo.Context) error {
+		if apiKey, ok := c.Get(session.APIKeyContextKey).(*models.APIKey); ok {
+			return m.authenticateUser(c, next, apiKey.UserID)
+		}
+
 		// Extract access token cookie
 		accessCookie, err := c.Cookie("access_token")
 		if err != nil {
 			// No access token - check if there's a refresh token
 			_, refreshErr := c.Cookie("refresh_token")
 			if refreshErr != nil {
-				return c.JSON(http.StatusUnauthorized, map[string]string{
-					"error": "You must be logged in to access this resource",
-				})
+				return response.ErrorResponse(c, http.StatusUnauthorized,
+					response.CodeNotAuthenticated, "You must be logged in to access this resource", nil)
 			}
 			// Has refresh token but no access token
-			return c.JSON(http.StatusUnauthorized, map[string]string{
-				"error": "Access token expired",
-				"code":  "token_expired",
-			})
+			return response.ErrorResponse(c, http.StatusUnauthorized,
+				response.CodeTokenExpired, "Access token expired", nil)
 		}
 
 		// Validate access token
-		session, err := m.sessionService.ValidateAccessToken(c.Request().Context(), accessCookie.Value)
+		userSession, err := m.sessionService.ValidateAccessToken(c.Request().Context(), accessCookie.Value)
 		if err != nil {
 			// Clear invalid access token cookie
-			expiredCookie := new(http.Cookie)
-			expiredCookie.Name = "access_token"
-			expiredCookie.Value = ""
-			expiredCookie.MaxAge = -1
-			expiredCookie.Path = "/"
-			c.SetCookie(expiredCookie)
+			session.ClearAccessCookie(c)
 
-			return c.JSON(http.StatusUnauthorized, map[string]string{
-				"error": "Access token expired or invalid",
-				"code":  "token_invalid",
-			})
+			return response.ErrorResponse(c, http.StatusUnauthorized,
+				response.CodeTokenInvalid, "Access token expired or invalid", nil)
 		}
 
-		// Fetch user
-		user, err := m.userService.GetUserByID(c.Request().Context(), session.UserID)
-		if err != nil {
-			return c.JSON(http.StatusInternalServerError, map[string]string{
-				"error": "Failed to get user information",
-			})
-		}
+		return m.authenticateUser(c, next, userSession.UserID)
+	}
+}
 
-		// Add user to request context for handlers to access
-		c.Set("user", user)
-		return next(c)
+// authenticateUser loads the user and adds them to the context for handlers
+func (m *AuthMiddleware) authenticateUser(c echo.Context, next echo.HandlerFunc, userID uuid.UUID) error {
+	user, err := m.userService.GetUserByID(c.Request().Context(), userID)
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusInternalServerError,
+			response.CodeInternal, "Failed to get user information", nil)
+	}
+
+	c.Set("user", user)
+	return next(c)
+}
+
+// RequireRole only lets users with the given role through. It must run after
+// Authenticate, which loads the user it checks.
+func (m *AuthMiddleware) RequireRole(role string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			user, ok := c.Get("user").(*models.User)
+			if !ok || user.Role != role {
+				return response.ErrorResponse(c, http.StatusForbidden,
+					response.CodeForbidden, "You do not have permission to access this resource", nil)
+			}
+			return next(c)
+		}
 	}
 }
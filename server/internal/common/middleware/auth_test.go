@@ -0,0 +1,134 @@
+package middleware_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"black-lotus/internal/common/middleware"
+	"black-lotus/internal/domain/models"
+)
+
+// MockSessionService implements session.ServiceInterface; only access token
+// validation is used by the auth middleware
+type MockSessionService struct {
+	validateAccessTokenFunc func(ctx context.Context, token string) (*models.Session, error)
+}
+
+func (m *MockSessionService) CreateSession(ctx context.Context, userID uuid.UUID, refreshDuration time.Duration) (*models.Session, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockSessionService) ValidateAccessToken(ctx context.Context, token string) (*models.Session, error) {
+	if m.validateAccessTokenFunc != nil {
+		return m.validateAccessTokenFunc(ctx, token)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockSessionService) ValidateRefreshToken(ctx context.Context, token string) (*models.Session, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockSessionService) RefreshAccessToken(ctx context.Context, refreshToken string) (*models.Session, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockSessionService) EndSessionByAccessToken(ctx context.Context, token string) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockSessionService) EndSessionByRefreshToken(ctx context.Context, token string) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockSessionService) EndAllUserSessions(ctx context.Context, userID uuid.UUID) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockSessionService) EndOtherUserSessions(ctx context.Context, userID uuid.UUID, keepSessionID uuid.UUID) error {
+	return errors.New("not implemented")
+}
+
+// MockUserService implements user.ServiceInterface
+type MockUserService struct {
+	getUserByIDFunc func(ctx context.Context, userID uuid.UUID) (*models.User, error)
+}
+
+func (m *MockUserService) GetUserByID(ctx context.Context, userID uuid.UUID) (*models.User, error) {
+	if m.getUserByIDFunc != nil {
+		return m.getUserByIDFunc(ctx, userID)
+	}
+	return nil, errors.New("not implemented")
+}
+
+// newAdminServer serves /admin behind Authenticate and RequireRole, with
+// access tokens naming the role of the user they belong to
+func newAdminServer() *echo.Echo {
+	roles := make(map[uuid.UUID]string)
+
+	sessionService := &MockSessionService{
+		validateAccessTokenFunc: func(ctx context.Context, token string) (*models.Session, error) {
+			if token != models.UserRoleUser && token != models.UserRoleAdmin {
+				return nil, errors.New("invalid token")
+			}
+			userID := uuid.New()
+			roles[userID] = token
+			return &models.Session{ID: uuid.New(), UserID: userID, AccessToken: token}, nil
+		},
+	}
+	userService := &MockUserService{
+		getUserByIDFunc: func(ctx context.Context, userID uuid.UUID) (*models.User, error) {
+			return &models.User{ID: userID, Role: roles[userID]}, nil
+		},
+	}
+
+	auth := middleware.NewAuthMiddleware(sessionService, userService)
+
+	e := echo.New()
+	e.GET("/admin", func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	}, auth.Authenticate, auth.RequireRole(models.UserRoleAdmin))
+	e.GET("/unauthenticated", func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	}, auth.RequireRole(models.UserRoleAdmin))
+	return e
+}
+
+func TestRequireRole(t *testing.T) {
+	testCases := []struct {
+		name           string
+		path           string
+		accessToken    string
+		expectedStatus int
+	}{
+		{name: "Admin", path: "/admin", accessToken: models.UserRoleAdmin, expectedStatus: http.StatusOK},
+		{name: "RegularUser", path: "/admin", accessToken: models.UserRoleUser, expectedStatus: http.StatusForbidden},
+		{name: "NotLoggedIn", path: "/admin", expectedStatus: http.StatusUnauthorized},
+		{name: "InvalidToken", path: "/admin", accessToken: "forged", expectedStatus: http.StatusUnauthorized},
+		{name: "WithoutAuthenticate", path: "/unauthenticated", accessToken: models.UserRoleAdmin, expectedStatus: http.StatusForbidden},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := newAdminServer()
+			req := httptest.NewRequest(http.MethodGet, tc.path, nil)
+			if tc.accessToken != "" {
+				req.AddCookie(&http.Cookie{Name: "access_token", Value: tc.accessToken})
+			}
+			rec := httptest.NewRecorder()
+
+			e.ServeHTTP(rec, req)
+
+			if rec.Code != tc.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tc.expectedStatus, rec.Code)
+			}
+		})
+	}
+}
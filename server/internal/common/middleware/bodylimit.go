@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"os"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+	"github.com/labstack/gommon/bytes"
+)
+
+// DefaultBodyLimit caps request bodies when BODY_LIMIT isn't set
+const DefaultBodyLimit = "1M"
+
+// BodyLimitFromEnv reads BODY_LIMIT (e.g. "512K", "2M"), falling back to
+// DefaultBodyLimit when it is unset or not a valid size
+func BodyLimitFromEnv() string {
+	limit := os.Getenv("BODY_LIMIT")
+	if limit == "" {
+		return DefaultBodyLimit
+	}
+
+	if size, err := bytes.Parse(limit); err != nil || size <= 0 {
+		return DefaultBodyLimit
+	}
+
+	return limit
+}
+
+// BodyLimit rejects requests whose body is larger than limit with a 413
+// before handlers read it into memory
+func BodyLimit(limit string) echo.MiddlewareFunc {
+	return middleware.BodyLimit(limit)
+}
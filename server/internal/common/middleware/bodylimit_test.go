@@ -0,0 +1,67 @@
+package middleware_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+
+	"black-lotus/internal/common/middleware"
+	"black-lotus/internal/common/response"
+)
+
+func TestBodyLimitFromEnv(t *testing.T) {
+	testCases := []struct {
+		value    string
+		expected string
+	}{
+		{value: "", expected: middleware.DefaultBodyLimit},
+		{value: "2M", expected: "2M"},
+		{value: "512K", expected: "512K"},
+		{value: "lots", expected: middleware.DefaultBodyLimit},
+		{value: "0", expected: middleware.DefaultBodyLimit},
+	}
+
+	for _, tc := range testCases {
+		t.Setenv("BODY_LIMIT", tc.value)
+		if got := middleware.BodyLimitFromEnv(); got != tc.expected {
+			t.Errorf("BODY_LIMIT=%q: expected %q, got %q", tc.value, tc.expected, got)
+		}
+	}
+}
+
+func TestBodyLimit(t *testing.T) {
+	e := echo.New()
+	e.HTTPErrorHandler = response.HTTPErrorHandler
+	e.Use(middleware.BodyLimit("1K"))
+	e.POST("/upload", func(c echo.Context) error {
+		if _, err := io.ReadAll(c.Request().Body); err != nil {
+			return err
+		}
+		return c.NoContent(http.StatusOK)
+	})
+
+	testCases := []struct {
+		name     string
+		size     int
+		expected int
+	}{
+		{name: "UnderLimit", size: 512, expected: http.StatusOK},
+		{name: "OverLimit", size: 4096, expected: http.StatusRequestEntityTooLarge},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/upload", strings.NewReader(strings.Repeat("a", tc.size)))
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			if rec.Code != tc.expected {
+				t.Errorf("Expected status %d, got %d", tc.expected, rec.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package middleware_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+
+	"black-lotus/internal/common/middleware"
+	"black-lotus/internal/common/response"
+)
+
+func newCSRFServer() *echo.Echo {
+	e := echo.New()
+	e.Use(middleware.CSRF())
+	e.GET("/token", func(c echo.Context) error {
+		return c.String(http.StatusOK, c.Get(middleware.CSRFContextKey).(string))
+	})
+	e.POST("/mutate", func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+	return e
+}
+
+func TestCSRF(t *testing.T) {
+	e := newCSRFServer()
+
+	// Fetch a token the way the client does
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/token", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected GET to pass without a token, got %d", rec.Code)
+	}
+	token := rec.Body.String()
+	if token == "" {
+		t.Fatal("Expected a CSRF token in the context")
+	}
+
+	testCases := []struct {
+		name           string
+		cookieToken    string
+		headerToken    string
+		bearerToken    string
+		expectedStatus int
+	}{
+		{
+			name:           "MissingToken",
+			cookieToken:    token,
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "InvalidToken",
+			cookieToken:    token,
+			headerToken:    "not-the-token",
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "MissingCookie",
+			headerToken:    token,
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "ValidToken",
+			cookieToken:    token,
+			headerToken:    token,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			// API key clients aren't browsers, so they never hold a CSRF cookie
+			name:           "BearerTokenSkipsCheck",
+			bearerToken:    "bl_key",
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/mutate", nil)
+			if tc.cookieToken != "" {
+				req.AddCookie(&http.Cookie{Name: middleware.CSRFCookieName, Value: tc.cookieToken})
+			}
+			if tc.headerToken != "" {
+				req.Header.Set(middleware.CSRFHeader, tc.headerToken)
+			}
+			if tc.bearerToken != "" {
+				req.Header.Set(echo.HeaderAuthorization, "Bearer "+tc.bearerToken)
+			}
+			rec := httptest.NewRecorder()
+
+			e.ServeHTTP(rec, req)
+
+			if rec.Code != tc.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tc.expectedStatus, rec.Code)
+			}
+
+			if tc.expectedStatus == http.StatusForbidden {
+				var envelope response.ErrorEnvelope
+				if err := json.Unmarshal(rec.Body.Bytes(), &envelope); err != nil {
+					t.Fatalf("Failed to unmarshal response: %v", err)
+				}
+				if envelope.Error.Code != response.CodeCSRFInvalid {
+					t.Errorf("Expected code '%s', got '%s'", response.CodeCSRFInvalid, envelope.Error.Code)
+				}
+			}
+		})
+	}
+}
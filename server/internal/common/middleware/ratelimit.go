@@ -0,0 +1,96 @@
+package middleware
+
+import (
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"golang.org/x/time/rate"
+
+	"black-lotus/internal/common/response"
+)
+
+// rateLimiterVisitorTTL is how long an idle client's limiter is kept around
+const rateLimiterVisitorTTL = 3 * time.Minute
+
+type rateLimiterVisitor struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// RateLimiterStore keeps a token bucket per client. Unlike echo's memory store
+// it reports how long a rejected client has to wait, which is sent back as Retry-After.
+type RateLimiterStore struct {
+	mu          sync.Mutex
+	rate        rate.Limit
+	burst       int
+	visitors    map[string]*rateLimiterVisitor
+	lastCleanup time.Time
+}
+
+// NewRateLimiterStore allows each client requestsPerSecond requests with bursts of up to burst
+func NewRateLimiterStore(requestsPerSecond float64, burst int) *RateLimiterStore {
+	return &RateLimiterStore{
+		rate:        rate.Limit(requestsPerSecond),
+		burst:       burst,
+		visitors:    make(map[string]*rateLimiterVisitor),
+		lastCleanup: time.Now(),
+	}
+}
+
+// Allow reports whether the client may make a request now. When it may not,
+// the returned duration is how long until its next request will be allowed.
+func (s *RateLimiterStore) Allow(identifier string) (bool, time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+
+	visitor, exists := s.visitors[identifier]
+	if !exists {
+		visitor = &rateLimiterVisitor{limiter: rate.NewLimiter(s.rate, s.burst)}
+		s.visitors[identifier] = visitor
+	}
+	visitor.lastSeen = now
+
+	if now.Sub(s.lastCleanup) > rateLimiterVisitorTTL {
+		s.cleanupStaleVisitors(now)
+	}
+
+	reservation := visitor.limiter.ReserveN(now, 1)
+	if !reservation.OK() {
+		return false, time.Second
+	}
+
+	delay := reservation.DelayFrom(now)
+	if delay == 0 {
+		return true, 0
+	}
+
+	// The request is rejected rather than delayed, so hand the token back
+	reservation.CancelAt(now)
+	return false, delay
+}
+
+func (s *RateLimiterStore) cleanupStaleVisitors(now time.Time) {
+	for id, visitor := range s.visitors {
+		if now.Sub(visitor.lastSeen) > rateLimiterVisitorTTL {
+			delete(s.visitors, id)
+		}
+	}
+	s.lastCleanup = now
+}
+
+// RateLimit rejects clients (by IP) that exceed the store's rate with a 429
+// and a Retry-After header
+func RateLimit(store *RateLimiterStore) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			allowed, retryAfter := store.Allow(c.RealIP())
+			if !allowed {
+				return response.RateLimited(c, retryAfter)
+			}
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package middleware_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+
+	"black-lotus/internal/common/middleware"
+	"black-lotus/internal/common/response"
+)
+
+func TestRateLimit(t *testing.T) {
+	// One request every 10 seconds with no extra burst
+	e := echo.New()
+	e.Use(middleware.RateLimit(middleware.NewRateLimiterStore(0.1, 1)))
+	e.POST("/api/login", func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+
+	send := func(ip string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/login", nil)
+		req.RemoteAddr = ip + ":1234"
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	if rec := send("10.0.0.1"); rec.Code != http.StatusOK {
+		t.Fatalf("Expected first request to pass, got %d", rec.Code)
+	}
+
+	rec := send("10.0.0.1")
+	if rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("Expected status %d, got %d", http.StatusTooManyRequests, rec.Code)
+	}
+
+	retryAfter, err := strconv.Atoi(rec.Header().Get(echo.HeaderRetryAfter))
+	if err != nil {
+		t.Fatalf("Expected a numeric Retry-After header, got %q", rec.Header().Get(echo.HeaderRetryAfter))
+	}
+	if retryAfter < 9 || retryAfter > 10 {
+		t.Errorf("Expected Retry-After of about 10 seconds, got %d", retryAfter)
+	}
+
+	var envelope response.ErrorEnvelope
+	if err := json.Unmarshal(rec.Body.Bytes(), &envelope); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if envelope.Error.Code != response.CodeRateLimited {
+		t.Errorf("Expected code '%s', got '%s'", response.CodeRateLimited, envelope.Error.Code)
+	}
+
+	// A rejected request doesn't use up the client's next token
+	rec = send("10.0.0.1")
+	if got := rec.Header().Get(echo.HeaderRetryAfter); got != strconv.Itoa(retryAfter) {
+		t.Errorf("Expected Retry-After to stay at %d, got %s", retryAfter, got)
+	}
+
+	// Other clients have their own limit
+	if rec := send("10.0.0.2"); rec.Code != http.StatusOK {
+		t.Errorf("Expected a different client to pass, got %d", rec.Code)
+	}
+}
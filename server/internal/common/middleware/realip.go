@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"log/slog"
+	"net"
+	"os"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// IPExtractorFromEnv decides where ctx.RealIP() comes from. TRUSTED_PROXIES
+// lists the proxies in front of the server as comma-separated IPs or CIDRs
+// (e.g. "10.0.0.0/8, 203.0.113.7"); X-Forwarded-For is only believed when it
+// was added by one of them. Without it the connection's address is used, so
+// clients can't pick the IP that rate limits and login lockouts are keyed on.
+func IPExtractorFromEnv() echo.IPExtractor {
+	var options []echo.TrustOption
+	for _, value := range strings.Split(os.Getenv("TRUSTED_PROXIES"), ",") {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+
+		ipRange, err := parseIPRange(value)
+		if err != nil {
+			slog.Warn("Ignoring invalid TRUSTED_PROXIES entry", "value", value, "error", err)
+			continue
+		}
+		options = append(options, echo.TrustIPRange(ipRange))
+	}
+
+	if len(options) == 0 {
+		return echo.ExtractIPDirect()
+	}
+
+	// Only the listed proxies, not every private address
+	options = append(options, echo.TrustLoopback(false), echo.TrustLinkLocal(false), echo.TrustPrivateNet(false))
+	return echo.ExtractIPFromXFFHeader(options...)
+}
+
+// parseIPRange accepts a CIDR or a single IP
+func parseIPRange(value string) (*net.IPNet, error) {
+	if strings.Contains(value, "/") {
+		_, ipRange, err := net.ParseCIDR(value)
+		return ipRange, err
+	}
+
+	ip := net.ParseIP(value)
+	if ip == nil {
+		return nil, &net.ParseError{Type: "IP address", Text: value}
+	}
+	bits := 8 * net.IPv6len
+	if ip4 := ip.To4(); ip4 != nil {
+		ip, bits = ip4, 8*net.IPv4len
+	}
+	return &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}, nil
+}
@@ -0,0 +1,43 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"black-lotus/internal/common/middleware"
+)
+
+func TestIPExtractorFromEnv(t *testing.T) {
+	testCases := []struct {
+		name           string
+		trustedProxies string
+		remoteAddr     string
+		forwardedFor   string
+		expected       string
+	}{
+		{name: "NoProxiesIgnoresHeader", remoteAddr: "198.51.100.7:1234", forwardedFor: "203.0.113.9", expected: "198.51.100.7"},
+		{name: "PrivateNetworkNotTrustedByDefault", remoteAddr: "10.0.0.5:1234", forwardedFor: "203.0.113.9", expected: "10.0.0.5"},
+		{name: "TrustedProxyCIDR", trustedProxies: "10.0.0.0/8", remoteAddr: "10.0.0.5:1234", forwardedFor: "203.0.113.9", expected: "203.0.113.9"},
+		{name: "TrustedProxyIP", trustedProxies: " 192.0.2.1 , 10.0.0.0/8", remoteAddr: "192.0.2.1:1234", forwardedFor: "203.0.113.9", expected: "203.0.113.9"},
+		{name: "UntrustedPeer", trustedProxies: "10.0.0.0/8", remoteAddr: "198.51.100.7:1234", forwardedFor: "203.0.113.9", expected: "198.51.100.7"},
+		// A client can prepend anything, so only the address the proxy added counts
+		{name: "SpoofedEntryBeforeProxy", trustedProxies: "10.0.0.0/8", remoteAddr: "10.0.0.5:1234", forwardedFor: "1.2.3.4, 203.0.113.9", expected: "203.0.113.9"},
+		{name: "InvalidEntriesIgnored", trustedProxies: "not-an-ip, 10.0.0.0/33", remoteAddr: "10.0.0.5:1234", forwardedFor: "203.0.113.9", expected: "10.0.0.5"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("TRUSTED_PROXIES", tc.trustedProxies)
+			extract := middleware.IPExtractorFromEnv()
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.RemoteAddr = tc.remoteAddr
+			req.Header.Set("X-Forwarded-For", tc.forwardedFor)
+
+			if ip := extract(req); ip != tc.expected {
+				t.Errorf("Expected %q, got %q", tc.expected, ip)
+			}
+		})
+	}
+}
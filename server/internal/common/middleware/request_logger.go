@@ -0,0 +1,185 @@
+package middleware
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"black-lotus/internal/common/logging"
+	"black-lotus/internal/domain/models"
+)
+
+// RequestIDHeader is the header used to propagate and echo the request ID
+const RequestIDHeader = echo.HeaderXRequestID
+
+// maxLoggedBodyBytes caps how much of each body is captured for the log.
+// Larger bodies are left out, since a cut-off JSON body can't be redacted.
+const maxLoggedBodyBytes = 4 << 10
+
+// BodyLogging controls whether request and response bodies are logged
+type BodyLogging struct {
+	Enabled  bool
+	Redactor logging.Redactor
+}
+
+// BodyLoggingFromEnv reads LOG_BODIES (default false) and the redacted keys
+// from LOG_REDACT_KEYS. Body logging is meant for debugging only.
+func BodyLoggingFromEnv() BodyLogging {
+	enabled, _ := strconv.ParseBool(os.Getenv("LOG_BODIES"))
+	return BodyLogging{
+		Enabled:  enabled,
+		Redactor: logging.NewRedactor(logging.RedactedKeysFromEnv()),
+	}
+}
+
+// RequestLogger emits one structured log record per request and tags the
+// request with an ID that is echoed back in the X-Request-ID header.
+// An incoming X-Request-ID is reused so IDs can be correlated across services.
+// With body logging enabled the record also carries both bodies, with
+// sensitive JSON values redacted.
+func RequestLogger(logger *slog.Logger, bodies BodyLogging) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			start := time.Now()
+			req := c.Request()
+
+			requestID := req.Header.Get(RequestIDHeader)
+			if requestID == "" {
+				requestID = uuid.NewString()
+			}
+
+			// Attach the ID to the echo context, the request context and the response
+			c.Set("request_id", requestID)
+			c.SetRequest(req.WithContext(logging.WithRequestID(req.Context(), requestID)))
+			c.Response().Header().Set(RequestIDHeader, requestID)
+
+			var requestBody []byte
+			var requestBodyComplete bool
+			var responseBody *bodyRecorder
+			if bodies.Enabled {
+				requestBody, requestBodyComplete = peekRequestBody(c.Request())
+
+				responseBody = &bodyRecorder{ResponseWriter: c.Response().Writer}
+				c.Response().Writer = responseBody
+				defer func() { c.Response().Writer = responseBody.ResponseWriter }()
+			}
+
+			err := next(c)
+			if err != nil {
+				// Let echo render the error so the logged status is accurate
+				c.Error(err)
+			}
+
+			status := c.Response().Status
+			attrs := []any{
+				"request_id", requestID,
+				"method", req.Method,
+				"path", req.URL.Path,
+				"status", status,
+				"latency_ms", time.Since(start).Milliseconds(),
+			}
+			if userID, ok := authenticatedUserID(c); ok {
+				attrs = append(attrs, "user_id", userID.String())
+			}
+			if err != nil {
+				attrs = append(attrs, "error", err.Error())
+			}
+			if bodies.Enabled {
+				attrs = appendBody(attrs, "request_body", requestBody, requestBodyComplete, bodies.Redactor)
+				attrs = appendBody(attrs, "response_body", responseBody.body.Bytes(), !responseBody.overflowed, bodies.Redactor)
+			}
+
+			level := slog.LevelInfo
+			if status >= 500 {
+				level = slog.LevelError
+			} else if status >= 400 {
+				level = slog.LevelWarn
+			}
+			logger.Log(req.Context(), level, "request", attrs...)
+
+			return nil
+		}
+	}
+}
+
+// authenticatedUserID returns the user ID set by the auth middleware or a handler, if any
+func authenticatedUserID(c echo.Context) (uuid.UUID, bool) {
+	if user, ok := c.Get("user").(*models.User); ok && user != nil {
+		return user.ID, true
+	}
+	if userID, ok := c.Get("user_id").(uuid.UUID); ok {
+		return userID, true
+	}
+	return uuid.Nil, false
+}
+
+// peekRequestBody reads up to maxLoggedBodyBytes of the request body and puts
+// it back so handlers still see the whole body. complete is false when the
+// body is longer than that.
+func peekRequestBody(req *http.Request) (body []byte, complete bool) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, true
+	}
+
+	body, err := io.ReadAll(io.LimitReader(req.Body, maxLoggedBodyBytes+1))
+	req.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(body), req.Body), req.Body}
+	if err != nil {
+		return nil, false
+	}
+
+	return body, len(body) <= maxLoggedBodyBytes
+}
+
+// appendBody adds a redacted body to the log attributes, or a note when it
+// was too large to capture
+func appendBody(attrs []any, key string, body []byte, complete bool, redactor logging.Redactor) []any {
+	switch {
+	case !complete:
+		return append(attrs, key, fmt.Sprintf("[omitted, over %d bytes]", maxLoggedBodyBytes))
+	case len(body) == 0:
+		return attrs
+	default:
+		return append(attrs, key, redactor.Redact(body))
+	}
+}
+
+// bodyRecorder keeps a copy of the first maxLoggedBodyBytes of a response
+type bodyRecorder struct {
+	http.ResponseWriter
+	body       bytes.Buffer
+	overflowed bool
+}
+
+func (w *bodyRecorder) Write(b []byte) (int, error) {
+	if !w.overflowed {
+		if w.body.Len()+len(b) > maxLoggedBodyBytes {
+			w.overflowed = true
+			w.body.Reset()
+		} else {
+			w.body.Write(b)
+		}
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *bodyRecorder) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer
+func (w *bodyRecorder) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
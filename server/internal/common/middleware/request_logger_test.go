@@ -0,0 +1,280 @@
+package middleware_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"black-lotus/internal/common/logging"
+	"black-lotus/internal/common/middleware"
+	"black-lotus/internal/domain/models"
+)
+
+func TestRequestLogger(t *testing.T) {
+	testCases := []struct {
+		name           string
+		incomingID     string
+		handler        echo.HandlerFunc
+		expectedStatus int
+		expectedLevel  string
+		expectUserID   bool
+		expectIDEchoed string
+	}{
+		{
+			name: "GeneratesRequestID",
+			handler: func(c echo.Context) error {
+				return c.String(http.StatusOK, "ok")
+			},
+			expectedStatus: http.StatusOK,
+			expectedLevel:  "INFO",
+		},
+		{
+			name:       "ReusesIncomingRequestID",
+			incomingID: "incoming-id",
+			handler: func(c echo.Context) error {
+				return c.String(http.StatusOK, "ok")
+			},
+			expectedStatus: http.StatusOK,
+			expectedLevel:  "INFO",
+			expectIDEchoed: "incoming-id",
+		},
+		{
+			name: "LogsAuthenticatedUser",
+			handler: func(c echo.Context) error {
+				c.Set("user", &models.User{ID: uuid.New()})
+				return c.String(http.StatusOK, "ok")
+			},
+			expectedStatus: http.StatusOK,
+			expectedLevel:  "INFO",
+			expectUserID:   true,
+		},
+		{
+			name: "ClientErrorLoggedAsWarning",
+			handler: func(c echo.Context) error {
+				return c.JSON(http.StatusNotFound, map[string]string{"error": "Trip not found"})
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedLevel:  "WARN",
+		},
+		{
+			name: "ReturnedErrorIsRendered",
+			handler: func(c echo.Context) error {
+				return echo.NewHTTPError(http.StatusServiceUnavailable, "down")
+			},
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedLevel:  "ERROR",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			var buf bytes.Buffer
+			logger := logging.NewLogger(&buf, slog.LevelInfo, logging.FormatJSON)
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/trips", nil)
+			if tc.incomingID != "" {
+				req.Header.Set(middleware.RequestIDHeader, tc.incomingID)
+			}
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			var contextRequestID string
+			handler := func(c echo.Context) error {
+				contextRequestID = logging.RequestIDFromContext(c.Request().Context())
+				return tc.handler(c)
+			}
+
+			// Execute
+			if err := middleware.RequestLogger(logger, middleware.BodyLogging{})(handler)(c); err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			// Verify
+			if rec.Code != tc.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tc.expectedStatus, rec.Code)
+			}
+
+			requestID := rec.Header().Get(middleware.RequestIDHeader)
+			if requestID == "" {
+				t.Fatal("Expected X-Request-ID header to be set")
+			}
+			if tc.expectIDEchoed != "" && requestID != tc.expectIDEchoed {
+				t.Errorf("Expected request ID %s, got %s", tc.expectIDEchoed, requestID)
+			}
+			if contextRequestID != requestID {
+				t.Errorf("Expected request ID %s in request context, got %s", requestID, contextRequestID)
+			}
+
+			var record map[string]interface{}
+			if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+				t.Fatalf("Expected a JSON log record, got %q: %v", buf.String(), err)
+			}
+			if record["request_id"] != requestID {
+				t.Errorf("Expected logged request_id %s, got %v", requestID, record["request_id"])
+			}
+			if record["level"] != tc.expectedLevel {
+				t.Errorf("Expected level %s, got %v", tc.expectedLevel, record["level"])
+			}
+			if int(record["status"].(float64)) != tc.expectedStatus {
+				t.Errorf("Expected logged status %d, got %v", tc.expectedStatus, record["status"])
+			}
+			if record["method"] != http.MethodGet || record["path"] != "/api/trips" {
+				t.Errorf("Unexpected method/path in record: %v", record)
+			}
+			if _, ok := record["user_id"]; ok != tc.expectUserID {
+				t.Errorf("Expected user_id present=%v, got record %v", tc.expectUserID, record)
+			}
+		})
+	}
+}
+
+func TestRequestLoggerBodies(t *testing.T) {
+	testCases := []struct {
+		name             string
+		enabled          bool
+		requestBody      string
+		responseBody     string
+		expectedRequest  interface{}
+		expectedResponse interface{}
+	}{
+		{
+			name:             "Disabled",
+			requestBody:      `{"email":"user@example.com","password":"Secret1!"}`,
+			responseBody:     `{"csrf_token":"token"}`,
+			expectedRequest:  nil,
+			expectedResponse: nil,
+		},
+		{
+			name:             "SensitiveKeysRedacted",
+			enabled:          true,
+			requestBody:      `{"email":"user@example.com","password":"Secret1!"}`,
+			responseBody:     `{"csrf_token":"token","user":{"name":"Ann"}}`,
+			expectedRequest:  `{"email":"user@example.com","password":"***"}`,
+			expectedResponse: `{"csrf_token":"***","user":{"name":"Ann"}}`,
+		},
+		{
+			name:             "NonJSONLoggedAsIs",
+			enabled:          true,
+			requestBody:      `name=trip`,
+			responseBody:     `ok`,
+			expectedRequest:  `name=trip`,
+			expectedResponse: `ok`,
+		},
+		{
+			name:             "LargeBodiesOmitted",
+			enabled:          true,
+			requestBody:      `{"password":"` + strings.Repeat("x", 5000) + `"}`,
+			responseBody:     strings.Repeat("y", 5000),
+			expectedRequest:  "[omitted, over 4096 bytes]",
+			expectedResponse: "[omitted, over 4096 bytes]",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			var buf bytes.Buffer
+			logger := logging.NewLogger(&buf, slog.LevelInfo, logging.FormatJSON)
+			bodies := middleware.BodyLogging{
+				Enabled:  tc.enabled,
+				Redactor: logging.NewRedactor(logging.DefaultRedactedKeys),
+			}
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/api/login", strings.NewReader(tc.requestBody))
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			var handlerSaw string
+			handler := func(c echo.Context) error {
+				body, err := io.ReadAll(c.Request().Body)
+				if err != nil {
+					return err
+				}
+				handlerSaw = string(body)
+				return c.String(http.StatusOK, tc.responseBody)
+			}
+
+			// Execute
+			if err := middleware.RequestLogger(logger, bodies)(handler)(c); err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			// Verify
+			if handlerSaw != tc.requestBody {
+				t.Errorf("Expected handler to read the whole request body, got %d of %d bytes", len(handlerSaw), len(tc.requestBody))
+			}
+			if rec.Body.String() != tc.responseBody {
+				t.Errorf("Expected the whole response to be written, got %d of %d bytes", rec.Body.Len(), len(tc.responseBody))
+			}
+
+			var record map[string]interface{}
+			if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+				t.Fatalf("Expected a JSON log record, got %q: %v", buf.String(), err)
+			}
+			if record["request_body"] != tc.expectedRequest {
+				t.Errorf("Expected request_body %v, got %v", tc.expectedRequest, record["request_body"])
+			}
+			if record["response_body"] != tc.expectedResponse {
+				t.Errorf("Expected response_body %v, got %v", tc.expectedResponse, record["response_body"])
+			}
+		})
+	}
+}
+
+func TestRequestLoggerRedactsCreatedAPIKey(t *testing.T) {
+	// Setup
+	var buf bytes.Buffer
+	logger := logging.NewLogger(&buf, slog.LevelInfo, logging.FormatJSON)
+	bodies := middleware.BodyLogging{
+		Enabled:  true,
+		Redactor: logging.NewRedactor(logging.DefaultRedactedKeys),
+	}
+
+	const rawKey = "bl_live_0123456789abcdef"
+	created := models.CreatedAPIKey{
+		APIKey: models.APIKey{ID: uuid.New(), Name: "CI", Prefix: "bl_live_0123"},
+		Key:    rawKey,
+	}
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/auth/api-keys", strings.NewReader(`{"name":"CI"}`))
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	handler := func(c echo.Context) error {
+		return c.JSON(http.StatusCreated, created)
+	}
+
+	// Execute
+	if err := middleware.RequestLogger(logger, bodies)(handler)(c); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	// Verify: the client still gets the key, the log doesn't
+	if !strings.Contains(rec.Body.String(), rawKey) {
+		t.Errorf("Expected the response to contain the key, got %s", rec.Body.String())
+	}
+
+	var record map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("Expected a JSON log record, got %q: %v", buf.String(), err)
+	}
+	responseBody, _ := record["response_body"].(string)
+	if strings.Contains(responseBody, rawKey) {
+		t.Errorf("Expected the key to be redacted from the log, got %s", responseBody)
+	}
+	if !strings.Contains(responseBody, `"key":"***"`) {
+		t.Errorf("Expected a redacted key in the logged response, got %s", responseBody)
+	}
+}
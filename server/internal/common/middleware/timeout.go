@@ -0,0 +1,60 @@
+package middleware
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+)
+
+// DefaultRequestTimeout bounds a request's database work when REQUEST_TIMEOUT isn't set
+const DefaultRequestTimeout = 5 * time.Second
+
+// RequestTimeoutFromEnv reads REQUEST_TIMEOUT (e.g. "5s", "1500ms"), falling
+// back to DefaultRequestTimeout when it is unset or not a positive duration
+func RequestTimeoutFromEnv() time.Duration {
+	timeout, err := time.ParseDuration(os.Getenv("REQUEST_TIMEOUT"))
+	if err != nil || timeout <= 0 {
+		return DefaultRequestTimeout
+	}
+	return timeout
+}
+
+// RequestTimeoutConfig configures RequestTimeoutWithConfig
+type RequestTimeoutConfig struct {
+	// Timeout bounds the request's context
+	Timeout time.Duration
+	// Skipper leaves a request's context alone, for routes such as streamed
+	// downloads that outlive a single deadline and bound their own queries
+	Skipper middleware.Skipper
+}
+
+// RequestTimeout gives each request a context that is cancelled after timeout.
+// Handlers pass it down to the repositories, where pgx abandons the query once
+// it is done, and response.ErrorResponse turns the resulting failure into a 504.
+func RequestTimeout(timeout time.Duration) echo.MiddlewareFunc {
+	return RequestTimeoutWithConfig(RequestTimeoutConfig{Timeout: timeout})
+}
+
+// RequestTimeoutWithConfig is RequestTimeout with a Skipper
+func RequestTimeoutWithConfig(config RequestTimeoutConfig) echo.MiddlewareFunc {
+	if config.Skipper == nil {
+		config.Skipper = middleware.DefaultSkipper
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if config.Skipper(c) {
+				return next(c)
+			}
+
+			ctx, cancel := context.WithTimeout(c.Request().Context(), config.Timeout)
+			defer cancel()
+
+			c.SetRequest(c.Request().WithContext(ctx))
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package middleware_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"black-lotus/internal/common/middleware"
+	"black-lotus/internal/common/response"
+)
+
+// slowRepository stands in for a repository stuck on a slow query. Like pgx,
+// it gives up as soon as its context is done.
+type slowRepository struct {
+	delay time.Duration
+}
+
+func (r slowRepository) GetTrip(ctx context.Context) error {
+	select {
+	case <-time.After(r.delay):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func TestRequestTimeoutFromEnv(t *testing.T) {
+	testCases := []struct {
+		value    string
+		expected time.Duration
+	}{
+		{value: "", expected: middleware.DefaultRequestTimeout},
+		{value: "10s", expected: 10 * time.Second},
+		{value: "1500ms", expected: 1500 * time.Millisecond},
+		{value: "soon", expected: middleware.DefaultRequestTimeout},
+		{value: "-1s", expected: middleware.DefaultRequestTimeout},
+	}
+
+	for _, tc := range testCases {
+		t.Setenv("REQUEST_TIMEOUT", tc.value)
+		if got := middleware.RequestTimeoutFromEnv(); got != tc.expected {
+			t.Errorf("REQUEST_TIMEOUT=%q: expected %v, got %v", tc.value, tc.expected, got)
+		}
+	}
+}
+
+func TestRequestTimeout(t *testing.T) {
+	testCases := []struct {
+		name           string
+		delay          time.Duration
+		expectedStatus int
+		expectedCode   string
+	}{
+		{name: "FastQuery", delay: 0, expectedStatus: http.StatusOK},
+		{name: "SlowQuery", delay: 5 * time.Second, expectedStatus: http.StatusGatewayTimeout, expectedCode: response.CodeTimeout},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := slowRepository{delay: tc.delay}
+
+			e := echo.New()
+			e.Use(middleware.RequestTimeout(50 * time.Millisecond))
+			e.GET("/trips/1", func(c echo.Context) error {
+				if err := repo.GetTrip(c.Request().Context()); err != nil {
+					return response.ErrorResponse(c, http.StatusInternalServerError,
+						response.CodeInternal, "Failed to get trip", nil)
+				}
+				return c.JSON(http.StatusOK, map[string]string{"id": "1"})
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/trips/1", nil)
+			rec := httptest.NewRecorder()
+
+			started := time.Now()
+			e.ServeHTTP(rec, req)
+
+			if elapsed := time.Since(started); elapsed > time.Second {
+				t.Errorf("Expected the request to be cut short, took %v", elapsed)
+			}
+			if rec.Code != tc.expectedStatus {
+				t.Fatalf("Expected status %d, got %d", tc.expectedStatus, rec.Code)
+			}
+
+			if tc.expectedCode != "" {
+				var envelope response.ErrorEnvelope
+				if err := json.Unmarshal(rec.Body.Bytes(), &envelope); err != nil {
+					t.Fatalf("Failed to unmarshal response: %v", err)
+				}
+				if envelope.Error.Code != tc.expectedCode {
+					t.Errorf("Expected code %q, got %q", tc.expectedCode, envelope.Error.Code)
+				}
+			}
+		})
+	}
+}
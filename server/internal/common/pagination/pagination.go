@@ -0,0 +1,67 @@
+// Package pagination decides how many items list endpoints return per page
+package pagination
+
+import (
+	"errors"
+	"os"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	DefaultPageSize    = 10  // Items per page when a request doesn't give a limit
+	DefaultMaxPageSize = 100 // Most items a request may ask for
+)
+
+// Config holds the page sizes every list endpoint shares
+type Config struct {
+	DefaultSize int
+	MaxSize     int
+}
+
+// ConfigFromEnv reads DEFAULT_PAGE_SIZE and MAX_PAGE_SIZE, falling back to the
+// defaults when a value is unset or invalid. A default above the maximum is
+// lowered to it.
+func ConfigFromEnv() Config {
+	config := Config{DefaultSize: DefaultPageSize, MaxSize: DefaultMaxPageSize}
+
+	if size, err := strconv.Atoi(os.Getenv("DEFAULT_PAGE_SIZE")); err == nil && size > 0 {
+		config.DefaultSize = size
+	}
+	if size, err := strconv.Atoi(os.Getenv("MAX_PAGE_SIZE")); err == nil && size > 0 {
+		config.MaxSize = size
+	}
+	config.DefaultSize = min(config.DefaultSize, config.MaxSize)
+
+	return config
+}
+
+// Limit is the page size for a requested limit: the default when it is zero
+// or negative, as it is when the request leaves it out, and never above MaxSize
+func (c Config) Limit(requested int) int {
+	if requested <= 0 {
+		return c.DefaultSize
+	}
+	return min(requested, c.MaxSize)
+}
+
+// Parse reads the limit and offset query parameters. Both default to the
+// page defaults when absent: limit goes through Limit, offset starts at 0.
+// A limit or offset that isn't a whole number, or a negative offset, is an
+// error whose message can be shown to the client.
+func (c Config) Parse(ctx echo.Context) (limit, offset int, err error) {
+	if value := ctx.QueryParam("limit"); value != "" {
+		if limit, err = strconv.Atoi(value); err != nil {
+			return 0, 0, errors.New("limit must be a whole number")
+		}
+	}
+
+	if value := ctx.QueryParam("offset"); value != "" {
+		if offset, err = strconv.Atoi(value); err != nil || offset < 0 {
+			return 0, 0, errors.New("offset must be a whole number of 0 or more")
+		}
+	}
+
+	return c.Limit(limit), offset, nil
+}
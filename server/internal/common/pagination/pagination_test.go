@@ -0,0 +1,117 @@
+package pagination_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+
+	"black-lotus/internal/common/pagination"
+)
+
+func TestConfigFromEnv(t *testing.T) {
+	testCases := []struct {
+		name     string
+		env      map[string]string
+		expected pagination.Config
+	}{
+		{
+			name:     "Defaults",
+			expected: pagination.Config{DefaultSize: pagination.DefaultPageSize, MaxSize: pagination.DefaultMaxPageSize},
+		},
+		{
+			name:     "Configured",
+			env:      map[string]string{"DEFAULT_PAGE_SIZE": "25", "MAX_PAGE_SIZE": "50"},
+			expected: pagination.Config{DefaultSize: 25, MaxSize: 50},
+		},
+		{
+			name:     "InvalidValuesUseDefaults",
+			env:      map[string]string{"DEFAULT_PAGE_SIZE": "lots", "MAX_PAGE_SIZE": "-5"},
+			expected: pagination.Config{DefaultSize: pagination.DefaultPageSize, MaxSize: pagination.DefaultMaxPageSize},
+		},
+		{
+			name:     "DefaultAboveMax",
+			env:      map[string]string{"DEFAULT_PAGE_SIZE": "40", "MAX_PAGE_SIZE": "20"},
+			expected: pagination.Config{DefaultSize: 20, MaxSize: 20},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, key := range []string{"DEFAULT_PAGE_SIZE", "MAX_PAGE_SIZE"} {
+				t.Setenv(key, tc.env[key])
+			}
+
+			if config := pagination.ConfigFromEnv(); config != tc.expected {
+				t.Errorf("Expected %+v, got %+v", tc.expected, config)
+			}
+		})
+	}
+}
+
+func TestConfigLimit(t *testing.T) {
+	config := pagination.Config{DefaultSize: 10, MaxSize: 50}
+
+	testCases := []struct {
+		name      string
+		requested int
+		expected  int
+	}{
+		{name: "Absent", requested: 0, expected: 10},
+		{name: "Negative", requested: -3, expected: 10},
+		{name: "WithinMax", requested: 25, expected: 25},
+		{name: "AtMax", requested: 50, expected: 50},
+		{name: "AboveMax", requested: 500, expected: 50},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if limit := config.Limit(tc.requested); limit != tc.expected {
+				t.Errorf("Expected limit %d, got %d", tc.expected, limit)
+			}
+		})
+	}
+}
+
+func TestConfigParse(t *testing.T) {
+	config := pagination.Config{DefaultSize: 10, MaxSize: 50}
+
+	testCases := []struct {
+		name           string
+		query          string
+		expectedLimit  int
+		expectedOffset int
+		expectError    bool
+	}{
+		{name: "Absent", query: "", expectedLimit: 10, expectedOffset: 0},
+		{name: "Given", query: "?limit=25&offset=40", expectedLimit: 25, expectedOffset: 40},
+		{name: "LimitAboveMax", query: "?limit=500", expectedLimit: 50},
+		{name: "NegativeLimitUsesDefault", query: "?limit=-3", expectedLimit: 10},
+		{name: "NonNumericLimit", query: "?limit=ten", expectError: true},
+		{name: "NonNumericOffset", query: "?offset=abc", expectError: true},
+		{name: "NegativeOffset", query: "?offset=-5", expectError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/trips"+tc.query, nil)
+			c := echo.New().NewContext(req, httptest.NewRecorder())
+
+			limit, offset, err := config.Parse(c)
+
+			if tc.expectError {
+				if err == nil {
+					t.Fatalf("Expected error, got limit %d and offset %d", limit, offset)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if limit != tc.expectedLimit || offset != tc.expectedOffset {
+				t.Errorf("Expected limit %d and offset %d, got %d and %d", tc.expectedLimit, tc.expectedOffset, limit, offset)
+			}
+		})
+	}
+}
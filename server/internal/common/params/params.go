@@ -0,0 +1,22 @@
+// Package params reads the path parameters that several features share
+package params
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"black-lotus/internal/common/response"
+)
+
+// TripID reads the :id path parameter of a trip route. When it isn't a valid
+// UUID the error response has already been written and ok is false.
+func TripID(ctx echo.Context) (uuid.UUID, bool, error) {
+	tripID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return uuid.Nil, false, response.ErrorResponse(ctx, http.StatusBadRequest,
+			response.CodeInvalidID, "Invalid trip ID", nil)
+	}
+	return tripID, true, nil
+}
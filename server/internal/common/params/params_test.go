@@ -0,0 +1,68 @@
+package params_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"black-lotus/internal/common/params"
+	"black-lotus/internal/common/response"
+)
+
+func TestTripID(t *testing.T) {
+	validID := uuid.New()
+
+	testCases := []struct {
+		name       string
+		param      string
+		expectedOK bool
+	}{
+		{name: "Valid", param: validID.String(), expectedOK: true},
+		{name: "NotAUUID", param: "not-a-uuid", expectedOK: false},
+		{name: "Empty", param: "", expectedOK: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			c := e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), rec)
+			c.SetParamNames("id")
+			c.SetParamValues(tc.param)
+
+			// Execute
+			tripID, ok, err := params.TripID(c)
+
+			// Verify
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if ok != tc.expectedOK {
+				t.Fatalf("Expected ok %v, got %v", tc.expectedOK, ok)
+			}
+
+			if tc.expectedOK {
+				if tripID != validID {
+					t.Errorf("Expected trip ID %s, got %s", validID, tripID)
+				}
+				return
+			}
+
+			if rec.Code != http.StatusBadRequest {
+				t.Errorf("Expected status %d, got %d", http.StatusBadRequest, rec.Code)
+			}
+			var envelope response.ErrorEnvelope
+			if err := json.Unmarshal(rec.Body.Bytes(), &envelope); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+			if envelope.Error.Code != response.CodeInvalidID {
+				t.Errorf("Expected code %q, got %q", response.CodeInvalidID, envelope.Error.Code)
+			}
+		})
+	}
+}
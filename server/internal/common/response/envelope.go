@@ -0,0 +1,59 @@
+package response
+
+import (
+	"mime"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// EnvelopeProfile is the Accept profile that opts a request into enveloped
+// responses, e.g. Accept: application/json; profile="envelope"
+const EnvelopeProfile = "envelope"
+
+// Envelope wraps successful responses as {"data": ..., "meta": {...}} for
+// clients that opt in. List responses report their length in meta.count.
+type Envelope struct {
+	Data interface{}            `json:"data"`
+	Meta map[string]interface{} `json:"meta"`
+}
+
+// JSON writes data with the given status. The body is the bare value unless the
+// client asked for the envelope profile or RESPONSE_ENVELOPE is enabled.
+func JSON(c echo.Context, status int, data interface{}) error {
+	c.Response().Header().Add(echo.HeaderVary, echo.HeaderAccept)
+
+	if !wantsEnvelope(c) {
+		return c.JSON(status, data)
+	}
+
+	meta := map[string]interface{}{}
+	if value := reflect.ValueOf(data); value.Kind() == reflect.Slice {
+		meta["count"] = value.Len()
+	}
+
+	return c.JSON(status, Envelope{Data: data, Meta: meta})
+}
+
+// wantsEnvelope reports whether the server is configured to always envelope,
+// or the request's Accept header carries the envelope profile
+func wantsEnvelope(c echo.Context) bool {
+	if enabled, err := strconv.ParseBool(os.Getenv("RESPONSE_ENVELOPE")); err == nil && enabled {
+		return true
+	}
+
+	for _, accepted := range strings.Split(c.Request().Header.Get(echo.HeaderAccept), ",") {
+		_, params, err := mime.ParseMediaType(strings.TrimSpace(accepted))
+		if err != nil {
+			continue
+		}
+		if params["profile"] == EnvelopeProfile {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,72 @@
+package response_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+
+	"black-lotus/internal/common/response"
+)
+
+func TestJSON(t *testing.T) {
+	testCases := []struct {
+		name          string
+		accept        string
+		envEnabled    string
+		data          interface{}
+		expectWrapped bool
+		expectedCount float64
+	}{
+		{name: "BareByDefault", data: map[string]string{"name": "trip"}},
+		{name: "OtherProfileIsBare", accept: `application/json; profile="compact"`, data: map[string]string{"name": "trip"}},
+		{name: "AcceptProfileSingle", accept: `application/json; profile="envelope"`, data: map[string]string{"name": "trip"}, expectWrapped: true},
+		{name: "AcceptProfileInList", accept: `text/html, application/json; profile=envelope`, data: []string{"a", "b"}, expectWrapped: true, expectedCount: 2},
+		{name: "EnabledByEnv", envEnabled: "true", data: []string{"a"}, expectWrapped: true, expectedCount: 1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("RESPONSE_ENVELOPE", tc.envEnabled)
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tc.accept != "" {
+				req.Header.Set(echo.HeaderAccept, tc.accept)
+			}
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			if err := response.JSON(c, http.StatusOK, tc.data); err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			if rec.Header().Get(echo.HeaderVary) != echo.HeaderAccept {
+				t.Errorf("Expected Vary: Accept, got %q", rec.Header().Get(echo.HeaderVary))
+			}
+
+			var body map[string]interface{}
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				if !tc.expectWrapped {
+					// Bare lists don't decode into a map
+					return
+				}
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+
+			_, wrapped := body["data"]
+			if wrapped != tc.expectWrapped {
+				t.Fatalf("Expected wrapped=%v, got body %s", tc.expectWrapped, rec.Body.String())
+			}
+
+			if tc.expectedCount > 0 {
+				meta := body["meta"].(map[string]interface{})
+				if meta["count"] != tc.expectedCount {
+					t.Errorf("Expected meta.count %v, got %v", tc.expectedCount, meta["count"])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,176 @@
+package response
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"black-lotus/internal/common/binding"
+)
+
+// Stable, machine-readable error codes clients can branch on
+const (
+	// Authentication
+	CodeNotAuthenticated    = "not_authenticated"
+	CodeTokenExpired        = "token_expired"
+	CodeTokenInvalid        = "token_invalid"
+	CodeRefreshTokenMissing = "refresh_token_missing"
+	CodeRefreshTokenInvalid = "refresh_token_invalid"
+	CodeInvalidCredentials  = "invalid_credentials"
+	CodeCSRFInvalid         = "csrf_invalid"
+
+	// Request validation
+	CodeInvalidRequest     = "invalid_request"
+	CodeValidationFailed   = "validation_failed"
+	CodeInvalidID          = "invalid_id"
+	CodeInvalidDateRange   = "invalid_date_range"
+	CodeUnsupportedVersion = "unsupported_version"
+
+	// Resources
+	CodeNotFound             = "not_found"
+	CodeUserNotFound         = "user_not_found"
+	CodeTripNotFound         = "trip_not_found"
+	CodeActivityNotFound     = "activity_not_found"
+	CodeExpenseNotFound      = "expense_not_found"
+	CodeTagNotFound          = "tag_not_found"
+	CodeAPIKeyNotFound       = "api_key_not_found"
+	CodePhotoNotFound        = "photo_not_found"
+	CodePhotoLimitReached    = "photo_limit_reached"
+	CodeReminderNotFound     = "reminder_not_found"
+	CodeReminderAcknowledged = "reminder_acknowledged"
+	CodeLocationNotFound     = "location_not_found"
+	CodeForbidden            = "forbidden"
+	CodeEmailTaken           = "email_taken"
+	CodeRestoreExpired       = "restore_window_expired"
+	CodeTripAlreadyPlanned   = "trip_already_planned"
+	CodeTripNotPlanned       = "trip_not_planned"
+
+	// OAuth
+	CodeMissingOAuthCode = "missing_oauth_code"
+	CodeOAuthFailed      = "oauth_failed"
+
+	// Generic
+	CodeRateLimited     = "rate_limited"
+	CodePayloadTooLarge = "payload_too_large"
+	CodeTimeout         = "request_timeout"
+	CodeNotConfigured   = "not_configured"
+	CodeInternal        = "internal_error"
+)
+
+// ErrorBody is the machine-readable content of every error response
+type ErrorBody struct {
+	Code    string      `json:"code"`
+	Message string      `json:"message"`
+	Details interface{} `json:"details,omitempty"`
+}
+
+// ErrorEnvelope renders errors as {"error": {"code": ..., "message": ..., "details": ...}}
+type ErrorEnvelope struct {
+	Error ErrorBody `json:"error"`
+}
+
+// ErrorResponse writes an error envelope with the given status. details may be nil.
+// A server error raised after the request's deadline passed is almost always
+// the timeout itself, so it is reported as a 504 rather than a 500.
+func ErrorResponse(c echo.Context, status int, code, message string, details interface{}) error {
+	if status >= http.StatusInternalServerError && errors.Is(c.Request().Context().Err(), context.DeadlineExceeded) {
+		status, code, message, details = http.StatusGatewayTimeout, CodeTimeout, "The request took too long to complete", nil
+	}
+
+	return c.JSON(status, ErrorEnvelope{
+		Error: ErrorBody{
+			Code:    code,
+			Message: message,
+			Details: details,
+		},
+	})
+}
+
+// RateLimited writes the 429 envelope along with a Retry-After header telling
+// the client how many seconds to wait before the limiter will let it through.
+func RateLimited(c echo.Context, retryAfter time.Duration) error {
+	seconds := int(math.Ceil(retryAfter.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+
+	c.Response().Header().Set(echo.HeaderRetryAfter, strconv.Itoa(seconds))
+	return ErrorResponse(c, http.StatusTooManyRequests,
+		CodeRateLimited, "Too many requests, please try again later", nil)
+}
+
+// InvalidBody reports a request body that couldn't be bound. Oversized bodies
+// get a 413, unknown JSON fields are listed in details, and anything else is a
+// generic 400.
+func InvalidBody(c echo.Context, err error) error {
+	var httpErr *echo.HTTPError
+	if errors.As(err, &httpErr) && httpErr.Code == http.StatusRequestEntityTooLarge {
+		return ErrorResponse(c, http.StatusRequestEntityTooLarge,
+			CodePayloadTooLarge, "Request body too large", nil)
+	}
+
+	var unknownField *binding.UnknownFieldError
+	if errors.As(err, &unknownField) {
+		return ErrorResponse(c, http.StatusBadRequest, CodeInvalidRequest, "Unknown field: "+unknownField.Field,
+			map[string]string{unknownField.Field: "Unknown field"})
+	}
+
+	return ErrorResponse(c, http.StatusBadRequest,
+		CodeInvalidRequest, "Invalid request body", nil)
+}
+
+// HTTPErrorHandler renders errors returned by handlers and echo middleware
+// (routing, CSRF, body limits) using the same envelope.
+func HTTPErrorHandler(err error, c echo.Context) {
+	if c.Response().Committed {
+		return
+	}
+
+	status := http.StatusInternalServerError
+	message := http.StatusText(status)
+
+	var httpErr *echo.HTTPError
+	if errors.As(err, &httpErr) {
+		status = httpErr.Code
+		message = fmt.Sprint(httpErr.Message)
+	}
+
+	if c.Request().Method == http.MethodHead {
+		_ = c.NoContent(status)
+		return
+	}
+
+	_ = ErrorResponse(c, status, codeForStatus(status), message, nil)
+}
+
+// codeForStatus maps HTTP statuses to a generic error code for errors that
+// don't originate from a handler
+func codeForStatus(status int) string {
+	switch status {
+	case http.StatusBadRequest:
+		return CodeInvalidRequest
+	case http.StatusUnauthorized:
+		return CodeNotAuthenticated
+	case http.StatusForbidden:
+		return CodeForbidden
+	case http.StatusNotFound:
+		return CodeNotFound
+	case http.StatusRequestEntityTooLarge:
+		return CodePayloadTooLarge
+	case http.StatusTooManyRequests:
+		return CodeRateLimited
+	case http.StatusGatewayTimeout:
+		return CodeTimeout
+	default:
+		if status >= 500 {
+			return CodeInternal
+		}
+		return CodeInvalidRequest
+	}
+}
@@ -0,0 +1,196 @@
+package response_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"black-lotus/internal/common/binding"
+	"black-lotus/internal/common/response"
+)
+
+func decodeEnvelope(t *testing.T, rec *httptest.ResponseRecorder) response.ErrorEnvelope {
+	t.Helper()
+	var envelope response.ErrorEnvelope
+	if err := json.Unmarshal(rec.Body.Bytes(), &envelope); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	return envelope
+}
+
+func TestErrorResponse(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	details := map[string]string{"name": "name is required"}
+	if err := response.ErrorResponse(c, http.StatusBadRequest, response.CodeValidationFailed, "Validation failed", details); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d, got %d", http.StatusBadRequest, rec.Code)
+	}
+
+	envelope := decodeEnvelope(t, rec)
+	if envelope.Error.Code != response.CodeValidationFailed {
+		t.Errorf("Expected code '%s', got '%s'", response.CodeValidationFailed, envelope.Error.Code)
+	}
+	if envelope.Error.Message != "Validation failed" {
+		t.Errorf("Expected message 'Validation failed', got '%s'", envelope.Error.Message)
+	}
+	if detailsMap, ok := envelope.Error.Details.(map[string]interface{}); !ok || detailsMap["name"] != "name is required" {
+		t.Errorf("Expected details to be preserved, got %v", envelope.Error.Details)
+	}
+}
+
+func TestErrorResponseOmitsEmptyDetails(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	response.ErrorResponse(c, http.StatusNotFound, response.CodeTripNotFound, "Trip not found", nil)
+
+	var raw map[string]map[string]interface{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &raw); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if _, exists := raw["error"]["details"]; exists {
+		t.Errorf("Expected details to be omitted, got %v", raw["error"])
+	}
+}
+
+func TestHTTPErrorHandler(t *testing.T) {
+	testCases := []struct {
+		name            string
+		err             error
+		expectedStatus  int
+		expectedCode    string
+		expectedMessage string
+	}{
+		{
+			name:            "NotFound",
+			err:             echo.ErrNotFound,
+			expectedStatus:  http.StatusNotFound,
+			expectedCode:    response.CodeNotFound,
+			expectedMessage: "Not Found",
+		},
+		{
+			name:            "TooManyRequests",
+			err:             echo.NewHTTPError(http.StatusTooManyRequests, "rate limit exceeded"),
+			expectedStatus:  http.StatusTooManyRequests,
+			expectedCode:    response.CodeRateLimited,
+			expectedMessage: "rate limit exceeded",
+		},
+		{
+			name:            "PlainError",
+			err:             errors.New("boom"),
+			expectedStatus:  http.StatusInternalServerError,
+			expectedCode:    response.CodeInternal,
+			expectedMessage: "Internal Server Error",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			response.HTTPErrorHandler(tc.err, c)
+
+			if rec.Code != tc.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tc.expectedStatus, rec.Code)
+			}
+
+			envelope := decodeEnvelope(t, rec)
+			if envelope.Error.Code != tc.expectedCode {
+				t.Errorf("Expected code '%s', got '%s'", tc.expectedCode, envelope.Error.Code)
+			}
+			if envelope.Error.Message != tc.expectedMessage {
+				t.Errorf("Expected message '%s', got '%s'", tc.expectedMessage, envelope.Error.Message)
+			}
+		})
+	}
+}
+
+func TestRateLimited(t *testing.T) {
+	testCases := []struct {
+		name       string
+		retryAfter time.Duration
+		expected   string
+	}{
+		{name: "RoundsUp", retryAfter: 2300 * time.Millisecond, expected: "3"},
+		{name: "WholeSeconds", retryAfter: 10 * time.Second, expected: "10"},
+		{name: "AtLeastOneSecond", retryAfter: 0, expected: "1"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			c := e.NewContext(httptest.NewRequest(http.MethodPost, "/", nil), rec)
+
+			if err := response.RateLimited(c, tc.retryAfter); err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			if rec.Code != http.StatusTooManyRequests {
+				t.Errorf("Expected status %d, got %d", http.StatusTooManyRequests, rec.Code)
+			}
+			if got := rec.Header().Get(echo.HeaderRetryAfter); got != tc.expected {
+				t.Errorf("Expected Retry-After '%s', got '%s'", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestInvalidBody(t *testing.T) {
+	testCases := []struct {
+		name           string
+		err            error
+		expectedStatus int
+		expectedCode   string
+		expectedField  string
+	}{
+		{name: "Malformed", err: errors.New("unexpected EOF"), expectedStatus: http.StatusBadRequest, expectedCode: response.CodeInvalidRequest},
+		{name: "UnknownField", err: &binding.UnknownFieldError{Field: "nmae"}, expectedStatus: http.StatusBadRequest, expectedCode: response.CodeInvalidRequest, expectedField: "nmae"},
+		{name: "TooLarge", err: echo.ErrStatusRequestEntityTooLarge, expectedStatus: http.StatusRequestEntityTooLarge, expectedCode: response.CodePayloadTooLarge},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			c := e.NewContext(httptest.NewRequest(http.MethodPost, "/", nil), rec)
+
+			if err := response.InvalidBody(c, tc.err); err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			if rec.Code != tc.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tc.expectedStatus, rec.Code)
+			}
+
+			envelope := decodeEnvelope(t, rec)
+			if envelope.Error.Code != tc.expectedCode {
+				t.Errorf("Expected code '%s', got '%s'", tc.expectedCode, envelope.Error.Code)
+			}
+
+			if tc.expectedField != "" {
+				details, ok := envelope.Error.Details.(map[string]interface{})
+				if !ok || details[tc.expectedField] == nil {
+					t.Errorf("Expected details for field '%s', got %v", tc.expectedField, envelope.Error.Details)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package response
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ETag builds a weak entity tag from strings that together identify a version
+// of a resource, such as IDs and update timestamps. It never needs the body.
+func ETag(versions ...string) string {
+	hash := sha256.New()
+	for _, version := range versions {
+		hash.Write([]byte(version))
+		hash.Write([]byte{0})
+	}
+	return `W/"` + hex.EncodeToString(hash.Sum(nil))[:32] + `"`
+}
+
+// NotModified sets the ETag header and reports whether the request's
+// If-None-Match already matches it. When it does, a 304 without a body has
+// been written and the handler should return without serializing anything.
+func NotModified(c echo.Context, etag string) (bool, error) {
+	c.Response().Header().Set("ETag", etag)
+
+	if !etagMatches(c.Request().Header.Get("If-None-Match"), etag) {
+		return false, nil
+	}
+
+	return true, c.NoContent(http.StatusNotModified)
+}
+
+// etagMatches implements the weak comparison If-None-Match uses
+func etagMatches(header, etag string) bool {
+	if header == "" {
+		return false
+	}
+
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,66 @@
+package response_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+
+	"black-lotus/internal/common/response"
+)
+
+func TestETag(t *testing.T) {
+	if response.ETag("a", "1") != response.ETag("a", "1") {
+		t.Error("Expected the same versions to produce the same ETag")
+	}
+	if response.ETag("a", "1") == response.ETag("a", "2") {
+		t.Error("Expected different versions to produce different ETags")
+	}
+	// Parts are delimited, so shifting characters between them changes the tag
+	if response.ETag("ab", "c") == response.ETag("a", "bc") {
+		t.Error("Expected version boundaries to affect the ETag")
+	}
+}
+
+func TestNotModified(t *testing.T) {
+	etag := response.ETag("trip", "v1")
+
+	testCases := []struct {
+		name        string
+		ifNoneMatch string
+		expected    bool
+	}{
+		{name: "NoHeader", expected: false},
+		{name: "Matching", ifNoneMatch: etag, expected: true},
+		{name: "MatchingInList", ifNoneMatch: `W/"other", ` + etag, expected: true},
+		{name: "Wildcard", ifNoneMatch: "*", expected: true},
+		{name: "Stale", ifNoneMatch: response.ETag("trip", "v0"), expected: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tc.ifNoneMatch != "" {
+				req.Header.Set("If-None-Match", tc.ifNoneMatch)
+			}
+			rec := httptest.NewRecorder()
+			c := echo.New().NewContext(req, rec)
+
+			notModified, err := response.NotModified(c, etag)
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			if notModified != tc.expected {
+				t.Errorf("Expected not modified %v, got %v", tc.expected, notModified)
+			}
+			if rec.Header().Get("ETag") != etag {
+				t.Errorf("Expected ETag header %s, got %s", etag, rec.Header().Get("ETag"))
+			}
+			if tc.expected && (rec.Code != http.StatusNotModified || rec.Body.Len() != 0) {
+				t.Errorf("Expected an empty 304, got %d with %d bytes", rec.Code, rec.Body.Len())
+			}
+		})
+	}
+}
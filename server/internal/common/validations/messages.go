@@ -0,0 +1,189 @@
+package validation
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// MessageResolver looks up the message template for key in a locale.
+// Templates may use {field} and {param}. ok is false when the locale has no
+// entry, in which case the next preferred locale and finally English are tried.
+type MessageResolver interface {
+	Resolve(locale, key string) (template string, ok bool)
+}
+
+// Catalog is a MessageResolver backed by per-locale message maps. Locales are
+// lowercase language tags such as "es" or "pt-br".
+type Catalog map[string]map[string]string
+
+func (c Catalog) Resolve(locale, key string) (string, bool) {
+	template, ok := c[locale][key]
+	return template, ok
+}
+
+// english is the fallback for every key, so a partial catalog or a custom
+// resolver never leaves a message blank
+var english = map[string]string{
+	"validation_failed":   "Validation failed",
+	"invalid_body":        "Invalid request body",
+	"required":            "{field} is required",
+	"email":               "Please enter a valid email address",
+	"min":                 "{field} must be at least {param} characters long",
+	"not_empty":           "{field} cannot be empty",
+	"max":                 "{field} must be at most {param} characters long",
+	"min_items":           "{field} must have at least {param} items",
+	"max_items":           "{field} must have at most {param} items",
+	"gt":                  "{field} must be greater than {param}",
+	"url":                 "{field} must be a valid URL",
+	"iso4217":             "{field} must be an ISO 4217 currency code",
+	"oneof":               "{field} must be one of: {param}",
+	"containsuppercase":   "Password must contain at least one uppercase letter",
+	"containslowercase":   "Password must contain at least one lowercase letter",
+	"containsnumber":      "Password must contain at least one number",
+	"containsspecialchar": "Password must contain at least one special character",
+	"nefield":             "New password must be different from the current password",
+	"invalid":             "{field} is invalid",
+}
+
+// DefaultCatalog holds the bundled translations
+var DefaultCatalog = Catalog{
+	"en": english,
+	"es": {
+		"validation_failed":   "La validación falló",
+		"invalid_body":        "El cuerpo de la solicitud no es válido",
+		"required":            "{field} es obligatorio",
+		"email":               "Introduce una dirección de correo electrónico válida",
+		"min":                 "{field} debe tener al menos {param} caracteres",
+		"not_empty":           "{field} no puede estar vacío",
+		"max":                 "{field} debe tener como máximo {param} caracteres",
+		"min_items":           "{field} debe tener al menos {param} elementos",
+		"max_items":           "{field} debe tener como máximo {param} elementos",
+		"gt":                  "{field} debe ser mayor que {param}",
+		"url":                 "{field} debe ser una URL válida",
+		"iso4217":             "{field} debe ser un código de moneda ISO 4217",
+		"oneof":               "{field} debe ser uno de: {param}",
+		"containsuppercase":   "La contraseña debe contener al menos una letra mayúscula",
+		"containslowercase":   "La contraseña debe contener al menos una letra minúscula",
+		"containsnumber":      "La contraseña debe contener al menos un número",
+		"containsspecialchar": "La contraseña debe contener al menos un carácter especial",
+		"nefield":             "La nueva contraseña debe ser distinta de la actual",
+		"invalid":             "{field} no es válido",
+	},
+}
+
+var resolver MessageResolver = DefaultCatalog
+
+// SetMessageResolver replaces the resolver used for validation messages. It is
+// meant to be called once at startup; nil restores DefaultCatalog.
+func SetMessageResolver(r MessageResolver) {
+	if r == nil {
+		r = DefaultCatalog
+	}
+	resolver = r
+}
+
+// Messages renders validation messages in the caller's preferred locales
+type Messages struct {
+	locales []string
+}
+
+// MessagesFor picks locales from an Accept-Language header, most preferred
+// first. A regional tag such as es-MX also tries its base language.
+func MessagesFor(acceptLanguage string) Messages {
+	type weighted struct {
+		locale string
+		q      float64
+	}
+
+	var preferred []weighted
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		locale, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		locale = strings.ToLower(strings.TrimSpace(locale))
+		if locale == "" || locale == "*" {
+			continue
+		}
+
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if q <= 0 {
+			continue
+		}
+
+		preferred = append(preferred, weighted{locale: locale, q: q})
+	}
+	sort.SliceStable(preferred, func(i, j int) bool { return preferred[i].q > preferred[j].q })
+
+	var messages Messages
+	for _, p := range preferred {
+		messages.locales = append(messages.locales, p.locale)
+		if base, _, regional := strings.Cut(p.locale, "-"); regional {
+			messages.locales = append(messages.locales, base)
+		}
+	}
+	return messages
+}
+
+// Message renders key for field and param, falling back to English
+func (m Messages) Message(key, field, param string) string {
+	template, ok := "", false
+	for _, locale := range m.locales {
+		if template, ok = resolver.Resolve(locale, key); ok {
+			break
+		}
+	}
+	if !ok {
+		template = english[key]
+	}
+
+	return strings.NewReplacer("{field}", field, "{param}", param).Replace(template)
+}
+
+// Failed is the top-level message for a request that failed validation
+func (m Messages) Failed() string {
+	return m.Message("validation_failed", "", "")
+}
+
+// InvalidBody is the top-level message the trip, activity, expense and photo
+// endpoints use for a request body that failed validation
+func (m Messages) InvalidBody() string {
+	return m.Message("invalid_body", "", "")
+}
+
+// Details maps validator errors to per-field messages
+func (m Messages) Details(validationErrors validator.ValidationErrors) map[string]string {
+	details := make(map[string]string)
+	for _, e := range validationErrors {
+		details[e.Field()] = m.FieldMessage(e)
+	}
+	return details
+}
+
+// FieldMessage renders a single validator error, for endpoints that report
+// errors per item rather than per field. Conditional required rules read as
+// required, min and max on lists count items, and rules without a message of
+// their own are reported as invalid.
+func (m Messages) FieldMessage(e validator.FieldError) string {
+	key := e.Tag()
+	isList := e.Kind() == reflect.Slice || e.Kind() == reflect.Array || e.Kind() == reflect.Map
+	switch {
+	case strings.HasPrefix(key, "required"):
+		key = "required"
+	case key == "min" && e.Param() == "1":
+		key = "not_empty"
+	case (key == "min" || key == "max") && isList:
+		key += "_items"
+	case english[key] == "":
+		key = "invalid"
+	}
+	return m.Message(key, e.Field(), e.Param())
+}
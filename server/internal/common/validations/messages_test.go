@@ -0,0 +1,109 @@
+package validation_test
+
+import (
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+
+	validation "black-lotus/internal/common/validations"
+)
+
+func TestMessagesFor(t *testing.T) {
+	testCases := []struct {
+		name           string
+		acceptLanguage string
+		expected       string
+	}{
+		{name: "NoHeader", acceptLanguage: "", expected: "Name is required"},
+		{name: "Spanish", acceptLanguage: "es", expected: "Name es obligatorio"},
+		{name: "RegionalFallsBackToBase", acceptLanguage: "es-MX", expected: "Name es obligatorio"},
+		{name: "QualityOrder", acceptLanguage: "en;q=0.5, es;q=0.9", expected: "Name es obligatorio"},
+		{name: "ZeroQualityIgnored", acceptLanguage: "es;q=0, en", expected: "Name is required"},
+		{name: "UnknownLocale", acceptLanguage: "fr-FR, fr", expected: "Name is required"},
+		{name: "Wildcard", acceptLanguage: "*", expected: "Name is required"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			message := validation.MessagesFor(tc.acceptLanguage).Message("required", "Name", "")
+			if message != tc.expected {
+				t.Errorf("Expected %q, got %q", tc.expected, message)
+			}
+		})
+	}
+}
+
+func TestMessagesDetails(t *testing.T) {
+	type input struct {
+		Name     *string `validate:"omitempty,min=1"`
+		Password string  `validate:"min=8"`
+		Code     string  `validate:"len=3"`
+	}
+
+	empty := ""
+	err := validator.New().Struct(input{Name: &empty, Password: "short", Code: "toolong"})
+	validationErrors, ok := err.(validator.ValidationErrors)
+	if !ok {
+		t.Fatalf("Expected validation errors, got: %v", err)
+	}
+
+	details := validation.MessagesFor("en").Details(validationErrors)
+
+	expected := map[string]string{
+		"Name":     "Name cannot be empty",
+		"Password": "Password must be at least 8 characters long",
+		"Code":     "Code is invalid",
+	}
+	for field, message := range expected {
+		if details[field] != message {
+			t.Errorf("Expected %s message %q, got %q", field, message, details[field])
+		}
+	}
+}
+
+func TestMessagesFieldMessage(t *testing.T) {
+	type input struct {
+		Location string `validate:"required_unless=Wishlist true"`
+		Wishlist bool
+		Tags     []string `validate:"max=2"`
+		Link     string   `validate:"url"`
+		Amount   int      `validate:"gt=0"`
+	}
+
+	err := validator.New().Struct(input{Tags: []string{"a", "b", "c"}, Link: "not a url"})
+	validationErrors, ok := err.(validator.ValidationErrors)
+	if !ok {
+		t.Fatalf("Expected validation errors, got: %v", err)
+	}
+
+	details := validation.MessagesFor("es").Details(validationErrors)
+
+	expected := map[string]string{
+		"Location": "Location es obligatorio",
+		"Tags":     "Tags debe tener como máximo 2 elementos",
+		"Link":     "Link debe ser una URL válida",
+		"Amount":   "Amount debe ser mayor que 0",
+	}
+	for field, message := range expected {
+		if details[field] != message {
+			t.Errorf("Expected %s message %q, got %q", field, message, details[field])
+		}
+	}
+}
+
+func TestSetMessageResolver(t *testing.T) {
+	t.Cleanup(func() { validation.SetMessageResolver(nil) })
+
+	// A partial catalog only overrides the keys it has
+	validation.SetMessageResolver(validation.Catalog{
+		"de": {"required": "{field} ist erforderlich"},
+	})
+
+	messages := validation.MessagesFor("de")
+	if message := messages.Message("required", "Name", ""); message != "Name ist erforderlich" {
+		t.Errorf("Expected German required message, got %q", message)
+	}
+	if message := messages.Failed(); message != "Validation failed" {
+		t.Errorf("Expected English fallback, got %q", message)
+	}
+}
@@ -0,0 +1,61 @@
+package validation
+
+import (
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+
+	"black-lotus/internal/domain/models"
+)
+
+// TripFieldRules decides which descriptive trip fields a deployment requires.
+// Dates are governed by the struct tags; these fields differ per product.
+type TripFieldRules struct {
+	LocationRequired    bool
+	DescriptionRequired bool
+}
+
+// TripFieldRulesFromEnv reads TRIP_LOCATION_REQUIRED (default true) and
+// TRIP_DESCRIPTION_REQUIRED (default false). Invalid values use the defaults.
+func TripFieldRulesFromEnv() TripFieldRules {
+	rules := TripFieldRules{LocationRequired: true}
+
+	if required, err := strconv.ParseBool(os.Getenv("TRIP_LOCATION_REQUIRED")); err == nil {
+		rules.LocationRequired = required
+	}
+	if required, err := strconv.ParseBool(os.Getenv("TRIP_DESCRIPTION_REQUIRED")); err == nil {
+		rules.DescriptionRequired = required
+	}
+
+	return rules
+}
+
+// RegisterTripValidators enforces rules on trip inputs. Missing fields are
+// reported with the "required" tag, so callers handle them exactly as they
+// would a required struct tag.
+func RegisterTripValidators(v *validator.Validate, rules TripFieldRules) {
+	v.RegisterStructValidation(func(sl validator.StructLevel) {
+		input := sl.Current().Interface().(models.CreateTripInput)
+
+		if rules.LocationRequired && strings.TrimSpace(input.Location) == "" {
+			sl.ReportError(input.Location, "location", "Location", "required", "")
+		}
+		if rules.DescriptionRequired && strings.TrimSpace(input.Description) == "" {
+			sl.ReportError(input.Description, "description", "Description", "required", "")
+		}
+	}, models.CreateTripInput{})
+
+	// Updates leave unset fields alone, but can't clear a required one
+	v.RegisterStructValidation(func(sl validator.StructLevel) {
+		input := sl.Current().Interface().(models.UpdateTripInput)
+
+		if rules.LocationRequired && input.Location != nil && strings.TrimSpace(*input.Location) == "" {
+			sl.ReportError(input.Location, "location", "Location", "required", "")
+		}
+		if rules.DescriptionRequired && input.Description != nil && strings.TrimSpace(*input.Description) == "" {
+			sl.ReportError(input.Description, "description", "Description", "required", "")
+		}
+	}, models.UpdateTripInput{})
+}
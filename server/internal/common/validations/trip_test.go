@@ -0,0 +1,71 @@
+package validation_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+
+	validation "black-lotus/internal/common/validations"
+	"black-lotus/internal/domain/models"
+)
+
+func TestRegisterTripValidators(t *testing.T) {
+	start := time.Date(2025, time.June, 10, 0, 0, 0, 0, time.UTC)
+	noLocation := models.CreateTripInput{StartDate: start, EndDate: start.Add(72 * time.Hour)}
+	withLocation := models.CreateTripInput{StartDate: start, EndDate: start.Add(72 * time.Hour), Location: "Lisbon"}
+	blank := "  "
+
+	testCases := []struct {
+		name          string
+		rules         validation.TripFieldRules
+		input         interface{}
+		expectedField string // Empty when the input is valid
+	}{
+		{name: "LocationRequiredAndMissing", rules: validation.TripFieldRules{LocationRequired: true}, input: noLocation, expectedField: "location"},
+		{name: "LocationRequiredAndGiven", rules: validation.TripFieldRules{LocationRequired: true}, input: withLocation},
+		{name: "LocationOptionalAndMissing", rules: validation.TripFieldRules{}, input: noLocation},
+		{name: "DescriptionRequiredAndMissing", rules: validation.TripFieldRules{DescriptionRequired: true}, input: withLocation, expectedField: "description"},
+		{name: "UpdateClearsRequiredLocation", rules: validation.TripFieldRules{LocationRequired: true}, input: models.UpdateTripInput{Location: &blank}, expectedField: "location"},
+		{name: "UpdateClearsOptionalLocation", rules: validation.TripFieldRules{}, input: models.UpdateTripInput{Location: &blank}},
+		{name: "UpdateLeavesLocationUnset", rules: validation.TripFieldRules{LocationRequired: true}, input: models.UpdateTripInput{Name: &blank}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			v := validator.New()
+			validation.RegisterTripValidators(v, tc.rules)
+
+			err := v.Struct(tc.input)
+
+			if tc.expectedField == "" {
+				if err != nil {
+					t.Errorf("Expected no error, got: %v", err)
+				}
+				return
+			}
+
+			validationErrors, ok := err.(validator.ValidationErrors)
+			if !ok || len(validationErrors) != 1 {
+				t.Fatalf("Expected one validation error, got: %v", err)
+			}
+			if validationErrors[0].Field() != tc.expectedField || validationErrors[0].Tag() != "required" {
+				t.Errorf("Expected %s to be required, got %s failing %s", tc.expectedField, validationErrors[0].Field(), validationErrors[0].Tag())
+			}
+		})
+	}
+}
+
+func TestTripFieldRulesFromEnv(t *testing.T) {
+	t.Setenv("TRIP_LOCATION_REQUIRED", "")
+	t.Setenv("TRIP_DESCRIPTION_REQUIRED", "")
+	if rules := validation.TripFieldRulesFromEnv(); !rules.LocationRequired || rules.DescriptionRequired {
+		t.Errorf("Expected location required and description optional by default, got %+v", rules)
+	}
+
+	t.Setenv("TRIP_LOCATION_REQUIRED", "false")
+	t.Setenv("TRIP_DESCRIPTION_REQUIRED", "true")
+	if rules := validation.TripFieldRulesFromEnv(); rules.LocationRequired || !rules.DescriptionRequired {
+		t.Errorf("Expected location optional and description required, got %+v", rules)
+	}
+}
@@ -0,0 +1,51 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Activity is a single itinerary entry within a trip
+type Activity struct {
+	ID          uuid.UUID `json:"id"`
+	TripID      uuid.UUID `json:"trip_id"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	StartTime   time.Time `json:"start_time"`
+	EndTime     time.Time `json:"end_time"`
+	Location    string    `json:"location"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type CreateActivityInput struct {
+	Title       string    `json:"title" validate:"required,min=1,max=100"`
+	Description string    `json:"description"`
+	StartTime   time.Time `json:"start_time" validate:"required"`
+	EndTime     time.Time `json:"end_time" validate:"required"`
+	Location    string    `json:"location" validate:"max=100"`
+}
+
+type UpdateActivityInput struct {
+	Title       *string    `json:"title" validate:"omitempty,min=1,max=100"`
+	Description *string    `json:"description"`
+	StartTime   *time.Time `json:"start_time" validate:"omitempty"`
+	EndTime     *time.Time `json:"end_time" validate:"omitempty"`
+	Location    *string    `json:"location" validate:"omitempty,max=100"`
+}
+
+// WithinTripDates reports whether the period start-end falls inside a trip.
+// Trip dates are treated as whole days, so an activity may run at any time
+// on the trip's first and last day.
+func WithinTripDates(tripStart, tripEnd, start, end time.Time) bool {
+	firstDay := startOfDay(tripStart)
+	afterLastDay := startOfDay(tripEnd).AddDate(0, 0, 1)
+
+	return !start.Before(firstDay) && end.Before(afterLastDay)
+}
+
+func startOfDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
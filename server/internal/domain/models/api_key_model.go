@@ -0,0 +1,37 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// APIKey lets a script act as its user by sending it as a Bearer token.
+// Only a hash of the key is stored; Prefix identifies it in listings.
+type APIKey struct {
+	ID         uuid.UUID  `json:"id"`
+	UserID     uuid.UUID  `json:"user_id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	ExpiresAt  *time.Time `json:"expires_at"`   // Nil for a key that never expires
+	LastUsedAt *time.Time `json:"last_used_at"` // Nil until the key is first used
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// CreatedAPIKey is returned once, when the key is created. The raw key can't
+// be recovered afterwards.
+type CreatedAPIKey struct {
+	APIKey
+	Key string `json:"key"`
+}
+
+type CreateAPIKeyInput struct {
+	Name      string     `json:"name" validate:"required,max=100"`
+	ExpiresAt *time.Time `json:"expires_at"` // Optional; must be in the future
+}
+
+// Normalize trims the name so a blank one fails the required check
+func (i *CreateAPIKeyInput) Normalize() {
+	i.Name = strings.TrimSpace(i.Name)
+}
@@ -0,0 +1,92 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Expense is money spent during a trip. Amounts are integer minor units (cents)
+// so totals never suffer from floating point rounding.
+type Expense struct {
+	ID          uuid.UUID `json:"id"`
+	TripID      uuid.UUID `json:"trip_id"`
+	AmountCents int64     `json:"amount_cents"`
+	Currency    string    `json:"currency"`
+	Category    string    `json:"category"`
+	Note        string    `json:"note"`
+	PaidBy      string    `json:"paid_by"`     // Empty for expenses that aren't shared
+	SplitAmong  []string  `json:"split_among"` // People sharing the cost equally
+	IncurredAt  time.Time `json:"incurred_at"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type CreateExpenseInput struct {
+	AmountCents int64     `json:"amount_cents" validate:"required,gt=0"`
+	Currency    string    `json:"currency" validate:"required,iso4217"`
+	Category    string    `json:"category" validate:"required,max=50"`
+	Note        string    `json:"note" validate:"max=500"`
+	PaidBy      string    `json:"paid_by" validate:"max=100"`
+	SplitAmong  []string  `json:"split_among" validate:"max=20,dive,max=100"` // Given together with paid_by
+	IncurredAt  time.Time `json:"incurred_at" validate:"required"`
+}
+
+// BudgetTotal is the amount spent in one category and currency
+type BudgetTotal struct {
+	Category    string `json:"category"`
+	Currency    string `json:"currency"`
+	AmountCents int64  `json:"amount_cents"`
+}
+
+// BudgetSummary aggregates a trip's expenses. Amounts in different currencies
+// are never added together.
+type BudgetSummary struct {
+	TripID     uuid.UUID        `json:"trip_id"`
+	ByCategory []*BudgetTotal   `json:"by_category"`
+	ByCurrency map[string]int64 `json:"by_currency"`
+}
+
+// Expense timeline granularities. Periods start at midnight UTC; weeks start on Monday.
+const (
+	ExpenseGranularityDay  = "day"
+	ExpenseGranularityWeek = "week"
+)
+
+// ExpenseTimelineBucket is the amount spent in one currency during one period
+type ExpenseTimelineBucket struct {
+	PeriodStart time.Time `json:"period_start"`
+	Currency    string    `json:"currency"`
+	AmountCents int64     `json:"amount_cents"`
+}
+
+// ExpenseTimeline is a trip's spending over time, oldest period first.
+// Periods without expenses are left out.
+type ExpenseTimeline struct {
+	TripID      uuid.UUID                `json:"trip_id"`
+	Granularity string                   `json:"granularity"`
+	Buckets     []*ExpenseTimelineBucket `json:"buckets"`
+}
+
+// ExpenseBalance is how much one person is owed (positive) or owes (negative)
+// in one currency across a trip's shared expenses
+type ExpenseBalance struct {
+	Name         string `json:"name"`
+	Currency     string `json:"currency"`
+	BalanceCents int64  `json:"balance_cents"`
+}
+
+// SettlementTransfer is a payment that settles part of what From owes To
+type SettlementTransfer struct {
+	From        string `json:"from"`
+	To          string `json:"to"`
+	Currency    string `json:"currency"`
+	AmountCents int64  `json:"amount_cents"`
+}
+
+// ExpenseSettlement says who owes whom for a trip's shared expenses. Each
+// currency is settled on its own, since amounts are never converted.
+type ExpenseSettlement struct {
+	TripID    uuid.UUID            `json:"trip_id"`
+	Balances  []ExpenseBalance     `json:"balances"`
+	Transfers []SettlementTransfer `json:"transfers"`
+}
@@ -7,11 +7,26 @@ import (
 )
 
 type Session struct {
-	ID            uuid.UUID `json:"id"`
-	UserID        uuid.UUID `json:"user_id"`
-	AccessToken   string    `json:"-"` // Short-lived token
-	RefreshToken  string    `json:"-"` // Long-lived token
-	AccessExpiry  time.Time `json:"access_expires_at"`
-	RefreshExpiry time.Time `json:"refresh_expires_at"`
-	CreatedAt     time.Time `json:"created_at"`
+	ID              uuid.UUID  `json:"id"`
+	UserID          uuid.UUID  `json:"user_id"`
+	AccessToken     string     `json:"-"` // Short-lived token
+	RefreshToken    string     `json:"-"` // Long-lived token
+	AccessExpiry    time.Time  `json:"access_expires_at"`
+	RefreshExpiry   time.Time  `json:"refresh_expires_at"`
+	LastRefreshedAt *time.Time `json:"last_refreshed_at,omitempty"` // Nil until the access token is first refreshed
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+// Filters accepted by RevokeSessionsInput
+const (
+	SessionFilterAllExceptCurrent = "all_except_current"
+	SessionFilterAll              = "all"
+)
+
+// RevokeSessionsInput signs out the caller's sessions matching Filter. The
+// "all" filter also ends the session making the request, so it must be
+// confirmed with IncludeCurrent.
+type RevokeSessionsInput struct {
+	Filter         string `json:"filter"`
+	IncludeCurrent bool   `json:"include_current"`
 }
@@ -0,0 +1,45 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Tag is a user-scoped label such as "business" or "vacation" that can be attached to trips
+type Tag struct {
+	ID        uuid.UUID `json:"id"`
+	UserID    uuid.UUID `json:"user_id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type AddTripTagInput struct {
+	Name string `json:"name" validate:"required,min=1,max=50"`
+}
+
+// Bulk tag modes
+const (
+	BulkTagModeAdd     = "add"     // Keep each trip's existing tags
+	BulkTagModeReplace = "replace" // Drop each trip's existing tags first
+)
+
+// BulkTagTripsInput applies the same tags to several trips at once
+type BulkTagTripsInput struct {
+	IDs  []uuid.UUID `json:"ids" validate:"required,min=1"`
+	Tags []string    `json:"tags" validate:"required,min=1,dive,min=1,max=50"`
+	Mode string      `json:"mode" validate:"required,oneof=add replace"`
+}
+
+// Bulk tag result statuses
+const (
+	BulkTagStatusTagged   = "tagged"
+	BulkTagStatusNotFound = "not_found" // Missing, deleted, or owned by someone else
+)
+
+// BulkTagResult reports the outcome of a bulk tag request for one trip
+type BulkTagResult struct {
+	ID     uuid.UUID `json:"id"`
+	Status string    `json:"status"`
+	Tags   []*Tag    `json:"tags,omitempty"` // The trip's tags after the change
+}
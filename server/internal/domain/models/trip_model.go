@@ -1,38 +1,493 @@
 package models
 
 import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
+
+	"black-lotus/internal/common/binding"
 )
 
 type Trip struct {
 	// Will generate default names for Trips in service file
-	ID          uuid.UUID `json:"id"`
-	UserID      uuid.UUID `json:"user_id"`
-	Name        string    `json:"name"`
-	Description string    `json:"description"`
-	StartDate   time.Time `json:"start_date" validate:"required"`
-	EndDate     time.Time `json:"end_date" validate:"required"`
-	Location    string    `json:"location" validate:"required"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
-	User        *User     `json:"-,omitempty"`
+	ID          uuid.UUID    `json:"id"`
+	UserID      uuid.UUID    `json:"user_id"`
+	Name        string       `json:"name"`
+	Description string       `json:"description"`
+	StartDate   *time.Time   `json:"start_date" format:"date"` // Nil for wishlist trips without dates
+	EndDate     *time.Time   `json:"end_date" format:"date"`
+	Location    string       `json:"location" validate:"required"`
+	IsWishlist  bool         `json:"is_wishlist"`           // Bucket-list idea rather than a planned trip
+	Visibility  string       `json:"visibility"`            // TripVisibilityPrivate or TripVisibilityUnlisted
+	ShareToken  *string      `json:"share_token,omitempty"` // Set while unlisted; the public view never includes it
+	Status      string       `json:"status,omitempty"`      // Computed by the service from the dates, never stored
+	ClientID    string       `json:"client_id,omitempty"`   // Echoed from the create request, never stored
+	CreatedAt   time.Time    `json:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at"`
+	DeletedAt   *time.Time   `json:"deleted_at,omitempty"`
+	Tags        []*Tag       `json:"tags,omitempty"`
+	User        *User        `json:"user,omitempty"`        // Owner, only loaded by GetTripWithUser
+	Photos      []*TripPhoto `json:"photos,omitempty"`      // Only loaded when the trip detail asks for them
+	PhotoCount  *int         `json:"photo_count,omitempty"` // Likewise; nil when not loaded
+	// CoverImageURL is the user's cover, or a fallback generated from the
+	// location when they haven't set one. CoverImageFallback tells them apart.
+	CoverImageURL      string `json:"cover_image_url"`
+	CoverImageFallback bool   `json:"cover_image_fallback,omitempty"`
+}
+
+// Trip listing sort orders
+const (
+	TripSortStartDate = ""       // Newest start date first (default)
+	TripSortManual    = "manual" // The user's pinned order, unordered trips last
+)
+
+// Trip statuses, derived from the trip's dates relative to the current time
+// when the listing is queried. Wishlist trips have no dates and no status.
+// Both dates are whole days in UTC, so a trip is ongoing for all of its last day.
+//   - past: the trip's last day is over
+//   - ongoing: the trip's first day has begun and its last day isn't over
+//   - upcoming: the trip's first day hasn't begun
+const (
+	TripStatusPast     = "past"
+	TripStatusOngoing  = "ongoing"
+	TripStatusUpcoming = "upcoming"
+)
+
+// TripStatusAt derives a trip's status from its dates at the given time, using
+// the same rules as the listing's status filter. Trips without dates have none.
+func TripStatusAt(startDate, endDate *time.Time, now time.Time) string {
+	switch {
+	case startDate == nil || endDate == nil:
+		return ""
+	case !TripDayEnd(*endDate).After(now):
+		return TripStatusPast
+	case startDate.After(now):
+		return TripStatusUpcoming
+	default:
+		return TripStatusOngoing
+	}
+}
+
+// TripSortFields are the trip fields a listing can be sorted by as "field:asc" or "field:desc"
+var TripSortFields = []string{"start_date", "end_date", "created_at", "name"}
+
+// TripSortSpec is a validated field and direction for ordering a trip listing
+type TripSortSpec struct {
+	Field      string
+	Descending bool
+}
+
+// ParseTripSortSpec parses "field:asc" or "field:desc" for one of TripSortFields
+func ParseTripSortSpec(value string) (TripSortSpec, bool) {
+	field, direction, found := strings.Cut(value, ":")
+	if !found || !slices.Contains(TripSortFields, field) {
+		return TripSortSpec{}, false
+	}
+
+	switch direction {
+	case "asc":
+		return TripSortSpec{Field: field}, true
+	case "desc":
+		return TripSortSpec{Field: field, Descending: true}, true
+	}
+	return TripSortSpec{}, false
+}
+
+// TripCursor marks a position in a cursor-paginated trip listing, which is
+// ordered newest first by (created_at, id). The zero cursor is the first page.
+type TripCursor struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+// Encode renders the cursor as the opaque string handed to clients
+func (c TripCursor) Encode() string {
+	raw := c.CreatedAt.UTC().Format(time.RFC3339Nano) + "," + c.ID.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// ParseTripCursor decodes a cursor made by Encode. An empty string is the first page.
+func ParseTripCursor(value string) (TripCursor, bool) {
+	if value == "" {
+		return TripCursor{}, true
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return TripCursor{}, false
+	}
+
+	createdAt, id, found := strings.Cut(string(raw), ",")
+	if !found {
+		return TripCursor{}, false
+	}
+
+	cursor := TripCursor{}
+	if cursor.CreatedAt, err = time.Parse(time.RFC3339Nano, createdAt); err != nil {
+		return TripCursor{}, false
+	}
+	if cursor.ID, err = uuid.Parse(id); err != nil {
+		return TripCursor{}, false
+	}
+	return cursor, true
+}
+
+// TripFilter narrows and orders a trip listing. Zero values apply no filtering.
+type TripFilter struct {
+	Tag      string
+	Sort     string
+	SortSpec *TripSortSpec // Set by the service when Sort is a field:direction pair
+	Wishlist *bool         // Nil lists both wishlist and planned trips
+	From     *time.Time    // Only trips starting at or after this time
+	To       *time.Time    // Only trips ending at or before this time
+	Status   string        // One of the TripStatus values
+	Cursor   *TripCursor   // Keyset pagination newest first; replaces Sort and offset
+}
+
+// TripPage is one page of a cursor-paginated trip listing. NextCursor is nil
+// on the last page.
+type TripPage struct {
+	Trips      []*Trip `json:"trips"`
+	NextCursor *string `json:"next_cursor"`
+}
+
+// TripListVersion summarizes a user's active trips cheaply enough to answer
+// conditional list requests without loading the list. Started and Ended count
+// trips whose start or end has passed, so the version also moves when a
+// trip's computed status does.
+type TripListVersion struct {
+	LatestUpdate *time.Time // Nil when the user has no trips
+	Count        int
+	Started      int
+	Ended        int
+}
+
+// TripBatchError describes an invalid trip in a bulk create by its position
+type TripBatchError struct {
+	Index   int    `json:"index"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+// TripRowError reports a problem with one row of a CSV import. Line is the
+// line of the file the row starts on, counting the header as line 1.
+type TripRowError struct {
+	Line    int    `json:"line"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+// TripCSVImportResult is the outcome of a CSV import. Imports are all or
+// nothing, so Imported is the number of rows in the file.
+type TripCSVImportResult struct {
+	Imported int     `json:"imported"`
+	Trips    []*Trip `json:"trips"`
+}
+
+// ReorderTripsInput is the user's manual trip order, first trip first
+type ReorderTripsInput struct {
+	TripIDs []uuid.UUID `json:"trip_ids" validate:"required,min=1"`
 }
 
 type CreateTripInput struct {
 	// Will generate default names for Trips in service file
-	Name        string    `json:"name"`
-	Description string    `json:"description"`
-	StartDate   time.Time `json:"start_date" validate:"required"`
-	EndDate     time.Time `json:"end_date" validate:"required"`
-	Location    string    `json:"location" validate:"required"`
+	Name          string    `json:"name"`
+	Description   string    `json:"description"`
+	StartDate     time.Time `json:"start_date" format:"date" validate:"required_unless=IsWishlist true"`
+	EndDate       time.Time `json:"end_date" format:"date" validate:"required_unless=IsWishlist true"`
+	Location      string    `json:"location"`                               // Required by default, see validation.TripFieldRules
+	IsWishlist    bool      `json:"is_wishlist"`                            // Wishlist trips may leave the dates out
+	ClientID      string    `json:"client_id" validate:"omitempty,max=128"` // An offline client's temporary ID, echoed back so it can reconcile
+	CoverImageURL string    `json:"cover_image_url" validate:"omitempty,url,max=2048"`
 }
 
 type UpdateTripInput struct {
-	Name        *string    `json:"name" validate:"omitempty,min=1"`
-	Description *string    `json:"description"`
-	StartDate   *time.Time `json:"start_date" validate:"omitempty"`
-	EndDate     *time.Time `json:"end_date" validate:"omitempty"`
-	Location    *string    `json:"location" validate:"omitempty,min=1"`
+	Name          *string    `json:"name" validate:"omitempty,min=1"`
+	Description   *string    `json:"description"`
+	StartDate     *time.Time `json:"start_date" format:"date" validate:"omitempty"`
+	EndDate       *time.Time `json:"end_date" format:"date" validate:"omitempty"`
+	Location      *string    `json:"location"`
+	CoverImageURL *string    `json:"cover_image_url" validate:"omitempty,url,max=2048"` // Empty removes the cover
+}
+
+// TripDateLayout is how trip dates are written: a calendar day with no time
+// or offset, so clients in other time zones can't shift it to a different day
+const TripDateLayout = time.DateOnly
+
+// TripDay is midnight UTC on the calendar day t falls on in its own offset,
+// which is how trip dates are stored. The zero time stays zero.
+func TripDay(t time.Time) time.Time {
+	if t.IsZero() {
+		return t
+	}
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// TripDayEnd is when a trip's last day is over. End dates are inclusive, so a
+// trip ending on June 3 lasts until midnight UTC on June 4.
+func TripDayEnd(end time.Time) time.Time {
+	return TripDay(end).AddDate(0, 0, 1)
+}
+
+// ParseTripDate reads a trip date as YYYY-MM-DD. Older clients and exports
+// send RFC3339 times instead; those keep the day they name in their offset.
+func ParseTripDate(value string) (time.Time, error) {
+	if t, err := time.Parse(TripDateLayout, value); err == nil {
+		return t, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid trip date %q, use YYYY-MM-DD", value)
+	}
+	return TripDay(t), nil
+}
+
+// Normalize brings the trip dates to midnight UTC
+func (i *CreateTripInput) Normalize() {
+	i.StartDate, i.EndDate = TripDay(i.StartDate), TripDay(i.EndDate)
+}
+
+// Normalize brings whichever trip dates are set to midnight UTC
+func (i *UpdateTripInput) Normalize() {
+	if i.StartDate != nil {
+		start := TripDay(*i.StartDate)
+		i.StartDate = &start
+	}
+	if i.EndDate != nil {
+		end := TripDay(*i.EndDate)
+		i.EndDate = &end
+	}
+}
+
+// Normalize brings the trip dates to midnight UTC
+func (i *PlanTripInput) Normalize() {
+	i.StartDate, i.EndDate = TripDay(i.StartDate), TripDay(i.EndDate)
+}
+
+// Normalize brings the exported trip's dates to midnight UTC
+func (e *TripExport) Normalize() {
+	e.Trip.Normalize()
+}
+
+// tripDateJSON writes the date it points to as a TripDateLayout day and reads
+// it back with ParseTripDate. null leaves the date alone.
+type tripDateJSON struct{ date *time.Time }
+
+func (d tripDateJSON) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.date.Format(TripDateLayout))
+}
+
+func (d tripDateJSON) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+
+	date, err := ParseTripDate(value)
+	if err != nil {
+		return err
+	}
+	*d.date = date
+	return nil
+}
+
+// optionalTripDateJSON is tripDateJSON for dates that may be nil
+type optionalTripDateJSON struct{ date **time.Time }
+
+func (d optionalTripDateJSON) MarshalJSON() ([]byte, error) {
+	if *d.date == nil {
+		return []byte("null"), nil
+	}
+	return tripDateJSON{*d.date}.MarshalJSON()
+}
+
+func (d optionalTripDateJSON) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*d.date = nil
+		return nil
+	}
+
+	var date time.Time
+	if err := (tripDateJSON{&date}).UnmarshalJSON(data); err != nil {
+		return err
+	}
+	*d.date = &date
+	return nil
+}
+
+func (t Trip) MarshalJSON() ([]byte, error) {
+	type plain Trip
+	return json.Marshal(struct {
+		plain
+		StartDate optionalTripDateJSON `json:"start_date"`
+		EndDate   optionalTripDateJSON `json:"end_date"`
+	}{plain(t), optionalTripDateJSON{&t.StartDate}, optionalTripDateJSON{&t.EndDate}})
+}
+
+func (t *Trip) UnmarshalJSON(data []byte) error {
+	type plain Trip
+	return json.Unmarshal(data, &struct {
+		*plain
+		StartDate optionalTripDateJSON `json:"start_date"`
+		EndDate   optionalTripDateJSON `json:"end_date"`
+	}{(*plain)(t), optionalTripDateJSON{&t.StartDate}, optionalTripDateJSON{&t.EndDate}})
+}
+
+func (p PublicTrip) MarshalJSON() ([]byte, error) {
+	type plain PublicTrip
+	return json.Marshal(struct {
+		plain
+		StartDate optionalTripDateJSON `json:"start_date"`
+		EndDate   optionalTripDateJSON `json:"end_date"`
+	}{plain(p), optionalTripDateJSON{&p.StartDate}, optionalTripDateJSON{&p.EndDate}})
+}
+
+// MarshalJSON writes the dates as days, as exports hold a CreateTripInput
+func (i CreateTripInput) MarshalJSON() ([]byte, error) {
+	type plain CreateTripInput
+	return json.Marshal(struct {
+		plain
+		StartDate tripDateJSON `json:"start_date"`
+		EndDate   tripDateJSON `json:"end_date"`
+	}{plain(i), tripDateJSON{&i.StartDate}, tripDateJSON{&i.EndDate}})
+}
+
+func (i *CreateTripInput) UnmarshalJSON(data []byte) error {
+	type plain CreateTripInput
+	return binding.DecodeJSON(data, &struct {
+		*plain
+		StartDate tripDateJSON `json:"start_date"`
+		EndDate   tripDateJSON `json:"end_date"`
+	}{(*plain)(i), tripDateJSON{&i.StartDate}, tripDateJSON{&i.EndDate}})
+}
+
+func (i *UpdateTripInput) UnmarshalJSON(data []byte) error {
+	type plain UpdateTripInput
+	return binding.DecodeJSON(data, &struct {
+		*plain
+		StartDate optionalTripDateJSON `json:"start_date"`
+		EndDate   optionalTripDateJSON `json:"end_date"`
+	}{(*plain)(i), optionalTripDateJSON{&i.StartDate}, optionalTripDateJSON{&i.EndDate}})
+}
+
+func (i *PlanTripInput) UnmarshalJSON(data []byte) error {
+	type plain PlanTripInput
+	return binding.DecodeJSON(data, &struct {
+		*plain
+		StartDate tripDateJSON `json:"start_date"`
+		EndDate   tripDateJSON `json:"end_date"`
+	}{(*plain)(i), tripDateJSON{&i.StartDate}, tripDateJSON{&i.EndDate}})
+}
+
+// PlanTripInput gives a wishlist trip the dates it needs to become a planned trip
+type PlanTripInput struct {
+	StartDate time.Time `json:"start_date" format:"date" validate:"required"`
+	EndDate   time.Time `json:"end_date" format:"date" validate:"required"`
+}
+
+// TripCadence describes how often a user travels, from the start dates of
+// their planned trips. The gap figures are nil until there are two trips.
+type TripCadence struct {
+	TripCount      int             `json:"trip_count"`
+	AverageGapDays *float64        `json:"average_gap_days"`
+	LongestGapDays *float64        `json:"longest_gap_days"`
+	TripsPerYear   []TripYearCount `json:"trips_per_year"`
+}
+
+// LocationHistory is a user's planned trips to one location, earliest first,
+// with totals across them. Days count both the first and last day of each
+// trip; the visits are the earliest and latest start dates. Both are nil
+// without any trips.
+type LocationHistory struct {
+	Location   string     `json:"location"` // Normalized: lowercase, single spaces
+	TripCount  int        `json:"trip_count"`
+	TotalDays  int        `json:"total_days"`
+	FirstVisit *time.Time `json:"first_visit" format:"date"`
+	LastVisit  *time.Time `json:"last_visit" format:"date"`
+	Trips      []*Trip    `json:"trips"`
+}
+
+// Trip visibility levels. Private trips are only visible to their owner;
+// unlisted trips can also be read, without logging in, through their share link.
+const (
+	TripVisibilityPrivate  = "private"
+	TripVisibilityUnlisted = "unlisted"
+)
+
+// TripShare is the share link state of a trip
+type TripShare struct {
+	TripID     uuid.UUID `json:"trip_id"`
+	Visibility string    `json:"visibility"`
+	ShareToken string    `json:"share_token"`
+}
+
+// PublicTrip is the read-only view of a shared trip. It deliberately leaves
+// out the owner and anything else that identifies them.
+type PublicTrip struct {
+	Name          string     `json:"name"`
+	Description   string     `json:"description"`
+	StartDate     *time.Time `json:"start_date" format:"date"`
+	EndDate       *time.Time `json:"end_date" format:"date"`
+	Location      string     `json:"location"`
+	IsWishlist    bool       `json:"is_wishlist"`
+	Status        string     `json:"status,omitempty"`
+	Tags          []string   `json:"tags"`
+	CoverImageURL string     `json:"cover_image_url"`
+}
+
+// TripDaysBreakdown splits the calendar days a trip covers into weekdays
+// (Monday to Friday) and weekend days, counting both the first and last day
+type TripDaysBreakdown struct {
+	TotalDays   int `json:"total_days"`
+	Weekdays    int `json:"weekdays"`
+	WeekendDays int `json:"weekend_days"`
+}
+
+// TripNamePreview is the name a trip created without one would be given
+type TripNamePreview struct {
+	Name string `json:"name"`
+}
+
+// TripYearCount is the number of trips starting in a calendar year
+type TripYearCount struct {
+	Year  int `json:"year"`
+	Trips int `json:"trips"`
+}
+
+// TripExportVersion is bumped whenever the export document shape changes.
+// Version 2 added expenses and tags; version 1 documents still import.
+const TripExportVersion = 2
+
+// TripExport is a self-contained document describing a single trip, suitable for re-import
+type TripExport struct {
+	Version    int                   `json:"version"`
+	ExportedAt time.Time             `json:"exported_at"`
+	Trip       CreateTripInput       `json:"trip"`
+	Activities []CreateActivityInput `json:"activities"`
+	Expenses   []CreateExpenseInput  `json:"expenses"`
+	Tags       []string              `json:"tags"`
+}
+
+// TripImportError describes a single invalid item in an imported trip document
+type TripImportError struct {
+	Section string `json:"section"`
+	Index   int    `json:"index"`
+	Message string `json:"message"`
+}
+
+// TripImportResult reports what was recreated from an imported trip document
+type TripImportResult struct {
+	Trip     *Trip          `json:"trip"`
+	Imported map[string]int `json:"imported"`
 }
@@ -0,0 +1,47 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TripPhoto is a photo attached to a trip. Only its metadata is stored; URL
+// points at wherever the image itself is hosted.
+type TripPhoto struct {
+	ID         uuid.UUID `json:"id"`
+	TripID     uuid.UUID `json:"trip_id"`
+	URL        string    `json:"url"`
+	Caption    string    `json:"caption"`
+	UploadedAt time.Time `json:"uploaded_at"`
+}
+
+type CreateTripPhotoInput struct {
+	URL     string `json:"url" validate:"required,url,max=2048"`
+	Caption string `json:"caption" validate:"max=500"`
+}
+
+// Normalize trims the URL and caption
+func (i *CreateTripPhotoInput) Normalize() {
+	i.URL = strings.TrimSpace(i.URL)
+	i.Caption = strings.TrimSpace(i.Caption)
+}
+
+// PhotoUploadInput asks for a presigned URL to upload a photo of the given type
+type PhotoUploadInput struct {
+	ContentType string `json:"content_type" validate:"required"`
+}
+
+// Normalize trims and lowercases the content type, which is case-insensitive
+func (i *PhotoUploadInput) Normalize() {
+	i.ContentType = strings.ToLower(strings.TrimSpace(i.ContentType))
+}
+
+// How much of a trip's photos the trip detail includes, chosen with the
+// photos query parameter. Leaving them out saves the extra query.
+const (
+	TripPhotosNone  = ""
+	TripPhotosCount = "count" // photo_count only
+	TripPhotosList  = "list"  // photos, oldest first, and photo_count
+)
@@ -0,0 +1,22 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TripReminder tells a trip's owner the trip starts soon. It is sent when
+// RemindAt passes and again after each snooze, until it is acknowledged.
+type TripReminder struct {
+	ID             uuid.UUID  `json:"id"`
+	TripID         uuid.UUID  `json:"trip_id"`
+	TripStart      time.Time  `json:"trip_start" format:"date"` // The start date it reminds about
+	Token          string     `json:"-"`                        // Only ever sent in the reminder's links
+	RemindAt       time.Time  `json:"remind_at"`
+	SentAt         *time.Time `json:"sent_at"`
+	AcknowledgedAt *time.Time `json:"acknowledged_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+
+	Trip *Trip `json:"-"` // Set on reminders due to be sent
+}
@@ -0,0 +1,32 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+
+	"black-lotus/pkg/weather"
+)
+
+// TripWeather is the forecast for the days of a trip that fall inside the
+// provider's forecast window. Days is empty when the trip is too far off or
+// already over; ForecastFrom and ForecastUntil show the window that was
+// available.
+type TripWeather struct {
+	TripID        uuid.UUID     `json:"trip_id"`
+	Location      string        `json:"location"`
+	Place         weather.Place `json:"place"`
+	Days          []weather.Day `json:"days"`
+	ForecastFrom  *time.Time    `json:"forecast_from" format:"date"`
+	ForecastUntil *time.Time    `json:"forecast_until" format:"date"`
+}
+
+func (w TripWeather) MarshalJSON() ([]byte, error) {
+	type plain TripWeather
+	return json.Marshal(struct {
+		plain
+		ForecastFrom  optionalTripDateJSON `json:"forecast_from"`
+		ForecastUntil optionalTripDateJSON `json:"forecast_until"`
+	}{plain(w), optionalTripDateJSON{&w.ForecastFrom}, optionalTripDateJSON{&w.ForecastUntil}})
+}
@@ -10,13 +10,36 @@ type User struct {
 	ID             uuid.UUID `json:"id"`
 	Name           string    `json:"name"`
 	Email          string    `json:"email"`
-	HashedPassword *string   `json:"hashed_password,omitempty"`
+	HashedPassword *string   `json:"-"` // Never serialized, even when loaded
 	EmailVerified  bool      `json:"email_verified" default:"false"`
+	Role           string    `json:"role"` // One of the UserRole values; never set at signup
 	CreatedAt      time.Time `json:"created_at"`
 	UpdatedAt      time.Time `json:"updated_at"`
 	Trips          []*Trip   `json:"trips,omitempty"`
 }
 
+// User roles. Everyone signs up as a user; admins are promoted in the
+// database or by another admin.
+const (
+	UserRoleUser  = "user"
+	UserRoleAdmin = "admin"
+)
+
+// UpdateUserRoleInput is an admin's change to another user's role
+type UpdateUserRoleInput struct {
+	Role string `json:"role" validate:"required,oneof=user admin"`
+}
+
+// UserStats summarizes a user's planned trips for their dashboard. Wishlist
+// trips aren't counted. A user without trips gets zeros and an empty location.
+type UserStats struct {
+	TotalTrips          int     `json:"total_trips"`
+	UpcomingTrips       int     `json:"upcoming_trips"`
+	PastTrips           int     `json:"past_trips"`
+	DaysTraveled        float64 `json:"days_traveled"`
+	MostVisitedLocation string  `json:"most_visited_location"`
+}
+
 type CreateUserInput struct {
 	Name     string  `json:"name" validate:"required"`
 	Email    string  `json:"email" validate:"required,email"`
@@ -26,4 +49,29 @@ type CreateUserInput struct {
 type LoginUserInput struct {
 	Email    string `json:"email" validate:"required,email"`
 	Password string `json:"password" validate:"required"`
+	// RememberMe extends the session when true and keeps it to the browser
+	// session when false. Omitted keeps the default lifetime.
+	RememberMe *bool `json:"remember_me"`
+}
+
+// UpdateUserInput holds the profile fields a user can change. Nil fields are left as they are.
+type UpdateUserInput struct {
+	Name  *string `json:"name" validate:"omitempty,min=1,max=100"`
+	Email *string `json:"email" validate:"omitempty,email,max=100"`
+}
+
+// ChangePasswordInput replaces a signed-in user's password. EndOtherSessions
+// signs out every other device once the password has changed.
+type ChangePasswordInput struct {
+	CurrentPassword  string `json:"current_password" validate:"required"`
+	NewPassword      string `json:"new_password" validate:"required,min=8,containsuppercase,containslowercase,containsnumber,containsspecialchar,nefield=CurrentPassword"`
+	EndOtherSessions bool   `json:"end_other_sessions"`
+}
+
+// VerificationStatus says whether the user's email is verified and, while it
+// isn't, when the outstanding verification code expires. The expiry is omitted
+// when there is no unexpired code.
+type VerificationStatus struct {
+	EmailVerified                bool       `json:"email_verified"`
+	PendingVerificationExpiresAt *time.Time `json:"pending_verification_expires_at,omitempty"`
 }
@@ -0,0 +1,221 @@
+package activities
+
+import (
+	"log/slog"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"black-lotus/internal/common/binding"
+	"black-lotus/internal/common/response"
+	validation "black-lotus/internal/common/validations"
+	"black-lotus/internal/domain/models"
+	"black-lotus/internal/features/auth/session"
+)
+
+type Handler struct {
+	service        ServiceInterface
+	sessionService session.ServiceInterface
+	validator      *validator.Validate
+}
+
+func NewHandler(service ServiceInterface, sessionService session.ServiceInterface) *Handler {
+	validate := validator.New()
+
+	// Report validation errors using JSON field names
+	validate.RegisterTagNameFunc(func(fld reflect.StructField) string {
+		name := strings.SplitN(fld.Tag.Get("json"), ",", 2)[0]
+		if name == "-" {
+			return ""
+		}
+		return name
+	})
+
+	return &Handler{
+		service:        service,
+		sessionService: sessionService,
+		validator:      validate,
+	}
+}
+
+// parseIDs reads the :id (trip) and, when requested, :activityId path parameters.
+// When either isn't a valid UUID the error response has already been written and ok is false.
+func parseIDs(ctx echo.Context, withActivity bool) (tripID uuid.UUID, activityID uuid.UUID, ok bool, err error) {
+	tripID, parseErr := uuid.Parse(ctx.Param("id"))
+	if parseErr != nil {
+		return uuid.Nil, uuid.Nil, false, response.ErrorResponse(ctx, http.StatusBadRequest,
+			response.CodeInvalidID, "Invalid trip ID", nil)
+	}
+
+	if withActivity {
+		activityID, parseErr = uuid.Parse(ctx.Param("activityId"))
+		if parseErr != nil {
+			return uuid.Nil, uuid.Nil, false, response.ErrorResponse(ctx, http.StatusBadRequest,
+				response.CodeInvalidID, "Invalid activity ID", nil)
+		}
+	}
+
+	return tripID, activityID, true, nil
+}
+
+// handleServiceError maps activity service errors to responses
+func handleServiceError(ctx echo.Context, err error, action string) error {
+	switch err.Error() {
+	case "trip not found":
+		return response.ErrorResponse(ctx, http.StatusNotFound,
+			response.CodeTripNotFound, "Trip not found", nil)
+	case "activity not found":
+		return response.ErrorResponse(ctx, http.StatusNotFound,
+			response.CodeActivityNotFound, "Activity not found", nil)
+	case "unauthorized access to trip":
+		return response.ErrorResponse(ctx, http.StatusForbidden,
+			response.CodeForbidden, "You do not have permission to access this trip", nil)
+	case "trip has no dates":
+		return response.ErrorResponse(ctx, http.StatusConflict,
+			response.CodeTripNotPlanned, "Add dates to this wishlist trip before adding activities", nil)
+	case "end time cannot be before start time", "activity must be within the trip dates":
+		return response.ErrorResponse(ctx, http.StatusBadRequest,
+			response.CodeInvalidDateRange, err.Error(), nil)
+	}
+
+	slog.Error("Failed to "+action, "error", err)
+	return response.ErrorResponse(ctx, http.StatusInternalServerError,
+		response.CodeInternal, "Failed to "+action, nil)
+}
+
+// CreateActivity adds an activity to a trip
+func (h *Handler) CreateActivity(ctx echo.Context) error {
+	sess, err := session.Authenticate(ctx, h.sessionService)
+	if sess == nil {
+		return err
+	}
+
+	tripID, _, ok, err := parseIDs(ctx, false)
+	if !ok {
+		return err
+	}
+
+	var input models.CreateActivityInput
+	if err := binding.Bind(ctx, &input); err != nil {
+		return response.InvalidBody(ctx, err)
+	}
+
+	if err := h.validator.Struct(input); err != nil {
+		messages := validation.MessagesFor(ctx.Request().Header.Get("Accept-Language"))
+		validationErrors, _ := err.(validator.ValidationErrors)
+		return response.ErrorResponse(ctx, http.StatusBadRequest,
+			response.CodeValidationFailed, messages.InvalidBody(), messages.Details(validationErrors))
+	}
+
+	activity, err := h.service.CreateActivity(ctx.Request().Context(), tripID, sess.UserID, input)
+	if err != nil {
+		return handleServiceError(ctx, err, "create activity")
+	}
+
+	return response.JSON(ctx, http.StatusCreated, activity)
+}
+
+// GetActivities lists a trip's activities sorted by start time
+func (h *Handler) GetActivities(ctx echo.Context) error {
+	sess, err := session.Authenticate(ctx, h.sessionService)
+	if sess == nil {
+		return err
+	}
+
+	tripID, _, ok, err := parseIDs(ctx, false)
+	if !ok {
+		return err
+	}
+
+	activities, err := h.service.GetActivitiesByTripID(ctx.Request().Context(), tripID, sess.UserID)
+	if err != nil {
+		return handleServiceError(ctx, err, "get activities")
+	}
+
+	return response.JSON(ctx, http.StatusOK, activities)
+}
+
+// GetActivity retrieves a single activity
+func (h *Handler) GetActivity(ctx echo.Context) error {
+	sess, err := session.Authenticate(ctx, h.sessionService)
+	if sess == nil {
+		return err
+	}
+
+	tripID, activityID, ok, err := parseIDs(ctx, true)
+	if !ok {
+		return err
+	}
+
+	activity, err := h.service.GetActivity(ctx.Request().Context(), tripID, activityID, sess.UserID)
+	if err != nil {
+		return handleServiceError(ctx, err, "get activity")
+	}
+
+	return response.JSON(ctx, http.StatusOK, activity)
+}
+
+// UpdateActivity updates a single activity
+func (h *Handler) UpdateActivity(ctx echo.Context) error {
+	sess, err := session.Authenticate(ctx, h.sessionService)
+	if sess == nil {
+		return err
+	}
+
+	tripID, activityID, ok, err := parseIDs(ctx, true)
+	if !ok {
+		return err
+	}
+
+	var input models.UpdateActivityInput
+	if err := binding.Bind(ctx, &input); err != nil {
+		return response.InvalidBody(ctx, err)
+	}
+
+	// Reject empty updates
+	if input.Title == nil && input.Description == nil &&
+		input.StartTime == nil && input.EndTime == nil &&
+		input.Location == nil {
+		return response.ErrorResponse(ctx, http.StatusBadRequest,
+			response.CodeInvalidRequest, "Invalid request body", nil)
+	}
+
+	if err := h.validator.Struct(input); err != nil {
+		messages := validation.MessagesFor(ctx.Request().Header.Get("Accept-Language"))
+		validationErrors, _ := err.(validator.ValidationErrors)
+		return response.ErrorResponse(ctx, http.StatusBadRequest,
+			response.CodeValidationFailed, messages.InvalidBody(), messages.Details(validationErrors))
+	}
+
+	activity, err := h.service.UpdateActivity(ctx.Request().Context(), tripID, activityID, sess.UserID, input)
+	if err != nil {
+		return handleServiceError(ctx, err, "update activity")
+	}
+
+	return response.JSON(ctx, http.StatusOK, activity)
+}
+
+// DeleteActivity removes a single activity
+func (h *Handler) DeleteActivity(ctx echo.Context) error {
+	sess, err := session.Authenticate(ctx, h.sessionService)
+	if sess == nil {
+		return err
+	}
+
+	tripID, activityID, ok, err := parseIDs(ctx, true)
+	if !ok {
+		return err
+	}
+
+	if err := h.service.DeleteActivity(ctx.Request().Context(), tripID, activityID, sess.UserID); err != nil {
+		return handleServiceError(ctx, err, "delete activity")
+	}
+
+	return response.JSON(ctx, http.StatusOK, map[string]string{
+		"message": "Activity deleted successfully",
+	})
+}
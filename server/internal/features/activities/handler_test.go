@@ -0,0 +1,431 @@
+package activities_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"black-lotus/internal/common/response"
+	"black-lotus/internal/domain/models"
+	"black-lotus/internal/features/activities"
+)
+
+// MockActivityService implements activities.ServiceInterface for testing
+type MockActivityService struct {
+	createActivityFunc        func(ctx context.Context, tripID uuid.UUID, userID uuid.UUID, input models.CreateActivityInput) (*models.Activity, error)
+	getActivityFunc           func(ctx context.Context, tripID uuid.UUID, activityID uuid.UUID, userID uuid.UUID) (*models.Activity, error)
+	getActivitiesByTripIDFunc func(ctx context.Context, tripID uuid.UUID, userID uuid.UUID) ([]*models.Activity, error)
+	updateActivityFunc        func(ctx context.Context, tripID uuid.UUID, activityID uuid.UUID, userID uuid.UUID, input models.UpdateActivityInput) (*models.Activity, error)
+	deleteActivityFunc        func(ctx context.Context, tripID uuid.UUID, activityID uuid.UUID, userID uuid.UUID) error
+}
+
+func (m *MockActivityService) CreateActivity(ctx context.Context, tripID uuid.UUID, userID uuid.UUID, input models.CreateActivityInput) (*models.Activity, error) {
+	if m.createActivityFunc != nil {
+		return m.createActivityFunc(ctx, tripID, userID, input)
+	}
+	return nil, errors.New("CreateActivity not implemented")
+}
+
+func (m *MockActivityService) GetActivity(ctx context.Context, tripID uuid.UUID, activityID uuid.UUID, userID uuid.UUID) (*models.Activity, error) {
+	if m.getActivityFunc != nil {
+		return m.getActivityFunc(ctx, tripID, activityID, userID)
+	}
+	return nil, errors.New("GetActivity not implemented")
+}
+
+func (m *MockActivityService) GetActivitiesByTripID(ctx context.Context, tripID uuid.UUID, userID uuid.UUID) ([]*models.Activity, error) {
+	if m.getActivitiesByTripIDFunc != nil {
+		return m.getActivitiesByTripIDFunc(ctx, tripID, userID)
+	}
+	return nil, errors.New("GetActivitiesByTripID not implemented")
+}
+
+func (m *MockActivityService) UpdateActivity(ctx context.Context, tripID uuid.UUID, activityID uuid.UUID, userID uuid.UUID, input models.UpdateActivityInput) (*models.Activity, error) {
+	if m.updateActivityFunc != nil {
+		return m.updateActivityFunc(ctx, tripID, activityID, userID, input)
+	}
+	return nil, errors.New("UpdateActivity not implemented")
+}
+
+func (m *MockActivityService) DeleteActivity(ctx context.Context, tripID uuid.UUID, activityID uuid.UUID, userID uuid.UUID) error {
+	if m.deleteActivityFunc != nil {
+		return m.deleteActivityFunc(ctx, tripID, activityID, userID)
+	}
+	return errors.New("DeleteActivity not implemented")
+}
+
+// MockSessionService implements session.ServiceInterface for testing
+type MockSessionService struct {
+	validateAccessTokenFunc func(ctx context.Context, token string) (*models.Session, error)
+}
+
+func (m *MockSessionService) ValidateAccessToken(ctx context.Context, token string) (*models.Session, error) {
+	if m.validateAccessTokenFunc != nil {
+		return m.validateAccessTokenFunc(ctx, token)
+	}
+	return nil, errors.New("ValidateAccessToken not implemented")
+}
+
+func (m *MockSessionService) ValidateRefreshToken(ctx context.Context, token string) (*models.Session, error) {
+	return nil, errors.New("ValidateRefreshToken not implemented")
+}
+
+func (m *MockSessionService) CreateSession(ctx context.Context, userID uuid.UUID, refreshDuration time.Duration) (*models.Session, error) {
+	return nil, errors.New("CreateSession not implemented")
+}
+
+func (m *MockSessionService) RefreshAccessToken(ctx context.Context, refreshToken string) (*models.Session, error) {
+	return nil, errors.New("RefreshAccessToken not implemented")
+}
+
+func (m *MockSessionService) EndSessionByAccessToken(ctx context.Context, token string) error {
+	return errors.New("EndSessionByAccessToken not implemented")
+}
+
+func (m *MockSessionService) EndSessionByRefreshToken(ctx context.Context, token string) error {
+	return errors.New("EndSessionByRefreshToken not implemented")
+}
+
+func (m *MockSessionService) EndAllUserSessions(ctx context.Context, userID uuid.UUID) error {
+	return errors.New("EndAllUserSessions not implemented")
+}
+
+func (m *MockSessionService) EndOtherUserSessions(ctx context.Context, userID uuid.UUID, keepSessionID uuid.UUID) error {
+	return errors.New("EndOtherUserSessions not implemented")
+}
+
+// Helper function to create a test context with the given path parameters and an access token
+func newTestContext(method, path string, body []byte, paramNames []string, paramValues []string, withToken bool) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	req := httptest.NewRequest(method, path, bytes.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	if withToken {
+		req.AddCookie(&http.Cookie{Name: "access_token", Value: "valid_access_token"})
+	}
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames(paramNames...)
+	c.SetParamValues(paramValues...)
+	return c, rec
+}
+
+// Helper function to setup handler for testing
+func setupHandlerTest(userID uuid.UUID) (*activities.Handler, *MockActivityService) {
+	mockService := &MockActivityService{}
+	mockSession := &MockSessionService{}
+
+	mockSession.validateAccessTokenFunc = func(ctx context.Context, token string) (*models.Session, error) {
+		if token == "valid_access_token" {
+			return &models.Session{
+				ID:           uuid.New(),
+				UserID:       userID,
+				AccessToken:  token,
+				AccessExpiry: time.Now().Add(15 * time.Minute),
+			}, nil
+		}
+		return nil, errors.New("invalid token")
+	}
+
+	return activities.NewHandler(mockService, mockSession), mockService
+}
+
+// Helper function to check the error code of an error response
+func checkErrorCode(t *testing.T, rec *httptest.ResponseRecorder, expectedCode string) {
+	t.Helper()
+	var envelope response.ErrorEnvelope
+	if err := json.Unmarshal(rec.Body.Bytes(), &envelope); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if envelope.Error.Code != expectedCode {
+		t.Errorf("Expected code '%s', got '%s'", expectedCode, envelope.Error.Code)
+	}
+}
+
+func TestHandlerCreateActivity(t *testing.T) {
+	testCases := []struct {
+		name           string
+		tripID         string
+		body           string
+		withToken      bool
+		serviceErr     error
+		expectedStatus int
+		expectedCode   string
+	}{
+		{
+			name:           "SuccessfulCreation",
+			tripID:         uuid.New().String(),
+			body:           `{"title": "Museum", "start_time": "2025-06-10T10:00:00Z", "end_time": "2025-06-10T12:00:00Z"}`,
+			withToken:      true,
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "NoAccessToken",
+			tripID:         uuid.New().String(),
+			body:           `{"title": "Museum", "start_time": "2025-06-10T10:00:00Z", "end_time": "2025-06-10T12:00:00Z"}`,
+			expectedStatus: http.StatusUnauthorized,
+			expectedCode:   response.CodeNotAuthenticated,
+		},
+		{
+			name:           "InvalidTripID",
+			tripID:         "not-a-uuid",
+			body:           `{"title": "Museum", "start_time": "2025-06-10T10:00:00Z", "end_time": "2025-06-10T12:00:00Z"}`,
+			withToken:      true,
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   response.CodeInvalidID,
+		},
+		{
+			name:           "MissingTitle",
+			tripID:         uuid.New().String(),
+			body:           `{"start_time": "2025-06-10T10:00:00Z", "end_time": "2025-06-10T12:00:00Z"}`,
+			withToken:      true,
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   response.CodeValidationFailed,
+		},
+		{
+			name:           "OutsideTripDates",
+			tripID:         uuid.New().String(),
+			body:           `{"title": "Museum", "start_time": "2025-07-10T10:00:00Z", "end_time": "2025-07-10T12:00:00Z"}`,
+			withToken:      true,
+			serviceErr:     errors.New("activity must be within the trip dates"),
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   response.CodeInvalidDateRange,
+		},
+		{
+			name:           "UnauthorizedAccess",
+			tripID:         uuid.New().String(),
+			body:           `{"title": "Museum", "start_time": "2025-06-10T10:00:00Z", "end_time": "2025-06-10T12:00:00Z"}`,
+			withToken:      true,
+			serviceErr:     errors.New("unauthorized access to trip"),
+			expectedStatus: http.StatusForbidden,
+			expectedCode:   response.CodeForbidden,
+		},
+		{
+			name:           "WishlistTripWithoutDates",
+			tripID:         uuid.New().String(),
+			body:           `{"title": "Museum", "start_time": "2025-06-10T10:00:00Z", "end_time": "2025-06-10T12:00:00Z"}`,
+			withToken:      true,
+			serviceErr:     errors.New("trip has no dates"),
+			expectedStatus: http.StatusConflict,
+			expectedCode:   response.CodeTripNotPlanned,
+		},
+		{
+			name:           "TripNotFound",
+			tripID:         uuid.New().String(),
+			body:           `{"title": "Museum", "start_time": "2025-06-10T10:00:00Z", "end_time": "2025-06-10T12:00:00Z"}`,
+			withToken:      true,
+			serviceErr:     errors.New("trip not found"),
+			expectedStatus: http.StatusNotFound,
+			expectedCode:   response.CodeTripNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			userID := uuid.New()
+			handler, mockService := setupHandlerTest(userID)
+
+			mockService.createActivityFunc = func(ctx context.Context, tripID uuid.UUID, uid uuid.UUID, input models.CreateActivityInput) (*models.Activity, error) {
+				if tc.serviceErr != nil {
+					return nil, tc.serviceErr
+				}
+				return &models.Activity{ID: uuid.New(), TripID: tripID, Title: input.Title}, nil
+			}
+
+			c, rec := newTestContext(http.MethodPost, "/api/trips/"+tc.tripID+"/activities", []byte(tc.body),
+				[]string{"id"}, []string{tc.tripID}, tc.withToken)
+
+			if err := handler.CreateActivity(c); err != nil {
+				t.Errorf("Expected no error, got: %v", err)
+			}
+
+			if rec.Code != tc.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tc.expectedStatus, rec.Code)
+			}
+			if tc.expectedCode != "" {
+				checkErrorCode(t, rec, tc.expectedCode)
+			}
+		})
+	}
+}
+
+func TestHandlerGetActivities(t *testing.T) {
+	userID := uuid.New()
+	handler, mockService := setupHandlerTest(userID)
+	tripID := uuid.New()
+
+	mockService.getActivitiesByTripIDFunc = func(ctx context.Context, tid uuid.UUID, uid uuid.UUID) ([]*models.Activity, error) {
+		return []*models.Activity{
+			{ID: uuid.New(), TripID: tid, Title: "Breakfast"},
+			{ID: uuid.New(), TripID: tid, Title: "Museum"},
+		}, nil
+	}
+
+	c, rec := newTestContext(http.MethodGet, "/api/trips/"+tripID.String()+"/activities", nil,
+		[]string{"id"}, []string{tripID.String()}, true)
+
+	if err := handler.GetActivities(c); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, rec.Code)
+	}
+
+	var result []*models.Activity
+	if err := json.Unmarshal(rec.Body.Bytes(), &result); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if len(result) != 2 || result[0].Title != "Breakfast" {
+		t.Errorf("Expected activities in service order, got %v", result)
+	}
+}
+
+func TestHandlerGetActivity(t *testing.T) {
+	testCases := []struct {
+		name           string
+		activityID     string
+		serviceErr     error
+		expectedStatus int
+		expectedCode   string
+	}{
+		{
+			name:           "SuccessfulRetrieval",
+			activityID:     uuid.New().String(),
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "InvalidActivityID",
+			activityID:     "not-a-uuid",
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   response.CodeInvalidID,
+		},
+		{
+			name:           "ActivityNotFound",
+			activityID:     uuid.New().String(),
+			serviceErr:     errors.New("activity not found"),
+			expectedStatus: http.StatusNotFound,
+			expectedCode:   response.CodeActivityNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			userID := uuid.New()
+			handler, mockService := setupHandlerTest(userID)
+			tripID := uuid.New().String()
+
+			mockService.getActivityFunc = func(ctx context.Context, tid uuid.UUID, aid uuid.UUID, uid uuid.UUID) (*models.Activity, error) {
+				if tc.serviceErr != nil {
+					return nil, tc.serviceErr
+				}
+				return &models.Activity{ID: aid, TripID: tid, Title: "Museum"}, nil
+			}
+
+			c, rec := newTestContext(http.MethodGet, "/api/trips/"+tripID+"/activities/"+tc.activityID, nil,
+				[]string{"id", "activityId"}, []string{tripID, tc.activityID}, true)
+
+			if err := handler.GetActivity(c); err != nil {
+				t.Errorf("Expected no error, got: %v", err)
+			}
+
+			if rec.Code != tc.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tc.expectedStatus, rec.Code)
+			}
+			if tc.expectedCode != "" {
+				checkErrorCode(t, rec, tc.expectedCode)
+			}
+		})
+	}
+}
+
+func TestHandlerUpdateActivity(t *testing.T) {
+	testCases := []struct {
+		name           string
+		body           string
+		serviceErr     error
+		expectedStatus int
+		expectedCode   string
+	}{
+		{
+			name:           "SuccessfulUpdate",
+			body:           `{"title": "Gallery"}`,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "EmptyUpdate",
+			body:           `{}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   response.CodeInvalidRequest,
+		},
+		{
+			name:           "EndBeforeStart",
+			body:           `{"end_time": "2025-06-10T08:00:00Z"}`,
+			serviceErr:     errors.New("end time cannot be before start time"),
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   response.CodeInvalidDateRange,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			userID := uuid.New()
+			handler, mockService := setupHandlerTest(userID)
+			tripID := uuid.New().String()
+			activityID := uuid.New().String()
+
+			mockService.updateActivityFunc = func(ctx context.Context, tid uuid.UUID, aid uuid.UUID, uid uuid.UUID, input models.UpdateActivityInput) (*models.Activity, error) {
+				if tc.serviceErr != nil {
+					return nil, tc.serviceErr
+				}
+				return &models.Activity{ID: aid, TripID: tid, Title: *input.Title}, nil
+			}
+
+			c, rec := newTestContext(http.MethodPut, "/api/trips/"+tripID+"/activities/"+activityID, []byte(tc.body),
+				[]string{"id", "activityId"}, []string{tripID, activityID}, true)
+
+			if err := handler.UpdateActivity(c); err != nil {
+				t.Errorf("Expected no error, got: %v", err)
+			}
+
+			if rec.Code != tc.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tc.expectedStatus, rec.Code)
+			}
+			if tc.expectedCode != "" {
+				checkErrorCode(t, rec, tc.expectedCode)
+			}
+		})
+	}
+}
+
+func TestHandlerDeleteActivity(t *testing.T) {
+	userID := uuid.New()
+	handler, mockService := setupHandlerTest(userID)
+	tripID := uuid.New().String()
+	activityID := uuid.New().String()
+
+	mockService.deleteActivityFunc = func(ctx context.Context, tid uuid.UUID, aid uuid.UUID, uid uuid.UUID) error {
+		if uid != userID {
+			return errors.New("unauthorized access to trip")
+		}
+		return nil
+	}
+
+	c, rec := newTestContext(http.MethodDelete, "/api/trips/"+tripID+"/activities/"+activityID, nil,
+		[]string{"id", "activityId"}, []string{tripID, activityID}, true)
+
+	if err := handler.DeleteActivity(c); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("Expected status %d, got %d", http.StatusOK, rec.Code)
+	}
+}
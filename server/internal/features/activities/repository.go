@@ -0,0 +1,22 @@
+package activities
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"black-lotus/internal/domain/models"
+)
+
+type Repository interface {
+	CreateActivity(ctx context.Context, tripID uuid.UUID, input models.CreateActivityInput) (*models.Activity, error)
+	GetActivityByID(ctx context.Context, activityID uuid.UUID) (*models.Activity, error)
+	GetActivitiesByTripID(ctx context.Context, tripID uuid.UUID) ([]*models.Activity, error)
+	UpdateActivity(ctx context.Context, activityID uuid.UUID, input models.UpdateActivityInput) (*models.Activity, error)
+	DeleteActivity(ctx context.Context, activityID uuid.UUID) error
+}
+
+// TripRepository defines trip operations needed by the activities feature
+type TripRepository interface {
+	GetTripByID(ctx context.Context, tripID uuid.UUID) (*models.Trip, error)
+}
@@ -0,0 +1,152 @@
+package activities
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"black-lotus/internal/domain/models"
+)
+
+type ServiceInterface interface {
+	CreateActivity(ctx context.Context, tripID uuid.UUID, userID uuid.UUID, input models.CreateActivityInput) (*models.Activity, error)
+	GetActivity(ctx context.Context, tripID uuid.UUID, activityID uuid.UUID, userID uuid.UUID) (*models.Activity, error)
+	GetActivitiesByTripID(ctx context.Context, tripID uuid.UUID, userID uuid.UUID) ([]*models.Activity, error)
+	UpdateActivity(ctx context.Context, tripID uuid.UUID, activityID uuid.UUID, userID uuid.UUID, input models.UpdateActivityInput) (*models.Activity, error)
+	DeleteActivity(ctx context.Context, tripID uuid.UUID, activityID uuid.UUID, userID uuid.UUID) error
+}
+
+type Service struct {
+	repo     Repository
+	tripRepo TripRepository
+}
+
+func NewService(repo Repository, tripRepo TripRepository) *Service {
+	return &Service{repo: repo, tripRepo: tripRepo}
+}
+
+// CreateActivity adds an activity to a trip the user owns. Activities happen
+// at set times, so a wishlist trip needs dates before it can have any.
+func (s *Service) CreateActivity(ctx context.Context, tripID uuid.UUID, userID uuid.UUID, input models.CreateActivityInput) (*models.Activity, error) {
+	trip, err := s.getOwnedTrip(ctx, tripID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if trip.StartDate == nil || trip.EndDate == nil {
+		return nil, errors.New("trip has no dates")
+	}
+
+	if err := validateActivityTimes(trip, input.StartTime, input.EndTime); err != nil {
+		return nil, err
+	}
+
+	return s.repo.CreateActivity(ctx, tripID, input)
+}
+
+// GetActivity retrieves a single activity of a trip the user owns
+func (s *Service) GetActivity(ctx context.Context, tripID uuid.UUID, activityID uuid.UUID, userID uuid.UUID) (*models.Activity, error) {
+	if _, err := s.getOwnedTrip(ctx, tripID, userID); err != nil {
+		return nil, err
+	}
+
+	return s.getTripActivity(ctx, tripID, activityID)
+}
+
+// GetActivitiesByTripID lists a trip's activities ordered by start time
+func (s *Service) GetActivitiesByTripID(ctx context.Context, tripID uuid.UUID, userID uuid.UUID) ([]*models.Activity, error) {
+	if _, err := s.getOwnedTrip(ctx, tripID, userID); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetActivitiesByTripID(ctx, tripID)
+}
+
+// UpdateActivity updates an activity, keeping it within the trip's dates
+func (s *Service) UpdateActivity(ctx context.Context, tripID uuid.UUID, activityID uuid.UUID, userID uuid.UUID, input models.UpdateActivityInput) (*models.Activity, error) {
+	trip, err := s.getOwnedTrip(ctx, tripID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	activity, err := s.getTripActivity(ctx, tripID, activityID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate the times the activity will have after the update
+	startTime := activity.StartTime
+	if input.StartTime != nil {
+		startTime = *input.StartTime
+	}
+	endTime := activity.EndTime
+	if input.EndTime != nil {
+		endTime = *input.EndTime
+	}
+
+	if err := validateActivityTimes(trip, startTime, endTime); err != nil {
+		return nil, err
+	}
+
+	return s.repo.UpdateActivity(ctx, activityID, input)
+}
+
+// DeleteActivity removes an activity from a trip the user owns
+func (s *Service) DeleteActivity(ctx context.Context, tripID uuid.UUID, activityID uuid.UUID, userID uuid.UUID) error {
+	if _, err := s.getOwnedTrip(ctx, tripID, userID); err != nil {
+		return err
+	}
+
+	if _, err := s.getTripActivity(ctx, tripID, activityID); err != nil {
+		return err
+	}
+
+	return s.repo.DeleteActivity(ctx, activityID)
+}
+
+// getOwnedTrip loads the parent trip and verifies the user owns it
+func (s *Service) getOwnedTrip(ctx context.Context, tripID uuid.UUID, userID uuid.UUID) (*models.Trip, error) {
+	trip, err := s.tripRepo.GetTripByID(ctx, tripID)
+	if err != nil {
+		return nil, err
+	}
+
+	if trip.UserID != userID {
+		return nil, errors.New("unauthorized access to trip")
+	}
+
+	return trip, nil
+}
+
+// getTripActivity loads an activity and makes sure it belongs to the trip in the URL
+func (s *Service) getTripActivity(ctx context.Context, tripID uuid.UUID, activityID uuid.UUID) (*models.Activity, error) {
+	activity, err := s.repo.GetActivityByID(ctx, activityID)
+	if err != nil {
+		return nil, err
+	}
+
+	if activity.TripID != tripID {
+		return nil, errors.New("activity not found")
+	}
+
+	return activity, nil
+}
+
+func validateActivityTimes(trip *models.Trip, startTime, endTime time.Time) error {
+	if endTime.Before(startTime) {
+		return errors.New("end time cannot be before start time")
+	}
+
+	// Older wishlist trips may already have activities but no dates to check them against
+	if trip.StartDate == nil || trip.EndDate == nil {
+		return nil
+	}
+
+	if !models.WithinTripDates(*trip.StartDate, *trip.EndDate, startTime, endTime) {
+		return errors.New("activity must be within the trip dates")
+	}
+
+	return nil
+}
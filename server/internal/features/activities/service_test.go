@@ -0,0 +1,393 @@
+package activities_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"black-lotus/internal/domain/models"
+	"black-lotus/internal/features/activities"
+)
+
+// MockActivityRepository implements activities.Repository for testing
+type MockActivityRepository struct {
+	createActivityFunc        func(ctx context.Context, tripID uuid.UUID, input models.CreateActivityInput) (*models.Activity, error)
+	getActivityByIDFunc       func(ctx context.Context, activityID uuid.UUID) (*models.Activity, error)
+	getActivitiesByTripIDFunc func(ctx context.Context, tripID uuid.UUID) ([]*models.Activity, error)
+	updateActivityFunc        func(ctx context.Context, activityID uuid.UUID, input models.UpdateActivityInput) (*models.Activity, error)
+	deleteActivityFunc        func(ctx context.Context, activityID uuid.UUID) error
+}
+
+func (m *MockActivityRepository) CreateActivity(ctx context.Context, tripID uuid.UUID, input models.CreateActivityInput) (*models.Activity, error) {
+	if m.createActivityFunc != nil {
+		return m.createActivityFunc(ctx, tripID, input)
+	}
+	return nil, errors.New("CreateActivity not implemented")
+}
+
+func (m *MockActivityRepository) GetActivityByID(ctx context.Context, activityID uuid.UUID) (*models.Activity, error) {
+	if m.getActivityByIDFunc != nil {
+		return m.getActivityByIDFunc(ctx, activityID)
+	}
+	return nil, errors.New("GetActivityByID not implemented")
+}
+
+func (m *MockActivityRepository) GetActivitiesByTripID(ctx context.Context, tripID uuid.UUID) ([]*models.Activity, error) {
+	if m.getActivitiesByTripIDFunc != nil {
+		return m.getActivitiesByTripIDFunc(ctx, tripID)
+	}
+	return nil, errors.New("GetActivitiesByTripID not implemented")
+}
+
+func (m *MockActivityRepository) UpdateActivity(ctx context.Context, activityID uuid.UUID, input models.UpdateActivityInput) (*models.Activity, error) {
+	if m.updateActivityFunc != nil {
+		return m.updateActivityFunc(ctx, activityID, input)
+	}
+	return nil, errors.New("UpdateActivity not implemented")
+}
+
+func (m *MockActivityRepository) DeleteActivity(ctx context.Context, activityID uuid.UUID) error {
+	if m.deleteActivityFunc != nil {
+		return m.deleteActivityFunc(ctx, activityID)
+	}
+	return errors.New("DeleteActivity not implemented")
+}
+
+// MockTripRepository implements activities.TripRepository for testing
+type MockTripRepository struct {
+	getTripByIDFunc func(ctx context.Context, tripID uuid.UUID) (*models.Trip, error)
+}
+
+func (m *MockTripRepository) GetTripByID(ctx context.Context, tripID uuid.UUID) (*models.Trip, error) {
+	if m.getTripByIDFunc != nil {
+		return m.getTripByIDFunc(ctx, tripID)
+	}
+	return nil, errors.New("GetTripByID not implemented")
+}
+
+// Trip dates used across the service tests: June 10th - June 15th
+var (
+	tripStart = time.Date(2025, 6, 10, 0, 0, 0, 0, time.UTC)
+	tripEnd   = time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+)
+
+// Helper function to setup service for testing
+func setupServiceTest(owner uuid.UUID) (*activities.Service, *MockActivityRepository, *MockTripRepository) {
+	mockRepo := &MockActivityRepository{}
+	mockTripRepo := &MockTripRepository{}
+
+	mockTripRepo.getTripByIDFunc = func(ctx context.Context, tripID uuid.UUID) (*models.Trip, error) {
+		return &models.Trip{
+			ID:        tripID,
+			UserID:    owner,
+			Name:      "Test Trip",
+			StartDate: &tripStart,
+			EndDate:   &tripEnd,
+		}, nil
+	}
+
+	mockRepo.createActivityFunc = func(ctx context.Context, tripID uuid.UUID, input models.CreateActivityInput) (*models.Activity, error) {
+		return &models.Activity{
+			ID:        uuid.New(),
+			TripID:    tripID,
+			Title:     input.Title,
+			StartTime: input.StartTime,
+			EndTime:   input.EndTime,
+		}, nil
+	}
+
+	return activities.NewService(mockRepo, mockTripRepo), mockRepo, mockTripRepo
+}
+
+func TestServiceCreateActivity(t *testing.T) {
+	userID := uuid.New()
+	tripID := uuid.New()
+
+	testCases := []struct {
+		name          string
+		userID        uuid.UUID
+		input         models.CreateActivityInput
+		expectedError string
+	}{
+		{
+			name:   "SuccessfulCreation",
+			userID: userID,
+			input: models.CreateActivityInput{
+				Title:     "Museum",
+				StartTime: tripStart.Add(10 * time.Hour),
+				EndTime:   tripStart.Add(12 * time.Hour),
+			},
+		},
+		{
+			name:   "LastDayOfTrip",
+			userID: userID,
+			input: models.CreateActivityInput{
+				Title:     "Farewell Dinner",
+				StartTime: tripEnd.Add(19 * time.Hour),
+				EndTime:   tripEnd.Add(22 * time.Hour),
+			},
+		},
+		{
+			name:   "EndBeforeStart",
+			userID: userID,
+			input: models.CreateActivityInput{
+				Title:     "Museum",
+				StartTime: tripStart.Add(12 * time.Hour),
+				EndTime:   tripStart.Add(10 * time.Hour),
+			},
+			expectedError: "end time cannot be before start time",
+		},
+		{
+			name:   "BeforeTrip",
+			userID: userID,
+			input: models.CreateActivityInput{
+				Title:     "Museum",
+				StartTime: tripStart.Add(-2 * time.Hour),
+				EndTime:   tripStart.Add(2 * time.Hour),
+			},
+			expectedError: "activity must be within the trip dates",
+		},
+		{
+			name:   "AfterTrip",
+			userID: userID,
+			input: models.CreateActivityInput{
+				Title:     "Museum",
+				StartTime: tripEnd.Add(22 * time.Hour),
+				EndTime:   tripEnd.Add(26 * time.Hour),
+			},
+			expectedError: "activity must be within the trip dates",
+		},
+		{
+			name:   "UnauthorizedAccess",
+			userID: uuid.New(),
+			input: models.CreateActivityInput{
+				Title:     "Museum",
+				StartTime: tripStart.Add(10 * time.Hour),
+				EndTime:   tripStart.Add(12 * time.Hour),
+			},
+			expectedError: "unauthorized access to trip",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			service, _, _ := setupServiceTest(userID)
+
+			activity, err := service.CreateActivity(context.Background(), tripID, tc.userID, tc.input)
+
+			if tc.expectedError != "" {
+				if err == nil || err.Error() != tc.expectedError {
+					t.Fatalf("Expected error '%s', got %v", tc.expectedError, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if activity.TripID != tripID {
+				t.Errorf("Expected activity for trip %s, got %s", tripID, activity.TripID)
+			}
+		})
+	}
+}
+
+func TestServiceCreateActivityOnWishlistTrip(t *testing.T) {
+	// Setup
+	userID := uuid.New()
+	tripID := uuid.New()
+	service, _, mockTripRepo := setupServiceTest(userID)
+
+	trip := &models.Trip{ID: tripID, UserID: userID, Name: "Someday", IsWishlist: true}
+	mockTripRepo.getTripByIDFunc = func(ctx context.Context, id uuid.UUID) (*models.Trip, error) {
+		return trip, nil
+	}
+
+	input := models.CreateActivityInput{
+		Title:     "Museum",
+		StartTime: tripStart.Add(10 * time.Hour),
+		EndTime:   tripStart.Add(12 * time.Hour),
+	}
+
+	// Execute
+	_, err := service.CreateActivity(context.Background(), tripID, userID, input)
+
+	// Verify
+	if err == nil || err.Error() != "trip has no dates" {
+		t.Fatalf("Expected 'trip has no dates', got %v", err)
+	}
+
+	// Planning the trip gives it the dates activities need
+	trip.StartDate, trip.EndDate, trip.IsWishlist = &tripStart, &tripEnd, false
+
+	activity, err := service.CreateActivity(context.Background(), tripID, userID, input)
+	if err != nil {
+		t.Fatalf("Expected no error after planning, got: %v", err)
+	}
+	if activity.TripID != tripID {
+		t.Errorf("Expected activity for trip %s, got %s", tripID, activity.TripID)
+	}
+}
+
+func TestServiceGetActivity(t *testing.T) {
+	userID := uuid.New()
+	tripID := uuid.New()
+	activityID := uuid.New()
+
+	testCases := []struct {
+		name          string
+		activityTrip  uuid.UUID
+		expectedError string
+	}{
+		{
+			name:         "SuccessfulRetrieval",
+			activityTrip: tripID,
+		},
+		{
+			name:          "ActivityOfAnotherTrip",
+			activityTrip:  uuid.New(),
+			expectedError: "activity not found",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			service, mockRepo, _ := setupServiceTest(userID)
+
+			mockRepo.getActivityByIDFunc = func(ctx context.Context, id uuid.UUID) (*models.Activity, error) {
+				return &models.Activity{ID: id, TripID: tc.activityTrip}, nil
+			}
+
+			activity, err := service.GetActivity(context.Background(), tripID, activityID, userID)
+
+			if tc.expectedError != "" {
+				if err == nil || err.Error() != tc.expectedError {
+					t.Fatalf("Expected error '%s', got %v", tc.expectedError, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if activity.ID != activityID {
+				t.Errorf("Expected activity %s, got %s", activityID, activity.ID)
+			}
+		})
+	}
+}
+
+func TestServiceUpdateActivity(t *testing.T) {
+	userID := uuid.New()
+	tripID := uuid.New()
+	activityID := uuid.New()
+
+	lateStart := tripEnd.Add(30 * time.Hour)
+	lateEnd := tripEnd.Add(32 * time.Hour)
+	earlyEnd := tripStart.Add(9 * time.Hour)
+	newTitle := "Updated"
+
+	testCases := []struct {
+		name          string
+		input         models.UpdateActivityInput
+		expectedError string
+	}{
+		{
+			name:  "SuccessfulUpdate",
+			input: models.UpdateActivityInput{Title: &newTitle},
+		},
+		{
+			name:          "MovedOutsideTrip",
+			input:         models.UpdateActivityInput{StartTime: &lateStart, EndTime: &lateEnd},
+			expectedError: "activity must be within the trip dates",
+		},
+		{
+			// Only the end changes, so it is compared with the stored start
+			name:          "EndMovedBeforeStoredStart",
+			input:         models.UpdateActivityInput{EndTime: &earlyEnd},
+			expectedError: "end time cannot be before start time",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			service, mockRepo, _ := setupServiceTest(userID)
+
+			mockRepo.getActivityByIDFunc = func(ctx context.Context, id uuid.UUID) (*models.Activity, error) {
+				return &models.Activity{
+					ID:        id,
+					TripID:    tripID,
+					Title:     "Museum",
+					StartTime: tripStart.Add(10 * time.Hour),
+					EndTime:   tripStart.Add(12 * time.Hour),
+				}, nil
+			}
+
+			updated := false
+			mockRepo.updateActivityFunc = func(ctx context.Context, id uuid.UUID, input models.UpdateActivityInput) (*models.Activity, error) {
+				updated = true
+				return &models.Activity{ID: id, TripID: tripID, Title: *input.Title}, nil
+			}
+
+			_, err := service.UpdateActivity(context.Background(), tripID, activityID, userID, tc.input)
+
+			if tc.expectedError != "" {
+				if err == nil || err.Error() != tc.expectedError {
+					t.Fatalf("Expected error '%s', got %v", tc.expectedError, err)
+				}
+				if updated {
+					t.Error("Expected the repository not to be called")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if !updated {
+				t.Error("Expected the repository to be called")
+			}
+		})
+	}
+}
+
+func TestServiceDeleteActivity(t *testing.T) {
+	userID := uuid.New()
+	tripID := uuid.New()
+
+	t.Run("SuccessfulDeletion", func(t *testing.T) {
+		service, mockRepo, _ := setupServiceTest(userID)
+
+		mockRepo.getActivityByIDFunc = func(ctx context.Context, id uuid.UUID) (*models.Activity, error) {
+			return &models.Activity{ID: id, TripID: tripID}, nil
+		}
+		deleted := false
+		mockRepo.deleteActivityFunc = func(ctx context.Context, id uuid.UUID) error {
+			deleted = true
+			return nil
+		}
+
+		if err := service.DeleteActivity(context.Background(), tripID, uuid.New(), userID); err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if !deleted {
+			t.Error("Expected the activity to be deleted")
+		}
+	})
+
+	t.Run("TripNotFound", func(t *testing.T) {
+		service, _, mockTripRepo := setupServiceTest(userID)
+
+		mockTripRepo.getTripByIDFunc = func(ctx context.Context, id uuid.UUID) (*models.Trip, error) {
+			return nil, errors.New("trip not found")
+		}
+
+		err := service.DeleteActivity(context.Background(), tripID, uuid.New(), userID)
+		if err == nil || err.Error() != "trip not found" {
+			t.Fatalf("Expected error 'trip not found', got %v", err)
+		}
+	})
+}
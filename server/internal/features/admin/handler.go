@@ -0,0 +1,106 @@
+package admin
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"black-lotus/internal/common/binding"
+	"black-lotus/internal/common/pagination"
+	"black-lotus/internal/common/response"
+	validation "black-lotus/internal/common/validations"
+	"black-lotus/internal/domain/models"
+)
+
+// Handler serves the admin routes. They sit behind AuthMiddleware.Authenticate
+// and RequireRole, so every request here comes from an admin.
+type Handler struct {
+	service   ServiceInterface
+	validator *validator.Validate
+	pages     pagination.Config
+}
+
+func NewHandler(service ServiceInterface, validator *validator.Validate) *Handler {
+	return &Handler{
+		service:   service,
+		validator: validator,
+		pages:     pagination.ConfigFromEnv(),
+	}
+}
+
+// GetUserTrips lists the trips of the user given by the user_id query parameter
+func (h *Handler) GetUserTrips(ctx echo.Context) error {
+	userID, err := uuid.Parse(ctx.QueryParam("user_id"))
+	if err != nil {
+		return response.ErrorResponse(ctx, http.StatusBadRequest,
+			response.CodeInvalidID, "user_id must be a valid user ID", nil)
+	}
+
+	limit, offset, err := h.pages.Parse(ctx)
+	if err != nil {
+		return response.ErrorResponse(ctx, http.StatusBadRequest,
+			response.CodeInvalidRequest, err.Error(), nil)
+	}
+
+	trips, err := h.service.GetUserTrips(ctx.Request().Context(), userID, limit, offset)
+	if err != nil {
+		slog.Error("Failed to list trips for admin", "user_id", userID, "error", err)
+		return response.ErrorResponse(ctx, http.StatusInternalServerError,
+			response.CodeInternal, "Failed to get trips", nil)
+	}
+
+	return response.JSON(ctx, http.StatusOK, trips)
+}
+
+// SetUserRole promotes a user to admin or demotes them back to a user
+func (h *Handler) SetUserRole(ctx echo.Context) error {
+	admin, ok := ctx.Get("user").(*models.User)
+	if !ok {
+		return response.ErrorResponse(ctx, http.StatusUnauthorized,
+			response.CodeNotAuthenticated, "Not authenticated", nil)
+	}
+
+	userID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return response.ErrorResponse(ctx, http.StatusBadRequest,
+			response.CodeInvalidID, "Invalid user ID format", nil)
+	}
+
+	var input models.UpdateUserRoleInput
+	if err := binding.Bind(ctx, &input); err != nil {
+		return response.InvalidBody(ctx, err)
+	}
+
+	if err := h.validator.Struct(input); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			messages := validation.MessagesFor(ctx.Request().Header.Get("Accept-Language"))
+			return response.ErrorResponse(ctx, http.StatusBadRequest,
+				response.CodeValidationFailed, messages.Failed(), messages.Details(validationErrors))
+		}
+
+		return response.ErrorResponse(ctx, http.StatusBadRequest,
+			response.CodeValidationFailed, err.Error(), nil)
+	}
+
+	user, err := h.service.SetUserRole(ctx.Request().Context(), admin.ID, userID, input.Role)
+	if err != nil {
+		switch err.Error() {
+		case "cannot change own role":
+			return response.ErrorResponse(ctx, http.StatusForbidden,
+				response.CodeForbidden, "Admins cannot change their own role", nil)
+		case "user not found":
+			return response.ErrorResponse(ctx, http.StatusNotFound,
+				response.CodeUserNotFound, "User not found", nil)
+		}
+
+		slog.Error("Failed to set user role", "user_id", userID, "error", err)
+		return response.ErrorResponse(ctx, http.StatusInternalServerError,
+			response.CodeInternal, "Failed to set user role", nil)
+	}
+
+	slog.Info("User role changed", "admin_id", admin.ID, "user_id", userID, "role", user.Role)
+	return response.JSON(ctx, http.StatusOK, user)
+}
@@ -0,0 +1,170 @@
+package admin_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"black-lotus/internal/domain/models"
+	"black-lotus/internal/features/admin"
+)
+
+// MockAdminService implements admin.ServiceInterface for testing
+type MockAdminService struct {
+	getUserTripsFunc func(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*models.Trip, error)
+	setUserRoleFunc  func(ctx context.Context, adminID uuid.UUID, userID uuid.UUID, role string) (*models.User, error)
+}
+
+func (m *MockAdminService) GetUserTrips(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*models.Trip, error) {
+	if m.getUserTripsFunc != nil {
+		return m.getUserTripsFunc(ctx, userID, limit, offset)
+	}
+	return nil, errors.New("GetUserTrips not implemented")
+}
+
+func (m *MockAdminService) SetUserRole(ctx context.Context, adminID uuid.UUID, userID uuid.UUID, role string) (*models.User, error) {
+	if m.setUserRoleFunc != nil {
+		return m.setUserRoleFunc(ctx, adminID, userID, role)
+	}
+	return nil, errors.New("SetUserRole not implemented")
+}
+
+// newAdminContext builds a request as it arrives after the auth middleware,
+// with the signed-in admin in the context
+func newAdminContext(method, path string, body []byte, adminUser *models.User) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	req := httptest.NewRequest(method, path, bytes.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user", adminUser)
+	return c, rec
+}
+
+func TestHandlerGetUserTrips(t *testing.T) {
+	userID := uuid.New()
+
+	testCases := []struct {
+		name           string
+		query          string
+		serviceErr     error
+		expectedStatus int
+		expectedLimit  int
+	}{
+		{name: "Success", query: "?user_id=" + userID.String(), expectedStatus: http.StatusOK, expectedLimit: 10},
+		{name: "LimitClamped", query: "?user_id=" + userID.String() + "&limit=1000", expectedStatus: http.StatusOK, expectedLimit: 100},
+		{name: "MissingUserID", query: "", expectedStatus: http.StatusBadRequest},
+		{name: "InvalidUserID", query: "?user_id=nope", expectedStatus: http.StatusBadRequest},
+		{name: "NonNumericLimit", query: "?user_id=" + userID.String() + "&limit=abc", expectedStatus: http.StatusBadRequest},
+		{name: "NegativeOffset", query: "?user_id=" + userID.String() + "&offset=-1", expectedStatus: http.StatusBadRequest},
+		{name: "ServiceError", query: "?user_id=" + userID.String(), serviceErr: errors.New("database error"), expectedStatus: http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			t.Setenv("DEFAULT_PAGE_SIZE", "")
+			t.Setenv("MAX_PAGE_SIZE", "")
+			mockService := &MockAdminService{}
+			handler := admin.NewHandler(mockService, validator.New())
+
+			var receivedUser uuid.UUID
+			var receivedLimit int
+			mockService.getUserTripsFunc = func(ctx context.Context, uid uuid.UUID, limit, offset int) ([]*models.Trip, error) {
+				receivedUser, receivedLimit = uid, limit
+				if tc.serviceErr != nil {
+					return nil, tc.serviceErr
+				}
+				return []*models.Trip{{ID: uuid.New(), UserID: uid, Location: "Lisbon"}}, nil
+			}
+
+			adminUser := &models.User{ID: uuid.New(), Role: models.UserRoleAdmin}
+			c, rec := newAdminContext(http.MethodGet, "/api/admin/trips"+tc.query, nil, adminUser)
+
+			// Execute
+			if err := handler.GetUserTrips(c); err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			// Verify
+			if rec.Code != tc.expectedStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tc.expectedStatus, rec.Code, rec.Body.String())
+			}
+			if tc.expectedStatus != http.StatusOK {
+				return
+			}
+			if receivedUser != userID || receivedLimit != tc.expectedLimit {
+				t.Errorf("Expected trips of %s with limit %d, got %s with limit %d", userID, tc.expectedLimit, receivedUser, receivedLimit)
+			}
+
+			var trips []*models.Trip
+			if err := json.Unmarshal(rec.Body.Bytes(), &trips); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+			if len(trips) != 1 || trips[0].UserID != userID {
+				t.Errorf("Expected the user's trip, got %+v", trips)
+			}
+		})
+	}
+}
+
+func TestHandlerSetUserRole(t *testing.T) {
+	adminUser := &models.User{ID: uuid.New(), Role: models.UserRoleAdmin}
+	userID := uuid.New()
+
+	testCases := []struct {
+		name           string
+		userID         string
+		body           string
+		serviceErr     error
+		expectedStatus int
+	}{
+		{name: "Promote", userID: userID.String(), body: `{"role": "admin"}`, expectedStatus: http.StatusOK},
+		{name: "Demote", userID: userID.String(), body: `{"role": "user"}`, expectedStatus: http.StatusOK},
+		{name: "UnknownRole", userID: userID.String(), body: `{"role": "superuser"}`, expectedStatus: http.StatusBadRequest},
+		{name: "MissingRole", userID: userID.String(), body: `{}`, expectedStatus: http.StatusBadRequest},
+		{name: "InvalidUserID", userID: "nope", body: `{"role": "admin"}`, expectedStatus: http.StatusBadRequest},
+		{name: "OwnRole", userID: adminUser.ID.String(), body: `{"role": "user"}`, serviceErr: errors.New("cannot change own role"), expectedStatus: http.StatusForbidden},
+		{name: "UserNotFound", userID: userID.String(), body: `{"role": "admin"}`, serviceErr: errors.New("user not found"), expectedStatus: http.StatusNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			mockService := &MockAdminService{}
+			handler := admin.NewHandler(mockService, validator.New())
+
+			mockService.setUserRoleFunc = func(ctx context.Context, adminID uuid.UUID, uid uuid.UUID, role string) (*models.User, error) {
+				if adminID != adminUser.ID {
+					t.Errorf("Expected the change to be made by %s, got %s", adminUser.ID, adminID)
+				}
+				if tc.serviceErr != nil {
+					return nil, tc.serviceErr
+				}
+				return &models.User{ID: uid, Role: role}, nil
+			}
+
+			c, rec := newAdminContext(http.MethodPut, "/api/admin/users/"+tc.userID+"/role", []byte(tc.body), adminUser)
+			c.SetParamNames("id")
+			c.SetParamValues(tc.userID)
+
+			// Execute
+			if err := handler.SetUserRole(c); err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			// Verify
+			if rec.Code != tc.expectedStatus {
+				t.Errorf("Expected status %d, got %d: %s", tc.expectedStatus, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
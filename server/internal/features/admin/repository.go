@@ -0,0 +1,19 @@
+package admin
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"black-lotus/internal/domain/models"
+)
+
+// UserRepository defines user operations needed by the admin feature
+type UserRepository interface {
+	SetUserRole(ctx context.Context, userID uuid.UUID, role string) (*models.User, error)
+}
+
+// TripRepository defines trip operations needed by the admin feature
+type TripRepository interface {
+	GetTripsByUserID(ctx context.Context, userID uuid.UUID, limit, offset int, filter models.TripFilter) ([]*models.Trip, error)
+}
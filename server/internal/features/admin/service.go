@@ -0,0 +1,52 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"black-lotus/internal/domain/models"
+)
+
+type ServiceInterface interface {
+	GetUserTrips(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*models.Trip, error)
+	SetUserRole(ctx context.Context, adminID uuid.UUID, userID uuid.UUID, role string) (*models.User, error)
+}
+
+type Service struct {
+	userRepo UserRepository
+	tripRepo TripRepository
+}
+
+func NewService(userRepo UserRepository, tripRepo TripRepository) *Service {
+	return &Service{userRepo: userRepo, tripRepo: tripRepo}
+}
+
+// GetUserTrips lists any user's active trips, newest start date first, for
+// support. An unknown user simply has no trips.
+func (s *Service) GetUserTrips(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*models.Trip, error) {
+	trips, err := s.tripRepo.GetTripsByUserID(ctx, userID, limit, offset, models.TripFilter{})
+	if err != nil {
+		return nil, err
+	}
+
+	// Status is computed per request and never stored
+	now := time.Now()
+	for _, trip := range trips {
+		trip.Status = models.TripStatusAt(trip.StartDate, trip.EndDate, now)
+	}
+
+	return trips, nil
+}
+
+// SetUserRole changes another user's role. Admins can't change their own, so
+// the last admin can't demote themselves and leave nobody to promote others.
+func (s *Service) SetUserRole(ctx context.Context, adminID uuid.UUID, userID uuid.UUID, role string) (*models.User, error) {
+	if adminID == userID {
+		return nil, errors.New("cannot change own role")
+	}
+
+	return s.userRepo.SetUserRole(ctx, userID, role)
+}
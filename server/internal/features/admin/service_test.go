@@ -0,0 +1,105 @@
+package admin_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"black-lotus/internal/domain/models"
+	"black-lotus/internal/features/admin"
+)
+
+// MockUserRepository implements admin.UserRepository for testing
+type MockUserRepository struct {
+	setUserRoleFunc func(ctx context.Context, userID uuid.UUID, role string) (*models.User, error)
+}
+
+func (m *MockUserRepository) SetUserRole(ctx context.Context, userID uuid.UUID, role string) (*models.User, error) {
+	if m.setUserRoleFunc != nil {
+		return m.setUserRoleFunc(ctx, userID, role)
+	}
+	return nil, errors.New("SetUserRole not implemented")
+}
+
+// MockTripRepository implements admin.TripRepository for testing
+type MockTripRepository struct {
+	getTripsByUserIDFunc func(ctx context.Context, userID uuid.UUID, limit, offset int, filter models.TripFilter) ([]*models.Trip, error)
+}
+
+func (m *MockTripRepository) GetTripsByUserID(ctx context.Context, userID uuid.UUID, limit, offset int, filter models.TripFilter) ([]*models.Trip, error) {
+	if m.getTripsByUserIDFunc != nil {
+		return m.getTripsByUserIDFunc(ctx, userID, limit, offset, filter)
+	}
+	return nil, errors.New("GetTripsByUserID not implemented")
+}
+
+func TestServiceGetUserTrips(t *testing.T) {
+	// Setup
+	userRepo, tripRepo := &MockUserRepository{}, &MockTripRepository{}
+	service := admin.NewService(userRepo, tripRepo)
+	userID := uuid.New()
+	start, end := time.Now().AddDate(0, 0, -10), time.Now().AddDate(0, 0, -5)
+
+	tripRepo.getTripsByUserIDFunc = func(ctx context.Context, uid uuid.UUID, limit, offset int, filter models.TripFilter) ([]*models.Trip, error) {
+		if uid != userID || limit != 10 || offset != 20 {
+			t.Errorf("Expected trips of %s with limit 10 and offset 20, got %s, %d, %d", userID, uid, limit, offset)
+		}
+		return []*models.Trip{{ID: uuid.New(), UserID: uid, StartDate: &start, EndDate: &end}}, nil
+	}
+
+	// Execute
+	trips, err := service.GetUserTrips(context.Background(), userID, 10, 20)
+
+	// Verify
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(trips) != 1 || trips[0].Status != models.TripStatusPast {
+		t.Errorf("Expected one past trip, got %+v", trips)
+	}
+}
+
+func TestServiceSetUserRole(t *testing.T) {
+	adminID := uuid.New()
+
+	testCases := []struct {
+		name          string
+		userID        uuid.UUID
+		expectedError string
+	}{
+		{name: "AnotherUser", userID: uuid.New()},
+		{name: "OwnRole", userID: adminID, expectedError: "cannot change own role"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			userRepo := &MockUserRepository{}
+			service := admin.NewService(userRepo, &MockTripRepository{})
+
+			userRepo.setUserRoleFunc = func(ctx context.Context, userID uuid.UUID, role string) (*models.User, error) {
+				return &models.User{ID: userID, Role: role}, nil
+			}
+
+			// Execute
+			user, err := service.SetUserRole(context.Background(), adminID, tc.userID, models.UserRoleAdmin)
+
+			// Verify
+			if tc.expectedError != "" {
+				if err == nil || err.Error() != tc.expectedError {
+					t.Fatalf("Expected error '%s', got %v", tc.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if user.Role != models.UserRoleAdmin {
+				t.Errorf("Expected role %q, got %q", models.UserRoleAdmin, user.Role)
+			}
+		})
+	}
+}
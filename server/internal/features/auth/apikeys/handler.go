@@ -0,0 +1,130 @@
+package apikeys
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"black-lotus/internal/common/binding"
+	"black-lotus/internal/common/response"
+	validation "black-lotus/internal/common/validations"
+	"black-lotus/internal/domain/models"
+	"black-lotus/internal/features/auth/session"
+)
+
+// Handler serves the API key routes. They sit behind AuthMiddleware.Authenticate,
+// which puts the signed-in user on the context.
+type Handler struct {
+	service   ServiceInterface
+	validator *validator.Validate
+}
+
+func NewHandler(service ServiceInterface, validator *validator.Validate) *Handler {
+	return &Handler{service: service, validator: validator}
+}
+
+// currentUser returns the signed-in user. Keys can only be managed from a
+// browser session, so a leaked key can't be used to mint more keys.
+func currentUser(ctx echo.Context) (*models.User, error) {
+	user, ok := ctx.Get("user").(*models.User)
+	if !ok {
+		return nil, response.ErrorResponse(ctx, http.StatusUnauthorized,
+			response.CodeNotAuthenticated, "Not authenticated", nil)
+	}
+
+	if _, usingKey := ctx.Get(session.APIKeyContextKey).(*models.APIKey); usingKey {
+		return nil, response.ErrorResponse(ctx, http.StatusForbidden,
+			response.CodeForbidden, "API keys can only be managed when signed in, not with an API key", nil)
+	}
+
+	return user, nil
+}
+
+// CreateAPIKey issues a new key and returns it in full, for the only time
+func (h *Handler) CreateAPIKey(ctx echo.Context) error {
+	user, err := currentUser(ctx)
+	if user == nil {
+		return err
+	}
+
+	var input models.CreateAPIKeyInput
+	if err := binding.Bind(ctx, &input); err != nil {
+		return response.InvalidBody(ctx, err)
+	}
+
+	if err := h.validator.Struct(input); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			messages := validation.MessagesFor(ctx.Request().Header.Get("Accept-Language"))
+			return response.ErrorResponse(ctx, http.StatusBadRequest,
+				response.CodeValidationFailed, messages.Failed(), messages.Details(validationErrors))
+		}
+
+		return response.ErrorResponse(ctx, http.StatusBadRequest,
+			response.CodeValidationFailed, err.Error(), nil)
+	}
+
+	key, err := h.service.CreateAPIKey(ctx.Request().Context(), user.ID, input)
+	if err != nil {
+		if err.Error() == "expiry must be in the future" {
+			return response.ErrorResponse(ctx, http.StatusBadRequest,
+				response.CodeValidationFailed, "Expiry must be in the future", nil)
+		}
+
+		slog.Error("Failed to create API key", "user_id", user.ID, "error", err)
+		return response.ErrorResponse(ctx, http.StatusInternalServerError,
+			response.CodeInternal, "Failed to create API key", nil)
+	}
+
+	slog.Info("API key created", "user_id", user.ID, "api_key_id", key.ID)
+	return response.JSON(ctx, http.StatusCreated, key)
+}
+
+// ListAPIKeys lists the user's keys without their values
+func (h *Handler) ListAPIKeys(ctx echo.Context) error {
+	user, err := currentUser(ctx)
+	if user == nil {
+		return err
+	}
+
+	keys, err := h.service.ListAPIKeys(ctx.Request().Context(), user.ID)
+	if err != nil {
+		slog.Error("Failed to list API keys", "user_id", user.ID, "error", err)
+		return response.ErrorResponse(ctx, http.StatusInternalServerError,
+			response.CodeInternal, "Failed to get API keys", nil)
+	}
+
+	return response.JSON(ctx, http.StatusOK, keys)
+}
+
+// RevokeAPIKey deletes one of the user's keys; it stops working immediately
+func (h *Handler) RevokeAPIKey(ctx echo.Context) error {
+	user, err := currentUser(ctx)
+	if user == nil {
+		return err
+	}
+
+	keyID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return response.ErrorResponse(ctx, http.StatusBadRequest,
+			response.CodeInvalidID, "Invalid API key ID format", nil)
+	}
+
+	if err := h.service.RevokeAPIKey(ctx.Request().Context(), keyID, user.ID); err != nil {
+		if err.Error() == "api key not found" {
+			return response.ErrorResponse(ctx, http.StatusNotFound,
+				response.CodeAPIKeyNotFound, "API key not found", nil)
+		}
+
+		slog.Error("Failed to revoke API key", "user_id", user.ID, "api_key_id", keyID, "error", err)
+		return response.ErrorResponse(ctx, http.StatusInternalServerError,
+			response.CodeInternal, "Failed to revoke API key", nil)
+	}
+
+	slog.Info("API key revoked", "user_id", user.ID, "api_key_id", keyID)
+	return response.JSON(ctx, http.StatusOK, map[string]string{
+		"message": "API key revoked",
+	})
+}
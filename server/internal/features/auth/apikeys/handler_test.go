@@ -0,0 +1,220 @@
+package apikeys_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"black-lotus/internal/common/response"
+	"black-lotus/internal/domain/models"
+	"black-lotus/internal/features/auth/apikeys"
+	"black-lotus/internal/features/auth/session"
+)
+
+// MockService implements apikeys.ServiceInterface for testing
+type MockService struct {
+	createAPIKeyFunc   func(ctx context.Context, userID uuid.UUID, input models.CreateAPIKeyInput) (*models.CreatedAPIKey, error)
+	listAPIKeysFunc    func(ctx context.Context, userID uuid.UUID) ([]*models.APIKey, error)
+	revokeAPIKeyFunc   func(ctx context.Context, keyID uuid.UUID, userID uuid.UUID) error
+	validateAPIKeyFunc func(ctx context.Context, key string) (*models.APIKey, error)
+}
+
+func (m *MockService) CreateAPIKey(ctx context.Context, userID uuid.UUID, input models.CreateAPIKeyInput) (*models.CreatedAPIKey, error) {
+	if m.createAPIKeyFunc != nil {
+		return m.createAPIKeyFunc(ctx, userID, input)
+	}
+	return nil, errors.New("CreateAPIKey not implemented")
+}
+
+func (m *MockService) ListAPIKeys(ctx context.Context, userID uuid.UUID) ([]*models.APIKey, error) {
+	if m.listAPIKeysFunc != nil {
+		return m.listAPIKeysFunc(ctx, userID)
+	}
+	return nil, errors.New("ListAPIKeys not implemented")
+}
+
+func (m *MockService) RevokeAPIKey(ctx context.Context, keyID uuid.UUID, userID uuid.UUID) error {
+	if m.revokeAPIKeyFunc != nil {
+		return m.revokeAPIKeyFunc(ctx, keyID, userID)
+	}
+	return errors.New("RevokeAPIKey not implemented")
+}
+
+func (m *MockService) ValidateAPIKey(ctx context.Context, key string) (*models.APIKey, error) {
+	if m.validateAPIKeyFunc != nil {
+		return m.validateAPIKeyFunc(ctx, key)
+	}
+	return nil, errors.New("ValidateAPIKey not implemented")
+}
+
+// Helper function to create a test context, signed in as user when it isn't nil
+func newTestContext(method, path, body string, user *models.User) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+	if user != nil {
+		ctx.Set("user", user)
+	}
+	return ctx, rec
+}
+
+func setupHandlerTest() (*apikeys.Handler, *MockService) {
+	mockService := &MockService{}
+	handler := apikeys.NewHandler(mockService, validator.New())
+	return handler, mockService
+}
+
+func TestHandlerCreateAPIKey(t *testing.T) {
+	testCases := []struct {
+		name           string
+		body           string
+		signedIn       bool
+		usingAPIKey    bool
+		serviceErr     error
+		expectedStatus int
+	}{
+		{name: "Success", body: `{"name":"CI"}`, signedIn: true, expectedStatus: http.StatusCreated},
+		{name: "BlankName", body: `{"name":"   "}`, signedIn: true, expectedStatus: http.StatusBadRequest},
+		{name: "PastExpiry", body: `{"name":"CI","expires_at":"2000-01-01T00:00:00Z"}`, signedIn: true, serviceErr: errors.New("expiry must be in the future"), expectedStatus: http.StatusBadRequest},
+		{name: "NotSignedIn", body: `{"name":"CI"}`, expectedStatus: http.StatusUnauthorized},
+		{name: "WithAPIKey", body: `{"name":"CI"}`, signedIn: true, usingAPIKey: true, expectedStatus: http.StatusForbidden},
+		{name: "ServiceError", body: `{"name":"CI"}`, signedIn: true, serviceErr: errors.New("database error"), expectedStatus: http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			handler, mockService := setupHandlerTest()
+			var user *models.User
+			if tc.signedIn {
+				user = &models.User{ID: uuid.New()}
+			}
+			mockService.createAPIKeyFunc = func(ctx context.Context, userID uuid.UUID, input models.CreateAPIKeyInput) (*models.CreatedAPIKey, error) {
+				if tc.serviceErr != nil {
+					return nil, tc.serviceErr
+				}
+				return &models.CreatedAPIKey{
+					APIKey: models.APIKey{ID: uuid.New(), UserID: userID, Name: input.Name, Prefix: "bl_abcdefgh"},
+					Key:    "bl_abcdefghsecret",
+				}, nil
+			}
+
+			ctx, rec := newTestContext(http.MethodPost, "/api/auth/api-keys", tc.body, user)
+			if tc.usingAPIKey {
+				ctx.Set(session.APIKeyContextKey, &models.APIKey{ID: uuid.New(), UserID: user.ID})
+			}
+
+			// Execute
+			err := handler.CreateAPIKey(ctx)
+
+			// Verify
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if rec.Code != tc.expectedStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tc.expectedStatus, rec.Code, rec.Body.String())
+			}
+
+			if tc.expectedStatus == http.StatusCreated {
+				var created models.CreatedAPIKey
+				if err := json.Unmarshal(rec.Body.Bytes(), &created); err != nil {
+					t.Fatalf("Failed to unmarshal response: %v", err)
+				}
+				if created.Key == "" {
+					t.Error("Expected the raw key in the create response")
+				}
+			}
+		})
+	}
+}
+
+func TestHandlerListAPIKeys(t *testing.T) {
+	// Setup
+	handler, mockService := setupHandlerTest()
+	user := &models.User{ID: uuid.New()}
+	mockService.listAPIKeysFunc = func(ctx context.Context, userID uuid.UUID) ([]*models.APIKey, error) {
+		return []*models.APIKey{{ID: uuid.New(), UserID: userID, Name: "CI", Prefix: "bl_abcdefgh"}}, nil
+	}
+	ctx, rec := newTestContext(http.MethodGet, "/api/auth/api-keys", "", user)
+
+	// Execute
+	if err := handler.ListAPIKeys(ctx); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	// Verify: listings never include the raw key
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, rec.Code)
+	}
+	var keys []map[string]any
+	if err := json.Unmarshal(rec.Body.Bytes(), &keys); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if len(keys) != 1 {
+		t.Fatalf("Expected 1 key, got %d", len(keys))
+	}
+	if _, ok := keys[0]["key"]; ok {
+		t.Error("Expected the listing to omit the raw key")
+	}
+}
+
+func TestHandlerRevokeAPIKey(t *testing.T) {
+	testCases := []struct {
+		name           string
+		keyID          string
+		serviceErr     error
+		expectedStatus int
+		expectedCode   string
+	}{
+		{name: "Success", keyID: uuid.New().String(), expectedStatus: http.StatusOK},
+		{name: "NotFound", keyID: uuid.New().String(), serviceErr: errors.New("api key not found"), expectedStatus: http.StatusNotFound, expectedCode: response.CodeAPIKeyNotFound},
+		{name: "InvalidID", keyID: "not-a-uuid", expectedStatus: http.StatusBadRequest, expectedCode: response.CodeInvalidID},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			handler, mockService := setupHandlerTest()
+			user := &models.User{ID: uuid.New()}
+			mockService.revokeAPIKeyFunc = func(ctx context.Context, keyID uuid.UUID, userID uuid.UUID) error {
+				if userID != user.ID {
+					t.Errorf("Expected revoke for user %s, got %s", user.ID, userID)
+				}
+				return tc.serviceErr
+			}
+
+			ctx, rec := newTestContext(http.MethodDelete, "/api/auth/api-keys/"+tc.keyID, "", user)
+			ctx.SetParamNames("id")
+			ctx.SetParamValues(tc.keyID)
+
+			// Execute
+			if err := handler.RevokeAPIKey(ctx); err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			// Verify
+			if rec.Code != tc.expectedStatus {
+				t.Fatalf("Expected status %d, got %d", tc.expectedStatus, rec.Code)
+			}
+			if tc.expectedCode != "" {
+				var envelope response.ErrorEnvelope
+				if err := json.Unmarshal(rec.Body.Bytes(), &envelope); err != nil {
+					t.Fatalf("Failed to unmarshal response: %v", err)
+				}
+				if envelope.Error.Code != tc.expectedCode {
+					t.Errorf("Expected code '%s', got '%s'", tc.expectedCode, envelope.Error.Code)
+				}
+			}
+		})
+	}
+}
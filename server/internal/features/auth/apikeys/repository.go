@@ -0,0 +1,18 @@
+package apikeys
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"black-lotus/internal/domain/models"
+)
+
+// Repository defines database operations needed by API key management
+type Repository interface {
+	CreateAPIKey(ctx context.Context, userID uuid.UUID, name string, expiresAt *time.Time) (*models.CreatedAPIKey, error)
+	GetAPIKeysByUserID(ctx context.Context, userID uuid.UUID) ([]*models.APIKey, error)
+	DeleteAPIKey(ctx context.Context, keyID uuid.UUID, userID uuid.UUID) error
+	UseAPIKey(ctx context.Context, key string) (*models.APIKey, error)
+}
@@ -0,0 +1,76 @@
+package apikeys
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"black-lotus/internal/domain/models"
+)
+
+// KeyPrefix starts every API key, so keys are easy to spot in scripts and
+// secret scanners and other bearer tokens are rejected without a query
+const KeyPrefix = "bl_"
+
+type ServiceInterface interface {
+	CreateAPIKey(ctx context.Context, userID uuid.UUID, input models.CreateAPIKeyInput) (*models.CreatedAPIKey, error)
+	ListAPIKeys(ctx context.Context, userID uuid.UUID) ([]*models.APIKey, error)
+	RevokeAPIKey(ctx context.Context, keyID uuid.UUID, userID uuid.UUID) error
+	ValidateAPIKey(ctx context.Context, key string) (*models.APIKey, error)
+}
+
+type Service struct {
+	repo Repository
+}
+
+func NewService(repo Repository) *Service {
+	return &Service{repo: repo}
+}
+
+// CreateAPIKey issues a key for the user. The returned key is the only time
+// the raw value is available.
+func (s *Service) CreateAPIKey(ctx context.Context, userID uuid.UUID, input models.CreateAPIKeyInput) (*models.CreatedAPIKey, error) {
+	if input.ExpiresAt != nil && !input.ExpiresAt.After(time.Now()) {
+		return nil, errors.New("expiry must be in the future")
+	}
+
+	return s.repo.CreateAPIKey(ctx, userID, input.Name, input.ExpiresAt)
+}
+
+// ListAPIKeys returns the user's keys, newest first, without their raw values
+func (s *Service) ListAPIKeys(ctx context.Context, userID uuid.UUID) ([]*models.APIKey, error) {
+	keys, err := s.repo.GetAPIKeysByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if keys == nil {
+		keys = []*models.APIKey{}
+	}
+	return keys, nil
+}
+
+// RevokeAPIKey deletes one of the user's keys; other users' keys are reported as not found
+func (s *Service) RevokeAPIKey(ctx context.Context, keyID uuid.UUID, userID uuid.UUID) error {
+	return s.repo.DeleteAPIKey(ctx, keyID, userID)
+}
+
+// ValidateAPIKey resolves a raw key to its unexpired record and notes that it was used
+func (s *Service) ValidateAPIKey(ctx context.Context, key string) (*models.APIKey, error) {
+	if !strings.HasPrefix(key, KeyPrefix) {
+		return nil, errors.New("invalid api key")
+	}
+
+	apiKey, err := s.repo.UseAPIKey(ctx, key)
+	if err != nil {
+		if err.Error() == "api key not found" {
+			return nil, errors.New("invalid api key")
+		}
+		return nil, err
+	}
+
+	return apiKey, nil
+}
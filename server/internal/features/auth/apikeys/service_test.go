@@ -0,0 +1,183 @@
+package apikeys_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"black-lotus/internal/domain/models"
+	"black-lotus/internal/features/auth/apikeys"
+)
+
+// MockRepository implements apikeys.Repository for testing
+type MockRepository struct {
+	createAPIKeyFunc       func(ctx context.Context, userID uuid.UUID, name string, expiresAt *time.Time) (*models.CreatedAPIKey, error)
+	getAPIKeysByUserIDFunc func(ctx context.Context, userID uuid.UUID) ([]*models.APIKey, error)
+	deleteAPIKeyFunc       func(ctx context.Context, keyID uuid.UUID, userID uuid.UUID) error
+	useAPIKeyFunc          func(ctx context.Context, key string) (*models.APIKey, error)
+}
+
+func (m *MockRepository) CreateAPIKey(ctx context.Context, userID uuid.UUID, name string, expiresAt *time.Time) (*models.CreatedAPIKey, error) {
+	if m.createAPIKeyFunc != nil {
+		return m.createAPIKeyFunc(ctx, userID, name, expiresAt)
+	}
+	return nil, errors.New("CreateAPIKey not implemented")
+}
+
+func (m *MockRepository) GetAPIKeysByUserID(ctx context.Context, userID uuid.UUID) ([]*models.APIKey, error) {
+	if m.getAPIKeysByUserIDFunc != nil {
+		return m.getAPIKeysByUserIDFunc(ctx, userID)
+	}
+	return nil, errors.New("GetAPIKeysByUserID not implemented")
+}
+
+func (m *MockRepository) DeleteAPIKey(ctx context.Context, keyID uuid.UUID, userID uuid.UUID) error {
+	if m.deleteAPIKeyFunc != nil {
+		return m.deleteAPIKeyFunc(ctx, keyID, userID)
+	}
+	return errors.New("DeleteAPIKey not implemented")
+}
+
+func (m *MockRepository) UseAPIKey(ctx context.Context, key string) (*models.APIKey, error) {
+	if m.useAPIKeyFunc != nil {
+		return m.useAPIKeyFunc(ctx, key)
+	}
+	return nil, errors.New("UseAPIKey not implemented")
+}
+
+// Helper function to setup service for testing
+func setupServiceTest() (*apikeys.Service, *MockRepository) {
+	mockRepo := &MockRepository{}
+	service := apikeys.NewService(mockRepo)
+	return service, mockRepo
+}
+
+func TestServiceCreateAPIKey(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(24 * time.Hour)
+
+	testCases := []struct {
+		name          string
+		expiresAt     *time.Time
+		expectedError bool
+		errorMessage  string
+	}{
+		{name: "NoExpiry", expiresAt: nil, expectedError: false},
+		{name: "FutureExpiry", expiresAt: &future, expectedError: false},
+		{name: "PastExpiry", expiresAt: &past, expectedError: true, errorMessage: "expiry must be in the future"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			service, mockRepo := setupServiceTest()
+			userID := uuid.New()
+			mockRepo.createAPIKeyFunc = func(ctx context.Context, id uuid.UUID, name string, expiresAt *time.Time) (*models.CreatedAPIKey, error) {
+				if tc.expectedError {
+					t.Error("CreateAPIKey should not be called for an invalid expiry")
+				}
+				return &models.CreatedAPIKey{
+					APIKey: models.APIKey{ID: uuid.New(), UserID: id, Name: name, ExpiresAt: expiresAt},
+					Key:    apikeys.KeyPrefix + "secret",
+				}, nil
+			}
+
+			// Execute
+			key, err := service.CreateAPIKey(context.Background(), userID,
+				models.CreateAPIKeyInput{Name: "CI", ExpiresAt: tc.expiresAt})
+
+			// Verify
+			if tc.expectedError {
+				if err == nil {
+					t.Error("Expected error, got nil")
+				} else if err.Error() != tc.errorMessage {
+					t.Errorf("Expected error message '%s', got '%s'", tc.errorMessage, err.Error())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if key.UserID != userID || key.Name != "CI" {
+				t.Errorf("Expected key for user %s named CI, got %+v", userID, key.APIKey)
+			}
+		})
+	}
+}
+
+func TestServiceListAPIKeys(t *testing.T) {
+	// Setup
+	service, mockRepo := setupServiceTest()
+	mockRepo.getAPIKeysByUserIDFunc = func(ctx context.Context, userID uuid.UUID) ([]*models.APIKey, error) {
+		return nil, nil
+	}
+
+	// Execute
+	keys, err := service.ListAPIKeys(context.Background(), uuid.New())
+
+	// Verify: an empty list rather than nil, so the response is [] not null
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if keys == nil || len(keys) != 0 {
+		t.Errorf("Expected an empty slice, got %v", keys)
+	}
+}
+
+func TestServiceValidateAPIKey(t *testing.T) {
+	userID := uuid.New()
+
+	testCases := []struct {
+		name          string
+		key           string
+		repoErr       error
+		expectLookup  bool
+		expectedError bool
+		errorMessage  string
+	}{
+		{name: "ValidKey", key: "bl_valid", expectLookup: true},
+		{name: "UnknownOrExpiredKey", key: "bl_unknown", repoErr: errors.New("api key not found"), expectLookup: true, expectedError: true, errorMessage: "invalid api key"},
+		{name: "WrongPrefix", key: "some-session-token", expectedError: true, errorMessage: "invalid api key"},
+		{name: "DatabaseError", key: "bl_valid", repoErr: errors.New("database error"), expectLookup: true, expectedError: true, errorMessage: "database error"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			service, mockRepo := setupServiceTest()
+			looked := false
+			mockRepo.useAPIKeyFunc = func(ctx context.Context, key string) (*models.APIKey, error) {
+				looked = true
+				if tc.repoErr != nil {
+					return nil, tc.repoErr
+				}
+				return &models.APIKey{ID: uuid.New(), UserID: userID}, nil
+			}
+
+			// Execute
+			key, err := service.ValidateAPIKey(context.Background(), tc.key)
+
+			// Verify
+			if looked != tc.expectLookup {
+				t.Errorf("Expected lookup %v, got %v", tc.expectLookup, looked)
+			}
+			if tc.expectedError {
+				if err == nil {
+					t.Error("Expected error, got nil")
+				} else if err.Error() != tc.errorMessage {
+					t.Errorf("Expected error message '%s', got '%s'", tc.errorMessage, err.Error())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if key.UserID != userID {
+				t.Errorf("Expected key for user %s, got %s", userID, key.UserID)
+			}
+		})
+	}
+}
@@ -1,12 +1,15 @@
 package login
 
 import (
-	"log"
+	"errors"
+	"log/slog"
 	"net/http"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/labstack/echo/v4"
 
+	"black-lotus/internal/common/binding"
+	"black-lotus/internal/common/response"
 	"black-lotus/internal/domain/models"
 	"black-lotus/internal/features/auth/session"
 )
@@ -29,60 +32,44 @@ func (h *Handler) Login(ctx echo.Context) error {
 	var input models.LoginUserInput
 
 	// Validate request data
-	if err := ctx.Bind(&input); err != nil {
-		return ctx.JSON(http.StatusBadRequest, map[string]string{
-			"error": "Invalid request body",
-		})
+	if err := binding.Bind(ctx, &input); err != nil {
+		return response.InvalidBody(ctx, err)
 	}
 
 	if err := h.validator.Struct(input); err != nil {
-		return ctx.JSON(http.StatusBadRequest, map[string]string{
-			"error": err.Error(),
-		})
+		return response.ErrorResponse(ctx, http.StatusBadRequest,
+			response.CodeValidationFailed, err.Error(), nil)
 	}
 
 	// Authenticate user credentials
-	user, err := h.service.LoginUser(ctx.Request().Context(), input)
+	user, err := h.service.LoginUser(ctx.Request().Context(), input, ctx.RealIP())
 	if err != nil {
+		var lockedOut *LockedOutError
+		if errors.As(err, &lockedOut) {
+			return response.RateLimited(ctx, lockedOut.RetryAfter)
+		}
+
 		// Generic error for security (don't reveal if email or password was wrong)
-		return ctx.JSON(http.StatusUnauthorized, map[string]string{
-			"error": "Invalid credentials. Please check your email and password and try again.",
-		})
+		return response.ErrorResponse(ctx, http.StatusUnauthorized, response.CodeInvalidCredentials,
+			"Invalid credentials. Please check your email and password and try again.", nil)
+	}
+
+	refreshDuration := session.RefreshTokenDuration
+	if input.RememberMe != nil && *input.RememberMe {
+		refreshDuration = session.RememberMeRefreshTokenDuration
 	}
 
 	// Create a session for the authenticated user
-	session, err := h.sessionService.CreateSession(ctx.Request().Context(), user.ID)
+	newSession, err := h.sessionService.CreateSession(ctx.Request().Context(), user.ID, refreshDuration)
 	if err != nil {
-		log.Printf("Session creation error: %v", err)
-		return ctx.JSON(http.StatusInternalServerError, map[string]string{
-			"error": "Failed to create session: " + err.Error(),
-		})
+		slog.Error("Session creation error", "user_id", user.ID, "error", err)
+		return response.ErrorResponse(ctx, http.StatusInternalServerError,
+			response.CodeInternal, "Failed to create session: "+err.Error(), nil)
 	}
 
-	// Set access token cookie
-	accessCookie := new(http.Cookie)
-	accessCookie.Name = "access_token"
-	accessCookie.Value = session.AccessToken
-	accessCookie.Expires = session.AccessExpiry
-	accessCookie.Path = "/"
-	accessCookie.HttpOnly = true
-	// For production
-	accessCookie.Secure = true
-	accessCookie.SameSite = http.SameSiteStrictMode
-
-	// Set refresh token cookie
-	refreshCookie := new(http.Cookie)
-	refreshCookie.Name = "refresh_token"
-	refreshCookie.Value = session.RefreshToken
-	refreshCookie.Expires = session.RefreshExpiry
-	refreshCookie.Path = "/"
-	refreshCookie.HttpOnly = true
-	// For production
-	refreshCookie.Secure = true
-	refreshCookie.SameSite = http.SameSiteStrictMode
-
-	ctx.SetCookie(accessCookie)
-	ctx.SetCookie(refreshCookie)
+	// Without remember me the cookies go when the browser closes
+	persistent := input.RememberMe == nil || *input.RememberMe
+	session.SetSessionCookies(ctx, newSession, persistent)
 
-	return ctx.JSON(http.StatusOK, user)
+	return response.JSON(ctx, http.StatusOK, user)
 }
@@ -6,8 +6,10 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -16,23 +18,26 @@ import (
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 
+	"black-lotus/internal/common/response"
 	"black-lotus/internal/domain/models"
 	"black-lotus/internal/features/auth/login"
+	"black-lotus/internal/features/auth/session"
 )
 
 type MockSessionService struct {
-	createSessionFunc            func(ctx context.Context, userID uuid.UUID) (*models.Session, error)
+	createSessionFunc            func(ctx context.Context, userID uuid.UUID, refreshDuration time.Duration) (*models.Session, error)
 	validateAccessTokenFunc      func(ctx context.Context, token string) (*models.Session, error)
 	validateRefreshTokenFunc     func(ctx context.Context, token string) (*models.Session, error)
 	refreshAccessTokenFunc       func(ctx context.Context, refreshToken string) (*models.Session, error)
 	endSessionByAccessTokenFunc  func(ctx context.Context, token string) error
 	endSessionByRefreshTokenFunc func(ctx context.Context, token string) error
 	endAllUserSessionsFunc       func(ctx context.Context, userID uuid.UUID) error
+	endOtherUserSessionsFunc     func(ctx context.Context, userID uuid.UUID, keepSessionID uuid.UUID) error
 }
 
-func (m *MockSessionService) CreateSession(ctx context.Context, userID uuid.UUID) (*models.Session, error) {
+func (m *MockSessionService) CreateSession(ctx context.Context, userID uuid.UUID, refreshDuration time.Duration) (*models.Session, error) {
 	if m.createSessionFunc != nil {
-		return m.createSessionFunc(ctx, userID)
+		return m.createSessionFunc(ctx, userID, refreshDuration)
 	}
 	return nil, errors.New("not implemented")
 }
@@ -78,6 +83,13 @@ func (m *MockSessionService) EndAllUserSessions(ctx context.Context, userID uuid
 	return errors.New("not implemented")
 }
 
+func (m *MockSessionService) EndOtherUserSessions(ctx context.Context, userID uuid.UUID, keepSessionID uuid.UUID) error {
+	if m.endOtherUserSessionsFunc != nil {
+		return m.endOtherUserSessionsFunc(ctx, userID, keepSessionID)
+	}
+	return errors.New("not implemented")
+}
+
 // Helper function to create a new test context
 func newTestContext(method, path string, body []byte) (echo.Context, *httptest.ResponseRecorder) {
 	e := echo.New()
@@ -187,7 +199,7 @@ func TestLogin(t *testing.T) {
 		}
 
 		// Mock session service
-		mockSessionService.createSessionFunc = func(ctx context.Context, id uuid.UUID) (*models.Session, error) {
+		mockSessionService.createSessionFunc = func(ctx context.Context, id uuid.UUID, refreshDuration time.Duration) (*models.Session, error) {
 			if id == userID {
 				return createTestSession(userID, "test_access_token", "test_refresh_token"), nil
 			}
@@ -240,14 +252,44 @@ func TestLogin(t *testing.T) {
 		checkResponseStatus(t, rec, http.StatusBadRequest)
 
 		// Verify error message
-		var response map[string]string
-		err = json.Unmarshal(rec.Body.Bytes(), &response)
+		var envelope response.ErrorEnvelope
+		err = json.Unmarshal(rec.Body.Bytes(), &envelope)
 		if err != nil {
 			t.Fatalf("Failed to unmarshal response: %v", err)
 		}
 
-		if response["error"] != "Invalid request body" {
-			t.Errorf("Expected 'Invalid request body' error, got: %s", response["error"])
+		if envelope.Error.Message != "Invalid request body" {
+			t.Errorf("Expected 'Invalid request body' error, got: %s", envelope.Error.Message)
+		}
+	})
+
+	t.Run("UnknownField", func(t *testing.T) {
+		handler, _, _ := setupHandler()
+
+		// "pasword" is a typo for "password"
+		body := []byte(`{"email": "test@example.com", "pasword": "Password123!"}`)
+
+		// Setup request
+		c, rec := newTestContext(http.MethodPost, "/auth/login", body)
+
+		// Execute
+		err := handler.Login(c)
+		if err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+
+		// Check status code
+		checkResponseStatus(t, rec, http.StatusBadRequest)
+
+		// Verify the typo is named instead of failing validation
+		var envelope response.ErrorEnvelope
+		err = json.Unmarshal(rec.Body.Bytes(), &envelope)
+		if err != nil {
+			t.Fatalf("Failed to unmarshal response: %v", err)
+		}
+
+		if envelope.Error.Message != "Unknown field: pasword" {
+			t.Errorf("Expected 'Unknown field: pasword' error, got: %s", envelope.Error.Message)
 		}
 	})
 
@@ -274,13 +316,13 @@ func TestLogin(t *testing.T) {
 		checkResponseStatus(t, rec, http.StatusBadRequest)
 
 		// Verify that we get an error response (the exact message will depend on your validator)
-		var response map[string]string
-		err = json.Unmarshal(rec.Body.Bytes(), &response)
+		var envelope response.ErrorEnvelope
+		err = json.Unmarshal(rec.Body.Bytes(), &envelope)
 		if err != nil {
 			t.Fatalf("Failed to unmarshal response: %v", err)
 		}
 
-		if response["error"] == "" {
+		if envelope.Error.Message == "" {
 			t.Error("Expected validation error message, got empty string")
 		}
 	})
@@ -313,15 +355,15 @@ func TestLogin(t *testing.T) {
 		checkResponseStatus(t, rec, http.StatusUnauthorized)
 
 		// Verify error message
-		var response map[string]string
-		err = json.Unmarshal(rec.Body.Bytes(), &response)
+		var envelope response.ErrorEnvelope
+		err = json.Unmarshal(rec.Body.Bytes(), &envelope)
 		if err != nil {
 			t.Fatalf("Failed to unmarshal response: %v", err)
 		}
 
 		expectedError := "Invalid credentials. Please check your email and password and try again."
-		if response["error"] != expectedError {
-			t.Errorf("Expected '%s', got: '%s'", expectedError, response["error"])
+		if envelope.Error.Message != expectedError {
+			t.Errorf("Expected '%s', got: '%s'", expectedError, envelope.Error.Message)
 		}
 	})
 
@@ -353,7 +395,7 @@ func TestLogin(t *testing.T) {
 		}
 
 		// Mock session service to return error
-		mockSessionService.createSessionFunc = func(ctx context.Context, id uuid.UUID) (*models.Session, error) {
+		mockSessionService.createSessionFunc = func(ctx context.Context, id uuid.UUID, refreshDuration time.Duration) (*models.Session, error) {
 			return nil, errors.New("failed to create session")
 		}
 
@@ -367,14 +409,121 @@ func TestLogin(t *testing.T) {
 		checkResponseStatus(t, rec, http.StatusInternalServerError)
 
 		// Verify error message
-		var response map[string]string
-		err = json.Unmarshal(rec.Body.Bytes(), &response)
+		var envelope response.ErrorEnvelope
+		err = json.Unmarshal(rec.Body.Bytes(), &envelope)
 		if err != nil {
 			t.Fatalf("Failed to unmarshal response: %v", err)
 		}
 
-		if !strings.Contains(response["error"], "Failed to create session") {
-			t.Errorf("Expected error message to contain 'Failed to create session', got: '%s'", response["error"])
+		if !strings.Contains(envelope.Error.Message, "Failed to create session") {
+			t.Errorf("Expected error message to contain 'Failed to create session', got: '%s'", envelope.Error.Message)
 		}
 	})
 }
+
+func TestLoginRememberMe(t *testing.T) {
+	testCases := []struct {
+		name             string
+		rememberMe       string
+		expectedRefresh  time.Duration
+		expectPersistent bool
+	}{
+		{name: "Omitted", rememberMe: "", expectedRefresh: session.RefreshTokenDuration, expectPersistent: true},
+		{name: "RememberMe", rememberMe: `, "remember_me": true`, expectedRefresh: session.RememberMeRefreshTokenDuration, expectPersistent: true},
+		{name: "BrowserSession", rememberMe: `, "remember_me": false`, expectedRefresh: session.RefreshTokenDuration, expectPersistent: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			handler, mockRepo, mockSessionService := setupHandler()
+			userID := uuid.New()
+			body := []byte(`{"email": "test@example.com", "password": "Password123!"` + tc.rememberMe + `}`)
+			c, rec := newTestContext(http.MethodPost, "/auth/login", body)
+
+			mockRepo.loginUserFunc = func(ctx context.Context, i models.LoginUserInput) (*models.User, error) {
+				return &models.User{ID: userID, Email: i.Email}, nil
+			}
+			mockSessionService.createSessionFunc = func(ctx context.Context, id uuid.UUID, refreshDuration time.Duration) (*models.Session, error) {
+				if refreshDuration != tc.expectedRefresh {
+					t.Errorf("Expected refresh duration %v, got %v", tc.expectedRefresh, refreshDuration)
+				}
+				return createTestSession(userID, "test_access_token", "test_refresh_token"), nil
+			}
+
+			// Execute
+			if err := handler.Login(c); err != nil {
+				t.Errorf("Expected no error, got: %v", err)
+			}
+
+			// Verify
+			checkResponseStatus(t, rec, http.StatusOK)
+
+			cookies := rec.Result().Cookies()
+			if len(cookies) != 2 {
+				t.Fatalf("Expected 2 cookies, got %d", len(cookies))
+			}
+			for _, cookie := range cookies {
+				persistent := !cookie.Expires.IsZero() || cookie.MaxAge > 0
+				if persistent != tc.expectPersistent {
+					t.Errorf("Expected %s persistent=%v, got %v", cookie.Name, tc.expectPersistent, persistent)
+				}
+			}
+		})
+	}
+}
+
+func TestLoginLockedOut(t *testing.T) {
+	t.Setenv("LOGIN_MAX_FAILURES", "2")
+	t.Setenv("LOGIN_LOCKOUT_DURATION", "90s")
+
+	handler, mockRepo, _ := setupHandler()
+	mockRepo.loginUserFunc = func(ctx context.Context, i models.LoginUserInput) (*models.User, error) {
+		return nil, errors.New("invalid email or password")
+	}
+
+	inputJSON, _ := json.Marshal(models.LoginUserInput{Email: "test@example.com", Password: "WrongPassword!"})
+
+	expected := []int{http.StatusUnauthorized, http.StatusTooManyRequests, http.StatusTooManyRequests}
+	for i, status := range expected {
+		c, rec := newTestContext(http.MethodPost, "/auth/login", inputJSON)
+
+		if err := handler.Login(c); err != nil {
+			t.Fatalf("Attempt %d: expected no error, got: %v", i+1, err)
+		}
+
+		checkResponseStatus(t, rec, status)
+
+		if status == http.StatusTooManyRequests {
+			seconds, err := strconv.Atoi(rec.Header().Get(echo.HeaderRetryAfter))
+			if err != nil || seconds < 1 || seconds > 90 {
+				t.Errorf("Attempt %d: expected Retry-After between 1 and 90, got %q", i+1, rec.Header().Get(echo.HeaderRetryAfter))
+			}
+		}
+	}
+}
+
+func TestLoginLockedOutWithRotatingForwardedFor(t *testing.T) {
+	t.Setenv("LOGIN_MAX_FAILURES", "2")
+	t.Setenv("LOGIN_MAX_FAILURES_PER_EMAIL", "4")
+
+	handler, mockRepo, _ := setupHandler()
+	mockRepo.loginUserFunc = func(ctx context.Context, i models.LoginUserInput) (*models.User, error) {
+		return nil, errors.New("invalid email or password")
+	}
+
+	inputJSON, _ := json.Marshal(models.LoginUserInput{Email: "test@example.com", Password: "WrongPassword!"})
+
+	// Each attempt claims a new client IP, so only the per-email limit catches it
+	expected := []int{http.StatusUnauthorized, http.StatusUnauthorized, http.StatusUnauthorized, http.StatusTooManyRequests, http.StatusTooManyRequests}
+	for i, status := range expected {
+		c, rec := newTestContext(http.MethodPost, "/auth/login", inputJSON)
+		c.Request().Header.Set(echo.HeaderXForwardedFor, fmt.Sprintf("203.0.113.%d", i+1))
+
+		if err := handler.Login(c); err != nil {
+			t.Fatalf("Attempt %d: expected no error, got: %v", i+1, err)
+		}
+
+		checkResponseStatus(t, rec, status)
+	}
+}
@@ -0,0 +1,161 @@
+package login
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultMaxFailedLogins         = 5
+	DefaultMaxFailedLoginsPerEmail = 20               // Across all clients, so rotating IPs doesn't buy more guesses
+	DefaultFailedLoginWindow       = 15 * time.Minute // Failures older than this are forgotten
+	DefaultLockoutDuration         = 15 * time.Minute
+)
+
+// LockoutPolicy decides when repeated failed logins lock a client out.
+// MaxFailures applies to one client and email; MaxEmailFailures to an email
+// whichever clients the attempts come from.
+type LockoutPolicy struct {
+	MaxFailures      int
+	MaxEmailFailures int
+	Window           time.Duration
+	Duration         time.Duration
+}
+
+// LockoutPolicyFromEnv reads LOGIN_MAX_FAILURES, LOGIN_MAX_FAILURES_PER_EMAIL,
+// LOGIN_FAILURE_WINDOW (e.g. "15m") and LOGIN_LOCKOUT_DURATION, falling back
+// to the defaults when a value is unset or invalid
+func LockoutPolicyFromEnv() LockoutPolicy {
+	policy := LockoutPolicy{
+		MaxFailures:      DefaultMaxFailedLogins,
+		MaxEmailFailures: DefaultMaxFailedLoginsPerEmail,
+		Window:           DefaultFailedLoginWindow,
+		Duration:         DefaultLockoutDuration,
+	}
+
+	if maxFailures, err := strconv.Atoi(os.Getenv("LOGIN_MAX_FAILURES")); err == nil && maxFailures > 0 {
+		policy.MaxFailures = maxFailures
+	}
+	if maxFailures, err := strconv.Atoi(os.Getenv("LOGIN_MAX_FAILURES_PER_EMAIL")); err == nil && maxFailures > 0 {
+		policy.MaxEmailFailures = maxFailures
+	}
+	if window, err := time.ParseDuration(os.Getenv("LOGIN_FAILURE_WINDOW")); err == nil && window > 0 {
+		policy.Window = window
+	}
+	if duration, err := time.ParseDuration(os.Getenv("LOGIN_LOCKOUT_DURATION")); err == nil && duration > 0 {
+		policy.Duration = duration
+	}
+
+	return policy
+}
+
+// LockedOutError is returned while a client is locked out. It is returned
+// before credentials are checked, so it says nothing about whether the email exists.
+type LockedOutError struct {
+	RetryAfter time.Duration
+}
+
+func (e *LockedOutError) Error() string {
+	return "too many failed login attempts"
+}
+
+type failedLogins struct {
+	count       int
+	firstFailed time.Time
+	lockedUntil time.Time
+}
+
+// attemptTracker counts consecutive failed logins per key, locking the key
+// out after maxFailures. The service keeps one tracker per IP and email pair,
+// which stops one client from quickly locking a victim out everywhere, and one
+// per email with a higher limit, which a client can't get around by changing
+// its IP.
+type attemptTracker struct {
+	mu          sync.Mutex
+	policy      LockoutPolicy
+	maxFailures int
+	entries     map[string]*failedLogins
+	lastCleanup time.Time
+}
+
+func newAttemptTracker(policy LockoutPolicy, maxFailures int) *attemptTracker {
+	return &attemptTracker{
+		policy:      policy,
+		maxFailures: maxFailures,
+		entries:     make(map[string]*failedLogins),
+		lastCleanup: time.Now(),
+	}
+}
+
+func attemptKey(clientIP, email string) string {
+	return clientIP + "|" + emailKey(email)
+}
+
+func emailKey(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// lockedFor reports how much longer the key is locked out, or 0 if it isn't
+func (t *attemptTracker) lockedFor(key string) time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	entry, exists := t.entries[key]
+	if !exists {
+		return 0
+	}
+
+	if wait := time.Until(entry.lockedUntil); wait > 0 {
+		return wait
+	}
+	return 0
+}
+
+// recordFailure counts a failed login and reports how long the key is now
+// locked out for, or 0 if it still has attempts left
+func (t *attemptTracker) recordFailure(key string) time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(t.lastCleanup) > t.policy.Window {
+		t.cleanupStaleEntries(now)
+	}
+
+	entry, exists := t.entries[key]
+	if !exists || now.Sub(entry.firstFailed) > t.policy.Window {
+		entry = &failedLogins{firstFailed: now}
+		t.entries[key] = entry
+	}
+
+	entry.count++
+	if entry.count < t.maxFailures {
+		return 0
+	}
+
+	// Start over once the lockout ends
+	entry.count = 0
+	entry.firstFailed = now
+	entry.lockedUntil = now.Add(t.policy.Duration)
+	return t.policy.Duration
+}
+
+// reset forgets the key's failures after a successful login
+func (t *attemptTracker) reset(key string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.entries, key)
+}
+
+func (t *attemptTracker) cleanupStaleEntries(now time.Time) {
+	for key, entry := range t.entries {
+		if now.After(entry.lockedUntil) && now.Sub(entry.firstFailed) > t.policy.Window {
+			delete(t.entries, key)
+		}
+	}
+	t.lastCleanup = now
+}
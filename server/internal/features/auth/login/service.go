@@ -6,20 +6,45 @@ import (
 )
 
 type Service struct {
-	repo Repository
+	repo          Repository
+	attempts      *attemptTracker // Per client IP and email
+	emailAttempts *attemptTracker // Per email, from any client
 }
 
 func NewService(repo Repository) *Service {
-	return &Service{repo: repo}
+	policy := LockoutPolicyFromEnv()
+	return &Service{
+		repo:          repo,
+		attempts:      newAttemptTracker(policy, policy.MaxFailures),
+		emailAttempts: newAttemptTracker(policy, policy.MaxEmailFailures),
+	}
 }
 
-func (s *Service) LoginUser(ctx context.Context, input models.LoginUserInput) (*models.User, error) {
+// LoginUser checks the credentials. Too many failures from one client for one
+// email, or for one email from any clients, return a *LockedOutError until the
+// lockout ends, whether or not the email belongs to an account.
+func (s *Service) LoginUser(ctx context.Context, input models.LoginUserInput, clientIP string) (*models.User, error) {
+	key := attemptKey(clientIP, input.Email)
+	email := emailKey(input.Email)
+	if wait := max(s.attempts.lockedFor(key), s.emailAttempts.lockedFor(email)); wait > 0 {
+		return nil, &LockedOutError{RetryAfter: wait}
+	}
+
 	// Get user by email and password
 	user, err := s.repo.LoginUser(ctx, input)
 	if err != nil {
+		// Database errors aren't the client's fault and don't count
+		if err.Error() == "invalid email or password" {
+			if wait := max(s.attempts.recordFailure(key), s.emailAttempts.recordFailure(email)); wait > 0 {
+				return nil, &LockedOutError{RetryAfter: wait}
+			}
+		}
 		return nil, err
 	}
 
+	s.attempts.reset(key)
+	s.emailAttempts.reset(email)
+
 	// You could add additional checks here if needed
 	// For example, check if email is verified
 	if !user.EmailVerified {
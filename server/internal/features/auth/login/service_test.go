@@ -3,7 +3,9 @@ package login_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
@@ -144,7 +146,7 @@ func TestLoginService(t *testing.T) {
 			}
 
 			// Execute
-			user, err := service.LoginUser(context.Background(), input)
+			user, err := service.LoginUser(context.Background(), input, "192.0.2.1")
 
 			// Verify
 			if tc.expectedError {
@@ -170,3 +172,182 @@ func TestLoginService(t *testing.T) {
 		})
 	}
 }
+
+func TestLockoutPolicyFromEnv(t *testing.T) {
+	testCases := []struct {
+		name     string
+		env      map[string]string
+		expected login.LockoutPolicy
+	}{
+		{
+			name:     "Defaults",
+			expected: login.LockoutPolicy{MaxFailures: login.DefaultMaxFailedLogins, MaxEmailFailures: login.DefaultMaxFailedLoginsPerEmail, Window: login.DefaultFailedLoginWindow, Duration: login.DefaultLockoutDuration},
+		},
+		{
+			name:     "Configured",
+			env:      map[string]string{"LOGIN_MAX_FAILURES": "3", "LOGIN_MAX_FAILURES_PER_EMAIL": "10", "LOGIN_FAILURE_WINDOW": "5m", "LOGIN_LOCKOUT_DURATION": "1h"},
+			expected: login.LockoutPolicy{MaxFailures: 3, MaxEmailFailures: 10, Window: 5 * time.Minute, Duration: time.Hour},
+		},
+		{
+			name:     "InvalidValuesUseDefaults",
+			env:      map[string]string{"LOGIN_MAX_FAILURES": "0", "LOGIN_MAX_FAILURES_PER_EMAIL": "many", "LOGIN_FAILURE_WINDOW": "soon", "LOGIN_LOCKOUT_DURATION": "-1m"},
+			expected: login.LockoutPolicy{MaxFailures: login.DefaultMaxFailedLogins, MaxEmailFailures: login.DefaultMaxFailedLoginsPerEmail, Window: login.DefaultFailedLoginWindow, Duration: login.DefaultLockoutDuration},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, key := range []string{"LOGIN_MAX_FAILURES", "LOGIN_MAX_FAILURES_PER_EMAIL", "LOGIN_FAILURE_WINDOW", "LOGIN_LOCKOUT_DURATION"} {
+				t.Setenv(key, tc.env[key])
+			}
+
+			if policy := login.LockoutPolicyFromEnv(); policy != tc.expected {
+				t.Errorf("Expected %+v, got %+v", tc.expected, policy)
+			}
+		})
+	}
+}
+
+func TestLoginServiceLockout(t *testing.T) {
+	const clientIP = "192.0.2.1"
+
+	attempt := func(service *login.Service, email, password, ip string) error {
+		_, err := service.LoginUser(context.Background(), models.LoginUserInput{Email: email, Password: password}, ip)
+		return err
+	}
+	isLockedOut := func(err error) bool {
+		var lockedOut *login.LockedOutError
+		return errors.As(err, &lockedOut)
+	}
+
+	setup := func(t *testing.T, lockoutDuration string) (*login.Service, *MockRepository, string) {
+		t.Setenv("LOGIN_MAX_FAILURES", "3")
+		t.Setenv("LOGIN_FAILURE_WINDOW", "1m")
+		t.Setenv("LOGIN_LOCKOUT_DURATION", lockoutDuration)
+		mockRepo, _, password := setupTestUser()
+		return login.NewService(mockRepo), mockRepo, password
+	}
+
+	t.Run("LocksOutAfterMaxFailures", func(t *testing.T) {
+		service, _, password := setup(t, "10m")
+
+		for i := 1; i < 3; i++ {
+			if err := attempt(service, "test@example.com", "WrongPassword!", clientIP); err == nil || isLockedOut(err) {
+				t.Fatalf("Attempt %d: expected invalid credentials, got %v", i, err)
+			}
+		}
+
+		err := attempt(service, "test@example.com", "WrongPassword!", clientIP)
+		var lockedOut *login.LockedOutError
+		if !errors.As(err, &lockedOut) {
+			t.Fatalf("Expected lockout on the third failure, got %v", err)
+		}
+		if lockedOut.RetryAfter != 10*time.Minute {
+			t.Errorf("Expected RetryAfter 10m, got %v", lockedOut.RetryAfter)
+		}
+
+		// The right password doesn't get through while locked out
+		if err := attempt(service, "test@example.com", password, clientIP); !isLockedOut(err) {
+			t.Errorf("Expected lockout for the correct password, got %v", err)
+		}
+	})
+
+	t.Run("UnknownEmailLocksOutTheSame", func(t *testing.T) {
+		service, _, _ := setup(t, "10m")
+
+		var err error
+		for i := 0; i < 3; i++ {
+			err = attempt(service, "nobody@example.com", "Password123!", clientIP)
+		}
+		if !isLockedOut(err) {
+			t.Errorf("Expected lockout for an unknown email, got %v", err)
+		}
+	})
+
+	t.Run("EmailIsCaseInsensitive", func(t *testing.T) {
+		service, _, _ := setup(t, "10m")
+
+		attempt(service, "test@example.com", "WrongPassword!", clientIP)
+		attempt(service, "TEST@example.com", "WrongPassword!", clientIP)
+		if err := attempt(service, " Test@Example.com", "WrongPassword!", clientIP); !isLockedOut(err) {
+			t.Errorf("Expected lockout across email casings, got %v", err)
+		}
+	})
+
+	t.Run("SuccessResetsFailures", func(t *testing.T) {
+		service, _, password := setup(t, "10m")
+
+		attempt(service, "test@example.com", "WrongPassword!", clientIP)
+		attempt(service, "test@example.com", "WrongPassword!", clientIP)
+		if err := attempt(service, "test@example.com", password, clientIP); err != nil {
+			t.Fatalf("Expected successful login, got %v", err)
+		}
+
+		attempt(service, "test@example.com", "WrongPassword!", clientIP)
+		if err := attempt(service, "test@example.com", "WrongPassword!", clientIP); isLockedOut(err) {
+			t.Error("Expected failures before the successful login to be forgotten")
+		}
+	})
+
+	t.Run("OtherClientsUnaffected", func(t *testing.T) {
+		service, _, password := setup(t, "10m")
+
+		for i := 0; i < 3; i++ {
+			attempt(service, "test@example.com", "WrongPassword!", clientIP)
+		}
+
+		if err := attempt(service, "test@example.com", password, "198.51.100.7"); err != nil {
+			t.Errorf("Expected another client to log in, got %v", err)
+		}
+	})
+
+	t.Run("EmailLockedOutAcrossClients", func(t *testing.T) {
+		t.Setenv("LOGIN_MAX_FAILURES_PER_EMAIL", "5")
+		service, _, password := setup(t, "10m")
+
+		// No single client reaches its own limit of 3
+		for i := 1; i < 5; i++ {
+			ip := fmt.Sprintf("198.51.100.%d", i)
+			if err := attempt(service, "test@example.com", "WrongPassword!", ip); err == nil || isLockedOut(err) {
+				t.Fatalf("Attempt %d: expected invalid credentials, got %v", i, err)
+			}
+		}
+		if err := attempt(service, "test@example.com", "WrongPassword!", "198.51.100.5"); !isLockedOut(err) {
+			t.Fatalf("Expected lockout on the fifth failure for the email, got %v", err)
+		}
+
+		// Every client is locked out of the email, but not out of other emails
+		if err := attempt(service, "test@example.com", password, "203.0.113.1"); !isLockedOut(err) {
+			t.Errorf("Expected lockout from a new client, got %v", err)
+		}
+		if err := attempt(service, "other@example.com", "WrongPassword!", "203.0.113.1"); isLockedOut(err) {
+			t.Errorf("Expected other emails to be unaffected, got %v", err)
+		}
+	})
+
+	t.Run("DatabaseErrorsDontCount", func(t *testing.T) {
+		service, mockRepo, _ := setup(t, "10m")
+		mockRepo.loginUserFunc = func(ctx context.Context, input models.LoginUserInput) (*models.User, error) {
+			return nil, errors.New("database error")
+		}
+
+		for i := 0; i < 5; i++ {
+			if err := attempt(service, "test@example.com", "Password123!", clientIP); isLockedOut(err) {
+				t.Fatalf("Attempt %d: expected database errors not to lock out", i+1)
+			}
+		}
+	})
+
+	t.Run("LockoutExpires", func(t *testing.T) {
+		service, _, password := setup(t, "20ms")
+
+		for i := 0; i < 3; i++ {
+			attempt(service, "test@example.com", "WrongPassword!", clientIP)
+		}
+		time.Sleep(30 * time.Millisecond)
+
+		if err := attempt(service, "test@example.com", password, clientIP); err != nil {
+			t.Errorf("Expected login after the lockout ended, got %v", err)
+		}
+	})
+}
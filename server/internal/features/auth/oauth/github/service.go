@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"os"
@@ -202,7 +203,7 @@ func (s *Service) Authenticate(ctx context.Context, code string) (*models.User,
 		err = s.userRepo.SetEmailVerified(ctx, user.ID, true)
 		if err != nil {
 			// Non-critical error, log but continue
-			fmt.Printf("failed to mark email as verified: %v", err)
+			slog.Warn("Failed to mark email as verified", "user_id", user.ID, "error", err)
 		}
 	}
 
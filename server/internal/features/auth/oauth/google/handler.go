@@ -8,6 +8,7 @@ import (
 
 	"github.com/labstack/echo/v4"
 
+	"black-lotus/internal/common/response"
 	"black-lotus/internal/features/auth/session"
 )
 
@@ -50,7 +51,7 @@ func (h *Handler) GetAuthURL(ctx echo.Context) error {
 	// Pass returnTo as state parameter for security
 	authURL := h.googleService.GetAuthURL(redirectURI, returnTo)
 
-	return ctx.JSON(http.StatusOK, map[string]string{
+	return response.JSON(ctx, http.StatusOK, map[string]string{
 		"url": authURL,
 	})
 }
@@ -60,9 +61,8 @@ func (h *Handler) HandleCallback(ctx echo.Context) error {
 	// Get code from query parameters
 	code := ctx.QueryParam("code")
 	if code == "" {
-		return ctx.JSON(http.StatusBadRequest, map[string]string{
-			"error": "Missing code parameter",
-		})
+		return response.ErrorResponse(ctx, http.StatusBadRequest,
+			response.CodeMissingOAuthCode, "Missing code parameter", nil)
 	}
 
 	// Get state parameter (contains our returnTo value)
@@ -84,17 +84,15 @@ func (h *Handler) HandleCallback(ctx echo.Context) error {
 	// Authenticate with Google
 	user, err := h.googleService.Authenticate(ctx.Request().Context(), code, redirectURI)
 	if err != nil {
-		return ctx.JSON(http.StatusInternalServerError, map[string]string{
-			"error": "Authentication failed: " + err.Error(),
-		})
+		return response.ErrorResponse(ctx, http.StatusInternalServerError,
+			response.CodeOAuthFailed, "Authentication failed: "+err.Error(), nil)
 	}
 
 	// Create session
-	session, err := h.sessionService.CreateSession(ctx.Request().Context(), user.ID)
+	newSession, err := h.sessionService.CreateSession(ctx.Request().Context(), user.ID, session.RefreshTokenDuration)
 	if err != nil {
-		return ctx.JSON(http.StatusInternalServerError, map[string]string{
-			"error": "Failed to create session",
-		})
+		return response.ErrorResponse(ctx, http.StatusInternalServerError,
+			response.CodeInternal, "Failed to create session", nil)
 	}
 
 	// Get frontend URL from environment or use default
@@ -105,28 +103,7 @@ func (h *Handler) HandleCallback(ctx echo.Context) error {
 
 	redirectURL := frontendURL + "/auth/callback?returnTo=" + url.QueryEscape(returnTo)
 
-	// Set access token cookie
-	accessCookie := new(http.Cookie)
-	accessCookie.Name = "access_token"
-	accessCookie.Value = session.AccessToken
-	accessCookie.Expires = session.AccessExpiry
-	accessCookie.Path = "/"
-	accessCookie.HttpOnly = true
-	accessCookie.Secure = true
-	accessCookie.SameSite = http.SameSiteLaxMode // Critical for OAuth
-
-	// Set refresh token cookie
-	refreshCookie := new(http.Cookie)
-	refreshCookie.Name = "refresh_token"
-	refreshCookie.Value = session.RefreshToken
-	refreshCookie.Expires = session.RefreshExpiry
-	refreshCookie.Path = "/"
-	refreshCookie.HttpOnly = true
-	refreshCookie.Secure = true
-	refreshCookie.SameSite = http.SameSiteLaxMode // Critical for OAuth
-
-	ctx.SetCookie(accessCookie)
-	ctx.SetCookie(refreshCookie)
+	session.SetSessionCookies(ctx, newSession, true)
 
 	// Redirect to frontend
 	return ctx.Redirect(http.StatusTemporaryRedirect, redirectURL)
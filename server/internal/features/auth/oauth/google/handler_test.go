@@ -14,6 +14,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 
+	"black-lotus/internal/common/response"
 	"black-lotus/internal/domain/models"
 	"black-lotus/internal/features/auth/oauth/google"
 	"black-lotus/internal/features/auth/session"
@@ -41,18 +42,19 @@ func (m *MockService) GetAuthURL(redirectURI string, state string) string {
 
 // MockSessionService mocks the session service
 type MockSessionService struct {
-	createSessionFunc            func(ctx context.Context, userID uuid.UUID) (*models.Session, error)
+	createSessionFunc            func(ctx context.Context, userID uuid.UUID, refreshDuration time.Duration) (*models.Session, error)
 	validateAccessTokenFunc      func(ctx context.Context, token string) (*models.Session, error)
 	validateRefreshTokenFunc     func(ctx context.Context, token string) (*models.Session, error)
 	refreshAccessTokenFunc       func(ctx context.Context, refreshToken string) (*models.Session, error)
 	endSessionByAccessTokenFunc  func(ctx context.Context, token string) error
 	endSessionByRefreshTokenFunc func(ctx context.Context, token string) error
 	endAllUserSessionsFunc       func(ctx context.Context, userID uuid.UUID) error
+	endOtherUserSessionsFunc     func(ctx context.Context, userID uuid.UUID, keepSessionID uuid.UUID) error
 }
 
-func (m *MockSessionService) CreateSession(ctx context.Context, userID uuid.UUID) (*models.Session, error) {
+func (m *MockSessionService) CreateSession(ctx context.Context, userID uuid.UUID, refreshDuration time.Duration) (*models.Session, error) {
 	if m.createSessionFunc != nil {
-		return m.createSessionFunc(ctx, userID)
+		return m.createSessionFunc(ctx, userID, refreshDuration)
 	}
 	return nil, errors.New("not implemented")
 }
@@ -98,6 +100,13 @@ func (m *MockSessionService) EndAllUserSessions(ctx context.Context, userID uuid
 	return errors.New("not implemented")
 }
 
+func (m *MockSessionService) EndOtherUserSessions(ctx context.Context, userID uuid.UUID, keepSessionID uuid.UUID) error {
+	if m.endOtherUserSessionsFunc != nil {
+		return m.endOtherUserSessionsFunc(ctx, userID, keepSessionID)
+	}
+	return errors.New("not implemented")
+}
+
 var _ session.ServiceInterface = (*MockSessionService)(nil)
 
 // Helper functions that will be common across tests
@@ -118,6 +127,25 @@ func checkResponseStatus(t *testing.T, rec *httptest.ResponseRecorder, expectedS
 	}
 }
 
+// Helper function to check the code and message of an error envelope
+func checkErrorResponse(t *testing.T, rec *httptest.ResponseRecorder, expectedFields map[string]string) {
+	t.Helper()
+	var envelope response.ErrorEnvelope
+	if err := json.Unmarshal(rec.Body.Bytes(), &envelope); err != nil {
+		t.Fatalf("Error parsing response: %v", err)
+	}
+
+	actual := map[string]string{
+		"code":    envelope.Error.Code,
+		"message": envelope.Error.Message,
+	}
+	for key, expectedValue := range expectedFields {
+		if value := actual[key]; value != expectedValue {
+			t.Errorf("Expected %s='%s', got '%s'", key, expectedValue, value)
+		}
+	}
+}
+
 // Helper function to check JSON response fields
 func checkJSONResponse(t *testing.T, rec *httptest.ResponseRecorder, expectedFields map[string]string) {
 	t.Helper()
@@ -273,7 +301,7 @@ func TestHandleCallback(t *testing.T) {
 						Email: "test@example.com",
 					}, nil
 				}
-				mockSession.createSessionFunc = func(ctx context.Context, uid uuid.UUID) (*models.Session, error) {
+				mockSession.createSessionFunc = func(ctx context.Context, uid uuid.UUID, refreshDuration time.Duration) (*models.Session, error) {
 					return createTestSession(uid, "test-access-token", "test-refresh-token"), nil
 				}
 			},
@@ -291,7 +319,8 @@ func TestHandleCallback(t *testing.T) {
 			expectedStatusCode:  http.StatusBadRequest,
 			expectedRedirectURL: "",
 			expectedJSONError: map[string]string{
-				"error": "Missing code parameter",
+				"code":    response.CodeMissingOAuthCode,
+				"message": "Missing code parameter",
 			},
 		},
 		{
@@ -305,7 +334,8 @@ func TestHandleCallback(t *testing.T) {
 			expectedStatusCode:  http.StatusInternalServerError,
 			expectedRedirectURL: "",
 			expectedJSONError: map[string]string{
-				"error": "Authentication failed: authentication failed",
+				"code":    response.CodeOAuthFailed,
+				"message": "Authentication failed: authentication failed",
 			},
 		},
 		{
@@ -319,14 +349,15 @@ func TestHandleCallback(t *testing.T) {
 						Email: "test@example.com",
 					}, nil
 				}
-				mockSession.createSessionFunc = func(ctx context.Context, uid uuid.UUID) (*models.Session, error) {
+				mockSession.createSessionFunc = func(ctx context.Context, uid uuid.UUID, refreshDuration time.Duration) (*models.Session, error) {
 					return nil, errors.New("session creation failed")
 				}
 			},
 			expectedStatusCode:  http.StatusInternalServerError,
 			expectedRedirectURL: "",
 			expectedJSONError: map[string]string{
-				"error": "Failed to create session",
+				"code":    response.CodeInternal,
+				"message": "Failed to create session",
 			},
 		},
 	}
@@ -365,7 +396,7 @@ func TestHandleCallback(t *testing.T) {
 				checkTokenCookies(t, rec, tc.expectedTokens)
 			} else if tc.expectedJSONError != nil {
 				// For error cases, check JSON error response
-				checkJSONResponse(t, rec, tc.expectedJSONError)
+				checkErrorResponse(t, rec, tc.expectedJSONError)
 			}
 		})
 	}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"os"
@@ -80,6 +81,10 @@ func (s *Service) Authenticate(ctx context.Context, code string, redirectURI str
 		return nil, fmt.Errorf("failed to parse token response: %w", err)
 	}
 
+	if resp.StatusCode != http.StatusOK || tokenResp.AccessToken == "" {
+		return nil, fmt.Errorf("failed to exchange code for token: status %d", resp.StatusCode)
+	}
+
 	// Get user info
 	userURL := "https://www.googleapis.com/oauth2/v1/userinfo"
 	req, err = http.NewRequestWithContext(ctx, "GET", userURL, nil)
@@ -109,6 +114,10 @@ func (s *Service) Authenticate(ctx context.Context, code string, redirectURI str
 		return nil, fmt.Errorf("failed to parse user response: %w", err)
 	}
 
+	if userResp.ID == "" || userResp.Email == "" {
+		return nil, fmt.Errorf("no email provided by Google")
+	}
+
 	// Check if OAuth account exists
 	account, err := s.oauthRepo.GetOAuthAccount(ctx, "google", userResp.ID)
 
@@ -137,6 +146,12 @@ func (s *Service) Authenticate(ctx context.Context, code string, redirectURI str
 		return nil, fmt.Errorf("failed to check for existing user: %w", err)
 	}
 
+	// Only link to an existing account when Google vouches for the email,
+	// otherwise anyone could sign in as that user by claiming their address
+	if user != nil && !userResp.VerifiedEmail {
+		return nil, fmt.Errorf("google email is not verified")
+	}
+
 	// If user is nil, create a new one
 	if user == nil {
 		input := models.CreateUserInput{
@@ -169,7 +184,9 @@ func (s *Service) Authenticate(ctx context.Context, code string, redirectURI str
 		err = s.userRepo.SetEmailVerified(ctx, user.ID, true)
 		if err != nil {
 			// Non-critical error, log but continue
-			fmt.Printf("failed to mark email as verified: %v", err)
+			slog.Warn("Failed to mark email as verified", "user_id", user.ID, "error", err)
+		} else {
+			user.EmailVerified = true
 		}
 	}
 
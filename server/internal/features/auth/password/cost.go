@@ -0,0 +1,29 @@
+package password
+
+import (
+	"log/slog"
+	"os"
+	"strconv"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// BcryptCostFromEnv reads BCRYPT_COST for hashing new passwords. Unset or
+// unparsable values use bcrypt.DefaultCost; values outside bcrypt's 4-31 range
+// are logged and use the default too. Existing hashes keep the cost they were
+// made with, so changing it only affects passwords set afterwards.
+func BcryptCostFromEnv() int {
+	value := os.Getenv("BCRYPT_COST")
+	if value == "" {
+		return bcrypt.DefaultCost
+	}
+
+	cost, err := strconv.Atoi(value)
+	if err != nil || cost < bcrypt.MinCost || cost > bcrypt.MaxCost {
+		slog.Warn("Ignoring invalid BCRYPT_COST, using the default",
+			"value", value, "min", bcrypt.MinCost, "max", bcrypt.MaxCost, "default", bcrypt.DefaultCost)
+		return bcrypt.DefaultCost
+	}
+
+	return cost
+}
@@ -0,0 +1,84 @@
+package password
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+
+	"black-lotus/internal/common/binding"
+	"black-lotus/internal/common/response"
+	validation "black-lotus/internal/common/validations"
+	"black-lotus/internal/domain/models"
+	"black-lotus/internal/features/auth/session"
+)
+
+type Handler struct {
+	service        ServiceInterface
+	sessionService session.ServiceInterface
+	validator      *validator.Validate
+}
+
+func NewHandler(service ServiceInterface, sessionService session.ServiceInterface, validator *validator.Validate) *Handler {
+	return &Handler{
+		service:        service,
+		sessionService: sessionService,
+		validator:      validator,
+	}
+}
+
+// ChangePassword replaces the signed-in user's password after checking the current one
+func (h *Handler) ChangePassword(ctx echo.Context) error {
+	userSession, err := session.Authenticate(ctx, h.sessionService)
+	if userSession == nil {
+		return err
+	}
+
+	var input models.ChangePasswordInput
+	if err := binding.Bind(ctx, &input); err != nil {
+		return response.InvalidBody(ctx, err)
+	}
+
+	if err := h.validator.Struct(input); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			messages := validation.MessagesFor(ctx.Request().Header.Get("Accept-Language"))
+			return response.ErrorResponse(ctx, http.StatusBadRequest,
+				response.CodeValidationFailed, messages.Failed(), messages.Details(validationErrors))
+		}
+
+		return response.ErrorResponse(ctx, http.StatusBadRequest,
+			response.CodeValidationFailed, err.Error(), nil)
+	}
+
+	err = h.service.ChangePassword(ctx.Request().Context(), userSession.UserID, input)
+	if err != nil {
+		switch err.Error() {
+		case "current password is incorrect":
+			return response.ErrorResponse(ctx, http.StatusUnauthorized,
+				response.CodeInvalidCredentials, "Current password is incorrect", nil)
+		case "password not set":
+			return response.ErrorResponse(ctx, http.StatusBadRequest,
+				response.CodeInvalidRequest, "This account signs in with a linked provider and has no password", nil)
+		case "user not found":
+			return response.ErrorResponse(ctx, http.StatusNotFound,
+				response.CodeUserNotFound, "User not found", nil)
+		}
+
+		slog.Error("Failed to change password", "user_id", userSession.UserID, "error", err)
+		return response.ErrorResponse(ctx, http.StatusInternalServerError,
+			response.CodeInternal, "Failed to change password", nil)
+	}
+
+	if input.EndOtherSessions {
+		// The password has already changed, so a failure here is logged rather than returned.
+		// An API key has no session of its own, so its zero session ID keeps none.
+		if err := h.sessionService.EndOtherUserSessions(ctx.Request().Context(), userSession.UserID, userSession.ID); err != nil {
+			slog.Error("Failed to end other sessions after password change", "user_id", userSession.UserID, "error", err)
+		}
+	}
+
+	return response.JSON(ctx, http.StatusOK, map[string]string{
+		"message": "Password changed successfully",
+	})
+}
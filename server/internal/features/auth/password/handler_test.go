@@ -0,0 +1,268 @@
+package password_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	validation "black-lotus/internal/common/validations"
+	"black-lotus/internal/domain/models"
+	"black-lotus/internal/features/auth/password"
+	"black-lotus/internal/features/auth/session"
+)
+
+// Define a custom mock service that implements ServiceInterface
+type MockPasswordService struct {
+	changePasswordFunc func(ctx context.Context, userID uuid.UUID, input models.ChangePasswordInput) error
+}
+
+func (m *MockPasswordService) ChangePassword(ctx context.Context, userID uuid.UUID, input models.ChangePasswordInput) error {
+	if m.changePasswordFunc != nil {
+		return m.changePasswordFunc(ctx, userID, input)
+	}
+	return errors.New("ChangePassword not implemented")
+}
+
+// Define a custom mock session service that implements session.ServiceInterface
+type MockSessionService struct {
+	validateAccessTokenFunc  func(ctx context.Context, token string) (*models.Session, error)
+	endOtherUserSessionsFunc func(ctx context.Context, userID uuid.UUID, keepSessionID uuid.UUID) error
+}
+
+func (m *MockSessionService) ValidateAccessToken(ctx context.Context, token string) (*models.Session, error) {
+	if m.validateAccessTokenFunc != nil {
+		return m.validateAccessTokenFunc(ctx, token)
+	}
+	return nil, errors.New("ValidateAccessToken not implemented")
+}
+
+func (m *MockSessionService) ValidateRefreshToken(ctx context.Context, token string) (*models.Session, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockSessionService) CreateSession(ctx context.Context, userID uuid.UUID, refreshDuration time.Duration) (*models.Session, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockSessionService) RefreshAccessToken(ctx context.Context, refreshToken string) (*models.Session, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockSessionService) EndSessionByAccessToken(ctx context.Context, token string) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockSessionService) EndSessionByRefreshToken(ctx context.Context, token string) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockSessionService) EndAllUserSessions(ctx context.Context, userID uuid.UUID) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockSessionService) EndOtherUserSessions(ctx context.Context, userID uuid.UUID, keepSessionID uuid.UUID) error {
+	if m.endOtherUserSessionsFunc != nil {
+		return m.endOtherUserSessionsFunc(ctx, userID, keepSessionID)
+	}
+	return errors.New("not implemented")
+}
+
+// Helper function to create a new test context with a JSON body
+func newTestContext(method, path string, body []byte) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	req := httptest.NewRequest(method, path, bytes.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	return e.NewContext(req, rec), rec
+}
+
+func setupHandlerTest() (*password.Handler, *MockPasswordService, *MockSessionService) {
+	v := validator.New()
+	validation.RegisterPasswordValidators(v)
+
+	mockService := &MockPasswordService{}
+	mockSessionService := &MockSessionService{}
+	handler := password.NewHandler(mockService, mockSessionService, v)
+	return handler, mockService, mockSessionService
+}
+
+func TestHandlerChangePassword(t *testing.T) {
+	testCases := []struct {
+		name                string
+		body                string
+		withCookie          bool
+		serviceErr          error
+		expectedStatus      int
+		expectedDetailField string
+		expectOthersEnded   bool
+	}{
+		{
+			name:           "SuccessfulChange",
+			body:           `{"current_password":"OldPassword1!","new_password":"NewPassword1!"}`,
+			withCookie:     true,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:              "SuccessfulChangeEndingOtherSessions",
+			body:              `{"current_password":"OldPassword1!","new_password":"NewPassword1!","end_other_sessions":true}`,
+			withCookie:        true,
+			expectedStatus:    http.StatusOK,
+			expectOthersEnded: true,
+		},
+		{
+			name:           "NoAccessToken",
+			body:           `{"current_password":"OldPassword1!","new_password":"NewPassword1!"}`,
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "WrongCurrentPassword",
+			body:           `{"current_password":"Wrong1!","new_password":"NewPassword1!"}`,
+			withCookie:     true,
+			serviceErr:     errors.New("current password is incorrect"),
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:                "WeakNewPassword",
+			body:                `{"current_password":"OldPassword1!","new_password":"weak"}`,
+			withCookie:          true,
+			expectedStatus:      http.StatusBadRequest,
+			expectedDetailField: "NewPassword",
+		},
+		{
+			name:                "SameAsCurrent",
+			body:                `{"current_password":"OldPassword1!","new_password":"OldPassword1!"}`,
+			withCookie:          true,
+			expectedStatus:      http.StatusBadRequest,
+			expectedDetailField: "NewPassword",
+		},
+		{
+			name:                "MissingCurrentPassword",
+			body:                `{"new_password":"NewPassword1!"}`,
+			withCookie:          true,
+			expectedStatus:      http.StatusBadRequest,
+			expectedDetailField: "CurrentPassword",
+		},
+		{
+			name:           "PasswordNotSet",
+			body:           `{"current_password":"OldPassword1!","new_password":"NewPassword1!"}`,
+			withCookie:     true,
+			serviceErr:     errors.New("password not set"),
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "ServiceError",
+			body:           `{"current_password":"OldPassword1!","new_password":"NewPassword1!"}`,
+			withCookie:     true,
+			serviceErr:     errors.New("database error"),
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			handler, mockService, mockSession := setupHandlerTest()
+			userID := uuid.New()
+			sessionID := uuid.New()
+			othersEnded := false
+
+			mockSession.validateAccessTokenFunc = func(ctx context.Context, token string) (*models.Session, error) {
+				return &models.Session{
+					ID:           sessionID,
+					UserID:       userID,
+					AccessToken:  token,
+					AccessExpiry: time.Now().Add(15 * time.Minute),
+				}, nil
+			}
+			mockSession.endOtherUserSessionsFunc = func(ctx context.Context, uid uuid.UUID, keepSessionID uuid.UUID) error {
+				if keepSessionID != sessionID {
+					t.Errorf("Expected current session %s to be kept, got %s", sessionID, keepSessionID)
+				}
+				othersEnded = true
+				return nil
+			}
+			mockService.changePasswordFunc = func(ctx context.Context, uid uuid.UUID, input models.ChangePasswordInput) error {
+				return tc.serviceErr
+			}
+
+			c, rec := newTestContext(http.MethodPost, "/api/auth/change-password", []byte(tc.body))
+			if tc.withCookie {
+				c.Request().AddCookie(&http.Cookie{Name: "access_token", Value: "valid_access_token"})
+			}
+
+			// Execute
+			if err := handler.ChangePassword(c); err != nil {
+				t.Errorf("Expected no error, got: %v", err)
+			}
+
+			// Verify
+			if rec.Code != tc.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tc.expectedStatus, rec.Code)
+			}
+
+			if othersEnded != tc.expectOthersEnded {
+				t.Errorf("Expected other sessions ended to be %v, got %v", tc.expectOthersEnded, othersEnded)
+			}
+
+			if tc.expectedDetailField != "" {
+				var body struct {
+					Error struct {
+						Details map[string]string `json:"details"`
+					} `json:"error"`
+				}
+				if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+					t.Fatalf("Failed to unmarshal response: %v", err)
+				}
+				if _, ok := body.Error.Details[tc.expectedDetailField]; !ok {
+					t.Errorf("Expected validation details for %s, got %v", tc.expectedDetailField, body.Error.Details)
+				}
+			}
+		})
+	}
+}
+
+func TestHandlerChangePasswordWithAPIKey(t *testing.T) {
+	// Setup
+	handler, mockService, mockSession := setupHandlerTest()
+	userID := uuid.New()
+	var changedFor, endedFor, keptSession uuid.UUID
+
+	mockService.changePasswordFunc = func(ctx context.Context, uid uuid.UUID, input models.ChangePasswordInput) error {
+		changedFor = uid
+		return nil
+	}
+	mockSession.endOtherUserSessionsFunc = func(ctx context.Context, uid uuid.UUID, keepSessionID uuid.UUID) error {
+		endedFor, keptSession = uid, keepSessionID
+		return nil
+	}
+
+	body := `{"current_password":"OldPassword1!","new_password":"NewPassword1!","end_other_sessions":true}`
+	c, rec := newTestContext(http.MethodPost, "/api/auth/change-password", []byte(body))
+	c.Set(session.APIKeyContextKey, &models.APIKey{ID: uuid.New(), UserID: userID})
+
+	// Execute
+	if err := handler.ChangePassword(c); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	// Verify
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	if changedFor != userID {
+		t.Errorf("Expected password of %s to change, got %s", userID, changedFor)
+	}
+	// The key has no browser session to keep, so every session ends
+	if endedFor != userID || keptSession != uuid.Nil {
+		t.Errorf("Expected all sessions of %s to end, got user %s keeping %s", userID, endedFor, keptSession)
+	}
+}
@@ -0,0 +1,14 @@
+package password
+
+import (
+	"black-lotus/internal/domain/models"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// Repository defines database operations needed to change a password
+type Repository interface {
+	GetUserByID(ctx context.Context, userID uuid.UUID) (*models.User, error)
+	UpdatePassword(ctx context.Context, userID uuid.UUID, hashedPassword string) error
+}
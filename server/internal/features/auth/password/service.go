@@ -0,0 +1,53 @@
+package password
+
+import (
+	"black-lotus/internal/domain/models"
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type ServiceInterface interface {
+	ChangePassword(ctx context.Context, userID uuid.UUID, input models.ChangePasswordInput) error
+}
+
+type Service struct {
+	repo       Repository
+	bcryptCost int
+}
+
+func NewService(repo Repository) *Service {
+	return &Service{repo: repo, bcryptCost: BcryptCostFromEnv()}
+}
+
+// ChangePassword checks the current password against the stored hash and
+// replaces it with the new one
+func (s *Service) ChangePassword(ctx context.Context, userID uuid.UUID, input models.ChangePasswordInput) error {
+	user, err := s.repo.GetUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if user == nil {
+		return errors.New("user not found")
+	}
+
+	// Accounts created through OAuth have no password to change
+	if user.HashedPassword == nil {
+		return errors.New("password not set")
+	}
+
+	err = bcrypt.CompareHashAndPassword([]byte(*user.HashedPassword), []byte(input.CurrentPassword))
+	if err != nil {
+		return errors.New("current password is incorrect")
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(input.NewPassword), s.bcryptCost)
+	if err != nil {
+		return err
+	}
+
+	return s.repo.UpdatePassword(ctx, userID, string(hash))
+}
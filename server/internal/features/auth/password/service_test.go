@@ -0,0 +1,190 @@
+package password_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+
+	"black-lotus/internal/domain/models"
+	"black-lotus/internal/features/auth/password"
+)
+
+// MockRepository implements password.Repository for testing
+type MockRepository struct {
+	getUserByIDFunc    func(ctx context.Context, userID uuid.UUID) (*models.User, error)
+	updatePasswordFunc func(ctx context.Context, userID uuid.UUID, hashedPassword string) error
+}
+
+func (m *MockRepository) GetUserByID(ctx context.Context, userID uuid.UUID) (*models.User, error) {
+	if m.getUserByIDFunc != nil {
+		return m.getUserByIDFunc(ctx, userID)
+	}
+	return nil, errors.New("GetUserByID not implemented")
+}
+
+func (m *MockRepository) UpdatePassword(ctx context.Context, userID uuid.UUID, hashedPassword string) error {
+	if m.updatePasswordFunc != nil {
+		return m.updatePasswordFunc(ctx, userID, hashedPassword)
+	}
+	return errors.New("UpdatePassword not implemented")
+}
+
+// Helper function to setup service for testing
+func setupServiceTest() (*password.Service, *MockRepository) {
+	mockRepo := &MockRepository{}
+	service := password.NewService(mockRepo)
+	return service, mockRepo
+}
+
+func hashPassword(t *testing.T, plain string) *string {
+	t.Helper()
+	hash, err := bcrypt.GenerateFromPassword([]byte(plain), bcrypt.MinCost)
+	if err != nil {
+		t.Fatalf("Failed to hash password: %v", err)
+	}
+	hashStr := string(hash)
+	return &hashStr
+}
+
+func TestServiceChangePassword(t *testing.T) {
+	input := models.ChangePasswordInput{CurrentPassword: "OldPassword1!", NewPassword: "NewPassword1!"}
+
+	testCases := []struct {
+		name          string
+		setupMock     func(*testing.T, *MockRepository, uuid.UUID)
+		expectedError bool
+		errorMessage  string
+	}{
+		{
+			name: "SuccessfulChange",
+			setupMock: func(t *testing.T, mockRepo *MockRepository, userID uuid.UUID) {
+				mockRepo.getUserByIDFunc = func(ctx context.Context, id uuid.UUID) (*models.User, error) {
+					return &models.User{ID: userID, HashedPassword: hashPassword(t, "OldPassword1!")}, nil
+				}
+				mockRepo.updatePasswordFunc = func(ctx context.Context, id uuid.UUID, hashedPassword string) error {
+					if bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte("NewPassword1!")) != nil {
+						t.Error("Expected the stored hash to match the new password")
+					}
+					return nil
+				}
+			},
+			expectedError: false,
+		},
+		{
+			name: "WrongCurrentPassword",
+			setupMock: func(t *testing.T, mockRepo *MockRepository, userID uuid.UUID) {
+				mockRepo.getUserByIDFunc = func(ctx context.Context, id uuid.UUID) (*models.User, error) {
+					return &models.User{ID: userID, HashedPassword: hashPassword(t, "SomethingElse1!")}, nil
+				}
+				mockRepo.updatePasswordFunc = func(ctx context.Context, id uuid.UUID, hashedPassword string) error {
+					t.Error("UpdatePassword should not be called when the current password is wrong")
+					return nil
+				}
+			},
+			expectedError: true,
+			errorMessage:  "current password is incorrect",
+		},
+		{
+			name: "OAuthAccountWithoutPassword",
+			setupMock: func(t *testing.T, mockRepo *MockRepository, userID uuid.UUID) {
+				mockRepo.getUserByIDFunc = func(ctx context.Context, id uuid.UUID) (*models.User, error) {
+					return &models.User{ID: userID}, nil
+				}
+			},
+			expectedError: true,
+			errorMessage:  "password not set",
+		},
+		{
+			name: "UserNotFound",
+			setupMock: func(t *testing.T, mockRepo *MockRepository, userID uuid.UUID) {
+				mockRepo.getUserByIDFunc = func(ctx context.Context, id uuid.UUID) (*models.User, error) {
+					return nil, nil
+				}
+			},
+			expectedError: true,
+			errorMessage:  "user not found",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			service, mockRepo := setupServiceTest()
+			userID := uuid.New()
+			tc.setupMock(t, mockRepo, userID)
+
+			// Execute
+			err := service.ChangePassword(context.Background(), userID, input)
+
+			// Verify
+			if tc.expectedError {
+				if err == nil {
+					t.Error("Expected error, got nil")
+				} else if tc.errorMessage != "" && err.Error() != tc.errorMessage {
+					t.Errorf("Expected error message '%s', got '%s'", tc.errorMessage, err.Error())
+				}
+			} else if err != nil {
+				t.Errorf("Expected no error, got: %v", err)
+			}
+		})
+	}
+}
+
+func TestBcryptCostFromEnv(t *testing.T) {
+	testCases := []struct {
+		name         string
+		value        string
+		expectedCost int
+	}{
+		{name: "Unset", value: "", expectedCost: bcrypt.DefaultCost},
+		{name: "Configured", value: "12", expectedCost: 12},
+		{name: "Minimum", value: "4", expectedCost: bcrypt.MinCost},
+		{name: "Maximum", value: "31", expectedCost: bcrypt.MaxCost},
+		{name: "BelowRange", value: "3", expectedCost: bcrypt.DefaultCost},
+		{name: "AboveRange", value: "32", expectedCost: bcrypt.DefaultCost},
+		{name: "NotANumber", value: "strong", expectedCost: bcrypt.DefaultCost},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("BCRYPT_COST", tc.value)
+
+			if cost := password.BcryptCostFromEnv(); cost != tc.expectedCost {
+				t.Errorf("Expected cost %d, got %d", tc.expectedCost, cost)
+			}
+		})
+	}
+}
+
+func TestServiceChangePasswordUsesConfiguredCost(t *testing.T) {
+	t.Setenv("BCRYPT_COST", "5")
+
+	service, mockRepo := setupServiceTest()
+	userID := uuid.New()
+
+	mockRepo.getUserByIDFunc = func(ctx context.Context, id uuid.UUID) (*models.User, error) {
+		return &models.User{ID: userID, HashedPassword: hashPassword(t, "OldPassword1!")}, nil
+	}
+
+	var storedHash string
+	mockRepo.updatePasswordFunc = func(ctx context.Context, id uuid.UUID, hashedPassword string) error {
+		storedHash = hashedPassword
+		return nil
+	}
+
+	input := models.ChangePasswordInput{CurrentPassword: "OldPassword1!", NewPassword: "NewPassword1!"}
+	if err := service.ChangePassword(context.Background(), userID, input); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	cost, err := bcrypt.Cost([]byte(storedHash))
+	if err != nil {
+		t.Fatalf("Failed to read hash cost: %v", err)
+	}
+	if cost != 5 {
+		t.Errorf("Expected the new hash to use cost 5, got %d", cost)
+	}
+}
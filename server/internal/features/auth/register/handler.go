@@ -1,13 +1,15 @@
 package register
 
 import (
-	"fmt"
-	"log"
+	"log/slog"
 	"net/http"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/labstack/echo/v4"
 
+	"black-lotus/internal/common/binding"
+	"black-lotus/internal/common/response"
+	validation "black-lotus/internal/common/validations"
 	"black-lotus/internal/domain/models"
 	"black-lotus/internal/features/auth/session"
 )
@@ -30,45 +32,20 @@ func (h *Handler) Register(ctx echo.Context) error {
 	var input models.CreateUserInput
 
 	// Validate request data
-	if err := ctx.Bind(&input); err != nil {
-		return ctx.JSON(http.StatusBadRequest, map[string]string{
-			"error": "Invalid request body",
-		})
+	if err := binding.Bind(ctx, &input); err != nil {
+		return response.InvalidBody(ctx, err)
 	}
 
 	if err := h.validator.Struct(input); err != nil {
 		// Extract validation errors
 		if validationErrors, ok := err.(validator.ValidationErrors); ok {
-			errorMessages := make(map[string]string)
-			for _, e := range validationErrors {
-				switch e.Tag() {
-				case "required":
-					errorMessages[e.Field()] = fmt.Sprintf("%s is required", e.Field())
-				case "email":
-					errorMessages[e.Field()] = "Please enter a valid email address"
-				case "min":
-					errorMessages[e.Field()] = fmt.Sprintf("%s must be at least %s characters long", e.Field(), e.Param())
-				case "containsuppercase":
-					errorMessages[e.Field()] = "Password must contain at least one uppercase letter"
-				case "containslowercase":
-					errorMessages[e.Field()] = "Password must contain at least one lowercase letter"
-				case "containsnumber":
-					errorMessages[e.Field()] = "Password must contain at least one number"
-				case "containsspecialchar":
-					errorMessages[e.Field()] = "Password must contain at least one special character"
-				default:
-					errorMessages[e.Field()] = fmt.Sprintf("%s is invalid", e.Field())
-				}
-			}
-			return ctx.JSON(http.StatusBadRequest, map[string]interface{}{
-				"error":   "Validation failed",
-				"details": errorMessages,
-			})
+			messages := validation.MessagesFor(ctx.Request().Header.Get("Accept-Language"))
+			return response.ErrorResponse(ctx, http.StatusBadRequest,
+				response.CodeValidationFailed, messages.Failed(), messages.Details(validationErrors))
 		}
 
-		return ctx.JSON(http.StatusBadRequest, map[string]string{
-			"error": err.Error(),
-		})
+		return response.ErrorResponse(ctx, http.StatusBadRequest,
+			response.CodeValidationFailed, err.Error(), nil)
 	}
 
 	// Create the user
@@ -76,48 +53,23 @@ func (h *Handler) Register(ctx echo.Context) error {
 	if err != nil {
 		// Check for specific errors
 		if err.Error() == "user with this email already exists" {
-			return ctx.JSON(http.StatusConflict, map[string]string{
-				"error": err.Error(),
-			})
+			return response.ErrorResponse(ctx, http.StatusConflict,
+				response.CodeEmailTaken, err.Error(), nil)
 		}
 
-		return ctx.JSON(http.StatusInternalServerError, map[string]string{
-			"error": "Failed to create user",
-		})
+		return response.ErrorResponse(ctx, http.StatusInternalServerError,
+			response.CodeInternal, "Failed to create user", nil)
 	}
 
 	// Create a session to automatically log in the new user
-	session, err := h.sessionService.CreateSession(ctx.Request().Context(), user.ID)
+	newSession, err := h.sessionService.CreateSession(ctx.Request().Context(), user.ID, session.RefreshTokenDuration)
 	if err != nil {
 		// User was created, but session creation failed
 		// We'll still return success but log the error
-		log.Printf("Failed to create session for new user: %v", err)
+		slog.Error("Failed to create session for new user", "user_id", user.ID, "error", err)
 	} else {
-		// Set access token cookie
-		accessCookie := new(http.Cookie)
-		accessCookie.Name = "access_token"
-		accessCookie.Value = session.AccessToken
-		accessCookie.Expires = session.AccessExpiry
-		accessCookie.Path = "/"
-		accessCookie.HttpOnly = true
-		// For production
-		accessCookie.Secure = true
-		accessCookie.SameSite = http.SameSiteStrictMode
-
-		// Set refresh token cookie
-		refreshCookie := new(http.Cookie)
-		refreshCookie.Name = "refresh_token"
-		refreshCookie.Value = session.RefreshToken
-		refreshCookie.Expires = session.RefreshExpiry
-		refreshCookie.Path = "/"
-		refreshCookie.HttpOnly = true
-		// For production
-		refreshCookie.Secure = true
-		refreshCookie.SameSite = http.SameSiteStrictMode
-
-		ctx.SetCookie(accessCookie)
-		ctx.SetCookie(refreshCookie)
+		session.SetSessionCookies(ctx, newSession, true)
 	}
 
-	return ctx.JSON(http.StatusCreated, user)
+	return response.JSON(ctx, http.StatusCreated, user)
 }
@@ -16,24 +16,26 @@ import (
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 
+	"black-lotus/internal/common/response"
 	validation "black-lotus/internal/common/validations"
 	"black-lotus/internal/domain/models"
 	"black-lotus/internal/features/auth/register"
 )
 
 type MockSessionService struct {
-	createSessionFunc            func(ctx context.Context, userID uuid.UUID) (*models.Session, error)
+	createSessionFunc            func(ctx context.Context, userID uuid.UUID, refreshDuration time.Duration) (*models.Session, error)
 	validateAccessTokenFunc      func(ctx context.Context, token string) (*models.Session, error)
 	validateRefreshTokenFunc     func(ctx context.Context, token string) (*models.Session, error)
 	refreshAccessTokenFunc       func(ctx context.Context, refreshToken string) (*models.Session, error)
 	endSessionByAccessTokenFunc  func(ctx context.Context, token string) error
 	endSessionByRefreshTokenFunc func(ctx context.Context, token string) error
 	endAllUserSessionsFunc       func(ctx context.Context, userID uuid.UUID) error
+	endOtherUserSessionsFunc     func(ctx context.Context, userID uuid.UUID, keepSessionID uuid.UUID) error
 }
 
-func (m *MockSessionService) CreateSession(ctx context.Context, userID uuid.UUID) (*models.Session, error) {
+func (m *MockSessionService) CreateSession(ctx context.Context, userID uuid.UUID, refreshDuration time.Duration) (*models.Session, error) {
 	if m.createSessionFunc != nil {
-		return m.createSessionFunc(ctx, userID)
+		return m.createSessionFunc(ctx, userID, refreshDuration)
 	}
 	return nil, errors.New("not implemented")
 }
@@ -79,6 +81,13 @@ func (m *MockSessionService) EndAllUserSessions(ctx context.Context, userID uuid
 	return errors.New("not implemented")
 }
 
+func (m *MockSessionService) EndOtherUserSessions(ctx context.Context, userID uuid.UUID, keepSessionID uuid.UUID) error {
+	if m.endOtherUserSessionsFunc != nil {
+		return m.endOtherUserSessionsFunc(ctx, userID, keepSessionID)
+	}
+	return errors.New("not implemented")
+}
+
 func setupValidator() *validator.Validate {
 	v := validator.New()
 	validation.RegisterPasswordValidators(v)
@@ -199,7 +208,7 @@ func TestRegister(t *testing.T) {
 		}
 
 		// Mock session service
-		mockSessionService.createSessionFunc = func(ctx context.Context, id uuid.UUID) (*models.Session, error) {
+		mockSessionService.createSessionFunc = func(ctx context.Context, id uuid.UUID, refreshDuration time.Duration) (*models.Session, error) {
 			if id == userID {
 				return createTestSession(userID, "test_access_token", "test_refresh_token"), nil
 			}
@@ -252,15 +261,35 @@ func TestRegister(t *testing.T) {
 		checkResponseStatus(t, rec, http.StatusBadRequest)
 
 		// Verify error message
-		var response map[string]string
-		err = json.Unmarshal(rec.Body.Bytes(), &response)
+		var envelope response.ErrorEnvelope
+		err = json.Unmarshal(rec.Body.Bytes(), &envelope)
 		if err != nil {
 			t.Fatalf("Failed to unmarshal response: %v", err)
 		}
 
-		if response["error"] != "Invalid request body" {
-			t.Errorf("Expected 'Invalid request body' error, got: %s", response["error"])
+		if envelope.Error.Message != "Invalid request body" {
+			t.Errorf("Expected 'Invalid request body' error, got: %s", envelope.Error.Message)
+		}
+	})
+
+	t.Run("RoleCannotBeSet", func(t *testing.T) {
+		handler, mockRepo, _ := setupHandler()
+		mockRepo.createUserFunc = func(ctx context.Context, input models.CreateUserInput, hashedPassword *string) (*models.User, error) {
+			t.Error("Expected no user to be created")
+			return nil, errors.New("unexpected call")
+		}
+
+		// Signup has no role field, so asking for one is an unknown field
+		body := []byte(`{"name": "Test User", "email": "test@example.com", "password": "Password123!", "role": "admin"}`)
+		c, rec := newTestContext(http.MethodPost, "/auth/register", body)
+
+		// Execute
+		if err := handler.Register(c); err != nil {
+			t.Errorf("Expected no error, got: %v", err)
 		}
+
+		// Verify
+		checkResponseStatus(t, rec, http.StatusBadRequest)
 	})
 
 	t.Run("ValidationError", func(t *testing.T) {
@@ -287,17 +316,21 @@ func TestRegister(t *testing.T) {
 		checkResponseStatus(t, rec, http.StatusBadRequest)
 
 		// Verify that we get validation error details
-		var response map[string]interface{}
-		err = json.Unmarshal(rec.Body.Bytes(), &response)
+		var envelope response.ErrorEnvelope
+		err = json.Unmarshal(rec.Body.Bytes(), &envelope)
 		if err != nil {
 			t.Fatalf("Failed to unmarshal response: %v", err)
 		}
 
-		if response["error"] != "Validation failed" {
-			t.Errorf("Expected 'Validation failed' error, got: %v", response["error"])
+		if envelope.Error.Message != "Validation failed" {
+			t.Errorf("Expected 'Validation failed' error, got: %v", envelope.Error.Message)
 		}
 
-		details, ok := response["details"].(map[string]interface{})
+		if envelope.Error.Code != response.CodeValidationFailed {
+			t.Errorf("Expected code '%s', got: %s", response.CodeValidationFailed, envelope.Error.Code)
+		}
+
+		details, ok := envelope.Error.Details.(map[string]interface{})
 		if !ok {
 			t.Fatal("Expected details field to be a map")
 		}
@@ -307,6 +340,44 @@ func TestRegister(t *testing.T) {
 		}
 	})
 
+	t.Run("LocalizedValidationError", func(t *testing.T) {
+		handler, _, _ := setupHandler()
+
+		input := models.CreateUserInput{
+			Name:     "",
+			Email:    "test@example.com",
+			Password: stringPtr("Password123!"),
+		}
+		inputJSON, _ := json.Marshal(input)
+
+		c, rec := newTestContext(http.MethodPost, "/auth/register", inputJSON)
+		c.Request().Header.Set("Accept-Language", "es-ES,es;q=0.9,en;q=0.8")
+
+		err := handler.Register(c)
+		if err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+
+		checkResponseStatus(t, rec, http.StatusBadRequest)
+
+		var envelope response.ErrorEnvelope
+		if err := json.Unmarshal(rec.Body.Bytes(), &envelope); err != nil {
+			t.Fatalf("Failed to unmarshal response: %v", err)
+		}
+
+		if envelope.Error.Message != "La validación falló" {
+			t.Errorf("Expected Spanish 'Validation failed', got: %v", envelope.Error.Message)
+		}
+		if envelope.Error.Code != response.CodeValidationFailed {
+			t.Errorf("Expected code '%s', got: %s", response.CodeValidationFailed, envelope.Error.Code)
+		}
+
+		details, ok := envelope.Error.Details.(map[string]interface{})
+		if !ok || details["Name"] != "Name es obligatorio" {
+			t.Errorf("Expected Spanish required message for Name, got: %v", envelope.Error.Details)
+		}
+	})
+
 	t.Run("PasswordValidationError", func(t *testing.T) {
 		handler, _, _ := setupHandler()
 
@@ -331,13 +402,13 @@ func TestRegister(t *testing.T) {
 		checkResponseStatus(t, rec, http.StatusBadRequest)
 
 		// Verify that we get correct password validation error
-		var response map[string]interface{}
-		err = json.Unmarshal(rec.Body.Bytes(), &response)
+		var envelope response.ErrorEnvelope
+		err = json.Unmarshal(rec.Body.Bytes(), &envelope)
 		if err != nil {
 			t.Fatalf("Failed to unmarshal response: %v", err)
 		}
 
-		details, ok := response["details"].(map[string]interface{})
+		details, ok := envelope.Error.Details.(map[string]interface{})
 		if !ok {
 			t.Fatal("Expected details field to be a map")
 		}
@@ -381,14 +452,18 @@ func TestRegister(t *testing.T) {
 		checkResponseStatus(t, rec, http.StatusConflict)
 
 		// Verify error message
-		var response map[string]string
-		err = json.Unmarshal(rec.Body.Bytes(), &response)
+		var envelope response.ErrorEnvelope
+		err = json.Unmarshal(rec.Body.Bytes(), &envelope)
 		if err != nil {
 			t.Fatalf("Failed to unmarshal response: %v", err)
 		}
 
-		if response["error"] != "user with this email already exists" {
-			t.Errorf("Expected duplicate email error, got: %s", response["error"])
+		if envelope.Error.Message != "user with this email already exists" {
+			t.Errorf("Expected duplicate email error, got: %s", envelope.Error.Message)
+		}
+
+		if envelope.Error.Code != response.CodeEmailTaken {
+			t.Errorf("Expected code '%s', got: %s", response.CodeEmailTaken, envelope.Error.Code)
 		}
 	})
 
@@ -421,14 +496,14 @@ func TestRegister(t *testing.T) {
 		checkResponseStatus(t, rec, http.StatusInternalServerError)
 
 		// Verify error message
-		var response map[string]string
-		err = json.Unmarshal(rec.Body.Bytes(), &response)
+		var envelope response.ErrorEnvelope
+		err = json.Unmarshal(rec.Body.Bytes(), &envelope)
 		if err != nil {
 			t.Fatalf("Failed to unmarshal response: %v", err)
 		}
 
-		if response["error"] != "Failed to create user" {
-			t.Errorf("Expected 'Failed to create user' error, got: %s", response["error"])
+		if envelope.Error.Message != "Failed to create user" {
+			t.Errorf("Expected 'Failed to create user' error, got: %s", envelope.Error.Message)
 		}
 	})
 
@@ -461,7 +536,7 @@ func TestRegister(t *testing.T) {
 		}
 
 		// Mock session service to return error
-		mockSessionService.createSessionFunc = func(ctx context.Context, id uuid.UUID) (*models.Session, error) {
+		mockSessionService.createSessionFunc = func(ctx context.Context, id uuid.UUID, refreshDuration time.Duration) (*models.Session, error) {
 			return nil, errors.New("failed to create session")
 		}
 
@@ -513,13 +588,13 @@ func TestRegister(t *testing.T) {
 		checkResponseStatus(t, rec, http.StatusBadRequest)
 
 		// Verify error details
-		var response map[string]interface{}
-		err = json.Unmarshal(rec.Body.Bytes(), &response)
+		var envelope response.ErrorEnvelope
+		err = json.Unmarshal(rec.Body.Bytes(), &envelope)
 		if err != nil {
 			t.Fatalf("Failed to unmarshal response: %v", err)
 		}
 
-		details, ok := response["details"].(map[string]interface{})
+		details, ok := envelope.Error.Details.(map[string]interface{})
 		if !ok {
 			t.Fatal("Expected details field to be a map")
 		}
@@ -558,13 +633,13 @@ func TestRegister(t *testing.T) {
 		checkResponseStatus(t, rec, http.StatusBadRequest)
 
 		// Verify error details
-		var response map[string]interface{}
-		err = json.Unmarshal(rec.Body.Bytes(), &response)
+		var envelope response.ErrorEnvelope
+		err = json.Unmarshal(rec.Body.Bytes(), &envelope)
 		if err != nil {
 			t.Fatalf("Failed to unmarshal response: %v", err)
 		}
 
-		details, ok := response["details"].(map[string]interface{})
+		details, ok := envelope.Error.Details.(map[string]interface{})
 		if !ok {
 			t.Fatal("Expected details field to be a map")
 		}
@@ -603,13 +678,13 @@ func TestRegister(t *testing.T) {
 		checkResponseStatus(t, rec, http.StatusBadRequest)
 
 		// Verify error details
-		var response map[string]interface{}
-		err = json.Unmarshal(rec.Body.Bytes(), &response)
+		var envelope response.ErrorEnvelope
+		err = json.Unmarshal(rec.Body.Bytes(), &envelope)
 		if err != nil {
 			t.Fatalf("Failed to unmarshal response: %v", err)
 		}
 
-		details, ok := response["details"].(map[string]interface{})
+		details, ok := envelope.Error.Details.(map[string]interface{})
 		if !ok {
 			t.Fatal("Expected details field to be a map")
 		}
@@ -648,13 +723,13 @@ func TestRegister(t *testing.T) {
 		checkResponseStatus(t, rec, http.StatusBadRequest)
 
 		// Verify error details
-		var response map[string]interface{}
-		err = json.Unmarshal(rec.Body.Bytes(), &response)
+		var envelope response.ErrorEnvelope
+		err = json.Unmarshal(rec.Body.Bytes(), &envelope)
 		if err != nil {
 			t.Fatalf("Failed to unmarshal response: %v", err)
 		}
 
-		details, ok := response["details"].(map[string]interface{})
+		details, ok := envelope.Error.Details.(map[string]interface{})
 		if !ok {
 			t.Fatal("Expected details field to be a map")
 		}
@@ -694,13 +769,13 @@ func TestRegister(t *testing.T) {
 		checkResponseStatus(t, rec, http.StatusBadRequest)
 
 		// Verify error details
-		var response map[string]interface{}
-		err = json.Unmarshal(rec.Body.Bytes(), &response)
+		var envelope response.ErrorEnvelope
+		err = json.Unmarshal(rec.Body.Bytes(), &envelope)
 		if err != nil {
 			t.Fatalf("Failed to unmarshal response: %v", err)
 		}
 
-		details, ok := response["details"].(map[string]interface{})
+		details, ok := envelope.Error.Details.(map[string]interface{})
 		if !ok {
 			t.Fatal("Expected details field to be a map")
 		}
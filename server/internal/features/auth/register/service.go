@@ -2,6 +2,7 @@ package register
 
 import (
 	"black-lotus/internal/domain/models"
+	"black-lotus/internal/features/auth/password"
 	"context"
 	"errors"
 
@@ -9,11 +10,12 @@ import (
 )
 
 type Service struct {
-	repo Repository
+	repo       Repository
+	bcryptCost int
 }
 
 func NewService(repo Repository) *Service {
-	return &Service{repo: repo}
+	return &Service{repo: repo, bcryptCost: password.BcryptCostFromEnv()}
 }
 
 func (s *Service) Register(ctx context.Context, input models.CreateUserInput) (*models.User, error) {
@@ -30,7 +32,7 @@ func (s *Service) Register(ctx context.Context, input models.CreateUserInput) (*
 	// Hash password if provided
 	var hashedPassword *string
 	if input.Password != nil {
-		hash, err := bcrypt.GenerateFromPassword([]byte(*input.Password), bcrypt.DefaultCost)
+		hash, err := bcrypt.GenerateFromPassword([]byte(*input.Password), s.bcryptCost)
 		if err != nil {
 			return nil, err
 		}
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
 
 	"black-lotus/internal/domain/models"
 	"black-lotus/internal/features/auth/register"
@@ -172,3 +173,35 @@ func TestRegisterService(t *testing.T) {
 		})
 	}
 }
+
+func TestRegisterServiceUsesConfiguredCost(t *testing.T) {
+	t.Setenv("BCRYPT_COST", "6")
+
+	repo := NewMockRepository()
+	service := register.NewService(repo)
+
+	var storedHash string
+	repo.createUserFunc = func(ctx context.Context, input models.CreateUserInput, hashedPassword *string) (*models.User, error) {
+		if hashedPassword != nil {
+			storedHash = *hashedPassword
+		}
+		return &models.User{ID: uuid.New(), Name: input.Name, Email: input.Email, HashedPassword: hashedPassword}, nil
+	}
+
+	_, err := service.Register(context.Background(), models.CreateUserInput{
+		Name:     "Test User",
+		Email:    "cost@example.com",
+		Password: stringPtr("Password123!"),
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	cost, err := bcrypt.Cost([]byte(storedHash))
+	if err != nil {
+		t.Fatalf("Failed to read hash cost: %v", err)
+	}
+	if cost != 6 {
+		t.Errorf("Expected the hash to use cost 6, got %d", cost)
+	}
+}
@@ -0,0 +1,50 @@
+package session
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"black-lotus/internal/common/response"
+	"black-lotus/internal/domain/models"
+)
+
+// APIKeyContextKey is where middleware.APIKeyAuth stores the *models.APIKey a
+// request authenticated with
+const APIKeyContextKey = "api_key"
+
+// Authenticate validates the access token cookie and returns the caller's session.
+// A request already authenticated with an API key gets a session with only
+// UserID set, since there is no browser session behind it.
+// When authentication fails the error response has already been written, the
+// returned session is nil and the returned error is the result of writing it.
+func Authenticate(ctx echo.Context, service ServiceInterface) (*models.Session, error) {
+	if apiKey, ok := ctx.Get(APIKeyContextKey).(*models.APIKey); ok {
+		return &models.Session{UserID: apiKey.UserID}, nil
+	}
+
+	accessCookie, err := ctx.Cookie("access_token")
+	if err != nil {
+		// No access token - check if there's a refresh token
+		_, refreshErr := ctx.Cookie("refresh_token")
+		if refreshErr != nil {
+			return nil, response.ErrorResponse(ctx, http.StatusUnauthorized,
+				response.CodeNotAuthenticated, "Not authenticated", nil)
+		}
+
+		// Has refresh token but no access token - client should refresh
+		return nil, response.ErrorResponse(ctx, http.StatusUnauthorized,
+			response.CodeTokenExpired, "Access token expired", nil)
+	}
+
+	session, err := service.ValidateAccessToken(ctx.Request().Context(), accessCookie.Value)
+	if err != nil {
+		return nil, response.ErrorResponse(ctx, http.StatusUnauthorized,
+			response.CodeTokenInvalid, "Invalid access token", nil)
+	}
+
+	// Expose the caller to request logging
+	ctx.Set("user_id", session.UserID)
+
+	return session, nil
+}
@@ -1,8 +1,23 @@
 package session
 
-import "time"
+import (
+	"os"
+	"time"
+)
 
 const (
-	AccessTokenDuration  = 15 * time.Minute
-	RefreshTokenDuration = 7 * 24 * time.Hour // 1 week
+	AccessTokenDuration            = 15 * time.Minute
+	RefreshTokenDuration           = 7 * 24 * time.Hour  // 1 week
+	RememberMeRefreshTokenDuration = 30 * 24 * time.Hour // Used when the user asks to stay signed in
+	DefaultMinRefreshInterval      = 10 * time.Second    // Shortest gap allowed between two refreshes of one session
 )
+
+// MinRefreshInterval reads SESSION_MIN_REFRESH_INTERVAL (e.g. "30s"), falling
+// back to DefaultMinRefreshInterval when it is unset or invalid
+func MinRefreshInterval() time.Duration {
+	interval, err := time.ParseDuration(os.Getenv("SESSION_MIN_REFRESH_INTERVAL"))
+	if err != nil || interval < 0 {
+		return DefaultMinRefreshInterval
+	}
+	return interval
+}
@@ -0,0 +1,110 @@
+package session
+
+import (
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"black-lotus/internal/domain/models"
+)
+
+const (
+	AccessTokenCookie  = "access_token"
+	RefreshTokenCookie = "refresh_token"
+)
+
+// CookieConfig holds the attributes shared by the token cookies. HttpOnly
+// isn't configurable: the tokens must never be readable from scripts.
+type CookieConfig struct {
+	Secure   bool
+	SameSite http.SameSite
+	Domain   string
+	Path     string
+}
+
+// LoadCookieConfig reads COOKIE_SECURE (default true), COOKIE_SAMESITE
+// (strict, lax or none; default lax), COOKIE_DOMAIN (default host-only) and
+// COOKIE_PATH (default "/"). Invalid values fall back to the defaults.
+// SameSite=None is only honoured by browsers on secure cookies, so it forces Secure.
+func LoadCookieConfig() CookieConfig {
+	config := CookieConfig{
+		Secure:   true,
+		SameSite: http.SameSiteLaxMode,
+		Domain:   os.Getenv("COOKIE_DOMAIN"),
+		Path:     os.Getenv("COOKIE_PATH"),
+	}
+
+	if secure, err := strconv.ParseBool(os.Getenv("COOKIE_SECURE")); err == nil {
+		config.Secure = secure
+	}
+
+	switch strings.ToLower(os.Getenv("COOKIE_SAMESITE")) {
+	case "strict":
+		config.SameSite = http.SameSiteStrictMode
+	case "none":
+		config.SameSite = http.SameSiteNoneMode
+		config.Secure = true
+	}
+
+	if config.Path == "" {
+		config.Path = "/"
+	}
+
+	return config
+}
+
+// SetSessionCookies sets the access and refresh token cookies for a new session.
+// Persistent cookies expire with their tokens; otherwise they are session
+// cookies the browser drops when it closes.
+func SetSessionCookies(ctx echo.Context, session *models.Session, persistent bool) {
+	accessExpiry, refreshExpiry := session.AccessExpiry, session.RefreshExpiry
+	if !persistent {
+		accessExpiry, refreshExpiry = time.Time{}, time.Time{}
+	}
+
+	ctx.SetCookie(tokenCookie(AccessTokenCookie, session.AccessToken, accessExpiry))
+	ctx.SetCookie(tokenCookie(RefreshTokenCookie, session.RefreshToken, refreshExpiry))
+}
+
+// SetAccessCookie replaces the access token cookie after a refresh
+func SetAccessCookie(ctx echo.Context, session *models.Session) {
+	ctx.SetCookie(tokenCookie(AccessTokenCookie, session.AccessToken, session.AccessExpiry))
+}
+
+// ClearAccessCookie tells the browser to drop the access token cookie
+func ClearAccessCookie(ctx echo.Context) {
+	ctx.SetCookie(expiredCookie(AccessTokenCookie))
+}
+
+// ClearSessionCookies tells the browser to drop both token cookies
+func ClearSessionCookies(ctx echo.Context) {
+	ctx.SetCookie(expiredCookie(AccessTokenCookie))
+	ctx.SetCookie(expiredCookie(RefreshTokenCookie))
+}
+
+func tokenCookie(name, value string, expires time.Time) *http.Cookie {
+	config := LoadCookieConfig()
+
+	return &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Expires:  expires,
+		Path:     config.Path,
+		Domain:   config.Domain,
+		HttpOnly: true,
+		Secure:   config.Secure,
+		SameSite: config.SameSite,
+	}
+}
+
+// expiredCookie must match the path and domain the cookie was set with, or
+// the browser treats it as a different cookie and keeps the original
+func expiredCookie(name string) *http.Cookie {
+	cookie := tokenCookie(name, "", time.Time{})
+	cookie.MaxAge = -1 // Expire immediately
+	return cookie
+}
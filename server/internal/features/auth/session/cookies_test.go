@@ -0,0 +1,144 @@
+package session_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"black-lotus/internal/domain/models"
+	"black-lotus/internal/features/auth/session"
+)
+
+func TestSetSessionCookies(t *testing.T) {
+	testCases := []struct {
+		name             string
+		env              map[string]string
+		persistent       bool
+		expectedSecure   bool
+		expectedSameSite http.SameSite
+		expectedDomain   string
+		expectedPath     string
+	}{
+		{
+			name:             "Defaults",
+			persistent:       true,
+			expectedSecure:   true,
+			expectedSameSite: http.SameSiteLaxMode,
+			expectedPath:     "/",
+		},
+		{
+			name:             "Configured",
+			env:              map[string]string{"COOKIE_SECURE": "false", "COOKIE_SAMESITE": "Strict", "COOKIE_DOMAIN": "example.com", "COOKIE_PATH": "/api"},
+			persistent:       true,
+			expectedSecure:   false,
+			expectedSameSite: http.SameSiteStrictMode,
+			expectedDomain:   "example.com",
+			expectedPath:     "/api",
+		},
+		{
+			name:             "SameSiteNoneForcesSecure",
+			env:              map[string]string{"COOKIE_SECURE": "false", "COOKIE_SAMESITE": "none"},
+			persistent:       true,
+			expectedSecure:   true,
+			expectedSameSite: http.SameSiteNoneMode,
+			expectedPath:     "/",
+		},
+		{
+			name:             "InvalidValuesUseDefaults",
+			env:              map[string]string{"COOKIE_SECURE": "sometimes", "COOKIE_SAMESITE": "loose"},
+			persistent:       true,
+			expectedSecure:   true,
+			expectedSameSite: http.SameSiteLaxMode,
+			expectedPath:     "/",
+		},
+		{
+			name:             "BrowserSession",
+			persistent:       false,
+			expectedSecure:   true,
+			expectedSameSite: http.SameSiteLaxMode,
+			expectedPath:     "/",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, key := range []string{"COOKIE_SECURE", "COOKIE_SAMESITE", "COOKIE_DOMAIN", "COOKIE_PATH"} {
+				t.Setenv(key, tc.env[key])
+			}
+
+			c, rec := newCookieContext()
+			userSession := &models.Session{
+				ID:            uuid.New(),
+				AccessToken:   "access",
+				RefreshToken:  "refresh",
+				AccessExpiry:  time.Now().Add(session.AccessTokenDuration),
+				RefreshExpiry: time.Now().Add(session.RefreshTokenDuration),
+			}
+
+			session.SetSessionCookies(c, userSession, tc.persistent)
+
+			cookies := rec.Result().Cookies()
+			if len(cookies) != 2 {
+				t.Fatalf("Expected 2 cookies, got %d", len(cookies))
+			}
+
+			for _, cookie := range cookies {
+				if !cookie.HttpOnly {
+					t.Errorf("Expected %s to be HttpOnly", cookie.Name)
+				}
+				if cookie.Secure != tc.expectedSecure {
+					t.Errorf("Expected %s Secure=%v, got %v", cookie.Name, tc.expectedSecure, cookie.Secure)
+				}
+				if cookie.SameSite != tc.expectedSameSite {
+					t.Errorf("Expected %s SameSite=%v, got %v", cookie.Name, tc.expectedSameSite, cookie.SameSite)
+				}
+				if cookie.Domain != tc.expectedDomain {
+					t.Errorf("Expected %s Domain=%q, got %q", cookie.Name, tc.expectedDomain, cookie.Domain)
+				}
+				if cookie.Path != tc.expectedPath {
+					t.Errorf("Expected %s Path=%q, got %q", cookie.Name, tc.expectedPath, cookie.Path)
+				}
+				if cookie.Expires.IsZero() == tc.persistent {
+					t.Errorf("Expected %s persistent=%v, got expiry %v", cookie.Name, tc.persistent, cookie.Expires)
+				}
+			}
+		})
+	}
+}
+
+func TestClearSessionCookies(t *testing.T) {
+	t.Setenv("COOKIE_DOMAIN", "example.com")
+	t.Setenv("COOKIE_PATH", "/api")
+
+	c, rec := newCookieContext()
+	session.ClearSessionCookies(c)
+
+	cookies := rec.Result().Cookies()
+	if len(cookies) != 2 {
+		t.Fatalf("Expected 2 cookies, got %d", len(cookies))
+	}
+
+	// Clearing only works when path and domain match the cookies that were set
+	for _, cookie := range cookies {
+		if cookie.MaxAge >= 0 || cookie.Value != "" {
+			t.Errorf("Expected %s to be expired, got MaxAge=%d value=%q", cookie.Name, cookie.MaxAge, cookie.Value)
+		}
+		if cookie.Domain != "example.com" || cookie.Path != "/api" {
+			t.Errorf("Expected %s on example.com/api, got %s%s", cookie.Name, cookie.Domain, cookie.Path)
+		}
+		if !cookie.HttpOnly {
+			t.Errorf("Expected %s to be HttpOnly", cookie.Name)
+		}
+	}
+}
+
+func newCookieContext() (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/login", nil)
+	rec := httptest.NewRecorder()
+	return e.NewContext(req, rec), rec
+}
@@ -1,10 +1,15 @@
 package session
 
 import (
-	"log"
+	"errors"
+	"log/slog"
 	"net/http"
 
 	"github.com/labstack/echo/v4"
+
+	"black-lotus/internal/common/binding"
+	"black-lotus/internal/common/response"
+	"black-lotus/internal/domain/models"
 )
 
 type Handler struct {
@@ -21,34 +26,24 @@ func (h *Handler) RefreshToken(ctx echo.Context) error {
 	// Get refresh token from cookie
 	refreshCookie, err := ctx.Cookie("refresh_token")
 	if err != nil {
-		return ctx.JSON(http.StatusUnauthorized, map[string]string{
-			"error": "No refresh token provided",
-		})
+		return response.ErrorResponse(ctx, http.StatusUnauthorized,
+			response.CodeRefreshTokenMissing, "No refresh token provided", nil)
 	}
 
 	// Use the refresh token to get a new access token
 	session, err := h.service.RefreshAccessToken(ctx.Request().Context(), refreshCookie.Value)
 	if err != nil {
-		return ctx.JSON(http.StatusUnauthorized, map[string]string{
-			"error": "Invalid refresh token",
-		})
+		var tooSoon *RefreshTooSoonError
+		if errors.As(err, &tooSoon) {
+			return response.RateLimited(ctx, tooSoon.RetryAfter)
+		}
+		return response.ErrorResponse(ctx, http.StatusUnauthorized,
+			response.CodeRefreshTokenInvalid, "Invalid refresh token", nil)
 	}
 
-	// Set the new access token cookie
-	accessCookie := new(http.Cookie)
-	accessCookie.Name = "access_token"
-	accessCookie.Value = session.AccessToken
-	accessCookie.Expires = session.AccessExpiry
-	accessCookie.Path = "/"
-	accessCookie.HttpOnly = true
-
-	// For production
-	accessCookie.Secure = true
-	accessCookie.SameSite = http.SameSiteLaxMode
-
-	ctx.SetCookie(accessCookie)
+	SetAccessCookie(ctx, session)
 
-	return ctx.JSON(http.StatusOK, map[string]string{
+	return response.JSON(ctx, http.StatusOK, map[string]string{
 		"message": "Access token refreshed successfully",
 	})
 }
@@ -60,7 +55,7 @@ func (h *Handler) LogoutUser(ctx echo.Context) error {
 
 	// Check if already logged out
 	if accessErr != nil && refreshErr != nil {
-		return ctx.JSON(http.StatusOK, map[string]string{
+		return response.JSON(ctx, http.StatusOK, map[string]string{
 			"message": "Already logged out",
 		})
 	}
@@ -70,7 +65,7 @@ func (h *Handler) LogoutUser(ctx echo.Context) error {
 		err := h.service.EndSessionByAccessToken(ctx.Request().Context(), accessCookie.Value)
 		if err != nil {
 			// Log the error but continue
-			log.Printf("Failed to end session by access token: %v", err)
+			slog.Warn("Failed to end session by access token", "error", err)
 		}
 	}
 
@@ -79,35 +74,68 @@ func (h *Handler) LogoutUser(ctx echo.Context) error {
 		err := h.service.EndSessionByRefreshToken(ctx.Request().Context(), refreshCookie.Value)
 		if err != nil {
 			// Log the error but continue
-			log.Printf("Failed to end session by refresh token: %v", err)
+			slog.Warn("Failed to end session by refresh token", "error", err)
 		}
 	}
 
-	// Clear access token cookie
-	accessCookieClear := new(http.Cookie)
-	accessCookieClear.Name = "access_token"
-	accessCookieClear.Value = ""
-	accessCookieClear.MaxAge = -1 // Expire immediately
-	accessCookieClear.Path = "/"
-	ctx.SetCookie(accessCookieClear)
-
-	// Clear refresh token cookie
-	refreshCookieClear := new(http.Cookie)
-	refreshCookieClear.Name = "refresh_token"
-	refreshCookieClear.Value = ""
-	refreshCookieClear.MaxAge = -1 // Expire immediately
-	refreshCookieClear.Path = "/"
-	ctx.SetCookie(refreshCookieClear)
-
-	return ctx.JSON(http.StatusOK, map[string]string{
+	ClearSessionCookies(ctx)
+
+	return response.JSON(ctx, http.StatusOK, map[string]string{
 		"message": "Successfully logged out",
 	})
 }
 
+// RevokeSessions signs out the caller's other sessions, or all of them when
+// the current one is explicitly included. A request made with an API key has
+// no current session, so all_except_current ends every browser session.
+func (h *Handler) RevokeSessions(ctx echo.Context) error {
+	current, err := Authenticate(ctx, h.service)
+	if current == nil {
+		return err
+	}
+
+	var input models.RevokeSessionsInput
+	if err := binding.Bind(ctx, &input); err != nil {
+		return response.InvalidBody(ctx, err)
+	}
+
+	reqCtx := ctx.Request().Context()
+	switch input.Filter {
+	case models.SessionFilterAllExceptCurrent:
+		err = h.service.EndOtherUserSessions(reqCtx, current.UserID, current.ID)
+	case models.SessionFilterAll:
+		if !input.IncludeCurrent {
+			return response.ErrorResponse(ctx, http.StatusBadRequest, response.CodeValidationFailed,
+				"Revoking all sessions signs you out here too; set include_current to confirm", nil)
+		}
+		err = h.service.EndAllUserSessions(reqCtx, current.UserID)
+	default:
+		return response.ErrorResponse(ctx, http.StatusBadRequest, response.CodeValidationFailed,
+			"filter must be all_except_current or all", nil)
+	}
+
+	if err != nil {
+		slog.Error("Failed to revoke sessions", "user_id", current.UserID, "filter", input.Filter, "error", err)
+		return response.ErrorResponse(ctx, http.StatusInternalServerError,
+			response.CodeInternal, "Failed to revoke sessions", nil)
+	}
+
+	if input.Filter == models.SessionFilterAll {
+		ClearSessionCookies(ctx)
+	}
+
+	slog.Info("Sessions revoked", "user_id", current.UserID, "filter", input.Filter)
+	return response.JSON(ctx, http.StatusOK, map[string]string{
+		"message": "Sessions revoked",
+	})
+}
+
+// GetCSRFToken returns the token set by the CSRF middleware. Clients must send it
+// back in the X-CSRF-Token header on every POST, PUT and DELETE request.
 func (h *Handler) GetCSRFToken(ctx echo.Context) error {
 	token := ctx.Get("csrf").(string)
 
-	return ctx.JSON(http.StatusOK, map[string]string{
+	return response.JSON(ctx, http.StatusOK, map[string]string{
 		"csrf_token": token,
 	})
 }
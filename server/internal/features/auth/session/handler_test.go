@@ -13,16 +13,18 @@ import (
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 
+	"black-lotus/internal/common/response"
 	"black-lotus/internal/domain/models"
 	"black-lotus/internal/features/auth/session"
 )
 
 // MockRepository implements session.Repository for testing
 type MockRepository struct {
-	refreshAccessTokenFunc       func(ctx context.Context, sessionID uuid.UUID) (*models.Session, error)
+	refreshAccessTokenFunc       func(ctx context.Context, sessionID uuid.UUID, minInterval time.Duration) (*models.Session, error)
 	endSessionByAccessTokenFunc  func(ctx context.Context, accessToken string) error
 	endSessionByRefreshTokenFunc func(ctx context.Context, refreshToken string) error
 	endAllUserSessionsFunc       func(ctx context.Context, userID uuid.UUID) error
+	endOtherUserSessionsFunc     func(ctx context.Context, userID uuid.UUID, keepSessionID uuid.UUID) error
 	getSessionByAccessTokenFunc  func(ctx context.Context, token string) (*models.Session, error)
 	getSessionByRefreshTokenFunc func(ctx context.Context, token string) (*models.Session, error)
 	createSessionFunc            func(ctx context.Context, userID uuid.UUID, accessDuration, refreshDuration time.Duration) (*models.Session, error)
@@ -57,9 +59,9 @@ func (m *MockRepository) CreateSession(ctx context.Context, userID uuid.UUID, ac
 	}, nil
 }
 
-func (m *MockRepository) RefreshAccessToken(ctx context.Context, sessionID uuid.UUID) (*models.Session, error) {
+func (m *MockRepository) RefreshAccessToken(ctx context.Context, sessionID uuid.UUID, minInterval time.Duration) (*models.Session, error) {
 	if m.refreshAccessTokenFunc != nil {
-		return m.refreshAccessTokenFunc(ctx, sessionID)
+		return m.refreshAccessTokenFunc(ctx, sessionID, minInterval)
 	}
 	return nil, errors.New("RefreshAccessToken not implemented")
 }
@@ -85,6 +87,13 @@ func (m *MockRepository) DeleteUserSessions(ctx context.Context, userID uuid.UUI
 	return errors.New("DeleteUserSessions not implemented")
 }
 
+func (m *MockRepository) DeleteOtherUserSessions(ctx context.Context, userID uuid.UUID, keepSessionID uuid.UUID) error {
+	if m.endOtherUserSessionsFunc != nil {
+		return m.endOtherUserSessionsFunc(ctx, userID, keepSessionID)
+	}
+	return errors.New("DeleteOtherUserSessions not implemented")
+}
+
 // Helper functions
 
 // Helper function to create a new test context
@@ -295,7 +304,7 @@ func TestRefreshToken(t *testing.T) {
 						RefreshExpiry: time.Now().Add(7 * 24 * time.Hour),
 					}, nil
 				}
-				mockRepo.refreshAccessTokenFunc = func(ctx context.Context, sessionID uuid.UUID) (*models.Session, error) {
+				mockRepo.refreshAccessTokenFunc = func(ctx context.Context, sessionID uuid.UUID, minInterval time.Duration) (*models.Session, error) {
 					return &models.Session{
 						ID:            uuid.New(),
 						UserID:        uuid.New(),
@@ -349,7 +358,7 @@ func TestRefreshToken(t *testing.T) {
 						RefreshExpiry: time.Now().Add(7 * 24 * time.Hour),
 					}, nil
 				}
-				mockRepo.refreshAccessTokenFunc = func(ctx context.Context, sessionID uuid.UUID) (*models.Session, error) {
+				mockRepo.refreshAccessTokenFunc = func(ctx context.Context, sessionID uuid.UUID, minInterval time.Duration) (*models.Session, error) {
 					return nil, errors.New("failed to refresh access token")
 				}
 			},
@@ -357,6 +366,31 @@ func TestRefreshToken(t *testing.T) {
 			expectedMessage:   "Invalid refresh token",
 			checkAccessCookie: false,
 		},
+		{
+			name: "RefreshedTooRecently",
+			setupCookies: []*http.Cookie{
+				{Name: "refresh_token", Value: "valid_refresh_token"},
+			},
+			mockRepoFunc: func(mockRepo *MockRepository) {
+				lastRefreshed := time.Now()
+				mockRepo.getSessionByRefreshTokenFunc = func(ctx context.Context, token string) (*models.Session, error) {
+					return &models.Session{
+						ID:              uuid.New(),
+						UserID:          uuid.New(),
+						AccessExpiry:    time.Now().Add(15 * time.Minute),
+						RefreshExpiry:   time.Now().Add(7 * 24 * time.Hour),
+						LastRefreshedAt: &lastRefreshed,
+					}, nil
+				}
+				mockRepo.refreshAccessTokenFunc = func(ctx context.Context, sessionID uuid.UUID, minInterval time.Duration) (*models.Session, error) {
+					t.Error("Access token should not be rotated")
+					return nil, errors.New("unexpected refresh")
+				}
+			},
+			expectedStatus:    http.StatusTooManyRequests,
+			expectedMessage:   "Too many requests, please try again later",
+			checkAccessCookie: false,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -382,21 +416,30 @@ func TestRefreshToken(t *testing.T) {
 			// Check status code
 			checkResponseStatus(t, rec, tc.expectedStatus)
 
-			// Verify response
-			var response map[string]string
-			err = json.Unmarshal(rec.Body.Bytes(), &response)
-			if err != nil {
-				t.Fatalf("Failed to unmarshal response: %v", err)
+			if tc.expectedStatus == http.StatusTooManyRequests && rec.Header().Get(echo.HeaderRetryAfter) == "" {
+				t.Error("Expected Retry-After header to be set")
 			}
 
 			// Check for message or error
 			if tc.expectedStatus == http.StatusOK {
-				if response["message"] != tc.expectedMessage {
-					t.Errorf("Expected message '%s', got '%s'", tc.expectedMessage, response["message"])
+				var body map[string]string
+				err = json.Unmarshal(rec.Body.Bytes(), &body)
+				if err != nil {
+					t.Fatalf("Failed to unmarshal response: %v", err)
+				}
+
+				if body["message"] != tc.expectedMessage {
+					t.Errorf("Expected message '%s', got '%s'", tc.expectedMessage, body["message"])
 				}
 			} else {
-				if response["error"] != tc.expectedMessage {
-					t.Errorf("Expected error '%s', got '%s'", tc.expectedMessage, response["error"])
+				var envelope response.ErrorEnvelope
+				err = json.Unmarshal(rec.Body.Bytes(), &envelope)
+				if err != nil {
+					t.Fatalf("Failed to unmarshal response: %v", err)
+				}
+
+				if envelope.Error.Message != tc.expectedMessage {
+					t.Errorf("Expected error '%s', got '%s'", tc.expectedMessage, envelope.Error.Message)
 				}
 			}
 
@@ -451,3 +494,97 @@ func TestGetCSRFToken(t *testing.T) {
 		}
 	})
 }
+
+func TestRevokeSessions(t *testing.T) {
+	currentID := uuid.New()
+	userID := uuid.New()
+
+	testCases := []struct {
+		name            string
+		body            string
+		withCookie      bool
+		expectedStatus  int
+		expectOthers    bool
+		expectAll       bool
+		expectedCleared bool
+	}{
+		{
+			name:           "AllExceptCurrent",
+			body:           `{"filter":"all_except_current"}`,
+			withCookie:     true,
+			expectedStatus: http.StatusOK,
+			expectOthers:   true,
+		},
+		{
+			name:            "AllIncludingCurrent",
+			body:            `{"filter":"all","include_current":true}`,
+			withCookie:      true,
+			expectedStatus:  http.StatusOK,
+			expectAll:       true,
+			expectedCleared: true,
+		},
+		{
+			name:           "AllWithoutConfirmation",
+			body:           `{"filter":"all"}`,
+			withCookie:     true,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "UnknownFilter",
+			body:           `{"filter":"name_contains"}`,
+			withCookie:     true,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "NotLoggedIn",
+			body:           `{"filter":"all_except_current"}`,
+			expectedStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			handler, mockRepo := setupHandler()
+			mockRepo.getSessionByAccessTokenFunc = func(ctx context.Context, token string) (*models.Session, error) {
+				return &models.Session{ID: currentID, UserID: userID}, nil
+			}
+			var endedOthers, endedAll bool
+			mockRepo.endOtherUserSessionsFunc = func(ctx context.Context, id uuid.UUID, keepSessionID uuid.UUID) error {
+				if keepSessionID != currentID {
+					t.Errorf("Expected to keep session %s, got %s", currentID, keepSessionID)
+				}
+				endedOthers = true
+				return nil
+			}
+			mockRepo.endAllUserSessionsFunc = func(ctx context.Context, id uuid.UUID) error {
+				endedAll = true
+				return nil
+			}
+
+			c, rec := newTestContext(http.MethodPost, "/api/auth/sessions/revoke", []byte(tc.body))
+			if tc.withCookie {
+				addCookies(c, &http.Cookie{Name: session.AccessTokenCookie, Value: "current_access_token"})
+			}
+
+			// Execute
+			if err := handler.RevokeSessions(c); err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			// Verify
+			checkResponseStatus(t, rec, tc.expectedStatus)
+			if endedOthers != tc.expectOthers {
+				t.Errorf("Expected other sessions ended %v, got %v", tc.expectOthers, endedOthers)
+			}
+			if endedAll != tc.expectAll {
+				t.Errorf("Expected all sessions ended %v, got %v", tc.expectAll, endedAll)
+			}
+			if tc.expectedCleared {
+				checkCookiesCleared(t, rec, session.AccessTokenCookie, session.RefreshTokenCookie)
+			} else if len(rec.Result().Cookies()) != 0 {
+				t.Error("Expected the session cookies to be left alone")
+			}
+		})
+	}
+}
@@ -13,8 +13,9 @@ type Repository interface {
 	CreateSession(ctx context.Context, userID uuid.UUID, accessDuration, refreshDuration time.Duration) (*models.Session, error)
 	GetSessionByAccessToken(ctx context.Context, token string) (*models.Session, error)
 	GetSessionByRefreshToken(ctx context.Context, token string) (*models.Session, error)
-	RefreshAccessToken(ctx context.Context, sessionID uuid.UUID) (*models.Session, error)
+	RefreshAccessToken(ctx context.Context, sessionID uuid.UUID, minInterval time.Duration) (*models.Session, error)
 	DeleteSessionByAccessToken(ctx context.Context, token string) error
 	DeleteSessionByRefreshToken(ctx context.Context, token string) error
 	DeleteUserSessions(ctx context.Context, userID uuid.UUID) error
+	DeleteOtherUserSessions(ctx context.Context, userID uuid.UUID, keepSessionID uuid.UUID) error
 }
@@ -2,6 +2,8 @@ package session
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -9,25 +11,47 @@ import (
 )
 
 type Service struct {
-	repo Repository
+	repo               Repository
+	minRefreshInterval time.Duration
+}
+
+// ErrRefreshTooSoon is returned by Repository.RefreshAccessToken when the
+// session exists but was refreshed within the minimum interval, as happens
+// when a concurrent request rotates the token first
+var ErrRefreshTooSoon = errors.New("refresh too soon")
+
+// RefreshTooSoonError is returned when a session is refreshed again before its
+// minimum refresh interval has passed. The current access token stays valid.
+type RefreshTooSoonError struct {
+	RetryAfter time.Duration
+}
+
+func (e *RefreshTooSoonError) Error() string {
+	return "refresh too soon"
 }
 
 type ServiceInterface interface {
-	CreateSession(ctx context.Context, userID uuid.UUID) (*models.Session, error)
+	CreateSession(ctx context.Context, userID uuid.UUID, refreshDuration time.Duration) (*models.Session, error)
 	ValidateAccessToken(ctx context.Context, token string) (*models.Session, error)
 	ValidateRefreshToken(ctx context.Context, token string) (*models.Session, error)
 	RefreshAccessToken(ctx context.Context, refreshToken string) (*models.Session, error)
 	EndSessionByAccessToken(ctx context.Context, token string) error
 	EndSessionByRefreshToken(ctx context.Context, token string) error
 	EndAllUserSessions(ctx context.Context, userID uuid.UUID) error
+	EndOtherUserSessions(ctx context.Context, userID uuid.UUID, keepSessionID uuid.UUID) error
 }
 
 func NewService(repo Repository) ServiceInterface {
-	return &Service{repo: repo}
+	return &Service{repo: repo, minRefreshInterval: MinRefreshInterval()}
 }
 
-func (s *Service) CreateSession(ctx context.Context, userID uuid.UUID) (*models.Session, error) {
-	return s.repo.CreateSession(ctx, userID, AccessTokenDuration, RefreshTokenDuration)
+// CreateSession starts a session whose refresh token lasts refreshDuration,
+// or RefreshTokenDuration when it is zero
+func (s *Service) CreateSession(ctx context.Context, userID uuid.UUID, refreshDuration time.Duration) (*models.Session, error) {
+	if refreshDuration <= 0 {
+		refreshDuration = RefreshTokenDuration
+	}
+	return s.repo.CreateSession(ctx, userID, AccessTokenDuration, refreshDuration)
 }
 
 func (s *Service) ValidateAccessToken(ctx context.Context, token string) (*models.Session, error) {
@@ -45,8 +69,24 @@ func (s *Service) RefreshAccessToken(ctx context.Context, refreshToken string) (
 		return nil, err
 	}
 
+	// Don't rotate the access token if this session was refreshed moments ago
+	if session.LastRefreshedAt != nil {
+		if wait := s.minRefreshInterval - time.Since(*session.LastRefreshedAt); wait > 0 {
+			return nil, &RefreshTooSoonError{RetryAfter: wait}
+		}
+	}
+
 	// Then get a new access token
-	return s.repo.RefreshAccessToken(ctx, session.ID)
+	refreshed, err := s.repo.RefreshAccessToken(ctx, session.ID, s.minRefreshInterval)
+	if err != nil {
+		// A concurrent request refreshed the session between our read and update
+		if errors.Is(err, ErrRefreshTooSoon) {
+			return nil, &RefreshTooSoonError{RetryAfter: s.minRefreshInterval}
+		}
+		return nil, err
+	}
+
+	return refreshed, nil
 }
 
 func (s *Service) EndSessionByAccessToken(ctx context.Context, token string) error {
@@ -60,3 +100,8 @@ func (s *Service) EndSessionByRefreshToken(ctx context.Context, token string) er
 func (s *Service) EndAllUserSessions(ctx context.Context, userID uuid.UUID) error {
 	return s.repo.DeleteUserSessions(ctx, userID)
 }
+
+// EndOtherUserSessions signs the user out everywhere except the given session
+func (s *Service) EndOtherUserSessions(ctx context.Context, userID uuid.UUID, keepSessionID uuid.UUID) error {
+	return s.repo.DeleteOtherUserSessions(ctx, userID, keepSessionID)
+}
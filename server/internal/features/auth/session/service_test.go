@@ -78,7 +78,7 @@ func TestServiceCreateSession(t *testing.T) {
 			expectedSession := tc.mockSetup(t, mockRepo, tc.userID)
 
 			// Execute
-			result, err := service.CreateSession(context.Background(), tc.userID)
+			result, err := service.CreateSession(context.Background(), tc.userID, 0)
 
 			// Verify
 			if tc.expectedError {
@@ -102,6 +102,25 @@ func TestServiceCreateSession(t *testing.T) {
 	}
 }
 
+func TestServiceCreateSessionRefreshDuration(t *testing.T) {
+	service, mockRepo := setupServiceTest()
+	userID := uuid.New()
+
+	var gotRefresh time.Duration
+	mockRepo.createSessionFunc = func(ctx context.Context, uid uuid.UUID, accessDuration, refreshDuration time.Duration) (*models.Session, error) {
+		gotRefresh = refreshDuration
+		return &models.Session{ID: uuid.New(), UserID: uid}, nil
+	}
+
+	if _, err := service.CreateSession(context.Background(), userID, session.RememberMeRefreshTokenDuration); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if gotRefresh != session.RememberMeRefreshTokenDuration {
+		t.Errorf("Expected refresh duration %v, got %v", session.RememberMeRefreshTokenDuration, gotRefresh)
+	}
+}
+
 func TestServiceValidateAccessToken(t *testing.T) {
 	testCases := []struct {
 		name          string
@@ -256,6 +275,102 @@ func TestServiceValidateRefreshToken(t *testing.T) {
 	}
 }
 
+func TestServiceRefreshAccessTokenMinInterval(t *testing.T) {
+	t.Run("RapidRefreshesRotateOnce", func(t *testing.T) {
+		service, mockRepo := setupServiceTest()
+
+		// Simulate a single stored session whose last_refreshed_at is updated on rotation
+		stored := &models.Session{
+			ID:            uuid.New(),
+			UserID:        uuid.New(),
+			AccessExpiry:  time.Now().Add(-1 * time.Minute),
+			RefreshExpiry: time.Now().Add(7 * 24 * time.Hour),
+		}
+		rotations := 0
+
+		mockRepo.getSessionByRefreshTokenFunc = func(ctx context.Context, token string) (*models.Session, error) {
+			current := *stored
+			return &current, nil
+		}
+		mockRepo.refreshAccessTokenFunc = func(ctx context.Context, sessionID uuid.UUID, minInterval time.Duration) (*models.Session, error) {
+			if minInterval != session.MinRefreshInterval() {
+				t.Errorf("Expected min interval %v, got %v", session.MinRefreshInterval(), minInterval)
+			}
+			rotations++
+			now := time.Now()
+			stored.LastRefreshedAt = &now
+			stored.AccessToken = "rotated_access_token"
+			current := *stored
+			return &current, nil
+		}
+
+		first, err := service.RefreshAccessToken(context.Background(), "valid_refresh_token")
+		if err != nil {
+			t.Fatalf("Expected first refresh to succeed, got: %v", err)
+		}
+		if first.AccessToken != "rotated_access_token" {
+			t.Errorf("Expected rotated access token, got '%s'", first.AccessToken)
+		}
+
+		second, err := service.RefreshAccessToken(context.Background(), "valid_refresh_token")
+		var tooSoon *session.RefreshTooSoonError
+		if !errors.As(err, &tooSoon) {
+			t.Fatalf("Expected RefreshTooSoonError, got: %v", err)
+		}
+		if tooSoon.RetryAfter <= 0 || tooSoon.RetryAfter > session.MinRefreshInterval() {
+			t.Errorf("Expected retry after within (0, %v], got %v", session.MinRefreshInterval(), tooSoon.RetryAfter)
+		}
+		if second != nil {
+			t.Errorf("Expected nil session, got %v", second)
+		}
+		if rotations != 1 {
+			t.Errorf("Expected access token to rotate once, rotated %d times", rotations)
+		}
+	})
+
+	t.Run("ConcurrentRefreshLosesRace", func(t *testing.T) {
+		service, mockRepo := setupServiceTest()
+
+		mockRepo.getSessionByRefreshTokenFunc = func(ctx context.Context, token string) (*models.Session, error) {
+			return &models.Session{ID: uuid.New(), UserID: uuid.New()}, nil
+		}
+		mockRepo.refreshAccessTokenFunc = func(ctx context.Context, sessionID uuid.UUID, minInterval time.Duration) (*models.Session, error) {
+			// Another request refreshed the session after it was read
+			return nil, session.ErrRefreshTooSoon
+		}
+
+		_, err := service.RefreshAccessToken(context.Background(), "valid_refresh_token")
+		var tooSoon *session.RefreshTooSoonError
+		if !errors.As(err, &tooSoon) {
+			t.Fatalf("Expected RefreshTooSoonError, got: %v", err)
+		}
+		if tooSoon.RetryAfter != session.MinRefreshInterval() {
+			t.Errorf("Expected retry after %v, got %v", session.MinRefreshInterval(), tooSoon.RetryAfter)
+		}
+	})
+
+	t.Run("SessionDeletedBeforeRefresh", func(t *testing.T) {
+		service, mockRepo := setupServiceTest()
+
+		mockRepo.getSessionByRefreshTokenFunc = func(ctx context.Context, token string) (*models.Session, error) {
+			return &models.Session{ID: uuid.New(), UserID: uuid.New()}, nil
+		}
+		mockRepo.refreshAccessTokenFunc = func(ctx context.Context, sessionID uuid.UUID, minInterval time.Duration) (*models.Session, error) {
+			// The user logged out between the read and the update
+			return nil, errors.New("invalid refresh token")
+		}
+
+		_, err := service.RefreshAccessToken(context.Background(), "valid_refresh_token")
+		var tooSoon *session.RefreshTooSoonError
+		if errors.As(err, &tooSoon) {
+			t.Fatalf("Expected an invalid refresh token, got RefreshTooSoonError")
+		}
+		if err == nil || err.Error() != "invalid refresh token" {
+			t.Errorf("Expected 'invalid refresh token', got: %v", err)
+		}
+	})
+}
+
 func TestServiceEndAllUserSessions(t *testing.T) {
 	testCases := []struct {
 		name          string
@@ -5,6 +5,8 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
+
+	"black-lotus/internal/common/response"
 )
 
 type HandlerInterface interface {
@@ -26,25 +28,22 @@ func (h *Handler) GetUserByID(ctx echo.Context) error {
 	idParam := ctx.Param("id")
 	userID, err := uuid.Parse(idParam)
 	if err != nil {
-		return ctx.JSON(http.StatusBadRequest, map[string]string{
-			"error": "Invalid user ID format",
-		})
+		return response.ErrorResponse(ctx, http.StatusBadRequest,
+			response.CodeInvalidID, "Invalid user ID format", nil)
 	}
 
 	// Get user by ID
 	user, err := h.userService.GetUserByID(ctx.Request().Context(), userID)
 	if err != nil {
-		return ctx.JSON(http.StatusInternalServerError, map[string]string{
-			"error": "Failed to get user: " + err.Error(),
-		})
+		return response.ErrorResponse(ctx, http.StatusInternalServerError,
+			response.CodeInternal, "Failed to get user: "+err.Error(), nil)
 	}
 
 	// Add explicit check for nil user
 	if user == nil {
-		return ctx.JSON(http.StatusNotFound, map[string]string{
-			"error": "User not found",
-		})
+		return response.ErrorResponse(ctx, http.StatusNotFound,
+			response.CodeUserNotFound, "User not found", nil)
 	}
 
-	return ctx.JSON(http.StatusOK, user)
+	return response.JSON(ctx, http.StatusOK, user)
 }
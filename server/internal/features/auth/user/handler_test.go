@@ -12,6 +12,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 
+	"black-lotus/internal/common/response"
 	"black-lotus/internal/domain/models"
 	"black-lotus/internal/features/auth/user"
 )
@@ -44,7 +45,7 @@ func (m *MockSessionService) ValidateRefreshToken(ctx context.Context, token str
 	return nil, errors.New("ValidateRefreshToken not implemented")
 }
 
-func (m *MockSessionService) CreateSession(ctx context.Context, userID uuid.UUID) (*models.Session, error) {
+func (m *MockSessionService) CreateSession(ctx context.Context, userID uuid.UUID, refreshDuration time.Duration) (*models.Session, error) {
 	return nil, errors.New("CreateSession not implemented")
 }
 
@@ -64,6 +65,10 @@ func (m *MockSessionService) EndAllUserSessions(ctx context.Context, userID uuid
 	return errors.New("EndAllUserSessions not implemented")
 }
 
+func (m *MockSessionService) EndOtherUserSessions(ctx context.Context, userID uuid.UUID, keepSessionID uuid.UUID) error {
+	return errors.New("EndOtherUserSessions not implemented")
+}
+
 // Helper function to create a new test context
 func newTestContext(method, path string) (echo.Context, *httptest.ResponseRecorder) {
 	e := echo.New()
@@ -187,9 +192,9 @@ func TestHandlerGetUserByID(t *testing.T) {
 
 			// Verify response
 			if tc.expectedError {
-				var errorResponse map[string]string
+				var errorResponse response.ErrorEnvelope
 				json.Unmarshal(rec.Body.Bytes(), &errorResponse)
-				if errorResponse["error"] == "" {
+				if errorResponse.Error.Message == "" {
 					t.Errorf("Expected error message in response, got none")
 				}
 			} else {
@@ -0,0 +1,46 @@
+package verification
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"black-lotus/internal/common/response"
+	"black-lotus/internal/features/auth/session"
+)
+
+type Handler struct {
+	service        ServiceInterface
+	sessionService session.ServiceInterface
+}
+
+func NewHandler(service ServiceInterface, sessionService session.ServiceInterface) *Handler {
+	return &Handler{
+		service:        service,
+		sessionService: sessionService,
+	}
+}
+
+// GetVerificationStatus lets the client poll whether the signed-in user has
+// verified their email yet
+func (h *Handler) GetVerificationStatus(ctx echo.Context) error {
+	userSession, err := session.Authenticate(ctx, h.sessionService)
+	if userSession == nil {
+		return err
+	}
+
+	status, err := h.service.GetVerificationStatus(ctx.Request().Context(), userSession.UserID)
+	if err != nil {
+		if err.Error() == "user not found" {
+			return response.ErrorResponse(ctx, http.StatusNotFound,
+				response.CodeUserNotFound, "User not found", nil)
+		}
+
+		slog.Error("Failed to get verification status", "error", err)
+		return response.ErrorResponse(ctx, http.StatusInternalServerError,
+			response.CodeInternal, "Failed to get verification status", nil)
+	}
+
+	return response.JSON(ctx, http.StatusOK, status)
+}
@@ -0,0 +1,164 @@
+package verification_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"black-lotus/internal/domain/models"
+	"black-lotus/internal/features/auth/verification"
+)
+
+// MockVerificationService implements verification.ServiceInterface for testing
+type MockVerificationService struct {
+	getVerificationStatusFunc func(ctx context.Context, userID uuid.UUID) (*models.VerificationStatus, error)
+}
+
+func (m *MockVerificationService) GetVerificationStatus(ctx context.Context, userID uuid.UUID) (*models.VerificationStatus, error) {
+	if m.getVerificationStatusFunc != nil {
+		return m.getVerificationStatusFunc(ctx, userID)
+	}
+	return nil, errors.New("GetVerificationStatus not implemented")
+}
+
+// MockSessionService implements session.ServiceInterface for testing
+type MockSessionService struct {
+	validateAccessTokenFunc func(ctx context.Context, token string) (*models.Session, error)
+}
+
+func (m *MockSessionService) ValidateAccessToken(ctx context.Context, token string) (*models.Session, error) {
+	if m.validateAccessTokenFunc != nil {
+		return m.validateAccessTokenFunc(ctx, token)
+	}
+	return nil, errors.New("ValidateAccessToken not implemented")
+}
+
+func (m *MockSessionService) ValidateRefreshToken(ctx context.Context, token string) (*models.Session, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockSessionService) CreateSession(ctx context.Context, userID uuid.UUID, refreshDuration time.Duration) (*models.Session, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockSessionService) RefreshAccessToken(ctx context.Context, refreshToken string) (*models.Session, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockSessionService) EndSessionByAccessToken(ctx context.Context, token string) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockSessionService) EndSessionByRefreshToken(ctx context.Context, token string) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockSessionService) EndAllUserSessions(ctx context.Context, userID uuid.UUID) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockSessionService) EndOtherUserSessions(ctx context.Context, userID uuid.UUID, keepSessionID uuid.UUID) error {
+	return errors.New("not implemented")
+}
+
+func TestHandlerGetVerificationStatus(t *testing.T) {
+	expiresAt := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)
+
+	testCases := []struct {
+		name            string
+		withCookie      bool
+		status          *models.VerificationStatus
+		serviceErr      error
+		expectedStatus  int
+		expectExpiresAt bool
+	}{
+		{
+			name:            "Pending",
+			withCookie:      true,
+			status:          &models.VerificationStatus{PendingVerificationExpiresAt: &expiresAt},
+			expectedStatus:  http.StatusOK,
+			expectExpiresAt: true,
+		},
+		{
+			name:           "Verified",
+			withCookie:     true,
+			status:         &models.VerificationStatus{EmailVerified: true},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "NoAccessToken",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "UserNotFound",
+			withCookie:     true,
+			serviceErr:     errors.New("user not found"),
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "ServiceError",
+			withCookie:     true,
+			serviceErr:     errors.New("database error"),
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			userID := uuid.New()
+			mockService := &MockVerificationService{
+				getVerificationStatusFunc: func(ctx context.Context, uid uuid.UUID) (*models.VerificationStatus, error) {
+					if uid != userID {
+						t.Errorf("Expected user %s, got %s", userID, uid)
+					}
+					return tc.status, tc.serviceErr
+				},
+			}
+			mockSession := &MockSessionService{
+				validateAccessTokenFunc: func(ctx context.Context, token string) (*models.Session, error) {
+					return &models.Session{ID: uuid.New(), UserID: userID, AccessToken: token}, nil
+				},
+			}
+			handler := verification.NewHandler(mockService, mockSession)
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/auth/verify/status", nil)
+			if tc.withCookie {
+				req.AddCookie(&http.Cookie{Name: "access_token", Value: "valid_access_token"})
+			}
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			// Execute
+			if err := handler.GetVerificationStatus(c); err != nil {
+				t.Errorf("Expected no error, got: %v", err)
+			}
+
+			// Verify
+			if rec.Code != tc.expectedStatus {
+				t.Fatalf("Expected status %d, got %d", tc.expectedStatus, rec.Code)
+			}
+
+			if tc.expectedStatus == http.StatusOK {
+				var body map[string]interface{}
+				if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+					t.Fatalf("Failed to unmarshal response: %v", err)
+				}
+				if body["email_verified"] != tc.status.EmailVerified {
+					t.Errorf("Expected email_verified %v, got %v", tc.status.EmailVerified, body["email_verified"])
+				}
+				if _, present := body["pending_verification_expires_at"]; present != tc.expectExpiresAt {
+					t.Errorf("Expected pending_verification_expires_at present to be %v, got %v", tc.expectExpiresAt, present)
+				}
+			}
+		})
+	}
+}
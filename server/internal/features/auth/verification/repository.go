@@ -0,0 +1,13 @@
+package verification
+
+import (
+	"black-lotus/internal/domain/models"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// Repository defines database operations needed by the email verification feature
+type Repository interface {
+	GetVerificationStatus(ctx context.Context, userID uuid.UUID) (*models.VerificationStatus, error)
+}
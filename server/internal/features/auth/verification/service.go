@@ -0,0 +1,44 @@
+package verification
+
+import (
+	"black-lotus/internal/domain/models"
+	"black-lotus/pkg/mail"
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+type ServiceInterface interface {
+	GetVerificationStatus(ctx context.Context, userID uuid.UUID) (*models.VerificationStatus, error)
+}
+
+type Service struct {
+	repo   Repository
+	mailer mail.Mailer
+}
+
+func NewService(repo Repository, mailer mail.Mailer) *Service {
+	return &Service{repo: repo, mailer: mailer}
+}
+
+// GetVerificationStatus reports whether the user has verified their email.
+// A leftover code is ignored once the email is verified, so clients polling
+// for the flip never see both at once.
+func (s *Service) GetVerificationStatus(ctx context.Context, userID uuid.UUID) (*models.VerificationStatus, error) {
+	status, err := s.repo.GetVerificationStatus(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if status.EmailVerified {
+		status.PendingVerificationExpiresAt = nil
+	}
+	return status, nil
+}
+
+// SendVerificationEmail emails a verification code to the address it verifies
+func (s *Service) SendVerificationEmail(ctx context.Context, email, code string) error {
+	body := fmt.Sprintf("Use this code to verify your email address for Black Lotus:\n\n%s\n\nThe code expires in 24 hours. If you didn't ask for it, you can ignore this email.\n", code)
+	return s.mailer.Send(ctx, email, "Verify your email address", body)
+}
@@ -0,0 +1,137 @@
+package verification_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"black-lotus/internal/domain/models"
+	"black-lotus/internal/features/auth/verification"
+	"black-lotus/pkg/mail"
+)
+
+// MockRepository implements verification.Repository for testing
+type MockRepository struct {
+	getVerificationStatusFunc func(ctx context.Context, userID uuid.UUID) (*models.VerificationStatus, error)
+}
+
+func (m *MockRepository) GetVerificationStatus(ctx context.Context, userID uuid.UUID) (*models.VerificationStatus, error) {
+	if m.getVerificationStatusFunc != nil {
+		return m.getVerificationStatusFunc(ctx, userID)
+	}
+	return nil, errors.New("GetVerificationStatus not implemented")
+}
+
+func TestServiceGetVerificationStatus(t *testing.T) {
+	expiresAt := time.Now().Add(24 * time.Hour)
+
+	testCases := []struct {
+		name            string
+		stored          *models.VerificationStatus
+		repoErr         error
+		expectVerified  bool
+		expectExpiresAt bool
+		expectedError   string
+	}{
+		{
+			name:            "PendingCode",
+			stored:          &models.VerificationStatus{PendingVerificationExpiresAt: &expiresAt},
+			expectExpiresAt: true,
+		},
+		{
+			name:   "NoCode",
+			stored: &models.VerificationStatus{},
+		},
+		{
+			name:           "VerifiedIgnoresLeftoverCode",
+			stored:         &models.VerificationStatus{EmailVerified: true, PendingVerificationExpiresAt: &expiresAt},
+			expectVerified: true,
+		},
+		{
+			name:          "UserNotFound",
+			repoErr:       errors.New("user not found"),
+			expectedError: "user not found",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := &MockRepository{
+				getVerificationStatusFunc: func(ctx context.Context, userID uuid.UUID) (*models.VerificationStatus, error) {
+					return tc.stored, tc.repoErr
+				},
+			}
+			service := verification.NewService(mockRepo, &mail.MockMailer{})
+
+			status, err := service.GetVerificationStatus(context.Background(), uuid.New())
+
+			if tc.expectedError != "" {
+				if err == nil || err.Error() != tc.expectedError {
+					t.Fatalf("Expected error '%s', got %v", tc.expectedError, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if status.EmailVerified != tc.expectVerified {
+				t.Errorf("Expected email_verified %v, got %v", tc.expectVerified, status.EmailVerified)
+			}
+			if (status.PendingVerificationExpiresAt != nil) != tc.expectExpiresAt {
+				t.Errorf("Expected pending expiry present to be %v, got %v", tc.expectExpiresAt, status.PendingVerificationExpiresAt)
+			}
+		})
+	}
+}
+
+func TestServiceSendVerificationEmail(t *testing.T) {
+	testCases := []struct {
+		name          string
+		mailErr       error
+		expectedError bool
+	}{
+		{name: "Sent"},
+		{name: "MailerError", mailErr: errors.New("connection refused"), expectedError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			mailer := &mail.MockMailer{Err: tc.mailErr}
+			service := verification.NewService(&MockRepository{}, mailer)
+
+			// Execute
+			err := service.SendVerificationEmail(context.Background(), "user@example.com", "abc123")
+
+			// Verify
+			if tc.expectedError {
+				if err == nil {
+					t.Fatal("Expected an error, got nil")
+				}
+				if len(mailer.Sent()) != 0 {
+					t.Errorf("Expected nothing recorded, got %v", mailer.Sent())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			sent := mailer.Sent()
+			if len(sent) != 1 {
+				t.Fatalf("Expected 1 message, got %d", len(sent))
+			}
+			if sent[0].To != "user@example.com" || sent[0].Subject != "Verify your email address" {
+				t.Errorf("Unexpected message: %+v", sent[0])
+			}
+			if !strings.Contains(sent[0].Body, "abc123") {
+				t.Errorf("Expected the code in the body, got %q", sent[0].Body)
+			}
+		})
+	}
+}
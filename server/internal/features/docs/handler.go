@@ -0,0 +1,43 @@
+package docs
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// swaggerUIPage renders Swagger UI from the CDN against /openapi.json
+const swaggerUIPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8" />
+  <title>Black Lotus API</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css" />
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js" crossorigin></script>
+  <script>
+    window.ui = SwaggerUIBundle({ url: "/openapi.json", dom_id: "#swagger-ui", withCredentials: true });
+  </script>
+</body>
+</html>`
+
+type Handler struct {
+	document *Document
+}
+
+// NewHandler builds the document once; it only changes with the code
+func NewHandler() *Handler {
+	return &Handler{document: Build()}
+}
+
+// Spec serves the OpenAPI document
+func (h *Handler) Spec(ctx echo.Context) error {
+	return ctx.JSON(http.StatusOK, h.document)
+}
+
+// UI serves the Swagger UI page
+func (h *Handler) UI(ctx echo.Context) error {
+	return ctx.HTML(http.StatusOK, swaggerUIPage)
+}
@@ -0,0 +1,308 @@
+package docs
+
+import (
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"black-lotus/internal/common/middleware"
+	"black-lotus/internal/common/response"
+	validation "black-lotus/internal/common/validations"
+	"black-lotus/internal/domain/models"
+	"black-lotus/internal/features/health"
+	"black-lotus/pkg/storage"
+)
+
+// Response bodies that handlers build inline with maps
+
+type MessageResponse struct {
+	Message string `json:"message"`
+}
+
+type CSRFTokenResponse struct {
+	CSRFToken string `json:"csrf_token"`
+}
+
+type AuthURLResponse struct {
+	URL string `json:"url"`
+}
+
+type HealthResponse struct {
+	Status      string `json:"status"`
+	DBLatencyMS *int64 `json:"db_latency_ms,omitempty"`
+}
+
+const (
+	cookieAuthScheme = "cookieAuth"
+	csrfScheme       = "csrfToken"
+)
+
+// endpoint describes a single route. Paths use echo's :param syntax, the same
+// as the route registrations, and are converted for the spec.
+type endpoint struct {
+	method   string
+	path     string
+	tag      string
+	summary  string
+	auth     bool
+	query    []Parameter
+	request  interface{}
+	status   int
+	response interface{}
+}
+
+// endpoints lists every documented route. TestBuildDocumentsEveryRoute fails
+// when a route registered in internal/api/routes is missing here.
+var endpoints = []endpoint{
+	// Health
+	{method: http.MethodGet, path: "/", tag: "health", summary: "API name, version and links to the probes and docs", status: http.StatusOK, response: health.RootDocument{}},
+	{method: http.MethodGet, path: "/version", tag: "health", summary: "API name and version", status: http.StatusOK, response: health.APIInfo{}},
+	{method: http.MethodGet, path: "/health", tag: "health", summary: "Liveness probe", status: http.StatusOK, response: HealthResponse{}},
+	{method: http.MethodGet, path: "/ready", tag: "health", summary: "Readiness probe, 503 when the database is unreachable", status: http.StatusOK, response: HealthResponse{}},
+
+	// Auth
+	{method: http.MethodPost, path: "/api/signup", tag: "auth", summary: "Register a new user and start a session", request: models.CreateUserInput{}, status: http.StatusCreated, response: models.User{}},
+	{method: http.MethodPost, path: "/api/login", tag: "auth", summary: "Log in and start a session, 429 after repeated failures for the same email", request: models.LoginUserInput{}, status: http.StatusOK, response: models.User{}},
+	{method: http.MethodPost, path: "/api/logout", tag: "auth", summary: "End the current session", status: http.StatusOK, response: MessageResponse{}},
+	{method: http.MethodPost, path: "/api/auth/refresh", tag: "auth", summary: "Rotate the access token using the refresh_token cookie, 429 if refreshed too recently", status: http.StatusOK, response: MessageResponse{}},
+	{method: http.MethodGet, path: "/api/csrf-token", tag: "auth", summary: "Issue a CSRF token for state-changing requests", status: http.StatusOK, response: CSRFTokenResponse{}},
+	{method: http.MethodGet, path: "/api/auth/github", tag: "auth", summary: "Get the GitHub authorization URL", status: http.StatusOK, response: AuthURLResponse{}},
+	{method: http.MethodGet, path: "/api/auth/github/callback", tag: "auth", summary: "GitHub OAuth callback, redirects to the client", status: http.StatusFound},
+	{method: http.MethodGet, path: "/api/auth/google", tag: "auth", summary: "Get the Google authorization URL", status: http.StatusOK, response: AuthURLResponse{}},
+	{method: http.MethodGet, path: "/api/auth/google/callback", tag: "auth", summary: "Google OAuth callback, redirects to the client", status: http.StatusFound},
+	{method: http.MethodGet, path: "/api/user/:id", tag: "users", summary: "Get a user by ID", auth: true, status: http.StatusOK, response: models.User{}},
+	{method: http.MethodGet, path: "/api/profile", tag: "users", summary: "Get the current user's profile", auth: true, status: http.StatusOK, response: models.User{}},
+	{method: http.MethodGet, path: "/api/auth/me/stats", tag: "users", summary: "Trip counts, days traveled and most visited location for the current user", auth: true, status: http.StatusOK, response: models.UserStats{}},
+	{method: http.MethodPatch, path: "/api/auth/profile", tag: "users", summary: "Change the current user's name or email; a new email must be verified again", auth: true, request: models.UpdateUserInput{}, status: http.StatusOK, response: models.User{}},
+	{method: http.MethodGet, path: "/api/auth/verify/status", tag: "users", summary: "Whether the current user's email is verified and when the outstanding verification code expires, for polling after a verification email", auth: true, status: http.StatusOK, response: models.VerificationStatus{}},
+	{method: http.MethodPost, path: "/api/auth/change-password", tag: "users", summary: "Change the current user's password, optionally signing out other sessions; 401 if the current password is wrong", auth: true, request: models.ChangePasswordInput{}, status: http.StatusOK, response: MessageResponse{}},
+	{method: http.MethodPost, path: "/api/auth/sessions/revoke", tag: "users", summary: "Sign out the current user's sessions: filter all_except_current keeps this one, all ends every session and requires include_current=true", auth: true, request: models.RevokeSessionsInput{}, status: http.StatusOK, response: MessageResponse{}},
+	{method: http.MethodPost, path: "/api/auth/api-keys", tag: "users", summary: "Create an API key, sent as Authorization: Bearer <key> instead of the session cookie. The key is only returned here; store it, since only its hash is kept", auth: true, request: models.CreateAPIKeyInput{}, status: http.StatusCreated, response: models.CreatedAPIKey{}},
+	{method: http.MethodGet, path: "/api/auth/api-keys", tag: "users", summary: "List the current user's API keys, newest first, without their values", auth: true, status: http.StatusOK, response: []models.APIKey{}},
+	{method: http.MethodDelete, path: "/api/auth/api-keys/:id", tag: "users", summary: "Revoke one of the current user's API keys; it stops working immediately", auth: true, status: http.StatusOK, response: MessageResponse{}},
+
+	// Trips
+	{method: http.MethodPost, path: "/api/trips", tag: "trips", summary: "Create a trip", auth: true, request: models.CreateTripInput{}, status: http.StatusCreated, response: models.Trip{}},
+	{method: http.MethodGet, path: "/api/trips", tag: "trips", summary: "List the current user's trips. Offset paging suits jumping to a page of a sorted list; cursor paging suits infinite scroll and sync, since trips added between requests are never skipped or repeated", auth: true, query: []Parameter{
+		queryParam("limit", "integer", "Maximum number of trips to return; defaults to DEFAULT_PAGE_SIZE and is capped at MAX_PAGE_SIZE"),
+		queryParam("offset", "integer", "Number of trips to skip; 0 or more, defaults to 0. A non-numeric limit or offset is a 400"),
+		queryParam("cursor", "string", "Switches to cursor paging, newest created first: pass it empty for the first page, then the previous page's next_cursor. The response becomes {trips, next_cursor} with next_cursor null on the last page; cannot be combined with offset or sort"),
+		queryParam("tag", "string", "Only return trips with this tag"),
+		queryParam("sort", "string", "Empty for newest start date first, manual for the saved order, or field:asc|desc with field one of "+strings.Join(models.TripSortFields, ", ")),
+		queryParam("wishlist", "boolean", "true for wishlist trips only, false for planned trips only"),
+		queryParam("from", "string", "Only trips starting on or after this RFC3339 time or YYYY-MM-DD date"),
+		queryParam("to", "string", "Only trips ending on or before this RFC3339 time or YYYY-MM-DD date (the whole day)"),
+		queryParam("status", "string", "past, ongoing or upcoming, computed from the trip dates against the current time"),
+	}, status: http.StatusOK, response: []models.Trip{}},
+	{method: http.MethodGet, path: "/api/trips/current", tag: "trips", summary: "Trips in progress right now, earliest start first", auth: true, status: http.StatusOK, response: []models.Trip{}},
+	{method: http.MethodGet, path: "/api/trips/upcoming", tag: "trips", summary: "Trips starting within the next few days, soonest first", auth: true, query: []Parameter{
+		queryParam("within", "integer", "How many days ahead to look, from 1 to 365; defaults to 7"),
+	}, status: http.StatusOK, response: []models.Trip{}},
+	{method: http.MethodGet, path: "/api/trips/preview-name", tag: "trips", summary: "The name a trip created without one would get, for use as a placeholder", auth: true, query: []Parameter{
+		queryParam("location", "string", "The trip location the name is generated from"),
+		queryParam("start_date", "string", "The trip start date as YYYY-MM-DD; checked but not yet used in the name"),
+	}, status: http.StatusOK, response: models.TripNamePreview{}},
+	{method: http.MethodGet, path: "/api/trips/export", tag: "trips", summary: "Download every trip as a JSON array or a CSV file", auth: true, query: []Parameter{
+		queryParam("format", "string", "json (default) or csv; csv columns are name, description, start_date, end_date, location"),
+	}, status: http.StatusOK, response: []models.Trip{}},
+	{method: http.MethodGet, path: "/api/trips/export.ics", tag: "trips", summary: "All dated trips as an iCalendar (text/calendar) feed of all-day events", auth: true, status: http.StatusOK},
+	{method: http.MethodGet, path: "/api/trips/by-location/:location", tag: "trips", summary: "The user's planned trips to a location, matched ignoring case and spacing, with trip count, total days and first and last visit", auth: true, status: http.StatusOK, response: models.LocationHistory{}},
+	{method: http.MethodGet, path: "/api/trips/stats/cadence", tag: "trips", summary: "Average and longest gap between trips and trips per year", auth: true, status: http.StatusOK, response: models.TripCadence{}},
+	{method: http.MethodPut, path: "/api/trips/order", tag: "trips", summary: "Save the manual trip order", auth: true, request: models.ReorderTripsInput{}, status: http.StatusOK, response: MessageResponse{}},
+	{method: http.MethodPost, path: "/api/trips/bulk", tag: "trips", summary: "Create up to 50 trips at once, all or nothing", auth: true, request: []models.CreateTripInput{}, status: http.StatusCreated, response: []models.Trip{}},
+	{method: http.MethodPost, path: "/api/trips/bulk-tag", tag: "trips", summary: "Add or replace tags on up to 50 owned trips at once, with a result per trip", auth: true, request: models.BulkTagTripsInput{}, status: http.StatusOK, response: []models.BulkTagResult{}},
+	{method: http.MethodPost, path: "/api/trips/import", tag: "trips", summary: "Import up to 50 trips, all or nothing, from a CSV file uploaded as multipart field \"file\" (at most 512 KB, export layout, header row first); row errors carry line numbers", auth: true, status: http.StatusCreated, response: models.TripCSVImportResult{}},
+	{method: http.MethodPost, path: "/api/trips/import-one", tag: "trips", summary: "Import a trip from an export document: the trip with its activities, expenses and tags. Tags are added to the importing user's own. Version 1 documents, without expenses and tags, still import", auth: true, request: models.TripExport{}, status: http.StatusCreated, response: models.TripImportResult{}},
+	{method: http.MethodGet, path: "/api/trips/:id", tag: "trips", summary: "Get a trip", auth: true, query: []Parameter{
+		queryParam("include_deleted", "boolean", "true to also find the caller's own trips in the trash, returned with deleted_at set"),
+		queryParam("photos", "string", "count to include photo_count, or list to include photos (oldest first) and photo_count; left out by default"),
+	}, status: http.StatusOK, response: models.Trip{}},
+	{method: http.MethodGet, path: "/api/trips/:id/with-user", tag: "trips", summary: "Get a trip with its owner embedded under user", auth: true, status: http.StatusOK, response: models.Trip{}},
+	{method: http.MethodGet, path: "/api/trips/:id/export.json", tag: "trips", summary: "Export a trip with its activities, expenses and tag names. Photos aren't included", auth: true, status: http.StatusOK, response: models.TripExport{}},
+	{method: http.MethodGet, path: "/api/trips/:id/export.ics", tag: "trips", summary: "Export a trip as an all-day iCalendar (text/calendar) event", auth: true, status: http.StatusOK},
+	{method: http.MethodGet, path: "/api/trips/:id/print", tag: "trips", summary: "Printable HTML page with the trip and its itinerary", auth: true, status: http.StatusOK},
+	{method: http.MethodGet, path: "/api/trips/:id/days-breakdown", tag: "trips", summary: "Calendar days the trip covers, split into weekdays and weekend days", auth: true, status: http.StatusOK, response: models.TripDaysBreakdown{}},
+	{method: http.MethodGet, path: "/api/trips/:id/weather", tag: "trips", summary: "Daily forecast for the trip's location on the trip days within the next few days the provider forecasts; days is empty outside that window. Results are cached for 15 minutes. 400 for wishlist trips, 404 location_not_found when the location can't be geocoded, 503 when weather isn't configured", auth: true, status: http.StatusOK, response: models.TripWeather{}},
+	{method: http.MethodPut, path: "/api/trips/:id", tag: "trips", summary: "Update a trip", auth: true, request: models.UpdateTripInput{}, status: http.StatusOK, response: models.Trip{}},
+	{method: http.MethodDelete, path: "/api/trips/:id", tag: "trips", summary: "Delete a trip", auth: true, status: http.StatusOK, response: MessageResponse{}},
+	{method: http.MethodPost, path: "/api/trips/:id/restore", tag: "trips", summary: "Restore a recently deleted trip", auth: true, status: http.StatusOK, response: models.Trip{}},
+	{method: http.MethodPost, path: "/api/trips/:id/share", tag: "trips", summary: "Make a trip unlisted and get its share token; sharing again keeps the same token", auth: true, status: http.StatusOK, response: models.TripShare{}},
+	{method: http.MethodDelete, path: "/api/trips/:id/share", tag: "trips", summary: "Make a trip private again, revoking its share token", auth: true, status: http.StatusOK, response: MessageResponse{}},
+	{method: http.MethodGet, path: "/api/public/trips/:token", tag: "trips", summary: "Read an unlisted trip by share token without logging in; owner details are never included", status: http.StatusOK, response: models.PublicTrip{}},
+	{method: http.MethodGet, path: "/api/reminders/:token/ack", tag: "reminders", summary: "Acknowledge a trip reminder from its link so it isn't sent again; needs only the reminder's token", status: http.StatusOK, response: models.TripReminder{}},
+	{method: http.MethodGet, path: "/api/reminders/:token/snooze", tag: "reminders", summary: "Send a trip reminder again after the given number of days; 409 once it has been acknowledged. Needs only the reminder's token", query: []Parameter{
+		queryParam("days", "integer", "Days to snooze for, 1 to 30; defaults to 1"),
+	}, status: http.StatusOK, response: models.TripReminder{}},
+	{method: http.MethodPost, path: "/api/trips/:id/plan", tag: "trips", summary: "Give a wishlist trip dates and make it a planned trip", auth: true, request: models.PlanTripInput{}, status: http.StatusOK, response: models.Trip{}},
+	{method: http.MethodPost, path: "/api/trips/:id/tags", tag: "trips", summary: "Tag a trip", auth: true, request: models.AddTripTagInput{}, status: http.StatusOK, response: []models.Tag{}},
+	{method: http.MethodDelete, path: "/api/trips/:id/tags/:tag", tag: "trips", summary: "Remove a tag from a trip", auth: true, status: http.StatusOK, response: MessageResponse{}},
+
+	// Activities
+	{method: http.MethodPost, path: "/api/trips/:id/activities", tag: "activities", summary: "Add an activity to a trip", auth: true, request: models.CreateActivityInput{}, status: http.StatusCreated, response: models.Activity{}},
+	{method: http.MethodGet, path: "/api/trips/:id/activities", tag: "activities", summary: "List a trip's activities by start time", auth: true, status: http.StatusOK, response: []models.Activity{}},
+	{method: http.MethodGet, path: "/api/trips/:id/activities/:activityId", tag: "activities", summary: "Get an activity", auth: true, status: http.StatusOK, response: models.Activity{}},
+	{method: http.MethodPut, path: "/api/trips/:id/activities/:activityId", tag: "activities", summary: "Update an activity", auth: true, request: models.UpdateActivityInput{}, status: http.StatusOK, response: models.Activity{}},
+	{method: http.MethodDelete, path: "/api/trips/:id/activities/:activityId", tag: "activities", summary: "Delete an activity", auth: true, status: http.StatusOK, response: MessageResponse{}},
+
+	// Expenses
+	{method: http.MethodPost, path: "/api/trips/:id/expenses", tag: "expenses", summary: "Record an expense", auth: true, request: models.CreateExpenseInput{}, status: http.StatusCreated, response: models.Expense{}},
+	{method: http.MethodGet, path: "/api/trips/:id/expenses", tag: "expenses", summary: "List a trip's expenses", auth: true, status: http.StatusOK, response: []models.Expense{}},
+	{method: http.MethodGet, path: "/api/trips/:id/expenses/timeline", tag: "expenses", summary: "A trip's spending per currency over time, oldest period first", auth: true, query: []Parameter{
+		queryParam("granularity", "string", "day (default) or week; periods start at midnight UTC and weeks on Monday"),
+	}, status: http.StatusOK, response: models.ExpenseTimeline{}},
+	{method: http.MethodGet, path: "/api/trips/:id/expenses/settlement", tag: "expenses", summary: "Who owes whom for a trip's shared expenses, per currency, in as few transfers as possible", auth: true, status: http.StatusOK, response: models.ExpenseSettlement{}},
+	{method: http.MethodDelete, path: "/api/trips/:id/expenses/:expenseId", tag: "expenses", summary: "Delete an expense", auth: true, status: http.StatusOK, response: MessageResponse{}},
+	{method: http.MethodGet, path: "/api/trips/:id/budget", tag: "expenses", summary: "Summarize a trip's spending", auth: true, status: http.StatusOK, response: models.BudgetSummary{}},
+
+	// Photos
+	{method: http.MethodPost, path: "/api/trips/:id/photos", tag: "photos", summary: "Attach a photo hosted elsewhere to a trip; 409 once the trip has 50 photos", auth: true, request: models.CreateTripPhotoInput{}, status: http.StatusCreated, response: models.TripPhoto{}},
+	{method: http.MethodPost, path: "/api/trips/:id/photos/upload-url", tag: "photos", summary: "Presign an upload of a jpeg, png, webp, gif or heic image: PUT it to upload_url with the returned headers, then attach public_url as a photo. 503 when object storage isn't configured", auth: true, request: models.PhotoUploadInput{}, status: http.StatusCreated, response: storage.Upload{}},
+	{method: http.MethodGet, path: "/api/trips/:id/photos", tag: "photos", summary: "List a trip's photos, oldest first", auth: true, status: http.StatusOK, response: []models.TripPhoto{}},
+	{method: http.MethodDelete, path: "/api/trips/:id/photos/:photoId", tag: "photos", summary: "Remove a photo from a trip; the hosted image is left alone", auth: true, status: http.StatusOK, response: MessageResponse{}},
+
+	// Admin
+	{method: http.MethodGet, path: "/api/admin/trips", tag: "admin", summary: "List any user's trips; 403 unless the current user is an admin", auth: true, query: []Parameter{
+		queryParam("user_id", "string", "The user whose trips to list"),
+		queryParam("limit", "integer", "Maximum number of trips to return; defaults to DEFAULT_PAGE_SIZE and is capped at MAX_PAGE_SIZE"),
+		queryParam("offset", "integer", "Number of trips to skip; 0 or more, defaults to 0. A non-numeric limit or offset is a 400"),
+	}, status: http.StatusOK, response: []models.Trip{}},
+	{method: http.MethodPut, path: "/api/admin/users/:id/role", tag: "admin", summary: "Change another user's role; 403 unless the current user is an admin", auth: true, request: models.UpdateUserRoleInput{}, status: http.StatusOK, response: models.User{}},
+}
+
+var pathParamPattern = regexp.MustCompile(`:([A-Za-z]+)`)
+
+func queryParam(name, schemaType, description string) Parameter {
+	return Parameter{Name: name, In: "query", Description: description, Schema: &Schema{Type: schemaType}}
+}
+
+// Build generates the OpenAPI document for all documented endpoints
+func Build() *Document {
+	registry := newSchemaRegistry()
+	errorSchema := registry.schemaFor(response.ErrorEnvelope{})
+
+	info := health.APIInfoFromEnv()
+
+	doc := &Document{
+		OpenAPI: "3.0.3",
+		Info: Info{
+			Title:   info.Name,
+			Version: info.Version,
+			Description: "Authenticated endpoints read the access_token cookie set by login, signup and OAuth. " +
+				"POST, PUT and DELETE requests must also send the csrf_token cookie value in the " +
+				middleware.CSRFHeader + " header. Successful responses are bare JSON unless the client sends " +
+				`Accept: application/json; profile="` + response.EnvelopeProfile + `", which wraps them as {"data": ..., "meta": ...}.`,
+		},
+		Paths: make(map[string]PathItem),
+		Tags: []Tag{
+			{Name: "health", Description: "Service probes"},
+			{Name: "auth", Description: "Registration, login and sessions"},
+			{Name: "users", Description: "User profiles"},
+			{Name: "trips", Description: "Trips owned by the current user"},
+			{Name: "activities", Description: "Itinerary entries within a trip"},
+			{Name: "expenses", Description: "Spending within a trip"},
+			{Name: "photos", Description: "Photos attached to a trip"},
+			{Name: "reminders", Description: "Links sent in trip reminders"},
+			{Name: "admin", Description: "Support tools for admins"},
+		},
+	}
+
+	for _, ep := range endpoints {
+		path, pathParams := openAPIPath(ep.path)
+
+		op := &Operation{
+			Summary:     ep.summary,
+			Tags:        []string{ep.tag},
+			OperationID: operationID(ep.method, ep.path),
+			Parameters:  append(pathParams, ep.query...),
+			Responses: map[string]Response{
+				"default": {
+					Description: "Error",
+					Content:     map[string]MediaType{"application/json": {Schema: errorSchema}},
+				},
+			},
+			Security: securityFor(ep),
+		}
+
+		if ep.request != nil {
+			op.RequestBody = &RequestBody{
+				Required: true,
+				Content:  map[string]MediaType{"application/json": {Schema: registry.schemaFor(ep.request)}},
+			}
+		}
+
+		success := Response{Description: http.StatusText(ep.status)}
+		if ep.response != nil {
+			success.Content = map[string]MediaType{"application/json": {Schema: registry.schemaFor(ep.response)}}
+		}
+		op.Responses[strconv.Itoa(ep.status)] = success
+
+		if doc.Paths[path] == nil {
+			doc.Paths[path] = PathItem{}
+		}
+		doc.Paths[path][strings.ToLower(ep.method)] = op
+	}
+
+	// Trip fields a deployment requires aren't in the struct tags
+	if schema, ok := registry.schemas["CreateTripInput"]; ok {
+		rules := validation.TripFieldRulesFromEnv()
+		if rules.LocationRequired {
+			schema.Required = append(schema.Required, "location")
+		}
+		if rules.DescriptionRequired {
+			schema.Required = append(schema.Required, "description")
+		}
+	}
+
+	doc.Components = Components{
+		Schemas: registry.schemas,
+		SecuritySchemes: map[string]SecurityScheme{
+			cookieAuthScheme: {
+				Type:        "apiKey",
+				In:          "cookie",
+				Name:        "access_token",
+				Description: "Set by login, signup and OAuth. Refreshed with the refresh_token cookie.",
+			},
+			csrfScheme: {
+				Type:        "apiKey",
+				In:          "header",
+				Name:        middleware.CSRFHeader,
+				Description: "Must match the csrf_token cookie on POST, PUT and DELETE requests.",
+			},
+		},
+	}
+
+	return doc
+}
+
+// securityFor lists the cookies and headers a request must carry. An empty
+// list marks the endpoint as public.
+func securityFor(ep endpoint) []SecurityRequirement {
+	requirement := SecurityRequirement{}
+	if ep.auth {
+		requirement[cookieAuthScheme] = []string{}
+	}
+	if ep.method != http.MethodGet {
+		requirement[csrfScheme] = []string{}
+	}
+
+	if len(requirement) == 0 {
+		return []SecurityRequirement{}
+	}
+	return []SecurityRequirement{requirement}
+}
+
+// openAPIPath converts /api/trips/:id to /api/trips/{id} and returns its path parameters
+func openAPIPath(path string) (string, []Parameter) {
+	var params []Parameter
+	for _, match := range pathParamPattern.FindAllStringSubmatch(path, -1) {
+		params = append(params, Parameter{Name: match[1], In: "path", Required: true, Schema: &Schema{Type: "string"}})
+	}
+	return pathParamPattern.ReplaceAllString(path, "{$1}"), params
+}
+
+// operationID derives a stable identifier such as get_api_trips_id
+func operationID(method, path string) string {
+	cleaned := strings.NewReplacer("/", "_", ":", "", ".", "_", "-", "_").Replace(strings.Trim(path, "/"))
+	return strings.ToLower(method) + "_" + cleaned
+}
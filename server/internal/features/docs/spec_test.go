@@ -0,0 +1,117 @@
+package docs_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+
+	"black-lotus/internal/api"
+	"black-lotus/internal/features/docs"
+)
+
+func TestBuildDocumentsEveryRoute(t *testing.T) {
+	e := echo.New()
+	api.SetupRouter(e)
+
+	doc := docs.Build()
+	paramPattern := regexp.MustCompile(`:([A-Za-z]+)`)
+
+	// Routes that are not part of the API contract
+	undocumented := map[string]bool{
+		"/oauth-test":   true,
+		"/openapi.json": true,
+		"/docs":         true,
+	}
+
+	for _, route := range e.Routes() {
+		// Groups register catch-all not-found routes
+		if undocumented[route.Path] || route.Method == echo.RouteNotFound {
+			continue
+		}
+
+		path := paramPattern.ReplaceAllString(route.Path, "{$1}")
+		item, ok := doc.Paths[path]
+		if !ok {
+			t.Errorf("Route %s %s is missing from the OpenAPI document", route.Method, route.Path)
+			continue
+		}
+		if _, ok := item[strings.ToLower(route.Method)]; !ok {
+			t.Errorf("Route %s %s is missing from the OpenAPI document", route.Method, route.Path)
+		}
+	}
+}
+
+func TestBuildSchemasFollowJSONTags(t *testing.T) {
+	doc := docs.Build()
+
+	schema, ok := doc.Components.Schemas["CreateTripInput"]
+	if !ok {
+		t.Fatal("Expected a CreateTripInput schema")
+	}
+
+	startDate, ok := schema.Properties["start_date"]
+	if !ok {
+		t.Fatalf("Expected property 'start_date', got %v", schema.Properties)
+	}
+	if startDate.Format != "date" {
+		t.Errorf("Expected start_date to be a date, got '%s'", startDate.Format)
+	}
+
+	// Dates are only conditionally required (not for wishlist trips), so only location is listed
+	required := strings.Join(schema.Required, ",")
+	if !strings.Contains(required, "location") || strings.Contains(required, "name") || strings.Contains(required, "start_date") {
+		t.Errorf("Expected required fields from validate tags, got %v", schema.Required)
+	}
+
+	// Fields tagged json:"-" are never exposed
+	if _, ok := doc.Components.Schemas["Trip"].Properties["-"]; ok {
+		t.Error("Expected fields hidden from JSON to be left out")
+	}
+}
+
+func TestBuildDocumentsAuthRequirements(t *testing.T) {
+	doc := docs.Build()
+
+	createTrip := doc.Paths["/api/trips"]["post"]
+	if len(createTrip.Security) != 1 {
+		t.Fatalf("Expected one security requirement, got %v", createTrip.Security)
+	}
+	if _, ok := createTrip.Security[0]["cookieAuth"]; !ok {
+		t.Error("Expected create trip to require the access token cookie")
+	}
+	if _, ok := createTrip.Security[0]["csrfToken"]; !ok {
+		t.Error("Expected create trip to require the CSRF header")
+	}
+
+	health := doc.Paths["/health"]["get"]
+	if len(health.Security) != 0 {
+		t.Errorf("Expected /health to be public, got %v", health.Security)
+	}
+}
+
+func TestHandlerSpec(t *testing.T) {
+	handler := docs.NewHandler()
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/openapi.json", nil), rec)
+
+	if err := handler.Spec(c); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, rec.Code)
+	}
+
+	var doc map[string]interface{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &doc); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if doc["openapi"] != "3.0.3" {
+		t.Errorf("Expected an OpenAPI 3.0 document, got %v", doc["openapi"])
+	}
+}
@@ -0,0 +1,212 @@
+package expenses
+
+import (
+	"log/slog"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"black-lotus/internal/common/binding"
+	"black-lotus/internal/common/params"
+	"black-lotus/internal/common/response"
+	validation "black-lotus/internal/common/validations"
+	"black-lotus/internal/domain/models"
+	"black-lotus/internal/features/auth/session"
+)
+
+type Handler struct {
+	service        ServiceInterface
+	sessionService session.ServiceInterface
+	validator      *validator.Validate
+}
+
+func NewHandler(service ServiceInterface, sessionService session.ServiceInterface) *Handler {
+	validate := validator.New()
+
+	// Report validation errors using JSON field names
+	validate.RegisterTagNameFunc(func(fld reflect.StructField) string {
+		name := strings.SplitN(fld.Tag.Get("json"), ",", 2)[0]
+		if name == "-" {
+			return ""
+		}
+		return name
+	})
+
+	return &Handler{
+		service:        service,
+		sessionService: sessionService,
+		validator:      validate,
+	}
+}
+
+// handleServiceError maps expense service errors to responses
+func handleServiceError(ctx echo.Context, err error, action string) error {
+	switch err.Error() {
+	case "trip not found":
+		return response.ErrorResponse(ctx, http.StatusNotFound,
+			response.CodeTripNotFound, "Trip not found", nil)
+	case "expense not found":
+		return response.ErrorResponse(ctx, http.StatusNotFound,
+			response.CodeExpenseNotFound, "Expense not found", nil)
+	case "unauthorized access to trip":
+		return response.ErrorResponse(ctx, http.StatusForbidden,
+			response.CodeForbidden, "You do not have permission to access this trip", nil)
+	case "amount must be positive", "category is required",
+		"paid_by and split_among must be set together", "split_among names cannot be empty":
+		return response.ErrorResponse(ctx, http.StatusBadRequest,
+			response.CodeValidationFailed, err.Error(), nil)
+	case "invalid granularity":
+		return response.ErrorResponse(ctx, http.StatusBadRequest,
+			response.CodeInvalidRequest, "granularity must be day or week", nil)
+	}
+
+	slog.Error("Failed to "+action, "error", err)
+	return response.ErrorResponse(ctx, http.StatusInternalServerError,
+		response.CodeInternal, "Failed to "+action, nil)
+}
+
+// CreateExpense records an expense on a trip
+func (h *Handler) CreateExpense(ctx echo.Context) error {
+	sess, err := session.Authenticate(ctx, h.sessionService)
+	if sess == nil {
+		return err
+	}
+
+	tripID, ok, err := params.TripID(ctx)
+	if !ok {
+		return err
+	}
+
+	var input models.CreateExpenseInput
+	if err := binding.Bind(ctx, &input); err != nil {
+		return response.InvalidBody(ctx, err)
+	}
+
+	// Currency codes are accepted in any case
+	input.Currency = strings.ToUpper(strings.TrimSpace(input.Currency))
+
+	if err := h.validator.Struct(input); err != nil {
+		messages := validation.MessagesFor(ctx.Request().Header.Get("Accept-Language"))
+		validationErrors, _ := err.(validator.ValidationErrors)
+		return response.ErrorResponse(ctx, http.StatusBadRequest,
+			response.CodeValidationFailed, messages.InvalidBody(), messages.Details(validationErrors))
+	}
+
+	expense, err := h.service.CreateExpense(ctx.Request().Context(), tripID, sess.UserID, input)
+	if err != nil {
+		return handleServiceError(ctx, err, "create expense")
+	}
+
+	return response.JSON(ctx, http.StatusCreated, expense)
+}
+
+// GetExpenses lists a trip's expenses
+func (h *Handler) GetExpenses(ctx echo.Context) error {
+	sess, err := session.Authenticate(ctx, h.sessionService)
+	if sess == nil {
+		return err
+	}
+
+	tripID, ok, err := params.TripID(ctx)
+	if !ok {
+		return err
+	}
+
+	expenses, err := h.service.GetExpensesByTripID(ctx.Request().Context(), tripID, sess.UserID)
+	if err != nil {
+		return handleServiceError(ctx, err, "get expenses")
+	}
+
+	return response.JSON(ctx, http.StatusOK, expenses)
+}
+
+// DeleteExpense removes a single expense
+func (h *Handler) DeleteExpense(ctx echo.Context) error {
+	sess, err := session.Authenticate(ctx, h.sessionService)
+	if sess == nil {
+		return err
+	}
+
+	tripID, ok, err := params.TripID(ctx)
+	if !ok {
+		return err
+	}
+
+	expenseID, err := uuid.Parse(ctx.Param("expenseId"))
+	if err != nil {
+		return response.ErrorResponse(ctx, http.StatusBadRequest,
+			response.CodeInvalidID, "Invalid expense ID", nil)
+	}
+
+	if err := h.service.DeleteExpense(ctx.Request().Context(), tripID, expenseID, sess.UserID); err != nil {
+		return handleServiceError(ctx, err, "delete expense")
+	}
+
+	return response.JSON(ctx, http.StatusOK, map[string]string{
+		"message": "Expense deleted successfully",
+	})
+}
+
+// GetBudget returns a trip's spending totals by category and currency
+func (h *Handler) GetBudget(ctx echo.Context) error {
+	sess, err := session.Authenticate(ctx, h.sessionService)
+	if sess == nil {
+		return err
+	}
+
+	tripID, ok, err := params.TripID(ctx)
+	if !ok {
+		return err
+	}
+
+	summary, err := h.service.GetBudget(ctx.Request().Context(), tripID, sess.UserID)
+	if err != nil {
+		return handleServiceError(ctx, err, "get budget")
+	}
+
+	return response.JSON(ctx, http.StatusOK, summary)
+}
+
+// GetExpenseTimeline returns a trip's spending bucketed by day or week
+func (h *Handler) GetExpenseTimeline(ctx echo.Context) error {
+	sess, err := session.Authenticate(ctx, h.sessionService)
+	if sess == nil {
+		return err
+	}
+
+	tripID, ok, err := params.TripID(ctx)
+	if !ok {
+		return err
+	}
+
+	timeline, err := h.service.GetExpenseTimeline(ctx.Request().Context(), tripID, sess.UserID, ctx.QueryParam("granularity"))
+	if err != nil {
+		return handleServiceError(ctx, err, "get expense timeline")
+	}
+
+	return response.JSON(ctx, http.StatusOK, timeline)
+}
+
+// GetSettlement returns balances and the transfers that settle a trip's shared expenses
+func (h *Handler) GetSettlement(ctx echo.Context) error {
+	sess, err := session.Authenticate(ctx, h.sessionService)
+	if sess == nil {
+		return err
+	}
+
+	tripID, ok, err := params.TripID(ctx)
+	if !ok {
+		return err
+	}
+
+	settlement, err := h.service.GetSettlement(ctx.Request().Context(), tripID, sess.UserID)
+	if err != nil {
+		return handleServiceError(ctx, err, "get settlement")
+	}
+
+	return response.JSON(ctx, http.StatusOK, settlement)
+}
@@ -0,0 +1,398 @@
+package expenses_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"black-lotus/internal/common/response"
+	"black-lotus/internal/domain/models"
+	"black-lotus/internal/features/expenses"
+)
+
+// MockExpenseService implements expenses.ServiceInterface for testing
+type MockExpenseService struct {
+	createExpenseFunc       func(ctx context.Context, tripID uuid.UUID, userID uuid.UUID, input models.CreateExpenseInput) (*models.Expense, error)
+	getExpensesByTripIDFunc func(ctx context.Context, tripID uuid.UUID, userID uuid.UUID) ([]*models.Expense, error)
+	deleteExpenseFunc       func(ctx context.Context, tripID uuid.UUID, expenseID uuid.UUID, userID uuid.UUID) error
+	getBudgetFunc           func(ctx context.Context, tripID uuid.UUID, userID uuid.UUID) (*models.BudgetSummary, error)
+	getExpenseTimelineFunc  func(ctx context.Context, tripID uuid.UUID, userID uuid.UUID, granularity string) (*models.ExpenseTimeline, error)
+	getSettlementFunc       func(ctx context.Context, tripID uuid.UUID, userID uuid.UUID) (*models.ExpenseSettlement, error)
+}
+
+func (m *MockExpenseService) CreateExpense(ctx context.Context, tripID uuid.UUID, userID uuid.UUID, input models.CreateExpenseInput) (*models.Expense, error) {
+	if m.createExpenseFunc != nil {
+		return m.createExpenseFunc(ctx, tripID, userID, input)
+	}
+	return nil, errors.New("CreateExpense not implemented")
+}
+
+func (m *MockExpenseService) GetExpensesByTripID(ctx context.Context, tripID uuid.UUID, userID uuid.UUID) ([]*models.Expense, error) {
+	if m.getExpensesByTripIDFunc != nil {
+		return m.getExpensesByTripIDFunc(ctx, tripID, userID)
+	}
+	return nil, errors.New("GetExpensesByTripID not implemented")
+}
+
+func (m *MockExpenseService) DeleteExpense(ctx context.Context, tripID uuid.UUID, expenseID uuid.UUID, userID uuid.UUID) error {
+	if m.deleteExpenseFunc != nil {
+		return m.deleteExpenseFunc(ctx, tripID, expenseID, userID)
+	}
+	return errors.New("DeleteExpense not implemented")
+}
+
+func (m *MockExpenseService) GetBudget(ctx context.Context, tripID uuid.UUID, userID uuid.UUID) (*models.BudgetSummary, error) {
+	if m.getBudgetFunc != nil {
+		return m.getBudgetFunc(ctx, tripID, userID)
+	}
+	return nil, errors.New("GetBudget not implemented")
+}
+
+func (m *MockExpenseService) GetExpenseTimeline(ctx context.Context, tripID uuid.UUID, userID uuid.UUID, granularity string) (*models.ExpenseTimeline, error) {
+	if m.getExpenseTimelineFunc != nil {
+		return m.getExpenseTimelineFunc(ctx, tripID, userID, granularity)
+	}
+	return nil, errors.New("GetExpenseTimeline not implemented")
+}
+
+func (m *MockExpenseService) GetSettlement(ctx context.Context, tripID uuid.UUID, userID uuid.UUID) (*models.ExpenseSettlement, error) {
+	if m.getSettlementFunc != nil {
+		return m.getSettlementFunc(ctx, tripID, userID)
+	}
+	return nil, errors.New("GetSettlement not implemented")
+}
+
+// MockSessionService implements session.ServiceInterface for testing
+type MockSessionService struct {
+	validateAccessTokenFunc func(ctx context.Context, token string) (*models.Session, error)
+}
+
+func (m *MockSessionService) ValidateAccessToken(ctx context.Context, token string) (*models.Session, error) {
+	if m.validateAccessTokenFunc != nil {
+		return m.validateAccessTokenFunc(ctx, token)
+	}
+	return nil, errors.New("ValidateAccessToken not implemented")
+}
+
+func (m *MockSessionService) ValidateRefreshToken(ctx context.Context, token string) (*models.Session, error) {
+	return nil, errors.New("ValidateRefreshToken not implemented")
+}
+
+func (m *MockSessionService) CreateSession(ctx context.Context, userID uuid.UUID, refreshDuration time.Duration) (*models.Session, error) {
+	return nil, errors.New("CreateSession not implemented")
+}
+
+func (m *MockSessionService) RefreshAccessToken(ctx context.Context, refreshToken string) (*models.Session, error) {
+	return nil, errors.New("RefreshAccessToken not implemented")
+}
+
+func (m *MockSessionService) EndSessionByAccessToken(ctx context.Context, token string) error {
+	return errors.New("EndSessionByAccessToken not implemented")
+}
+
+func (m *MockSessionService) EndSessionByRefreshToken(ctx context.Context, token string) error {
+	return errors.New("EndSessionByRefreshToken not implemented")
+}
+
+func (m *MockSessionService) EndAllUserSessions(ctx context.Context, userID uuid.UUID) error {
+	return errors.New("EndAllUserSessions not implemented")
+}
+
+func (m *MockSessionService) EndOtherUserSessions(ctx context.Context, userID uuid.UUID, keepSessionID uuid.UUID) error {
+	return errors.New("EndOtherUserSessions not implemented")
+}
+
+// Helper function to create a test context for a trip with an access token
+func newTestContext(method, path string, body []byte, tripID string) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	req := httptest.NewRequest(method, path, bytes.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.AddCookie(&http.Cookie{Name: "access_token", Value: "valid_access_token"})
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(tripID)
+	return c, rec
+}
+
+// Helper function to setup handler for testing
+func setupHandlerTest(userID uuid.UUID) (*expenses.Handler, *MockExpenseService) {
+	mockService := &MockExpenseService{}
+	mockSession := &MockSessionService{}
+
+	mockSession.validateAccessTokenFunc = func(ctx context.Context, token string) (*models.Session, error) {
+		return &models.Session{
+			ID:           uuid.New(),
+			UserID:       userID,
+			AccessToken:  token,
+			AccessExpiry: time.Now().Add(15 * time.Minute),
+		}, nil
+	}
+
+	return expenses.NewHandler(mockService, mockSession), mockService
+}
+
+func TestHandlerCreateExpense(t *testing.T) {
+	testCases := []struct {
+		name             string
+		body             string
+		serviceErr       error
+		expectedStatus   int
+		expectedCode     string
+		expectedCurrency string
+	}{
+		{
+			name:             "SuccessfulCreation",
+			body:             `{"amount_cents": 1250, "currency": "eur", "category": "food", "incurred_at": "2025-06-10T12:00:00Z"}`,
+			expectedStatus:   http.StatusCreated,
+			expectedCurrency: "EUR",
+		},
+		{
+			name:           "InvalidCurrency",
+			body:           `{"amount_cents": 1250, "currency": "EURO", "category": "food", "incurred_at": "2025-06-10T12:00:00Z"}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   response.CodeValidationFailed,
+		},
+		{
+			name:           "NegativeAmount",
+			body:           `{"amount_cents": -5, "currency": "EUR", "category": "food", "incurred_at": "2025-06-10T12:00:00Z"}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   response.CodeValidationFailed,
+		},
+		{
+			name:           "FractionalAmount",
+			body:           `{"amount_cents": 12.5, "currency": "EUR", "category": "food", "incurred_at": "2025-06-10T12:00:00Z"}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   response.CodeInvalidRequest,
+		},
+		{
+			name:           "UnauthorizedAccess",
+			body:           `{"amount_cents": 1250, "currency": "EUR", "category": "food", "incurred_at": "2025-06-10T12:00:00Z"}`,
+			serviceErr:     errors.New("unauthorized access to trip"),
+			expectedStatus: http.StatusForbidden,
+			expectedCode:   response.CodeForbidden,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			userID := uuid.New()
+			handler, mockService := setupHandlerTest(userID)
+			tripID := uuid.New().String()
+
+			mockService.createExpenseFunc = func(ctx context.Context, tid uuid.UUID, uid uuid.UUID, input models.CreateExpenseInput) (*models.Expense, error) {
+				if tc.serviceErr != nil {
+					return nil, tc.serviceErr
+				}
+				return &models.Expense{
+					ID:          uuid.New(),
+					TripID:      tid,
+					AmountCents: input.AmountCents,
+					Currency:    input.Currency,
+					Category:    input.Category,
+				}, nil
+			}
+
+			c, rec := newTestContext(http.MethodPost, "/api/trips/"+tripID+"/expenses", []byte(tc.body), tripID)
+
+			if err := handler.CreateExpense(c); err != nil {
+				t.Errorf("Expected no error, got: %v", err)
+			}
+
+			if rec.Code != tc.expectedStatus {
+				t.Fatalf("Expected status %d, got %d", tc.expectedStatus, rec.Code)
+			}
+
+			if tc.expectedCode != "" {
+				var envelope response.ErrorEnvelope
+				if err := json.Unmarshal(rec.Body.Bytes(), &envelope); err != nil {
+					t.Fatalf("Failed to unmarshal response: %v", err)
+				}
+				if envelope.Error.Code != tc.expectedCode {
+					t.Errorf("Expected code '%s', got '%s'", tc.expectedCode, envelope.Error.Code)
+				}
+				return
+			}
+
+			var expense models.Expense
+			if err := json.Unmarshal(rec.Body.Bytes(), &expense); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+			if expense.Currency != tc.expectedCurrency {
+				t.Errorf("Expected currency '%s', got '%s'", tc.expectedCurrency, expense.Currency)
+			}
+		})
+	}
+}
+
+func TestHandlerGetBudget(t *testing.T) {
+	userID := uuid.New()
+	handler, mockService := setupHandlerTest(userID)
+	tripID := uuid.New()
+
+	mockService.getBudgetFunc = func(ctx context.Context, tid uuid.UUID, uid uuid.UUID) (*models.BudgetSummary, error) {
+		return &models.BudgetSummary{
+			TripID:     tid,
+			ByCategory: []*models.BudgetTotal{{Category: "food", Currency: "EUR", AmountCents: 2500}},
+			ByCurrency: map[string]int64{"EUR": 2500},
+		}, nil
+	}
+
+	c, rec := newTestContext(http.MethodGet, "/api/trips/"+tripID.String()+"/budget", nil, tripID.String())
+
+	if err := handler.GetBudget(c); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, rec.Code)
+	}
+
+	var summary models.BudgetSummary
+	if err := json.Unmarshal(rec.Body.Bytes(), &summary); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if summary.ByCurrency["EUR"] != 2500 {
+		t.Errorf("Expected EUR total 2500, got %d", summary.ByCurrency["EUR"])
+	}
+}
+
+func TestHandlerDeleteExpense(t *testing.T) {
+	userID := uuid.New()
+	handler, mockService := setupHandlerTest(userID)
+	tripID := uuid.New().String()
+
+	mockService.deleteExpenseFunc = func(ctx context.Context, tid uuid.UUID, eid uuid.UUID, uid uuid.UUID) error {
+		return errors.New("expense not found")
+	}
+
+	expenseID := uuid.New().String()
+
+	c, rec := newTestContext(http.MethodDelete, "/api/trips/"+tripID+"/expenses/"+expenseID, nil, tripID)
+	c.SetParamNames("id", "expenseId")
+	c.SetParamValues(tripID, expenseID)
+
+	if err := handler.DeleteExpense(c); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d, got %d", http.StatusNotFound, rec.Code)
+	}
+}
+
+func TestHandlerGetExpenseTimeline(t *testing.T) {
+	testCases := []struct {
+		name           string
+		query          string
+		serviceErr     error
+		expectedStatus int
+	}{
+		{name: "Success", query: "?granularity=week", expectedStatus: http.StatusOK},
+		{name: "InvalidGranularity", query: "?granularity=month", serviceErr: errors.New("invalid granularity"), expectedStatus: http.StatusBadRequest},
+		{name: "Forbidden", serviceErr: errors.New("unauthorized access to trip"), expectedStatus: http.StatusForbidden},
+		{name: "TripNotFound", serviceErr: errors.New("trip not found"), expectedStatus: http.StatusNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			userID := uuid.New()
+			handler, mockService := setupHandlerTest(userID)
+			tripID := uuid.New()
+			week := time.Date(2030, 6, 3, 0, 0, 0, 0, time.UTC)
+
+			mockService.getExpenseTimelineFunc = func(ctx context.Context, tid uuid.UUID, uid uuid.UUID, granularity string) (*models.ExpenseTimeline, error) {
+				if tc.serviceErr != nil {
+					return nil, tc.serviceErr
+				}
+				if granularity != "week" {
+					t.Errorf("Expected granularity week, got %q", granularity)
+				}
+				return &models.ExpenseTimeline{
+					TripID:      tid,
+					Granularity: granularity,
+					Buckets:     []*models.ExpenseTimelineBucket{{PeriodStart: week, Currency: "EUR", AmountCents: 3500}},
+				}, nil
+			}
+
+			c, rec := newTestContext(http.MethodGet, "/api/trips/"+tripID.String()+"/expenses/timeline"+tc.query, nil, tripID.String())
+
+			if err := handler.GetExpenseTimeline(c); err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			if rec.Code != tc.expectedStatus {
+				t.Fatalf("Expected status %d, got %d", tc.expectedStatus, rec.Code)
+			}
+
+			if tc.expectedStatus == http.StatusOK {
+				var timeline models.ExpenseTimeline
+				if err := json.Unmarshal(rec.Body.Bytes(), &timeline); err != nil {
+					t.Fatalf("Failed to unmarshal response: %v", err)
+				}
+				if len(timeline.Buckets) != 1 || !timeline.Buckets[0].PeriodStart.Equal(week) {
+					t.Errorf("Expected one bucket for the week of %v, got %+v", week, timeline.Buckets)
+				}
+			}
+		})
+	}
+}
+
+func TestHandlerGetSettlement(t *testing.T) {
+	testCases := []struct {
+		name           string
+		serviceErr     error
+		expectedStatus int
+	}{
+		{name: "Success", expectedStatus: http.StatusOK},
+		{name: "Forbidden", serviceErr: errors.New("unauthorized access to trip"), expectedStatus: http.StatusForbidden},
+		{name: "TripNotFound", serviceErr: errors.New("trip not found"), expectedStatus: http.StatusNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			userID := uuid.New()
+			handler, mockService := setupHandlerTest(userID)
+			tripID := uuid.New()
+
+			mockService.getSettlementFunc = func(ctx context.Context, tid uuid.UUID, uid uuid.UUID) (*models.ExpenseSettlement, error) {
+				if tc.serviceErr != nil {
+					return nil, tc.serviceErr
+				}
+				return &models.ExpenseSettlement{
+					TripID:    tid,
+					Balances:  []models.ExpenseBalance{{Name: "Alice", Currency: "EUR", BalanceCents: 1500}, {Name: "Bob", Currency: "EUR", BalanceCents: -1500}},
+					Transfers: []models.SettlementTransfer{{From: "Bob", To: "Alice", Currency: "EUR", AmountCents: 1500}},
+				}, nil
+			}
+
+			c, rec := newTestContext(http.MethodGet, "/api/trips/"+tripID.String()+"/expenses/settlement", nil, tripID.String())
+
+			if err := handler.GetSettlement(c); err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			if rec.Code != tc.expectedStatus {
+				t.Fatalf("Expected status %d, got %d", tc.expectedStatus, rec.Code)
+			}
+
+			if tc.expectedStatus == http.StatusOK {
+				var settlement models.ExpenseSettlement
+				if err := json.Unmarshal(rec.Body.Bytes(), &settlement); err != nil {
+					t.Fatalf("Failed to unmarshal response: %v", err)
+				}
+				if len(settlement.Transfers) != 1 || settlement.Transfers[0].From != "Bob" {
+					t.Errorf("Expected one transfer from Bob, got %+v", settlement.Transfers)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,23 @@
+package expenses
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"black-lotus/internal/domain/models"
+)
+
+type Repository interface {
+	CreateExpense(ctx context.Context, tripID uuid.UUID, input models.CreateExpenseInput) (*models.Expense, error)
+	GetExpenseByID(ctx context.Context, expenseID uuid.UUID) (*models.Expense, error)
+	GetExpensesByTripID(ctx context.Context, tripID uuid.UUID) ([]*models.Expense, error)
+	DeleteExpense(ctx context.Context, expenseID uuid.UUID) error
+	GetBudgetTotals(ctx context.Context, tripID uuid.UUID) ([]*models.BudgetTotal, error)
+	GetExpenseTimeline(ctx context.Context, tripID uuid.UUID, granularity string) ([]*models.ExpenseTimelineBucket, error)
+}
+
+// TripRepository defines trip operations needed by the expenses feature
+type TripRepository interface {
+	GetTripByID(ctx context.Context, tripID uuid.UUID) (*models.Trip, error)
+}
@@ -0,0 +1,194 @@
+package expenses
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"black-lotus/internal/domain/models"
+)
+
+type ServiceInterface interface {
+	CreateExpense(ctx context.Context, tripID uuid.UUID, userID uuid.UUID, input models.CreateExpenseInput) (*models.Expense, error)
+	GetExpensesByTripID(ctx context.Context, tripID uuid.UUID, userID uuid.UUID) ([]*models.Expense, error)
+	DeleteExpense(ctx context.Context, tripID uuid.UUID, expenseID uuid.UUID, userID uuid.UUID) error
+	GetBudget(ctx context.Context, tripID uuid.UUID, userID uuid.UUID) (*models.BudgetSummary, error)
+	GetExpenseTimeline(ctx context.Context, tripID uuid.UUID, userID uuid.UUID, granularity string) (*models.ExpenseTimeline, error)
+	GetSettlement(ctx context.Context, tripID uuid.UUID, userID uuid.UUID) (*models.ExpenseSettlement, error)
+}
+
+type Service struct {
+	repo     Repository
+	tripRepo TripRepository
+}
+
+func NewService(repo Repository, tripRepo TripRepository) *Service {
+	return &Service{repo: repo, tripRepo: tripRepo}
+}
+
+// CreateExpense records an expense on a trip the user owns
+func (s *Service) CreateExpense(ctx context.Context, tripID uuid.UUID, userID uuid.UUID, input models.CreateExpenseInput) (*models.Expense, error) {
+	if err := s.verifyTripOwnership(ctx, tripID, userID); err != nil {
+		return nil, err
+	}
+
+	if input.AmountCents <= 0 {
+		return nil, errors.New("amount must be positive")
+	}
+
+	// Categories are grouped case-insensitively in the budget summary
+	input.Category = strings.ToLower(strings.TrimSpace(input.Category))
+	if input.Category == "" {
+		return nil, errors.New("category is required")
+	}
+
+	paidBy, splitAmong, err := NormalizeSplit(input.PaidBy, input.SplitAmong)
+	if err != nil {
+		return nil, err
+	}
+	input.PaidBy, input.SplitAmong = paidBy, splitAmong
+
+	return s.repo.CreateExpense(ctx, tripID, input)
+}
+
+// GetExpensesByTripID lists a trip's expenses
+func (s *Service) GetExpensesByTripID(ctx context.Context, tripID uuid.UUID, userID uuid.UUID) ([]*models.Expense, error) {
+	if err := s.verifyTripOwnership(ctx, tripID, userID); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetExpensesByTripID(ctx, tripID)
+}
+
+// DeleteExpense removes an expense from a trip the user owns
+func (s *Service) DeleteExpense(ctx context.Context, tripID uuid.UUID, expenseID uuid.UUID, userID uuid.UUID) error {
+	if err := s.verifyTripOwnership(ctx, tripID, userID); err != nil {
+		return err
+	}
+
+	expense, err := s.repo.GetExpenseByID(ctx, expenseID)
+	if err != nil {
+		return err
+	}
+
+	// Expenses of other trips are reported as missing rather than leaking their existence
+	if expense.TripID != tripID {
+		return errors.New("expense not found")
+	}
+
+	return s.repo.DeleteExpense(ctx, expenseID)
+}
+
+// GetBudget summarizes a trip's spending by category and by currency
+func (s *Service) GetBudget(ctx context.Context, tripID uuid.UUID, userID uuid.UUID) (*models.BudgetSummary, error) {
+	if err := s.verifyTripOwnership(ctx, tripID, userID); err != nil {
+		return nil, err
+	}
+
+	totals, err := s.repo.GetBudgetTotals(ctx, tripID)
+	if err != nil {
+		return nil, err
+	}
+
+	byCurrency := make(map[string]int64)
+	for _, total := range totals {
+		byCurrency[total.Currency] += total.AmountCents
+	}
+
+	return &models.BudgetSummary{
+		TripID:     tripID,
+		ByCategory: totals,
+		ByCurrency: byCurrency,
+	}, nil
+}
+
+// GetExpenseTimeline buckets a trip's spending by day or week and currency.
+// An empty granularity means daily.
+func (s *Service) GetExpenseTimeline(ctx context.Context, tripID uuid.UUID, userID uuid.UUID, granularity string) (*models.ExpenseTimeline, error) {
+	switch granularity {
+	case "":
+		granularity = models.ExpenseGranularityDay
+	case models.ExpenseGranularityDay, models.ExpenseGranularityWeek:
+	default:
+		return nil, errors.New("invalid granularity")
+	}
+
+	if err := s.verifyTripOwnership(ctx, tripID, userID); err != nil {
+		return nil, err
+	}
+
+	buckets, err := s.repo.GetExpenseTimeline(ctx, tripID, granularity)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.ExpenseTimeline{
+		TripID:      tripID,
+		Granularity: granularity,
+		Buckets:     buckets,
+	}, nil
+}
+
+// GetSettlement works out who owes whom for a trip's shared expenses, using
+// as few transfers as possible. Expenses that aren't split are ignored.
+func (s *Service) GetSettlement(ctx context.Context, tripID uuid.UUID, userID uuid.UUID) (*models.ExpenseSettlement, error) {
+	if err := s.verifyTripOwnership(ctx, tripID, userID); err != nil {
+		return nil, err
+	}
+
+	expenses, err := s.repo.GetExpensesByTripID(ctx, tripID)
+	if err != nil {
+		return nil, err
+	}
+
+	balances, transfers := settle(expenses)
+	return &models.ExpenseSettlement{
+		TripID:    tripID,
+		Balances:  balances,
+		Transfers: transfers,
+	}, nil
+}
+
+// NormalizeSplit trims names and drops duplicates from the split. An expense
+// is either shared, with a payer and at least one person to split among, or
+// not shared at all. Trip import checks expenses with it too.
+func NormalizeSplit(paidBy string, splitAmong []string) (string, []string, error) {
+	paidBy = strings.TrimSpace(paidBy)
+	if paidBy == "" && len(splitAmong) == 0 {
+		return "", nil, nil
+	}
+	if paidBy == "" || len(splitAmong) == 0 {
+		return "", nil, errors.New("paid_by and split_among must be set together")
+	}
+
+	seen := make(map[string]bool, len(splitAmong))
+	names := make([]string, 0, len(splitAmong))
+	for _, name := range splitAmong {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			return "", nil, errors.New("split_among names cannot be empty")
+		}
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+
+	return paidBy, names, nil
+}
+
+// verifyTripOwnership makes sure the trip exists and belongs to the user
+func (s *Service) verifyTripOwnership(ctx context.Context, tripID uuid.UUID, userID uuid.UUID) error {
+	trip, err := s.tripRepo.GetTripByID(ctx, tripID)
+	if err != nil {
+		return err
+	}
+
+	if trip.UserID != userID {
+		return errors.New("unauthorized access to trip")
+	}
+
+	return nil
+}
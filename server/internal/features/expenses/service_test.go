@@ -0,0 +1,432 @@
+package expenses_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"black-lotus/internal/domain/models"
+	"black-lotus/internal/features/expenses"
+)
+
+// MockExpenseRepository implements expenses.Repository for testing
+type MockExpenseRepository struct {
+	createExpenseFunc       func(ctx context.Context, tripID uuid.UUID, input models.CreateExpenseInput) (*models.Expense, error)
+	getExpenseByIDFunc      func(ctx context.Context, expenseID uuid.UUID) (*models.Expense, error)
+	getExpensesByTripIDFunc func(ctx context.Context, tripID uuid.UUID) ([]*models.Expense, error)
+	deleteExpenseFunc       func(ctx context.Context, expenseID uuid.UUID) error
+	getBudgetTotalsFunc     func(ctx context.Context, tripID uuid.UUID) ([]*models.BudgetTotal, error)
+	getExpenseTimelineFunc  func(ctx context.Context, tripID uuid.UUID, granularity string) ([]*models.ExpenseTimelineBucket, error)
+}
+
+func (m *MockExpenseRepository) CreateExpense(ctx context.Context, tripID uuid.UUID, input models.CreateExpenseInput) (*models.Expense, error) {
+	if m.createExpenseFunc != nil {
+		return m.createExpenseFunc(ctx, tripID, input)
+	}
+	return nil, errors.New("CreateExpense not implemented")
+}
+
+func (m *MockExpenseRepository) GetExpenseByID(ctx context.Context, expenseID uuid.UUID) (*models.Expense, error) {
+	if m.getExpenseByIDFunc != nil {
+		return m.getExpenseByIDFunc(ctx, expenseID)
+	}
+	return nil, errors.New("GetExpenseByID not implemented")
+}
+
+func (m *MockExpenseRepository) GetExpensesByTripID(ctx context.Context, tripID uuid.UUID) ([]*models.Expense, error) {
+	if m.getExpensesByTripIDFunc != nil {
+		return m.getExpensesByTripIDFunc(ctx, tripID)
+	}
+	return nil, errors.New("GetExpensesByTripID not implemented")
+}
+
+func (m *MockExpenseRepository) DeleteExpense(ctx context.Context, expenseID uuid.UUID) error {
+	if m.deleteExpenseFunc != nil {
+		return m.deleteExpenseFunc(ctx, expenseID)
+	}
+	return errors.New("DeleteExpense not implemented")
+}
+
+func (m *MockExpenseRepository) GetBudgetTotals(ctx context.Context, tripID uuid.UUID) ([]*models.BudgetTotal, error) {
+	if m.getBudgetTotalsFunc != nil {
+		return m.getBudgetTotalsFunc(ctx, tripID)
+	}
+	return nil, errors.New("GetBudgetTotals not implemented")
+}
+
+func (m *MockExpenseRepository) GetExpenseTimeline(ctx context.Context, tripID uuid.UUID, granularity string) ([]*models.ExpenseTimelineBucket, error) {
+	if m.getExpenseTimelineFunc != nil {
+		return m.getExpenseTimelineFunc(ctx, tripID, granularity)
+	}
+	return nil, errors.New("GetExpenseTimeline not implemented")
+}
+
+// MockTripRepository implements expenses.TripRepository for testing
+type MockTripRepository struct {
+	getTripByIDFunc func(ctx context.Context, tripID uuid.UUID) (*models.Trip, error)
+}
+
+func (m *MockTripRepository) GetTripByID(ctx context.Context, tripID uuid.UUID) (*models.Trip, error) {
+	if m.getTripByIDFunc != nil {
+		return m.getTripByIDFunc(ctx, tripID)
+	}
+	return nil, errors.New("GetTripByID not implemented")
+}
+
+// Helper function to setup service for testing with a trip owned by owner
+func setupServiceTest(owner uuid.UUID) (*expenses.Service, *MockExpenseRepository, *MockTripRepository) {
+	mockRepo := &MockExpenseRepository{}
+	mockTripRepo := &MockTripRepository{}
+
+	mockTripRepo.getTripByIDFunc = func(ctx context.Context, tripID uuid.UUID) (*models.Trip, error) {
+		return &models.Trip{ID: tripID, UserID: owner, Name: "Test Trip"}, nil
+	}
+
+	return expenses.NewService(mockRepo, mockTripRepo), mockRepo, mockTripRepo
+}
+
+func TestServiceCreateExpense(t *testing.T) {
+	userID := uuid.New()
+	tripID := uuid.New()
+
+	testCases := []struct {
+		name             string
+		userID           uuid.UUID
+		input            models.CreateExpenseInput
+		expectedCategory string
+		expectedError    string
+	}{
+		{
+			name:   "NormalizesCategory",
+			userID: userID,
+			input: models.CreateExpenseInput{
+				AmountCents: 1250,
+				Currency:    "EUR",
+				Category:    " Food ",
+				IncurredAt:  time.Now(),
+			},
+			expectedCategory: "food",
+		},
+		{
+			name:   "NonPositiveAmount",
+			userID: userID,
+			input: models.CreateExpenseInput{
+				AmountCents: 0,
+				Currency:    "EUR",
+				Category:    "food",
+				IncurredAt:  time.Now(),
+			},
+			expectedError: "amount must be positive",
+		},
+		{
+			name:   "PaidByWithoutSplit",
+			userID: userID,
+			input: models.CreateExpenseInput{
+				AmountCents: 1250,
+				Currency:    "EUR",
+				Category:    "food",
+				PaidBy:      "Alice",
+				IncurredAt:  time.Now(),
+			},
+			expectedError: "paid_by and split_among must be set together",
+		},
+		{
+			name:   "BlankSplitName",
+			userID: userID,
+			input: models.CreateExpenseInput{
+				AmountCents: 1250,
+				Currency:    "EUR",
+				Category:    "food",
+				PaidBy:      "Alice",
+				SplitAmong:  []string{"Alice", " "},
+				IncurredAt:  time.Now(),
+			},
+			expectedError: "split_among names cannot be empty",
+		},
+		{
+			name:   "UnauthorizedAccess",
+			userID: uuid.New(),
+			input: models.CreateExpenseInput{
+				AmountCents: 1250,
+				Currency:    "EUR",
+				Category:    "food",
+				IncurredAt:  time.Now(),
+			},
+			expectedError: "unauthorized access to trip",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			service, mockRepo, _ := setupServiceTest(userID)
+
+			mockRepo.createExpenseFunc = func(ctx context.Context, tid uuid.UUID, input models.CreateExpenseInput) (*models.Expense, error) {
+				return &models.Expense{
+					ID:          uuid.New(),
+					TripID:      tid,
+					AmountCents: input.AmountCents,
+					Currency:    input.Currency,
+					Category:    input.Category,
+					IncurredAt:  input.IncurredAt,
+				}, nil
+			}
+
+			expense, err := service.CreateExpense(context.Background(), tripID, tc.userID, tc.input)
+
+			if tc.expectedError != "" {
+				if err == nil || err.Error() != tc.expectedError {
+					t.Fatalf("Expected error '%s', got %v", tc.expectedError, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if expense.Category != tc.expectedCategory {
+				t.Errorf("Expected category '%s', got '%s'", tc.expectedCategory, expense.Category)
+			}
+		})
+	}
+}
+
+func TestServiceDeleteExpense(t *testing.T) {
+	userID := uuid.New()
+	tripID := uuid.New()
+
+	testCases := []struct {
+		name          string
+		expenseTrip   uuid.UUID
+		expectedError string
+	}{
+		{
+			name:        "SuccessfulDeletion",
+			expenseTrip: tripID,
+		},
+		{
+			name:          "ExpenseOfAnotherTrip",
+			expenseTrip:   uuid.New(),
+			expectedError: "expense not found",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			service, mockRepo, _ := setupServiceTest(userID)
+
+			mockRepo.getExpenseByIDFunc = func(ctx context.Context, id uuid.UUID) (*models.Expense, error) {
+				return &models.Expense{ID: id, TripID: tc.expenseTrip}, nil
+			}
+			deleted := false
+			mockRepo.deleteExpenseFunc = func(ctx context.Context, id uuid.UUID) error {
+				deleted = true
+				return nil
+			}
+
+			err := service.DeleteExpense(context.Background(), tripID, uuid.New(), userID)
+
+			if tc.expectedError != "" {
+				if err == nil || err.Error() != tc.expectedError {
+					t.Fatalf("Expected error '%s', got %v", tc.expectedError, err)
+				}
+				if deleted {
+					t.Error("Expected the expense not to be deleted")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if !deleted {
+				t.Error("Expected the expense to be deleted")
+			}
+		})
+	}
+}
+
+func TestServiceGetBudget(t *testing.T) {
+	userID := uuid.New()
+	tripID := uuid.New()
+
+	service, mockRepo, _ := setupServiceTest(userID)
+
+	mockRepo.getBudgetTotalsFunc = func(ctx context.Context, tid uuid.UUID) ([]*models.BudgetTotal, error) {
+		return []*models.BudgetTotal{
+			{Category: "food", Currency: "EUR", AmountCents: 2500},
+			{Category: "food", Currency: "USD", AmountCents: 1000},
+			{Category: "lodging", Currency: "EUR", AmountCents: 12000},
+		}, nil
+	}
+
+	summary, err := service.GetBudget(context.Background(), tripID, userID)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if len(summary.ByCategory) != 3 {
+		t.Errorf("Expected 3 category totals, got %d", len(summary.ByCategory))
+	}
+	if summary.ByCurrency["EUR"] != 14500 {
+		t.Errorf("Expected EUR total 14500, got %d", summary.ByCurrency["EUR"])
+	}
+	if summary.ByCurrency["USD"] != 1000 {
+		t.Errorf("Expected USD total 1000, got %d", summary.ByCurrency["USD"])
+	}
+
+	t.Run("UnauthorizedAccess", func(t *testing.T) {
+		_, err := service.GetBudget(context.Background(), tripID, uuid.New())
+		if err == nil || err.Error() != "unauthorized access to trip" {
+			t.Fatalf("Expected error 'unauthorized access to trip', got %v", err)
+		}
+	})
+}
+
+func TestServiceGetExpenseTimeline(t *testing.T) {
+	userID := uuid.New()
+	tripID := uuid.New()
+
+	testCases := []struct {
+		name                string
+		userID              uuid.UUID
+		granularity         string
+		expectedGranularity string
+		expectedError       string
+	}{
+		{name: "DefaultsToDay", userID: userID, granularity: "", expectedGranularity: "day"},
+		{name: "Day", userID: userID, granularity: "day", expectedGranularity: "day"},
+		{name: "Week", userID: userID, granularity: "week", expectedGranularity: "week"},
+		{name: "InvalidGranularity", userID: userID, granularity: "month", expectedError: "invalid granularity"},
+		{name: "UnauthorizedAccess", userID: uuid.New(), granularity: "day", expectedError: "unauthorized access to trip"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			service, mockRepo, _ := setupServiceTest(userID)
+
+			day := time.Date(2030, 6, 7, 0, 0, 0, 0, time.UTC)
+			mockRepo.getExpenseTimelineFunc = func(ctx context.Context, tid uuid.UUID, granularity string) ([]*models.ExpenseTimelineBucket, error) {
+				if granularity != tc.expectedGranularity {
+					t.Errorf("Expected granularity %q, got %q", tc.expectedGranularity, granularity)
+				}
+				return []*models.ExpenseTimelineBucket{
+					{PeriodStart: day, Currency: "EUR", AmountCents: 2500},
+					{PeriodStart: day.AddDate(0, 0, 1), Currency: "EUR", AmountCents: 1000},
+				}, nil
+			}
+
+			timeline, err := service.GetExpenseTimeline(context.Background(), tripID, tc.userID, tc.granularity)
+
+			if tc.expectedError != "" {
+				if err == nil || err.Error() != tc.expectedError {
+					t.Fatalf("Expected error %q, got %v", tc.expectedError, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if timeline.TripID != tripID || timeline.Granularity != tc.expectedGranularity {
+				t.Errorf("Expected trip %s at %q, got %s at %q", tripID, tc.expectedGranularity, timeline.TripID, timeline.Granularity)
+			}
+			if len(timeline.Buckets) != 2 {
+				t.Errorf("Expected 2 buckets, got %d", len(timeline.Buckets))
+			}
+		})
+	}
+}
+
+func TestServiceCreateExpenseNormalizesSplit(t *testing.T) {
+	userID := uuid.New()
+	service, mockRepo, _ := setupServiceTest(userID)
+
+	mockRepo.createExpenseFunc = func(ctx context.Context, tid uuid.UUID, input models.CreateExpenseInput) (*models.Expense, error) {
+		return &models.Expense{TripID: tid, PaidBy: input.PaidBy, SplitAmong: input.SplitAmong}, nil
+	}
+
+	expense, err := service.CreateExpense(context.Background(), uuid.New(), userID, models.CreateExpenseInput{
+		AmountCents: 9000,
+		Currency:    "EUR",
+		Category:    "lodging",
+		PaidBy:      " Alice ",
+		SplitAmong:  []string{"Alice", " Bob", "Alice", "Carol"},
+		IncurredAt:  time.Now(),
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if expense.PaidBy != "Alice" {
+		t.Errorf("Expected paid_by 'Alice', got %q", expense.PaidBy)
+	}
+	if len(expense.SplitAmong) != 3 || expense.SplitAmong[1] != "Bob" {
+		t.Errorf("Expected split among [Alice Bob Carol], got %v", expense.SplitAmong)
+	}
+}
+
+func TestServiceGetSettlement(t *testing.T) {
+	userID := uuid.New()
+	tripID := uuid.New()
+
+	service, mockRepo, _ := setupServiceTest(userID)
+
+	everyone := []string{"Alice", "Bob", "Carol"}
+	mockRepo.getExpensesByTripIDFunc = func(ctx context.Context, tid uuid.UUID) ([]*models.Expense, error) {
+		return []*models.Expense{
+			{AmountCents: 9000, Currency: "EUR", PaidBy: "Alice", SplitAmong: everyone},
+			{AmountCents: 3000, Currency: "EUR", PaidBy: "Bob", SplitAmong: []string{"Carol", "Bob"}},
+			// 100 cents don't split evenly three ways; Alice takes the extra cent
+			{AmountCents: 100, Currency: "USD", PaidBy: "Carol", SplitAmong: everyone},
+			// Not shared, so it doesn't affect anyone's balance
+			{AmountCents: 5000, Currency: "EUR"},
+		}, nil
+	}
+
+	settlement, err := service.GetSettlement(context.Background(), tripID, userID)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	expectedBalances := []models.ExpenseBalance{
+		{Name: "Alice", Currency: "EUR", BalanceCents: 6000},
+		{Name: "Bob", Currency: "EUR", BalanceCents: -1500},
+		{Name: "Carol", Currency: "EUR", BalanceCents: -4500},
+		{Name: "Alice", Currency: "USD", BalanceCents: -34},
+		{Name: "Bob", Currency: "USD", BalanceCents: -33},
+		{Name: "Carol", Currency: "USD", BalanceCents: 67},
+	}
+	if len(settlement.Balances) != len(expectedBalances) {
+		t.Fatalf("Expected %d balances, got %+v", len(expectedBalances), settlement.Balances)
+	}
+	for i, expected := range expectedBalances {
+		if settlement.Balances[i] != expected {
+			t.Errorf("Expected balance %+v, got %+v", expected, settlement.Balances[i])
+		}
+	}
+
+	expectedTransfers := []models.SettlementTransfer{
+		{From: "Carol", To: "Alice", Currency: "EUR", AmountCents: 4500},
+		{From: "Bob", To: "Alice", Currency: "EUR", AmountCents: 1500},
+		{From: "Alice", To: "Carol", Currency: "USD", AmountCents: 34},
+		{From: "Bob", To: "Carol", Currency: "USD", AmountCents: 33},
+	}
+	if len(settlement.Transfers) != len(expectedTransfers) {
+		t.Fatalf("Expected %d transfers, got %+v", len(expectedTransfers), settlement.Transfers)
+	}
+	for i, expected := range expectedTransfers {
+		if settlement.Transfers[i] != expected {
+			t.Errorf("Expected transfer %+v, got %+v", expected, settlement.Transfers[i])
+		}
+	}
+
+	t.Run("UnauthorizedAccess", func(t *testing.T) {
+		_, err := service.GetSettlement(context.Background(), tripID, uuid.New())
+		if err == nil || err.Error() != "unauthorized access to trip" {
+			t.Fatalf("Expected error 'unauthorized access to trip', got %v", err)
+		}
+	})
+}
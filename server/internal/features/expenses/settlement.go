@@ -0,0 +1,123 @@
+package expenses
+
+import (
+	"sort"
+
+	"black-lotus/internal/domain/models"
+)
+
+// settle works out each person's balance per currency and the transfers that
+// clear them. Every shared expense credits its payer with the full amount and
+// debits each person it's split among an equal share. Leftover cents go to the
+// first names alphabetically, so the result doesn't depend on input order.
+func settle(expenses []*models.Expense) ([]models.ExpenseBalance, []models.SettlementTransfer) {
+	balances := make(map[string]map[string]int64)
+	for _, expense := range expenses {
+		if expense.PaidBy == "" || len(expense.SplitAmong) == 0 {
+			continue
+		}
+
+		byName, exists := balances[expense.Currency]
+		if !exists {
+			byName = make(map[string]int64)
+			balances[expense.Currency] = byName
+		}
+
+		names := append([]string(nil), expense.SplitAmong...)
+		sort.Strings(names)
+
+		share := expense.AmountCents / int64(len(names))
+		remainder := expense.AmountCents % int64(len(names))
+
+		byName[expense.PaidBy] += expense.AmountCents
+		for i, name := range names {
+			owed := share
+			if int64(i) < remainder {
+				owed++
+			}
+			byName[name] -= owed
+		}
+	}
+
+	currencies := make([]string, 0, len(balances))
+	for currency := range balances {
+		currencies = append(currencies, currency)
+	}
+	sort.Strings(currencies)
+
+	result := []models.ExpenseBalance{}
+	transfers := []models.SettlementTransfer{}
+	for _, currency := range currencies {
+		var creditors, debtors []models.ExpenseBalance
+		for name, cents := range balances[currency] {
+			balance := models.ExpenseBalance{Name: name, Currency: currency, BalanceCents: cents}
+			switch {
+			case cents > 0:
+				creditors = append(creditors, balance)
+			case cents < 0:
+				debtors = append(debtors, balance)
+			}
+			result = append(result, balance)
+		}
+
+		transfers = append(transfers, minimizeTransfers(currency, creditors, debtors)...)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Currency != result[j].Currency {
+			return result[i].Currency < result[j].Currency
+		}
+		return result[i].Name < result[j].Name
+	})
+
+	return result, transfers
+}
+
+// minimizeTransfers repeatedly has the biggest debtor pay the biggest
+// creditor. Each transfer clears at least one of them, so n people never need
+// more than n-1 transfers.
+func minimizeTransfers(currency string, creditors, debtors []models.ExpenseBalance) []models.SettlementTransfer {
+	var transfers []models.SettlementTransfer
+	for len(creditors) > 0 && len(debtors) > 0 {
+		sortByAmount(creditors)
+		sortByAmount(debtors)
+
+		creditor, debtor := &creditors[0], &debtors[0]
+		amount := min(creditor.BalanceCents, -debtor.BalanceCents)
+
+		transfers = append(transfers, models.SettlementTransfer{
+			From:        debtor.Name,
+			To:          creditor.Name,
+			Currency:    currency,
+			AmountCents: amount,
+		})
+
+		creditor.BalanceCents -= amount
+		debtor.BalanceCents += amount
+		if creditor.BalanceCents == 0 {
+			creditors = creditors[1:]
+		}
+		if debtor.BalanceCents == 0 {
+			debtors = debtors[1:]
+		}
+	}
+	return transfers
+}
+
+// sortByAmount puts the largest balance (either sign) first, ties by name
+func sortByAmount(balances []models.ExpenseBalance) {
+	sort.Slice(balances, func(i, j int) bool {
+		a, b := abs(balances[i].BalanceCents), abs(balances[j].BalanceCents)
+		if a != b {
+			return a > b
+		}
+		return balances[i].Name < balances[j].Name
+	})
+}
+
+func abs(n int64) int64 {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,70 @@
+package forecasts
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"black-lotus/internal/common/params"
+	"black-lotus/internal/common/response"
+	"black-lotus/internal/features/auth/session"
+	"black-lotus/pkg/weather"
+)
+
+type Handler struct {
+	service        ServiceInterface
+	sessionService session.ServiceInterface
+}
+
+func NewHandler(service ServiceInterface, sessionService session.ServiceInterface) *Handler {
+	return &Handler{
+		service:        service,
+		sessionService: sessionService,
+	}
+}
+
+// handleServiceError maps forecast service and provider errors to responses
+func handleServiceError(ctx echo.Context, err error, action string) error {
+	switch err.Error() {
+	case "trip not found":
+		return response.ErrorResponse(ctx, http.StatusNotFound,
+			response.CodeTripNotFound, "Trip not found", nil)
+	case "unauthorized access to trip":
+		return response.ErrorResponse(ctx, http.StatusForbidden,
+			response.CodeForbidden, "You do not have permission to access this trip", nil)
+	case "trip has no dates":
+		return response.ErrorResponse(ctx, http.StatusBadRequest,
+			response.CodeInvalidRequest, "Trip has no dates to forecast; give it a start and end date first", nil)
+	case weather.ErrLocationNotFound.Error():
+		return response.ErrorResponse(ctx, http.StatusNotFound,
+			response.CodeLocationNotFound, "Couldn't find the trip's location to forecast", nil)
+	case weather.ErrNotConfigured.Error():
+		return response.ErrorResponse(ctx, http.StatusServiceUnavailable,
+			response.CodeNotConfigured, "Weather forecasts are not configured on this server", nil)
+	}
+
+	slog.Error("Failed to "+action, "error", err)
+	return response.ErrorResponse(ctx, http.StatusInternalServerError,
+		response.CodeInternal, "Failed to "+action, nil)
+}
+
+// GetTripWeather returns the daily forecast for a trip's location and dates
+func (h *Handler) GetTripWeather(ctx echo.Context) error {
+	sess, err := session.Authenticate(ctx, h.sessionService)
+	if sess == nil {
+		return err
+	}
+
+	tripID, ok, err := params.TripID(ctx)
+	if !ok {
+		return err
+	}
+
+	forecast, err := h.service.GetTripWeather(ctx.Request().Context(), tripID, sess.UserID)
+	if err != nil {
+		return handleServiceError(ctx, err, "get trip weather")
+	}
+
+	return response.JSON(ctx, http.StatusOK, forecast)
+}
@@ -0,0 +1,160 @@
+package forecasts_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"black-lotus/internal/common/response"
+	"black-lotus/internal/domain/models"
+	"black-lotus/internal/features/forecasts"
+	"black-lotus/pkg/weather"
+)
+
+// MockForecastService implements forecasts.ServiceInterface for testing
+type MockForecastService struct {
+	getTripWeatherFunc func(ctx context.Context, tripID uuid.UUID, userID uuid.UUID) (*models.TripWeather, error)
+}
+
+func (m *MockForecastService) GetTripWeather(ctx context.Context, tripID uuid.UUID, userID uuid.UUID) (*models.TripWeather, error) {
+	if m.getTripWeatherFunc != nil {
+		return m.getTripWeatherFunc(ctx, tripID, userID)
+	}
+	return nil, errors.New("GetTripWeather not implemented")
+}
+
+// MockSessionService implements session.ServiceInterface for testing
+type MockSessionService struct {
+	validateAccessTokenFunc func(ctx context.Context, token string) (*models.Session, error)
+}
+
+func (m *MockSessionService) ValidateAccessToken(ctx context.Context, token string) (*models.Session, error) {
+	if m.validateAccessTokenFunc != nil {
+		return m.validateAccessTokenFunc(ctx, token)
+	}
+	return nil, errors.New("ValidateAccessToken not implemented")
+}
+
+func (m *MockSessionService) ValidateRefreshToken(ctx context.Context, token string) (*models.Session, error) {
+	return nil, errors.New("ValidateRefreshToken not implemented")
+}
+
+func (m *MockSessionService) CreateSession(ctx context.Context, userID uuid.UUID, refreshDuration time.Duration) (*models.Session, error) {
+	return nil, errors.New("CreateSession not implemented")
+}
+
+func (m *MockSessionService) RefreshAccessToken(ctx context.Context, refreshToken string) (*models.Session, error) {
+	return nil, errors.New("RefreshAccessToken not implemented")
+}
+
+func (m *MockSessionService) EndSessionByAccessToken(ctx context.Context, token string) error {
+	return errors.New("EndSessionByAccessToken not implemented")
+}
+
+func (m *MockSessionService) EndSessionByRefreshToken(ctx context.Context, token string) error {
+	return errors.New("EndSessionByRefreshToken not implemented")
+}
+
+func (m *MockSessionService) EndAllUserSessions(ctx context.Context, userID uuid.UUID) error {
+	return errors.New("EndAllUserSessions not implemented")
+}
+
+func (m *MockSessionService) EndOtherUserSessions(ctx context.Context, userID uuid.UUID, keepSessionID uuid.UUID) error {
+	return errors.New("EndOtherUserSessions not implemented")
+}
+
+func TestHandlerGetTripWeather(t *testing.T) {
+	testCases := []struct {
+		name           string
+		tripID         string
+		serviceErr     error
+		expectedStatus int
+		expectedCode   string
+	}{
+		{name: "Success", tripID: uuid.New().String(), expectedStatus: http.StatusOK},
+		{name: "InvalidTripID", tripID: "not-a-uuid", expectedStatus: http.StatusBadRequest, expectedCode: response.CodeInvalidID},
+		{name: "TripNotFound", tripID: uuid.New().String(), serviceErr: errors.New("trip not found"), expectedStatus: http.StatusNotFound, expectedCode: response.CodeTripNotFound},
+		{name: "UnauthorizedAccess", tripID: uuid.New().String(), serviceErr: errors.New("unauthorized access to trip"), expectedStatus: http.StatusForbidden, expectedCode: response.CodeForbidden},
+		{name: "WishlistTrip", tripID: uuid.New().String(), serviceErr: errors.New("trip has no dates"), expectedStatus: http.StatusBadRequest, expectedCode: response.CodeInvalidRequest},
+		{name: "LocationNotFound", tripID: uuid.New().String(), serviceErr: weather.ErrLocationNotFound, expectedStatus: http.StatusNotFound, expectedCode: response.CodeLocationNotFound},
+		{name: "NotConfigured", tripID: uuid.New().String(), serviceErr: weather.ErrNotConfigured, expectedStatus: http.StatusServiceUnavailable, expectedCode: response.CodeNotConfigured},
+		{name: "ProviderFailure", tripID: uuid.New().String(), serviceErr: errors.New("weather request to /data/2.5/forecast failed: status 502"), expectedStatus: http.StatusInternalServerError, expectedCode: response.CodeInternal},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			userID := uuid.New()
+			mockService := &MockForecastService{}
+			mockSession := &MockSessionService{
+				validateAccessTokenFunc: func(ctx context.Context, token string) (*models.Session, error) {
+					return &models.Session{ID: uuid.New(), UserID: userID, AccessToken: token, AccessExpiry: time.Now().Add(15 * time.Minute)}, nil
+				},
+			}
+			handler := forecasts.NewHandler(mockService, mockSession)
+
+			mockService.getTripWeatherFunc = func(ctx context.Context, tripID uuid.UUID, uid uuid.UUID) (*models.TripWeather, error) {
+				if tc.serviceErr != nil {
+					return nil, tc.serviceErr
+				}
+				if uid != userID {
+					t.Errorf("Expected user %s, got %s", userID, uid)
+				}
+				return &models.TripWeather{
+					TripID:   tripID,
+					Location: "Lisbon",
+					Days:     []weather.Day{{Date: time.Date(2026, 6, 2, 0, 0, 0, 0, time.UTC), Summary: "clear sky", TempMinC: 16, TempMaxC: 24}},
+				}, nil
+			}
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/trips/"+tc.tripID+"/weather", nil)
+			req.AddCookie(&http.Cookie{Name: "access_token", Value: "valid_access_token"})
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues(tc.tripID)
+
+			// Execute
+			if err := handler.GetTripWeather(c); err != nil {
+				t.Errorf("Expected no error, got: %v", err)
+			}
+
+			// Verify
+			if rec.Code != tc.expectedStatus {
+				t.Fatalf("Expected status %d, got %d", tc.expectedStatus, rec.Code)
+			}
+
+			if tc.expectedCode != "" {
+				var envelope response.ErrorEnvelope
+				if err := json.Unmarshal(rec.Body.Bytes(), &envelope); err != nil {
+					t.Fatalf("Failed to unmarshal response: %v", err)
+				}
+				if envelope.Error.Code != tc.expectedCode {
+					t.Errorf("Expected code '%s', got '%s'", tc.expectedCode, envelope.Error.Code)
+				}
+				return
+			}
+
+			var result struct {
+				Days []struct {
+					Date    string `json:"date"`
+					Summary string `json:"summary"`
+				} `json:"days"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &result); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+			if len(result.Days) != 1 || result.Days[0].Date != "2026-06-02" || result.Days[0].Summary != "clear sky" {
+				t.Errorf("Unexpected days: %+v", result.Days)
+			}
+		})
+	}
+}
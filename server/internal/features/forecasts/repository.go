@@ -0,0 +1,14 @@
+package forecasts
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"black-lotus/internal/domain/models"
+)
+
+// TripRepository defines trip operations needed by the forecasts feature
+type TripRepository interface {
+	GetTripByID(ctx context.Context, tripID uuid.UUID) (*models.Trip, error)
+}
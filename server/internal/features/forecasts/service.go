@@ -0,0 +1,71 @@
+package forecasts
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+
+	"black-lotus/internal/domain/models"
+	"black-lotus/pkg/weather"
+)
+
+type ServiceInterface interface {
+	GetTripWeather(ctx context.Context, tripID uuid.UUID, userID uuid.UUID) (*models.TripWeather, error)
+}
+
+type Service struct {
+	tripRepo TripRepository
+	provider weather.WeatherProvider
+}
+
+func NewService(tripRepo TripRepository, provider weather.WeatherProvider) *Service {
+	return &Service{tripRepo: tripRepo, provider: provider}
+}
+
+// GetTripWeather geocodes a trip's location and returns the forecast for the
+// trip days the provider covers. Wishlist trips have no dates to forecast.
+func (s *Service) GetTripWeather(ctx context.Context, tripID uuid.UUID, userID uuid.UUID) (*models.TripWeather, error) {
+	trip, err := s.tripRepo.GetTripByID(ctx, tripID)
+	if err != nil {
+		return nil, err
+	}
+
+	if trip.UserID != userID {
+		return nil, errors.New("unauthorized access to trip")
+	}
+
+	if trip.StartDate == nil || trip.EndDate == nil {
+		return nil, errors.New("trip has no dates")
+	}
+
+	place, err := s.provider.Geocode(ctx, trip.Location)
+	if err != nil {
+		return nil, err
+	}
+
+	forecast, err := s.provider.DailyForecast(ctx, *place)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.TripWeather{
+		TripID:   trip.ID,
+		Location: trip.Location,
+		Place:    *place,
+		Days:     []weather.Day{},
+	}
+	if len(forecast) > 0 {
+		result.ForecastFrom = &forecast[0].Date
+		result.ForecastUntil = &forecast[len(forecast)-1].Date
+	}
+
+	// Trip dates and forecast days are both midnight UTC, so they compare directly
+	for _, day := range forecast {
+		if !day.Date.Before(*trip.StartDate) && !day.Date.After(*trip.EndDate) {
+			result.Days = append(result.Days, day)
+		}
+	}
+
+	return result, nil
+}
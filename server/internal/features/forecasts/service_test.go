@@ -0,0 +1,188 @@
+package forecasts_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"black-lotus/internal/domain/models"
+	"black-lotus/internal/features/forecasts"
+	"black-lotus/pkg/weather"
+)
+
+// MockTripRepository implements forecasts.TripRepository for testing
+type MockTripRepository struct {
+	getTripByIDFunc func(ctx context.Context, tripID uuid.UUID) (*models.Trip, error)
+}
+
+func (m *MockTripRepository) GetTripByID(ctx context.Context, tripID uuid.UUID) (*models.Trip, error) {
+	if m.getTripByIDFunc != nil {
+		return m.getTripByIDFunc(ctx, tripID)
+	}
+	return nil, errors.New("GetTripByID not implemented")
+}
+
+func day(month time.Month, d int) time.Time {
+	return time.Date(2026, month, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestServiceGetTripWeather(t *testing.T) {
+	// The provider forecasts June 1st to 5th
+	var forecast []weather.Day
+	for d := 1; d <= 5; d++ {
+		forecast = append(forecast, weather.Day{Date: day(time.June, d), Summary: "clear sky"})
+	}
+	lisbon := weather.Place{Name: "Lisbon", Country: "PT", Latitude: 38.7077, Longitude: -9.1365}
+
+	testCases := []struct {
+		name          string
+		location      string
+		startDate     *time.Time
+		endDate       *time.Time
+		otherOwner    bool
+		providerErr   error
+		expectedDays  []time.Time
+		expectedError string
+	}{
+		{
+			name:         "TripInsideWindow",
+			location:     "Lisbon",
+			startDate:    ptr(day(time.June, 2)),
+			endDate:      ptr(day(time.June, 3)),
+			expectedDays: []time.Time{day(time.June, 2), day(time.June, 3)},
+		},
+		{
+			name:         "TripOverlapsWindowEnd",
+			location:     "Lisbon",
+			startDate:    ptr(day(time.June, 4)),
+			endDate:      ptr(day(time.June, 12)),
+			expectedDays: []time.Time{day(time.June, 4), day(time.June, 5)},
+		},
+		{
+			name:         "TripAlreadyStarted",
+			location:     "Lisbon",
+			startDate:    ptr(day(time.May, 28)),
+			endDate:      ptr(day(time.June, 1)),
+			expectedDays: []time.Time{day(time.June, 1)},
+		},
+		{
+			name:         "TripBeyondWindow",
+			location:     "Lisbon",
+			startDate:    ptr(day(time.July, 1)),
+			endDate:      ptr(day(time.July, 8)),
+			expectedDays: []time.Time{},
+		},
+		{
+			name:          "WishlistTrip",
+			location:      "Lisbon",
+			expectedError: "trip has no dates",
+		},
+		{
+			name:          "UnknownLocation",
+			location:      "Atlantis",
+			startDate:     ptr(day(time.June, 2)),
+			endDate:       ptr(day(time.June, 3)),
+			expectedError: weather.ErrLocationNotFound.Error(),
+		},
+		{
+			name:          "NotConfigured",
+			location:      "Lisbon",
+			startDate:     ptr(day(time.June, 2)),
+			endDate:       ptr(day(time.June, 3)),
+			providerErr:   weather.ErrNotConfigured,
+			expectedError: weather.ErrNotConfigured.Error(),
+		},
+		{
+			name:          "UnauthorizedAccess",
+			location:      "Lisbon",
+			startDate:     ptr(day(time.June, 2)),
+			endDate:       ptr(day(time.June, 3)),
+			otherOwner:    true,
+			expectedError: "unauthorized access to trip",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			userID := uuid.New()
+			trip := &models.Trip{ID: uuid.New(), UserID: userID, Location: tc.location, StartDate: tc.startDate, EndDate: tc.endDate}
+			if tc.otherOwner {
+				trip.UserID = uuid.New()
+			}
+			tripRepo := &MockTripRepository{
+				getTripByIDFunc: func(ctx context.Context, tripID uuid.UUID) (*models.Trip, error) {
+					return trip, nil
+				},
+			}
+			provider := &weather.MockProvider{
+				Places: map[string]weather.Place{"lisbon": lisbon},
+				Days:   forecast,
+				Err:    tc.providerErr,
+			}
+			service := forecasts.NewService(tripRepo, provider)
+
+			// Execute
+			result, err := service.GetTripWeather(context.Background(), trip.ID, userID)
+
+			// Verify
+			if tc.expectedError != "" {
+				if err == nil || err.Error() != tc.expectedError {
+					t.Fatalf("Expected error '%s', got: %v", tc.expectedError, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if result.TripID != trip.ID || result.Place != lisbon {
+				t.Errorf("Unexpected trip or place: %+v", result)
+			}
+			if result.ForecastFrom == nil || !result.ForecastFrom.Equal(day(time.June, 1)) ||
+				result.ForecastUntil == nil || !result.ForecastUntil.Equal(day(time.June, 5)) {
+				t.Errorf("Expected forecast window June 1 to 5, got %v to %v", result.ForecastFrom, result.ForecastUntil)
+			}
+			if result.Days == nil {
+				t.Fatal("Expected days to be an empty list rather than nil")
+			}
+			if len(result.Days) != len(tc.expectedDays) {
+				t.Fatalf("Expected %d days, got %d", len(tc.expectedDays), len(result.Days))
+			}
+			for i, expected := range tc.expectedDays {
+				if !result.Days[i].Date.Equal(expected) {
+					t.Errorf("Day %d: expected %s, got %s", i, expected.Format(models.TripDateLayout), result.Days[i].Date.Format(models.TripDateLayout))
+				}
+			}
+		})
+	}
+}
+
+func TestServiceGetTripWeatherTripNotFound(t *testing.T) {
+	// Setup
+	tripRepo := &MockTripRepository{
+		getTripByIDFunc: func(ctx context.Context, tripID uuid.UUID) (*models.Trip, error) {
+			return nil, errors.New("trip not found")
+		},
+	}
+	provider := &weather.MockProvider{}
+	service := forecasts.NewService(tripRepo, provider)
+
+	// Execute
+	_, err := service.GetTripWeather(context.Background(), uuid.New(), uuid.New())
+
+	// Verify
+	if err == nil || err.Error() != "trip not found" {
+		t.Errorf("Expected 'trip not found', got: %v", err)
+	}
+	if provider.Calls() != 0 {
+		t.Errorf("Expected provider not to be called, got %d calls", provider.Calls())
+	}
+}
+
+func ptr(t time.Time) *time.Time {
+	return &t
+}
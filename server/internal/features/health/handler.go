@@ -0,0 +1,53 @@
+package health
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ReadinessTimeout bounds how long the readiness probe waits on the database
+const ReadinessTimeout = 2 * time.Second
+
+// PingFunc checks a dependency and reports its round-trip latency
+type PingFunc func(ctx context.Context) (time.Duration, error)
+
+type Handler struct {
+	pingDB PingFunc
+}
+
+func NewHandler(pingDB PingFunc) *Handler {
+	return &Handler{
+		pingDB: pingDB,
+	}
+}
+
+// Liveness reports that the process is up and serving requests
+func (h *Handler) Liveness(ctx echo.Context) error {
+	return ctx.JSON(http.StatusOK, map[string]string{
+		"status": "healthy",
+	})
+}
+
+// Readiness reports whether the server can reach the database
+func (h *Handler) Readiness(ctx echo.Context) error {
+	pingCtx, cancel := context.WithTimeout(ctx.Request().Context(), ReadinessTimeout)
+	defer cancel()
+
+	latency, err := h.pingDB(pingCtx)
+	if err != nil {
+		slog.Warn("Readiness check failed", "error", err)
+		return ctx.JSON(http.StatusServiceUnavailable, map[string]interface{}{
+			"status":        "unavailable",
+			"db_latency_ms": latency.Milliseconds(),
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, map[string]interface{}{
+		"status":        "ready",
+		"db_latency_ms": latency.Milliseconds(),
+	})
+}
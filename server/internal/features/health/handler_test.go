@@ -0,0 +1,165 @@
+package health_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"black-lotus/internal/features/health"
+)
+
+func newTestContext(path string) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	rec := httptest.NewRecorder()
+	return e.NewContext(req, rec), rec
+}
+
+func TestLiveness(t *testing.T) {
+	handler := health.NewHandler(func(ctx context.Context) (time.Duration, error) {
+		t.Error("Liveness should not ping the database")
+		return 0, nil
+	})
+
+	c, rec := newTestContext("/health")
+	if err := handler.Liveness(c); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("Expected status %d, got %d", http.StatusOK, rec.Code)
+	}
+}
+
+func TestReadiness(t *testing.T) {
+	testCases := []struct {
+		name           string
+		ping           health.PingFunc
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "DatabaseReachable",
+			ping: func(ctx context.Context) (time.Duration, error) {
+				if _, ok := ctx.Deadline(); !ok {
+					t.Error("Expected ping context to have a deadline")
+				}
+				return 3 * time.Millisecond, nil
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   "ready",
+		},
+		{
+			name: "DatabaseUnreachable",
+			ping: func(ctx context.Context) (time.Duration, error) {
+				return 2 * time.Second, errors.New("connection refused")
+			},
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedBody:   "unavailable",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := health.NewHandler(tc.ping)
+
+			c, rec := newTestContext("/ready")
+			if err := handler.Readiness(c); err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			if rec.Code != tc.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tc.expectedStatus, rec.Code)
+			}
+
+			var body map[string]interface{}
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+			if body["status"] != tc.expectedBody {
+				t.Errorf("Expected status '%s', got '%v'", tc.expectedBody, body["status"])
+			}
+			if _, ok := body["db_latency_ms"]; !ok {
+				t.Error("Expected db_latency_ms in response")
+			}
+		})
+	}
+}
+
+func TestRoot(t *testing.T) {
+	testCases := []struct {
+		name            string
+		env             map[string]string
+		expectedName    string
+		expectedVersion string
+	}{
+		{name: "Defaults", expectedName: health.DefaultAPIName, expectedVersion: health.DefaultAPIVersion},
+		{name: "Configured", env: map[string]string{"API_NAME": "Trips API", "API_VERSION": "2.3.0"}, expectedName: "Trips API", expectedVersion: "2.3.0"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("API_NAME", tc.env["API_NAME"])
+			t.Setenv("API_VERSION", tc.env["API_VERSION"])
+
+			handler := health.NewHandler(func(ctx context.Context) (time.Duration, error) {
+				t.Error("The root document should not ping the database")
+				return 0, nil
+			})
+
+			c, rec := newTestContext("/")
+			if err := handler.Root(c); err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			if rec.Code != http.StatusOK {
+				t.Errorf("Expected status %d, got %d", http.StatusOK, rec.Code)
+			}
+			if contentType := rec.Header().Get(echo.HeaderContentType); contentType != echo.MIMEApplicationJSON {
+				t.Errorf("Expected JSON content type, got %q", contentType)
+			}
+
+			var body struct {
+				Name    string            `json:"name"`
+				Version string            `json:"version"`
+				Links   map[string]string `json:"links"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+
+			if body.Name != tc.expectedName || body.Version != tc.expectedVersion {
+				t.Errorf("Expected %s %s, got %s %s", tc.expectedName, tc.expectedVersion, body.Name, body.Version)
+			}
+			for key, path := range map[string]string{"health": "/health", "version": "/version", "docs": "/docs"} {
+				if body.Links[key] != path {
+					t.Errorf("Expected %s link %q, got %q", key, path, body.Links[key])
+				}
+			}
+		})
+	}
+}
+
+func TestVersion(t *testing.T) {
+	t.Setenv("API_VERSION", "2.3.0")
+
+	handler := health.NewHandler(nil)
+	c, rec := newTestContext("/version")
+	if err := handler.Version(c); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	var info health.APIInfo
+	if err := json.Unmarshal(rec.Body.Bytes(), &info); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if info.Version != "2.3.0" || info.Name != health.DefaultAPIName {
+		t.Errorf("Expected %s 2.3.0, got %+v", health.DefaultAPIName, info)
+	}
+}
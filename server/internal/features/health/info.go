@@ -0,0 +1,61 @@
+package health
+
+import (
+	"net/http"
+	"os"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Defaults reported when API_NAME and API_VERSION aren't set
+const (
+	DefaultAPIName    = "Black Lotus API"
+	DefaultAPIVersion = "1.0.0"
+)
+
+// APIInfo identifies the running API
+type APIInfo struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// RootDocument is served at / so probing the root shows where to go next
+type RootDocument struct {
+	Name    string            `json:"name"`
+	Version string            `json:"version"`
+	Links   map[string]string `json:"links"`
+}
+
+// APIInfoFromEnv reads API_NAME and API_VERSION, using the defaults for unset values
+func APIInfoFromEnv() APIInfo {
+	info := APIInfo{Name: os.Getenv("API_NAME"), Version: os.Getenv("API_VERSION")}
+	if info.Name == "" {
+		info.Name = DefaultAPIName
+	}
+	if info.Version == "" {
+		info.Version = DefaultAPIVersion
+	}
+	return info
+}
+
+// Root describes the API and links to its probes and documentation
+func (h *Handler) Root(ctx echo.Context) error {
+	info := APIInfoFromEnv()
+
+	return ctx.JSON(http.StatusOK, RootDocument{
+		Name:    info.Name,
+		Version: info.Version,
+		Links: map[string]string{
+			"health":  "/health",
+			"ready":   "/ready",
+			"version": "/version",
+			"docs":    "/docs",
+			"openapi": "/openapi.json",
+		},
+	})
+}
+
+// Version reports the API name and version
+func (h *Handler) Version(ctx echo.Context) error {
+	return ctx.JSON(http.StatusOK, APIInfoFromEnv())
+}
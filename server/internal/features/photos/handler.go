@@ -0,0 +1,193 @@
+package photos
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"black-lotus/internal/common/binding"
+	"black-lotus/internal/common/params"
+	"black-lotus/internal/common/response"
+	validation "black-lotus/internal/common/validations"
+	"black-lotus/internal/domain/models"
+	"black-lotus/internal/features/auth/session"
+	"black-lotus/pkg/storage"
+)
+
+type Handler struct {
+	service        ServiceInterface
+	sessionService session.ServiceInterface
+	validator      *validator.Validate
+}
+
+func NewHandler(service ServiceInterface, sessionService session.ServiceInterface) *Handler {
+	validate := validator.New()
+
+	// Report validation errors using JSON field names
+	validate.RegisterTagNameFunc(func(fld reflect.StructField) string {
+		name := strings.SplitN(fld.Tag.Get("json"), ",", 2)[0]
+		if name == "-" {
+			return ""
+		}
+		return name
+	})
+
+	return &Handler{
+		service:        service,
+		sessionService: sessionService,
+		validator:      validate,
+	}
+}
+
+// handleServiceError maps photo service errors to responses
+func handleServiceError(ctx echo.Context, err error, action string) error {
+	switch err.Error() {
+	case "trip not found":
+		return response.ErrorResponse(ctx, http.StatusNotFound,
+			response.CodeTripNotFound, "Trip not found", nil)
+	case "photo not found":
+		return response.ErrorResponse(ctx, http.StatusNotFound,
+			response.CodePhotoNotFound, "Photo not found", nil)
+	case "unauthorized access to trip":
+		return response.ErrorResponse(ctx, http.StatusForbidden,
+			response.CodeForbidden, "You do not have permission to access this trip", nil)
+	case "photo limit reached":
+		return response.ErrorResponse(ctx, http.StatusConflict, response.CodePhotoLimitReached,
+			fmt.Sprintf("A trip can have at most %d photos", MaxPhotosPerTrip), nil)
+	case "unsupported content type":
+		contentTypes := make([]string, 0, len(UploadContentTypes))
+		for contentType := range UploadContentTypes {
+			contentTypes = append(contentTypes, contentType)
+		}
+		sort.Strings(contentTypes)
+		messages := validation.MessagesFor(ctx.Request().Header.Get("Accept-Language"))
+		return response.ErrorResponse(ctx, http.StatusBadRequest, response.CodeValidationFailed, messages.InvalidBody(),
+			map[string]string{"content_type": messages.Message("oneof", "content_type", strings.Join(contentTypes, ", "))})
+	case storage.ErrNotConfigured.Error():
+		return response.ErrorResponse(ctx, http.StatusServiceUnavailable,
+			response.CodeNotConfigured, "Photo uploads are not configured on this server", nil)
+	}
+
+	slog.Error("Failed to "+action, "error", err)
+	return response.ErrorResponse(ctx, http.StatusInternalServerError,
+		response.CodeInternal, "Failed to "+action, nil)
+}
+
+// AddPhoto attaches a photo to a trip
+func (h *Handler) AddPhoto(ctx echo.Context) error {
+	sess, err := session.Authenticate(ctx, h.sessionService)
+	if sess == nil {
+		return err
+	}
+
+	tripID, ok, err := params.TripID(ctx)
+	if !ok {
+		return err
+	}
+
+	var input models.CreateTripPhotoInput
+	if err := binding.Bind(ctx, &input); err != nil {
+		return response.InvalidBody(ctx, err)
+	}
+
+	if err := h.validator.Struct(input); err != nil {
+		messages := validation.MessagesFor(ctx.Request().Header.Get("Accept-Language"))
+		validationErrors, _ := err.(validator.ValidationErrors)
+		return response.ErrorResponse(ctx, http.StatusBadRequest,
+			response.CodeValidationFailed, messages.InvalidBody(), messages.Details(validationErrors))
+	}
+
+	photo, err := h.service.AddPhoto(ctx.Request().Context(), tripID, sess.UserID, input)
+	if err != nil {
+		return handleServiceError(ctx, err, "add photo")
+	}
+
+	return response.JSON(ctx, http.StatusCreated, photo)
+}
+
+// GetPhotos lists a trip's photos
+func (h *Handler) GetPhotos(ctx echo.Context) error {
+	sess, err := session.Authenticate(ctx, h.sessionService)
+	if sess == nil {
+		return err
+	}
+
+	tripID, ok, err := params.TripID(ctx)
+	if !ok {
+		return err
+	}
+
+	photos, err := h.service.GetPhotosByTripID(ctx.Request().Context(), tripID, sess.UserID)
+	if err != nil {
+		return handleServiceError(ctx, err, "get photos")
+	}
+
+	return response.JSON(ctx, http.StatusOK, photos)
+}
+
+// DeletePhoto removes a single photo
+func (h *Handler) DeletePhoto(ctx echo.Context) error {
+	sess, err := session.Authenticate(ctx, h.sessionService)
+	if sess == nil {
+		return err
+	}
+
+	tripID, ok, err := params.TripID(ctx)
+	if !ok {
+		return err
+	}
+
+	photoID, err := uuid.Parse(ctx.Param("photoId"))
+	if err != nil {
+		return response.ErrorResponse(ctx, http.StatusBadRequest,
+			response.CodeInvalidID, "Invalid photo ID", nil)
+	}
+
+	if err := h.service.DeletePhoto(ctx.Request().Context(), tripID, photoID, sess.UserID); err != nil {
+		return handleServiceError(ctx, err, "delete photo")
+	}
+
+	return response.JSON(ctx, http.StatusOK, map[string]string{
+		"message": "Photo deleted successfully",
+	})
+}
+
+// CreateUpload returns a presigned URL the client uploads an image to
+// directly, and the public URL to add as a photo afterwards
+func (h *Handler) CreateUpload(ctx echo.Context) error {
+	sess, err := session.Authenticate(ctx, h.sessionService)
+	if sess == nil {
+		return err
+	}
+
+	tripID, ok, err := params.TripID(ctx)
+	if !ok {
+		return err
+	}
+
+	var input models.PhotoUploadInput
+	if err := binding.Bind(ctx, &input); err != nil {
+		return response.InvalidBody(ctx, err)
+	}
+
+	if err := h.validator.Struct(input); err != nil {
+		messages := validation.MessagesFor(ctx.Request().Header.Get("Accept-Language"))
+		validationErrors, _ := err.(validator.ValidationErrors)
+		return response.ErrorResponse(ctx, http.StatusBadRequest,
+			response.CodeValidationFailed, messages.InvalidBody(), messages.Details(validationErrors))
+	}
+
+	upload, err := h.service.CreateUpload(ctx.Request().Context(), tripID, sess.UserID, input.ContentType)
+	if err != nil {
+		return handleServiceError(ctx, err, "create photo upload")
+	}
+
+	return response.JSON(ctx, http.StatusCreated, upload)
+}
@@ -0,0 +1,384 @@
+package photos_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"black-lotus/internal/common/response"
+	"black-lotus/internal/domain/models"
+	"black-lotus/internal/features/photos"
+	"black-lotus/pkg/storage"
+)
+
+// MockPhotoService implements photos.ServiceInterface for testing
+type MockPhotoService struct {
+	addPhotoFunc          func(ctx context.Context, tripID uuid.UUID, userID uuid.UUID, input models.CreateTripPhotoInput) (*models.TripPhoto, error)
+	getPhotosByTripIDFunc func(ctx context.Context, tripID uuid.UUID, userID uuid.UUID) ([]*models.TripPhoto, error)
+	deletePhotoFunc       func(ctx context.Context, tripID uuid.UUID, photoID uuid.UUID, userID uuid.UUID) error
+	createUploadFunc      func(ctx context.Context, tripID uuid.UUID, userID uuid.UUID, contentType string) (*storage.Upload, error)
+}
+
+func (m *MockPhotoService) AddPhoto(ctx context.Context, tripID uuid.UUID, userID uuid.UUID, input models.CreateTripPhotoInput) (*models.TripPhoto, error) {
+	if m.addPhotoFunc != nil {
+		return m.addPhotoFunc(ctx, tripID, userID, input)
+	}
+	return nil, errors.New("AddPhoto not implemented")
+}
+
+func (m *MockPhotoService) GetPhotosByTripID(ctx context.Context, tripID uuid.UUID, userID uuid.UUID) ([]*models.TripPhoto, error) {
+	if m.getPhotosByTripIDFunc != nil {
+		return m.getPhotosByTripIDFunc(ctx, tripID, userID)
+	}
+	return nil, errors.New("GetPhotosByTripID not implemented")
+}
+
+func (m *MockPhotoService) DeletePhoto(ctx context.Context, tripID uuid.UUID, photoID uuid.UUID, userID uuid.UUID) error {
+	if m.deletePhotoFunc != nil {
+		return m.deletePhotoFunc(ctx, tripID, photoID, userID)
+	}
+	return errors.New("DeletePhoto not implemented")
+}
+
+func (m *MockPhotoService) CreateUpload(ctx context.Context, tripID uuid.UUID, userID uuid.UUID, contentType string) (*storage.Upload, error) {
+	if m.createUploadFunc != nil {
+		return m.createUploadFunc(ctx, tripID, userID, contentType)
+	}
+	return nil, errors.New("CreateUpload not implemented")
+}
+
+// MockSessionService implements session.ServiceInterface for testing
+type MockSessionService struct {
+	validateAccessTokenFunc func(ctx context.Context, token string) (*models.Session, error)
+}
+
+func (m *MockSessionService) ValidateAccessToken(ctx context.Context, token string) (*models.Session, error) {
+	if m.validateAccessTokenFunc != nil {
+		return m.validateAccessTokenFunc(ctx, token)
+	}
+	return nil, errors.New("ValidateAccessToken not implemented")
+}
+
+func (m *MockSessionService) ValidateRefreshToken(ctx context.Context, token string) (*models.Session, error) {
+	return nil, errors.New("ValidateRefreshToken not implemented")
+}
+
+func (m *MockSessionService) CreateSession(ctx context.Context, userID uuid.UUID, refreshDuration time.Duration) (*models.Session, error) {
+	return nil, errors.New("CreateSession not implemented")
+}
+
+func (m *MockSessionService) RefreshAccessToken(ctx context.Context, refreshToken string) (*models.Session, error) {
+	return nil, errors.New("RefreshAccessToken not implemented")
+}
+
+func (m *MockSessionService) EndSessionByAccessToken(ctx context.Context, token string) error {
+	return errors.New("EndSessionByAccessToken not implemented")
+}
+
+func (m *MockSessionService) EndSessionByRefreshToken(ctx context.Context, token string) error {
+	return errors.New("EndSessionByRefreshToken not implemented")
+}
+
+func (m *MockSessionService) EndAllUserSessions(ctx context.Context, userID uuid.UUID) error {
+	return errors.New("EndAllUserSessions not implemented")
+}
+
+func (m *MockSessionService) EndOtherUserSessions(ctx context.Context, userID uuid.UUID, keepSessionID uuid.UUID) error {
+	return errors.New("EndOtherUserSessions not implemented")
+}
+
+// Helper function to create a test context for a trip with an access token
+func newTestContext(method, path string, body []byte, tripID string) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	req := httptest.NewRequest(method, path, bytes.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.AddCookie(&http.Cookie{Name: "access_token", Value: "valid_access_token"})
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(tripID)
+	return c, rec
+}
+
+// Helper function to setup handler for testing
+func setupHandlerTest(userID uuid.UUID) (*photos.Handler, *MockPhotoService) {
+	mockService := &MockPhotoService{}
+	mockSession := &MockSessionService{}
+
+	mockSession.validateAccessTokenFunc = func(ctx context.Context, token string) (*models.Session, error) {
+		return &models.Session{
+			ID:           uuid.New(),
+			UserID:       userID,
+			AccessToken:  token,
+			AccessExpiry: time.Now().Add(15 * time.Minute),
+		}, nil
+	}
+
+	return photos.NewHandler(mockService, mockSession), mockService
+}
+
+func TestHandlerAddPhoto(t *testing.T) {
+	testCases := []struct {
+		name           string
+		body           string
+		serviceErr     error
+		expectedStatus int
+		expectedCode   string
+	}{
+		{
+			name:           "SuccessfulAdd",
+			body:           `{"url": " https://cdn.example.com/beach.jpg ", "caption": "Beach"}`,
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "MissingURL",
+			body:           `{"caption": "Beach"}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   response.CodeValidationFailed,
+		},
+		{
+			name:           "InvalidURL",
+			body:           `{"url": "not a url"}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   response.CodeValidationFailed,
+		},
+		{
+			name:           "LimitReached",
+			body:           `{"url": "https://cdn.example.com/beach.jpg"}`,
+			serviceErr:     errors.New("photo limit reached"),
+			expectedStatus: http.StatusConflict,
+			expectedCode:   response.CodePhotoLimitReached,
+		},
+		{
+			name:           "UnauthorizedAccess",
+			body:           `{"url": "https://cdn.example.com/beach.jpg"}`,
+			serviceErr:     errors.New("unauthorized access to trip"),
+			expectedStatus: http.StatusForbidden,
+			expectedCode:   response.CodeForbidden,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			userID := uuid.New()
+			handler, mockService := setupHandlerTest(userID)
+			tripID := uuid.New().String()
+
+			mockService.addPhotoFunc = func(ctx context.Context, tid uuid.UUID, uid uuid.UUID, input models.CreateTripPhotoInput) (*models.TripPhoto, error) {
+				if tc.serviceErr != nil {
+					return nil, tc.serviceErr
+				}
+				return &models.TripPhoto{ID: uuid.New(), TripID: tid, URL: input.URL, Caption: input.Caption}, nil
+			}
+
+			c, rec := newTestContext(http.MethodPost, "/api/trips/"+tripID+"/photos", []byte(tc.body), tripID)
+
+			if err := handler.AddPhoto(c); err != nil {
+				t.Errorf("Expected no error, got: %v", err)
+			}
+
+			if rec.Code != tc.expectedStatus {
+				t.Fatalf("Expected status %d, got %d", tc.expectedStatus, rec.Code)
+			}
+
+			if tc.expectedCode != "" {
+				var envelope response.ErrorEnvelope
+				if err := json.Unmarshal(rec.Body.Bytes(), &envelope); err != nil {
+					t.Fatalf("Failed to unmarshal response: %v", err)
+				}
+				if envelope.Error.Code != tc.expectedCode {
+					t.Errorf("Expected code '%s', got '%s'", tc.expectedCode, envelope.Error.Code)
+				}
+				return
+			}
+
+			var photo models.TripPhoto
+			if err := json.Unmarshal(rec.Body.Bytes(), &photo); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+			if photo.URL != "https://cdn.example.com/beach.jpg" {
+				t.Errorf("Expected the trimmed URL, got '%s'", photo.URL)
+			}
+		})
+	}
+}
+
+func TestHandlerGetPhotos(t *testing.T) {
+	userID := uuid.New()
+	handler, mockService := setupHandlerTest(userID)
+	tripID := uuid.New()
+
+	mockService.getPhotosByTripIDFunc = func(ctx context.Context, tid uuid.UUID, uid uuid.UUID) ([]*models.TripPhoto, error) {
+		return []*models.TripPhoto{{ID: uuid.New(), TripID: tid, URL: "https://cdn.example.com/beach.jpg"}}, nil
+	}
+
+	c, rec := newTestContext(http.MethodGet, "/api/trips/"+tripID.String()+"/photos", nil, tripID.String())
+
+	if err := handler.GetPhotos(c); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, rec.Code)
+	}
+
+	var photos []models.TripPhoto
+	if err := json.Unmarshal(rec.Body.Bytes(), &photos); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if len(photos) != 1 {
+		t.Errorf("Expected 1 photo, got %d", len(photos))
+	}
+}
+
+func TestHandlerDeletePhoto(t *testing.T) {
+	userID := uuid.New()
+	handler, mockService := setupHandlerTest(userID)
+	tripID := uuid.New().String()
+
+	mockService.deletePhotoFunc = func(ctx context.Context, tid uuid.UUID, pid uuid.UUID, uid uuid.UUID) error {
+		return errors.New("photo not found")
+	}
+
+	photoID := uuid.New().String()
+
+	c, rec := newTestContext(http.MethodDelete, "/api/trips/"+tripID+"/photos/"+photoID, nil, tripID)
+	c.SetParamNames("id", "photoId")
+	c.SetParamValues(tripID, photoID)
+
+	if err := handler.DeletePhoto(c); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d, got %d", http.StatusNotFound, rec.Code)
+	}
+}
+
+func TestHandlerCreateUpload(t *testing.T) {
+	testCases := []struct {
+		name           string
+		body           string
+		serviceErr     error
+		expectedStatus int
+		expectedCode   string
+	}{
+		{
+			name:           "SuccessfulUpload",
+			body:           `{"content_type": " Image/PNG "}`,
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "MissingContentType",
+			body:           `{}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   response.CodeValidationFailed,
+		},
+		{
+			name:           "UnsupportedContentType",
+			body:           `{"content_type": "application/pdf"}`,
+			serviceErr:     errors.New("unsupported content type"),
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   response.CodeValidationFailed,
+		},
+		{
+			name:           "StorageNotConfigured",
+			body:           `{"content_type": "image/png"}`,
+			serviceErr:     storage.ErrNotConfigured,
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedCode:   response.CodeNotConfigured,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			userID := uuid.New()
+			handler, mockService := setupHandlerTest(userID)
+			tripID := uuid.New().String()
+
+			mockService.createUploadFunc = func(ctx context.Context, tid uuid.UUID, uid uuid.UUID, contentType string) (*storage.Upload, error) {
+				if tc.serviceErr != nil {
+					return nil, tc.serviceErr
+				}
+				if contentType != "image/png" {
+					t.Errorf("Expected normalized content type image/png, got %q", contentType)
+				}
+				return (&storage.FakeStorage{BaseURL: "https://photos.example.com"}).PresignPut(ctx, "trips/"+tid.String()+"/photo.png", contentType)
+			}
+
+			c, rec := newTestContext(http.MethodPost, "/api/trips/"+tripID+"/photos/upload-url", []byte(tc.body), tripID)
+
+			if err := handler.CreateUpload(c); err != nil {
+				t.Errorf("Expected no error, got: %v", err)
+			}
+
+			if rec.Code != tc.expectedStatus {
+				t.Fatalf("Expected status %d, got %d", tc.expectedStatus, rec.Code)
+			}
+
+			if tc.expectedCode != "" {
+				var envelope response.ErrorEnvelope
+				if err := json.Unmarshal(rec.Body.Bytes(), &envelope); err != nil {
+					t.Fatalf("Failed to unmarshal response: %v", err)
+				}
+				if envelope.Error.Code != tc.expectedCode {
+					t.Errorf("Expected code '%s', got '%s'", tc.expectedCode, envelope.Error.Code)
+				}
+				return
+			}
+
+			var upload storage.Upload
+			if err := json.Unmarshal(rec.Body.Bytes(), &upload); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+			if upload.URL == "" || upload.PublicURL != "https://photos.example.com/trips/"+tripID+"/photo.png" {
+				t.Errorf("Unexpected upload: %+v", upload)
+			}
+		})
+	}
+}
+
+func TestHandlerPhotoValidationLocalized(t *testing.T) {
+	// Setup
+	userID := uuid.New()
+	handler, mockService := setupHandlerTest(userID)
+	tripID := uuid.New().String()
+
+	mockService.addPhotoFunc = func(ctx context.Context, tid uuid.UUID, uid uuid.UUID, input models.CreateTripPhotoInput) (*models.TripPhoto, error) {
+		t.Error("Service should not be called for an invalid photo")
+		return nil, nil
+	}
+
+	c, rec := newTestContext(http.MethodPost, "/api/trips/"+tripID+"/photos", []byte(`{"url": "not a url"}`), tripID)
+	c.Request().Header.Set("Accept-Language", "es")
+
+	// Execute
+	if err := handler.AddPhoto(c); err != nil {
+		t.Errorf("Expected no error, got: %v", err)
+	}
+
+	// Verify
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("Expected status %d, got %d", http.StatusBadRequest, rec.Code)
+	}
+
+	var envelope response.ErrorEnvelope
+	if err := json.Unmarshal(rec.Body.Bytes(), &envelope); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if envelope.Error.Message != "El cuerpo de la solicitud no es válido" {
+		t.Errorf("Expected the Spanish message, got %q", envelope.Error.Message)
+	}
+	details, _ := envelope.Error.Details.(map[string]interface{})
+	if details["url"] != "url debe ser una URL válida" {
+		t.Errorf("Expected the Spanish url message, got %v", envelope.Error.Details)
+	}
+}
@@ -0,0 +1,22 @@
+package photos
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"black-lotus/internal/domain/models"
+)
+
+type Repository interface {
+	CreatePhoto(ctx context.Context, tripID uuid.UUID, input models.CreateTripPhotoInput, maxPhotos int) (*models.TripPhoto, error)
+	GetPhotoByID(ctx context.Context, photoID uuid.UUID) (*models.TripPhoto, error)
+	GetPhotosByTripID(ctx context.Context, tripID uuid.UUID) ([]*models.TripPhoto, error)
+	DeletePhoto(ctx context.Context, photoID uuid.UUID) error
+}
+
+// TripRepository defines trip operations needed by the photos feature
+type TripRepository interface {
+	GetTripByID(ctx context.Context, tripID uuid.UUID) (*models.Trip, error)
+	CountTripPhotos(ctx context.Context, tripID uuid.UUID) (int, error)
+}
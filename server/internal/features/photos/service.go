@@ -0,0 +1,121 @@
+package photos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"black-lotus/internal/domain/models"
+	"black-lotus/pkg/storage"
+)
+
+// MaxPhotosPerTrip caps how many photos a single trip can have
+const MaxPhotosPerTrip = 50
+
+// UploadContentTypes are the image types that can be uploaded, with the
+// extension their objects are stored under
+var UploadContentTypes = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/webp": ".webp",
+	"image/gif":  ".gif",
+	"image/heic": ".heic",
+}
+
+type ServiceInterface interface {
+	AddPhoto(ctx context.Context, tripID uuid.UUID, userID uuid.UUID, input models.CreateTripPhotoInput) (*models.TripPhoto, error)
+	GetPhotosByTripID(ctx context.Context, tripID uuid.UUID, userID uuid.UUID) ([]*models.TripPhoto, error)
+	DeletePhoto(ctx context.Context, tripID uuid.UUID, photoID uuid.UUID, userID uuid.UUID) error
+	CreateUpload(ctx context.Context, tripID uuid.UUID, userID uuid.UUID, contentType string) (*storage.Upload, error)
+}
+
+type Service struct {
+	repo     Repository
+	tripRepo TripRepository
+	storage  storage.ObjectStorage
+}
+
+func NewService(repo Repository, tripRepo TripRepository, objectStorage storage.ObjectStorage) *Service {
+	return &Service{repo: repo, tripRepo: tripRepo, storage: objectStorage}
+}
+
+// AddPhoto attaches a photo to a trip the user owns, unless the trip already
+// has MaxPhotosPerTrip photos
+func (s *Service) AddPhoto(ctx context.Context, tripID uuid.UUID, userID uuid.UUID, input models.CreateTripPhotoInput) (*models.TripPhoto, error) {
+	if err := s.verifyTripOwnership(ctx, tripID, userID); err != nil {
+		return nil, err
+	}
+
+	return s.repo.CreatePhoto(ctx, tripID, input, MaxPhotosPerTrip)
+}
+
+// GetPhotosByTripID lists a trip's photos, oldest first
+func (s *Service) GetPhotosByTripID(ctx context.Context, tripID uuid.UUID, userID uuid.UUID) ([]*models.TripPhoto, error) {
+	if err := s.verifyTripOwnership(ctx, tripID, userID); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetPhotosByTripID(ctx, tripID)
+}
+
+// DeletePhoto removes a photo from a trip the user owns. The image itself
+// lives in external storage and is left alone.
+func (s *Service) DeletePhoto(ctx context.Context, tripID uuid.UUID, photoID uuid.UUID, userID uuid.UUID) error {
+	if err := s.verifyTripOwnership(ctx, tripID, userID); err != nil {
+		return err
+	}
+
+	photo, err := s.repo.GetPhotoByID(ctx, photoID)
+	if err != nil {
+		return err
+	}
+
+	// Photos of other trips are reported as missing rather than leaking their existence
+	if photo.TripID != tripID {
+		return errors.New("photo not found")
+	}
+
+	return s.repo.DeletePhoto(ctx, photoID)
+}
+
+// CreateUpload presigns an upload of a new image for a trip the user owns.
+// Nothing is recorded until the client adds the returned public URL with
+// AddPhoto, so abandoned uploads never show up on the trip.
+func (s *Service) CreateUpload(ctx context.Context, tripID uuid.UUID, userID uuid.UUID, contentType string) (*storage.Upload, error) {
+	extension, ok := UploadContentTypes[contentType]
+	if !ok {
+		return nil, errors.New("unsupported content type")
+	}
+
+	if err := s.verifyTripOwnership(ctx, tripID, userID); err != nil {
+		return nil, err
+	}
+
+	// Don't hand out an upload that AddPhoto would refuse anyway
+	count, err := s.tripRepo.CountTripPhotos(ctx, tripID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= MaxPhotosPerTrip {
+		return nil, errors.New("photo limit reached")
+	}
+
+	key := fmt.Sprintf("trips/%s/%s%s", tripID, uuid.New(), extension)
+	return s.storage.PresignPut(ctx, key, contentType)
+}
+
+// verifyTripOwnership makes sure the trip exists and belongs to the user
+func (s *Service) verifyTripOwnership(ctx context.Context, tripID uuid.UUID, userID uuid.UUID) error {
+	trip, err := s.tripRepo.GetTripByID(ctx, tripID)
+	if err != nil {
+		return err
+	}
+
+	if trip.UserID != userID {
+		return errors.New("unauthorized access to trip")
+	}
+
+	return nil
+}
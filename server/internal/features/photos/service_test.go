@@ -0,0 +1,249 @@
+package photos_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"black-lotus/internal/domain/models"
+	"black-lotus/internal/features/photos"
+	"black-lotus/pkg/storage"
+)
+
+// MockPhotoRepository implements photos.Repository for testing
+type MockPhotoRepository struct {
+	createPhotoFunc       func(ctx context.Context, tripID uuid.UUID, input models.CreateTripPhotoInput, maxPhotos int) (*models.TripPhoto, error)
+	getPhotoByIDFunc      func(ctx context.Context, photoID uuid.UUID) (*models.TripPhoto, error)
+	getPhotosByTripIDFunc func(ctx context.Context, tripID uuid.UUID) ([]*models.TripPhoto, error)
+	deletePhotoFunc       func(ctx context.Context, photoID uuid.UUID) error
+}
+
+func (m *MockPhotoRepository) CreatePhoto(ctx context.Context, tripID uuid.UUID, input models.CreateTripPhotoInput, maxPhotos int) (*models.TripPhoto, error) {
+	if m.createPhotoFunc != nil {
+		return m.createPhotoFunc(ctx, tripID, input, maxPhotos)
+	}
+	return nil, errors.New("CreatePhoto not implemented")
+}
+
+func (m *MockPhotoRepository) GetPhotoByID(ctx context.Context, photoID uuid.UUID) (*models.TripPhoto, error) {
+	if m.getPhotoByIDFunc != nil {
+		return m.getPhotoByIDFunc(ctx, photoID)
+	}
+	return nil, errors.New("GetPhotoByID not implemented")
+}
+
+func (m *MockPhotoRepository) GetPhotosByTripID(ctx context.Context, tripID uuid.UUID) ([]*models.TripPhoto, error) {
+	if m.getPhotosByTripIDFunc != nil {
+		return m.getPhotosByTripIDFunc(ctx, tripID)
+	}
+	return nil, errors.New("GetPhotosByTripID not implemented")
+}
+
+func (m *MockPhotoRepository) DeletePhoto(ctx context.Context, photoID uuid.UUID) error {
+	if m.deletePhotoFunc != nil {
+		return m.deletePhotoFunc(ctx, photoID)
+	}
+	return errors.New("DeletePhoto not implemented")
+}
+
+// MockTripRepository implements photos.TripRepository for testing
+type MockTripRepository struct {
+	getTripByIDFunc     func(ctx context.Context, tripID uuid.UUID) (*models.Trip, error)
+	countTripPhotosFunc func(ctx context.Context, tripID uuid.UUID) (int, error)
+}
+
+func (m *MockTripRepository) GetTripByID(ctx context.Context, tripID uuid.UUID) (*models.Trip, error) {
+	if m.getTripByIDFunc != nil {
+		return m.getTripByIDFunc(ctx, tripID)
+	}
+	return nil, errors.New("GetTripByID not implemented")
+}
+
+func (m *MockTripRepository) CountTripPhotos(ctx context.Context, tripID uuid.UUID) (int, error) {
+	if m.countTripPhotosFunc != nil {
+		return m.countTripPhotosFunc(ctx, tripID)
+	}
+	return 0, errors.New("CountTripPhotos not implemented")
+}
+
+// Helper function to setup service for testing with a trip owned by owner
+func setupServiceTest(owner uuid.UUID) (*photos.Service, *MockPhotoRepository, *MockTripRepository, *storage.FakeStorage) {
+	mockRepo := &MockPhotoRepository{}
+	mockTripRepo := &MockTripRepository{}
+	fakeStorage := &storage.FakeStorage{BaseURL: "https://photos.example.com"}
+
+	mockTripRepo.getTripByIDFunc = func(ctx context.Context, tripID uuid.UUID) (*models.Trip, error) {
+		return &models.Trip{ID: tripID, UserID: owner, Name: "Test Trip"}, nil
+	}
+
+	return photos.NewService(mockRepo, mockTripRepo, fakeStorage), mockRepo, mockTripRepo, fakeStorage
+}
+
+func TestServiceAddPhoto(t *testing.T) {
+	owner := uuid.New()
+	tripID := uuid.New()
+	input := models.CreateTripPhotoInput{URL: "https://cdn.example.com/beach.jpg", Caption: "Beach"}
+
+	testCases := []struct {
+		name          string
+		userID        uuid.UUID
+		repoErr       error
+		expectCreate  bool
+		expectedError string
+	}{
+		{name: "Success", userID: owner, expectCreate: true},
+		{name: "LimitReached", userID: owner, repoErr: errors.New("photo limit reached"), expectCreate: true, expectedError: "photo limit reached"},
+		{name: "NotOwner", userID: uuid.New(), expectedError: "unauthorized access to trip"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			service, mockRepo, _, _ := setupServiceTest(owner)
+			created := false
+			mockRepo.createPhotoFunc = func(ctx context.Context, tid uuid.UUID, in models.CreateTripPhotoInput, maxPhotos int) (*models.TripPhoto, error) {
+				created = true
+				if maxPhotos != photos.MaxPhotosPerTrip {
+					t.Errorf("Expected limit %d, got %d", photos.MaxPhotosPerTrip, maxPhotos)
+				}
+				if tc.repoErr != nil {
+					return nil, tc.repoErr
+				}
+				return &models.TripPhoto{ID: uuid.New(), TripID: tid, URL: in.URL, Caption: in.Caption}, nil
+			}
+
+			// Execute
+			photo, err := service.AddPhoto(context.Background(), tripID, tc.userID, input)
+
+			// Verify
+			if created != tc.expectCreate {
+				t.Errorf("Expected create %v, got %v", tc.expectCreate, created)
+			}
+			if tc.expectedError != "" {
+				if err == nil || err.Error() != tc.expectedError {
+					t.Fatalf("Expected error '%s', got %v", tc.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if photo.TripID != tripID || photo.URL != input.URL {
+				t.Errorf("Expected photo %s on trip %s, got %+v", input.URL, tripID, photo)
+			}
+		})
+	}
+}
+
+func TestServiceDeletePhoto(t *testing.T) {
+	userID := uuid.New()
+	tripID := uuid.New()
+
+	testCases := []struct {
+		name          string
+		photoTrip     uuid.UUID
+		expectedError string
+	}{
+		{name: "SuccessfulDeletion", photoTrip: tripID},
+		{name: "PhotoOfAnotherTrip", photoTrip: uuid.New(), expectedError: "photo not found"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			service, mockRepo, _, _ := setupServiceTest(userID)
+
+			mockRepo.getPhotoByIDFunc = func(ctx context.Context, id uuid.UUID) (*models.TripPhoto, error) {
+				return &models.TripPhoto{ID: id, TripID: tc.photoTrip}, nil
+			}
+			deleted := false
+			mockRepo.deletePhotoFunc = func(ctx context.Context, id uuid.UUID) error {
+				deleted = true
+				return nil
+			}
+
+			err := service.DeletePhoto(context.Background(), tripID, uuid.New(), userID)
+
+			if tc.expectedError != "" {
+				if err == nil || err.Error() != tc.expectedError {
+					t.Fatalf("Expected error '%s', got %v", tc.expectedError, err)
+				}
+				if deleted {
+					t.Error("Expected the photo not to be deleted")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if !deleted {
+				t.Error("Expected the photo to be deleted")
+			}
+		})
+	}
+}
+
+func TestServiceCreateUpload(t *testing.T) {
+	owner := uuid.New()
+	tripID := uuid.New()
+
+	testCases := []struct {
+		name          string
+		userID        uuid.UUID
+		contentType   string
+		photoCount    int
+		disabled      bool
+		expectedError string
+	}{
+		{name: "Success", userID: owner, contentType: "image/png"},
+		{name: "UnsupportedContentType", userID: owner, contentType: "application/pdf", expectedError: "unsupported content type"},
+		{name: "NotOwner", userID: uuid.New(), contentType: "image/png", expectedError: "unauthorized access to trip"},
+		{name: "LimitReached", userID: owner, contentType: "image/png", photoCount: photos.MaxPhotosPerTrip, expectedError: "photo limit reached"},
+		{name: "StorageDisabled", userID: owner, contentType: "image/png", disabled: true, expectedError: storage.ErrNotConfigured.Error()},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			_, mockRepo, mockTripRepo, fakeStorage := setupServiceTest(owner)
+			mockTripRepo.countTripPhotosFunc = func(ctx context.Context, id uuid.UUID) (int, error) {
+				return tc.photoCount, nil
+			}
+			service := photos.NewService(mockRepo, mockTripRepo, fakeStorage)
+			if tc.disabled {
+				service = photos.NewService(mockRepo, mockTripRepo, storage.Disabled{})
+			}
+
+			// Execute
+			upload, err := service.CreateUpload(context.Background(), tripID, tc.userID, tc.contentType)
+
+			// Verify
+			if tc.expectedError != "" {
+				if err == nil || err.Error() != tc.expectedError {
+					t.Fatalf("Expected error '%s', got %v", tc.expectedError, err)
+				}
+				if len(fakeStorage.Keys()) != 0 {
+					t.Errorf("Expected nothing to be presigned, got %v", fakeStorage.Keys())
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			keys := fakeStorage.Keys()
+			if len(keys) != 1 || !strings.HasPrefix(keys[0], "trips/"+tripID.String()+"/") || !strings.HasSuffix(keys[0], ".png") {
+				t.Fatalf("Expected one png key under the trip, got %v", keys)
+			}
+			if upload.PublicURL != "https://photos.example.com/"+keys[0] {
+				t.Errorf("Expected public URL for %s, got %s", keys[0], upload.PublicURL)
+			}
+			if upload.Headers["Content-Type"] != "image/png" {
+				t.Errorf("Expected the upload to require Content-Type image/png, got %v", upload.Headers)
+			}
+		})
+	}
+}
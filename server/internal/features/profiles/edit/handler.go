@@ -0,0 +1,74 @@
+package edit
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+
+	"black-lotus/internal/common/binding"
+	"black-lotus/internal/common/response"
+	validation "black-lotus/internal/common/validations"
+	"black-lotus/internal/domain/models"
+	"black-lotus/internal/features/auth/session"
+)
+
+type Handler struct {
+	service        ServiceInterface
+	sessionService session.ServiceInterface
+	validator      *validator.Validate
+}
+
+func NewHandler(service ServiceInterface, sessionService session.ServiceInterface, validator *validator.Validate) *Handler {
+	return &Handler{
+		service:        service,
+		sessionService: sessionService,
+		validator:      validator,
+	}
+}
+
+// UpdateProfile changes the current user's name and/or email
+func (h *Handler) UpdateProfile(ctx echo.Context) error {
+	userSession, err := session.Authenticate(ctx, h.sessionService)
+	if userSession == nil {
+		return err
+	}
+
+	var input models.UpdateUserInput
+	if err := binding.Bind(ctx, &input); err != nil {
+		return response.InvalidBody(ctx, err)
+	}
+
+	if err := h.validator.Struct(input); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			messages := validation.MessagesFor(ctx.Request().Header.Get("Accept-Language"))
+			return response.ErrorResponse(ctx, http.StatusBadRequest,
+				response.CodeValidationFailed, messages.Failed(), messages.Details(validationErrors))
+		}
+
+		return response.ErrorResponse(ctx, http.StatusBadRequest,
+			response.CodeValidationFailed, err.Error(), nil)
+	}
+
+	user, err := h.service.UpdateProfile(ctx.Request().Context(), userSession.UserID, input)
+	if err != nil {
+		switch err.Error() {
+		case "nothing to update":
+			return response.ErrorResponse(ctx, http.StatusBadRequest,
+				response.CodeInvalidRequest, "Provide a name or email to update", nil)
+		case "user with this email already exists":
+			return response.ErrorResponse(ctx, http.StatusConflict,
+				response.CodeEmailTaken, err.Error(), nil)
+		case "user not found":
+			return response.ErrorResponse(ctx, http.StatusNotFound,
+				response.CodeUserNotFound, "User not found", nil)
+		}
+
+		slog.Error("Failed to update profile", "user_id", userSession.UserID, "error", err)
+		return response.ErrorResponse(ctx, http.StatusInternalServerError,
+			response.CodeInternal, "Failed to update profile", nil)
+	}
+
+	return response.JSON(ctx, http.StatusOK, user)
+}
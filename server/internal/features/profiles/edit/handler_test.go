@@ -0,0 +1,203 @@
+package edit_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"black-lotus/internal/domain/models"
+	"black-lotus/internal/features/profiles/edit"
+)
+
+// Define a custom mock service that implements ServiceInterface
+type MockEditService struct {
+	updateProfileFunc func(ctx context.Context, userID uuid.UUID, input models.UpdateUserInput) (*models.User, error)
+}
+
+func (m *MockEditService) UpdateProfile(ctx context.Context, userID uuid.UUID, input models.UpdateUserInput) (*models.User, error) {
+	if m.updateProfileFunc != nil {
+		return m.updateProfileFunc(ctx, userID, input)
+	}
+	return nil, errors.New("UpdateProfile not implemented")
+}
+
+// Define a custom mock session service that implements session.ServiceInterface
+type MockSessionService struct {
+	validateAccessTokenFunc func(ctx context.Context, token string) (*models.Session, error)
+}
+
+func (m *MockSessionService) ValidateAccessToken(ctx context.Context, token string) (*models.Session, error) {
+	if m.validateAccessTokenFunc != nil {
+		return m.validateAccessTokenFunc(ctx, token)
+	}
+	return nil, errors.New("ValidateAccessToken not implemented")
+}
+
+func (m *MockSessionService) ValidateRefreshToken(ctx context.Context, token string) (*models.Session, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockSessionService) CreateSession(ctx context.Context, userID uuid.UUID, refreshDuration time.Duration) (*models.Session, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockSessionService) RefreshAccessToken(ctx context.Context, refreshToken string) (*models.Session, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockSessionService) EndSessionByAccessToken(ctx context.Context, token string) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockSessionService) EndSessionByRefreshToken(ctx context.Context, token string) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockSessionService) EndAllUserSessions(ctx context.Context, userID uuid.UUID) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockSessionService) EndOtherUserSessions(ctx context.Context, userID uuid.UUID, keepSessionID uuid.UUID) error {
+	return errors.New("not implemented")
+}
+
+// Helper function to create a new test context with a JSON body
+func newTestContext(method, path string, body []byte) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	req := httptest.NewRequest(method, path, bytes.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	return e.NewContext(req, rec), rec
+}
+
+func setupHandlerTest() (*edit.Handler, *MockEditService, *MockSessionService) {
+	mockService := &MockEditService{}
+	mockSessionService := &MockSessionService{}
+	handler := edit.NewHandler(mockService, mockSessionService, validator.New())
+	return handler, mockService, mockSessionService
+}
+
+func TestHandlerUpdateProfile(t *testing.T) {
+	testCases := []struct {
+		name           string
+		body           string
+		withCookie     bool
+		serviceErr     error
+		expectedStatus int
+	}{
+		{
+			name:           "SuccessfulUpdate",
+			body:           `{"name":"New Name","email":"new@example.com"}`,
+			withCookie:     true,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "NoAccessToken",
+			body:           `{"name":"New Name"}`,
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "InvalidEmail",
+			body:           `{"email":"not-an-email"}`,
+			withCookie:     true,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "EmptyName",
+			body:           `{"name":""}`,
+			withCookie:     true,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "UnknownField",
+			body:           `{"password":"Secret123!"}`,
+			withCookie:     true,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "NothingToUpdate",
+			body:           `{}`,
+			withCookie:     true,
+			serviceErr:     errors.New("nothing to update"),
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "EmailTaken",
+			body:           `{"email":"taken@example.com"}`,
+			withCookie:     true,
+			serviceErr:     errors.New("user with this email already exists"),
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:           "ServiceError",
+			body:           `{"name":"New Name"}`,
+			withCookie:     true,
+			serviceErr:     errors.New("database error"),
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			handler, mockService, mockSession := setupHandlerTest()
+			userID := uuid.New()
+
+			mockSession.validateAccessTokenFunc = func(ctx context.Context, token string) (*models.Session, error) {
+				return &models.Session{
+					ID:           uuid.New(),
+					UserID:       userID,
+					AccessToken:  token,
+					AccessExpiry: time.Now().Add(15 * time.Minute),
+				}, nil
+			}
+			mockService.updateProfileFunc = func(ctx context.Context, uid uuid.UUID, input models.UpdateUserInput) (*models.User, error) {
+				if tc.serviceErr != nil {
+					return nil, tc.serviceErr
+				}
+				user := &models.User{ID: uid, Name: "Old Name", Email: "old@example.com"}
+				if input.Name != nil {
+					user.Name = *input.Name
+				}
+				if input.Email != nil {
+					user.Email = *input.Email
+				}
+				return user, nil
+			}
+
+			c, rec := newTestContext(http.MethodPatch, "/api/auth/profile", []byte(tc.body))
+			if tc.withCookie {
+				c.Request().AddCookie(&http.Cookie{Name: "access_token", Value: "valid_access_token"})
+			}
+
+			// Execute
+			if err := handler.UpdateProfile(c); err != nil {
+				t.Errorf("Expected no error, got: %v", err)
+			}
+
+			// Verify
+			if rec.Code != tc.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tc.expectedStatus, rec.Code)
+			}
+
+			if tc.expectedStatus == http.StatusOK {
+				var user models.User
+				if err := json.Unmarshal(rec.Body.Bytes(), &user); err != nil {
+					t.Fatalf("Failed to unmarshal response: %v", err)
+				}
+				if user.Name != "New Name" || user.Email != "new@example.com" {
+					t.Errorf("Expected updated name and email, got %+v", user)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,14 @@
+package edit
+
+import (
+	"black-lotus/internal/domain/models"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// Repository defines database operations needed by the profile edit feature
+type Repository interface {
+	GetUserByEmail(ctx context.Context, email string) (*models.User, error)
+	UpdateUser(ctx context.Context, userID uuid.UUID, input models.UpdateUserInput) (*models.User, error)
+}
@@ -0,0 +1,49 @@
+package edit
+
+import (
+	"black-lotus/internal/domain/models"
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+type Service struct {
+	repo Repository
+}
+
+type ServiceInterface interface {
+	UpdateProfile(ctx context.Context, userID uuid.UUID, input models.UpdateUserInput) (*models.User, error)
+}
+
+func NewService(repo Repository) *Service {
+	return &Service{repo: repo}
+}
+
+// UpdateProfile changes the user's name and/or email. Fields missing from the
+// input keep their current values.
+func (s *Service) UpdateProfile(ctx context.Context, userID uuid.UUID, input models.UpdateUserInput) (*models.User, error) {
+	if input.Name == nil && input.Email == nil {
+		return nil, errors.New("nothing to update")
+	}
+
+	if input.Email != nil {
+		existingUser, err := s.repo.GetUserByEmail(ctx, *input.Email)
+		if err != nil {
+			return nil, err
+		}
+
+		if existingUser != nil && existingUser.ID != userID {
+			return nil, errors.New("user with this email already exists")
+		}
+	}
+
+	user, err := s.repo.UpdateUser(ctx, userID, input)
+	if err != nil {
+		return nil, err
+	}
+
+	// Don't return the hashed password
+	user.HashedPassword = nil
+	return user, nil
+}
@@ -0,0 +1,158 @@
+package edit_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"black-lotus/internal/domain/models"
+	"black-lotus/internal/features/profiles/edit"
+)
+
+// MockRepository implements edit.Repository for testing
+type MockRepository struct {
+	getUserByEmailFunc func(ctx context.Context, email string) (*models.User, error)
+	updateUserFunc     func(ctx context.Context, userID uuid.UUID, input models.UpdateUserInput) (*models.User, error)
+}
+
+func (m *MockRepository) GetUserByEmail(ctx context.Context, email string) (*models.User, error) {
+	if m.getUserByEmailFunc != nil {
+		return m.getUserByEmailFunc(ctx, email)
+	}
+	return nil, errors.New("GetUserByEmail not implemented")
+}
+
+func (m *MockRepository) UpdateUser(ctx context.Context, userID uuid.UUID, input models.UpdateUserInput) (*models.User, error) {
+	if m.updateUserFunc != nil {
+		return m.updateUserFunc(ctx, userID, input)
+	}
+	return nil, errors.New("UpdateUser not implemented")
+}
+
+// Helper function to setup service for testing
+func setupServiceTest() (*edit.Service, *MockRepository) {
+	mockRepo := &MockRepository{}
+	service := edit.NewService(mockRepo)
+	return service, mockRepo
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func TestServiceUpdateProfile(t *testing.T) {
+	testCases := []struct {
+		name          string
+		input         models.UpdateUserInput
+		setupMock     func(*testing.T, *MockRepository, uuid.UUID)
+		expectedError bool
+		errorMessage  string
+	}{
+		{
+			name:  "NameOnlyLeavesEmailAlone",
+			input: models.UpdateUserInput{Name: stringPtr("New Name")},
+			setupMock: func(t *testing.T, mockRepo *MockRepository, userID uuid.UUID) {
+				mockRepo.getUserByEmailFunc = func(ctx context.Context, email string) (*models.User, error) {
+					t.Error("GetUserByEmail should not be called when the email is unchanged")
+					return nil, nil
+				}
+				mockRepo.updateUserFunc = func(ctx context.Context, uid uuid.UUID, input models.UpdateUserInput) (*models.User, error) {
+					if input.Email != nil {
+						t.Errorf("Expected email to be left unset, got %q", *input.Email)
+					}
+					return &models.User{ID: uid, Name: *input.Name, Email: "old@example.com", EmailVerified: true}, nil
+				}
+			},
+			expectedError: false,
+		},
+		{
+			name:  "NewEmail",
+			input: models.UpdateUserInput{Email: stringPtr("new@example.com")},
+			setupMock: func(t *testing.T, mockRepo *MockRepository, userID uuid.UUID) {
+				mockRepo.getUserByEmailFunc = func(ctx context.Context, email string) (*models.User, error) {
+					return nil, nil
+				}
+				mockRepo.updateUserFunc = func(ctx context.Context, uid uuid.UUID, input models.UpdateUserInput) (*models.User, error) {
+					return &models.User{ID: uid, Name: "Test User", Email: *input.Email}, nil
+				}
+			},
+			expectedError: false,
+		},
+		{
+			name:  "OwnEmailIsNotTaken",
+			input: models.UpdateUserInput{Email: stringPtr("me@example.com")},
+			setupMock: func(t *testing.T, mockRepo *MockRepository, userID uuid.UUID) {
+				mockRepo.getUserByEmailFunc = func(ctx context.Context, email string) (*models.User, error) {
+					return &models.User{ID: userID, Email: email}, nil
+				}
+				mockRepo.updateUserFunc = func(ctx context.Context, uid uuid.UUID, input models.UpdateUserInput) (*models.User, error) {
+					return &models.User{ID: uid, Email: *input.Email, EmailVerified: true}, nil
+				}
+			},
+			expectedError: false,
+		},
+		{
+			name:  "EmailTaken",
+			input: models.UpdateUserInput{Email: stringPtr("taken@example.com")},
+			setupMock: func(t *testing.T, mockRepo *MockRepository, userID uuid.UUID) {
+				mockRepo.getUserByEmailFunc = func(ctx context.Context, email string) (*models.User, error) {
+					return &models.User{ID: uuid.New(), Email: email}, nil
+				}
+				mockRepo.updateUserFunc = func(ctx context.Context, uid uuid.UUID, input models.UpdateUserInput) (*models.User, error) {
+					t.Error("UpdateUser should not be called when the email is taken")
+					return nil, nil
+				}
+			},
+			expectedError: true,
+			errorMessage:  "user with this email already exists",
+		},
+		{
+			name:          "NothingToUpdate",
+			input:         models.UpdateUserInput{},
+			setupMock:     func(t *testing.T, mockRepo *MockRepository, userID uuid.UUID) {},
+			expectedError: true,
+			errorMessage:  "nothing to update",
+		},
+		{
+			name:  "RepositoryError",
+			input: models.UpdateUserInput{Name: stringPtr("New Name")},
+			setupMock: func(t *testing.T, mockRepo *MockRepository, userID uuid.UUID) {
+				mockRepo.updateUserFunc = func(ctx context.Context, uid uuid.UUID, input models.UpdateUserInput) (*models.User, error) {
+					return nil, errors.New("database error")
+				}
+			},
+			expectedError: true,
+			errorMessage:  "database error",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			service, mockRepo := setupServiceTest()
+			userID := uuid.New()
+			tc.setupMock(t, mockRepo, userID)
+
+			// Execute
+			user, err := service.UpdateProfile(context.Background(), userID, tc.input)
+
+			// Verify
+			if tc.expectedError {
+				if err == nil {
+					t.Error("Expected error, got nil")
+				} else if tc.errorMessage != "" && err.Error() != tc.errorMessage {
+					t.Errorf("Expected error message '%s', got '%s'", tc.errorMessage, err.Error())
+				}
+			} else {
+				if err != nil {
+					t.Errorf("Expected no error, got: %v", err)
+				}
+				if user == nil || user.ID != userID {
+					t.Errorf("Expected updated user %s, got %+v", userID, user)
+				}
+			}
+		})
+	}
+}
@@ -1,9 +1,10 @@
 package trips
 
 import (
+	"black-lotus/internal/common/pagination"
+	"black-lotus/internal/common/response"
 	"black-lotus/internal/features/auth/session"
 	"net/http"
-	"strconv"
 
 	"github.com/labstack/echo/v4"
 )
@@ -11,59 +12,41 @@ import (
 type Handler struct {
 	service        ServiceInterface
 	sessionService session.ServiceInterface
+	pages          pagination.Config
 }
 
 func NewHandler(service ServiceInterface, sessionService session.ServiceInterface) *Handler {
 	return &Handler{
 		service:        service,
 		sessionService: sessionService,
+		pages:          pagination.ConfigFromEnv(),
 	}
 }
 
 func (h *Handler) GetUserProfileWithTrips(ctx echo.Context) error {
-	// Get access token from cookie
-	accessCookie, err := ctx.Cookie("access_token")
-	if err != nil {
-		// No access token - check if there's a refresh token
-		_, refreshErr := ctx.Cookie("refresh_token")
-		if refreshErr != nil {
-			return ctx.JSON(http.StatusUnauthorized, map[string]string{
-				"error": "Not authenticated",
-			})
-		}
-		// Has refresh token but no access token - client should refresh
-		return ctx.JSON(http.StatusUnauthorized, map[string]string{
-			"error": "Access token expired",
-			"code":  "token_expired",
-		})
+	userSession, err := session.Authenticate(ctx, h.sessionService)
+	if userSession == nil {
+		return err
 	}
 
-	// Validate access token
-	session, err := h.sessionService.ValidateAccessToken(ctx.Request().Context(), accessCookie.Value)
+	// Parse pagination parameters
+	limit, offset, err := h.pages.Parse(ctx)
 	if err != nil {
-		return ctx.JSON(http.StatusUnauthorized, map[string]string{
-			"error": "Invalid access token",
-			"code":  "token_invalid",
-		})
+		return response.ErrorResponse(ctx, http.StatusBadRequest,
+			response.CodeInvalidRequest, err.Error(), nil)
 	}
 
-	// Parse pagination parameters
-	limit, _ := strconv.Atoi(ctx.QueryParam("limit"))
-	offset, _ := strconv.Atoi(ctx.QueryParam("offset"))
-
-	user, err := h.service.GetUserWithTrips(ctx.Request().Context(), session.UserID, limit, offset)
+	user, err := h.service.GetUserWithTrips(ctx.Request().Context(), userSession.UserID, limit, offset)
 	if err != nil {
-		return ctx.JSON(http.StatusInternalServerError, map[string]string{
-			"error": "Failed to get user profile with trips",
-		})
+		return response.ErrorResponse(ctx, http.StatusInternalServerError,
+			response.CodeInternal, "Failed to get user profile with trips", nil)
 	}
 
 	// Check if user is nil
 	if user == nil {
-		return ctx.JSON(http.StatusNotFound, map[string]string{
-			"error": "User not found",
-		})
+		return response.ErrorResponse(ctx, http.StatusNotFound,
+			response.CodeUserNotFound, "User not found", nil)
 	}
 
-	return ctx.JSON(http.StatusOK, user)
+	return response.JSON(ctx, http.StatusOK, user)
 }
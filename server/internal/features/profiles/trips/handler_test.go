@@ -13,6 +13,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 
+	"black-lotus/internal/common/response"
 	"black-lotus/internal/domain/models"
 	"black-lotus/internal/features/profiles/trips"
 )
@@ -46,7 +47,7 @@ func (m *MockSessionService) ValidateRefreshToken(ctx context.Context, token str
 	return nil, errors.New("ValidateRefreshToken not implemented")
 }
 
-func (m *MockSessionService) CreateSession(ctx context.Context, userID uuid.UUID) (*models.Session, error) {
+func (m *MockSessionService) CreateSession(ctx context.Context, userID uuid.UUID, refreshDuration time.Duration) (*models.Session, error) {
 	return nil, errors.New("CreateSession not implemented")
 }
 
@@ -66,6 +67,10 @@ func (m *MockSessionService) EndAllUserSessions(ctx context.Context, userID uuid
 	return errors.New("EndAllUserSessions not implemented")
 }
 
+func (m *MockSessionService) EndOtherUserSessions(ctx context.Context, userID uuid.UUID, keepSessionID uuid.UUID) error {
+	return errors.New("EndOtherUserSessions not implemented")
+}
+
 // Helper function to create a new test context
 func newTestContext(method, path string) (echo.Context, *httptest.ResponseRecorder) {
 	e := echo.New()
@@ -277,9 +282,9 @@ func TestGetUserProfileWithTrips(t *testing.T) {
 
 			// Verify response
 			if tc.expectedError {
-				var errorResponse map[string]string
+				var errorResponse response.ErrorEnvelope
 				json.Unmarshal(rec.Body.Bytes(), &errorResponse)
-				if tc.expectedStatus != http.StatusOK && errorResponse["error"] == "" {
+				if tc.expectedStatus != http.StatusOK && errorResponse.Error.Message == "" {
 					t.Errorf("Expected error message in response, got none")
 				}
 			} else {
@@ -314,3 +319,94 @@ func TestGetUserProfileWithTrips(t *testing.T) {
 		})
 	}
 }
+
+func TestGetUserProfileWithTripsPageSize(t *testing.T) {
+	testCases := []struct {
+		name          string
+		query         string
+		expectedLimit int
+	}{
+		{name: "LimitAbsent", query: "", expectedLimit: 5},
+		{name: "WithinMax", query: "?limit=15", expectedLimit: 15},
+		{name: "AboveMax", query: "?limit=1000", expectedLimit: 20},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			t.Setenv("DEFAULT_PAGE_SIZE", "5")
+			t.Setenv("MAX_PAGE_SIZE", "20")
+			handler, mockService, mockSession := setupHandler()
+			userID := uuid.New()
+
+			mockSession.validateAccessTokenFunc = func(ctx context.Context, token string) (*models.Session, error) {
+				return createTestSession(userID, token, "valid_refresh_token"), nil
+			}
+
+			var receivedLimit int
+			mockService.getUserWithTripsFunc = func(ctx context.Context, uid uuid.UUID, limit, offset int) (*models.User, error) {
+				receivedLimit = limit
+				return &models.User{ID: uid, Trips: []*models.Trip{}}, nil
+			}
+
+			c, rec := newTestContext(http.MethodGet, "/api/profile/trips"+tc.query)
+			addCookies(c, &http.Cookie{Name: "access_token", Value: "valid_access_token"})
+
+			// Execute
+			if err := handler.GetUserProfileWithTrips(c); err != nil {
+				t.Errorf("Expected no error, got: %v", err)
+			}
+
+			// Verify
+			checkResponseStatus(t, rec, http.StatusOK)
+			if receivedLimit != tc.expectedLimit {
+				t.Errorf("Expected limit %d, got %d", tc.expectedLimit, receivedLimit)
+			}
+		})
+	}
+}
+
+func TestGetUserProfileWithTripsInvalidPagination(t *testing.T) {
+	testCases := []struct {
+		name  string
+		query string
+	}{
+		{name: "NonNumericLimit", query: "?limit=ten"},
+		{name: "NonNumericOffset", query: "?offset=abc"},
+		{name: "NegativeOffset", query: "?offset=-5"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			handler, mockService, mockSession := setupHandler()
+			userID := uuid.New()
+
+			mockSession.validateAccessTokenFunc = func(ctx context.Context, token string) (*models.Session, error) {
+				return createTestSession(userID, token, "valid_refresh_token"), nil
+			}
+			mockService.getUserWithTripsFunc = func(ctx context.Context, uid uuid.UUID, limit, offset int) (*models.User, error) {
+				t.Error("Expected service not to be called")
+				return &models.User{ID: uid}, nil
+			}
+
+			c, rec := newTestContext(http.MethodGet, "/api/profile/trips"+tc.query)
+			addCookies(c, &http.Cookie{Name: "access_token", Value: "valid_access_token"})
+
+			// Execute
+			if err := handler.GetUserProfileWithTrips(c); err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			// Verify
+			checkResponseStatus(t, rec, http.StatusBadRequest)
+			var envelope response.ErrorEnvelope
+			if err := json.Unmarshal(rec.Body.Bytes(), &envelope); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+			if envelope.Error.Code != response.CodeInvalidRequest {
+				t.Errorf("Expected code '%s', got '%s'", response.CodeInvalidRequest, envelope.Error.Code)
+			}
+		})
+	}
+}
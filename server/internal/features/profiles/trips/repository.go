@@ -14,5 +14,5 @@ type UserRepository interface {
 
 // TripRepository defines trip operations needed by the trips feature
 type TripRepository interface {
-	GetTripsByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*models.Trip, error)
+	GetTripsByUserID(ctx context.Context, userID uuid.UUID, limit, offset int, filter models.TripFilter) ([]*models.Trip, error)
 }
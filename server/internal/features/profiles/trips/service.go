@@ -4,6 +4,7 @@ import (
 	"black-lotus/internal/domain/models"
 	"context"
 	"errors"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -36,11 +37,17 @@ func (s *Service) GetUserWithTrips(ctx context.Context, userID uuid.UUID, limit,
 	user.HashedPassword = nil
 
 	// Get the user's trips
-	trips, err := s.tripRepo.GetTripsByUserID(ctx, userID, limit, offset)
+	trips, err := s.tripRepo.GetTripsByUserID(ctx, userID, limit, offset, models.TripFilter{})
 	if err != nil {
 		return nil, err
 	}
 
+	// Status is computed per request and never stored
+	now := time.Now()
+	for _, trip := range trips {
+		trip.Status = models.TripStatusAt(trip.StartDate, trip.EndDate, now)
+	}
+
 	// Attach trips to user
 	user.Trips = trips
 	return user, nil
@@ -26,12 +26,12 @@ func (m *MockUserRepository) GetUserByID(ctx context.Context, userID uuid.UUID)
 
 // MockTripRepository implements trips.TripRepository for testing
 type MockTripRepository struct {
-	getTripsByUserIDFunc func(ctx context.Context, userID uuid.UUID, limit int, offset int) ([]*models.Trip, error)
+	getTripsByUserIDFunc func(ctx context.Context, userID uuid.UUID, limit int, offset int, filter models.TripFilter) ([]*models.Trip, error)
 }
 
-func (m *MockTripRepository) GetTripsByUserID(ctx context.Context, userID uuid.UUID, limit int, offset int) ([]*models.Trip, error) {
+func (m *MockTripRepository) GetTripsByUserID(ctx context.Context, userID uuid.UUID, limit int, offset int, filter models.TripFilter) ([]*models.Trip, error) {
 	if m.getTripsByUserIDFunc != nil {
-		return m.getTripsByUserIDFunc(ctx, userID, limit, offset)
+		return m.getTripsByUserIDFunc(ctx, userID, limit, offset, filter)
 	}
 	return nil, errors.New("GetTripsByUserID not implemented")
 }
@@ -60,7 +60,7 @@ func TestGetUserWithTrips(t *testing.T) {
 					return nil, errors.New("user not found")
 				}
 
-				mockTripRepo.getTripsByUserIDFunc = func(ctx context.Context, uid uuid.UUID, limit, offset int) ([]*models.Trip, error) {
+				mockTripRepo.getTripsByUserIDFunc = func(ctx context.Context, uid uuid.UUID, limit, offset int, filter models.TripFilter) ([]*models.Trip, error) {
 					return []*models.Trip{}, nil
 				}
 			},
@@ -84,7 +84,7 @@ func TestGetUserWithTrips(t *testing.T) {
 					return nil, errors.New("user not found")
 				}
 
-				mockTripRepo.getTripsByUserIDFunc = func(ctx context.Context, uid uuid.UUID, limit, offset int) ([]*models.Trip, error) {
+				mockTripRepo.getTripsByUserIDFunc = func(ctx context.Context, uid uuid.UUID, limit, offset int, filter models.TripFilter) ([]*models.Trip, error) {
 					return []*models.Trip{
 						{
 							ID:          uuid.New(),
@@ -143,7 +143,7 @@ func TestGetUserWithTrips(t *testing.T) {
 					return nil, errors.New("user not found")
 				}
 
-				mockTripRepo.getTripsByUserIDFunc = func(ctx context.Context, uid uuid.UUID, limit, offset int) ([]*models.Trip, error) {
+				mockTripRepo.getTripsByUserIDFunc = func(ctx context.Context, uid uuid.UUID, limit, offset int, filter models.TripFilter) ([]*models.Trip, error) {
 					return nil, errors.New("database error")
 				}
 			},
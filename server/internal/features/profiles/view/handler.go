@@ -1,6 +1,7 @@
 package view
 
 import (
+	"black-lotus/internal/common/response"
 	"black-lotus/internal/features/auth/session"
 	"net/http"
 
@@ -20,39 +21,33 @@ func NewHandler(service ServiceInterface, sessionService session.ServiceInterfac
 }
 
 func (h *Handler) GetUserProfile(ctx echo.Context) error {
-	// Get access token from cookie
-	accessCookie, err := ctx.Cookie("access_token")
-	if err != nil {
-		// No access token - check if there's a refresh token
-		_, refreshErr := ctx.Cookie("refresh_token")
-		if refreshErr != nil {
-			return ctx.JSON(http.StatusUnauthorized, map[string]string{
-				"error": "Not authenticated",
-			})
-		}
-		// Has refresh token but no access token - client should refresh
-		return ctx.JSON(http.StatusUnauthorized, map[string]string{
-			"error": "Access token expired",
-			"code":  "token_expired",
-		})
+	userSession, err := session.Authenticate(ctx, h.sessionService)
+	if userSession == nil {
+		return err
 	}
 
-	// Validate access token
-	session, err := h.sessionService.ValidateAccessToken(ctx.Request().Context(), accessCookie.Value)
+	// Get user from session
+	user, err := h.service.GetUserProfile(ctx.Request().Context(), userSession.UserID)
 	if err != nil {
-		return ctx.JSON(http.StatusUnauthorized, map[string]string{
-			"error": "Invalid access token",
-			"code":  "token_invalid",
-		})
+		return response.ErrorResponse(ctx, http.StatusInternalServerError,
+			response.CodeInternal, "Failed to get user", nil)
 	}
 
-	// Get user from session
-	user, err := h.service.GetUserProfile(ctx.Request().Context(), session.UserID)
+	return response.JSON(ctx, http.StatusOK, user)
+}
+
+// GetUserStats returns the dashboard summary for the authenticated user
+func (h *Handler) GetUserStats(ctx echo.Context) error {
+	userSession, err := session.Authenticate(ctx, h.sessionService)
+	if userSession == nil {
+		return err
+	}
+
+	stats, err := h.service.GetUserStats(ctx.Request().Context(), userSession.UserID)
 	if err != nil {
-		return ctx.JSON(http.StatusInternalServerError, map[string]string{
-			"error": "Failed to get user",
-		})
+		return response.ErrorResponse(ctx, http.StatusInternalServerError,
+			response.CodeInternal, "Failed to get user stats", nil)
 	}
 
-	return ctx.JSON(http.StatusOK, user)
+	return response.JSON(ctx, http.StatusOK, stats)
 }
@@ -12,13 +12,16 @@ import (
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 
+	"black-lotus/internal/common/response"
 	"black-lotus/internal/domain/models"
+	"black-lotus/internal/features/auth/session"
 	"black-lotus/internal/features/profiles/view"
 )
 
 // Define a custom mock service that implements ServiceInterface
 type MockViewService struct {
 	getUserProfileFunc func(ctx context.Context, userID uuid.UUID) (*models.User, error)
+	getUserStatsFunc   func(ctx context.Context, userID uuid.UUID) (*models.UserStats, error)
 }
 
 func (m *MockViewService) GetUserProfile(ctx context.Context, userID uuid.UUID) (*models.User, error) {
@@ -28,6 +31,13 @@ func (m *MockViewService) GetUserProfile(ctx context.Context, userID uuid.UUID)
 	return nil, errors.New("GetUserProfile not implemented")
 }
 
+func (m *MockViewService) GetUserStats(ctx context.Context, userID uuid.UUID) (*models.UserStats, error) {
+	if m.getUserStatsFunc != nil {
+		return m.getUserStatsFunc(ctx, userID)
+	}
+	return nil, errors.New("GetUserStats not implemented")
+}
+
 // Define a custom mock session service that implements session.ServiceInterface
 type MockSessionService struct {
 	validateAccessTokenFunc func(ctx context.Context, token string) (*models.Session, error)
@@ -44,7 +54,7 @@ func (m *MockSessionService) ValidateRefreshToken(ctx context.Context, token str
 	return nil, errors.New("not implemented")
 }
 
-func (m *MockSessionService) CreateSession(ctx context.Context, userID uuid.UUID) (*models.Session, error) {
+func (m *MockSessionService) CreateSession(ctx context.Context, userID uuid.UUID, refreshDuration time.Duration) (*models.Session, error) {
 	return nil, errors.New("not implemented")
 }
 
@@ -64,6 +74,10 @@ func (m *MockSessionService) EndAllUserSessions(ctx context.Context, userID uuid
 	return errors.New("not implemented")
 }
 
+func (m *MockSessionService) EndOtherUserSessions(ctx context.Context, userID uuid.UUID, keepSessionID uuid.UUID) error {
+	return errors.New("not implemented")
+}
+
 // Helper function to create a new test context
 func newTestContext(method, path string) (echo.Context, *httptest.ResponseRecorder) {
 	e := echo.New()
@@ -242,9 +256,9 @@ func TestHandlerGetUserProfile(t *testing.T) {
 
 			// Verify response
 			if tc.expectedError {
-				var errorResponse map[string]string
+				var errorResponse response.ErrorEnvelope
 				json.Unmarshal(rec.Body.Bytes(), &errorResponse)
-				if tc.expectedStatus != http.StatusOK && errorResponse["error"] == "" {
+				if tc.expectedStatus != http.StatusOK && errorResponse.Error.Message == "" {
 					t.Errorf("Expected error message in response, got none")
 				}
 			} else {
@@ -277,3 +291,148 @@ func TestHandlerGetUserProfile(t *testing.T) {
 		})
 	}
 }
+
+func TestHandlerGetUserStats(t *testing.T) {
+	testCases := []struct {
+		name           string
+		setupCookies   []*http.Cookie
+		setupMocks     func(*testing.T, *MockViewService, *MockSessionService, uuid.UUID)
+		expectedStatus int
+		expectedStats  *models.UserStats
+	}{
+		{
+			name: "SuccessfulFetch",
+			setupCookies: []*http.Cookie{
+				{Name: "access_token", Value: "valid_access_token"},
+			},
+			setupMocks: func(t *testing.T, mockService *MockViewService, mockSession *MockSessionService, userID uuid.UUID) {
+				mockSession.validateAccessTokenFunc = func(ctx context.Context, token string) (*models.Session, error) {
+					return createTestSession(userID, token, "valid_refresh_token"), nil
+				}
+				mockService.getUserStatsFunc = func(ctx context.Context, uid uuid.UUID) (*models.UserStats, error) {
+					if uid != userID {
+						t.Errorf("Expected stats for user %s, got %s", userID, uid)
+					}
+					return &models.UserStats{TotalTrips: 2, PastTrips: 2, DaysTraveled: 6, MostVisitedLocation: "Lisbon"}, nil
+				}
+			},
+			expectedStatus: http.StatusOK,
+			expectedStats:  &models.UserStats{TotalTrips: 2, PastTrips: 2, DaysTraveled: 6, MostVisitedLocation: "Lisbon"},
+		},
+		{
+			name: "NoTrips",
+			setupCookies: []*http.Cookie{
+				{Name: "access_token", Value: "valid_access_token"},
+			},
+			setupMocks: func(t *testing.T, mockService *MockViewService, mockSession *MockSessionService, userID uuid.UUID) {
+				mockSession.validateAccessTokenFunc = func(ctx context.Context, token string) (*models.Session, error) {
+					return createTestSession(userID, token, "valid_refresh_token"), nil
+				}
+				mockService.getUserStatsFunc = func(ctx context.Context, uid uuid.UUID) (*models.UserStats, error) {
+					return &models.UserStats{}, nil
+				}
+			},
+			expectedStatus: http.StatusOK,
+			expectedStats:  &models.UserStats{},
+		},
+		{
+			name:         "NoAccessToken",
+			setupCookies: []*http.Cookie{},
+			setupMocks: func(t *testing.T, mockService *MockViewService, mockSession *MockSessionService, userID uuid.UUID) {
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "ServiceError",
+			setupCookies: []*http.Cookie{
+				{Name: "access_token", Value: "valid_access_token"},
+			},
+			setupMocks: func(t *testing.T, mockService *MockViewService, mockSession *MockSessionService, userID uuid.UUID) {
+				mockSession.validateAccessTokenFunc = func(ctx context.Context, token string) (*models.Session, error) {
+					return createTestSession(userID, token, "valid_refresh_token"), nil
+				}
+				mockService.getUserStatsFunc = func(ctx context.Context, uid uuid.UUID) (*models.UserStats, error) {
+					return nil, errors.New("service error")
+				}
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler, mockService, mockSession := setupHandlerTest()
+			userID := uuid.New()
+
+			c, rec := newTestContext(http.MethodGet, "/api/auth/me/stats")
+			if len(tc.setupCookies) > 0 {
+				addCookies(c, tc.setupCookies...)
+			}
+
+			tc.setupMocks(t, mockService, mockSession, userID)
+
+			err := handler.GetUserStats(c)
+			if err != nil {
+				t.Errorf("Expected no error, got: %v", err)
+			}
+
+			checkResponseStatus(t, rec, tc.expectedStatus)
+
+			if tc.expectedStats == nil {
+				return
+			}
+
+			// Zero counts must be serialized rather than omitted or null
+			var body map[string]any
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+			for _, key := range []string{"total_trips", "upcoming_trips", "past_trips", "days_traveled", "most_visited_location"} {
+				if value, ok := body[key]; !ok || value == nil {
+					t.Errorf("Expected %s in response, got %v", key, value)
+				}
+			}
+
+			var stats models.UserStats
+			if err := json.Unmarshal(rec.Body.Bytes(), &stats); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+			if stats != *tc.expectedStats {
+				t.Errorf("Expected stats %+v, got %+v", *tc.expectedStats, stats)
+			}
+		})
+	}
+}
+
+func TestHandlerGetUserProfileWithAPIKey(t *testing.T) {
+	// Setup
+	handler, mockService, mockSession := setupHandlerTest()
+	userID := uuid.New()
+
+	mockSession.validateAccessTokenFunc = func(ctx context.Context, token string) (*models.Session, error) {
+		t.Error("Expected no access token lookup for an API key request")
+		return nil, errors.New("unexpected call")
+	}
+	mockService.getUserProfileFunc = func(ctx context.Context, uid uuid.UUID) (*models.User, error) {
+		return &models.User{ID: uid, Name: "Test User", Email: "test@example.com"}, nil
+	}
+
+	// No cookies: the API key middleware has already authenticated the request
+	c, rec := newTestContext(http.MethodGet, "/api/profile")
+	c.Set(session.APIKeyContextKey, &models.APIKey{ID: uuid.New(), UserID: userID})
+
+	// Execute
+	if err := handler.GetUserProfile(c); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	// Verify
+	checkResponseStatus(t, rec, http.StatusOK)
+	var user models.User
+	if err := json.Unmarshal(rec.Body.Bytes(), &user); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if user.ID != userID {
+		t.Errorf("Expected the API key owner's profile %s, got %s", userID, user.ID)
+	}
+}
@@ -10,4 +10,5 @@ import (
 // Repository defines database operations needed by the profile view feature
 type Repository interface {
 	GetUserByID(ctx context.Context, userID uuid.UUID) (*models.User, error)
+	GetUserStats(ctx context.Context, userID uuid.UUID) (*models.UserStats, error)
 }
@@ -13,6 +13,7 @@ type Service struct {
 
 type ServiceInterface interface {
 	GetUserProfile(ctx context.Context, userID uuid.UUID) (*models.User, error)
+	GetUserStats(ctx context.Context, userID uuid.UUID) (*models.UserStats, error)
 }
 
 func NewService(repo Repository) *Service {
@@ -34,3 +35,17 @@ func (s *Service) GetUserProfile(ctx context.Context, userID uuid.UUID) (*models
 	user.HashedPassword = nil
 	return user, nil
 }
+
+// GetUserStats returns the user's trip summary. Missing stats are reported as
+// zeros so clients never have to handle nulls.
+func (s *Service) GetUserStats(ctx context.Context, userID uuid.UUID) (*models.UserStats, error) {
+	stats, err := s.repo.GetUserStats(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if stats == nil {
+		return &models.UserStats{}, nil
+	}
+	return stats, nil
+}
@@ -13,7 +13,8 @@ import (
 
 // MockRepository implements view.Repository for testing
 type MockRepository struct {
-	getUserByIDFunc func(ctx context.Context, userID uuid.UUID) (*models.User, error)
+	getUserByIDFunc  func(ctx context.Context, userID uuid.UUID) (*models.User, error)
+	getUserStatsFunc func(ctx context.Context, userID uuid.UUID) (*models.UserStats, error)
 }
 
 func (m *MockRepository) GetUserByID(ctx context.Context, userID uuid.UUID) (*models.User, error) {
@@ -23,6 +24,13 @@ func (m *MockRepository) GetUserByID(ctx context.Context, userID uuid.UUID) (*mo
 	return nil, errors.New("GetUserByID not implemented")
 }
 
+func (m *MockRepository) GetUserStats(ctx context.Context, userID uuid.UUID) (*models.UserStats, error) {
+	if m.getUserStatsFunc != nil {
+		return m.getUserStatsFunc(ctx, userID)
+	}
+	return nil, errors.New("GetUserStats not implemented")
+}
+
 // Helper function to setup service for testing
 func setupServiceTest() (*view.Service, *MockRepository) {
 	mockRepo := &MockRepository{}
@@ -159,3 +167,74 @@ func TestServiceGetUserProfile(t *testing.T) {
 		})
 	}
 }
+
+func TestServiceGetUserStats(t *testing.T) {
+	testCases := []struct {
+		name          string
+		repoStats     *models.UserStats
+		repoErr       error
+		expectedStats models.UserStats
+		expectedError bool
+	}{
+		{
+			name: "WithTrips",
+			repoStats: &models.UserStats{
+				TotalTrips:          3,
+				UpcomingTrips:       1,
+				PastTrips:           2,
+				DaysTraveled:        9.5,
+				MostVisitedLocation: "Tokyo",
+			},
+			expectedStats: models.UserStats{
+				TotalTrips:          3,
+				UpcomingTrips:       1,
+				PastTrips:           2,
+				DaysTraveled:        9.5,
+				MostVisitedLocation: "Tokyo",
+			},
+		},
+		{
+			name:          "NoStatsReturnsZeros",
+			repoStats:     nil,
+			expectedStats: models.UserStats{},
+		},
+		{
+			name:          "RepositoryError",
+			repoErr:       errors.New("database error"),
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			service, mockRepo := setupServiceTest()
+			userID := uuid.New()
+
+			mockRepo.getUserStatsFunc = func(ctx context.Context, id uuid.UUID) (*models.UserStats, error) {
+				if id != userID {
+					t.Errorf("Expected stats for user %s, got %s", userID, id)
+				}
+				return tc.repoStats, tc.repoErr
+			}
+
+			stats, err := service.GetUserStats(context.Background(), userID)
+
+			if tc.expectedError {
+				if err == nil {
+					t.Fatal("Expected error, got nil")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if stats == nil {
+				t.Fatal("Expected stats, got nil")
+			}
+			if *stats != tc.expectedStats {
+				t.Errorf("Expected stats %+v, got %+v", tc.expectedStats, *stats)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package reminders
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"black-lotus/internal/common/response"
+)
+
+// Handler serves the links sent in reminders. They are authenticated by the
+// reminder's token alone, so they work straight from an email without logging in.
+type Handler struct {
+	service ServiceInterface
+}
+
+func NewHandler(service ServiceInterface) *Handler {
+	return &Handler{service: service}
+}
+
+// handleServiceError maps reminder service errors to responses
+func handleServiceError(ctx echo.Context, err error, action string) error {
+	switch err.Error() {
+	case "reminder not found":
+		return response.ErrorResponse(ctx, http.StatusNotFound,
+			response.CodeReminderNotFound, "Reminder not found", nil)
+	case "reminder already acknowledged":
+		return response.ErrorResponse(ctx, http.StatusConflict,
+			response.CodeReminderAcknowledged, "This reminder has already been acknowledged", nil)
+	}
+
+	slog.Error("Failed to "+action, "error", err)
+	return response.ErrorResponse(ctx, http.StatusInternalServerError,
+		response.CodeInternal, "Failed to "+action, nil)
+}
+
+// AcknowledgeReminder stops a reminder from being sent again
+func (h *Handler) AcknowledgeReminder(ctx echo.Context) error {
+	reminder, err := h.service.AcknowledgeReminder(ctx.Request().Context(), ctx.Param("token"))
+	if err != nil {
+		return handleServiceError(ctx, err, "acknowledge reminder")
+	}
+
+	return response.JSON(ctx, http.StatusOK, reminder)
+}
+
+// SnoozeReminder sends a reminder again after the given number of days
+func (h *Handler) SnoozeReminder(ctx echo.Context) error {
+	days := DefaultSnoozeDays
+	if daysParam := ctx.QueryParam("days"); daysParam != "" {
+		var err error
+		days, err = strconv.Atoi(daysParam)
+		if err != nil || days < 1 || days > MaxSnoozeDays {
+			return response.ErrorResponse(ctx, http.StatusBadRequest, response.CodeInvalidRequest,
+				fmt.Sprintf("days must be a whole number from 1 to %d", MaxSnoozeDays), nil)
+		}
+	}
+
+	reminder, err := h.service.SnoozeReminder(ctx.Request().Context(), ctx.Param("token"), days)
+	if err != nil {
+		return handleServiceError(ctx, err, "snooze reminder")
+	}
+
+	return response.JSON(ctx, http.StatusOK, reminder)
+}
@@ -0,0 +1,179 @@
+package reminders_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"black-lotus/internal/common/response"
+	"black-lotus/internal/domain/models"
+	"black-lotus/internal/features/reminders"
+)
+
+// MockService implements reminders.ServiceInterface for testing
+type MockService struct {
+	acknowledgeReminderFunc func(ctx context.Context, token string) (*models.TripReminder, error)
+	snoozeReminderFunc      func(ctx context.Context, token string, days int) (*models.TripReminder, error)
+}
+
+func (m *MockService) AcknowledgeReminder(ctx context.Context, token string) (*models.TripReminder, error) {
+	if m.acknowledgeReminderFunc != nil {
+		return m.acknowledgeReminderFunc(ctx, token)
+	}
+	return nil, errors.New("AcknowledgeReminder not implemented")
+}
+
+func (m *MockService) SnoozeReminder(ctx context.Context, token string, days int) (*models.TripReminder, error) {
+	if m.snoozeReminderFunc != nil {
+		return m.snoozeReminderFunc(ctx, token, days)
+	}
+	return nil, errors.New("SnoozeReminder not implemented")
+}
+
+// Helper function to create a test context for a reminder link, with no session
+func newTestContext(path, token string) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("token")
+	c.SetParamValues(token)
+	return c, rec
+}
+
+func checkErrorCode(t *testing.T, rec *httptest.ResponseRecorder, expectedCode string) {
+	t.Helper()
+	var envelope response.ErrorEnvelope
+	if err := json.Unmarshal(rec.Body.Bytes(), &envelope); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if envelope.Error.Code != expectedCode {
+		t.Errorf("Expected code '%s', got '%s'", expectedCode, envelope.Error.Code)
+	}
+}
+
+func TestHandlerAcknowledgeReminder(t *testing.T) {
+	testCases := []struct {
+		name           string
+		serviceErr     error
+		expectedStatus int
+		expectedCode   string
+	}{
+		{name: "Acknowledged", expectedStatus: http.StatusOK},
+		{name: "UnknownToken", serviceErr: errors.New("reminder not found"), expectedStatus: http.StatusNotFound, expectedCode: response.CodeReminderNotFound},
+		{name: "ServiceError", serviceErr: errors.New("database error"), expectedStatus: http.StatusInternalServerError, expectedCode: response.CodeInternal},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			mockService := &MockService{}
+			handler := reminders.NewHandler(mockService)
+			now := time.Now()
+			mockService.acknowledgeReminderFunc = func(ctx context.Context, token string) (*models.TripReminder, error) {
+				if token != "reminder-token" {
+					t.Errorf("Expected token reminder-token, got %q", token)
+				}
+				if tc.serviceErr != nil {
+					return nil, tc.serviceErr
+				}
+				return &models.TripReminder{ID: uuid.New(), Token: token, AcknowledgedAt: &now}, nil
+			}
+
+			c, rec := newTestContext("/api/reminders/reminder-token/ack", "reminder-token")
+
+			// Execute
+			if err := handler.AcknowledgeReminder(c); err != nil {
+				t.Errorf("Expected no error, got: %v", err)
+			}
+
+			// Verify
+			if rec.Code != tc.expectedStatus {
+				t.Fatalf("Expected status %d, got %d", tc.expectedStatus, rec.Code)
+			}
+			if tc.expectedCode != "" {
+				checkErrorCode(t, rec, tc.expectedCode)
+				return
+			}
+
+			var body map[string]any
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+			if body["acknowledged_at"] == nil {
+				t.Error("Expected acknowledged_at to be set")
+			}
+			if _, ok := body["token"]; ok {
+				t.Error("Expected the token not to be echoed back")
+			}
+		})
+	}
+}
+
+func TestHandlerSnoozeReminder(t *testing.T) {
+	testCases := []struct {
+		name           string
+		query          string
+		serviceErr     error
+		expectedDays   int
+		expectedStatus int
+		expectedCode   string
+	}{
+		{name: "DefaultDays", expectedDays: reminders.DefaultSnoozeDays, expectedStatus: http.StatusOK},
+		{name: "GivenDays", query: "?days=3", expectedDays: 3, expectedStatus: http.StatusOK},
+		{name: "NotANumber", query: "?days=soon", expectedStatus: http.StatusBadRequest, expectedCode: response.CodeInvalidRequest},
+		{name: "TooManyDays", query: "?days=31", expectedStatus: http.StatusBadRequest, expectedCode: response.CodeInvalidRequest},
+		{
+			name:           "AlreadyAcknowledged",
+			query:          "?days=2",
+			serviceErr:     errors.New("reminder already acknowledged"),
+			expectedDays:   2,
+			expectedStatus: http.StatusConflict,
+			expectedCode:   response.CodeReminderAcknowledged,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			mockService := &MockService{}
+			handler := reminders.NewHandler(mockService)
+			called := false
+			mockService.snoozeReminderFunc = func(ctx context.Context, token string, days int) (*models.TripReminder, error) {
+				called = true
+				if days != tc.expectedDays {
+					t.Errorf("Expected %d days, got %d", tc.expectedDays, days)
+				}
+				if tc.serviceErr != nil {
+					return nil, tc.serviceErr
+				}
+				return &models.TripReminder{ID: uuid.New(), RemindAt: time.Now().AddDate(0, 0, days)}, nil
+			}
+
+			c, rec := newTestContext("/api/reminders/reminder-token/snooze"+tc.query, "reminder-token")
+
+			// Execute
+			if err := handler.SnoozeReminder(c); err != nil {
+				t.Errorf("Expected no error, got: %v", err)
+			}
+
+			// Verify
+			if rec.Code != tc.expectedStatus {
+				t.Fatalf("Expected status %d, got %d", tc.expectedStatus, rec.Code)
+			}
+			if called != (tc.expectedDays != 0) {
+				t.Errorf("Expected service called=%v, got %v", tc.expectedDays != 0, called)
+			}
+			if tc.expectedCode != "" {
+				checkErrorCode(t, rec, tc.expectedCode)
+			}
+		})
+	}
+}
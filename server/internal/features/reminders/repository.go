@@ -0,0 +1,17 @@
+package reminders
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"black-lotus/internal/domain/models"
+)
+
+type Repository interface {
+	// GetReminderByToken ignores reminders of deleted trips
+	GetReminderByToken(ctx context.Context, token string) (*models.TripReminder, error)
+	AcknowledgeReminder(ctx context.Context, reminderID uuid.UUID) (*models.TripReminder, error)
+	SnoozeReminder(ctx context.Context, reminderID uuid.UUID, until time.Time) (*models.TripReminder, error)
+}
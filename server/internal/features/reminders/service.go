@@ -0,0 +1,66 @@
+package reminders
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"black-lotus/internal/domain/models"
+)
+
+const (
+	DefaultSnoozeDays = 1  // How long a reminder is snoozed when days isn't given
+	MaxSnoozeDays     = 30 // Longest a reminder can be snoozed at once
+)
+
+type ServiceInterface interface {
+	AcknowledgeReminder(ctx context.Context, token string) (*models.TripReminder, error)
+	SnoozeReminder(ctx context.Context, token string, days int) (*models.TripReminder, error)
+}
+
+type Service struct {
+	repo Repository
+	now  func() time.Time
+}
+
+// NewService creates a reminder service. now is its clock; nil uses time.Now.
+func NewService(repo Repository, now func() time.Time) *Service {
+	if now == nil {
+		now = time.Now
+	}
+	return &Service{repo: repo, now: now}
+}
+
+// AcknowledgeReminder marks a reminder handled so it is never sent again.
+// Acknowledging twice is harmless, since the link may be followed more than once.
+func (s *Service) AcknowledgeReminder(ctx context.Context, token string) (*models.TripReminder, error) {
+	reminder, err := s.repo.GetReminderByToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	if reminder.AcknowledgedAt != nil {
+		return reminder, nil
+	}
+
+	return s.repo.AcknowledgeReminder(ctx, reminder.ID)
+}
+
+// SnoozeReminder sends a reminder again days from now, unless it has been
+// acknowledged. A later snooze replaces an earlier one.
+func (s *Service) SnoozeReminder(ctx context.Context, token string, days int) (*models.TripReminder, error) {
+	if days < 1 || days > MaxSnoozeDays {
+		return nil, errors.New("invalid snooze days")
+	}
+
+	reminder, err := s.repo.GetReminderByToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	if reminder.AcknowledgedAt != nil {
+		return nil, errors.New("reminder already acknowledged")
+	}
+
+	return s.repo.SnoozeReminder(ctx, reminder.ID, s.now().AddDate(0, 0, days))
+}
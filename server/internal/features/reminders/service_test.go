@@ -0,0 +1,178 @@
+package reminders_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"black-lotus/internal/domain/models"
+	"black-lotus/internal/features/reminders"
+)
+
+// MockRepository implements reminders.Repository for testing
+type MockRepository struct {
+	getReminderByTokenFunc  func(ctx context.Context, token string) (*models.TripReminder, error)
+	acknowledgeReminderFunc func(ctx context.Context, reminderID uuid.UUID) (*models.TripReminder, error)
+	snoozeReminderFunc      func(ctx context.Context, reminderID uuid.UUID, until time.Time) (*models.TripReminder, error)
+}
+
+func (m *MockRepository) GetReminderByToken(ctx context.Context, token string) (*models.TripReminder, error) {
+	if m.getReminderByTokenFunc != nil {
+		return m.getReminderByTokenFunc(ctx, token)
+	}
+	return nil, errors.New("GetReminderByToken not implemented")
+}
+
+func (m *MockRepository) AcknowledgeReminder(ctx context.Context, reminderID uuid.UUID) (*models.TripReminder, error) {
+	if m.acknowledgeReminderFunc != nil {
+		return m.acknowledgeReminderFunc(ctx, reminderID)
+	}
+	return nil, errors.New("AcknowledgeReminder not implemented")
+}
+
+func (m *MockRepository) SnoozeReminder(ctx context.Context, reminderID uuid.UUID, until time.Time) (*models.TripReminder, error) {
+	if m.snoozeReminderFunc != nil {
+		return m.snoozeReminderFunc(ctx, reminderID, until)
+	}
+	return nil, errors.New("SnoozeReminder not implemented")
+}
+
+// Helper function to setup a service whose repository holds one reminder,
+// found by the token "valid-token" and updated in place
+func setupServiceTest(now time.Time, reminder *models.TripReminder) (*reminders.Service, *MockRepository) {
+	mockRepo := &MockRepository{}
+
+	mockRepo.getReminderByTokenFunc = func(ctx context.Context, token string) (*models.TripReminder, error) {
+		if token != "valid-token" {
+			return nil, errors.New("reminder not found")
+		}
+		copied := *reminder
+		return &copied, nil
+	}
+	mockRepo.acknowledgeReminderFunc = func(ctx context.Context, reminderID uuid.UUID) (*models.TripReminder, error) {
+		reminder.AcknowledgedAt = &now
+		copied := *reminder
+		return &copied, nil
+	}
+	mockRepo.snoozeReminderFunc = func(ctx context.Context, reminderID uuid.UUID, until time.Time) (*models.TripReminder, error) {
+		reminder.RemindAt = until
+		copied := *reminder
+		return &copied, nil
+	}
+
+	return reminders.NewService(mockRepo, func() time.Time { return now }), mockRepo
+}
+
+func TestServiceAcknowledgeReminder(t *testing.T) {
+	now := time.Date(2030, 6, 1, 15, 0, 0, 0, time.UTC)
+	earlier := now.Add(-time.Hour)
+
+	testCases := []struct {
+		name              string
+		token             string
+		acknowledgedAt    *time.Time
+		expectedAckedAt   time.Time
+		expectRepoUpdated bool
+		expectedError     string
+	}{
+		{name: "MarksHandled", token: "valid-token", expectedAckedAt: now, expectRepoUpdated: true},
+		{name: "AlreadyAcknowledged", token: "valid-token", acknowledgedAt: &earlier, expectedAckedAt: earlier},
+		{name: "UnknownToken", token: "other-token", expectedError: "reminder not found"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			reminder := &models.TripReminder{ID: uuid.New(), TripID: uuid.New(), RemindAt: now, SentAt: &now, AcknowledgedAt: tc.acknowledgedAt}
+			service, mockRepo := setupServiceTest(now, reminder)
+			updated := false
+			acknowledge := mockRepo.acknowledgeReminderFunc
+			mockRepo.acknowledgeReminderFunc = func(ctx context.Context, reminderID uuid.UUID) (*models.TripReminder, error) {
+				updated = true
+				if reminderID != reminder.ID {
+					t.Errorf("Expected reminder %s, got %s", reminder.ID, reminderID)
+				}
+				return acknowledge(ctx, reminderID)
+			}
+
+			// Execute
+			result, err := service.AcknowledgeReminder(context.Background(), tc.token)
+
+			// Verify
+			if tc.expectedError != "" {
+				if err == nil || err.Error() != tc.expectedError {
+					t.Fatalf("Expected error '%s', got %v", tc.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if updated != tc.expectRepoUpdated {
+				t.Errorf("Expected repository update=%v, got %v", tc.expectRepoUpdated, updated)
+			}
+			if result.AcknowledgedAt == nil || !result.AcknowledgedAt.Equal(tc.expectedAckedAt) {
+				t.Errorf("Expected the reminder acknowledged at %v, got %v", tc.expectedAckedAt, result.AcknowledgedAt)
+			}
+		})
+	}
+}
+
+func TestServiceSnoozeReminder(t *testing.T) {
+	now := time.Date(2030, 6, 1, 15, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name             string
+		token            string
+		days             int
+		acknowledged     bool
+		expectedRemindAt time.Time
+		expectedError    string
+	}{
+		{name: "Reschedules", token: "valid-token", days: 3, expectedRemindAt: now.AddDate(0, 0, 3)},
+		{name: "MaxDays", token: "valid-token", days: reminders.MaxSnoozeDays, expectedRemindAt: now.AddDate(0, 0, reminders.MaxSnoozeDays)},
+		{name: "ZeroDays", token: "valid-token", days: 0, expectedError: "invalid snooze days"},
+		{name: "TooManyDays", token: "valid-token", days: reminders.MaxSnoozeDays + 1, expectedError: "invalid snooze days"},
+		{name: "AlreadyAcknowledged", token: "valid-token", days: 1, acknowledged: true, expectedError: "reminder already acknowledged"},
+		{name: "UnknownToken", token: "other-token", days: 1, expectedError: "reminder not found"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup: the reminder was sent an hour ago
+			sentAt := now.Add(-time.Hour)
+			reminder := &models.TripReminder{ID: uuid.New(), TripID: uuid.New(), RemindAt: sentAt, SentAt: &sentAt}
+			if tc.acknowledged {
+				reminder.AcknowledgedAt = &now
+			}
+			service, _ := setupServiceTest(now, reminder)
+
+			// Execute
+			result, err := service.SnoozeReminder(context.Background(), tc.token, tc.days)
+
+			// Verify
+			if tc.expectedError != "" {
+				if err == nil || err.Error() != tc.expectedError {
+					t.Fatalf("Expected error '%s', got %v", tc.expectedError, err)
+				}
+				if !reminder.RemindAt.Equal(sentAt) {
+					t.Errorf("Expected the reminder not to be rescheduled, got %v", reminder.RemindAt)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if !result.RemindAt.Equal(tc.expectedRemindAt) {
+				t.Errorf("Expected the reminder rescheduled to %v, got %v", tc.expectedRemindAt, result.RemindAt)
+			}
+			// Due again: rescheduled past when it was last sent
+			if !result.SentAt.Before(result.RemindAt) {
+				t.Errorf("Expected remind_at %v after sent_at %v", result.RemindAt, result.SentAt)
+			}
+		})
+	}
+}
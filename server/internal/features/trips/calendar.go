@@ -0,0 +1,36 @@
+package trips
+
+import (
+	"black-lotus/internal/domain/models"
+	"black-lotus/pkg/ics"
+)
+
+// calendarProductID identifies this server as the producer of exported calendars
+const calendarProductID = "-//Black Lotus//Trips//EN"
+
+// RenderTripCalendar renders trips as an iCalendar document with one event per
+// trip. Trips without dates have nothing to put on a calendar and are skipped.
+// Trips are all-day events from the first day through the last.
+func RenderTripCalendar(name string, trips []*models.Trip) []byte {
+	calendar := ics.Calendar{ProductID: calendarProductID, Name: name}
+
+	for _, trip := range trips {
+		if trip.StartDate == nil || trip.EndDate == nil {
+			continue
+		}
+
+		calendar.Events = append(calendar.Events, ics.Event{
+			// Stable per trip, so re-importing updates the event rather than duplicating it
+			UID:         trip.ID.String() + "@black-lotus",
+			Summary:     trip.Name,
+			Description: trip.Description,
+			Location:    trip.Location,
+			Start:       models.TripDay(*trip.StartDate),
+			End:         models.TripDayEnd(*trip.EndDate),
+			AllDay:      true,
+			Stamp:       trip.UpdatedAt,
+		})
+	}
+
+	return calendar.Marshal()
+}
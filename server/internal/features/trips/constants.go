@@ -0,0 +1,19 @@
+package trips
+
+import (
+	"time"
+
+	"black-lotus/internal/common/pagination"
+)
+
+const (
+	TripRestoreWindow   = 30 * 24 * time.Hour        // Soft-deleted trips can be restored for 30 days
+	MaxBulkTrips        = 50                         // Most trips a single bulk create may contain
+	MaxCurrentTrips     = 10                         // Most overlapping ongoing trips /current returns
+	DefaultUpcomingDays = 7                          // How far ahead /upcoming looks when within isn't given
+	MaxUpcomingDays     = 365                        // Furthest ahead /upcoming may look, in days
+	MaxCalendarTrips    = 500                        // Most trips the calendar feed includes
+	ExportPageSize      = 100                        // Trips fetched per query while streaming an account export
+	MaxImportFileSize   = 512 << 10                  // Largest CSV file an import accepts, in bytes
+	DefaultTripPageSize = pagination.DefaultPageSize // Trips per page when a service caller doesn't give a limit
+)
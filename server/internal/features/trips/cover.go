@@ -0,0 +1,42 @@
+package trips
+
+import (
+	"net/url"
+	"os"
+	"strings"
+)
+
+// DefaultCoverURLTemplate serves a stable placeholder photo for each seed
+const DefaultCoverURLTemplate = "https://picsum.photos/seed/{seed}/1200/600"
+
+// CoverSource supplies fallback cover images for trips without one. The same
+// location must always give the same URL, so a trip's cover doesn't change
+// between requests.
+type CoverSource interface {
+	CoverURL(location string) string
+}
+
+// PlaceholderCoverSource fills {seed} in URLTemplate with the normalized location
+type PlaceholderCoverSource struct {
+	URLTemplate string
+}
+
+// CoverSourceFromEnv reads TRIP_COVER_URL_TEMPLATE, falling back to
+// DefaultCoverURLTemplate when it is unset or has no {seed} placeholder
+func CoverSourceFromEnv() PlaceholderCoverSource {
+	template := os.Getenv("TRIP_COVER_URL_TEMPLATE")
+	if !strings.Contains(template, "{seed}") {
+		template = DefaultCoverURLTemplate
+	}
+	return PlaceholderCoverSource{URLTemplate: template}
+}
+
+// CoverURL is case- and whitespace-insensitive in the location. Trips without
+// a location share a generic cover.
+func (s PlaceholderCoverSource) CoverURL(location string) string {
+	seed := strings.ReplaceAll(normalizeLocation(location), " ", "-")
+	if seed == "" {
+		seed = "trip"
+	}
+	return strings.ReplaceAll(s.URLTemplate, "{seed}", url.PathEscape(seed))
+}
@@ -0,0 +1,116 @@
+package trips
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"black-lotus/internal/domain/models"
+)
+
+// tripCSVRow is a parsed data row and the line it started on
+type tripCSVRow struct {
+	line  int
+	input models.CreateTripInput
+}
+
+// parseTripCSV reads trips in the layout the CSV export writes. The first line
+// is a header naming the columns; columns are matched by name in any order
+// and unknown ones are ignored. Dates are RFC3339 or YYYY-MM-DD, and rows with
+// both dates empty are imported as wishlist trips. Rows that can't be read are
+// returned as row errors; a malformed file or header is an error.
+func parseTripCSV(r io.Reader, maxRows int) ([]tripCSVRow, []models.TripRowError, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil, errors.New("CSV file is empty")
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid CSV: %w", err)
+	}
+
+	columns := make(map[string]int)
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	known := false
+	for _, name := range tripCSVHeader {
+		if _, ok := columns[name]; ok {
+			known = true
+		}
+	}
+	if !known {
+		return nil, nil, fmt.Errorf("CSV header must name the columns: %s", strings.Join(tripCSVHeader, ", "))
+	}
+
+	field := func(record []string, name string) string {
+		if i, ok := columns[name]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	var rows []tripCSVRow
+	var rowErrors []models.TripRowError
+	for dataRows := 0; ; dataRows++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+
+		if dataRows >= maxRows {
+			return nil, nil, fmt.Errorf("a CSV import may contain at most %d trips", maxRows)
+		}
+
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) && parseErr.Err == csv.ErrFieldCount {
+			// The row is still readable, just ragged; report it and carry on
+			rowErrors = append(rowErrors, models.TripRowError{Line: parseErr.StartLine, Message: "wrong number of fields"})
+			continue
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid CSV: %w", err)
+		}
+
+		line, _ := reader.FieldPos(0)
+		row := tripCSVRow{line: line, input: models.CreateTripInput{
+			Name:        field(record, "name"),
+			Description: field(record, "description"),
+			Location:    field(record, "location"),
+		}}
+
+		startValue, endValue := field(record, "start_date"), field(record, "end_date")
+		startDate, startErr := parseCSVDate(startValue)
+		if startErr != nil {
+			rowErrors = append(rowErrors, models.TripRowError{Line: line, Field: "start_date", Message: "use RFC3339 or YYYY-MM-DD"})
+		}
+		endDate, endErr := parseCSVDate(endValue)
+		if endErr != nil {
+			rowErrors = append(rowErrors, models.TripRowError{Line: line, Field: "end_date", Message: "use RFC3339 or YYYY-MM-DD"})
+		}
+		if startErr != nil || endErr != nil {
+			continue
+		}
+
+		row.input.StartDate, row.input.EndDate = startDate, endDate
+		row.input.IsWishlist = startValue == "" && endValue == ""
+		row.input.Normalize()
+		rows = append(rows, row)
+	}
+
+	return rows, rowErrors, nil
+}
+
+// parseCSVDate parses an optional date cell; an empty cell is the zero time
+func parseCSVDate(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	return parseDateParam(value, false)
+}
@@ -0,0 +1,20 @@
+package trips
+
+// ValidationError is trip input the service rejected. Field is the JSON name
+// of the offending field, so handlers can report it under details.
+type ValidationError struct {
+	Field   string
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return e.Message
+}
+
+var (
+	// ErrDatesRequired is returned when a planned trip is missing a date
+	ErrDatesRequired = &ValidationError{Field: "start_date", Message: "start and end dates are required"}
+
+	// ErrEndBeforeStart is returned when a trip's dates are out of order
+	ErrEndBeforeStart = &ValidationError{Field: "end_date", Message: "end date cannot be before start date"}
+)
@@ -0,0 +1,134 @@
+package trips
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"time"
+
+	"black-lotus/internal/domain/models"
+)
+
+// Account export formats accepted by GET /api/trips/export
+const (
+	ExportFormatJSON = "json"
+	ExportFormatCSV  = "csv"
+)
+
+// tripCSVHeader names the columns of a CSV trip export, in order
+var tripCSVHeader = []string{"name", "description", "start_date", "end_date", "location"}
+
+// tripStreamWriter writes an account export a page of trips at a time, so the
+// whole result set never has to be held in memory. WriteTrips stops with the
+// context's error once it is cancelled, e.g. when the client disconnects.
+type tripStreamWriter interface {
+	WriteTrips(ctx context.Context, trips []*models.Trip) error
+	Close() error
+}
+
+// newTripStreamWriter returns the writer for format and its Content-Type
+func newTripStreamWriter(format string, w io.Writer) (tripStreamWriter, string) {
+	if format == ExportFormatCSV {
+		return &csvTripWriter{w: csv.NewWriter(w)}, "text/csv; charset=utf-8"
+	}
+	return &jsonTripWriter{w: w}, "application/json"
+}
+
+// jsonTripWriter writes a single JSON array, element by element
+type jsonTripWriter struct {
+	w       io.Writer
+	started bool
+}
+
+func (j *jsonTripWriter) WriteTrips(ctx context.Context, trips []*models.Trip) error {
+	for _, trip := range trips {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		separator := ","
+		if !j.started {
+			separator, j.started = "[", true
+		}
+
+		data, err := json.Marshal(trip)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(j.w, separator); err != nil {
+			return err
+		}
+		if _, err := j.w.Write(data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (j *jsonTripWriter) Close() error {
+	closing := "]"
+	if !j.started {
+		closing = "[]"
+	}
+	_, err := io.WriteString(j.w, closing)
+	return err
+}
+
+// csvTripWriter writes a header row followed by one row per trip. Dates are
+// RFC 3339 in UTC and empty for wishlist trips without dates.
+type csvTripWriter struct {
+	w       *csv.Writer
+	started bool
+}
+
+func (c *csvTripWriter) WriteTrips(ctx context.Context, trips []*models.Trip) error {
+	if err := c.writeHeader(); err != nil {
+		return err
+	}
+
+	for _, trip := range trips {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if err := c.w.Write([]string{
+			trip.Name,
+			trip.Description,
+			csvTime(trip.StartDate),
+			csvTime(trip.EndDate),
+			trip.Location,
+		}); err != nil {
+			return err
+		}
+	}
+
+	c.w.Flush()
+	return c.w.Error()
+}
+
+func (c *csvTripWriter) Close() error {
+	// An empty export still gets its header row
+	if err := c.writeHeader(); err != nil {
+		return err
+	}
+
+	c.w.Flush()
+	return c.w.Error()
+}
+
+func (c *csvTripWriter) writeHeader() error {
+	if c.started {
+		return nil
+	}
+
+	c.started = true
+	return c.w.Write(tripCSVHeader)
+}
+
+func csvTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(models.TripDateLayout)
+}
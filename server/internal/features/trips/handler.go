@@ -1,25 +1,39 @@
 package trips
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"log"
+	"io"
+	"log/slog"
 	"net/http"
 	"reflect"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 
+	"black-lotus/internal/common/binding"
+	appmiddleware "black-lotus/internal/common/middleware"
+	"black-lotus/internal/common/pagination"
+	"black-lotus/internal/common/params"
+	"black-lotus/internal/common/response"
+	validation "black-lotus/internal/common/validations"
 	"black-lotus/internal/domain/models"
 	"black-lotus/internal/features/auth/session"
+	"black-lotus/pkg/ics"
 )
 
 type Handler struct {
 	service        ServiceInterface
 	sessionService session.ServiceInterface
 	validator      *validator.Validate
+	pages          pagination.Config
+	queryTimeout   time.Duration
 }
 
 func NewHandler(service ServiceInterface, sessionService session.ServiceInterface) *Handler {
@@ -34,361 +48,1314 @@ func NewHandler(service ServiceInterface, sessionService session.ServiceInterfac
 		return name
 	})
 
+	// Which of location and description are required varies per deployment
+	validation.RegisterTripValidators(validate, validation.TripFieldRulesFromEnv())
+
 	return &Handler{
 		service:        service,
 		sessionService: sessionService,
 		validator:      validate,
+		pages:          pagination.ConfigFromEnv(),
+		queryTimeout:   appmiddleware.RequestTimeoutFromEnv(),
 	}
 }
 
-// CreateTrip creates a new trip for the authenticated user
-func (h *Handler) CreateTrip(ctx echo.Context) error {
-	// Get access token from cookie
-	accessCookie, err := ctx.Cookie("access_token")
-	if err != nil {
-		// No access token - check if there's a refresh token
-		_, refreshErr := ctx.Cookie("refresh_token")
-		if refreshErr != nil {
-			return ctx.JSON(http.StatusUnauthorized, map[string]string{
-				"error": "Not authenticated",
-			})
-		}
+// authenticate validates the access token cookie and returns the caller's session.
+// When it returns a nil session the error response has already been written.
+func (h *Handler) authenticate(ctx echo.Context) (*models.Session, error) {
+	return session.Authenticate(ctx, h.sessionService)
+}
 
-		// Has refresh token but no access token - client should refresh
-		return ctx.JSON(http.StatusUnauthorized, map[string]string{
-			"error": "Access token expired",
-			"code":  "token_expired",
-		})
+// validationErrorResponse renders a service ValidationError as a 400 with the
+// message under the offending field. Out-of-order dates keep their own code.
+func validationErrorResponse(ctx echo.Context, err *ValidationError) error {
+	code := response.CodeValidationFailed
+	if err == ErrEndBeforeStart {
+		code = response.CodeInvalidDateRange
 	}
 
-	// Validate access token
-	session, err := h.sessionService.ValidateAccessToken(ctx.Request().Context(), accessCookie.Value)
-	if err != nil {
-		return ctx.JSON(http.StatusUnauthorized, map[string]string{
-			"error": "Invalid access token",
-			"code":  "token_invalid",
-		})
+	return response.ErrorResponse(ctx, http.StatusBadRequest,
+		code, "Invalid request body", map[string]string{err.Field: err.Message})
+}
+
+// CreateTrip creates a new trip for the authenticated user
+func (h *Handler) CreateTrip(ctx echo.Context) error {
+	session, err := h.authenticate(ctx)
+	if session == nil {
+		return err
 	}
 
 	// Parse request body
 	var input models.CreateTripInput
-	if err := ctx.Bind(&input); err != nil {
-		return ctx.JSON(http.StatusBadRequest, map[string]string{
-			"error": "Invalid request body",
-		})
+	if err := binding.Bind(ctx, &input); err != nil {
+		return response.InvalidBody(ctx, err)
 	}
 
 	// Validate the input
 	if err := h.validator.Struct(input); err != nil {
 		// Extract validation errors
 		if validationErrors, ok := err.(validator.ValidationErrors); ok {
-			errorMessages := make(map[string]string)
-
-			for _, e := range validationErrors {
-				switch e.Tag() {
-				case "required":
-					errorMessages[e.Field()] = fmt.Sprintf("%s is required", e.Field())
-				default:
-					errorMessages[e.Field()] = fmt.Sprintf("%s is invalid", e.Field())
-				}
-			}
-
-			return ctx.JSON(http.StatusBadRequest, map[string]interface{}{
-				"error":   "Invalid request body",
-				"details": errorMessages,
-			})
+			messages := validation.MessagesFor(ctx.Request().Header.Get("Accept-Language"))
+			return response.ErrorResponse(ctx, http.StatusBadRequest,
+				response.CodeValidationFailed, messages.InvalidBody(), messages.Details(validationErrors))
 		}
 
-		return ctx.JSON(http.StatusBadRequest, map[string]string{
-			"error": "Invalid request body",
-		})
+		return response.ErrorResponse(ctx, http.StatusBadRequest,
+			response.CodeInvalidRequest, "Invalid request body", nil)
 	}
 
 	// Create the trip
 	trip, err := h.service.CreateTrip(ctx.Request().Context(), session.UserID, input)
 	if err != nil {
-		log.Printf("Failed to create trip: %v", err)
-
-		// Handle specific business logic errors
-		if err.Error() == "end date cannot be before start date" {
-			return ctx.JSON(http.StatusBadRequest, map[string]string{
-				"error": "Invalid request body",
-			})
+		var validationErr *ValidationError
+		if errors.As(err, &validationErr) {
+			return validationErrorResponse(ctx, validationErr)
 		}
 
+		slog.Error("Failed to create trip", "user_id", session.UserID, "error", err)
+
 		// For consistency with tests, return 500 for NonValidationError
-		return ctx.JSON(http.StatusInternalServerError, map[string]string{
-			"error": "Failed to create trip",
-		})
+		return response.ErrorResponse(ctx, http.StatusInternalServerError,
+			response.CodeInternal, "Failed to create trip", nil)
 	}
 
-	return ctx.JSON(http.StatusCreated, trip)
+	return response.JSON(ctx, http.StatusCreated, trip)
+}
+
+// CreateTrips creates up to MaxBulkTrips trips in one all-or-nothing request
+func (h *Handler) CreateTrips(ctx echo.Context) error {
+	session, err := h.authenticate(ctx)
+	if session == nil {
+		return err
+	}
+
+	// Parse request body
+	var inputs []models.CreateTripInput
+	if err := binding.Bind(ctx, &inputs); err != nil {
+		return response.InvalidBody(ctx, err)
+	}
+
+	if len(inputs) == 0 || len(inputs) > MaxBulkTrips {
+		return response.ErrorResponse(ctx, http.StatusBadRequest, response.CodeInvalidRequest,
+			fmt.Sprintf("A bulk create must contain between 1 and %d trips", MaxBulkTrips), nil)
+	}
+
+	// Validate every trip so all problems are reported at once
+	messages := validation.MessagesFor(ctx.Request().Header.Get("Accept-Language"))
+	var batchErrors []models.TripBatchError
+	for i, input := range inputs {
+		if err := h.validator.Struct(input); err != nil {
+			validationErrors, ok := err.(validator.ValidationErrors)
+			if !ok {
+				return response.ErrorResponse(ctx, http.StatusBadRequest,
+					response.CodeInvalidRequest, "Invalid request body", nil)
+			}
+
+			for _, e := range validationErrors {
+				message := messages.FieldMessage(e)
+				batchErrors = append(batchErrors, models.TripBatchError{
+					Index:   i,
+					Field:   e.Field(),
+					Message: message,
+				})
+			}
+		}
+	}
+
+	if len(batchErrors) > 0 {
+		return response.ErrorResponse(ctx, http.StatusBadRequest,
+			response.CodeValidationFailed, "Invalid trips in batch", batchErrors)
+	}
+
+	trips, batchErrors, err := h.service.CreateTrips(ctx.Request().Context(), session.UserID, inputs)
+	if err != nil {
+		slog.Error("Failed to create trips", "user_id", session.UserID, "count", len(inputs), "error", err)
+		return response.ErrorResponse(ctx, http.StatusInternalServerError,
+			response.CodeInternal, "Failed to create trips", nil)
+	}
+
+	if len(batchErrors) > 0 {
+		return response.ErrorResponse(ctx, http.StatusBadRequest,
+			response.CodeValidationFailed, "Invalid trips in batch", batchErrors)
+	}
+
+	return response.JSON(ctx, http.StatusCreated, trips)
 }
 
 // GetTrip retrieves a specific trip by ID
 func (h *Handler) GetTrip(ctx echo.Context) error {
-	// Get access token from cookie
-	accessCookie, err := ctx.Cookie("access_token")
-	if err != nil {
-		// No access token - check if there's a refresh token
-		_, refreshErr := ctx.Cookie("refresh_token")
-		if refreshErr != nil {
-			return ctx.JSON(http.StatusUnauthorized, map[string]string{
-				"error": "Not authenticated",
-			})
+	session, err := h.authenticate(ctx)
+	if session == nil {
+		return err
+	}
+
+	tripID, ok, err := params.TripID(ctx)
+	if !ok {
+		return err
+	}
+
+	includeDeleted := false
+	if includeParam := ctx.QueryParam("include_deleted"); includeParam != "" {
+		includeDeleted, err = strconv.ParseBool(includeParam)
+		if err != nil {
+			return response.ErrorResponse(ctx, http.StatusBadRequest,
+				response.CodeInvalidRequest, "Invalid include_deleted flag", nil)
 		}
+	}
 
-		// Has refresh token but no access token - client should refresh
-		return ctx.JSON(http.StatusUnauthorized, map[string]string{
-			"error": "Access token expired",
-			"code":  "token_expired",
-		})
+	photosMode := ctx.QueryParam("photos")
+	if photosMode != models.TripPhotosNone && photosMode != models.TripPhotosCount && photosMode != models.TripPhotosList {
+		return response.ErrorResponse(ctx, http.StatusBadRequest,
+			response.CodeInvalidRequest, "photos must be count or list", nil)
 	}
 
-	// Validate access token
-	session, err := h.sessionService.ValidateAccessToken(ctx.Request().Context(), accessCookie.Value)
-	if err != nil {
-		return ctx.JSON(http.StatusUnauthorized, map[string]string{
-			"error": "Invalid access token",
-			"code":  "token_invalid",
-		})
+	// Get the trip, from the trash too if the owner asked for it
+	getTrip := h.service.GetTripByID
+	if includeDeleted {
+		getTrip = h.service.GetTripIncludingDeleted
 	}
 
-	// Parse trip ID from URL
-	tripID, err := uuid.Parse(ctx.Param("id"))
+	trip, err := getTrip(ctx.Request().Context(), tripID, session.UserID)
 	if err != nil {
-		return ctx.JSON(http.StatusBadRequest, map[string]string{
-			"error": "Invalid trip ID",
-		})
+		if err.Error() == "trip not found" {
+			return response.ErrorResponse(ctx, http.StatusNotFound,
+				response.CodeTripNotFound, "Trip not found", nil)
+		}
+		if err.Error() == "unauthorized access to trip" {
+			return response.ErrorResponse(ctx, http.StatusForbidden,
+				response.CodeForbidden, "You do not have permission to view this trip", nil)
+		}
+
+		slog.Error("Failed to get trip", "trip_id", tripID, "error", err)
+		return response.ErrorResponse(ctx, http.StatusInternalServerError,
+			response.CodeInternal, "Failed to get trip", nil)
 	}
 
-	// Get the trip
-	trip, err := h.service.GetTripByID(ctx.Request().Context(), tripID, session.UserID)
+	// Photo changes bump the trip's updated_at, so the version only needs
+	// to tell the photos modes apart
+	version := tripVersion(trip)
+	if photosMode != models.TripPhotosNone {
+		version = append(version, "photos="+photosMode)
+	}
+	if notModified, err := response.NotModified(ctx, response.ETag(version...)); notModified {
+		return err
+	}
+
+	if photosMode != models.TripPhotosNone {
+		if err := h.service.AttachTripPhotos(ctx.Request().Context(), trip, photosMode); err != nil {
+			slog.Error("Failed to get trip photos", "trip_id", tripID, "error", err)
+			return response.ErrorResponse(ctx, http.StatusInternalServerError,
+				response.CodeInternal, "Failed to get trip", nil)
+		}
+	}
+
+	return response.JSON(ctx, http.StatusOK, trip)
+}
+
+// GetTripWithUser retrieves a trip with its owner embedded under "user"
+func (h *Handler) GetTripWithUser(ctx echo.Context) error {
+	session, err := h.authenticate(ctx)
+	if session == nil {
+		return err
+	}
+
+	tripID, ok, err := params.TripID(ctx)
+	if !ok {
+		return err
+	}
+
+	trip, err := h.service.GetTripWithUser(ctx.Request().Context(), tripID, session.UserID)
 	if err != nil {
 		if err.Error() == "trip not found" {
-			return ctx.JSON(http.StatusNotFound, map[string]string{
-				"error": "Trip not found",
-			})
+			return response.ErrorResponse(ctx, http.StatusNotFound,
+				response.CodeTripNotFound, "Trip not found", nil)
 		}
 		if err.Error() == "unauthorized access to trip" {
-			return ctx.JSON(http.StatusForbidden, map[string]string{
-				"error": "You do not have permission to view this trip",
-			})
+			return response.ErrorResponse(ctx, http.StatusForbidden,
+				response.CodeForbidden, "You do not have permission to view this trip", nil)
 		}
 
-		log.Printf("Failed to get trip: %v", err)
-		return ctx.JSON(http.StatusInternalServerError, map[string]string{
-			"error": "Failed to get trip",
-		})
+		slog.Error("Failed to get trip with user", "trip_id", tripID, "error", err)
+		return response.ErrorResponse(ctx, http.StatusInternalServerError,
+			response.CodeInternal, "Failed to get trip", nil)
 	}
 
-	return ctx.JSON(http.StatusOK, trip)
+	return response.JSON(ctx, http.StatusOK, trip)
 }
 
-// GetUserTrips retrieves all trips for the authenticated user
+// GetUserTrips retrieves all trips for the authenticated user. With a cursor
+// parameter (empty for the first page) it pages by cursor instead of offset
+// and wraps the trips in a models.TripPage.
 func (h *Handler) GetUserTrips(ctx echo.Context) error {
-	// Get access token from cookie
-	accessCookie, err := ctx.Cookie("access_token")
+	session, err := h.authenticate(ctx)
+	if session == nil {
+		return err
+	}
+
+	// Parse pagination parameters
+	limit, offset, err := h.pages.Parse(ctx)
 	if err != nil {
-		// No access token - check if there's a refresh token
-		_, refreshErr := ctx.Cookie("refresh_token")
-		if refreshErr != nil {
-			return ctx.JSON(http.StatusUnauthorized, map[string]string{
-				"error": "Not authenticated",
-			})
+		return response.ErrorResponse(ctx, http.StatusBadRequest,
+			response.CodeInvalidRequest, err.Error(), nil)
+	}
+
+	useCursor := ctx.QueryParams().Has("cursor")
+	if useCursor && ctx.QueryParam("offset") != "" {
+		return response.ErrorResponse(ctx, http.StatusBadRequest,
+			response.CodeInvalidRequest, "Use either cursor or offset pagination, not both", nil)
+	}
+
+	// Optional filters
+	filter := models.TripFilter{
+		Tag:  ctx.QueryParam("tag"),
+		Sort: ctx.QueryParam("sort"),
+	}
+
+	if wishlistParam := ctx.QueryParam("wishlist"); wishlistParam != "" {
+		wishlist, err := strconv.ParseBool(wishlistParam)
+		if err != nil {
+			return response.ErrorResponse(ctx, http.StatusBadRequest,
+				response.CodeInvalidRequest, "Invalid wishlist filter", nil)
+		}
+		filter.Wishlist = &wishlist
+	}
+
+	if fromParam := ctx.QueryParam("from"); fromParam != "" {
+		from, err := parseDateParam(fromParam, false)
+		if err != nil {
+			return response.ErrorResponse(ctx, http.StatusBadRequest,
+				response.CodeInvalidRequest, "Invalid from date, use RFC3339 or YYYY-MM-DD", nil)
 		}
+		filter.From = &from
+	}
 
-		// Has refresh token but no access token - client should refresh
-		return ctx.JSON(http.StatusUnauthorized, map[string]string{
-			"error": "Access token expired",
-			"code":  "token_expired",
-		})
+	if toParam := ctx.QueryParam("to"); toParam != "" {
+		to, err := parseDateParam(toParam, true)
+		if err != nil {
+			return response.ErrorResponse(ctx, http.StatusBadRequest,
+				response.CodeInvalidRequest, "Invalid to date, use RFC3339 or YYYY-MM-DD", nil)
+		}
+		filter.To = &to
 	}
 
-	// Validate access token
-	session, err := h.sessionService.ValidateAccessToken(ctx.Request().Context(), accessCookie.Value)
+	if filter.From != nil && filter.To != nil && filter.To.Before(*filter.From) {
+		return response.ErrorResponse(ctx, http.StatusBadRequest,
+			response.CodeInvalidDateRange, "The to date cannot be before the from date", nil)
+	}
+
+	filter.Status = ctx.QueryParam("status")
+
+	// Answer polling clients from the cheap version query when nothing changed
+	version, err := h.service.GetTripListVersion(ctx.Request().Context(), session.UserID)
 	if err != nil {
-		return ctx.JSON(http.StatusUnauthorized, map[string]string{
-			"error": "Invalid access token",
-			"code":  "token_invalid",
-		})
+		slog.Error("Failed to get trip list version", "user_id", session.UserID, "error", err)
+		return response.ErrorResponse(ctx, http.StatusInternalServerError,
+			response.CodeInternal, "Failed to get trips", nil)
 	}
 
-	// Parse pagination parameters
-	limit, _ := strconv.Atoi(ctx.QueryParam("limit"))
-	offset, _ := strconv.Atoi(ctx.QueryParam("offset"))
+	etag := tripListETag(session.UserID, ctx.Request().URL.RawQuery, version)
+	if notModified, err := response.NotModified(ctx, etag); notModified {
+		return err
+	}
 
 	// Get the trips
-	trips, err := h.service.GetTripsByUserID(ctx.Request().Context(), session.UserID, limit, offset)
+	var trips interface{}
+	if useCursor {
+		trips, err = h.service.GetTripPage(ctx.Request().Context(), session.UserID, limit, ctx.QueryParam("cursor"), filter)
+	} else {
+		trips, err = h.service.GetTripsByUserID(ctx.Request().Context(), session.UserID, limit, offset, filter)
+	}
 	if err != nil {
-		log.Printf("Failed to get trips: %v", err)
-		return ctx.JSON(http.StatusInternalServerError, map[string]string{
-			"error": "Failed to get trips",
-		})
+		// The ETag above describes a listing, not this error
+		ctx.Response().Header().Del("ETag")
+
+		if err.Error() == "invalid cursor" {
+			return response.ErrorResponse(ctx, http.StatusBadRequest,
+				response.CodeInvalidRequest, "Invalid cursor", nil)
+		}
+		if err.Error() == "cursor pagination cannot be sorted" {
+			return response.ErrorResponse(ctx, http.StatusBadRequest,
+				response.CodeInvalidRequest, "Cursor pagination is always newest first and cannot be combined with sort", nil)
+		}
+		if err.Error() == "invalid sort option" {
+			return response.ErrorResponse(ctx, http.StatusBadRequest,
+				response.CodeInvalidRequest, "Invalid sort option", nil)
+		}
+		if err.Error() == "invalid status filter" {
+			return response.ErrorResponse(ctx, http.StatusBadRequest,
+				response.CodeInvalidRequest, "Invalid status filter, use past, ongoing or upcoming", nil)
+		}
+
+		slog.Error("Failed to get trips", "user_id", session.UserID, "error", err)
+		return response.ErrorResponse(ctx, http.StatusInternalServerError,
+			response.CodeInternal, "Failed to get trips", nil)
 	}
 
-	return ctx.JSON(http.StatusOK, trips)
+	return response.JSON(ctx, http.StatusOK, trips)
 }
 
-// UpdateTrip updates a specific trip by ID
-func (h *Handler) UpdateTrip(ctx echo.Context) error {
-	// Get access token from cookie
-	accessCookie, err := ctx.Cookie("access_token")
-	if err != nil {
-		// No access token - check if there's a refresh token
-		_, refreshErr := ctx.Cookie("refresh_token")
-		if refreshErr != nil {
-			return ctx.JSON(http.StatusUnauthorized, map[string]string{
-				"error": "Not authenticated",
-			})
-		}
+// tripListETag identifies a trip listing by the user's list version and the
+// query that filtered and paged it
+func tripListETag(userID uuid.UUID, query string, version *models.TripListVersion) string {
+	latest := ""
+	if version.LatestUpdate != nil {
+		latest = version.LatestUpdate.UTC().Format(time.RFC3339Nano)
+	}
 
-		// Has refresh token but no access token - client should refresh
-		return ctx.JSON(http.StatusUnauthorized, map[string]string{
-			"error": "Access token expired",
-			"code":  "token_expired",
-		})
+	return response.ETag(
+		userID.String(),
+		query,
+		latest,
+		strconv.Itoa(version.Count),
+		strconv.Itoa(version.Started),
+		strconv.Itoa(version.Ended),
+	)
+}
+
+// parseDateParam accepts an RFC3339 timestamp or a YYYY-MM-DD date. A date
+// covers the whole UTC day, so as an upper bound it means the end of that day.
+func parseDateParam(value string, endOfDay bool) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
 	}
 
-	// Validate access token
-	session, err := h.sessionService.ValidateAccessToken(ctx.Request().Context(), accessCookie.Value)
+	day, err := time.Parse(time.DateOnly, value)
 	if err != nil {
-		return ctx.JSON(http.StatusUnauthorized, map[string]string{
-			"error": "Invalid access token",
-			"code":  "token_invalid",
-		})
+		return time.Time{}, err
 	}
 
-	// Parse trip ID from URL
-	tripID, err := uuid.Parse(ctx.Param("id"))
-	if err != nil {
-		return ctx.JSON(http.StatusBadRequest, map[string]string{
-			"error": "Invalid trip ID",
-		})
+	if endOfDay {
+		return day.AddDate(0, 0, 1).Add(-time.Nanosecond), nil
+	}
+	return day, nil
+}
+
+// tripVersion identifies the state of a trip for its ETag. Adding or removing
+// a tag bumps updated_at through the trip_tags trigger, but tag names live in
+// the tags table, so they are included too. Status is included because it
+// changes with the clock rather than with the row.
+func tripVersion(trip *models.Trip) []string {
+	version := []string{trip.ID.String(), trip.UpdatedAt.UTC().Format(time.RFC3339Nano), trip.Status}
+	for _, tag := range trip.Tags {
+		version = append(version, tag.Name)
+	}
+	return version
+}
+
+// UpdateTrip updates a specific trip by ID
+func (h *Handler) UpdateTrip(ctx echo.Context) error {
+	session, err := h.authenticate(ctx)
+	if session == nil {
+		return err
+	}
+
+	tripID, ok, err := params.TripID(ctx)
+	if !ok {
+		return err
 	}
 
 	// Parse request body
 	var input models.UpdateTripInput
-	if err := ctx.Bind(&input); err != nil {
-		return ctx.JSON(http.StatusBadRequest, map[string]string{
-			"error": "Invalid request body",
-		})
+	if err := binding.Bind(ctx, &input); err != nil {
+		return response.InvalidBody(ctx, err)
 	}
 
 	// Reject empty updates - add this check
 	if input.Name == nil && input.Description == nil &&
 		input.StartDate == nil && input.EndDate == nil &&
-		input.Location == nil {
-		return ctx.JSON(http.StatusBadRequest, map[string]string{
-			"error": "Invalid request body",
-		})
+		input.Location == nil && input.CoverImageURL == nil {
+		return response.ErrorResponse(ctx, http.StatusBadRequest,
+			response.CodeInvalidRequest, "Invalid request body", nil)
 	}
 
 	// Validate the input
 	if err := h.validator.Struct(input); err != nil {
 		// Extract validation errors
 		if validationErrors, ok := err.(validator.ValidationErrors); ok {
-			errorMessages := make(map[string]string)
-
-			for _, e := range validationErrors {
-				errorMessages[e.Field()] = fmt.Sprintf("%s is invalid", e.Field())
-			}
-
-			return ctx.JSON(http.StatusBadRequest, map[string]interface{}{
-				"error":   "Invalid request body",
-				"details": errorMessages,
-			})
+			messages := validation.MessagesFor(ctx.Request().Header.Get("Accept-Language"))
+			return response.ErrorResponse(ctx, http.StatusBadRequest,
+				response.CodeValidationFailed, messages.InvalidBody(), messages.Details(validationErrors))
 		}
 
-		return ctx.JSON(http.StatusBadRequest, map[string]string{
-			"error": "Invalid request body",
-		})
+		return response.ErrorResponse(ctx, http.StatusBadRequest,
+			response.CodeInvalidRequest, "Invalid request body", nil)
 	}
 
 	// Update the trip
 	updatedTrip, err := h.service.UpdateTrip(ctx.Request().Context(), tripID, session.UserID, input)
 	if err != nil {
+		var validationErr *ValidationError
+		if errors.As(err, &validationErr) {
+			return validationErrorResponse(ctx, validationErr)
+		}
+
 		if err.Error() == "unauthorized access to trip" {
-			return ctx.JSON(http.StatusForbidden, map[string]string{
-				"error": "You do not have permission to update this trip",
-			})
+			return response.ErrorResponse(ctx, http.StatusForbidden,
+				response.CodeForbidden, "You do not have permission to update this trip", nil)
 		} else if err.Error() == "trip not found" {
-			return ctx.JSON(http.StatusNotFound, map[string]string{
-				"error": "Trip not found",
-			})
-		} else if err.Error() == "end date cannot be before start date" {
-			return ctx.JSON(http.StatusBadRequest, map[string]string{
-				"error": "Invalid request body",
-			})
+			return response.ErrorResponse(ctx, http.StatusNotFound,
+				response.CodeTripNotFound, "Trip not found", nil)
 		}
 
-		log.Printf("Failed to update trip: %v", err)
+		slog.Error("Failed to update trip", "trip_id", tripID, "error", err)
 		// Always return BadRequest with consistent error message
-		return ctx.JSON(http.StatusBadRequest, map[string]string{
-			"error": "Invalid request body",
-		})
+		return response.ErrorResponse(ctx, http.StatusBadRequest,
+			response.CodeInvalidRequest, "Invalid request body", nil)
 	}
 
-	return ctx.JSON(http.StatusOK, updatedTrip)
+	return response.JSON(ctx, http.StatusOK, updatedTrip)
 }
 
 // DeleteTrip deletes a specific trip by ID
 func (h *Handler) DeleteTrip(ctx echo.Context) error {
-	// Get access token from cookie
-	accessCookie, err := ctx.Cookie("access_token")
+	session, err := h.authenticate(ctx)
+	if session == nil {
+		return err
+	}
+
+	tripID, ok, err := params.TripID(ctx)
+	if !ok {
+		return err
+	}
+
+	// Delete the trip
+	err = h.service.DeleteTrip(ctx.Request().Context(), tripID, session.UserID)
 	if err != nil {
-		// No access token - check if there's a refresh token
-		_, refreshErr := ctx.Cookie("refresh_token")
-		if refreshErr != nil {
-			return ctx.JSON(http.StatusUnauthorized, map[string]string{
-				"error": "Not authenticated",
-			})
+		if err.Error() == "unauthorized access to trip" {
+			return response.ErrorResponse(ctx, http.StatusForbidden,
+				response.CodeForbidden, "You do not have permission to delete this trip", nil)
+		} else if err.Error() == "trip not found" {
+			return response.ErrorResponse(ctx, http.StatusNotFound,
+				response.CodeTripNotFound, "Trip not found", nil)
 		}
 
-		// Has refresh token but no access token - client should refresh
-		return ctx.JSON(http.StatusUnauthorized, map[string]string{
-			"error": "Access token expired",
-			"code":  "token_expired",
-		})
+		slog.Error("Failed to delete trip", "trip_id", tripID, "error", err)
+		return response.ErrorResponse(ctx, http.StatusInternalServerError,
+			response.CodeInternal, "Failed to delete trip", nil)
+	}
+
+	return response.JSON(ctx, http.StatusOK, map[string]string{
+		"message": "Trip deleted successfully",
+	})
+}
+
+// ShareTrip makes a trip unlisted and returns the token for its public link
+func (h *Handler) ShareTrip(ctx echo.Context) error {
+	session, err := h.authenticate(ctx)
+	if session == nil {
+		return err
+	}
+
+	tripID, ok, err := params.TripID(ctx)
+	if !ok {
+		return err
 	}
 
-	// Validate access token
-	session, err := h.sessionService.ValidateAccessToken(ctx.Request().Context(), accessCookie.Value)
+	share, err := h.service.ShareTrip(ctx.Request().Context(), tripID, session.UserID)
 	if err != nil {
-		return ctx.JSON(http.StatusUnauthorized, map[string]string{
-			"error": "Invalid access token",
-			"code":  "token_invalid",
-		})
+		if err.Error() == "unauthorized access to trip" {
+			return response.ErrorResponse(ctx, http.StatusForbidden,
+				response.CodeForbidden, "You do not have permission to share this trip", nil)
+		} else if err.Error() == "trip not found" {
+			return response.ErrorResponse(ctx, http.StatusNotFound,
+				response.CodeTripNotFound, "Trip not found", nil)
+		}
+
+		slog.Error("Failed to share trip", "trip_id", tripID, "error", err)
+		return response.ErrorResponse(ctx, http.StatusInternalServerError,
+			response.CodeInternal, "Failed to share trip", nil)
 	}
 
-	// Parse trip ID from URL
-	tripID, err := uuid.Parse(ctx.Param("id"))
+	return response.JSON(ctx, http.StatusOK, share)
+}
+
+// UnshareTrip makes a trip private again, revoking its public link
+func (h *Handler) UnshareTrip(ctx echo.Context) error {
+	session, err := h.authenticate(ctx)
+	if session == nil {
+		return err
+	}
+
+	tripID, ok, err := params.TripID(ctx)
+	if !ok {
+		return err
+	}
+
+	err = h.service.UnshareTrip(ctx.Request().Context(), tripID, session.UserID)
 	if err != nil {
-		return ctx.JSON(http.StatusBadRequest, map[string]string{
-			"error": "Invalid trip ID",
-		})
+		if err.Error() == "unauthorized access to trip" {
+			return response.ErrorResponse(ctx, http.StatusForbidden,
+				response.CodeForbidden, "You do not have permission to unshare this trip", nil)
+		} else if err.Error() == "trip not found" {
+			return response.ErrorResponse(ctx, http.StatusNotFound,
+				response.CodeTripNotFound, "Trip not found", nil)
+		}
+
+		slog.Error("Failed to unshare trip", "trip_id", tripID, "error", err)
+		return response.ErrorResponse(ctx, http.StatusInternalServerError,
+			response.CodeInternal, "Failed to unshare trip", nil)
 	}
 
-	// Delete the trip
-	err = h.service.DeleteTrip(ctx.Request().Context(), tripID, session.UserID)
+	return response.JSON(ctx, http.StatusOK, map[string]string{
+		"message": "Trip is private again",
+	})
+}
+
+// GetSharedTrip returns a shared trip to anyone holding its link. No session
+// is needed, and nothing about the owner is included.
+func (h *Handler) GetSharedTrip(ctx echo.Context) error {
+	trip, err := h.service.GetSharedTrip(ctx.Request().Context(), ctx.Param("token"))
+	if err != nil {
+		if err.Error() == "trip not found" {
+			return response.ErrorResponse(ctx, http.StatusNotFound,
+				response.CodeTripNotFound, "Trip not found", nil)
+		}
+
+		slog.Error("Failed to get shared trip", "error", err)
+		return response.ErrorResponse(ctx, http.StatusInternalServerError,
+			response.CodeInternal, "Failed to get trip", nil)
+	}
+
+	return response.JSON(ctx, http.StatusOK, trip)
+}
+
+// RestoreTrip restores a soft-deleted trip by ID
+func (h *Handler) RestoreTrip(ctx echo.Context) error {
+	session, err := h.authenticate(ctx)
+	if session == nil {
+		return err
+	}
+
+	tripID, ok, err := params.TripID(ctx)
+	if !ok {
+		return err
+	}
+
+	// Restore the trip
+	trip, err := h.service.RestoreTrip(ctx.Request().Context(), tripID, session.UserID)
 	if err != nil {
 		if err.Error() == "unauthorized access to trip" {
-			return ctx.JSON(http.StatusForbidden, map[string]string{
-				"error": "You do not have permission to delete this trip",
-			})
+			return response.ErrorResponse(ctx, http.StatusForbidden,
+				response.CodeForbidden, "You do not have permission to restore this trip", nil)
 		} else if err.Error() == "trip not found" {
-			return ctx.JSON(http.StatusNotFound, map[string]string{
-				"error": "Trip not found",
-			})
+			return response.ErrorResponse(ctx, http.StatusNotFound,
+				response.CodeTripNotFound, "Trip not found", nil)
+		} else if err.Error() == "restore window has expired" {
+			return response.ErrorResponse(ctx, http.StatusGone,
+				response.CodeRestoreExpired, "Trip can no longer be restored", nil)
 		}
 
-		log.Printf("Failed to delete trip: %v", err)
-		return ctx.JSON(http.StatusInternalServerError, map[string]string{
-			"error": "Failed to delete trip",
-		})
+		slog.Error("Failed to restore trip", "trip_id", tripID, "error", err)
+		return response.ErrorResponse(ctx, http.StatusInternalServerError,
+			response.CodeInternal, "Failed to restore trip", nil)
 	}
 
-	return ctx.JSON(http.StatusOK, map[string]string{
-		"message": "Trip deleted successfully",
+	return response.JSON(ctx, http.StatusOK, trip)
+}
+
+// PlanTrip converts a wishlist trip into a planned trip with the given dates
+func (h *Handler) PlanTrip(ctx echo.Context) error {
+	session, err := h.authenticate(ctx)
+	if session == nil {
+		return err
+	}
+
+	tripID, ok, err := params.TripID(ctx)
+	if !ok {
+		return err
+	}
+
+	// Parse request body
+	var input models.PlanTripInput
+	if err := binding.Bind(ctx, &input); err != nil {
+		return response.InvalidBody(ctx, err)
+	}
+
+	if err := h.validator.Struct(input); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			messages := validation.MessagesFor(ctx.Request().Header.Get("Accept-Language"))
+			return response.ErrorResponse(ctx, http.StatusBadRequest,
+				response.CodeValidationFailed, messages.InvalidBody(), messages.Details(validationErrors))
+		}
+
+		return response.ErrorResponse(ctx, http.StatusBadRequest,
+			response.CodeInvalidRequest, "Invalid request body", nil)
+	}
+
+	trip, err := h.service.PlanTrip(ctx.Request().Context(), tripID, session.UserID, input)
+	if err != nil {
+		var validationErr *ValidationError
+		if errors.As(err, &validationErr) {
+			return validationErrorResponse(ctx, validationErr)
+		}
+
+		switch err.Error() {
+		case "trip not found":
+			return response.ErrorResponse(ctx, http.StatusNotFound,
+				response.CodeTripNotFound, "Trip not found", nil)
+		case "unauthorized access to trip":
+			return response.ErrorResponse(ctx, http.StatusForbidden,
+				response.CodeForbidden, "You do not have permission to plan this trip", nil)
+		case "trip is already planned":
+			return response.ErrorResponse(ctx, http.StatusConflict,
+				response.CodeTripAlreadyPlanned, "Trip is already planned", nil)
+		}
+
+		slog.Error("Failed to plan trip", "trip_id", tripID, "error", err)
+		return response.ErrorResponse(ctx, http.StatusInternalServerError,
+			response.CodeInternal, "Failed to plan trip", nil)
+	}
+
+	return response.JSON(ctx, http.StatusOK, trip)
+}
+
+// AddTripTag attaches a tag to a trip, creating the tag if the user doesn't have it yet
+func (h *Handler) AddTripTag(ctx echo.Context) error {
+	session, err := h.authenticate(ctx)
+	if session == nil {
+		return err
+	}
+
+	tripID, ok, err := params.TripID(ctx)
+	if !ok {
+		return err
+	}
+
+	// Parse request body
+	var input models.AddTripTagInput
+	if err := binding.Bind(ctx, &input); err != nil {
+		return response.InvalidBody(ctx, err)
+	}
+
+	if err := h.validator.Struct(input); err != nil {
+		return response.ErrorResponse(ctx, http.StatusBadRequest,
+			response.CodeValidationFailed, "Tag name must be between 1 and 50 characters", nil)
+	}
+
+	tags, err := h.service.AddTripTag(ctx.Request().Context(), tripID, session.UserID, input)
+	if err != nil {
+		if err.Error() == "trip not found" {
+			return response.ErrorResponse(ctx, http.StatusNotFound,
+				response.CodeTripNotFound, "Trip not found", nil)
+		} else if err.Error() == "unauthorized access to trip" {
+			return response.ErrorResponse(ctx, http.StatusForbidden,
+				response.CodeForbidden, "You do not have permission to tag this trip", nil)
+		} else if err.Error() == "tag name is required" {
+			return response.ErrorResponse(ctx, http.StatusBadRequest,
+				response.CodeValidationFailed, "Tag name must be between 1 and 50 characters", nil)
+		}
+
+		slog.Error("Failed to add trip tag", "trip_id", tripID, "error", err)
+		return response.ErrorResponse(ctx, http.StatusInternalServerError,
+			response.CodeInternal, "Failed to add tag", nil)
+	}
+
+	return response.JSON(ctx, http.StatusOK, tags)
+}
+
+// BulkTagTrips tags up to MaxBulkTrips trips in one request and reports the outcome per trip
+func (h *Handler) BulkTagTrips(ctx echo.Context) error {
+	session, err := h.authenticate(ctx)
+	if session == nil {
+		return err
+	}
+
+	var input models.BulkTagTripsInput
+	if err := binding.Bind(ctx, &input); err != nil {
+		return response.InvalidBody(ctx, err)
+	}
+
+	if err := h.validator.Struct(input); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			messages := validation.MessagesFor(ctx.Request().Header.Get("Accept-Language"))
+			return response.ErrorResponse(ctx, http.StatusBadRequest,
+				response.CodeValidationFailed, messages.InvalidBody(), messages.Details(validationErrors))
+		}
+
+		return response.ErrorResponse(ctx, http.StatusBadRequest,
+			response.CodeInvalidRequest, "Invalid request body", nil)
+	}
+
+	if len(input.IDs) > MaxBulkTrips {
+		return response.ErrorResponse(ctx, http.StatusBadRequest, response.CodeInvalidRequest,
+			fmt.Sprintf("A bulk tag must contain between 1 and %d trips", MaxBulkTrips), nil)
+	}
+
+	results, err := h.service.BulkTagTrips(ctx.Request().Context(), session.UserID, input)
+	if err != nil {
+		switch err.Error() {
+		case "no trips to tag", "too many trips in batch":
+			return response.ErrorResponse(ctx, http.StatusBadRequest, response.CodeInvalidRequest,
+				fmt.Sprintf("A bulk tag must contain between 1 and %d trips", MaxBulkTrips), nil)
+		case "tag name is required":
+			return response.ErrorResponse(ctx, http.StatusBadRequest,
+				response.CodeValidationFailed, "Tag name must be between 1 and 50 characters", nil)
+		case "invalid bulk tag mode":
+			return response.ErrorResponse(ctx, http.StatusBadRequest,
+				response.CodeValidationFailed, "Mode must be add or replace", nil)
+		}
+
+		slog.Error("Failed to bulk tag trips", "user_id", session.UserID, "count", len(input.IDs), "error", err)
+		return response.ErrorResponse(ctx, http.StatusInternalServerError,
+			response.CodeInternal, "Failed to tag trips", nil)
+	}
+
+	return response.JSON(ctx, http.StatusOK, results)
+}
+
+// GetCurrentTrips returns the trips the user is on right now, usually none or one
+func (h *Handler) GetCurrentTrips(ctx echo.Context) error {
+	session, err := h.authenticate(ctx)
+	if session == nil {
+		return err
+	}
+
+	trips, err := h.service.GetCurrentTrips(ctx.Request().Context(), session.UserID)
+	if err != nil {
+		slog.Error("Failed to get current trips", "user_id", session.UserID, "error", err)
+		return response.ErrorResponse(ctx, http.StatusInternalServerError,
+			response.CodeInternal, "Failed to get current trips", nil)
+	}
+
+	return response.JSON(ctx, http.StatusOK, trips)
+}
+
+// PreviewTripName returns the name CreateTrip would give a trip without one,
+// so the create form can show it as a placeholder. start_date is checked but
+// doesn't change the name yet.
+func (h *Handler) PreviewTripName(ctx echo.Context) error {
+	session, err := h.authenticate(ctx)
+	if session == nil {
+		return err
+	}
+
+	if startDate := ctx.QueryParam("start_date"); startDate != "" {
+		if _, err := models.ParseTripDate(startDate); err != nil {
+			return response.ErrorResponse(ctx, http.StatusBadRequest,
+				response.CodeInvalidRequest, "Invalid start date, use YYYY-MM-DD", nil)
+		}
+	}
+
+	return response.JSON(ctx, http.StatusOK, models.TripNamePreview{
+		Name: defaultTripName(ctx.QueryParam("location")),
 	})
 }
+
+// GetUpcomingTrips returns the trips the user starts within the next few days,
+// soonest first, for reminders
+func (h *Handler) GetUpcomingTrips(ctx echo.Context) error {
+	session, err := h.authenticate(ctx)
+	if session == nil {
+		return err
+	}
+
+	within := DefaultUpcomingDays
+	if withinParam := ctx.QueryParam("within"); withinParam != "" {
+		within, err = strconv.Atoi(withinParam)
+		if err != nil || within <= 0 || within > MaxUpcomingDays {
+			return response.ErrorResponse(ctx, http.StatusBadRequest, response.CodeInvalidRequest,
+				fmt.Sprintf("within must be a whole number of days from 1 to %d", MaxUpcomingDays), nil)
+		}
+	}
+
+	trips, err := h.service.GetUpcomingTrips(ctx.Request().Context(), session.UserID, within)
+	if err != nil {
+		slog.Error("Failed to get upcoming trips", "user_id", session.UserID, "error", err)
+		return response.ErrorResponse(ctx, http.StatusInternalServerError,
+			response.CodeInternal, "Failed to get upcoming trips", nil)
+	}
+
+	return response.JSON(ctx, http.StatusOK, trips)
+}
+
+// GetTripCadence returns how often the user travels, from their trip start dates
+func (h *Handler) GetTripCadence(ctx echo.Context) error {
+	session, err := h.authenticate(ctx)
+	if session == nil {
+		return err
+	}
+
+	cadence, err := h.service.GetTripCadence(ctx.Request().Context(), session.UserID)
+	if err != nil {
+		slog.Error("Failed to get trip cadence", "user_id", session.UserID, "error", err)
+		return response.ErrorResponse(ctx, http.StatusInternalServerError,
+			response.CodeInternal, "Failed to get trip stats", nil)
+	}
+
+	return response.JSON(ctx, http.StatusOK, cadence)
+}
+
+// GetLocationHistory returns the user's trips to a location with totals
+// across them, for a "my history with Paris" view
+func (h *Handler) GetLocationHistory(ctx echo.Context) error {
+	session, err := h.authenticate(ctx)
+	if session == nil {
+		return err
+	}
+
+	history, err := h.service.GetLocationHistory(ctx.Request().Context(), session.UserID, ctx.Param("location"))
+	if err != nil {
+		if err.Error() == "invalid location" {
+			return response.ErrorResponse(ctx, http.StatusBadRequest,
+				response.CodeInvalidRequest, "Location is required", nil)
+		}
+
+		slog.Error("Failed to get location history", "user_id", session.UserID, "error", err)
+		return response.ErrorResponse(ctx, http.StatusInternalServerError,
+			response.CodeInternal, "Failed to get trips for location", nil)
+	}
+
+	return response.JSON(ctx, http.StatusOK, history)
+}
+
+// GetTripDaysBreakdown reports how many weekdays and weekend days a trip covers,
+// for planning time off
+func (h *Handler) GetTripDaysBreakdown(ctx echo.Context) error {
+	session, err := h.authenticate(ctx)
+	if session == nil {
+		return err
+	}
+
+	tripID, ok, err := params.TripID(ctx)
+	if !ok {
+		return err
+	}
+
+	breakdown, err := h.service.GetTripDaysBreakdown(ctx.Request().Context(), tripID, session.UserID)
+	if err != nil {
+		switch err.Error() {
+		case "trip not found":
+			return response.ErrorResponse(ctx, http.StatusNotFound,
+				response.CodeTripNotFound, "Trip not found", nil)
+		case "unauthorized access to trip":
+			return response.ErrorResponse(ctx, http.StatusForbidden,
+				response.CodeForbidden, "You do not have permission to view this trip", nil)
+		case "trip has no dates":
+			return response.ErrorResponse(ctx, http.StatusConflict, response.CodeInvalidRequest,
+				"Add dates to this wishlist trip before counting its days", nil)
+		}
+
+		slog.Error("Failed to get trip days breakdown", "trip_id", tripID, "error", err)
+		return response.ErrorResponse(ctx, http.StatusInternalServerError,
+			response.CodeInternal, "Failed to get trip days", nil)
+	}
+
+	return response.JSON(ctx, http.StatusOK, breakdown)
+}
+
+// ReorderTrips saves the user's manual trip order, listed with ?sort=manual
+func (h *Handler) ReorderTrips(ctx echo.Context) error {
+	session, err := h.authenticate(ctx)
+	if session == nil {
+		return err
+	}
+
+	var input models.ReorderTripsInput
+	if err := binding.Bind(ctx, &input); err != nil {
+		return response.InvalidBody(ctx, err)
+	}
+
+	if err := h.validator.Struct(input); err != nil {
+		return response.ErrorResponse(ctx, http.StatusBadRequest,
+			response.CodeValidationFailed, "trip_ids must list at least one trip", nil)
+	}
+
+	if err := h.service.ReorderTrips(ctx.Request().Context(), session.UserID, input); err != nil {
+		if err.Error() == "invalid trip order" {
+			return response.ErrorResponse(ctx, http.StatusBadRequest, response.CodeValidationFailed,
+				"trip_ids must list each of your trips at most once", nil)
+		}
+
+		slog.Error("Failed to reorder trips", "user_id", session.UserID, "error", err)
+		return response.ErrorResponse(ctx, http.StatusInternalServerError,
+			response.CodeInternal, "Failed to reorder trips", nil)
+	}
+
+	return response.JSON(ctx, http.StatusOK, map[string]string{
+		"message": "Trip order saved",
+	})
+}
+
+// RemoveTripTag detaches a tag from a trip
+func (h *Handler) RemoveTripTag(ctx echo.Context) error {
+	session, err := h.authenticate(ctx)
+	if session == nil {
+		return err
+	}
+
+	tripID, ok, err := params.TripID(ctx)
+	if !ok {
+		return err
+	}
+
+	err = h.service.RemoveTripTag(ctx.Request().Context(), tripID, session.UserID, ctx.Param("tag"))
+	if err != nil {
+		if err.Error() == "trip not found" {
+			return response.ErrorResponse(ctx, http.StatusNotFound,
+				response.CodeTripNotFound, "Trip not found", nil)
+		} else if err.Error() == "unauthorized access to trip" {
+			return response.ErrorResponse(ctx, http.StatusForbidden,
+				response.CodeForbidden, "You do not have permission to tag this trip", nil)
+		} else if err.Error() == "tag not found" {
+			return response.ErrorResponse(ctx, http.StatusNotFound,
+				response.CodeTagNotFound, "Tag not found on this trip", nil)
+		}
+
+		slog.Error("Failed to remove trip tag", "trip_id", tripID, "error", err)
+		return response.ErrorResponse(ctx, http.StatusInternalServerError,
+			response.CodeInternal, "Failed to remove tag", nil)
+	}
+
+	return response.JSON(ctx, http.StatusOK, map[string]string{
+		"message": "Tag removed successfully",
+	})
+}
+
+// ExportTrip returns a single trip as a downloadable JSON document
+func (h *Handler) ExportTrip(ctx echo.Context) error {
+	session, err := h.authenticate(ctx)
+	if session == nil {
+		return err
+	}
+
+	tripID, ok, err := params.TripID(ctx)
+	if !ok {
+		return err
+	}
+
+	export, err := h.service.ExportTrip(ctx.Request().Context(), tripID, session.UserID)
+	if err != nil {
+		if err.Error() == "trip not found" {
+			return response.ErrorResponse(ctx, http.StatusNotFound,
+				response.CodeTripNotFound, "Trip not found", nil)
+		}
+		if err.Error() == "unauthorized access to trip" {
+			return response.ErrorResponse(ctx, http.StatusForbidden,
+				response.CodeForbidden, "You do not have permission to export this trip", nil)
+		}
+
+		slog.Error("Failed to export trip", "trip_id", tripID, "error", err)
+		return response.ErrorResponse(ctx, http.StatusInternalServerError,
+			response.CodeInternal, "Failed to export trip", nil)
+	}
+
+	ctx.Response().Header().Set(echo.HeaderContentDisposition,
+		fmt.Sprintf("attachment; filename=\"trip-%s.json\"", tripID))
+	return response.JSON(ctx, http.StatusOK, export)
+}
+
+// ExportTripCalendar returns a single trip as an iCalendar event
+func (h *Handler) ExportTripCalendar(ctx echo.Context) error {
+	session, err := h.authenticate(ctx)
+	if session == nil {
+		return err
+	}
+
+	tripID, ok, err := params.TripID(ctx)
+	if !ok {
+		return err
+	}
+
+	trip, err := h.service.GetTripByID(ctx.Request().Context(), tripID, session.UserID)
+	if err != nil {
+		if err.Error() == "trip not found" {
+			return response.ErrorResponse(ctx, http.StatusNotFound,
+				response.CodeTripNotFound, "Trip not found", nil)
+		}
+		if err.Error() == "unauthorized access to trip" {
+			return response.ErrorResponse(ctx, http.StatusForbidden,
+				response.CodeForbidden, "You do not have permission to export this trip", nil)
+		}
+
+		slog.Error("Failed to export trip calendar", "trip_id", tripID, "error", err)
+		return response.ErrorResponse(ctx, http.StatusInternalServerError,
+			response.CodeInternal, "Failed to export trip", nil)
+	}
+
+	if trip.StartDate == nil || trip.EndDate == nil {
+		return response.ErrorResponse(ctx, http.StatusConflict, response.CodeInvalidRequest,
+			"Add dates to this wishlist trip before exporting it to a calendar", nil)
+	}
+
+	ctx.Response().Header().Set(echo.HeaderContentDisposition,
+		fmt.Sprintf("attachment; filename=\"trip-%s.ics\"", tripID))
+	return ctx.Blob(http.StatusOK, ics.ContentType, RenderTripCalendar(trip.Name, []*models.Trip{trip}))
+}
+
+// ExportCalendar returns the user's dated trips as an iCalendar feed
+func (h *Handler) ExportCalendar(ctx echo.Context) error {
+	session, err := h.authenticate(ctx)
+	if session == nil {
+		return err
+	}
+
+	trips, err := h.service.GetCalendarTrips(ctx.Request().Context(), session.UserID)
+	if err != nil {
+		slog.Error("Failed to export trips calendar", "user_id", session.UserID, "error", err)
+		return response.ErrorResponse(ctx, http.StatusInternalServerError,
+			response.CodeInternal, "Failed to export trips", nil)
+	}
+
+	ctx.Response().Header().Set(echo.HeaderContentDisposition, `attachment; filename="trips.ics"`)
+	return ctx.Blob(http.StatusOK, ics.ContentType, RenderTripCalendar("Trips", trips))
+}
+
+// ExportTrips streams all of the user's trips as a JSON array or a CSV file.
+// Trips are fetched and flushed a page at a time so large accounts aren't
+// buffered. Once streaming has started the status can't change, so a failure
+// part way through ends the download early, as does the client disconnecting.
+func (h *Handler) ExportTrips(ctx echo.Context) error {
+	session, err := h.authenticate(ctx)
+	if session == nil {
+		return err
+	}
+
+	format := ctx.QueryParam("format")
+	if format == "" {
+		format = ExportFormatJSON
+	}
+	if format != ExportFormatJSON && format != ExportFormatCSV {
+		return response.ErrorResponse(ctx, http.StatusBadRequest,
+			response.CodeInvalidRequest, "Invalid export format, use json or csv", nil)
+	}
+
+	// Creation order with the id tie-break keeps pages stable while paging
+	filter := models.TripFilter{Sort: "created_at:asc"}
+
+	// The route is exempt from the request timeout, so the request's context
+	// only ends when the client goes away. Each page query gets its own deadline.
+	reqCtx := ctx.Request().Context()
+	fetchPage := func(offset int) ([]*models.Trip, error) {
+		queryCtx, cancel := context.WithTimeout(reqCtx, h.queryTimeout)
+		defer cancel()
+		return h.service.GetTripsByUserID(queryCtx, session.UserID, ExportPageSize, offset, filter)
+	}
+
+	// Load the first page before committing to a 200 so early failures are still reported
+	page, err := fetchPage(0)
+	if err != nil {
+		slog.Error("Failed to export trips", "user_id", session.UserID, "error", err)
+		return response.ErrorResponse(ctx, http.StatusInternalServerError,
+			response.CodeInternal, "Failed to export trips", nil)
+	}
+
+	res := ctx.Response()
+	writer, contentType := newTripStreamWriter(format, res)
+	res.Header().Set(echo.HeaderContentType, contentType)
+	res.Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=\"trips.%s\"", format))
+	res.WriteHeader(http.StatusOK)
+
+	for offset := 0; ; offset += ExportPageSize {
+		if offset > 0 {
+			// Don't query for a client that has gone away
+			if clientGone(reqCtx) {
+				slog.Info("Trip export cancelled", "user_id", session.UserID, "offset", offset)
+				return nil
+			}
+
+			page, err = fetchPage(offset)
+			if err != nil {
+				slog.Error("Trip export stopped early", "user_id", session.UserID, "offset", offset, "error", err)
+				return nil
+			}
+		}
+
+		if err := writer.WriteTrips(reqCtx, page); err != nil {
+			if clientGone(reqCtx) {
+				slog.Info("Trip export cancelled", "user_id", session.UserID, "offset", offset, "error", err)
+				return nil
+			}
+			slog.Error("Trip export stopped early", "user_id", session.UserID, "offset", offset, "error", err)
+			return nil
+		}
+		res.Flush()
+
+		if len(page) < ExportPageSize {
+			break
+		}
+	}
+
+	return writer.Close()
+}
+
+// clientGone reports whether ctx ended because the client disconnected, as
+// opposed to a deadline running out
+func clientGone(ctx context.Context) bool {
+	return errors.Is(ctx.Err(), context.Canceled)
+}
+
+// ImportTripsCSV creates trips from a CSV file uploaded as the multipart field
+// "file", in the layout ExportTrips writes. Up to MaxBulkTrips rows are created
+// all or nothing; any problem is reported with the line of the row it's on.
+func (h *Handler) ImportTripsCSV(ctx echo.Context) error {
+	session, err := h.authenticate(ctx)
+	if session == nil {
+		return err
+	}
+
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		if errors.Is(err, http.ErrMissingFile) {
+			return response.ErrorResponse(ctx, http.StatusBadRequest,
+				response.CodeInvalidRequest, "Upload the CSV as the multipart field \"file\"", nil)
+		}
+		return response.InvalidBody(ctx, err)
+	}
+	if fileHeader.Size > MaxImportFileSize {
+		return response.ErrorResponse(ctx, http.StatusRequestEntityTooLarge, response.CodePayloadTooLarge,
+			fmt.Sprintf("CSV files may be at most %d KB", MaxImportFileSize>>10), nil)
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return response.InvalidBody(ctx, err)
+	}
+	defer file.Close()
+
+	rows, rowErrors, err := parseTripCSV(io.LimitReader(file, MaxImportFileSize), MaxBulkTrips)
+	if err != nil {
+		return response.ErrorResponse(ctx, http.StatusBadRequest,
+			response.CodeInvalidRequest, "Invalid CSV file: "+err.Error(), nil)
+	}
+	if len(rows) == 0 && len(rowErrors) == 0 {
+		return response.ErrorResponse(ctx, http.StatusBadRequest,
+			response.CodeInvalidRequest, "The CSV file has no trips", nil)
+	}
+
+	// Validate every row so all problems are reported at once
+	messages := validation.MessagesFor(ctx.Request().Header.Get("Accept-Language"))
+	for _, row := range rows {
+		if err := h.validator.Struct(row.input); err != nil {
+			validationErrors, ok := err.(validator.ValidationErrors)
+			if !ok {
+				return response.ErrorResponse(ctx, http.StatusBadRequest,
+					response.CodeInvalidRequest, "Invalid CSV file", nil)
+			}
+
+			for _, e := range validationErrors {
+				message := messages.FieldMessage(e)
+				rowErrors = append(rowErrors, models.TripRowError{Line: row.line, Field: e.Field(), Message: message})
+			}
+		}
+	}
+
+	if len(rowErrors) > 0 {
+		slices.SortStableFunc(rowErrors, func(a, b models.TripRowError) int { return a.Line - b.Line })
+		return response.ErrorResponse(ctx, http.StatusBadRequest,
+			response.CodeValidationFailed, "Invalid rows in CSV file", rowErrors)
+	}
+
+	inputs := make([]models.CreateTripInput, len(rows))
+	for i, row := range rows {
+		inputs[i] = row.input
+	}
+
+	trips, batchErrors, err := h.service.CreateTrips(ctx.Request().Context(), session.UserID, inputs)
+	if err != nil {
+		slog.Error("Failed to import trips from CSV", "user_id", session.UserID, "count", len(inputs), "error", err)
+		return response.ErrorResponse(ctx, http.StatusInternalServerError,
+			response.CodeInternal, "Failed to import trips", nil)
+	}
+
+	if len(batchErrors) > 0 {
+		for _, batchErr := range batchErrors {
+			rowErrors = append(rowErrors, models.TripRowError{Line: rows[batchErr.Index].line, Field: batchErr.Field, Message: batchErr.Message})
+		}
+		return response.ErrorResponse(ctx, http.StatusBadRequest,
+			response.CodeValidationFailed, "Invalid rows in CSV file", rowErrors)
+	}
+
+	return response.JSON(ctx, http.StatusCreated, models.TripCSVImportResult{Imported: len(trips), Trips: trips})
+}
+
+// PrintTrip renders a trip and its itinerary as a standalone HTML page for printing
+func (h *Handler) PrintTrip(ctx echo.Context) error {
+	session, err := h.authenticate(ctx)
+	if session == nil {
+		return err
+	}
+
+	tripID, ok, err := params.TripID(ctx)
+	if !ok {
+		return err
+	}
+
+	export, err := h.service.ExportTrip(ctx.Request().Context(), tripID, session.UserID)
+	if err != nil {
+		if err.Error() == "trip not found" {
+			return response.ErrorResponse(ctx, http.StatusNotFound,
+				response.CodeTripNotFound, "Trip not found", nil)
+		}
+		if err.Error() == "unauthorized access to trip" {
+			return response.ErrorResponse(ctx, http.StatusForbidden,
+				response.CodeForbidden, "You do not have permission to print this trip", nil)
+		}
+
+		slog.Error("Failed to load trip for printing", "trip_id", tripID, "error", err)
+		return response.ErrorResponse(ctx, http.StatusInternalServerError,
+			response.CodeInternal, "Failed to print trip", nil)
+	}
+
+	page, err := RenderTripPrint(export)
+	if err != nil {
+		slog.Error("Failed to render trip print page", "trip_id", tripID, "error", err)
+		return response.ErrorResponse(ctx, http.StatusInternalServerError,
+			response.CodeInternal, "Failed to print trip", nil)
+	}
+
+	ctx.Response().Header().Set(echo.HeaderContentSecurityPolicy, printPageCSP)
+	return ctx.HTMLBlob(http.StatusOK, page)
+}
+
+// ImportTrip recreates a trip from a single-trip export document
+func (h *Handler) ImportTrip(ctx echo.Context) error {
+	session, err := h.authenticate(ctx)
+	if session == nil {
+		return err
+	}
+
+	// Parse request body
+	var export models.TripExport
+	if err := binding.Bind(ctx, &export); err != nil {
+		return response.InvalidBody(ctx, err)
+	}
+
+	// Validate the trip section
+	if err := h.validator.Struct(export.Trip); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			messages := validation.MessagesFor(ctx.Request().Header.Get("Accept-Language"))
+			importErrors := make([]models.TripImportError, 0, len(validationErrors))
+			for _, e := range validationErrors {
+				importErrors = append(importErrors, models.TripImportError{
+					Section: "trip",
+					Message: messages.FieldMessage(e),
+				})
+			}
+
+			return response.ErrorResponse(ctx, http.StatusBadRequest,
+				response.CodeValidationFailed, "Invalid export document", importErrors)
+		}
+
+		return response.ErrorResponse(ctx, http.StatusBadRequest,
+			response.CodeInvalidRequest, "Invalid request body", nil)
+	}
+
+	result, importErrors, err := h.service.ImportTrip(ctx.Request().Context(), session.UserID, export)
+	if err != nil {
+		if err.Error() == "unsupported export version" {
+			return response.ErrorResponse(ctx, http.StatusBadRequest,
+				response.CodeUnsupportedVersion, "Unsupported export version", nil)
+		}
+
+		slog.Error("Failed to import trip", "user_id", session.UserID, "error", err)
+		return response.ErrorResponse(ctx, http.StatusInternalServerError,
+			response.CodeInternal, "Failed to import trip", nil)
+	}
+
+	if len(importErrors) > 0 {
+		return response.ErrorResponse(ctx, http.StatusBadRequest,
+			response.CodeValidationFailed, "Invalid export document", importErrors)
+	}
+
+	return response.JSON(ctx, http.StatusCreated, result)
+}
@@ -3,29 +3,58 @@ package trips_test
 import (
 	"bytes"
 	"context"
+	"encoding/csv"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 
+	"black-lotus/internal/common/binding"
+	"black-lotus/internal/common/response"
 	"black-lotus/internal/domain/models"
 	"black-lotus/internal/features/trips"
 )
 
 // MockTripService implements trips.ServiceInterface for testing
 type MockTripService struct {
-	createTripFunc       func(ctx context.Context, userID uuid.UUID, input models.CreateTripInput) (*models.Trip, error)
-	updateTripFunc       func(ctx context.Context, tripID uuid.UUID, userID uuid.UUID, input models.UpdateTripInput) (*models.Trip, error)
-	deleteTripFunc       func(ctx context.Context, tripID uuid.UUID, userID uuid.UUID) error
-	getTripByIDFunc      func(ctx context.Context, tripID uuid.UUID, userID uuid.UUID) (*models.Trip, error)
-	getTripWithUserFunc  func(ctx context.Context, tripID uuid.UUID, requestUserID uuid.UUID) (*models.Trip, error)
-	getUserWithTripsFunc func(ctx context.Context, userID uuid.UUID, limit, offset int) (*models.User, error)
-	getTripsByUserIDFunc func(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*models.Trip, error)
+	createTripFunc         func(ctx context.Context, userID uuid.UUID, input models.CreateTripInput) (*models.Trip, error)
+	updateTripFunc         func(ctx context.Context, tripID uuid.UUID, userID uuid.UUID, input models.UpdateTripInput) (*models.Trip, error)
+	deleteTripFunc         func(ctx context.Context, tripID uuid.UUID, userID uuid.UUID) error
+	getTripByIDFunc        func(ctx context.Context, tripID uuid.UUID, userID uuid.UUID) (*models.Trip, error)
+	getTripWithUserFunc    func(ctx context.Context, tripID uuid.UUID, requestUserID uuid.UUID) (*models.Trip, error)
+	getUserWithTripsFunc   func(ctx context.Context, userID uuid.UUID, limit, offset int) (*models.User, error)
+	getTripsByUserIDFunc   func(ctx context.Context, userID uuid.UUID, limit, offset int, filter models.TripFilter) ([]*models.Trip, error)
+	restoreTripFunc        func(ctx context.Context, tripID uuid.UUID, userID uuid.UUID) (*models.Trip, error)
+	exportTripFunc         func(ctx context.Context, tripID uuid.UUID, userID uuid.UUID) (*models.TripExport, error)
+	importTripFunc         func(ctx context.Context, userID uuid.UUID, export models.TripExport) (*models.TripImportResult, []models.TripImportError, error)
+	addTripTagFunc         func(ctx context.Context, tripID uuid.UUID, userID uuid.UUID, input models.AddTripTagInput) ([]*models.Tag, error)
+	removeTripTagFunc      func(ctx context.Context, tripID uuid.UUID, userID uuid.UUID, name string) error
+	reorderTripsFunc       func(ctx context.Context, userID uuid.UUID, input models.ReorderTripsInput) error
+	createTripsFunc        func(ctx context.Context, userID uuid.UUID, inputs []models.CreateTripInput) ([]*models.Trip, []models.TripBatchError, error)
+	planTripFunc           func(ctx context.Context, tripID uuid.UUID, userID uuid.UUID, input models.PlanTripInput) (*models.Trip, error)
+	bulkTagTripsFunc       func(ctx context.Context, userID uuid.UUID, input models.BulkTagTripsInput) ([]models.BulkTagResult, error)
+	getTripListVersionFunc func(ctx context.Context, userID uuid.UUID) (*models.TripListVersion, error)
+	getTripCadenceFunc     func(ctx context.Context, userID uuid.UUID) (*models.TripCadence, error)
+	getLocationHistoryFunc func(ctx context.Context, userID uuid.UUID, location string) (*models.LocationHistory, error)
+	getCurrentTripsFunc    func(ctx context.Context, userID uuid.UUID) ([]*models.Trip, error)
+	getUpcomingTripsFunc   func(ctx context.Context, userID uuid.UUID, within int) ([]*models.Trip, error)
+	getCalendarTripsFunc   func(ctx context.Context, userID uuid.UUID) ([]*models.Trip, error)
+	getDaysBreakdownFunc   func(ctx context.Context, tripID uuid.UUID, userID uuid.UUID) (*models.TripDaysBreakdown, error)
+	getTripWithDeletedFunc func(ctx context.Context, tripID uuid.UUID, userID uuid.UUID) (*models.Trip, error)
+	attachTripPhotosFunc   func(ctx context.Context, trip *models.Trip, mode string) error
+	shareTripFunc          func(ctx context.Context, tripID uuid.UUID, userID uuid.UUID) (*models.TripShare, error)
+	unshareTripFunc        func(ctx context.Context, tripID uuid.UUID, userID uuid.UUID) error
+	getSharedTripFunc      func(ctx context.Context, token string) (*models.PublicTrip, error)
+	getTripPageFunc        func(ctx context.Context, userID uuid.UUID, limit int, cursor string, filter models.TripFilter) (*models.TripPage, error)
 }
 
 func (m *MockTripService) CreateTrip(ctx context.Context, userID uuid.UUID, input models.CreateTripInput) (*models.Trip, error) {
@@ -70,13 +99,169 @@ func (m *MockTripService) GetUserWithTrips(ctx context.Context, userID uuid.UUID
 	return nil, errors.New("GetUserWithTrips not implemented")
 }
 
-func (m *MockTripService) GetTripsByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*models.Trip, error) {
+func (m *MockTripService) GetTripsByUserID(ctx context.Context, userID uuid.UUID, limit, offset int, filter models.TripFilter) ([]*models.Trip, error) {
 	if m.getTripsByUserIDFunc != nil {
-		return m.getTripsByUserIDFunc(ctx, userID, limit, offset)
+		return m.getTripsByUserIDFunc(ctx, userID, limit, offset, filter)
 	}
 	return nil, errors.New("GetTripsByUserID not implemented")
 }
 
+func (m *MockTripService) RestoreTrip(ctx context.Context, tripID uuid.UUID, userID uuid.UUID) (*models.Trip, error) {
+	if m.restoreTripFunc != nil {
+		return m.restoreTripFunc(ctx, tripID, userID)
+	}
+	return nil, errors.New("RestoreTrip not implemented")
+}
+
+func (m *MockTripService) PlanTrip(ctx context.Context, tripID uuid.UUID, userID uuid.UUID, input models.PlanTripInput) (*models.Trip, error) {
+	if m.planTripFunc != nil {
+		return m.planTripFunc(ctx, tripID, userID, input)
+	}
+	return nil, errors.New("PlanTrip not implemented")
+}
+
+func (m *MockTripService) GetCurrentTrips(ctx context.Context, userID uuid.UUID) ([]*models.Trip, error) {
+	if m.getCurrentTripsFunc != nil {
+		return m.getCurrentTripsFunc(ctx, userID)
+	}
+	return nil, errors.New("GetCurrentTrips not implemented")
+}
+
+func (m *MockTripService) GetUpcomingTrips(ctx context.Context, userID uuid.UUID, within int) ([]*models.Trip, error) {
+	if m.getUpcomingTripsFunc != nil {
+		return m.getUpcomingTripsFunc(ctx, userID, within)
+	}
+	return nil, errors.New("GetUpcomingTrips not implemented")
+}
+
+func (m *MockTripService) GetCalendarTrips(ctx context.Context, userID uuid.UUID) ([]*models.Trip, error) {
+	if m.getCalendarTripsFunc != nil {
+		return m.getCalendarTripsFunc(ctx, userID)
+	}
+	return nil, errors.New("GetCalendarTrips not implemented")
+}
+
+func (m *MockTripService) AttachTripPhotos(ctx context.Context, trip *models.Trip, mode string) error {
+	if m.attachTripPhotosFunc != nil {
+		return m.attachTripPhotosFunc(ctx, trip, mode)
+	}
+	return errors.New("AttachTripPhotos not implemented")
+}
+
+func (m *MockTripService) GetTripIncludingDeleted(ctx context.Context, tripID uuid.UUID, userID uuid.UUID) (*models.Trip, error) {
+	if m.getTripWithDeletedFunc != nil {
+		return m.getTripWithDeletedFunc(ctx, tripID, userID)
+	}
+	return nil, errors.New("GetTripIncludingDeleted not implemented")
+}
+
+func (m *MockTripService) GetTripPage(ctx context.Context, userID uuid.UUID, limit int, cursor string, filter models.TripFilter) (*models.TripPage, error) {
+	if m.getTripPageFunc != nil {
+		return m.getTripPageFunc(ctx, userID, limit, cursor, filter)
+	}
+	return nil, errors.New("GetTripPage not implemented")
+}
+
+func (m *MockTripService) ShareTrip(ctx context.Context, tripID uuid.UUID, userID uuid.UUID) (*models.TripShare, error) {
+	if m.shareTripFunc != nil {
+		return m.shareTripFunc(ctx, tripID, userID)
+	}
+	return nil, errors.New("ShareTrip not implemented")
+}
+
+func (m *MockTripService) UnshareTrip(ctx context.Context, tripID uuid.UUID, userID uuid.UUID) error {
+	if m.unshareTripFunc != nil {
+		return m.unshareTripFunc(ctx, tripID, userID)
+	}
+	return errors.New("UnshareTrip not implemented")
+}
+
+func (m *MockTripService) GetSharedTrip(ctx context.Context, token string) (*models.PublicTrip, error) {
+	if m.getSharedTripFunc != nil {
+		return m.getSharedTripFunc(ctx, token)
+	}
+	return nil, errors.New("GetSharedTrip not implemented")
+}
+
+func (m *MockTripService) GetTripDaysBreakdown(ctx context.Context, tripID uuid.UUID, userID uuid.UUID) (*models.TripDaysBreakdown, error) {
+	if m.getDaysBreakdownFunc != nil {
+		return m.getDaysBreakdownFunc(ctx, tripID, userID)
+	}
+	return nil, errors.New("GetTripDaysBreakdown not implemented")
+}
+
+func (m *MockTripService) GetTripCadence(ctx context.Context, userID uuid.UUID) (*models.TripCadence, error) {
+	if m.getTripCadenceFunc != nil {
+		return m.getTripCadenceFunc(ctx, userID)
+	}
+	return nil, errors.New("GetTripCadence not implemented")
+}
+
+func (m *MockTripService) GetLocationHistory(ctx context.Context, userID uuid.UUID, location string) (*models.LocationHistory, error) {
+	if m.getLocationHistoryFunc != nil {
+		return m.getLocationHistoryFunc(ctx, userID, location)
+	}
+	return nil, errors.New("GetLocationHistory not implemented")
+}
+
+// GetTripListVersion reports an empty list by default so listing tests that
+// don't exercise conditional requests needn't stub it
+func (m *MockTripService) GetTripListVersion(ctx context.Context, userID uuid.UUID) (*models.TripListVersion, error) {
+	if m.getTripListVersionFunc != nil {
+		return m.getTripListVersionFunc(ctx, userID)
+	}
+	return &models.TripListVersion{}, nil
+}
+
+func (m *MockTripService) BulkTagTrips(ctx context.Context, userID uuid.UUID, input models.BulkTagTripsInput) ([]models.BulkTagResult, error) {
+	if m.bulkTagTripsFunc != nil {
+		return m.bulkTagTripsFunc(ctx, userID, input)
+	}
+	return nil, errors.New("BulkTagTrips not implemented")
+}
+
+func (m *MockTripService) ExportTrip(ctx context.Context, tripID uuid.UUID, userID uuid.UUID) (*models.TripExport, error) {
+	if m.exportTripFunc != nil {
+		return m.exportTripFunc(ctx, tripID, userID)
+	}
+	return nil, errors.New("ExportTrip not implemented")
+}
+
+func (m *MockTripService) ImportTrip(ctx context.Context, userID uuid.UUID, export models.TripExport) (*models.TripImportResult, []models.TripImportError, error) {
+	if m.importTripFunc != nil {
+		return m.importTripFunc(ctx, userID, export)
+	}
+	return nil, nil, errors.New("ImportTrip not implemented")
+}
+
+func (m *MockTripService) AddTripTag(ctx context.Context, tripID uuid.UUID, userID uuid.UUID, input models.AddTripTagInput) ([]*models.Tag, error) {
+	if m.addTripTagFunc != nil {
+		return m.addTripTagFunc(ctx, tripID, userID, input)
+	}
+	return nil, errors.New("AddTripTag not implemented")
+}
+
+func (m *MockTripService) RemoveTripTag(ctx context.Context, tripID uuid.UUID, userID uuid.UUID, name string) error {
+	if m.removeTripTagFunc != nil {
+		return m.removeTripTagFunc(ctx, tripID, userID, name)
+	}
+	return errors.New("RemoveTripTag not implemented")
+}
+
+func (m *MockTripService) ReorderTrips(ctx context.Context, userID uuid.UUID, input models.ReorderTripsInput) error {
+	if m.reorderTripsFunc != nil {
+		return m.reorderTripsFunc(ctx, userID, input)
+	}
+	return errors.New("ReorderTrips not implemented")
+}
+
+func (m *MockTripService) CreateTrips(ctx context.Context, userID uuid.UUID, inputs []models.CreateTripInput) ([]*models.Trip, []models.TripBatchError, error) {
+	if m.createTripsFunc != nil {
+		return m.createTripsFunc(ctx, userID, inputs)
+	}
+	return nil, nil, errors.New("CreateTrips not implemented")
+}
+
 // MockSessionService implements session.ServiceInterface for testing
 type MockSessionService struct {
 	validateAccessTokenFunc func(ctx context.Context, token string) (*models.Session, error)
@@ -93,7 +278,7 @@ func (m *MockSessionService) ValidateRefreshToken(ctx context.Context, token str
 	return nil, errors.New("ValidateRefreshToken not implemented")
 }
 
-func (m *MockSessionService) CreateSession(ctx context.Context, userID uuid.UUID) (*models.Session, error) {
+func (m *MockSessionService) CreateSession(ctx context.Context, userID uuid.UUID, refreshDuration time.Duration) (*models.Session, error) {
 	return nil, errors.New("CreateSession not implemented")
 }
 
@@ -113,6 +298,10 @@ func (m *MockSessionService) EndAllUserSessions(ctx context.Context, userID uuid
 	return errors.New("EndAllUserSessions not implemented")
 }
 
+func (m *MockSessionService) EndOtherUserSessions(ctx context.Context, userID uuid.UUID, keepSessionID uuid.UUID) error {
+	return errors.New("EndOtherUserSessions not implemented")
+}
+
 // Helper function to create a new test context
 func newTestContext(method, path string, body []byte) (echo.Context, *httptest.ResponseRecorder) {
 	e := echo.New()
@@ -162,8 +351,8 @@ func setupHandlerTest() (*trips.Handler, *MockTripService, *MockSessionService)
 			UserID:      userID,
 			Name:        input.Name,
 			Description: input.Description,
-			StartDate:   input.StartDate,
-			EndDate:     input.EndDate,
+			StartDate:   timePtr(input.StartDate),
+			EndDate:     timePtr(input.EndDate),
 			Location:    input.Location,
 			CreatedAt:   time.Now(),
 			UpdatedAt:   time.Now(),
@@ -176,8 +365,8 @@ func setupHandlerTest() (*trips.Handler, *MockTripService, *MockSessionService)
 			UserID:      userID,
 			Name:        "Test Trip",
 			Description: "Test Description",
-			StartDate:   time.Now().Add(24 * time.Hour),
-			EndDate:     time.Now().Add(7 * 24 * time.Hour),
+			StartDate:   timePtr(time.Now().Add(24 * time.Hour)),
+			EndDate:     timePtr(time.Now().Add(7 * 24 * time.Hour)),
 			Location:    "Test City",
 			CreatedAt:   time.Now(),
 			UpdatedAt:   time.Now(),
@@ -191,8 +380,8 @@ func setupHandlerTest() (*trips.Handler, *MockTripService, *MockSessionService)
 			UserID:      userID,
 			Name:        "Original Trip",
 			Description: "Original Description",
-			StartDate:   time.Now().Add(24 * time.Hour),
-			EndDate:     time.Now().Add(7 * 24 * time.Hour),
+			StartDate:   timePtr(time.Now().Add(24 * time.Hour)),
+			EndDate:     timePtr(time.Now().Add(7 * 24 * time.Hour)),
 			Location:    "Original City",
 			CreatedAt:   time.Now(),
 			UpdatedAt:   time.Now(),
@@ -206,10 +395,10 @@ func setupHandlerTest() (*trips.Handler, *MockTripService, *MockSessionService)
 			trip.Description = *input.Description
 		}
 		if input.StartDate != nil {
-			trip.StartDate = *input.StartDate
+			trip.StartDate = input.StartDate
 		}
 		if input.EndDate != nil {
-			trip.EndDate = *input.EndDate
+			trip.EndDate = input.EndDate
 		}
 		if input.Location != nil {
 			trip.Location = *input.Location
@@ -222,15 +411,15 @@ func setupHandlerTest() (*trips.Handler, *MockTripService, *MockSessionService)
 		return nil
 	}
 
-	mockService.getTripsByUserIDFunc = func(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*models.Trip, error) {
+	mockService.getTripsByUserIDFunc = func(ctx context.Context, userID uuid.UUID, limit, offset int, filter models.TripFilter) ([]*models.Trip, error) {
 		return []*models.Trip{
 			{
 				ID:          uuid.New(),
 				UserID:      userID,
 				Name:        "Trip 1",
 				Description: "Description 1",
-				StartDate:   time.Now().Add(24 * time.Hour),
-				EndDate:     time.Now().Add(7 * 24 * time.Hour),
+				StartDate:   timePtr(time.Now().Add(24 * time.Hour)),
+				EndDate:     timePtr(time.Now().Add(7 * 24 * time.Hour)),
 				Location:    "Location 1",
 				CreatedAt:   time.Now(),
 				UpdatedAt:   time.Now(),
@@ -240,8 +429,8 @@ func setupHandlerTest() (*trips.Handler, *MockTripService, *MockSessionService)
 				UserID:      userID,
 				Name:        "Trip 2",
 				Description: "Description 2",
-				StartDate:   time.Now().Add(14 * 24 * time.Hour),
-				EndDate:     time.Now().Add(21 * 24 * time.Hour),
+				StartDate:   timePtr(time.Now().Add(14 * 24 * time.Hour)),
+				EndDate:     timePtr(time.Now().Add(21 * 24 * time.Hour)),
 				Location:    "Location 2",
 				CreatedAt:   time.Now(),
 				UpdatedAt:   time.Now(),
@@ -288,8 +477,8 @@ func TestHandlerCreateTrip(t *testing.T) {
 						UserID:      uid,
 						Name:        input.Name,
 						Description: input.Description,
-						StartDate:   input.StartDate,
-						EndDate:     input.EndDate,
+						StartDate:   timePtr(input.StartDate),
+						EndDate:     timePtr(input.EndDate),
 						Location:    input.Location,
 						CreatedAt:   time.Now(),
 						UpdatedAt:   time.Now(),
@@ -525,11 +714,11 @@ func TestHandlerCreateTrip(t *testing.T) {
 				}
 
 				// Verify response body
-				var response map[string]string
-				json.Unmarshal(rec.Body.Bytes(), &response)
+				var envelope response.ErrorEnvelope
+				json.Unmarshal(rec.Body.Bytes(), &envelope)
 
-				if response["error"] != "Invalid request body" {
-					t.Errorf("Expected error message 'Invalid request body', got '%s'", response["error"])
+				if envelope.Error.Message != "Invalid request body" {
+					t.Errorf("Expected error message 'Invalid request body', got '%s'", envelope.Error.Message)
 				}
 			}
 
@@ -568,10 +757,10 @@ func TestHandlerCreateTrip(t *testing.T) {
 					t.Errorf("Expected trip name '%s', got '%s'", tc.input.Name, trip.Name)
 				}
 			} else {
-				var errorResponse map[string]interface{}
+				var errorResponse response.ErrorEnvelope
 				json.Unmarshal(rec.Body.Bytes(), &errorResponse)
 
-				if errorResponse["error"] == nil && errorResponse["details"] == nil {
+				if errorResponse.Error.Message == "" && errorResponse.Error.Details == nil {
 					t.Error("Expected error message in response")
 				}
 			}
@@ -579,6 +768,327 @@ func TestHandlerCreateTrip(t *testing.T) {
 	}
 }
 
+func TestHandlerCreateTripLocationRequirement(t *testing.T) {
+	testCases := []struct {
+		name             string
+		locationRequired string
+		expectedStatus   int
+	}{
+		{name: "LocationRequired", locationRequired: "true", expectedStatus: http.StatusBadRequest},
+		{name: "LocationOptional", locationRequired: "false", expectedStatus: http.StatusCreated},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup - the rules are read when the handler builds its validator
+			t.Setenv("TRIP_LOCATION_REQUIRED", tc.locationRequired)
+			handler, mockService, mockSession := setupHandlerTest()
+			userID := uuid.New()
+
+			mockSession.validateAccessTokenFunc = func(ctx context.Context, token string) (*models.Session, error) {
+				return createTestSession(userID, token, "valid_refresh_token"), nil
+			}
+			mockService.createTripFunc = func(ctx context.Context, uid uuid.UUID, input models.CreateTripInput) (*models.Trip, error) {
+				return &models.Trip{ID: uuid.New(), UserID: uid}, nil
+			}
+
+			body := `{"start_date": "2025-06-10T00:00:00Z", "end_date": "2025-06-15T00:00:00Z"}`
+			c, rec := newTestContext(http.MethodPost, "/api/trips", []byte(body))
+			addCookies(c, &http.Cookie{Name: "access_token", Value: "valid_access_token"})
+
+			// Execute
+			if err := handler.CreateTrip(c); err != nil {
+				t.Errorf("Expected no error, got: %v", err)
+			}
+
+			// Verify
+			checkResponseStatus(t, rec, tc.expectedStatus)
+
+			if tc.expectedStatus == http.StatusBadRequest {
+				var envelope response.ErrorEnvelope
+				if err := json.Unmarshal(rec.Body.Bytes(), &envelope); err != nil {
+					t.Fatalf("Failed to unmarshal response: %v", err)
+				}
+				details, _ := envelope.Error.Details.(map[string]interface{})
+				if details["location"] != "location is required" {
+					t.Errorf("Expected 'location is required', got %v", envelope.Error.Details)
+				}
+			}
+		})
+	}
+}
+
+func TestHandlerCreateTripLocalizedValidation(t *testing.T) {
+	// Setup
+	t.Setenv("TRIP_LOCATION_REQUIRED", "true")
+	handler, _, mockSession := setupHandlerTest()
+	userID := uuid.New()
+
+	mockSession.validateAccessTokenFunc = func(ctx context.Context, token string) (*models.Session, error) {
+		return createTestSession(userID, token, "valid_refresh_token"), nil
+	}
+
+	body := `{"start_date": "2025-06-10T00:00:00Z", "end_date": "2025-06-15T00:00:00Z"}`
+	c, rec := newTestContext(http.MethodPost, "/api/trips", []byte(body))
+	c.Request().Header.Set("Accept-Language", "es")
+	addCookies(c, &http.Cookie{Name: "access_token", Value: "valid_access_token"})
+
+	// Execute
+	if err := handler.CreateTrip(c); err != nil {
+		t.Errorf("Expected no error, got: %v", err)
+	}
+
+	// Verify
+	checkResponseStatus(t, rec, http.StatusBadRequest)
+
+	var envelope response.ErrorEnvelope
+	if err := json.Unmarshal(rec.Body.Bytes(), &envelope); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if envelope.Error.Message != "El cuerpo de la solicitud no es válido" {
+		t.Errorf("Expected Spanish message, got %q", envelope.Error.Message)
+	}
+	details, _ := envelope.Error.Details.(map[string]interface{})
+	if details["location"] != "location es obligatorio" {
+		t.Errorf("Expected 'location es obligatorio', got %v", envelope.Error.Details)
+	}
+}
+
+func TestHandlerCreateTripNormalizesDates(t *testing.T) {
+	testCases := []struct {
+		name          string
+		body          string
+		expectedStart time.Time
+		expectedEnd   time.Time
+	}{
+		{
+			name:          "DateOnly",
+			body:          `{"location": "Lisbon", "start_date": "2025-06-10", "end_date": "2025-06-12"}`,
+			expectedStart: time.Date(2025, time.June, 10, 0, 0, 0, 0, time.UTC),
+			expectedEnd:   time.Date(2025, time.June, 12, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:          "LegacyTimesKeepTheirDay",
+			body:          `{"location": "Lisbon", "start_date": "2025-06-10T00:30:00.900+02:00", "end_date": "2025-06-10T23:30:00-05:00"}`,
+			expectedStart: time.Date(2025, time.June, 10, 0, 0, 0, 0, time.UTC),
+			expectedEnd:   time.Date(2025, time.June, 10, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			handler, mockService, mockSession := setupHandlerTest()
+			userID := uuid.New()
+
+			mockSession.validateAccessTokenFunc = func(ctx context.Context, token string) (*models.Session, error) {
+				return createTestSession(userID, token, "valid_refresh_token"), nil
+			}
+			mockService.createTripFunc = func(ctx context.Context, uid uuid.UUID, input models.CreateTripInput) (*models.Trip, error) {
+				if !input.StartDate.Equal(tc.expectedStart) || input.StartDate.Location() != time.UTC {
+					t.Errorf("Expected start %v, got %v", tc.expectedStart, input.StartDate)
+				}
+				if !input.EndDate.Equal(tc.expectedEnd) {
+					t.Errorf("Expected end %v, got %v", tc.expectedEnd, input.EndDate)
+				}
+				return &models.Trip{ID: uuid.New(), UserID: uid, Location: input.Location}, nil
+			}
+
+			c, rec := newTestContext(http.MethodPost, "/api/trips", []byte(tc.body))
+			addCookies(c, &http.Cookie{Name: "access_token", Value: "valid_access_token"})
+
+			// Execute
+			if err := handler.CreateTrip(c); err != nil {
+				t.Errorf("Expected no error, got: %v", err)
+			}
+
+			// Verify
+			checkResponseStatus(t, rec, http.StatusCreated)
+		})
+	}
+}
+
+func TestHandlerTripDatesAsDays(t *testing.T) {
+	// Setup
+	handler, mockService, mockSession := setupHandlerTest()
+	userID := uuid.New()
+	startDate := time.Date(2030, time.June, 1, 0, 0, 0, 0, time.UTC)
+	endDate := time.Date(2030, time.June, 3, 0, 0, 0, 0, time.UTC)
+
+	mockSession.validateAccessTokenFunc = func(ctx context.Context, token string) (*models.Session, error) {
+		return createTestSession(userID, token, "valid_refresh_token"), nil
+	}
+	mockService.getTripByIDFunc = func(ctx context.Context, tid uuid.UUID, uid uuid.UUID) (*models.Trip, error) {
+		return &models.Trip{ID: tid, UserID: uid, Location: "Lisbon", StartDate: &startDate, EndDate: &endDate}, nil
+	}
+	mockService.createTripFunc = func(ctx context.Context, uid uuid.UUID, input models.CreateTripInput) (*models.Trip, error) {
+		return &models.Trip{ID: uuid.New(), UserID: uid, Location: input.Location}, nil
+	}
+
+	tripID := uuid.New()
+	c, rec := newTestContext(http.MethodGet, "/api/trips/"+tripID.String(), nil)
+	c.SetParamNames("id")
+	c.SetParamValues(tripID.String())
+	addCookies(c, &http.Cookie{Name: "access_token", Value: "valid_access_token"})
+
+	// Execute
+	if err := handler.GetTrip(c); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	// Verify
+	checkResponseStatus(t, rec, http.StatusOK)
+	var body map[string]interface{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if body["start_date"] != "2030-06-01" || body["end_date"] != "2030-06-03" {
+		t.Errorf("Expected date-only dates, got start %v and end %v", body["start_date"], body["end_date"])
+	}
+
+	var trip models.Trip
+	if err := json.Unmarshal(rec.Body.Bytes(), &trip); err != nil {
+		t.Fatalf("Failed to unmarshal trip: %v", err)
+	}
+	if trip.StartDate == nil || !trip.StartDate.Equal(startDate) || trip.EndDate == nil || !trip.EndDate.Equal(endDate) {
+		t.Errorf("Expected the dates to round-trip, got %v and %v", trip.StartDate, trip.EndDate)
+	}
+
+	// Bad dates and unknown fields are still rejected when binding
+	for _, body := range []string{
+		`{"location": "Lisbon", "start_date": "June 1st", "end_date": "2030-06-03"}`,
+		`{"location": "Lisbon", "start_date": "2030-06-01", "end_date": "2030-06-03", "nights": 2}`,
+	} {
+		c, rec := newTestContext(http.MethodPost, "/api/trips", []byte(body))
+		addCookies(c, &http.Cookie{Name: "access_token", Value: "valid_access_token"})
+
+		if err := handler.CreateTrip(c); err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+		checkResponseStatus(t, rec, http.StatusBadRequest)
+	}
+}
+
+func TestHandlerServiceValidationError(t *testing.T) {
+	testCases := []struct {
+		name          string
+		method        string
+		serviceErr    *trips.ValidationError
+		expectedCode  string
+		expectedField string
+	}{
+		{name: "CreateDatesRequired", method: http.MethodPost, serviceErr: trips.ErrDatesRequired, expectedCode: "validation_failed", expectedField: "start_date"},
+		{name: "CreateEndBeforeStart", method: http.MethodPost, serviceErr: trips.ErrEndBeforeStart, expectedCode: "invalid_date_range", expectedField: "end_date"},
+		{name: "UpdateEndBeforeStart", method: http.MethodPut, serviceErr: trips.ErrEndBeforeStart, expectedCode: "invalid_date_range", expectedField: "end_date"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			handler, mockService, mockSession := setupHandlerTest()
+			userID := uuid.New()
+			tripID := uuid.New()
+
+			mockSession.validateAccessTokenFunc = func(ctx context.Context, token string) (*models.Session, error) {
+				return createTestSession(userID, token, "valid_refresh_token"), nil
+			}
+			mockService.createTripFunc = func(ctx context.Context, uid uuid.UUID, input models.CreateTripInput) (*models.Trip, error) {
+				return nil, tc.serviceErr
+			}
+			mockService.updateTripFunc = func(ctx context.Context, tid uuid.UUID, uid uuid.UUID, input models.UpdateTripInput) (*models.Trip, error) {
+				return nil, tc.serviceErr
+			}
+
+			body := `{"location": "Lisbon", "start_date": "2025-06-10T00:00:00Z", "end_date": "2025-06-12T00:00:00Z"}`
+			c, rec := newTestContext(tc.method, "/api/trips", []byte(body))
+			addCookies(c, &http.Cookie{Name: "access_token", Value: "valid_access_token"})
+
+			// Execute
+			var err error
+			if tc.method == http.MethodPost {
+				err = handler.CreateTrip(c)
+			} else {
+				c.SetParamNames("id")
+				c.SetParamValues(tripID.String())
+				err = handler.UpdateTrip(c)
+			}
+			if err != nil {
+				t.Errorf("Expected no error, got: %v", err)
+			}
+
+			// Verify
+			checkResponseStatus(t, rec, http.StatusBadRequest)
+
+			var errorBody struct {
+				Error struct {
+					Code    string            `json:"code"`
+					Details map[string]string `json:"details"`
+				} `json:"error"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &errorBody); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+			if errorBody.Error.Code != tc.expectedCode {
+				t.Errorf("Expected code %q, got %q", tc.expectedCode, errorBody.Error.Code)
+			}
+			if errorBody.Error.Details[tc.expectedField] != tc.serviceErr.Message {
+				t.Errorf("Expected details[%s] = %q, got %v", tc.expectedField, tc.serviceErr.Message, errorBody.Error.Details)
+			}
+		})
+	}
+}
+
+func TestHandlerGetTripPhotos(t *testing.T) {
+	testCases := []struct {
+		name           string
+		query          string
+		expectedMode   string
+		expectedStatus int
+	}{
+		{name: "NoPhotos", query: "", expectedStatus: http.StatusOK},
+		{name: "Count", query: "?photos=count", expectedMode: models.TripPhotosCount, expectedStatus: http.StatusOK},
+		{name: "List", query: "?photos=list", expectedMode: models.TripPhotosList, expectedStatus: http.StatusOK},
+		{name: "InvalidMode", query: "?photos=all", expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			handler, mockService, mockSession := setupHandlerTest()
+			tripID := uuid.New()
+			userID := uuid.New()
+
+			mockSession.validateAccessTokenFunc = func(ctx context.Context, token string) (*models.Session, error) {
+				return createTestSession(userID, token, "valid_refresh_token"), nil
+			}
+			mockService.getTripByIDFunc = func(ctx context.Context, tid uuid.UUID, uid uuid.UUID) (*models.Trip, error) {
+				return &models.Trip{ID: tid, UserID: uid, Name: "Test Trip"}, nil
+			}
+			attachedMode := ""
+			mockService.attachTripPhotosFunc = func(ctx context.Context, trip *models.Trip, mode string) error {
+				attachedMode = mode
+				return nil
+			}
+
+			c, rec := newTestContext(http.MethodGet, "/api/trips/"+tripID.String()+tc.query, nil)
+			c.SetParamNames("id")
+			c.SetParamValues(tripID.String())
+			addCookies(c, &http.Cookie{Name: "access_token", Value: "valid_access_token"})
+
+			// Execute
+			if err := handler.GetTrip(c); err != nil {
+				t.Errorf("Expected no error, got: %v", err)
+			}
+
+			// Verify
+			checkResponseStatus(t, rec, tc.expectedStatus)
+			if attachedMode != tc.expectedMode {
+				t.Errorf("Expected photos mode %q, got %q", tc.expectedMode, attachedMode)
+			}
+		})
+	}
+}
+
 func TestHandlerGetTrip(t *testing.T) {
 	testCases := []struct {
 		name           string
@@ -607,8 +1117,8 @@ func TestHandlerGetTrip(t *testing.T) {
 							UserID:      userID,
 							Name:        "Test Trip",
 							Description: "Test Description",
-							StartDate:   time.Now().Add(24 * time.Hour),
-							EndDate:     time.Now().Add(7 * 24 * time.Hour),
+							StartDate:   timePtr(time.Now().Add(24 * time.Hour)),
+							EndDate:     timePtr(time.Now().Add(7 * 24 * time.Hour)),
 							Location:    "Test City",
 							CreatedAt:   time.Now(),
 							UpdatedAt:   time.Now(),
@@ -744,11 +1254,11 @@ func TestHandlerGetTrip(t *testing.T) {
 				checkResponseStatus(t, rec, tc.expectedStatus)
 
 				// Verify error response
-				var errorResponse map[string]string
+				var errorResponse response.ErrorEnvelope
 				json.Unmarshal(rec.Body.Bytes(), &errorResponse)
 
-				if errorResponse["error"] != "Invalid trip ID" {
-					t.Errorf("Expected error message 'Invalid trip ID', got '%s'", errorResponse["error"])
+				if errorResponse.Error.Message != "Invalid trip ID" {
+					t.Errorf("Expected error message 'Invalid trip ID', got '%s'", errorResponse.Error.Message)
 				}
 
 				// Skip the rest of the test
@@ -789,10 +1299,10 @@ func TestHandlerGetTrip(t *testing.T) {
 					t.Errorf("Expected trip ID %s, got %s", tripID, trip.ID)
 				}
 			} else {
-				var errorResponse map[string]string
+				var errorResponse response.ErrorEnvelope
 				json.Unmarshal(rec.Body.Bytes(), &errorResponse)
 
-				if errorResponse["error"] == "" {
+				if errorResponse.Error.Message == "" {
 					t.Error("Expected error message in response")
 				}
 			}
@@ -837,8 +1347,8 @@ func TestHandlerUpdateTrip(t *testing.T) {
 							UserID:      userID,
 							Name:        *input.Name,
 							Description: *input.Description,
-							StartDate:   *input.StartDate,
-							EndDate:     *input.EndDate,
+							StartDate:   timePtr(*input.StartDate),
+							EndDate:     timePtr(*input.EndDate),
 							Location:    *input.Location,
 							CreatedAt:   time.Now(),
 							UpdatedAt:   time.Now(),
@@ -931,6 +1441,29 @@ func TestHandlerUpdateTrip(t *testing.T) {
 			expectedStatus: http.StatusBadRequest,
 			expectedError:  true,
 		},
+		{
+			name: "CoverImageOnly",
+			updateInput: models.UpdateTripInput{
+				CoverImageURL: stringPtr("https://example.com/cover.jpg"),
+			},
+			setupCookies: []*http.Cookie{
+				{Name: "access_token", Value: "valid_access_token"},
+			},
+			setupMocks: func(t *testing.T, mockService *MockTripService, mockSession *MockSessionService, tripID, userID uuid.UUID) {
+				mockSession.validateAccessTokenFunc = func(ctx context.Context, token string) (*models.Session, error) {
+					return createTestSession(userID, token, "valid_refresh_token"), nil
+				}
+
+				mockService.updateTripFunc = func(ctx context.Context, tid uuid.UUID, uid uuid.UUID, input models.UpdateTripInput) (*models.Trip, error) {
+					if input.CoverImageURL == nil || *input.CoverImageURL != "https://example.com/cover.jpg" {
+						t.Errorf("Expected the cover image to be passed on, got %v", input.CoverImageURL)
+					}
+					return &models.Trip{ID: tid, UserID: uid, CoverImageURL: *input.CoverImageURL}, nil
+				}
+			},
+			expectedStatus: http.StatusOK,
+			expectedError:  false,
+		},
 		{
 			name: "ValidationError",
 			updateInput: models.UpdateTripInput{
@@ -951,7 +1484,7 @@ func TestHandlerUpdateTrip(t *testing.T) {
 
 				// Return validation error
 				mockService.updateTripFunc = func(ctx context.Context, tid uuid.UUID, uid uuid.UUID, input models.UpdateTripInput) (*models.Trip, error) {
-					return nil, errors.New("end date cannot be before start date")
+					return nil, trips.ErrEndBeforeStart
 				}
 			},
 			expectedStatus: http.StatusBadRequest,
@@ -1041,7 +1574,7 @@ func TestHandlerUpdateTrip(t *testing.T) {
 				}
 
 				mockService.updateTripFunc = func(ctx context.Context, tid uuid.UUID, uid uuid.UUID, input models.UpdateTripInput) (*models.Trip, error) {
-					return nil, errors.New("end date cannot be before start date")
+					return nil, trips.ErrEndBeforeStart
 				}
 			},
 			expectedStatus: http.StatusBadRequest,
@@ -1103,11 +1636,11 @@ func TestHandlerUpdateTrip(t *testing.T) {
 				checkResponseStatus(t, rec, http.StatusBadRequest)
 
 				// Verify response body
-				var response map[string]string
-				json.Unmarshal(rec.Body.Bytes(), &response)
+				var envelope response.ErrorEnvelope
+				json.Unmarshal(rec.Body.Bytes(), &envelope)
 
-				if response["error"] != "Invalid request body" {
-					t.Errorf("Expected error message 'Invalid request body', got '%s'", response["error"])
+				if envelope.Error.Message != "Invalid request body" {
+					t.Errorf("Expected error message 'Invalid request body', got '%s'", envelope.Error.Message)
 				}
 				return // Skip the rest of the test
 			}
@@ -1136,11 +1669,11 @@ func TestHandlerUpdateTrip(t *testing.T) {
 				checkResponseStatus(t, rec, tc.expectedStatus)
 
 				// Verify error response
-				var response map[string]string
-				json.Unmarshal(rec.Body.Bytes(), &response)
+				var envelope response.ErrorEnvelope
+				json.Unmarshal(rec.Body.Bytes(), &envelope)
 
-				if response["error"] != "Invalid trip ID" {
-					t.Errorf("Expected error message 'Invalid trip ID', got '%s'", response["error"])
+				if envelope.Error.Message != "Invalid trip ID" {
+					t.Errorf("Expected error message 'Invalid trip ID', got '%s'", envelope.Error.Message)
 				}
 				return // Skip the rest of the test
 			}
@@ -1185,16 +1718,15 @@ func TestHandlerUpdateTrip(t *testing.T) {
 				}
 			} else {
 				// For error cases, verify the error message
-				var response map[string]interface{}
-				err = json.Unmarshal(rec.Body.Bytes(), &response)
+				var envelope response.ErrorEnvelope
+				err = json.Unmarshal(rec.Body.Bytes(), &envelope)
 				if err != nil {
 					t.Fatalf("Failed to unmarshal error response: %v", err)
 				}
 
 				// Check that there is an error message
-				errorMsg, ok := response["error"]
-				if !ok || errorMsg == nil {
-					t.Errorf("Expected error message in response, got %v", response)
+				if envelope.Error.Message == "" {
+					t.Errorf("Expected error message in response, got %v", envelope)
 				}
 			}
 		})
@@ -1354,11 +1886,11 @@ func TestHandlerDeleteTrip(t *testing.T) {
 				checkResponseStatus(t, rec, tc.expectedStatus)
 
 				// Verify error response
-				var errorResponse map[string]string
+				var errorResponse response.ErrorEnvelope
 				json.Unmarshal(rec.Body.Bytes(), &errorResponse)
 
-				if errorResponse["error"] != "Invalid trip ID" {
-					t.Errorf("Expected error message 'Invalid trip ID', got '%s'", errorResponse["error"])
+				if errorResponse.Error.Message != "Invalid trip ID" {
+					t.Errorf("Expected error message 'Invalid trip ID', got '%s'", errorResponse.Error.Message)
 				}
 				return // Skip the rest of the test
 			}
@@ -1394,10 +1926,10 @@ func TestHandlerDeleteTrip(t *testing.T) {
 					t.Errorf("Expected success message, got: %s", response["message"])
 				}
 			} else {
-				var errorResponse map[string]string
+				var errorResponse response.ErrorEnvelope
 				json.Unmarshal(rec.Body.Bytes(), &errorResponse)
 
-				if errorResponse["error"] == "" {
+				if errorResponse.Error.Message == "" {
 					t.Error("Expected error message in response")
 				}
 			}
@@ -1432,7 +1964,7 @@ func TestHandlerGetUserTrips(t *testing.T) {
 					return nil, errors.New("invalid token")
 				}
 
-				mockService.getTripsByUserIDFunc = func(ctx context.Context, uid uuid.UUID, limit, offset int) ([]*models.Trip, error) {
+				mockService.getTripsByUserIDFunc = func(ctx context.Context, uid uuid.UUID, limit, offset int, filter models.TripFilter) ([]*models.Trip, error) {
 					if uid == userID && limit == 10 && offset == 0 {
 						return []*models.Trip{
 							{
@@ -1440,8 +1972,8 @@ func TestHandlerGetUserTrips(t *testing.T) {
 								UserID:      userID,
 								Name:        "Trip 1",
 								Description: "Description 1",
-								StartDate:   time.Now().Add(24 * time.Hour),
-								EndDate:     time.Now().Add(7 * 24 * time.Hour),
+								StartDate:   timePtr(time.Now().Add(24 * time.Hour)),
+								EndDate:     timePtr(time.Now().Add(7 * 24 * time.Hour)),
 								Location:    "Location 1",
 							},
 							{
@@ -1449,8 +1981,8 @@ func TestHandlerGetUserTrips(t *testing.T) {
 								UserID:      userID,
 								Name:        "Trip 2",
 								Description: "Description 2",
-								StartDate:   time.Now().Add(14 * 24 * time.Hour),
-								EndDate:     time.Now().Add(21 * 24 * time.Hour),
+								StartDate:   timePtr(time.Now().Add(14 * 24 * time.Hour)),
+								EndDate:     timePtr(time.Now().Add(21 * 24 * time.Hour)),
 								Location:    "Location 2",
 							},
 						}, nil
@@ -1502,7 +2034,7 @@ func TestHandlerGetUserTrips(t *testing.T) {
 					return nil, errors.New("invalid token")
 				}
 
-				mockService.getTripsByUserIDFunc = func(ctx context.Context, uid uuid.UUID, limit, offset int) ([]*models.Trip, error) {
+				mockService.getTripsByUserIDFunc = func(ctx context.Context, uid uuid.UUID, limit, offset int, filter models.TripFilter) ([]*models.Trip, error) {
 					return nil, errors.New("service error")
 				}
 			},
@@ -1524,7 +2056,7 @@ func TestHandlerGetUserTrips(t *testing.T) {
 					return nil, errors.New("invalid token")
 				}
 
-				mockService.getTripsByUserIDFunc = func(ctx context.Context, uid uuid.UUID, limit, offset int) ([]*models.Trip, error) {
+				mockService.getTripsByUserIDFunc = func(ctx context.Context, uid uuid.UUID, limit, offset int, filter models.TripFilter) ([]*models.Trip, error) {
 					return []*models.Trip{}, nil
 				}
 			},
@@ -1590,13 +2122,2705 @@ func TestHandlerGetUserTrips(t *testing.T) {
 					t.Errorf("Expected %d trips, got %d", tc.tripCount, len(trips))
 				}
 			} else {
-				var errorResponse map[string]string
+				var errorResponse response.ErrorEnvelope
 				json.Unmarshal(rec.Body.Bytes(), &errorResponse)
 
-				if errorResponse["error"] == "" {
+				if errorResponse.Error.Message == "" {
 					t.Error("Expected error message in response")
 				}
 			}
 		})
 	}
 }
+
+func TestHandlerRestoreTrip(t *testing.T) {
+	testCases := []struct {
+		name           string
+		tripID         string
+		setupCookies   []*http.Cookie
+		serviceErr     error
+		expectedStatus int
+	}{
+		{
+			name:           "SuccessfulRestore",
+			setupCookies:   []*http.Cookie{{Name: "access_token", Value: "valid_access_token"}},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "NoAccessToken",
+			setupCookies:   []*http.Cookie{},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "InvalidTripID",
+			tripID:         "not-a-uuid",
+			setupCookies:   []*http.Cookie{{Name: "access_token", Value: "valid_access_token"}},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "TripNotFound",
+			setupCookies:   []*http.Cookie{{Name: "access_token", Value: "valid_access_token"}},
+			serviceErr:     errors.New("trip not found"),
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "UnauthorizedAccess",
+			setupCookies:   []*http.Cookie{{Name: "access_token", Value: "valid_access_token"}},
+			serviceErr:     errors.New("unauthorized access to trip"),
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "RestoreWindowExpired",
+			setupCookies:   []*http.Cookie{{Name: "access_token", Value: "valid_access_token"}},
+			serviceErr:     errors.New("restore window has expired"),
+			expectedStatus: http.StatusGone,
+		},
+		{
+			name:           "ServiceError",
+			setupCookies:   []*http.Cookie{{Name: "access_token", Value: "valid_access_token"}},
+			serviceErr:     errors.New("database error"),
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			handler, mockService, mockSession := setupHandlerTest()
+			userID := uuid.New()
+			tripID := tc.tripID
+			if tripID == "" {
+				tripID = uuid.New().String()
+			}
+
+			mockSession.validateAccessTokenFunc = func(ctx context.Context, token string) (*models.Session, error) {
+				return createTestSession(userID, token, "valid_refresh_token"), nil
+			}
+			mockService.restoreTripFunc = func(ctx context.Context, tid uuid.UUID, uid uuid.UUID) (*models.Trip, error) {
+				if tc.serviceErr != nil {
+					return nil, tc.serviceErr
+				}
+				return &models.Trip{ID: tid, UserID: uid, Name: "Restored Trip"}, nil
+			}
+
+			c, rec := newTestContext(http.MethodPost, "/api/trips/"+tripID+"/restore", nil)
+			c.SetParamNames("id")
+			c.SetParamValues(tripID)
+			addCookies(c, tc.setupCookies...)
+
+			// Execute
+			if err := handler.RestoreTrip(c); err != nil {
+				t.Errorf("Expected no error, got: %v", err)
+			}
+
+			// Verify
+			checkResponseStatus(t, rec, tc.expectedStatus)
+
+			if tc.expectedStatus == http.StatusOK {
+				var trip models.Trip
+				if err := json.Unmarshal(rec.Body.Bytes(), &trip); err != nil {
+					t.Fatalf("Failed to unmarshal response: %v", err)
+				}
+				if trip.ID.String() != tripID {
+					t.Errorf("Expected trip ID %s, got %s", tripID, trip.ID)
+				}
+			}
+		})
+	}
+}
+
+func TestHandlerPlanTrip(t *testing.T) {
+	validBody := []byte(`{"start_date":"2030-06-01T00:00:00Z","end_date":"2030-06-08T00:00:00Z"}`)
+
+	testCases := []struct {
+		name           string
+		body           []byte
+		setupCookies   []*http.Cookie
+		serviceErr     error
+		expectedStatus int
+	}{
+		{
+			name:           "SuccessfulPlan",
+			body:           validBody,
+			setupCookies:   []*http.Cookie{{Name: "access_token", Value: "valid_access_token"}},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "NoAccessToken",
+			body:           validBody,
+			setupCookies:   []*http.Cookie{},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "MissingDates",
+			body:           []byte(`{}`),
+			setupCookies:   []*http.Cookie{{Name: "access_token", Value: "valid_access_token"}},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "ReversedDates",
+			body:           []byte(`{"start_date":"2030-06-08T00:00:00Z","end_date":"2030-06-01T00:00:00Z"}`),
+			setupCookies:   []*http.Cookie{{Name: "access_token", Value: "valid_access_token"}},
+			serviceErr:     trips.ErrEndBeforeStart,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "AlreadyPlanned",
+			body:           validBody,
+			setupCookies:   []*http.Cookie{{Name: "access_token", Value: "valid_access_token"}},
+			serviceErr:     errors.New("trip is already planned"),
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:           "TripNotFound",
+			body:           validBody,
+			setupCookies:   []*http.Cookie{{Name: "access_token", Value: "valid_access_token"}},
+			serviceErr:     errors.New("trip not found"),
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "UnauthorizedAccess",
+			body:           validBody,
+			setupCookies:   []*http.Cookie{{Name: "access_token", Value: "valid_access_token"}},
+			serviceErr:     errors.New("unauthorized access to trip"),
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "ServiceError",
+			body:           validBody,
+			setupCookies:   []*http.Cookie{{Name: "access_token", Value: "valid_access_token"}},
+			serviceErr:     errors.New("database error"),
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			handler, mockService, mockSession := setupHandlerTest()
+			userID := uuid.New()
+			tripID := uuid.New().String()
+
+			mockSession.validateAccessTokenFunc = func(ctx context.Context, token string) (*models.Session, error) {
+				return createTestSession(userID, token, "valid_refresh_token"), nil
+			}
+			mockService.planTripFunc = func(ctx context.Context, tid uuid.UUID, uid uuid.UUID, input models.PlanTripInput) (*models.Trip, error) {
+				if tc.serviceErr != nil {
+					return nil, tc.serviceErr
+				}
+				return &models.Trip{ID: tid, UserID: uid, Name: "Planned Trip", StartDate: &input.StartDate, EndDate: &input.EndDate}, nil
+			}
+
+			c, rec := newTestContext(http.MethodPost, "/api/trips/"+tripID+"/plan", tc.body)
+			c.SetParamNames("id")
+			c.SetParamValues(tripID)
+			addCookies(c, tc.setupCookies...)
+
+			// Execute
+			if err := handler.PlanTrip(c); err != nil {
+				t.Errorf("Expected no error, got: %v", err)
+			}
+
+			// Verify
+			checkResponseStatus(t, rec, tc.expectedStatus)
+
+			if tc.expectedStatus == http.StatusOK {
+				var trip models.Trip
+				if err := json.Unmarshal(rec.Body.Bytes(), &trip); err != nil {
+					t.Fatalf("Failed to unmarshal response: %v", err)
+				}
+				if trip.IsWishlist || trip.StartDate == nil || trip.EndDate == nil {
+					t.Errorf("Expected a planned trip with dates, got %+v", trip)
+				}
+			}
+		})
+	}
+}
+
+func TestHandlerExportTrip(t *testing.T) {
+	testCases := []struct {
+		name           string
+		setupCookies   []*http.Cookie
+		serviceErr     error
+		expectedStatus int
+	}{
+		{
+			name:           "SuccessfulExport",
+			setupCookies:   []*http.Cookie{{Name: "access_token", Value: "valid_access_token"}},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "AccessTokenExpired",
+			setupCookies:   []*http.Cookie{{Name: "refresh_token", Value: "valid_refresh_token"}},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "TripNotFound",
+			setupCookies:   []*http.Cookie{{Name: "access_token", Value: "valid_access_token"}},
+			serviceErr:     errors.New("trip not found"),
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "UnauthorizedAccess",
+			setupCookies:   []*http.Cookie{{Name: "access_token", Value: "valid_access_token"}},
+			serviceErr:     errors.New("unauthorized access to trip"),
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			handler, mockService, mockSession := setupHandlerTest()
+			userID := uuid.New()
+			tripID := uuid.New()
+
+			mockSession.validateAccessTokenFunc = func(ctx context.Context, token string) (*models.Session, error) {
+				return createTestSession(userID, token, "valid_refresh_token"), nil
+			}
+			mockService.exportTripFunc = func(ctx context.Context, tid uuid.UUID, uid uuid.UUID) (*models.TripExport, error) {
+				if tc.serviceErr != nil {
+					return nil, tc.serviceErr
+				}
+				return &models.TripExport{
+					Version: models.TripExportVersion,
+					Trip:    models.CreateTripInput{Name: "Exported Trip", Location: "Lisbon"},
+				}, nil
+			}
+
+			c, rec := newTestContext(http.MethodGet, "/api/trips/"+tripID.String()+"/export.json", nil)
+			c.SetParamNames("id")
+			c.SetParamValues(tripID.String())
+			addCookies(c, tc.setupCookies...)
+
+			// Execute
+			if err := handler.ExportTrip(c); err != nil {
+				t.Errorf("Expected no error, got: %v", err)
+			}
+
+			// Verify
+			checkResponseStatus(t, rec, tc.expectedStatus)
+
+			if tc.expectedStatus == http.StatusOK {
+				if disposition := rec.Header().Get(echo.HeaderContentDisposition); disposition == "" {
+					t.Error("Expected Content-Disposition header to be set")
+				}
+
+				var export models.TripExport
+				if err := json.Unmarshal(rec.Body.Bytes(), &export); err != nil {
+					t.Fatalf("Failed to unmarshal response: %v", err)
+				}
+				if export.Trip.Name != "Exported Trip" {
+					t.Errorf("Expected exported trip name 'Exported Trip', got '%s'", export.Trip.Name)
+				}
+			}
+		})
+	}
+}
+
+func TestHandlerImportTrip(t *testing.T) {
+	validExport := models.TripExport{
+		Version: models.TripExportVersion,
+		Trip: models.CreateTripInput{
+			Name:      "Imported Trip",
+			StartDate: time.Now().Add(24 * time.Hour),
+			EndDate:   time.Now().Add(3 * 24 * time.Hour),
+			Location:  "Oslo",
+		},
+	}
+
+	testCases := []struct {
+		name           string
+		body           interface{}
+		importErrors   []models.TripImportError
+		serviceErr     error
+		expectedStatus int
+	}{
+		{
+			name:           "SuccessfulImport",
+			body:           validExport,
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "MissingTripFields",
+			body:           models.TripExport{Version: models.TripExportVersion},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "InvalidItemsReported",
+			body:           validExport,
+			importErrors:   []models.TripImportError{{Section: "trip", Message: "end date cannot be before start date"}},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "UnsupportedVersion",
+			body:           validExport,
+			serviceErr:     errors.New("unsupported export version"),
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "ServiceError",
+			body:           validExport,
+			serviceErr:     errors.New("database error"),
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			handler, mockService, mockSession := setupHandlerTest()
+			userID := uuid.New()
+
+			mockSession.validateAccessTokenFunc = func(ctx context.Context, token string) (*models.Session, error) {
+				return createTestSession(userID, token, "valid_refresh_token"), nil
+			}
+			mockService.importTripFunc = func(ctx context.Context, uid uuid.UUID, export models.TripExport) (*models.TripImportResult, []models.TripImportError, error) {
+				if tc.serviceErr != nil || len(tc.importErrors) > 0 {
+					return nil, tc.importErrors, tc.serviceErr
+				}
+				return &models.TripImportResult{
+					Trip:     &models.Trip{ID: uuid.New(), UserID: uid, Name: export.Trip.Name},
+					Imported: map[string]int{"trips": 1},
+				}, nil, nil
+			}
+
+			body, _ := json.Marshal(tc.body)
+			c, rec := newTestContext(http.MethodPost, "/api/trips/import-one", body)
+			addCookies(c, &http.Cookie{Name: "access_token", Value: "valid_access_token"})
+
+			// Execute
+			if err := handler.ImportTrip(c); err != nil {
+				t.Errorf("Expected no error, got: %v", err)
+			}
+
+			// Verify
+			checkResponseStatus(t, rec, tc.expectedStatus)
+
+			if tc.expectedStatus == http.StatusCreated {
+				var result models.TripImportResult
+				if err := json.Unmarshal(rec.Body.Bytes(), &result); err != nil {
+					t.Fatalf("Failed to unmarshal response: %v", err)
+				}
+				if result.Trip == nil || result.Trip.UserID != userID {
+					t.Errorf("Expected imported trip owned by %s, got %+v", userID, result.Trip)
+				}
+			}
+		})
+	}
+}
+
+func TestHandlerAddTripTag(t *testing.T) {
+	testCases := []struct {
+		name           string
+		body           string
+		setupCookies   []*http.Cookie
+		serviceErr     error
+		expectedStatus int
+	}{
+		{
+			name:           "SuccessfulAdd",
+			body:           `{"name": "Business"}`,
+			setupCookies:   []*http.Cookie{{Name: "access_token", Value: "valid_access_token"}},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "NoAccessToken",
+			body:           `{"name": "Business"}`,
+			setupCookies:   []*http.Cookie{},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "MissingName",
+			body:           `{}`,
+			setupCookies:   []*http.Cookie{{Name: "access_token", Value: "valid_access_token"}},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "UnauthorizedAccess",
+			body:           `{"name": "Business"}`,
+			setupCookies:   []*http.Cookie{{Name: "access_token", Value: "valid_access_token"}},
+			serviceErr:     errors.New("unauthorized access to trip"),
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "TripNotFound",
+			body:           `{"name": "Business"}`,
+			setupCookies:   []*http.Cookie{{Name: "access_token", Value: "valid_access_token"}},
+			serviceErr:     errors.New("trip not found"),
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			handler, mockService, mockSession := setupHandlerTest()
+			userID := uuid.New()
+			tripID := uuid.New().String()
+
+			mockSession.validateAccessTokenFunc = func(ctx context.Context, token string) (*models.Session, error) {
+				return createTestSession(userID, token, "valid_refresh_token"), nil
+			}
+			mockService.addTripTagFunc = func(ctx context.Context, tid uuid.UUID, uid uuid.UUID, input models.AddTripTagInput) ([]*models.Tag, error) {
+				if tc.serviceErr != nil {
+					return nil, tc.serviceErr
+				}
+				return []*models.Tag{{ID: uuid.New(), UserID: uid, Name: "business"}}, nil
+			}
+
+			c, rec := newTestContext(http.MethodPost, "/api/trips/"+tripID+"/tags", []byte(tc.body))
+			c.SetParamNames("id")
+			c.SetParamValues(tripID)
+			addCookies(c, tc.setupCookies...)
+
+			// Execute
+			if err := handler.AddTripTag(c); err != nil {
+				t.Errorf("Expected no error, got: %v", err)
+			}
+
+			// Verify
+			checkResponseStatus(t, rec, tc.expectedStatus)
+
+			if tc.expectedStatus == http.StatusOK {
+				var tags []*models.Tag
+				if err := json.Unmarshal(rec.Body.Bytes(), &tags); err != nil {
+					t.Fatalf("Failed to unmarshal response: %v", err)
+				}
+				if len(tags) != 1 || tags[0].Name != "business" {
+					t.Errorf("Expected the trip's tags in the response, got %v", tags)
+				}
+			}
+		})
+	}
+}
+
+func TestHandlerRemoveTripTag(t *testing.T) {
+	testCases := []struct {
+		name           string
+		serviceErr     error
+		expectedStatus int
+		expectedCode   string
+	}{
+		{
+			name:           "SuccessfulRemove",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "TagNotFound",
+			serviceErr:     errors.New("tag not found"),
+			expectedStatus: http.StatusNotFound,
+			expectedCode:   response.CodeTagNotFound,
+		},
+		{
+			name:           "UnauthorizedAccess",
+			serviceErr:     errors.New("unauthorized access to trip"),
+			expectedStatus: http.StatusForbidden,
+			expectedCode:   response.CodeForbidden,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			handler, mockService, mockSession := setupHandlerTest()
+			userID := uuid.New()
+			tripID := uuid.New().String()
+
+			mockSession.validateAccessTokenFunc = func(ctx context.Context, token string) (*models.Session, error) {
+				return createTestSession(userID, token, "valid_refresh_token"), nil
+			}
+			mockService.removeTripTagFunc = func(ctx context.Context, tid uuid.UUID, uid uuid.UUID, name string) error {
+				if name != "business" {
+					t.Errorf("Expected tag name 'business', got '%s'", name)
+				}
+				return tc.serviceErr
+			}
+
+			c, rec := newTestContext(http.MethodDelete, "/api/trips/"+tripID+"/tags/business", nil)
+			c.SetParamNames("id", "tag")
+			c.SetParamValues(tripID, "business")
+			addCookies(c, &http.Cookie{Name: "access_token", Value: "valid_access_token"})
+
+			// Execute
+			if err := handler.RemoveTripTag(c); err != nil {
+				t.Errorf("Expected no error, got: %v", err)
+			}
+
+			// Verify
+			checkResponseStatus(t, rec, tc.expectedStatus)
+
+			if tc.expectedCode != "" {
+				var envelope response.ErrorEnvelope
+				json.Unmarshal(rec.Body.Bytes(), &envelope)
+				if envelope.Error.Code != tc.expectedCode {
+					t.Errorf("Expected code '%s', got '%s'", tc.expectedCode, envelope.Error.Code)
+				}
+			}
+		})
+	}
+}
+
+func TestHandlerGetUserTripsTagFilter(t *testing.T) {
+	handler, mockService, mockSession := setupHandlerTest()
+	userID := uuid.New()
+
+	mockSession.validateAccessTokenFunc = func(ctx context.Context, token string) (*models.Session, error) {
+		return createTestSession(userID, token, "valid_refresh_token"), nil
+	}
+
+	var receivedFilter models.TripFilter
+	mockService.getTripsByUserIDFunc = func(ctx context.Context, uid uuid.UUID, limit, offset int, filter models.TripFilter) ([]*models.Trip, error) {
+		receivedFilter = filter
+		return []*models.Trip{}, nil
+	}
+
+	c, rec := newTestContext(http.MethodGet, "/api/trips?tag=vacation", nil)
+	addCookies(c, &http.Cookie{Name: "access_token", Value: "valid_access_token"})
+
+	if err := handler.GetUserTrips(c); err != nil {
+		t.Errorf("Expected no error, got: %v", err)
+	}
+
+	checkResponseStatus(t, rec, http.StatusOK)
+	if receivedFilter.Tag != "vacation" {
+		t.Errorf("Expected tag filter 'vacation', got '%s'", receivedFilter.Tag)
+	}
+}
+
+func TestHandlerGetUserTripsPageSize(t *testing.T) {
+	testCases := []struct {
+		name          string
+		query         string
+		expectedLimit int
+	}{
+		{name: "LimitAbsent", query: "", expectedLimit: 5},
+		{name: "LimitZero", query: "?limit=0", expectedLimit: 5},
+		{name: "WithinMax", query: "?limit=15", expectedLimit: 15},
+		{name: "AboveMax", query: "?limit=1000", expectedLimit: 20},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			t.Setenv("DEFAULT_PAGE_SIZE", "5")
+			t.Setenv("MAX_PAGE_SIZE", "20")
+			handler, mockService, mockSession := setupHandlerTest()
+			userID := uuid.New()
+
+			mockSession.validateAccessTokenFunc = func(ctx context.Context, token string) (*models.Session, error) {
+				return createTestSession(userID, token, "valid_refresh_token"), nil
+			}
+
+			var receivedLimit int
+			mockService.getTripsByUserIDFunc = func(ctx context.Context, uid uuid.UUID, limit, offset int, filter models.TripFilter) ([]*models.Trip, error) {
+				receivedLimit = limit
+				return []*models.Trip{}, nil
+			}
+
+			c, rec := newTestContext(http.MethodGet, "/api/trips"+tc.query, nil)
+			addCookies(c, &http.Cookie{Name: "access_token", Value: "valid_access_token"})
+
+			// Execute
+			if err := handler.GetUserTrips(c); err != nil {
+				t.Errorf("Expected no error, got: %v", err)
+			}
+
+			// Verify
+			checkResponseStatus(t, rec, http.StatusOK)
+			if receivedLimit != tc.expectedLimit {
+				t.Errorf("Expected limit %d, got %d", tc.expectedLimit, receivedLimit)
+			}
+		})
+	}
+}
+
+func TestHandlerReorderTrips(t *testing.T) {
+	tripIDs := []uuid.UUID{uuid.New(), uuid.New()}
+
+	testCases := []struct {
+		name           string
+		body           string
+		serviceErr     error
+		expectedStatus int
+	}{
+		{
+			name:           "SuccessfulReorder",
+			body:           `{"trip_ids": ["` + tripIDs[1].String() + `", "` + tripIDs[0].String() + `"]}`,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "EmptyList",
+			body:           `{"trip_ids": []}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "InvalidID",
+			body:           `{"trip_ids": ["not-a-uuid"]}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "TripsOfAnotherUser",
+			body:           `{"trip_ids": ["` + uuid.New().String() + `"]}`,
+			serviceErr:     errors.New("invalid trip order"),
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler, mockService, mockSession := setupHandlerTest()
+			userID := uuid.New()
+
+			mockSession.validateAccessTokenFunc = func(ctx context.Context, token string) (*models.Session, error) {
+				return createTestSession(userID, token, "valid_refresh_token"), nil
+			}
+
+			var received []uuid.UUID
+			mockService.reorderTripsFunc = func(ctx context.Context, uid uuid.UUID, input models.ReorderTripsInput) error {
+				received = input.TripIDs
+				return tc.serviceErr
+			}
+
+			c, rec := newTestContext(http.MethodPut, "/api/trips/order", []byte(tc.body))
+			addCookies(c, &http.Cookie{Name: "access_token", Value: "valid_access_token"})
+
+			if err := handler.ReorderTrips(c); err != nil {
+				t.Errorf("Expected no error, got: %v", err)
+			}
+
+			checkResponseStatus(t, rec, tc.expectedStatus)
+			if tc.expectedStatus == http.StatusOK && (len(received) != 2 || received[0] != tripIDs[1]) {
+				t.Errorf("Expected the order to be passed through, got %v", received)
+			}
+		})
+	}
+}
+
+func TestHandlerGetUserTripsManualSort(t *testing.T) {
+	handler, mockService, mockSession := setupHandlerTest()
+	userID := uuid.New()
+
+	mockSession.validateAccessTokenFunc = func(ctx context.Context, token string) (*models.Session, error) {
+		return createTestSession(userID, token, "valid_refresh_token"), nil
+	}
+
+	var receivedFilter models.TripFilter
+	mockService.getTripsByUserIDFunc = func(ctx context.Context, uid uuid.UUID, limit, offset int, filter models.TripFilter) ([]*models.Trip, error) {
+		receivedFilter = filter
+		return []*models.Trip{}, nil
+	}
+
+	c, rec := newTestContext(http.MethodGet, "/api/trips?sort=manual", nil)
+	addCookies(c, &http.Cookie{Name: "access_token", Value: "valid_access_token"})
+
+	if err := handler.GetUserTrips(c); err != nil {
+		t.Errorf("Expected no error, got: %v", err)
+	}
+
+	checkResponseStatus(t, rec, http.StatusOK)
+	if receivedFilter.Sort != models.TripSortManual {
+		t.Errorf("Expected sort 'manual', got '%s'", receivedFilter.Sort)
+	}
+}
+
+func TestHandlerGetUserTripsUnknownSortField(t *testing.T) {
+	handler, mockService, mockSession := setupHandlerTest()
+	userID := uuid.New()
+
+	mockSession.validateAccessTokenFunc = func(ctx context.Context, token string) (*models.Session, error) {
+		return createTestSession(userID, token, "valid_refresh_token"), nil
+	}
+	mockService.getTripsByUserIDFunc = func(ctx context.Context, uid uuid.UUID, limit, offset int, filter models.TripFilter) ([]*models.Trip, error) {
+		if filter.Sort != "location:asc" {
+			t.Errorf("Expected sort 'location:asc', got '%s'", filter.Sort)
+		}
+		return nil, errors.New("invalid sort option")
+	}
+
+	c, rec := newTestContext(http.MethodGet, "/api/trips?sort=location:asc", nil)
+	addCookies(c, &http.Cookie{Name: "access_token", Value: "valid_access_token"})
+
+	if err := handler.GetUserTrips(c); err != nil {
+		t.Errorf("Expected no error, got: %v", err)
+	}
+
+	checkResponseStatus(t, rec, http.StatusBadRequest)
+}
+
+func TestHandlerGetTripConditional(t *testing.T) {
+	handler, mockService, mockSession := setupHandlerTest()
+	userID := uuid.New()
+	tripID := uuid.New()
+	updatedAt := time.Now().Add(-time.Hour)
+
+	mockSession.validateAccessTokenFunc = func(ctx context.Context, token string) (*models.Session, error) {
+		return createTestSession(userID, token, "valid_refresh_token"), nil
+	}
+	mockService.getTripByIDFunc = func(ctx context.Context, id uuid.UUID, uid uuid.UUID) (*models.Trip, error) {
+		return &models.Trip{ID: id, UserID: uid, Name: "Test Trip", UpdatedAt: updatedAt}, nil
+	}
+
+	getTrip := func(ifNoneMatch string) *httptest.ResponseRecorder {
+		c, rec := newTestContext(http.MethodGet, "/api/trips/"+tripID.String(), nil)
+		c.SetParamNames("id")
+		c.SetParamValues(tripID.String())
+		addCookies(c, &http.Cookie{Name: "access_token", Value: "valid_access_token"})
+		if ifNoneMatch != "" {
+			c.Request().Header.Set("If-None-Match", ifNoneMatch)
+		}
+		if err := handler.GetTrip(c); err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		return rec
+	}
+
+	first := getTrip("")
+	checkResponseStatus(t, first, http.StatusOK)
+	etag := first.Header().Get("ETag")
+	if etag == "" {
+		t.Fatal("Expected an ETag header")
+	}
+
+	unchanged := getTrip(etag)
+	checkResponseStatus(t, unchanged, http.StatusNotModified)
+	if unchanged.Body.Len() != 0 {
+		t.Errorf("Expected an empty body on 304, got %q", unchanged.Body.String())
+	}
+
+	// Updating the trip moves UpdatedAt, so the old ETag no longer matches
+	updatedAt = time.Now()
+	changed := getTrip(etag)
+	checkResponseStatus(t, changed, http.StatusOK)
+	if changed.Header().Get("ETag") == etag {
+		t.Error("Expected the ETag to change after an update")
+	}
+}
+
+func TestHandlerGetUserTripsConditional(t *testing.T) {
+	handler, mockService, mockSession := setupHandlerTest()
+	userID := uuid.New()
+
+	mockSession.validateAccessTokenFunc = func(ctx context.Context, token string) (*models.Session, error) {
+		return createTestSession(userID, token, "valid_refresh_token"), nil
+	}
+	trips := []*models.Trip{{ID: uuid.New(), UpdatedAt: time.Now()}}
+	mockService.getTripsByUserIDFunc = func(ctx context.Context, uid uuid.UUID, limit, offset int, filter models.TripFilter) ([]*models.Trip, error) {
+		return trips, nil
+	}
+
+	c, rec := newTestContext(http.MethodGet, "/api/trips", nil)
+	addCookies(c, &http.Cookie{Name: "access_token", Value: "valid_access_token"})
+	if err := handler.GetUserTrips(c); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	etag := rec.Header().Get("ETag")
+
+	c, rec = newTestContext(http.MethodGet, "/api/trips", nil)
+	addCookies(c, &http.Cookie{Name: "access_token", Value: "valid_access_token"})
+	c.Request().Header.Set("If-None-Match", etag)
+	if err := handler.GetUserTrips(c); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	checkResponseStatus(t, rec, http.StatusNotModified)
+}
+
+func TestHandlerGetUserTripsListVersion(t *testing.T) {
+	handler, mockService, mockSession := setupHandlerTest()
+	userID := uuid.New()
+	latest := time.Now().Add(-time.Hour)
+	version := &models.TripListVersion{LatestUpdate: &latest, Count: 3, Started: 1}
+	listCalls := 0
+
+	mockSession.validateAccessTokenFunc = func(ctx context.Context, token string) (*models.Session, error) {
+		return createTestSession(userID, token, "valid_refresh_token"), nil
+	}
+	mockService.getTripListVersionFunc = func(ctx context.Context, uid uuid.UUID) (*models.TripListVersion, error) {
+		return version, nil
+	}
+	mockService.getTripsByUserIDFunc = func(ctx context.Context, uid uuid.UUID, limit, offset int, filter models.TripFilter) ([]*models.Trip, error) {
+		listCalls++
+		return []*models.Trip{{ID: uuid.New(), UpdatedAt: latest}}, nil
+	}
+
+	getTrips := func(path, ifNoneMatch string) *httptest.ResponseRecorder {
+		c, rec := newTestContext(http.MethodGet, path, nil)
+		addCookies(c, &http.Cookie{Name: "access_token", Value: "valid_access_token"})
+		if ifNoneMatch != "" {
+			c.Request().Header.Set("If-None-Match", ifNoneMatch)
+		}
+		if err := handler.GetUserTrips(c); err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		return rec
+	}
+
+	first := getTrips("/api/trips", "")
+	checkResponseStatus(t, first, http.StatusOK)
+	etag := first.Header().Get("ETag")
+	if etag == "" {
+		t.Fatal("Expected an ETag header")
+	}
+
+	// An unchanged list is answered from the version alone
+	unchanged := getTrips("/api/trips", etag)
+	checkResponseStatus(t, unchanged, http.StatusNotModified)
+	if unchanged.Header().Get("ETag") != etag {
+		t.Errorf("Expected the prior ETag %s, got %s", etag, unchanged.Header().Get("ETag"))
+	}
+	if listCalls != 1 {
+		t.Errorf("Expected the list to be loaded once, got %d", listCalls)
+	}
+
+	// Another page or filter is a different listing
+	if paged := getTrips("/api/trips?offset=10", etag); paged.Header().Get("ETag") == etag {
+		t.Error("Expected a different ETag for a different query")
+	}
+
+	// A trip starting moves the version even though no row changed
+	version = &models.TripListVersion{LatestUpdate: &latest, Count: 3, Started: 2}
+	changed := getTrips("/api/trips", etag)
+	checkResponseStatus(t, changed, http.StatusOK)
+	if changed.Header().Get("ETag") == etag {
+		t.Error("Expected the ETag to change with the list version")
+	}
+}
+
+func TestHandlerCreateTrips(t *testing.T) {
+	validTrip := `{"location": "Lisbon", "start_date": "2025-06-10T00:00:00Z", "end_date": "2025-06-15T00:00:00Z"}`
+	manyTrips := "[" + strings.TrimSuffix(strings.Repeat(validTrip+",", trips.MaxBulkTrips+1), ",") + "]"
+
+	testCases := []struct {
+		name           string
+		body           string
+		serviceErrors  []models.TripBatchError
+		expectedStatus int
+		expectedCount  int
+		expectedIndex  int
+	}{
+		{
+			name:           "SuccessfulCreation",
+			body:           "[" + validTrip + "," + validTrip + "]",
+			expectedStatus: http.StatusCreated,
+			expectedCount:  2,
+		},
+		{
+			name:           "EmptyBatch",
+			body:           `[]`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "BatchTooLarge",
+			body:           manyTrips,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "MissingLocationReportedByIndex",
+			body:           "[" + validTrip + `, {"start_date": "2025-06-10T00:00:00Z", "end_date": "2025-06-15T00:00:00Z"}]`,
+			expectedStatus: http.StatusBadRequest,
+			expectedIndex:  1,
+		},
+		{
+			name:           "ServiceValidationError",
+			body:           "[" + validTrip + "]",
+			serviceErrors:  []models.TripBatchError{{Index: 0, Field: "end_date", Message: "end date cannot be before start date"}},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler, mockService, mockSession := setupHandlerTest()
+			userID := uuid.New()
+
+			mockSession.validateAccessTokenFunc = func(ctx context.Context, token string) (*models.Session, error) {
+				return createTestSession(userID, token, "valid_refresh_token"), nil
+			}
+			mockService.createTripsFunc = func(ctx context.Context, uid uuid.UUID, inputs []models.CreateTripInput) ([]*models.Trip, []models.TripBatchError, error) {
+				if tc.serviceErrors != nil {
+					return nil, tc.serviceErrors, nil
+				}
+				created := make([]*models.Trip, 0, len(inputs))
+				for _, input := range inputs {
+					created = append(created, &models.Trip{ID: uuid.New(), UserID: uid, Location: input.Location})
+				}
+				return created, nil, nil
+			}
+
+			c, rec := newTestContext(http.MethodPost, "/api/trips/bulk", []byte(tc.body))
+			addCookies(c, &http.Cookie{Name: "access_token", Value: "valid_access_token"})
+
+			if err := handler.CreateTrips(c); err != nil {
+				t.Errorf("Expected no error, got: %v", err)
+			}
+
+			checkResponseStatus(t, rec, tc.expectedStatus)
+
+			if tc.expectedStatus == http.StatusCreated {
+				var created []*models.Trip
+				if err := json.Unmarshal(rec.Body.Bytes(), &created); err != nil {
+					t.Fatalf("Failed to unmarshal response: %v", err)
+				}
+				if len(created) != tc.expectedCount {
+					t.Errorf("Expected %d trips, got %d", tc.expectedCount, len(created))
+				}
+				return
+			}
+
+			if tc.expectedIndex > 0 {
+				var envelope struct {
+					Error struct {
+						Details []models.TripBatchError `json:"details"`
+					} `json:"error"`
+				}
+				if err := json.Unmarshal(rec.Body.Bytes(), &envelope); err != nil {
+					t.Fatalf("Failed to unmarshal response: %v", err)
+				}
+				if len(envelope.Error.Details) != 1 || envelope.Error.Details[0].Index != tc.expectedIndex {
+					t.Errorf("Expected an error for index %d, got %v", tc.expectedIndex, envelope.Error.Details)
+				}
+			}
+		})
+	}
+}
+
+func TestHandlerGetTripEnvelope(t *testing.T) {
+	handler, mockService, mockSession := setupHandlerTest()
+	userID := uuid.New()
+	tripID := uuid.New()
+
+	mockSession.validateAccessTokenFunc = func(ctx context.Context, token string) (*models.Session, error) {
+		return createTestSession(userID, token, "valid_refresh_token"), nil
+	}
+	mockService.getTripByIDFunc = func(ctx context.Context, id uuid.UUID, uid uuid.UUID) (*models.Trip, error) {
+		return &models.Trip{ID: id, UserID: uid, Name: "Test Trip", UpdatedAt: time.Now()}, nil
+	}
+
+	c, rec := newTestContext(http.MethodGet, "/api/trips/"+tripID.String(), nil)
+	c.SetParamNames("id")
+	c.SetParamValues(tripID.String())
+	c.Request().Header.Set(echo.HeaderAccept, `application/json; profile="envelope"`)
+	addCookies(c, &http.Cookie{Name: "access_token", Value: "valid_access_token"})
+
+	if err := handler.GetTrip(c); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	checkResponseStatus(t, rec, http.StatusOK)
+
+	var envelope struct {
+		Data models.Trip            `json:"data"`
+		Meta map[string]interface{} `json:"meta"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &envelope); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if envelope.Data.ID != tripID || envelope.Data.Name != "Test Trip" {
+		t.Errorf("Expected trip %s under data, got %+v", tripID, envelope.Data)
+	}
+	if envelope.Meta == nil {
+		t.Error("Expected meta to be present")
+	}
+}
+
+func TestHandlerCreateTripUnknownField(t *testing.T) {
+	handler, mockService, mockSession := setupHandlerTest()
+	userID := uuid.New()
+
+	mockSession.validateAccessTokenFunc = func(ctx context.Context, token string) (*models.Session, error) {
+		return createTestSession(userID, token, "valid_refresh_token"), nil
+	}
+	mockService.createTripFunc = func(ctx context.Context, uid uuid.UUID, input models.CreateTripInput) (*models.Trip, error) {
+		t.Error("CreateTrip should not be called for an unknown field")
+		return nil, nil
+	}
+
+	// "loction" is a typo for "location"
+	body := []byte(`{"loction": "Lisbon", "start_date": "2025-06-10T00:00:00Z", "end_date": "2025-06-15T00:00:00Z"}`)
+	c, rec := newTestContext(http.MethodPost, "/api/trips", body)
+	addCookies(c, &http.Cookie{Name: "access_token", Value: "valid_access_token"})
+
+	if err := handler.CreateTrip(c); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	checkResponseStatus(t, rec, http.StatusBadRequest)
+
+	var envelope struct {
+		Error struct {
+			Message string            `json:"message"`
+			Details map[string]string `json:"details"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &envelope); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if envelope.Error.Message != "Unknown field: loction" {
+		t.Errorf("Expected 'Unknown field: loction' error, got: %s", envelope.Error.Message)
+	}
+	if _, ok := envelope.Error.Details["loction"]; !ok {
+		t.Errorf("Expected the unknown field in details, got %v", envelope.Error.Details)
+	}
+}
+
+func TestHandlerTripInputsLenientJSON(t *testing.T) {
+	testCases := []struct {
+		name           string
+		method         string
+		body           string
+		call           func(*trips.Handler, echo.Context) error
+		expectedStatus int
+	}{
+		{
+			name:           "Create",
+			method:         http.MethodPost,
+			body:           `{"location": "Lisbon", "start_date": "2025-06-10", "end_date": "2025-06-15", "loction": "Lisbon"}`,
+			call:           (*trips.Handler).CreateTrip,
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "Update",
+			method:         http.MethodPut,
+			body:           `{"name": "Lisbon again", "nmae": "Lisbon again"}`,
+			call:           (*trips.Handler).UpdateTrip,
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup: the binder as the server builds it with STRICT_JSON=false
+			t.Setenv("STRICT_JSON", "false")
+			handler, mockService, mockSession := setupHandlerTest()
+			userID := uuid.New()
+			tripID := uuid.New()
+
+			mockSession.validateAccessTokenFunc = func(ctx context.Context, token string) (*models.Session, error) {
+				return createTestSession(userID, token, "valid_refresh_token"), nil
+			}
+			mockService.createTripFunc = func(ctx context.Context, uid uuid.UUID, input models.CreateTripInput) (*models.Trip, error) {
+				return &models.Trip{ID: tripID, UserID: uid, Location: input.Location}, nil
+			}
+			mockService.updateTripFunc = func(ctx context.Context, tid uuid.UUID, uid uuid.UUID, input models.UpdateTripInput) (*models.Trip, error) {
+				return &models.Trip{ID: tid, UserID: uid, Name: *input.Name}, nil
+			}
+
+			c, rec := newTestContext(tc.method, "/api/trips/"+tripID.String(), []byte(tc.body))
+			c.Echo().Binder = &binding.StrictBinder{AllowUnknownFields: !binding.StrictJSONFromEnv()}
+			c.SetParamNames("id")
+			c.SetParamValues(tripID.String())
+			addCookies(c, &http.Cookie{Name: "access_token", Value: "valid_access_token"})
+
+			// Execute
+			if err := tc.call(handler, c); err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			// Verify
+			if rec.Code != tc.expectedStatus {
+				t.Errorf("Expected status %d, got %d: %s", tc.expectedStatus, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandlerGetTripWithUser(t *testing.T) {
+	userID := uuid.New()
+	tripID := uuid.New()
+	hashedPassword := "$2a$10$secret"
+
+	testCases := []struct {
+		name           string
+		serviceErr     error
+		expectedStatus int
+	}{
+		{name: "Successful", expectedStatus: http.StatusOK},
+		{name: "TripNotFound", serviceErr: errors.New("trip not found"), expectedStatus: http.StatusNotFound},
+		{name: "NotOwner", serviceErr: errors.New("unauthorized access to trip"), expectedStatus: http.StatusForbidden},
+		{name: "ServiceError", serviceErr: errors.New("database error"), expectedStatus: http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler, mockService, mockSession := setupHandlerTest()
+
+			mockSession.validateAccessTokenFunc = func(ctx context.Context, token string) (*models.Session, error) {
+				return createTestSession(userID, token, "valid_refresh_token"), nil
+			}
+			mockService.getTripWithUserFunc = func(ctx context.Context, id uuid.UUID, requestUserID uuid.UUID) (*models.Trip, error) {
+				if requestUserID != userID {
+					t.Errorf("Expected requesting user %s, got %s", userID, requestUserID)
+				}
+				if tc.serviceErr != nil {
+					return nil, tc.serviceErr
+				}
+				return &models.Trip{
+					ID:     id,
+					UserID: userID,
+					Name:   "Test Trip",
+					User: &models.User{
+						ID:             userID,
+						Name:           "Test User",
+						Email:          "test@example.com",
+						HashedPassword: &hashedPassword,
+					},
+				}, nil
+			}
+
+			c, rec := newTestContext(http.MethodGet, "/api/trips/"+tripID.String()+"/with-user", nil)
+			c.SetParamNames("id")
+			c.SetParamValues(tripID.String())
+			addCookies(c, &http.Cookie{Name: "access_token", Value: "valid_access_token"})
+
+			if err := handler.GetTripWithUser(c); err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			checkResponseStatus(t, rec, tc.expectedStatus)
+			if tc.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var body map[string]interface{}
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+			user, ok := body["user"].(map[string]interface{})
+			if !ok {
+				t.Fatalf("Expected an embedded user, got %s", rec.Body.String())
+			}
+			if user["email"] != "test@example.com" {
+				t.Errorf("Expected owner email, got %v", user["email"])
+			}
+			if strings.Contains(rec.Body.String(), hashedPassword) {
+				t.Error("Expected the hashed password to be omitted")
+			}
+		})
+	}
+}
+
+func TestHandlerGetUserTripsWishlistFilter(t *testing.T) {
+	testCases := []struct {
+		name             string
+		query            string
+		expectedStatus   int
+		expectedWishlist *bool
+	}{
+		{name: "NoFilter", query: "", expectedStatus: http.StatusOK},
+		{name: "WishlistOnly", query: "?wishlist=true", expectedStatus: http.StatusOK, expectedWishlist: boolPtr(true)},
+		{name: "PlannedOnly", query: "?wishlist=false", expectedStatus: http.StatusOK, expectedWishlist: boolPtr(false)},
+		{name: "InvalidValue", query: "?wishlist=maybe", expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler, mockService, mockSession := setupHandlerTest()
+			userID := uuid.New()
+
+			mockSession.validateAccessTokenFunc = func(ctx context.Context, token string) (*models.Session, error) {
+				return createTestSession(userID, token, "valid_refresh_token"), nil
+			}
+			mockService.getTripsByUserIDFunc = func(ctx context.Context, uid uuid.UUID, limit, offset int, filter models.TripFilter) ([]*models.Trip, error) {
+				if (filter.Wishlist == nil) != (tc.expectedWishlist == nil) ||
+					(filter.Wishlist != nil && *filter.Wishlist != *tc.expectedWishlist) {
+					t.Errorf("Expected wishlist filter %v, got %v", tc.expectedWishlist, filter.Wishlist)
+				}
+				return []*models.Trip{}, nil
+			}
+
+			c, rec := newTestContext(http.MethodGet, "/api/trips"+tc.query, nil)
+			addCookies(c, &http.Cookie{Name: "access_token", Value: "valid_access_token"})
+
+			if err := handler.GetUserTrips(c); err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			checkResponseStatus(t, rec, tc.expectedStatus)
+		})
+	}
+}
+
+func TestHandlerPrintTrip(t *testing.T) {
+	// Setup
+	handler, mockService, mockSession := setupHandlerTest()
+	userID := uuid.New()
+	tripID := uuid.New()
+	startDate := time.Date(2030, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	mockSession.validateAccessTokenFunc = func(ctx context.Context, token string) (*models.Session, error) {
+		return createTestSession(userID, token, "valid_refresh_token"), nil
+	}
+	mockService.exportTripFunc = func(ctx context.Context, tid uuid.UUID, uid uuid.UUID) (*models.TripExport, error) {
+		return &models.TripExport{
+			Version: models.TripExportVersion,
+			Trip: models.CreateTripInput{
+				Name:        "Lisbon & Porto",
+				Description: `<script>alert("xss")</script>`,
+				StartDate:   startDate,
+				EndDate:     startDate.Add(7 * 24 * time.Hour),
+				Location:    "Portugal",
+			},
+			Activities: []models.CreateActivityInput{
+				{Title: `<img src=x onerror=alert(1)>`, StartTime: startDate, EndTime: startDate.Add(time.Hour)},
+			},
+		}, nil
+	}
+
+	c, rec := newTestContext(http.MethodGet, "/api/trips/"+tripID.String()+"/print", nil)
+	c.SetParamNames("id")
+	c.SetParamValues(tripID.String())
+	addCookies(c, &http.Cookie{Name: "access_token", Value: "valid_access_token"})
+
+	// Execute
+	if err := handler.PrintTrip(c); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	// Verify
+	checkResponseStatus(t, rec, http.StatusOK)
+
+	if contentType := rec.Header().Get(echo.HeaderContentType); !strings.HasPrefix(contentType, echo.MIMETextHTML) {
+		t.Errorf("Expected HTML content type, got %q", contentType)
+	}
+	if rec.Header().Get(echo.HeaderContentSecurityPolicy) == "" {
+		t.Error("Expected a Content-Security-Policy header")
+	}
+
+	page := rec.Body.String()
+	if !strings.Contains(page, "Lisbon &amp; Porto") {
+		t.Errorf("Expected page to contain the escaped trip name, got:\n%s", page)
+	}
+	if strings.Contains(page, "<script>") || strings.Contains(page, "<img") {
+		t.Errorf("Expected user content to be escaped, got:\n%s", page)
+	}
+	if !strings.Contains(page, "&lt;script&gt;alert(&#34;xss&#34;)&lt;/script&gt;") {
+		t.Errorf("Expected the escaped description in the page, got:\n%s", page)
+	}
+}
+
+func TestHandlerPrintTripErrors(t *testing.T) {
+	testCases := []struct {
+		name           string
+		serviceErr     error
+		expectedStatus int
+	}{
+		{name: "TripNotFound", serviceErr: errors.New("trip not found"), expectedStatus: http.StatusNotFound},
+		{name: "UnauthorizedAccess", serviceErr: errors.New("unauthorized access to trip"), expectedStatus: http.StatusForbidden},
+		{name: "ServiceError", serviceErr: errors.New("database error"), expectedStatus: http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			handler, mockService, mockSession := setupHandlerTest()
+			userID := uuid.New()
+			tripID := uuid.New().String()
+
+			mockSession.validateAccessTokenFunc = func(ctx context.Context, token string) (*models.Session, error) {
+				return createTestSession(userID, token, "valid_refresh_token"), nil
+			}
+			mockService.exportTripFunc = func(ctx context.Context, tid uuid.UUID, uid uuid.UUID) (*models.TripExport, error) {
+				return nil, tc.serviceErr
+			}
+
+			c, rec := newTestContext(http.MethodGet, "/api/trips/"+tripID+"/print", nil)
+			c.SetParamNames("id")
+			c.SetParamValues(tripID)
+			addCookies(c, &http.Cookie{Name: "access_token", Value: "valid_access_token"})
+
+			// Execute
+			if err := handler.PrintTrip(c); err != nil {
+				t.Errorf("Expected no error, got: %v", err)
+			}
+
+			// Verify
+			checkResponseStatus(t, rec, tc.expectedStatus)
+		})
+	}
+}
+
+func TestHandlerGetUserTripsDateRangeFilter(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		query                string
+		expectedStatus       int
+		expectedFrom         *time.Time
+		expectedTo           *time.Time
+		expectedStatusFilter string
+	}{
+		{name: "NoFilter", query: "", expectedStatus: http.StatusOK},
+		{
+			name:           "DateOnlyRangeCoversWholeDays",
+			query:          "?from=2024-01-01&to=2024-12-31",
+			expectedStatus: http.StatusOK,
+			expectedFrom:   timePtr(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)),
+			expectedTo:     timePtr(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC).Add(-time.Nanosecond)),
+		},
+		{
+			name:           "RFC3339Range",
+			query:          "?from=2024-03-01T09:00:00Z&to=2024-03-10T18:00:00Z",
+			expectedStatus: http.StatusOK,
+			expectedFrom:   timePtr(time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)),
+			expectedTo:     timePtr(time.Date(2024, 3, 10, 18, 0, 0, 0, time.UTC)),
+		},
+		{name: "StatusPassedThrough", query: "?status=upcoming", expectedStatus: http.StatusOK, expectedStatusFilter: models.TripStatusUpcoming},
+		{name: "InvalidFrom", query: "?from=01/01/2024", expectedStatus: http.StatusBadRequest},
+		{name: "InvalidTo", query: "?to=tomorrow", expectedStatus: http.StatusBadRequest},
+		{name: "ToBeforeFrom", query: "?from=2024-12-31&to=2024-01-01", expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler, mockService, mockSession := setupHandlerTest()
+			userID := uuid.New()
+
+			mockSession.validateAccessTokenFunc = func(ctx context.Context, token string) (*models.Session, error) {
+				return createTestSession(userID, token, "valid_refresh_token"), nil
+			}
+			mockService.getTripsByUserIDFunc = func(ctx context.Context, uid uuid.UUID, limit, offset int, filter models.TripFilter) ([]*models.Trip, error) {
+				if (filter.From == nil) != (tc.expectedFrom == nil) ||
+					(filter.From != nil && !filter.From.Equal(*tc.expectedFrom)) {
+					t.Errorf("Expected from %v, got %v", tc.expectedFrom, filter.From)
+				}
+				if (filter.To == nil) != (tc.expectedTo == nil) ||
+					(filter.To != nil && !filter.To.Equal(*tc.expectedTo)) {
+					t.Errorf("Expected to %v, got %v", tc.expectedTo, filter.To)
+				}
+				if filter.Status != tc.expectedStatusFilter {
+					t.Errorf("Expected status filter %q, got %q", tc.expectedStatusFilter, filter.Status)
+				}
+				return []*models.Trip{}, nil
+			}
+
+			c, rec := newTestContext(http.MethodGet, "/api/trips"+tc.query, nil)
+			addCookies(c, &http.Cookie{Name: "access_token", Value: "valid_access_token"})
+
+			if err := handler.GetUserTrips(c); err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			checkResponseStatus(t, rec, tc.expectedStatus)
+		})
+	}
+}
+
+func TestHandlerGetUserTripsInvalidStatus(t *testing.T) {
+	handler, mockService, mockSession := setupHandlerTest()
+	userID := uuid.New()
+
+	mockSession.validateAccessTokenFunc = func(ctx context.Context, token string) (*models.Session, error) {
+		return createTestSession(userID, token, "valid_refresh_token"), nil
+	}
+	mockService.getTripsByUserIDFunc = func(ctx context.Context, uid uuid.UUID, limit, offset int, filter models.TripFilter) ([]*models.Trip, error) {
+		return nil, errors.New("invalid status filter")
+	}
+
+	c, rec := newTestContext(http.MethodGet, "/api/trips?status=someday", nil)
+	addCookies(c, &http.Cookie{Name: "access_token", Value: "valid_access_token"})
+
+	if err := handler.GetUserTrips(c); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	checkResponseStatus(t, rec, http.StatusBadRequest)
+}
+
+func TestHandlerBulkTagTrips(t *testing.T) {
+	tripIDs := []uuid.UUID{uuid.New(), uuid.New(), uuid.New()}
+	validBody := fmt.Sprintf(`{"ids":["%s","%s","%s"],"tags":["beach"],"mode":"add"}`, tripIDs[0], tripIDs[1], tripIDs[2])
+
+	testCases := []struct {
+		name           string
+		body           string
+		serviceErr     error
+		expectedStatus int
+	}{
+		{name: "SuccessfulBulkTag", body: validBody, expectedStatus: http.StatusOK},
+		{name: "MissingIDs", body: `{"ids":[],"tags":["beach"],"mode":"add"}`, expectedStatus: http.StatusBadRequest},
+		{name: "MissingTags", body: fmt.Sprintf(`{"ids":["%s"],"tags":[],"mode":"add"}`, tripIDs[0]), expectedStatus: http.StatusBadRequest},
+		{name: "InvalidMode", body: fmt.Sprintf(`{"ids":["%s"],"tags":["beach"],"mode":"merge"}`, tripIDs[0]), expectedStatus: http.StatusBadRequest},
+		{name: "InvalidID", body: `{"ids":["not-a-uuid"],"tags":["beach"],"mode":"add"}`, expectedStatus: http.StatusBadRequest},
+		{name: "ServiceError", body: validBody, serviceErr: errors.New("database error"), expectedStatus: http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			handler, mockService, mockSession := setupHandlerTest()
+			userID := uuid.New()
+
+			mockSession.validateAccessTokenFunc = func(ctx context.Context, token string) (*models.Session, error) {
+				return createTestSession(userID, token, "valid_refresh_token"), nil
+			}
+			mockService.bulkTagTripsFunc = func(ctx context.Context, uid uuid.UUID, input models.BulkTagTripsInput) ([]models.BulkTagResult, error) {
+				if tc.serviceErr != nil {
+					return nil, tc.serviceErr
+				}
+				results := make([]models.BulkTagResult, 0, len(input.IDs))
+				for _, id := range input.IDs {
+					results = append(results, models.BulkTagResult{
+						ID:     id,
+						Status: models.BulkTagStatusTagged,
+						Tags:   []*models.Tag{{Name: input.Tags[0]}},
+					})
+				}
+				return results, nil
+			}
+
+			c, rec := newTestContext(http.MethodPost, "/api/trips/bulk-tag", []byte(tc.body))
+			addCookies(c, &http.Cookie{Name: "access_token", Value: "valid_access_token"})
+
+			// Execute
+			if err := handler.BulkTagTrips(c); err != nil {
+				t.Errorf("Expected no error, got: %v", err)
+			}
+
+			// Verify
+			checkResponseStatus(t, rec, tc.expectedStatus)
+
+			if tc.expectedStatus == http.StatusOK {
+				var results []models.BulkTagResult
+				if err := json.Unmarshal(rec.Body.Bytes(), &results); err != nil {
+					t.Fatalf("Failed to unmarshal response: %v", err)
+				}
+				if len(results) != len(tripIDs) {
+					t.Fatalf("Expected %d results, got %d", len(tripIDs), len(results))
+				}
+				for i, result := range results {
+					if result.ID != tripIDs[i] || len(result.Tags) != 1 || result.Tags[0].Name != "beach" {
+						t.Errorf("Expected trip %s tagged with beach, got %+v", tripIDs[i], result)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestHandlerGetTripCadence(t *testing.T) {
+	testCases := []struct {
+		name           string
+		serviceErr     error
+		expectedStatus int
+	}{
+		{name: "Success", expectedStatus: http.StatusOK},
+		{name: "ServiceError", serviceErr: errors.New("database error"), expectedStatus: http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			handler, mockService, mockSession := setupHandlerTest()
+			userID := uuid.New()
+			averageGap := 25.0
+
+			mockSession.validateAccessTokenFunc = func(ctx context.Context, token string) (*models.Session, error) {
+				return createTestSession(userID, token, "valid_refresh_token"), nil
+			}
+			mockService.getTripCadenceFunc = func(ctx context.Context, uid uuid.UUID) (*models.TripCadence, error) {
+				if uid != userID {
+					t.Errorf("Expected user ID %s, got %s", userID, uid)
+				}
+				if tc.serviceErr != nil {
+					return nil, tc.serviceErr
+				}
+				return &models.TripCadence{TripCount: 2, AverageGapDays: &averageGap, LongestGapDays: &averageGap}, nil
+			}
+
+			c, rec := newTestContext(http.MethodGet, "/api/trips/stats/cadence", nil)
+			addCookies(c, &http.Cookie{Name: "access_token", Value: "valid_access_token"})
+
+			// Execute
+			if err := handler.GetTripCadence(c); err != nil {
+				t.Errorf("Expected no error, got: %v", err)
+			}
+
+			// Verify
+			checkResponseStatus(t, rec, tc.expectedStatus)
+
+			if tc.expectedStatus == http.StatusOK {
+				var cadence models.TripCadence
+				if err := json.Unmarshal(rec.Body.Bytes(), &cadence); err != nil {
+					t.Fatalf("Failed to unmarshal response: %v", err)
+				}
+				if cadence.AverageGapDays == nil || *cadence.AverageGapDays != averageGap {
+					t.Errorf("Expected average gap %v, got %v", averageGap, cadence.AverageGapDays)
+				}
+			}
+		})
+	}
+}
+
+func TestHandlerGetLocationHistory(t *testing.T) {
+	testCases := []struct {
+		name           string
+		serviceErr     error
+		expectedStatus int
+	}{
+		{name: "Success", expectedStatus: http.StatusOK},
+		{name: "BlankLocation", serviceErr: errors.New("invalid location"), expectedStatus: http.StatusBadRequest},
+		{name: "ServiceError", serviceErr: errors.New("database error"), expectedStatus: http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			handler, mockService, mockSession := setupHandlerTest()
+			userID := uuid.New()
+
+			mockSession.validateAccessTokenFunc = func(ctx context.Context, token string) (*models.Session, error) {
+				return createTestSession(userID, token, "valid_refresh_token"), nil
+			}
+			mockService.getLocationHistoryFunc = func(ctx context.Context, uid uuid.UUID, location string) (*models.LocationHistory, error) {
+				if uid != userID || location != "Paris" {
+					t.Errorf("Expected Paris for user %s, got %q for %s", userID, location, uid)
+				}
+				if tc.serviceErr != nil {
+					return nil, tc.serviceErr
+				}
+				return &models.LocationHistory{Location: "paris", TripCount: 2, TotalDays: 9, Trips: []*models.Trip{}}, nil
+			}
+
+			c, rec := newTestContext(http.MethodGet, "/api/trips/by-location/Paris", nil)
+			c.SetParamNames("location")
+			c.SetParamValues("Paris")
+			addCookies(c, &http.Cookie{Name: "access_token", Value: "valid_access_token"})
+
+			// Execute
+			if err := handler.GetLocationHistory(c); err != nil {
+				t.Errorf("Expected no error, got: %v", err)
+			}
+
+			// Verify
+			checkResponseStatus(t, rec, tc.expectedStatus)
+
+			if tc.expectedStatus == http.StatusOK {
+				var history models.LocationHistory
+				if err := json.Unmarshal(rec.Body.Bytes(), &history); err != nil {
+					t.Fatalf("Failed to unmarshal response: %v", err)
+				}
+				if history.TripCount != 2 || history.TotalDays != 9 {
+					t.Errorf("Expected 2 trips over 9 days, got %+v", history)
+				}
+			}
+		})
+	}
+}
+
+func TestHandlerGetCurrentTrips(t *testing.T) {
+	handler, mockService, mockSession := setupHandlerTest()
+	userID := uuid.New()
+	tripID := uuid.New()
+
+	mockSession.validateAccessTokenFunc = func(ctx context.Context, token string) (*models.Session, error) {
+		return createTestSession(userID, token, "valid_refresh_token"), nil
+	}
+	mockService.getCurrentTripsFunc = func(ctx context.Context, uid uuid.UUID) ([]*models.Trip, error) {
+		return []*models.Trip{{ID: tripID, UserID: uid, Status: models.TripStatusOngoing}}, nil
+	}
+
+	c, rec := newTestContext(http.MethodGet, "/api/trips/current", nil)
+	addCookies(c, &http.Cookie{Name: "access_token", Value: "valid_access_token"})
+
+	if err := handler.GetCurrentTrips(c); err != nil {
+		t.Errorf("Expected no error, got: %v", err)
+	}
+
+	checkResponseStatus(t, rec, http.StatusOK)
+
+	var current []*models.Trip
+	if err := json.Unmarshal(rec.Body.Bytes(), &current); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if len(current) != 1 || current[0].ID != tripID {
+		t.Errorf("Expected trip %s, got %v", tripID, current)
+	}
+}
+
+func TestHandlerPreviewTripName(t *testing.T) {
+	testCases := []struct {
+		name           string
+		query          string
+		expectedStatus int
+		expectedName   string
+	}{
+		{name: "DefaultTemplate", query: "?location=Paris&start_date=2030-06-01", expectedStatus: http.StatusOK, expectedName: "Trip to Paris"},
+		{name: "NoStartDate", query: "?location=Paris", expectedStatus: http.StatusOK, expectedName: "Trip to Paris"},
+		{name: "NoLocation", query: "", expectedStatus: http.StatusOK, expectedName: "Untitled trip"},
+		{name: "InvalidStartDate", query: "?location=Paris&start_date=June", expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			handler, _, mockSession := setupHandlerTest()
+			userID := uuid.New()
+
+			mockSession.validateAccessTokenFunc = func(ctx context.Context, token string) (*models.Session, error) {
+				return createTestSession(userID, token, "valid_refresh_token"), nil
+			}
+
+			c, rec := newTestContext(http.MethodGet, "/api/trips/preview-name"+tc.query, nil)
+			addCookies(c, &http.Cookie{Name: "access_token", Value: "valid_access_token"})
+
+			// Execute
+			if err := handler.PreviewTripName(c); err != nil {
+				t.Errorf("Expected no error, got: %v", err)
+			}
+
+			// Verify
+			checkResponseStatus(t, rec, tc.expectedStatus)
+			if tc.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var preview models.TripNamePreview
+			if err := json.Unmarshal(rec.Body.Bytes(), &preview); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+			if preview.Name != tc.expectedName {
+				t.Errorf("Expected name %q, got %q", tc.expectedName, preview.Name)
+			}
+		})
+	}
+}
+
+func TestHandlerGetUpcomingTrips(t *testing.T) {
+	testCases := []struct {
+		name           string
+		query          string
+		serviceErr     error
+		expectedWithin int
+		expectedStatus int
+	}{
+		{name: "DefaultWindow", query: "", expectedWithin: trips.DefaultUpcomingDays, expectedStatus: http.StatusOK},
+		{name: "CustomWindow", query: "?within=30", expectedWithin: 30, expectedStatus: http.StatusOK},
+		{name: "MaxWindow", query: "?within=365", expectedWithin: 365, expectedStatus: http.StatusOK},
+		{name: "WindowTooLong", query: "?within=366", expectedStatus: http.StatusBadRequest},
+		{name: "ZeroWindow", query: "?within=0", expectedStatus: http.StatusBadRequest},
+		{name: "NegativeWindow", query: "?within=-3", expectedStatus: http.StatusBadRequest},
+		{name: "NotANumber", query: "?within=week", expectedStatus: http.StatusBadRequest},
+		{name: "ServiceError", query: "", serviceErr: errors.New("database error"), expectedWithin: trips.DefaultUpcomingDays, expectedStatus: http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			handler, mockService, mockSession := setupHandlerTest()
+			userID := uuid.New()
+			tripID := uuid.New()
+
+			mockSession.validateAccessTokenFunc = func(ctx context.Context, token string) (*models.Session, error) {
+				return createTestSession(userID, token, "valid_refresh_token"), nil
+			}
+			serviceCalled := false
+			mockService.getUpcomingTripsFunc = func(ctx context.Context, uid uuid.UUID, within int) ([]*models.Trip, error) {
+				serviceCalled = true
+				if within != tc.expectedWithin {
+					t.Errorf("Expected within %d, got %d", tc.expectedWithin, within)
+				}
+				if tc.serviceErr != nil {
+					return nil, tc.serviceErr
+				}
+				return []*models.Trip{{ID: tripID, UserID: uid, Status: models.TripStatusUpcoming}}, nil
+			}
+
+			c, rec := newTestContext(http.MethodGet, "/api/trips/upcoming"+tc.query, nil)
+			addCookies(c, &http.Cookie{Name: "access_token", Value: "valid_access_token"})
+
+			// Execute
+			if err := handler.GetUpcomingTrips(c); err != nil {
+				t.Errorf("Expected no error, got: %v", err)
+			}
+
+			// Verify
+			checkResponseStatus(t, rec, tc.expectedStatus)
+			if serviceCalled != (tc.expectedWithin != 0) {
+				t.Errorf("Expected service called=%v, got %v", tc.expectedWithin != 0, serviceCalled)
+			}
+
+			if tc.expectedStatus == http.StatusOK {
+				var upcoming []*models.Trip
+				if err := json.Unmarshal(rec.Body.Bytes(), &upcoming); err != nil {
+					t.Fatalf("Failed to unmarshal response: %v", err)
+				}
+				if len(upcoming) != 1 || upcoming[0].ID != tripID {
+					t.Errorf("Expected trip %s, got %v", tripID, upcoming)
+				}
+			}
+		})
+	}
+}
+
+func TestHandlerExportTripCalendar(t *testing.T) {
+	// The event covers the last day too, so it ends at midnight after it
+	startDate := time.Date(2030, 6, 1, 0, 0, 0, 0, time.UTC)
+	endDate := time.Date(2030, 6, 8, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name           string
+		trip           *models.Trip
+		serviceErr     error
+		expectedStatus int
+	}{
+		{
+			name:           "Success",
+			trip:           &models.Trip{Name: "Tokyo, again", Location: "Tokyo", Description: "Sushi; ramen", StartDate: &startDate, EndDate: &endDate},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "WishlistWithoutDates",
+			trip:           &models.Trip{Name: "Someday", Location: "Iceland", IsWishlist: true},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:           "NotFound",
+			serviceErr:     errors.New("trip not found"),
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "Forbidden",
+			serviceErr:     errors.New("unauthorized access to trip"),
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler, mockService, mockSession := setupHandlerTest()
+			userID := uuid.New()
+			tripID := uuid.New()
+
+			mockSession.validateAccessTokenFunc = func(ctx context.Context, token string) (*models.Session, error) {
+				return createTestSession(userID, token, "valid_refresh_token"), nil
+			}
+			mockService.getTripByIDFunc = func(ctx context.Context, tid uuid.UUID, uid uuid.UUID) (*models.Trip, error) {
+				if tc.serviceErr != nil {
+					return nil, tc.serviceErr
+				}
+				tc.trip.ID = tid
+				return tc.trip, nil
+			}
+
+			c, rec := newTestContext(http.MethodGet, "/api/trips/"+tripID.String()+"/export.ics", nil)
+			c.SetParamNames("id")
+			c.SetParamValues(tripID.String())
+			addCookies(c, &http.Cookie{Name: "access_token", Value: "valid_access_token"})
+
+			if err := handler.ExportTripCalendar(c); err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			checkResponseStatus(t, rec, tc.expectedStatus)
+			if tc.expectedStatus != http.StatusOK {
+				return
+			}
+
+			if contentType := rec.Header().Get(echo.HeaderContentType); !strings.HasPrefix(contentType, "text/calendar") {
+				t.Errorf("Expected text/calendar content type, got %q", contentType)
+			}
+
+			body := rec.Body.String()
+			for _, line := range []string{
+				"BEGIN:VEVENT",
+				"UID:" + tripID.String() + "@black-lotus",
+				"SUMMARY:Tokyo\\, again",
+				"DTSTART;VALUE=DATE:20300601",
+				"DTEND;VALUE=DATE:20300609",
+				"LOCATION:Tokyo",
+				"DESCRIPTION:Sushi\\; ramen",
+			} {
+				if !strings.Contains(body, line+"\r\n") {
+					t.Errorf("Expected line %q in calendar, got:\n%s", line, body)
+				}
+			}
+		})
+	}
+}
+
+func TestHandlerExportCalendar(t *testing.T) {
+	handler, mockService, mockSession := setupHandlerTest()
+	userID := uuid.New()
+	startDate := time.Date(2030, 6, 1, 0, 0, 0, 0, time.UTC)
+	endDate := startDate.Add(72 * time.Hour)
+
+	mockSession.validateAccessTokenFunc = func(ctx context.Context, token string) (*models.Session, error) {
+		return createTestSession(userID, token, "valid_refresh_token"), nil
+	}
+	mockService.getCalendarTripsFunc = func(ctx context.Context, uid uuid.UUID) ([]*models.Trip, error) {
+		if uid != userID {
+			t.Errorf("Expected trips for user %s, got %s", userID, uid)
+		}
+		return []*models.Trip{
+			{ID: uuid.New(), Name: "Lisbon", Location: "Portugal", StartDate: &startDate, EndDate: &endDate},
+			{ID: uuid.New(), Name: "Porto", Location: "Portugal", StartDate: &endDate, EndDate: &endDate},
+		}, nil
+	}
+
+	c, rec := newTestContext(http.MethodGet, "/api/trips/export.ics", nil)
+	addCookies(c, &http.Cookie{Name: "access_token", Value: "valid_access_token"})
+
+	if err := handler.ExportCalendar(c); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	checkResponseStatus(t, rec, http.StatusOK)
+
+	if contentType := rec.Header().Get(echo.HeaderContentType); !strings.HasPrefix(contentType, "text/calendar") {
+		t.Errorf("Expected text/calendar content type, got %q", contentType)
+	}
+
+	body := rec.Body.String()
+	if !strings.HasPrefix(body, "BEGIN:VCALENDAR\r\n") || !strings.HasSuffix(body, "END:VCALENDAR\r\n") {
+		t.Errorf("Expected a single VCALENDAR, got:\n%s", body)
+	}
+	if events := strings.Count(body, "BEGIN:VEVENT"); events != 2 {
+		t.Errorf("Expected 2 events, got %d", events)
+	}
+}
+
+func TestHandlerExportTrips(t *testing.T) {
+	startDate := time.Date(2030, 6, 1, 9, 0, 0, 0, time.FixedZone("JST", 9*60*60))
+	endDate := startDate.Add(72 * time.Hour)
+
+	// One full page followed by a partial one, so the export has to page
+	total := trips.ExportPageSize + 2
+	allTrips := make([]*models.Trip, total)
+	for i := range allTrips {
+		allTrips[i] = &models.Trip{ID: uuid.New(), Name: fmt.Sprintf("Trip %d", i), Location: "Tokyo", StartDate: &startDate, EndDate: &endDate}
+	}
+	allTrips[total-1] = &models.Trip{ID: uuid.New(), Name: "Someday, maybe", Description: "Line one\nLine two", Location: "Iceland", IsWishlist: true}
+
+	testCases := []struct {
+		name           string
+		query          string
+		serviceErr     error
+		expectedStatus int
+		checkBody      func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name:           "JSONByDefault",
+			expectedStatus: http.StatusOK,
+			checkBody: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				if contentType := rec.Header().Get(echo.HeaderContentType); !strings.HasPrefix(contentType, echo.MIMEApplicationJSON) {
+					t.Errorf("Expected JSON content type, got %q", contentType)
+				}
+				var exported []*models.Trip
+				if err := json.Unmarshal(rec.Body.Bytes(), &exported); err != nil {
+					t.Fatalf("Failed to unmarshal response: %v", err)
+				}
+				if len(exported) != total {
+					t.Fatalf("Expected %d trips, got %d", total, len(exported))
+				}
+				if exported[total-1].ID != allTrips[total-1].ID {
+					t.Errorf("Expected the last trip to be %s, got %s", allTrips[total-1].ID, exported[total-1].ID)
+				}
+			},
+		},
+		{
+			name:           "CSV",
+			query:          "format=csv",
+			expectedStatus: http.StatusOK,
+			checkBody: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				if contentType := rec.Header().Get(echo.HeaderContentType); !strings.HasPrefix(contentType, "text/csv") {
+					t.Errorf("Expected CSV content type, got %q", contentType)
+				}
+				if disposition := rec.Header().Get(echo.HeaderContentDisposition); disposition != `attachment; filename="trips.csv"` {
+					t.Errorf("Expected a trips.csv attachment, got %q", disposition)
+				}
+
+				records, err := csv.NewReader(rec.Body).ReadAll()
+				if err != nil {
+					t.Fatalf("Failed to parse CSV: %v", err)
+				}
+				if len(records) != total+1 {
+					t.Fatalf("Expected a header and %d rows, got %d records", total, len(records))
+				}
+				if strings.Join(records[0], ",") != "name,description,start_date,end_date,location" {
+					t.Errorf("Unexpected header row: %v", records[0])
+				}
+				if first := records[1]; first[2] != "2030-06-01" || first[3] != "2030-06-04" {
+					t.Errorf("Expected date-only dates, got %v", first)
+				}
+				if last := records[total]; last[0] != "Someday, maybe" || last[1] != "Line one\nLine two" || last[2] != "" || last[3] != "" {
+					t.Errorf("Expected the quoted wishlist trip without dates, got %v", last)
+				}
+			},
+		},
+		{
+			name:           "InvalidFormat",
+			query:          "format=xml",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "ServiceError",
+			serviceErr:     errors.New("database error"),
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler, mockService, mockSession := setupHandlerTest()
+			userID := uuid.New()
+
+			mockSession.validateAccessTokenFunc = func(ctx context.Context, token string) (*models.Session, error) {
+				return createTestSession(userID, token, "valid_refresh_token"), nil
+			}
+			mockService.getTripsByUserIDFunc = func(ctx context.Context, uid uuid.UUID, limit, offset int, filter models.TripFilter) ([]*models.Trip, error) {
+				if tc.serviceErr != nil {
+					return nil, tc.serviceErr
+				}
+				if filter.Sort != "created_at:asc" {
+					t.Errorf("Expected a stable created_at sort, got %q", filter.Sort)
+				}
+				if offset >= len(allTrips) {
+					return []*models.Trip{}, nil
+				}
+				return allTrips[offset:min(offset+limit, len(allTrips))], nil
+			}
+
+			c, rec := newTestContext(http.MethodGet, "/api/trips/export?"+tc.query, nil)
+			addCookies(c, &http.Cookie{Name: "access_token", Value: "valid_access_token"})
+
+			if err := handler.ExportTrips(c); err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			checkResponseStatus(t, rec, tc.expectedStatus)
+			if tc.checkBody != nil {
+				tc.checkBody(t, rec)
+			}
+		})
+	}
+}
+
+func TestHandlerExportTripsEmpty(t *testing.T) {
+	for _, format := range []string{"json", "csv"} {
+		t.Run(format, func(t *testing.T) {
+			handler, mockService, mockSession := setupHandlerTest()
+			userID := uuid.New()
+
+			mockSession.validateAccessTokenFunc = func(ctx context.Context, token string) (*models.Session, error) {
+				return createTestSession(userID, token, "valid_refresh_token"), nil
+			}
+			mockService.getTripsByUserIDFunc = func(ctx context.Context, uid uuid.UUID, limit, offset int, filter models.TripFilter) ([]*models.Trip, error) {
+				return []*models.Trip{}, nil
+			}
+
+			c, rec := newTestContext(http.MethodGet, "/api/trips/export?format="+format, nil)
+			addCookies(c, &http.Cookie{Name: "access_token", Value: "valid_access_token"})
+
+			if err := handler.ExportTrips(c); err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			checkResponseStatus(t, rec, http.StatusOK)
+
+			expected := map[string]string{"json": "[]", "csv": "name,description,start_date,end_date,location\n"}[format]
+			if rec.Body.String() != expected {
+				t.Errorf("Expected %q, got %q", expected, rec.Body.String())
+			}
+		})
+	}
+}
+
+// cancellingWriter counts writes to the response and cancels the request
+// context once cancelAfter have been made, like a client disconnecting
+type cancellingWriter struct {
+	http.ResponseWriter
+	writes      int
+	cancelAfter int
+	cancel      context.CancelFunc
+}
+
+func (w *cancellingWriter) Write(b []byte) (int, error) {
+	w.writes++
+	if w.writes == w.cancelAfter {
+		w.cancel()
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// Unwrap lets the response reach the recorder to flush it
+func (w *cancellingWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func TestHandlerExportTripsStopsWhenCancelled(t *testing.T) {
+	startDate := time.Date(2030, 6, 1, 0, 0, 0, 0, time.UTC)
+	allTrips := make([]*models.Trip, 3*trips.ExportPageSize)
+	for i := range allTrips {
+		allTrips[i] = &models.Trip{ID: uuid.New(), Name: fmt.Sprintf("Trip %d", i), Location: "Tokyo", StartDate: &startDate, EndDate: &startDate}
+	}
+
+	testCases := []struct {
+		name          string
+		cancelAfter   int
+		expectedPages int
+	}{
+		// The JSON writer writes a separator and then each trip, so this
+		// cancels after the second trip of the first page
+		{name: "DuringPage", cancelAfter: 4, expectedPages: 1},
+		// The first page is written in full, so the next page is never fetched
+		{name: "BetweenPages", cancelAfter: 2 * trips.ExportPageSize, expectedPages: 1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			handler, mockService, mockSession := setupHandlerTest()
+			userID := uuid.New()
+
+			mockSession.validateAccessTokenFunc = func(ctx context.Context, token string) (*models.Session, error) {
+				return createTestSession(userID, token, "valid_refresh_token"), nil
+			}
+			pages := 0
+			mockService.getTripsByUserIDFunc = func(ctx context.Context, uid uuid.UUID, limit, offset int, filter models.TripFilter) ([]*models.Trip, error) {
+				pages++
+				return allTrips[offset:min(offset+limit, len(allTrips))], nil
+			}
+
+			c, rec := newTestContext(http.MethodGet, "/api/trips/export", nil)
+			addCookies(c, &http.Cookie{Name: "access_token", Value: "valid_access_token"})
+
+			reqCtx, cancel := context.WithCancel(c.Request().Context())
+			defer cancel()
+			c.SetRequest(c.Request().WithContext(reqCtx))
+			writer := &cancellingWriter{ResponseWriter: rec, cancelAfter: tc.cancelAfter, cancel: cancel}
+			c.Response().Writer = writer
+
+			// Execute
+			if err := handler.ExportTrips(c); err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			// Verify: nothing is written or fetched after the cancellation
+			if writer.writes != tc.cancelAfter {
+				t.Errorf("Expected writing to stop after %d writes, got %d", tc.cancelAfter, writer.writes)
+			}
+			if pages != tc.expectedPages {
+				t.Errorf("Expected %d pages fetched, got %d", tc.expectedPages, pages)
+			}
+		})
+	}
+}
+
+func TestHandlerExportTripsOutlivesRequestTimeout(t *testing.T) {
+	startDate := time.Date(2030, 6, 1, 0, 0, 0, 0, time.UTC)
+	allTrips := make([]*models.Trip, 3*trips.ExportPageSize)
+	for i := range allTrips {
+		allTrips[i] = &models.Trip{ID: uuid.New(), Name: fmt.Sprintf("Trip %d", i), Location: "Tokyo", StartDate: &startDate, EndDate: &startDate}
+	}
+
+	testCases := []struct {
+		name          string
+		slowOffset    int
+		expectedTrips int
+	}{
+		// Every page is quick but together they take longer than REQUEST_TIMEOUT
+		{name: "QuickPages", slowOffset: -1, expectedTrips: len(allTrips)},
+		// A single page running past its own deadline ends the download early
+		{name: "SlowPage", slowOffset: trips.ExportPageSize, expectedTrips: trips.ExportPageSize},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup - the handler bounds each page query by REQUEST_TIMEOUT
+			t.Setenv("REQUEST_TIMEOUT", "50ms")
+			handler, mockService, mockSession := setupHandlerTest()
+			userID := uuid.New()
+
+			mockSession.validateAccessTokenFunc = func(ctx context.Context, token string) (*models.Session, error) {
+				return createTestSession(userID, token, "valid_refresh_token"), nil
+			}
+			mockService.getTripsByUserIDFunc = func(ctx context.Context, uid uuid.UUID, limit, offset int, filter models.TripFilter) ([]*models.Trip, error) {
+				if _, ok := ctx.Deadline(); !ok {
+					t.Errorf("Expected the page query at offset %d to have a deadline", offset)
+				}
+				delay := 30 * time.Millisecond
+				if offset == tc.slowOffset {
+					delay = time.Second
+				}
+				select {
+				case <-time.After(delay):
+					return allTrips[offset:min(offset+limit, len(allTrips))], nil
+				case <-ctx.Done():
+					return nil, ctx.Err()
+				}
+			}
+
+			c, rec := newTestContext(http.MethodGet, "/api/trips/export", nil)
+			addCookies(c, &http.Cookie{Name: "access_token", Value: "valid_access_token"})
+
+			// Execute
+			if err := handler.ExportTrips(c); err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			// Verify
+			checkResponseStatus(t, rec, http.StatusOK)
+
+			// A download cut short isn't valid JSON, so count the trips written
+			if written := strings.Count(rec.Body.String(), `"name":"Trip `); written != tc.expectedTrips {
+				t.Errorf("Expected %d trips, got %d", tc.expectedTrips, written)
+			}
+		})
+	}
+}
+
+// newCSVUploadContext builds a multipart request with content as the "file" field
+func newCSVUploadContext(t *testing.T, content string) (echo.Context, *httptest.ResponseRecorder) {
+	t.Helper()
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile("file", "trips.csv")
+	if err != nil {
+		t.Fatalf("Failed to create form file: %v", err)
+	}
+	part.Write([]byte(content))
+	writer.Close()
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/trips/import", &body)
+	req.Header.Set(echo.HeaderContentType, writer.FormDataContentType())
+	rec := httptest.NewRecorder()
+	return e.NewContext(req, rec), rec
+}
+
+func TestHandlerImportTripsCSV(t *testing.T) {
+	header := "name,description,start_date,end_date,location\n"
+
+	testCases := []struct {
+		name           string
+		content        string
+		noFile         bool
+		batchErrors    []models.TripBatchError
+		expectedStatus int
+		expectedInputs int                   // CreateTrips should see this many inputs, 0 when it isn't called
+		expectedErrors []models.TripRowError // Row errors in the response, in order
+		checkInputs    func(*testing.T, []models.CreateTripInput)
+	}{
+		{
+			name: "Success",
+			content: header +
+				"Lisbon,Pastéis,2030-06-01,2030-06-05,Portugal\n" +
+				"Someday,,,,Iceland\n",
+			expectedStatus: http.StatusCreated,
+			expectedInputs: 2,
+			checkInputs: func(t *testing.T, inputs []models.CreateTripInput) {
+				if inputs[0].Name != "Lisbon" || inputs[0].Location != "Portugal" || !inputs[0].StartDate.Equal(time.Date(2030, 6, 1, 0, 0, 0, 0, time.UTC)) {
+					t.Errorf("Unexpected first trip: %+v", inputs[0])
+				}
+				if inputs[0].IsWishlist || !inputs[1].IsWishlist {
+					t.Errorf("Expected only the dateless row to be a wishlist trip, got %v and %v", inputs[0].IsWishlist, inputs[1].IsWishlist)
+				}
+			},
+		},
+		{
+			name:           "ColumnsInAnyOrder",
+			content:        "Location,Name,End_Date,Start_Date,notes\nPortugal,Lisbon,2030-06-05T18:00:00Z,2030-06-01T09:00:00Z,ignored\n",
+			expectedStatus: http.StatusCreated,
+			expectedInputs: 1,
+			checkInputs: func(t *testing.T, inputs []models.CreateTripInput) {
+				if inputs[0].Name != "Lisbon" || !inputs[0].EndDate.Equal(time.Date(2030, 6, 5, 0, 0, 0, 0, time.UTC)) {
+					t.Errorf("Unexpected trip: %+v", inputs[0])
+				}
+			},
+		},
+		{
+			name: "RowErrorsWithLineNumbers",
+			content: header +
+				"Lisbon,,2030-06-01,2030-06-05,\n" + // line 2: no location
+				"Porto,\"Two\nlines\",2030-06-01,2030-06-05,Portugal\n" + // lines 3-4
+				"Madrid,,June 1st,2030-06-05,Spain\n" + // line 5: bad date
+				"Seville,,2030-06-01\n", // line 6: too few fields
+			expectedStatus: http.StatusBadRequest,
+			expectedErrors: []models.TripRowError{
+				{Line: 2, Field: "location", Message: "location is required"},
+				{Line: 5, Field: "start_date", Message: "use RFC3339 or YYYY-MM-DD"},
+				{Line: 6, Message: "wrong number of fields"},
+			},
+		},
+		{
+			name:           "ServiceBatchErrorsMappedToLines",
+			content:        header + "Lisbon,,2030-06-01,2030-06-05,Portugal\nPorto,,2030-06-05,2030-06-01,Portugal\n",
+			batchErrors:    []models.TripBatchError{{Index: 1, Field: "end_date", Message: "end date cannot be before start date"}},
+			expectedStatus: http.StatusBadRequest,
+			expectedInputs: 2,
+			expectedErrors: []models.TripRowError{{Line: 3, Field: "end_date", Message: "end date cannot be before start date"}},
+		},
+		{
+			name:           "HeaderOnly",
+			content:        header,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "UnknownHeader",
+			content:        "a,b,c\n1,2,3\n",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "TooManyRows",
+			content:        header + strings.Repeat("Lisbon,,2030-06-01,2030-06-05,Portugal\n", trips.MaxBulkTrips+1),
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "TooLarge",
+			content:        header + strings.Repeat("x", trips.MaxImportFileSize),
+			expectedStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:           "NoFile",
+			noFile:         true,
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler, mockService, mockSession := setupHandlerTest()
+			userID := uuid.New()
+
+			mockSession.validateAccessTokenFunc = func(ctx context.Context, token string) (*models.Session, error) {
+				return createTestSession(userID, token, "valid_refresh_token"), nil
+			}
+
+			calledWith := 0
+			mockService.createTripsFunc = func(ctx context.Context, uid uuid.UUID, inputs []models.CreateTripInput) ([]*models.Trip, []models.TripBatchError, error) {
+				calledWith = len(inputs)
+				if tc.checkInputs != nil {
+					tc.checkInputs(t, inputs)
+				}
+				if tc.batchErrors != nil {
+					return nil, tc.batchErrors, nil
+				}
+
+				created := make([]*models.Trip, len(inputs))
+				for i, input := range inputs {
+					created[i] = &models.Trip{ID: uuid.New(), UserID: uid, Name: input.Name, Location: input.Location}
+				}
+				return created, nil, nil
+			}
+
+			var c echo.Context
+			var rec *httptest.ResponseRecorder
+			if tc.noFile {
+				c, rec = newTestContext(http.MethodPost, "/api/trips/import", nil)
+				c.Request().Header.Set(echo.HeaderContentType, "multipart/form-data; boundary=empty")
+			} else {
+				c, rec = newCSVUploadContext(t, tc.content)
+			}
+			addCookies(c, &http.Cookie{Name: "access_token", Value: "valid_access_token"})
+
+			if err := handler.ImportTripsCSV(c); err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			checkResponseStatus(t, rec, tc.expectedStatus)
+
+			if calledWith != tc.expectedInputs {
+				t.Errorf("Expected CreateTrips with %d inputs, got %d", tc.expectedInputs, calledWith)
+			}
+
+			if tc.expectedStatus == http.StatusCreated {
+				var result models.TripCSVImportResult
+				if err := json.Unmarshal(rec.Body.Bytes(), &result); err != nil {
+					t.Fatalf("Failed to unmarshal response: %v", err)
+				}
+				if result.Imported != tc.expectedInputs || len(result.Trips) != tc.expectedInputs {
+					t.Errorf("Expected %d imported trips, got %+v", tc.expectedInputs, result)
+				}
+			}
+
+			if tc.expectedErrors != nil {
+				var envelope struct {
+					Error struct {
+						Details []models.TripRowError `json:"details"`
+					} `json:"error"`
+				}
+				if err := json.Unmarshal(rec.Body.Bytes(), &envelope); err != nil {
+					t.Fatalf("Failed to unmarshal response: %v", err)
+				}
+				if !slices.Equal(envelope.Error.Details, tc.expectedErrors) {
+					t.Errorf("Expected row errors %+v, got %+v", tc.expectedErrors, envelope.Error.Details)
+				}
+			}
+		})
+	}
+}
+
+func TestHandlerGetTripDaysBreakdown(t *testing.T) {
+	testCases := []struct {
+		name           string
+		breakdown      *models.TripDaysBreakdown
+		serviceErr     error
+		expectedStatus int
+	}{
+		{name: "Success", breakdown: &models.TripDaysBreakdown{TotalDays: 4, Weekdays: 2, WeekendDays: 2}, expectedStatus: http.StatusOK},
+		{name: "NotFound", serviceErr: errors.New("trip not found"), expectedStatus: http.StatusNotFound},
+		{name: "Forbidden", serviceErr: errors.New("unauthorized access to trip"), expectedStatus: http.StatusForbidden},
+		{name: "NoDates", serviceErr: errors.New("trip has no dates"), expectedStatus: http.StatusConflict},
+		{name: "ServiceError", serviceErr: errors.New("database error"), expectedStatus: http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler, mockService, mockSession := setupHandlerTest()
+			userID := uuid.New()
+			tripID := uuid.New()
+
+			mockSession.validateAccessTokenFunc = func(ctx context.Context, token string) (*models.Session, error) {
+				return createTestSession(userID, token, "valid_refresh_token"), nil
+			}
+			mockService.getDaysBreakdownFunc = func(ctx context.Context, tid uuid.UUID, uid uuid.UUID) (*models.TripDaysBreakdown, error) {
+				if tid != tripID || uid != userID {
+					t.Errorf("Expected trip %s for user %s, got %s for %s", tripID, userID, tid, uid)
+				}
+				return tc.breakdown, tc.serviceErr
+			}
+
+			c, rec := newTestContext(http.MethodGet, "/api/trips/"+tripID.String()+"/days-breakdown", nil)
+			c.SetParamNames("id")
+			c.SetParamValues(tripID.String())
+			addCookies(c, &http.Cookie{Name: "access_token", Value: "valid_access_token"})
+
+			if err := handler.GetTripDaysBreakdown(c); err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			checkResponseStatus(t, rec, tc.expectedStatus)
+
+			if tc.breakdown != nil {
+				var breakdown models.TripDaysBreakdown
+				if err := json.Unmarshal(rec.Body.Bytes(), &breakdown); err != nil {
+					t.Fatalf("Failed to unmarshal response: %v", err)
+				}
+				if breakdown != *tc.breakdown {
+					t.Errorf("Expected %+v, got %+v", *tc.breakdown, breakdown)
+				}
+			}
+		})
+	}
+}
+
+func TestHandlerGetTripIncludeDeleted(t *testing.T) {
+	testCases := []struct {
+		name           string
+		query          string
+		expectedStatus int
+		expectDeleted  bool
+	}{
+		{name: "OwnerWithFlag", query: "?include_deleted=true", expectedStatus: http.StatusOK, expectDeleted: true},
+		{name: "OwnerWithoutFlag", expectedStatus: http.StatusNotFound},
+		{name: "FlagFalse", query: "?include_deleted=false", expectedStatus: http.StatusNotFound},
+		{name: "InvalidFlag", query: "?include_deleted=maybe", expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler, mockService, mockSession := setupHandlerTest()
+			userID := uuid.New()
+			tripID := uuid.New()
+			deletedAt := time.Now().Add(-time.Hour)
+
+			mockSession.validateAccessTokenFunc = func(ctx context.Context, token string) (*models.Session, error) {
+				return createTestSession(userID, token, "valid_refresh_token"), nil
+			}
+			// The trip is in the trash, so only the include_deleted lookup finds it
+			mockService.getTripByIDFunc = func(ctx context.Context, tid uuid.UUID, uid uuid.UUID) (*models.Trip, error) {
+				return nil, errors.New("trip not found")
+			}
+			mockService.getTripWithDeletedFunc = func(ctx context.Context, tid uuid.UUID, uid uuid.UUID) (*models.Trip, error) {
+				return &models.Trip{ID: tid, UserID: uid, Name: "Deleted Trip", DeletedAt: &deletedAt}, nil
+			}
+
+			c, rec := newTestContext(http.MethodGet, "/api/trips/"+tripID.String()+tc.query, nil)
+			c.SetParamNames("id")
+			c.SetParamValues(tripID.String())
+			addCookies(c, &http.Cookie{Name: "access_token", Value: "valid_access_token"})
+
+			if err := handler.GetTrip(c); err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			checkResponseStatus(t, rec, tc.expectedStatus)
+
+			if tc.expectDeleted {
+				var trip models.Trip
+				if err := json.Unmarshal(rec.Body.Bytes(), &trip); err != nil {
+					t.Fatalf("Failed to unmarshal response: %v", err)
+				}
+				if trip.ID != tripID || trip.DeletedAt == nil {
+					t.Errorf("Expected trip %s with deleted_at, got %+v", tripID, trip)
+				}
+			}
+		})
+	}
+}
+
+func TestHandlerCreateTripClientID(t *testing.T) {
+	testCases := []struct {
+		name           string
+		clientID       string
+		expectedStatus int
+	}{
+		{name: "Echoed", clientID: "tmp-3f9c2a", expectedStatus: http.StatusCreated},
+		{name: "TooLong", clientID: strings.Repeat("x", 129), expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler, mockService, mockSession := setupHandlerTest()
+			userID := uuid.New()
+
+			mockSession.validateAccessTokenFunc = func(ctx context.Context, token string) (*models.Session, error) {
+				return createTestSession(userID, token, "valid_refresh_token"), nil
+			}
+			mockService.createTripFunc = func(ctx context.Context, uid uuid.UUID, input models.CreateTripInput) (*models.Trip, error) {
+				return &models.Trip{ID: uuid.New(), UserID: uid, Location: input.Location, ClientID: input.ClientID}, nil
+			}
+
+			start := time.Now().Add(24 * time.Hour)
+			body, _ := json.Marshal(models.CreateTripInput{
+				StartDate: start,
+				EndDate:   start.Add(48 * time.Hour),
+				Location:  "Lisbon",
+				ClientID:  tc.clientID,
+			})
+
+			c, rec := newTestContext(http.MethodPost, "/api/trips", body)
+			addCookies(c, &http.Cookie{Name: "access_token", Value: "valid_access_token"})
+
+			if err := handler.CreateTrip(c); err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			checkResponseStatus(t, rec, tc.expectedStatus)
+
+			if tc.expectedStatus == http.StatusCreated {
+				var created map[string]any
+				if err := json.Unmarshal(rec.Body.Bytes(), &created); err != nil {
+					t.Fatalf("Failed to unmarshal response: %v", err)
+				}
+				if created["client_id"] != tc.clientID {
+					t.Errorf("Expected client_id %q, got %v", tc.clientID, created["client_id"])
+				}
+			}
+		})
+	}
+}
+
+func TestHandlerShareTrip(t *testing.T) {
+	testCases := []struct {
+		name           string
+		serviceErr     error
+		expectedStatus int
+	}{
+		{name: "Success", expectedStatus: http.StatusOK},
+		{name: "NotOwner", serviceErr: errors.New("unauthorized access to trip"), expectedStatus: http.StatusForbidden},
+		{name: "NotFound", serviceErr: errors.New("trip not found"), expectedStatus: http.StatusNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler, mockService, mockSession := setupHandlerTest()
+			userID := uuid.New()
+			tripID := uuid.New()
+
+			mockSession.validateAccessTokenFunc = func(ctx context.Context, token string) (*models.Session, error) {
+				return createTestSession(userID, token, "valid_refresh_token"), nil
+			}
+			mockService.shareTripFunc = func(ctx context.Context, tid uuid.UUID, uid uuid.UUID) (*models.TripShare, error) {
+				if tc.serviceErr != nil {
+					return nil, tc.serviceErr
+				}
+				return &models.TripShare{TripID: tid, Visibility: models.TripVisibilityUnlisted, ShareToken: "share-token"}, nil
+			}
+			mockService.unshareTripFunc = func(ctx context.Context, tid uuid.UUID, uid uuid.UUID) error {
+				return tc.serviceErr
+			}
+
+			for _, method := range []string{http.MethodPost, http.MethodDelete} {
+				c, rec := newTestContext(method, "/api/trips/"+tripID.String()+"/share", nil)
+				c.SetParamNames("id")
+				c.SetParamValues(tripID.String())
+				addCookies(c, &http.Cookie{Name: "access_token", Value: "valid_access_token"})
+
+				handle := handler.ShareTrip
+				if method == http.MethodDelete {
+					handle = handler.UnshareTrip
+				}
+				if err := handle(c); err != nil {
+					t.Fatalf("%s: expected no error, got: %v", method, err)
+				}
+
+				checkResponseStatus(t, rec, tc.expectedStatus)
+
+				if method == http.MethodPost && tc.expectedStatus == http.StatusOK {
+					var share models.TripShare
+					if err := json.Unmarshal(rec.Body.Bytes(), &share); err != nil {
+						t.Fatalf("Failed to unmarshal response: %v", err)
+					}
+					if share.ShareToken != "share-token" || share.Visibility != models.TripVisibilityUnlisted {
+						t.Errorf("Expected an unlisted share, got %+v", share)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestHandlerGetSharedTrip(t *testing.T) {
+	testCases := []struct {
+		name           string
+		token          string
+		expectedStatus int
+	}{
+		{name: "Unlisted", token: "share-token", expectedStatus: http.StatusOK},
+		{name: "PrivateOrRevoked", token: "old-token", expectedStatus: http.StatusNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler, mockService, _ := setupHandlerTest()
+
+			mockService.getSharedTripFunc = func(ctx context.Context, token string) (*models.PublicTrip, error) {
+				if token != "share-token" {
+					return nil, errors.New("trip not found")
+				}
+				return &models.PublicTrip{Name: "Lisbon", Location: "Lisbon", Tags: []string{}}, nil
+			}
+
+			// No cookies: the link works without logging in
+			c, rec := newTestContext(http.MethodGet, "/api/public/trips/"+tc.token, nil)
+			c.SetParamNames("token")
+			c.SetParamValues(tc.token)
+
+			if err := handler.GetSharedTrip(c); err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			checkResponseStatus(t, rec, tc.expectedStatus)
+		})
+	}
+}
+
+func TestHandlerGetUserTripsCursor(t *testing.T) {
+	next := "next-cursor"
+
+	testCases := []struct {
+		name           string
+		query          string
+		expectedCursor string
+		serviceErr     error
+		expectedStatus int
+		expectPage     bool
+	}{
+		{name: "FirstPage", query: "?cursor=&limit=2", expectedStatus: http.StatusOK, expectPage: true},
+		{name: "NextPage", query: "?cursor=abc&limit=2", expectedCursor: "abc", expectedStatus: http.StatusOK, expectPage: true},
+		{name: "OffsetMode", query: "?offset=2&limit=2", expectedStatus: http.StatusOK},
+		{name: "CursorAndOffset", query: "?cursor=abc&offset=2", expectedStatus: http.StatusBadRequest},
+		{name: "InvalidCursor", query: "?cursor=bad", expectedCursor: "bad", serviceErr: errors.New("invalid cursor"), expectedStatus: http.StatusBadRequest},
+		{name: "CursorWithSort", query: "?cursor=&sort=name:asc", serviceErr: errors.New("cursor pagination cannot be sorted"), expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler, mockService, mockSession := setupHandlerTest()
+			userID := uuid.New()
+
+			mockSession.validateAccessTokenFunc = func(ctx context.Context, token string) (*models.Session, error) {
+				return createTestSession(userID, token, "valid_refresh_token"), nil
+			}
+			mockService.getTripPageFunc = func(ctx context.Context, uid uuid.UUID, limit int, cursor string, filter models.TripFilter) (*models.TripPage, error) {
+				if tc.serviceErr != nil {
+					return nil, tc.serviceErr
+				}
+				if cursor != tc.expectedCursor || limit != 2 {
+					t.Errorf("Expected cursor %q and limit 2, got %q and %d", tc.expectedCursor, cursor, limit)
+				}
+				return &models.TripPage{Trips: []*models.Trip{{ID: uuid.New(), UserID: uid}}, NextCursor: &next}, nil
+			}
+			mockService.getTripsByUserIDFunc = func(ctx context.Context, uid uuid.UUID, limit, offset int, filter models.TripFilter) ([]*models.Trip, error) {
+				if tc.expectPage {
+					t.Error("Expected cursor mode not to use offset paging")
+				}
+				return []*models.Trip{{ID: uuid.New(), UserID: uid}}, nil
+			}
+
+			c, rec := newTestContext(http.MethodGet, "/api/trips"+tc.query, nil)
+			addCookies(c, &http.Cookie{Name: "access_token", Value: "valid_access_token"})
+
+			if err := handler.GetUserTrips(c); err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			checkResponseStatus(t, rec, tc.expectedStatus)
+			if tc.expectedStatus != http.StatusOK {
+				return
+			}
+
+			if tc.expectPage {
+				var page models.TripPage
+				if err := json.Unmarshal(rec.Body.Bytes(), &page); err != nil {
+					t.Fatalf("Failed to unmarshal page: %v", err)
+				}
+				if len(page.Trips) != 1 || page.NextCursor == nil || *page.NextCursor != next {
+					t.Errorf("Expected one trip and next_cursor %q, got %+v", next, page)
+				}
+			} else {
+				var trips []*models.Trip
+				if err := json.Unmarshal(rec.Body.Bytes(), &trips); err != nil {
+					t.Fatalf("Expected offset mode to keep returning an array: %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestHandlerGetUserTripsInvalidPagination(t *testing.T) {
+	testCases := []struct {
+		name  string
+		query string
+	}{
+		{name: "NonNumericLimit", query: "?limit=ten"},
+		{name: "NonNumericOffset", query: "?offset=abc"},
+		{name: "NegativeOffset", query: "?offset=-5"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			handler, mockService, mockSession := setupHandlerTest()
+			userID := uuid.New()
+
+			mockSession.validateAccessTokenFunc = func(ctx context.Context, token string) (*models.Session, error) {
+				return createTestSession(userID, token, "valid_refresh_token"), nil
+			}
+			mockService.getTripsByUserIDFunc = func(ctx context.Context, uid uuid.UUID, limit, offset int, filter models.TripFilter) ([]*models.Trip, error) {
+				t.Error("Expected service not to be called")
+				return []*models.Trip{}, nil
+			}
+
+			c, rec := newTestContext(http.MethodGet, "/api/trips"+tc.query, nil)
+			addCookies(c, &http.Cookie{Name: "access_token", Value: "valid_access_token"})
+
+			// Execute
+			if err := handler.GetUserTrips(c); err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			// Verify
+			checkResponseStatus(t, rec, http.StatusBadRequest)
+			var envelope response.ErrorEnvelope
+			if err := json.Unmarshal(rec.Body.Bytes(), &envelope); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+			if envelope.Error.Code != response.CodeInvalidRequest {
+				t.Errorf("Expected code '%s', got '%s'", response.CodeInvalidRequest, envelope.Error.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package trips
+
+import (
+	"bytes"
+	"html/template"
+	"time"
+
+	"black-lotus/internal/domain/models"
+)
+
+// printPageCSP keeps the printable page inert: no scripts, frames or remote
+// resources, only the page's own inline styles
+const printPageCSP = "default-src 'none'; style-src 'unsafe-inline'; base-uri 'none'; form-action 'none'"
+
+// printTemplate renders a trip and its itinerary as a standalone page. html/template
+// escapes every value for its context, so user-entered text can't inject markup.
+var printTemplate = template.Must(template.New("trip-print").Funcs(template.FuncMap{
+	"date":     func(t time.Time) string { return t.Format("Mon, Jan 2 2006") },
+	"datetime": func(t time.Time) string { return t.Format("Mon, Jan 2 2006 15:04") },
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8" />
+  <title>{{.Trip.Name}}</title>
+  <style>
+    body { font-family: Georgia, serif; margin: 2rem auto; max-width: 48rem; color: #111; }
+    h1 { margin-bottom: 0.25rem; }
+    .meta { color: #555; margin-top: 0; }
+    .description { white-space: pre-wrap; }
+    table { border-collapse: collapse; width: 100%; margin-top: 1rem; }
+    th, td { border-bottom: 1px solid #ccc; padding: 0.5rem; text-align: left; vertical-align: top; }
+    @media print { body { margin: 0; max-width: none; } }
+  </style>
+</head>
+<body>
+  <h1>{{.Trip.Name}}</h1>
+  <p class="meta">
+    {{.Trip.Location}}
+    {{- if .Trip.IsWishlist}} &middot; Wishlist
+    {{- else}} &middot; {{date .Trip.StartDate}} &ndash; {{date .Trip.EndDate}}{{end}}
+  </p>
+  {{- with .Trip.Description}}
+  <p class="description">{{.}}</p>
+  {{- end}}
+
+  <h2>Itinerary</h2>
+  {{- if .Activities}}
+  <table>
+    <thead>
+      <tr><th>When</th><th>Activity</th><th>Where</th></tr>
+    </thead>
+    <tbody>
+      {{- range .Activities}}
+      <tr>
+        <td>{{datetime .StartTime}} &ndash; {{datetime .EndTime}}</td>
+        <td><strong>{{.Title}}</strong>{{with .Description}}<br />{{.}}{{end}}</td>
+        <td>{{.Location}}</td>
+      </tr>
+      {{- end}}
+    </tbody>
+  </table>
+  {{- else}}
+  <p>No activities planned yet.</p>
+  {{- end}}
+</body>
+</html>
+`))
+
+// RenderTripPrint renders an exported trip as a printable HTML page
+func RenderTripPrint(export *models.TripExport) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := printTemplate.Execute(&buf, export); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,157 @@
+package trips
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+
+	"black-lotus/internal/domain/models"
+)
+
+// DefaultReminderInterval is how often the reminder job checks for trips when
+// REMINDER_INTERVAL isn't set. Each day is only notified once, so checking
+// more often than daily just picks up a new day sooner.
+const DefaultReminderInterval = time.Hour
+
+// ReminderIntervalFromEnv reads REMINDER_INTERVAL (e.g. "1h", "30m"), falling
+// back to DefaultReminderInterval when it is unset or not a positive duration
+func ReminderIntervalFromEnv() time.Duration {
+	interval, err := time.ParseDuration(os.Getenv("REMINDER_INTERVAL"))
+	if err != nil || interval <= 0 {
+		return DefaultReminderInterval
+	}
+	return interval
+}
+
+// ReminderRepository finds the trips the reminder job notifies about and
+// keeps track of the reminders sent for them
+type ReminderRepository interface {
+	GetTripsStartingOn(ctx context.Context, day time.Time) ([]*models.Trip, error)
+	// CreateReminder does nothing if the trip already has a reminder for tripStart
+	CreateReminder(ctx context.Context, tripID uuid.UUID, tripStart time.Time, remindAt time.Time) error
+	// GetDueReminders returns unacknowledged reminders whose remind_at has
+	// passed since they were last sent, with their trips
+	GetDueReminders(ctx context.Context, now time.Time) ([]*models.TripReminder, error)
+	MarkReminderSent(ctx context.Context, reminderID uuid.UUID, sentAt time.Time) error
+}
+
+// Notifier delivers a reminder to its trip's owner. Email or push delivery
+// plug in here; the message should carry the links to acknowledge
+// (/api/reminders/{token}/ack) and snooze (/api/reminders/{token}/snooze?days=N) it.
+type Notifier interface {
+	NotifyTripReminder(ctx context.Context, reminder *models.TripReminder) error
+}
+
+// LogNotifier only logs each reminder, until a real delivery channel exists.
+// The links are logged at debug level since the token is all they need.
+type LogNotifier struct{}
+
+func (LogNotifier) NotifyTripReminder(ctx context.Context, reminder *models.TripReminder) error {
+	trip := reminder.Trip
+	slog.Info("Trip starts soon", "trip_id", trip.ID, "user_id", trip.UserID, "name", trip.Name,
+		"start_date", reminder.TripStart.Format(models.TripDateLayout))
+	slog.Debug("Trip reminder links", "reminder_id", reminder.ID,
+		"ack", "/api/reminders/"+reminder.Token+"/ack", "snooze", "/api/reminders/"+reminder.Token+"/snooze?days=1")
+	return nil
+}
+
+// ReminderJob reminds owners the day before their trips start, and again
+// whenever a snoozed reminder comes due
+type ReminderJob struct {
+	repo     ReminderRepository
+	notifier Notifier
+	now      func() time.Time
+
+	// The last day whose trips got reminders. Creating them is idempotent, so
+	// a restart only repeats the lookup, never the reminders.
+	lastScheduled time.Time
+}
+
+// NewReminderJob creates a reminder job. now is the job's clock; nil uses time.Now.
+func NewReminderJob(repo ReminderRepository, notifier Notifier, now func() time.Time) *ReminderJob {
+	if now == nil {
+		now = time.Now
+	}
+	return &ReminderJob{repo: repo, notifier: notifier, now: now}
+}
+
+// RunOnce creates reminders for trips starting tomorrow, unless tomorrow has
+// already been handled, then sends every reminder that is due and returns how
+// many were sent. A failed notification is logged and skipped so one bad trip
+// can't hold up the rest; it stays due and is retried on the next run.
+func (j *ReminderJob) RunOnce(ctx context.Context) (int, error) {
+	now := j.now()
+	if err := j.schedule(ctx, now); err != nil {
+		return 0, err
+	}
+
+	due, err := j.repo.GetDueReminders(ctx, now)
+	if err != nil {
+		slog.Error("Error finding due trip reminders", "error", err)
+		return 0, err
+	}
+
+	sent := 0
+	for _, reminder := range due {
+		if err := j.notifier.NotifyTripReminder(ctx, reminder); err != nil {
+			slog.Error("Error sending trip reminder", "trip_id", reminder.TripID, "error", err)
+			continue
+		}
+		if err := j.repo.MarkReminderSent(ctx, reminder.ID, now); err != nil {
+			slog.Error("Error recording sent trip reminder", "reminder_id", reminder.ID, "error", err)
+			continue
+		}
+		sent++
+	}
+
+	if len(due) > 0 {
+		slog.Info("Sent trip reminders", "due", len(due), "sent", sent)
+	}
+	return sent, nil
+}
+
+// schedule creates a reminder, due now, for each trip starting tomorrow
+func (j *ReminderJob) schedule(ctx context.Context, now time.Time) error {
+	tomorrow := models.TripDay(now).AddDate(0, 0, 1)
+	if tomorrow.Equal(j.lastScheduled) {
+		return nil
+	}
+
+	trips, err := j.repo.GetTripsStartingOn(ctx, tomorrow)
+	if err != nil {
+		slog.Error("Error finding trips to remind", "day", tomorrow.Format(models.TripDateLayout), "error", err)
+		return err
+	}
+
+	for _, trip := range trips {
+		if err := j.repo.CreateReminder(ctx, trip.ID, tomorrow, now); err != nil {
+			slog.Error("Error creating trip reminder", "trip_id", trip.ID, "error", err)
+			return err
+		}
+	}
+	j.lastScheduled = tomorrow
+
+	return nil
+}
+
+// Start runs the job now and then every interval in a background goroutine
+// until ctx is cancelled
+func (j *ReminderJob) Start(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			j.RunOnce(ctx)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
@@ -0,0 +1,300 @@
+package trips_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"black-lotus/internal/domain/models"
+	"black-lotus/internal/features/trips"
+)
+
+// MockReminderRepository finds trips through getTripsStartingOnFunc and keeps
+// reminders in memory, following the same rules for when they are due as the
+// database does
+type MockReminderRepository struct {
+	getTripsStartingOnFunc func(ctx context.Context, day time.Time) ([]*models.Trip, error)
+
+	mu        sync.Mutex
+	trips     map[uuid.UUID]*models.Trip
+	reminders []*models.TripReminder
+}
+
+func (m *MockReminderRepository) GetTripsStartingOn(ctx context.Context, day time.Time) ([]*models.Trip, error) {
+	if m.getTripsStartingOnFunc == nil {
+		return nil, errors.New("GetTripsStartingOn not implemented")
+	}
+
+	trips, err := m.getTripsStartingOnFunc(ctx, day)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.trips == nil {
+		m.trips = make(map[uuid.UUID]*models.Trip)
+	}
+	for _, trip := range trips {
+		m.trips[trip.ID] = trip
+	}
+	return trips, err
+}
+
+func (m *MockReminderRepository) CreateReminder(ctx context.Context, tripID uuid.UUID, tripStart time.Time, remindAt time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, reminder := range m.reminders {
+		if reminder.TripID == tripID && reminder.TripStart.Equal(tripStart) {
+			return nil
+		}
+	}
+	m.reminders = append(m.reminders, &models.TripReminder{
+		ID:        uuid.New(),
+		TripID:    tripID,
+		TripStart: tripStart,
+		Token:     uuid.NewString(),
+		RemindAt:  remindAt,
+	})
+	return nil
+}
+
+func (m *MockReminderRepository) GetDueReminders(ctx context.Context, now time.Time) ([]*models.TripReminder, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var due []*models.TripReminder
+	for _, reminder := range m.reminders {
+		if reminder.AcknowledgedAt == nil && !reminder.RemindAt.After(now) &&
+			(reminder.SentAt == nil || reminder.SentAt.Before(reminder.RemindAt)) {
+			reminder.Trip = m.trips[reminder.TripID]
+			due = append(due, reminder)
+		}
+	}
+	return due, nil
+}
+
+func (m *MockReminderRepository) MarkReminderSent(ctx context.Context, reminderID uuid.UUID, sentAt time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, reminder := range m.reminders {
+		if reminder.ID == reminderID {
+			reminder.SentAt = &sentAt
+			return nil
+		}
+	}
+	return errors.New("reminder not found")
+}
+
+// update applies change to the reminder for tripID, as the reminder links do
+func (m *MockReminderRepository) update(tripID uuid.UUID, change func(*models.TripReminder)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, reminder := range m.reminders {
+		if reminder.TripID == tripID {
+			change(reminder)
+		}
+	}
+}
+
+// fakeNotifier records the trips it was asked about and fails for those in failFor
+type fakeNotifier struct {
+	mu       sync.Mutex
+	notified []uuid.UUID
+	failFor  map[uuid.UUID]bool
+}
+
+func (n *fakeNotifier) NotifyTripReminder(ctx context.Context, reminder *models.TripReminder) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.failFor[reminder.Trip.ID] {
+		return errors.New("delivery failed")
+	}
+	n.notified = append(n.notified, reminder.Trip.ID)
+	return nil
+}
+
+// fakeClock is a settable clock that is safe to read from the job's goroutine
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func TestReminderJobRunOnce(t *testing.T) {
+	// Setup: mid-afternoon on 1 June, so tomorrow is 2 June
+	clock := &fakeClock{now: time.Date(2030, 6, 1, 15, 0, 0, 0, time.UTC)}
+	tomorrow := time.Date(2030, 6, 2, 0, 0, 0, 0, time.UTC)
+	first := &models.Trip{ID: uuid.New(), UserID: uuid.New(), StartDate: &tomorrow}
+	failing := &models.Trip{ID: uuid.New(), UserID: uuid.New(), StartDate: &tomorrow}
+	second := &models.Trip{ID: uuid.New(), UserID: uuid.New(), StartDate: &tomorrow}
+
+	var queried []time.Time
+	repo := &MockReminderRepository{
+		getTripsStartingOnFunc: func(ctx context.Context, day time.Time) ([]*models.Trip, error) {
+			queried = append(queried, day)
+			if day.Equal(tomorrow) {
+				return []*models.Trip{first, failing, second}, nil
+			}
+			return nil, nil
+		},
+	}
+	notifier := &fakeNotifier{failFor: map[uuid.UUID]bool{failing.ID: true}}
+	job := trips.NewReminderJob(repo, notifier, clock.Now)
+
+	// Execute: a failed notification doesn't stop the others
+	sent, err := job.RunOnce(context.Background())
+
+	// Verify
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if sent != 2 || len(notifier.notified) != 2 || notifier.notified[0] != first.ID || notifier.notified[1] != second.ID {
+		t.Errorf("Expected reminders for %s and %s, got %d sent: %v", first.ID, second.ID, sent, notifier.notified)
+	}
+	if len(queried) != 1 || !queried[0].Equal(tomorrow) {
+		t.Fatalf("Expected one query for %v, got %v", tomorrow, queried)
+	}
+
+	// Running again later the same day sends nothing new
+	clock.Advance(time.Hour)
+	if sent, err := job.RunOnce(context.Background()); err != nil || sent != 0 {
+		t.Errorf("Expected nothing sent twice on the same day, got %d, %v", sent, err)
+	}
+	if len(queried) != 1 {
+		t.Errorf("Expected no second query on the same day, got %v", queried)
+	}
+
+	// The next day looks at the day after
+	clock.Advance(24 * time.Hour)
+	if _, err := job.RunOnce(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(queried) != 2 || !queried[1].Equal(tomorrow.AddDate(0, 0, 1)) {
+		t.Errorf("Expected the next day to query %v, got %v", tomorrow.AddDate(0, 0, 1), queried)
+	}
+}
+
+func TestReminderJobAcknowledgeAndSnooze(t *testing.T) {
+	// Setup: two trips starting tomorrow, both reminded on the first run
+	clock := &fakeClock{now: time.Date(2030, 6, 1, 15, 0, 0, 0, time.UTC)}
+	tomorrow := time.Date(2030, 6, 2, 0, 0, 0, 0, time.UTC)
+	acknowledged := &models.Trip{ID: uuid.New(), UserID: uuid.New(), StartDate: &tomorrow}
+	snoozed := &models.Trip{ID: uuid.New(), UserID: uuid.New(), StartDate: &tomorrow}
+	repo := &MockReminderRepository{
+		getTripsStartingOnFunc: func(ctx context.Context, day time.Time) ([]*models.Trip, error) {
+			if day.Equal(tomorrow) {
+				return []*models.Trip{acknowledged, snoozed}, nil
+			}
+			return nil, nil
+		},
+	}
+	notifier := &fakeNotifier{}
+	job := trips.NewReminderJob(repo, notifier, clock.Now)
+
+	if sent, err := job.RunOnce(context.Background()); err != nil || sent != 2 {
+		t.Fatalf("Expected 2 reminders sent, got %d, %v", sent, err)
+	}
+
+	// Execute: one owner acknowledges, the other snoozes for two hours
+	acknowledgedAt := clock.Now()
+	repo.update(acknowledged.ID, func(r *models.TripReminder) { r.AcknowledgedAt = &acknowledgedAt })
+	repo.update(snoozed.ID, func(r *models.TripReminder) { r.RemindAt = clock.Now().Add(2 * time.Hour) })
+
+	// Verify: nothing is sent before the snooze ends, then only the snoozed one
+	clock.Advance(time.Hour)
+	if sent, err := job.RunOnce(context.Background()); err != nil || sent != 0 {
+		t.Errorf("Expected nothing sent while snoozed, got %d, %v", sent, err)
+	}
+
+	clock.Advance(time.Hour)
+	if sent, err := job.RunOnce(context.Background()); err != nil || sent != 1 {
+		t.Fatalf("Expected the snoozed reminder to be sent again, got %d, %v", sent, err)
+	}
+	if last := notifier.notified[len(notifier.notified)-1]; last != snoozed.ID {
+		t.Errorf("Expected the snoozed trip %s to be reminded again, got %s", snoozed.ID, last)
+	}
+
+	clock.Advance(time.Hour)
+	if sent, err := job.RunOnce(context.Background()); err != nil || sent != 0 {
+		t.Errorf("Expected the snoozed reminder to be sent only once more, got %d, %v", sent, err)
+	}
+}
+
+func TestReminderJobRetriesAfterQueryError(t *testing.T) {
+	// Setup
+	clock := &fakeClock{now: time.Date(2030, 6, 1, 15, 0, 0, 0, time.UTC)}
+	calls := 0
+	repo := &MockReminderRepository{
+		getTripsStartingOnFunc: func(ctx context.Context, day time.Time) ([]*models.Trip, error) {
+			calls++
+			if calls == 1 {
+				return nil, errors.New("database error")
+			}
+			return []*models.Trip{{ID: uuid.New(), StartDate: &day}}, nil
+		},
+	}
+	notifier := &fakeNotifier{}
+	job := trips.NewReminderJob(repo, notifier, clock.Now)
+
+	// Execute & verify: the failed day isn't marked as done
+	if _, err := job.RunOnce(context.Background()); err == nil {
+		t.Fatal("Expected the query error, got nil")
+	}
+
+	clock.Advance(time.Hour)
+	sent, err := job.RunOnce(context.Background())
+	if err != nil || sent != 1 {
+		t.Errorf("Expected the retry to send 1 reminder, got %d, %v", sent, err)
+	}
+}
+
+func TestReminderJobStopsWhenCancelled(t *testing.T) {
+	// Setup: every run sees a new day, so every run queries
+	clock := &fakeClock{now: time.Date(2030, 6, 1, 15, 0, 0, 0, time.UTC)}
+	runs := make(chan struct{}, 100)
+	repo := &MockReminderRepository{
+		getTripsStartingOnFunc: func(ctx context.Context, day time.Time) ([]*models.Trip, error) {
+			clock.Advance(24 * time.Hour)
+			runs <- struct{}{}
+			return nil, nil
+		},
+	}
+	job := trips.NewReminderJob(repo, &fakeNotifier{}, clock.Now)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// Execute: the first run happens straight away, the next on the first tick
+	job.Start(ctx, 10*time.Millisecond)
+	for i := 0; i < 2; i++ {
+		select {
+		case <-runs:
+		case <-time.After(time.Second):
+			t.Fatalf("Expected run %d to happen", i+1)
+		}
+	}
+	cancel()
+
+	// Verify: at most a run already under way finishes after cancelling
+	time.Sleep(50 * time.Millisecond)
+	settled := len(runs)
+	time.Sleep(50 * time.Millisecond)
+	if settled > 1 || len(runs) != settled {
+		t.Errorf("Expected the job to stop after cancelling, got %d more runs", len(runs))
+	}
+}
@@ -2,6 +2,7 @@ package trips
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -13,6 +14,27 @@ type Repository interface {
 	GetTripByID(ctx context.Context, tripID uuid.UUID) (*models.Trip, error)
 	UpdateTrip(ctx context.Context, tripID uuid.UUID, input models.UpdateTripInput) (*models.Trip, error)
 	DeleteTrip(ctx context.Context, tripID uuid.UUID) error
-	GetTripsByUserID(ctx context.Context, userID uuid.UUID, limit int, offset int) ([]*models.Trip, error)
+	GetDeletedTripByID(ctx context.Context, tripID uuid.UUID) (*models.Trip, error)
+	RestoreTrip(ctx context.Context, tripID uuid.UUID) (*models.Trip, error)
+	GetTripsByUserID(ctx context.Context, userID uuid.UUID, limit int, offset int, filter models.TripFilter) ([]*models.Trip, error)
+	GetTripListVersion(ctx context.Context, userID uuid.UUID) (*models.TripListVersion, error)
+	GetTripStartDates(ctx context.Context, userID uuid.UUID) ([]time.Time, error)
+	GetUpcomingTrips(ctx context.Context, userID uuid.UUID, within int) ([]*models.Trip, error)
+	GetTripsByLocation(ctx context.Context, userID uuid.UUID, location string) ([]*models.Trip, error)
 	GetTripWithUser(ctx context.Context, tripID uuid.UUID) (*models.Trip, error)
+	ImportTrip(ctx context.Context, userID uuid.UUID, export models.TripExport) (*models.TripImportResult, error)
+	AddTripTag(ctx context.Context, tripID uuid.UUID, userID uuid.UUID, name string) (*models.Tag, error)
+	RemoveTripTag(ctx context.Context, tripID uuid.UUID, userID uuid.UUID, name string) error
+	GetTripTags(ctx context.Context, tripID uuid.UUID) ([]*models.Tag, error)
+	GetTripActivities(ctx context.Context, tripID uuid.UUID) ([]*models.Activity, error)
+	GetTripExpenses(ctx context.Context, tripID uuid.UUID) ([]*models.Expense, error)
+	GetTripPhotos(ctx context.Context, tripID uuid.UUID) ([]*models.TripPhoto, error)
+	CountTripPhotos(ctx context.Context, tripID uuid.UUID) (int, error)
+	ReorderTrips(ctx context.Context, userID uuid.UUID, tripIDs []uuid.UUID) error
+	CreateTrips(ctx context.Context, userID uuid.UUID, inputs []models.CreateTripInput) ([]*models.Trip, error)
+	PlanTrip(ctx context.Context, tripID uuid.UUID, startDate, endDate time.Time) (*models.Trip, error)
+	BulkTagTrips(ctx context.Context, userID uuid.UUID, tripIDs []uuid.UUID, names []string, replace bool) ([]uuid.UUID, error)
+	ShareTrip(ctx context.Context, tripID uuid.UUID) (*models.TripShare, error)
+	UnshareTrip(ctx context.Context, tripID uuid.UUID) error
+	GetTripByShareToken(ctx context.Context, token string) (*models.Trip, error)
 }
@@ -4,41 +4,69 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 
 	"black-lotus/internal/domain/models"
+	"black-lotus/internal/features/expenses"
 	"black-lotus/internal/features/profiles/view"
 )
 
 type ServiceInterface interface {
 	CreateTrip(ctx context.Context, userID uuid.UUID, input models.CreateTripInput) (*models.Trip, error)
+	CreateTrips(ctx context.Context, userID uuid.UUID, inputs []models.CreateTripInput) ([]*models.Trip, []models.TripBatchError, error)
 	UpdateTrip(ctx context.Context, tripID uuid.UUID, userID uuid.UUID, input models.UpdateTripInput) (*models.Trip, error)
 	DeleteTrip(ctx context.Context, tripID uuid.UUID, userID uuid.UUID) error
+	RestoreTrip(ctx context.Context, tripID uuid.UUID, userID uuid.UUID) (*models.Trip, error)
 	GetTripByID(ctx context.Context, tripID uuid.UUID, userID uuid.UUID) (*models.Trip, error)
+	GetTripIncludingDeleted(ctx context.Context, tripID uuid.UUID, userID uuid.UUID) (*models.Trip, error)
+	AttachTripPhotos(ctx context.Context, trip *models.Trip, mode string) error
+	ShareTrip(ctx context.Context, tripID uuid.UUID, userID uuid.UUID) (*models.TripShare, error)
+	UnshareTrip(ctx context.Context, tripID uuid.UUID, userID uuid.UUID) error
+	GetSharedTrip(ctx context.Context, token string) (*models.PublicTrip, error)
 	GetTripWithUser(ctx context.Context, tripID uuid.UUID, requestUserID uuid.UUID) (*models.Trip, error)
+	ExportTrip(ctx context.Context, tripID uuid.UUID, userID uuid.UUID) (*models.TripExport, error)
+	ImportTrip(ctx context.Context, userID uuid.UUID, export models.TripExport) (*models.TripImportResult, []models.TripImportError, error)
 	GetUserWithTrips(ctx context.Context, userID uuid.UUID, limit, offset int) (*models.User, error)
-	GetTripsByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*models.Trip, error)
+	GetTripsByUserID(ctx context.Context, userID uuid.UUID, limit, offset int, filter models.TripFilter) ([]*models.Trip, error)
+	GetTripPage(ctx context.Context, userID uuid.UUID, limit int, cursor string, filter models.TripFilter) (*models.TripPage, error)
+	GetTripListVersion(ctx context.Context, userID uuid.UUID) (*models.TripListVersion, error)
+	GetTripCadence(ctx context.Context, userID uuid.UUID) (*models.TripCadence, error)
+	GetLocationHistory(ctx context.Context, userID uuid.UUID, location string) (*models.LocationHistory, error)
+	GetCurrentTrips(ctx context.Context, userID uuid.UUID) ([]*models.Trip, error)
+	GetUpcomingTrips(ctx context.Context, userID uuid.UUID, within int) ([]*models.Trip, error)
+	GetCalendarTrips(ctx context.Context, userID uuid.UUID) ([]*models.Trip, error)
+	GetTripDaysBreakdown(ctx context.Context, tripID uuid.UUID, userID uuid.UUID) (*models.TripDaysBreakdown, error)
+	AddTripTag(ctx context.Context, tripID uuid.UUID, userID uuid.UUID, input models.AddTripTagInput) ([]*models.Tag, error)
+	RemoveTripTag(ctx context.Context, tripID uuid.UUID, userID uuid.UUID, name string) error
+	ReorderTrips(ctx context.Context, userID uuid.UUID, input models.ReorderTripsInput) error
+	PlanTrip(ctx context.Context, tripID uuid.UUID, userID uuid.UUID, input models.PlanTripInput) (*models.Trip, error)
+	BulkTagTrips(ctx context.Context, userID uuid.UUID, input models.BulkTagTripsInput) ([]models.BulkTagResult, error)
 }
 
 type Service struct {
 	repo        Repository
 	userService view.ServiceInterface
+	covers      CoverSource
 }
 
-func NewService(repo Repository, userService view.ServiceInterface) *Service {
-	return &Service{repo: repo, userService: userService}
+func NewService(repo Repository, userService view.ServiceInterface, covers CoverSource) *Service {
+	return &Service{repo: repo, userService: userService, covers: covers}
 }
 
 func (s *Service) CreateTrip(ctx context.Context, userID uuid.UUID, input models.CreateTripInput) (*models.Trip, error) {
 	// Validate dates from user
-	if input.EndDate.Before(input.StartDate) {
-		return nil, errors.New("end date cannot be before start date")
+	if err := validateTripDates(input); err != nil {
+		return nil, err
 	}
 
 	// If name is empty, we generate a default name for the Trip
 	if input.Name == "" {
-		input.Name = fmt.Sprintf("Trip to %s", input.Location)
+		input.Name = defaultTripName(input.Location)
 	}
 
 	// Create the trip in the DB
@@ -48,9 +76,55 @@ func (s *Service) CreateTrip(ctx context.Context, userID uuid.UUID, input models
 		return nil, err
 	}
 
+	trip.ClientID = input.ClientID
+	s.setComputedFields(trip)
 	return trip, nil
 }
 
+// CreateTrips creates a batch of trips all-or-nothing. Every trip is checked
+// first; if any are invalid nothing is created and the errors are returned by index.
+func (s *Service) CreateTrips(ctx context.Context, userID uuid.UUID, inputs []models.CreateTripInput) ([]*models.Trip, []models.TripBatchError, error) {
+	if len(inputs) == 0 {
+		return nil, nil, errors.New("no trips to create")
+	}
+	if len(inputs) > MaxBulkTrips {
+		return nil, nil, errors.New("too many trips in batch")
+	}
+
+	var batchErrors []models.TripBatchError
+	for i := range inputs {
+		if err := validateTripDates(inputs[i]); err != nil {
+			batchErrors = append(batchErrors, models.TripBatchError{
+				Index:   i,
+				Field:   err.Field,
+				Message: err.Message,
+			})
+		}
+
+		// Same default naming as CreateTrip
+		if inputs[i].Name == "" {
+			inputs[i].Name = defaultTripName(inputs[i].Location)
+		}
+	}
+
+	if len(batchErrors) > 0 {
+		return nil, batchErrors, nil
+	}
+
+	trips, err := s.repo.CreateTrips(ctx, userID, inputs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Trips come back in input order
+	for i, trip := range trips {
+		trip.ClientID = inputs[i].ClientID
+	}
+
+	s.setComputedFields(trips...)
+	return trips, nil, nil
+}
+
 // UpdateTrip updates a trip with ownership verification
 func (s *Service) UpdateTrip(ctx context.Context, tripID uuid.UUID, userID uuid.UUID, input models.UpdateTripInput) (*models.Trip, error) {
 	// First, verify ownership
@@ -63,19 +137,27 @@ func (s *Service) UpdateTrip(ctx context.Context, tripID uuid.UUID, userID uuid.
 		return nil, errors.New("unauthorized access to trip")
 	}
 
-	// If updating dates, validate them
-	if input.StartDate != nil && input.EndDate != nil {
-		if input.EndDate.Before(*input.StartDate) {
-			return nil, errors.New("end date cannot be before start date")
+	// If updating dates, validate them. Wishlist trips are exempt until they're planned.
+	if !trip.IsWishlist {
+		if input.StartDate != nil && input.EndDate != nil {
+			if endsBeforeStart(*input.EndDate, *input.StartDate) {
+				return nil, ErrEndBeforeStart
+			}
+		} else if input.StartDate != nil && trip.EndDate != nil && endsBeforeStart(*trip.EndDate, *input.StartDate) {
+			return nil, ErrEndBeforeStart
+		} else if input.EndDate != nil && trip.StartDate != nil && endsBeforeStart(*input.EndDate, *trip.StartDate) {
+			return nil, ErrEndBeforeStart
 		}
-	} else if input.StartDate != nil && trip.EndDate.Before(*input.StartDate) {
-		return nil, errors.New("end date cannot be before start date")
-	} else if input.EndDate != nil && input.EndDate.Before(trip.StartDate) {
-		return nil, errors.New("end date cannot be before start date")
 	}
 
 	// Update the trip
-	return s.repo.UpdateTrip(ctx, tripID, input)
+	updated, err := s.repo.UpdateTrip(ctx, tripID, input)
+	if err != nil {
+		return nil, err
+	}
+
+	s.setComputedFields(updated)
+	return updated, nil
 }
 
 // DeleteTrip deletes a trip with ownership verification
@@ -93,6 +175,59 @@ func (s *Service) DeleteTrip(ctx context.Context, tripID uuid.UUID, userID uuid.
 	return s.repo.DeleteTrip(ctx, tripID)
 }
 
+// RestoreTrip restores a soft-deleted trip if it is still within the restore window
+func (s *Service) RestoreTrip(ctx context.Context, tripID uuid.UUID, userID uuid.UUID) (*models.Trip, error) {
+	trip, err := s.repo.GetDeletedTripByID(ctx, tripID)
+	if err != nil {
+		return nil, err
+	}
+
+	if trip.UserID != userID {
+		return nil, errors.New("unauthorized access to trip")
+	}
+
+	if trip.DeletedAt != nil && time.Since(*trip.DeletedAt) > TripRestoreWindow {
+		return nil, errors.New("restore window has expired")
+	}
+
+	restored, err := s.repo.RestoreTrip(ctx, tripID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.setComputedFields(restored)
+	return restored, nil
+}
+
+// PlanTrip converts a wishlist trip into a planned trip. The dates go through
+// the same rules as creating a planned trip.
+func (s *Service) PlanTrip(ctx context.Context, tripID uuid.UUID, userID uuid.UUID, input models.PlanTripInput) (*models.Trip, error) {
+	trip, err := s.repo.GetTripByID(ctx, tripID)
+	if err != nil {
+		return nil, err
+	}
+
+	if trip.UserID != userID {
+		return nil, errors.New("unauthorized access to trip")
+	}
+
+	if !trip.IsWishlist {
+		return nil, errors.New("trip is already planned")
+	}
+
+	if err := validateTripDates(models.CreateTripInput{StartDate: input.StartDate, EndDate: input.EndDate}); err != nil {
+		return nil, err
+	}
+
+	planned, err := s.repo.PlanTrip(ctx, tripID, input.StartDate, input.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	s.setComputedFields(planned)
+	return planned, nil
+}
+
 // GetTripByID retrieves a trip by ID, with ownership verification
 func (s *Service) GetTripByID(ctx context.Context, tripID uuid.UUID, userID uuid.UUID) (*models.Trip, error) {
 	trip, err := s.repo.GetTripByID(ctx, tripID)
@@ -105,9 +240,108 @@ func (s *Service) GetTripByID(ctx context.Context, tripID uuid.UUID, userID uuid
 		return nil, errors.New("unauthorized access to trip")
 	}
 
+	s.setComputedFields(trip)
 	return trip, nil
 }
 
+// AttachTripPhotos loads the photo count, and with TripPhotosList the photos
+// too, onto a trip the caller has already been authorized for
+func (s *Service) AttachTripPhotos(ctx context.Context, trip *models.Trip, mode string) error {
+	switch mode {
+	case models.TripPhotosNone:
+		return nil
+	case models.TripPhotosCount:
+		count, err := s.repo.CountTripPhotos(ctx, trip.ID)
+		if err != nil {
+			return err
+		}
+		trip.PhotoCount = &count
+	case models.TripPhotosList:
+		photos, err := s.repo.GetTripPhotos(ctx, trip.ID)
+		if err != nil {
+			return err
+		}
+		count := len(photos)
+		trip.Photos, trip.PhotoCount = photos, &count
+	default:
+		return errors.New("invalid photos mode")
+	}
+
+	return nil
+}
+
+// GetTripIncludingDeleted is GetTripByID that also finds the user's own
+// soft-deleted trips, with deleted_at set. Someone else's deleted trip is
+// reported as not found so its existence isn't revealed.
+func (s *Service) GetTripIncludingDeleted(ctx context.Context, tripID uuid.UUID, userID uuid.UUID) (*models.Trip, error) {
+	trip, err := s.GetTripByID(ctx, tripID, userID)
+	if err == nil || err.Error() != "trip not found" {
+		return trip, err
+	}
+
+	trip, err = s.repo.GetDeletedTripByID(ctx, tripID)
+	if err != nil {
+		return nil, err
+	}
+
+	if trip.UserID != userID {
+		return nil, errors.New("trip not found")
+	}
+
+	s.setComputedFields(trip)
+	return trip, nil
+}
+
+// ShareTrip makes a trip the user owns unlisted and returns its share link token
+func (s *Service) ShareTrip(ctx context.Context, tripID uuid.UUID, userID uuid.UUID) (*models.TripShare, error) {
+	if _, err := s.GetTripByID(ctx, tripID, userID); err != nil {
+		return nil, err
+	}
+
+	return s.repo.ShareTrip(ctx, tripID)
+}
+
+// UnshareTrip makes a trip the user owns private again, revoking its share link
+func (s *Service) UnshareTrip(ctx context.Context, tripID uuid.UUID, userID uuid.UUID) error {
+	if _, err := s.GetTripByID(ctx, tripID, userID); err != nil {
+		return err
+	}
+
+	return s.repo.UnshareTrip(ctx, tripID)
+}
+
+// GetSharedTrip returns the public view of the unlisted trip a share token
+// points at. Unknown, revoked and private tokens are all "trip not found".
+func (s *Service) GetSharedTrip(ctx context.Context, token string) (*models.PublicTrip, error) {
+	if token == "" {
+		return nil, errors.New("trip not found")
+	}
+
+	trip, err := s.repo.GetTripByShareToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	s.setComputedFields(trip)
+
+	tags := make([]string, 0, len(trip.Tags))
+	for _, tag := range trip.Tags {
+		tags = append(tags, tag.Name)
+	}
+
+	return &models.PublicTrip{
+		Name:          trip.Name,
+		Description:   trip.Description,
+		StartDate:     trip.StartDate,
+		EndDate:       trip.EndDate,
+		Location:      trip.Location,
+		IsWishlist:    trip.IsWishlist,
+		Status:        trip.Status,
+		Tags:          tags,
+		CoverImageURL: trip.CoverImageURL,
+	}, nil
+}
+
 func (s *Service) GetTripWithUser(ctx context.Context, tripID uuid.UUID, requestUserID uuid.UUID) (*models.Trip, error) {
 	trip, err := s.repo.GetTripWithUser(ctx, tripID)
 	if err != nil {
@@ -119,9 +353,201 @@ func (s *Service) GetTripWithUser(ctx context.Context, tripID uuid.UUID, request
 		return nil, errors.New("unauthorized access to trip")
 	}
 
+	s.setComputedFields(trip)
 	return trip, nil
 }
 
+// ExportTrip builds a self-contained export document for a trip the user owns
+func (s *Service) ExportTrip(ctx context.Context, tripID uuid.UUID, userID uuid.UUID) (*models.TripExport, error) {
+	trip, err := s.GetTripByID(ctx, tripID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	activities, err := s.repo.GetTripActivities(ctx, tripID)
+	if err != nil {
+		return nil, err
+	}
+
+	exportedActivities := make([]models.CreateActivityInput, 0, len(activities))
+	for _, activity := range activities {
+		exportedActivities = append(exportedActivities, models.CreateActivityInput{
+			Title:       activity.Title,
+			Description: activity.Description,
+			StartTime:   activity.StartTime,
+			EndTime:     activity.EndTime,
+			Location:    activity.Location,
+		})
+	}
+
+	tripExpenses, err := s.repo.GetTripExpenses(ctx, tripID)
+	if err != nil {
+		return nil, err
+	}
+
+	exportedExpenses := make([]models.CreateExpenseInput, 0, len(tripExpenses))
+	for _, expense := range tripExpenses {
+		exportedExpenses = append(exportedExpenses, models.CreateExpenseInput{
+			AmountCents: expense.AmountCents,
+			Currency:    expense.Currency,
+			Category:    expense.Category,
+			Note:        expense.Note,
+			PaidBy:      expense.PaidBy,
+			SplitAmong:  expense.SplitAmong,
+			IncurredAt:  expense.IncurredAt,
+		})
+	}
+
+	tags, err := s.repo.GetTripTags(ctx, tripID)
+	if err != nil {
+		return nil, err
+	}
+
+	exportedTags := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		exportedTags = append(exportedTags, tag.Name)
+	}
+
+	// A generated fallback isn't part of the trip, so it isn't exported
+	coverImageURL := trip.CoverImageURL
+	if trip.CoverImageFallback {
+		coverImageURL = ""
+	}
+
+	return &models.TripExport{
+		Version:    models.TripExportVersion,
+		ExportedAt: time.Now().UTC(),
+		Trip: models.CreateTripInput{
+			Name:          trip.Name,
+			Description:   trip.Description,
+			StartDate:     dateOrZero(trip.StartDate),
+			EndDate:       dateOrZero(trip.EndDate),
+			Location:      trip.Location,
+			IsWishlist:    trip.IsWishlist,
+			CoverImageURL: coverImageURL,
+		},
+		Activities: exportedActivities,
+		Expenses:   exportedExpenses,
+		Tags:       exportedTags,
+	}, nil
+}
+
+// ImportTrip recreates an exported trip under the given user. Every item in the
+// document is validated first; if any are invalid nothing is imported and the
+// per-item errors are returned instead.
+func (s *Service) ImportTrip(ctx context.Context, userID uuid.UUID, export models.TripExport) (*models.TripImportResult, []models.TripImportError, error) {
+	if export.Version < 1 || export.Version > models.TripExportVersion {
+		return nil, nil, errors.New("unsupported export version")
+	}
+
+	var importErrors []models.TripImportError
+	if err := validateTripDates(export.Trip); err != nil {
+		importErrors = append(importErrors, models.TripImportError{
+			Section: "trip",
+			Message: err.Error(),
+		})
+	}
+	hasDates := !export.Trip.StartDate.IsZero() && !export.Trip.EndDate.IsZero()
+
+	for i, activity := range export.Activities {
+		if activity.Title == "" {
+			importErrors = append(importErrors, models.TripImportError{
+				Section: "activities",
+				Index:   i,
+				Message: "title is required",
+			})
+		}
+		if activity.EndTime.Before(activity.StartTime) {
+			importErrors = append(importErrors, models.TripImportError{
+				Section: "activities",
+				Index:   i,
+				Message: "end time cannot be before start time",
+			})
+		} else if !hasDates {
+			// As with CreateActivity, activities need a trip with dates
+			importErrors = append(importErrors, models.TripImportError{
+				Section: "activities",
+				Index:   i,
+				Message: "trip has no dates",
+			})
+		} else if !models.WithinTripDates(export.Trip.StartDate, export.Trip.EndDate, activity.StartTime, activity.EndTime) {
+			importErrors = append(importErrors, models.TripImportError{
+				Section: "activities",
+				Index:   i,
+				Message: "activity must be within the trip dates",
+			})
+		}
+	}
+
+	for i := range export.Expenses {
+		// Checked and tidied the same way as CreateExpense
+		expense := &export.Expenses[i]
+		expense.Currency = strings.ToUpper(strings.TrimSpace(expense.Currency))
+		expense.Category = strings.ToLower(strings.TrimSpace(expense.Category))
+
+		var messages []string
+		if expense.AmountCents <= 0 {
+			messages = append(messages, "amount must be positive")
+		}
+		if !isCurrencyCode(expense.Currency) {
+			messages = append(messages, "currency must be a three-letter ISO 4217 code")
+		}
+		if expense.Category == "" {
+			messages = append(messages, "category is required")
+		}
+		if expense.IncurredAt.IsZero() {
+			messages = append(messages, "incurred_at is required")
+		}
+		paidBy, splitAmong, err := expenses.NormalizeSplit(expense.PaidBy, expense.SplitAmong)
+		if err != nil {
+			messages = append(messages, err.Error())
+		}
+		expense.PaidBy, expense.SplitAmong = paidBy, splitAmong
+
+		for _, message := range messages {
+			importErrors = append(importErrors, models.TripImportError{
+				Section: "expenses",
+				Index:   i,
+				Message: message,
+			})
+		}
+	}
+
+	// Duplicate tags collapse into one, as they would when added one by one
+	tags := make([]string, 0, len(export.Tags))
+	seenTags := make(map[string]bool, len(export.Tags))
+	for i, tag := range export.Tags {
+		name := normalizeTagName(tag)
+		switch {
+		case name == "":
+			importErrors = append(importErrors, models.TripImportError{Section: "tags", Index: i, Message: "tag name is required"})
+		case len(name) > maxTagNameLength:
+			importErrors = append(importErrors, models.TripImportError{Section: "tags", Index: i, Message: fmt.Sprintf("tag name must be at most %d characters long", maxTagNameLength)})
+		case !seenTags[name]:
+			seenTags[name] = true
+			tags = append(tags, name)
+		}
+	}
+	export.Tags = tags
+
+	if len(importErrors) > 0 {
+		return nil, importErrors, nil
+	}
+
+	// Same default naming as CreateTrip
+	if export.Trip.Name == "" {
+		export.Trip.Name = defaultTripName(export.Trip.Location)
+	}
+
+	result, err := s.repo.ImportTrip(ctx, userID, export)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	s.setComputedFields(result.Trip)
+	return result, nil, nil
+}
+
 func (s *Service) GetUserWithTrips(ctx context.Context, userID uuid.UUID, limit, offset int) (*models.User, error) {
 	// First, verify the user exists
 	user, err := s.userService.GetUserProfile(ctx, userID)
@@ -135,17 +561,203 @@ func (s *Service) GetUserWithTrips(ctx context.Context, userID uuid.UUID, limit,
 	}
 
 	// Then get their trips
-	trips, err := s.repo.GetTripsByUserID(ctx, userID, limit, offset)
+	trips, err := s.repo.GetTripsByUserID(ctx, userID, limit, offset, models.TripFilter{})
 	if err != nil {
 		return nil, err
 	}
 
 	// Attach trips to user
+	s.setComputedFields(trips...)
 	user.Trips = trips
 	return user, nil
 }
 
-func (s *Service) GetTripsByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*models.Trip, error) {
+// GetTripListVersion summarizes the user's trips for conditional list requests
+func (s *Service) GetTripListVersion(ctx context.Context, userID uuid.UUID) (*models.TripListVersion, error) {
+	return s.repo.GetTripListVersion(ctx, userID)
+}
+
+// GetCurrentTrips returns the user's trips in progress right now, earliest start first
+func (s *Service) GetCurrentTrips(ctx context.Context, userID uuid.UUID) ([]*models.Trip, error) {
+	wishlist := false
+	trips, err := s.repo.GetTripsByUserID(ctx, userID, MaxCurrentTrips, 0, models.TripFilter{
+		Status:   models.TripStatusOngoing,
+		Wishlist: &wishlist,
+		SortSpec: &models.TripSortSpec{Field: "start_date"},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The database and this server may disagree on "now" by a moment; keep
+	// only trips whose computed status says ongoing so the two never conflict
+	s.setComputedFields(trips...)
+	current := make([]*models.Trip, 0, len(trips))
+	for _, trip := range trips {
+		if trip.Status == models.TripStatusOngoing {
+			current = append(current, trip)
+		}
+	}
+
+	return current, nil
+}
+
+// GetUpcomingTrips returns the user's trips starting within the next within
+// days, soonest first
+func (s *Service) GetUpcomingTrips(ctx context.Context, userID uuid.UUID, within int) ([]*models.Trip, error) {
+	if within <= 0 || within > MaxUpcomingDays {
+		return nil, errors.New("invalid upcoming window")
+	}
+
+	trips, err := s.repo.GetUpcomingTrips(ctx, userID, within)
+	if err != nil {
+		return nil, err
+	}
+
+	s.setComputedFields(trips...)
+	return trips, nil
+}
+
+// GetCalendarTrips returns the user's dated, non-wishlist trips for the calendar
+// feed, earliest start first, up to MaxCalendarTrips
+func (s *Service) GetCalendarTrips(ctx context.Context, userID uuid.UUID) ([]*models.Trip, error) {
+	wishlist := false
+	trips, err := s.repo.GetTripsByUserID(ctx, userID, MaxCalendarTrips, 0, models.TripFilter{
+		Wishlist: &wishlist,
+		SortSpec: &models.TripSortSpec{Field: "start_date"},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.setComputedFields(trips...)
+	return trips, nil
+}
+
+// GetTripDaysBreakdown counts the weekdays and weekend days a trip the user owns covers
+func (s *Service) GetTripDaysBreakdown(ctx context.Context, tripID uuid.UUID, userID uuid.UUID) (*models.TripDaysBreakdown, error) {
+	trip, err := s.GetTripByID(ctx, tripID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if trip.StartDate == nil || trip.EndDate == nil {
+		return nil, errors.New("trip has no dates")
+	}
+
+	breakdown := tripDaysBreakdown(*trip.StartDate, *trip.EndDate)
+	return &breakdown, nil
+}
+
+// tripDaysBreakdown walks the UTC calendar days from start to end inclusive,
+// the days trip dates are stored in, so a trip that starts and ends on the
+// same day covers one day
+func tripDaysBreakdown(start, end time.Time) models.TripDaysBreakdown {
+	var breakdown models.TripDaysBreakdown
+
+	day := time.Date(start.UTC().Year(), start.UTC().Month(), start.UTC().Day(), 0, 0, 0, 0, time.UTC)
+	last := time.Date(end.UTC().Year(), end.UTC().Month(), end.UTC().Day(), 0, 0, 0, 0, time.UTC)
+	for ; !day.After(last); day = day.AddDate(0, 0, 1) {
+		breakdown.TotalDays++
+		if weekday := day.Weekday(); weekday == time.Saturday || weekday == time.Sunday {
+			breakdown.WeekendDays++
+		} else {
+			breakdown.Weekdays++
+		}
+	}
+
+	return breakdown
+}
+
+// GetTripCadence summarizes how often the user travels
+func (s *Service) GetTripCadence(ctx context.Context, userID uuid.UUID) (*models.TripCadence, error) {
+	starts, err := s.repo.GetTripStartDates(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return tripCadence(starts), nil
+}
+
+// tripCadence computes cadence stats from start dates sorted earliest first.
+// Gaps are measured between consecutive start dates, in days to one decimal.
+func tripCadence(starts []time.Time) *models.TripCadence {
+	cadence := &models.TripCadence{
+		TripCount:    len(starts),
+		TripsPerYear: []models.TripYearCount{},
+	}
+
+	for _, start := range starts {
+		year := start.UTC().Year()
+		if last := len(cadence.TripsPerYear) - 1; last >= 0 && cadence.TripsPerYear[last].Year == year {
+			cadence.TripsPerYear[last].Trips++
+			continue
+		}
+		cadence.TripsPerYear = append(cadence.TripsPerYear, models.TripYearCount{Year: year, Trips: 1})
+	}
+
+	if len(starts) < 2 {
+		return cadence
+	}
+
+	var longest time.Duration
+	for i := 1; i < len(starts); i++ {
+		longest = max(longest, starts[i].Sub(starts[i-1]))
+	}
+
+	// The gaps add up to the span from the first trip to the last
+	average := starts[len(starts)-1].Sub(starts[0]) / time.Duration(len(starts)-1)
+
+	averageDays := roundDays(average)
+	longestDays := roundDays(longest)
+	cadence.AverageGapDays = &averageDays
+	cadence.LongestGapDays = &longestDays
+
+	return cadence
+}
+
+// GetLocationHistory gathers the user's trips to a location, however its
+// case and spacing were written, with totals across them
+func (s *Service) GetLocationHistory(ctx context.Context, userID uuid.UUID, location string) (*models.LocationHistory, error) {
+	location = normalizeLocation(location)
+	if location == "" {
+		return nil, errors.New("invalid location")
+	}
+
+	trips, err := s.repo.GetTripsByLocation(ctx, userID, location)
+	if err != nil {
+		return nil, err
+	}
+
+	history := &models.LocationHistory{
+		Location:  location,
+		TripCount: len(trips),
+		Trips:     []*models.Trip{},
+	}
+	for _, trip := range trips {
+		s.setComputedFields(trip)
+		history.Trips = append(history.Trips, trip)
+
+		if trip.StartDate == nil || trip.EndDate == nil {
+			continue
+		}
+		history.TotalDays += int(math.Round(trip.EndDate.Sub(*trip.StartDate).Hours()/24)) + 1
+		if history.FirstVisit == nil || trip.StartDate.Before(*history.FirstVisit) {
+			history.FirstVisit = trip.StartDate
+		}
+		if history.LastVisit == nil || trip.StartDate.After(*history.LastVisit) {
+			history.LastVisit = trip.StartDate
+		}
+	}
+
+	return history, nil
+}
+
+func roundDays(d time.Duration) float64 {
+	return math.Round(d.Hours()/24*10) / 10
+}
+
+func (s *Service) GetTripsByUserID(ctx context.Context, userID uuid.UUID, limit, offset int, filter models.TripFilter) ([]*models.Trip, error) {
 	// Verify user exists first
 	user, err := s.userService.GetUserProfile(ctx, userID)
 	if err != nil {
@@ -157,10 +769,252 @@ func (s *Service) GetTripsByUserID(ctx context.Context, userID uuid.UUID, limit,
 		return nil, errors.New("user not found")
 	}
 
-	trips, err := s.repo.GetTripsByUserID(ctx, userID, limit, offset)
+	filter.Tag = normalizeTagName(filter.Tag)
+
+	if filter.Sort != models.TripSortStartDate && filter.Sort != models.TripSortManual {
+		spec, ok := models.ParseTripSortSpec(filter.Sort)
+		if !ok {
+			return nil, errors.New("invalid sort option")
+		}
+		filter.SortSpec = &spec
+	}
+
+	switch filter.Status {
+	case "", models.TripStatusPast, models.TripStatusOngoing, models.TripStatusUpcoming:
+	default:
+		return nil, errors.New("invalid status filter")
+	}
+
+	trips, err := s.repo.GetTripsByUserID(ctx, userID, limit, offset, filter)
 	if err != nil {
 		return nil, err
 	}
 
+	s.setComputedFields(trips...)
 	return trips, nil
 }
+
+// GetTripPage lists trips newest first using keyset pagination. cursor is
+// empty for the first page, then the previous page's NextCursor. Unlike
+// offsets, cursors don't skip or repeat trips when trips are added between pages.
+func (s *Service) GetTripPage(ctx context.Context, userID uuid.UUID, limit int, cursor string, filter models.TripFilter) (*models.TripPage, error) {
+	if filter.Sort != models.TripSortStartDate {
+		return nil, errors.New("cursor pagination cannot be sorted")
+	}
+
+	after, ok := models.ParseTripCursor(cursor)
+	if !ok {
+		return nil, errors.New("invalid cursor")
+	}
+	filter.Cursor = &after
+
+	if limit <= 0 {
+		limit = DefaultTripPageSize
+	}
+
+	// One extra trip tells whether there is another page
+	trips, err := s.GetTripsByUserID(ctx, userID, limit+1, 0, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &models.TripPage{Trips: trips}
+	if len(trips) > limit {
+		page.Trips = trips[:limit]
+		last := page.Trips[limit-1]
+		next := models.TripCursor{CreatedAt: last.CreatedAt, ID: last.ID}.Encode()
+		page.NextCursor = &next
+	}
+	if page.Trips == nil {
+		page.Trips = []*models.Trip{}
+	}
+
+	return page, nil
+}
+
+// AddTripTag attaches a tag to a trip the user owns and returns the trip's tags
+func (s *Service) AddTripTag(ctx context.Context, tripID uuid.UUID, userID uuid.UUID, input models.AddTripTagInput) ([]*models.Tag, error) {
+	if _, err := s.GetTripByID(ctx, tripID, userID); err != nil {
+		return nil, err
+	}
+
+	name := normalizeTagName(input.Name)
+	if name == "" {
+		return nil, errors.New("tag name is required")
+	}
+
+	if _, err := s.repo.AddTripTag(ctx, tripID, userID, name); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetTripTags(ctx, tripID)
+}
+
+// BulkTagTrips applies tags to up to MaxBulkTrips trips at once. Trips the user
+// doesn't own are reported as not found rather than failing the whole request.
+func (s *Service) BulkTagTrips(ctx context.Context, userID uuid.UUID, input models.BulkTagTripsInput) ([]models.BulkTagResult, error) {
+	if len(input.IDs) == 0 {
+		return nil, errors.New("no trips to tag")
+	}
+	if len(input.IDs) > MaxBulkTrips {
+		return nil, errors.New("too many trips in batch")
+	}
+	if input.Mode != models.BulkTagModeAdd && input.Mode != models.BulkTagModeReplace {
+		return nil, errors.New("invalid bulk tag mode")
+	}
+
+	var names []string
+	for _, tag := range input.Tags {
+		name := normalizeTagName(tag)
+		if name == "" {
+			return nil, errors.New("tag name is required")
+		}
+		if !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return nil, errors.New("tag name is required")
+	}
+
+	var tripIDs []uuid.UUID
+	for _, id := range input.IDs {
+		if !slices.Contains(tripIDs, id) {
+			tripIDs = append(tripIDs, id)
+		}
+	}
+
+	tagged, err := s.repo.BulkTagTrips(ctx, userID, tripIDs, names, input.Mode == models.BulkTagModeReplace)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]models.BulkTagResult, 0, len(tripIDs))
+	for _, id := range tripIDs {
+		if !slices.Contains(tagged, id) {
+			results = append(results, models.BulkTagResult{ID: id, Status: models.BulkTagStatusNotFound})
+			continue
+		}
+
+		tags, err := s.repo.GetTripTags(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, models.BulkTagResult{ID: id, Status: models.BulkTagStatusTagged, Tags: tags})
+	}
+
+	return results, nil
+}
+
+// RemoveTripTag detaches a tag from a trip the user owns
+func (s *Service) RemoveTripTag(ctx context.Context, tripID uuid.UUID, userID uuid.UUID, name string) error {
+	if _, err := s.GetTripByID(ctx, tripID, userID); err != nil {
+		return err
+	}
+
+	return s.repo.RemoveTripTag(ctx, tripID, userID, normalizeTagName(name))
+}
+
+// ReorderTrips saves the user's manual trip order, used by the manual sort
+func (s *Service) ReorderTrips(ctx context.Context, userID uuid.UUID, input models.ReorderTripsInput) error {
+	if len(input.TripIDs) == 0 {
+		return errors.New("invalid trip order")
+	}
+
+	seen := make(map[uuid.UUID]bool, len(input.TripIDs))
+	for _, id := range input.TripIDs {
+		if seen[id] {
+			return errors.New("invalid trip order")
+		}
+		seen[id] = true
+	}
+
+	// The repository rejects IDs that aren't the user's active trips
+	return s.repo.ReorderTrips(ctx, userID, input.TripIDs)
+}
+
+// setComputedFields fills in the status and, for trips without a cover, the
+// fallback cover of trips about to be returned
+func (s *Service) setComputedFields(trips ...*models.Trip) {
+	now := time.Now()
+	for _, trip := range trips {
+		if trip == nil {
+			continue
+		}
+
+		trip.Status = models.TripStatusAt(trip.StartDate, trip.EndDate, now)
+		if trip.CoverImageURL == "" {
+			trip.CoverImageURL = s.covers.CoverURL(trip.Location)
+			trip.CoverImageFallback = true
+		}
+	}
+}
+
+// maxTagNameLength matches the max on models.AddTripTagInput
+const maxTagNameLength = 50
+
+// isCurrencyCode reports whether code looks like an ISO 4217 code: three
+// uppercase letters
+func isCurrencyCode(code string) bool {
+	if len(code) != 3 {
+		return false
+	}
+	for _, r := range code {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// normalizeTagName makes tag names case- and whitespace-insensitive so
+// "Business" and " business " refer to the same tag
+func normalizeTagName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// normalizeLocation makes locations case- and whitespace-insensitive so
+// "Paris" and "  paris " group together
+func normalizeLocation(location string) string {
+	return strings.Join(strings.Fields(strings.ToLower(location)), " ")
+}
+
+// validateTripDates requires ordered start and end dates on planned trips.
+// Wishlist trips are exempt: their dates are optional and unchecked.
+func validateTripDates(input models.CreateTripInput) *ValidationError {
+	if input.IsWishlist {
+		return nil
+	}
+
+	if input.StartDate.IsZero() || input.EndDate.IsZero() {
+		return ErrDatesRequired
+	}
+
+	if endsBeforeStart(input.EndDate, input.StartDate) {
+		return ErrEndBeforeStart
+	}
+
+	return nil
+}
+
+// endsBeforeStart compares trip dates as the days they are stored as, so an
+// end on the start day is a valid single-day trip whatever the times were
+func endsBeforeStart(end, start time.Time) bool {
+	return models.TripDay(end).Before(models.TripDay(start))
+}
+
+// defaultTripName names a trip after its location, which some deployments make optional
+func defaultTripName(location string) string {
+	if strings.TrimSpace(location) == "" {
+		return "Untitled trip"
+	}
+	return fmt.Sprintf("Trip to %s", location)
+}
+
+// dateOrZero converts an optional trip date to the zero-means-unset form used by inputs
+func dateOrZero(date *time.Time) time.Time {
+	if date == nil {
+		return time.Time{}
+	}
+	return *date
+}
@@ -2,7 +2,10 @@ package trips_test
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
@@ -14,12 +17,33 @@ import (
 
 // MockRepository implements trips.Repository for testing
 type MockRepository struct {
-	createTripFunc       func(ctx context.Context, userID uuid.UUID, input models.CreateTripInput) (*models.Trip, error)
-	getTripByIDFunc      func(ctx context.Context, tripID uuid.UUID) (*models.Trip, error)
-	updateTripFunc       func(ctx context.Context, tripID uuid.UUID, input models.UpdateTripInput) (*models.Trip, error)
-	deleteTripFunc       func(ctx context.Context, tripID uuid.UUID) error
-	getTripsByUserIDFunc func(ctx context.Context, userID uuid.UUID, limit int, offset int) ([]*models.Trip, error)
-	getTripWithUserFunc  func(ctx context.Context, tripID uuid.UUID) (*models.Trip, error)
+	createTripFunc         func(ctx context.Context, userID uuid.UUID, input models.CreateTripInput) (*models.Trip, error)
+	getTripByIDFunc        func(ctx context.Context, tripID uuid.UUID) (*models.Trip, error)
+	updateTripFunc         func(ctx context.Context, tripID uuid.UUID, input models.UpdateTripInput) (*models.Trip, error)
+	deleteTripFunc         func(ctx context.Context, tripID uuid.UUID) error
+	getTripsByUserIDFunc   func(ctx context.Context, userID uuid.UUID, limit int, offset int, filter models.TripFilter) ([]*models.Trip, error)
+	getTripWithUserFunc    func(ctx context.Context, tripID uuid.UUID) (*models.Trip, error)
+	getDeletedTripFunc     func(ctx context.Context, tripID uuid.UUID) (*models.Trip, error)
+	restoreTripFunc        func(ctx context.Context, tripID uuid.UUID) (*models.Trip, error)
+	importTripFunc         func(ctx context.Context, userID uuid.UUID, export models.TripExport) (*models.TripImportResult, error)
+	addTripTagFunc         func(ctx context.Context, tripID uuid.UUID, userID uuid.UUID, name string) (*models.Tag, error)
+	removeTripTagFunc      func(ctx context.Context, tripID uuid.UUID, userID uuid.UUID, name string) error
+	getTripTagsFunc        func(ctx context.Context, tripID uuid.UUID) ([]*models.Tag, error)
+	getActivitiesFunc      func(ctx context.Context, tripID uuid.UUID) ([]*models.Activity, error)
+	getTripExpensesFunc    func(ctx context.Context, tripID uuid.UUID) ([]*models.Expense, error)
+	getTripPhotosFunc      func(ctx context.Context, tripID uuid.UUID) ([]*models.TripPhoto, error)
+	countTripPhotosFunc    func(ctx context.Context, tripID uuid.UUID) (int, error)
+	reorderTripsFunc       func(ctx context.Context, userID uuid.UUID, tripIDs []uuid.UUID) error
+	createTripsFunc        func(ctx context.Context, userID uuid.UUID, inputs []models.CreateTripInput) ([]*models.Trip, error)
+	planTripFunc           func(ctx context.Context, tripID uuid.UUID, startDate, endDate time.Time) (*models.Trip, error)
+	bulkTagTripsFunc       func(ctx context.Context, userID uuid.UUID, tripIDs []uuid.UUID, names []string, replace bool) ([]uuid.UUID, error)
+	getTripListVersionFunc func(ctx context.Context, userID uuid.UUID) (*models.TripListVersion, error)
+	getTripStartDatesFunc  func(ctx context.Context, userID uuid.UUID) ([]time.Time, error)
+	getUpcomingTripsFunc   func(ctx context.Context, userID uuid.UUID, within int) ([]*models.Trip, error)
+	getByLocationFunc      func(ctx context.Context, userID uuid.UUID, location string) ([]*models.Trip, error)
+	shareTripFunc          func(ctx context.Context, tripID uuid.UUID) (*models.TripShare, error)
+	unshareTripFunc        func(ctx context.Context, tripID uuid.UUID) error
+	getTripByShareFunc     func(ctx context.Context, token string) (*models.Trip, error)
 }
 
 func (m *MockRepository) CreateTrip(ctx context.Context, userID uuid.UUID, input models.CreateTripInput) (*models.Trip, error) {
@@ -50,9 +74,9 @@ func (m *MockRepository) DeleteTrip(ctx context.Context, tripID uuid.UUID) error
 	return errors.New("DeleteTrip not implemented")
 }
 
-func (m *MockRepository) GetTripsByUserID(ctx context.Context, userID uuid.UUID, limit int, offset int) ([]*models.Trip, error) {
+func (m *MockRepository) GetTripsByUserID(ctx context.Context, userID uuid.UUID, limit int, offset int, filter models.TripFilter) ([]*models.Trip, error) {
 	if m.getTripsByUserIDFunc != nil {
-		return m.getTripsByUserIDFunc(ctx, userID, limit, offset)
+		return m.getTripsByUserIDFunc(ctx, userID, limit, offset, filter)
 	}
 	return nil, errors.New("GetTripsByUserID not implemented")
 }
@@ -64,6 +88,153 @@ func (m *MockRepository) GetTripWithUser(ctx context.Context, tripID uuid.UUID)
 	return nil, errors.New("GetTripWithUser not implemented")
 }
 
+func (m *MockRepository) GetDeletedTripByID(ctx context.Context, tripID uuid.UUID) (*models.Trip, error) {
+	if m.getDeletedTripFunc != nil {
+		return m.getDeletedTripFunc(ctx, tripID)
+	}
+	return nil, errors.New("GetDeletedTripByID not implemented")
+}
+
+func (m *MockRepository) RestoreTrip(ctx context.Context, tripID uuid.UUID) (*models.Trip, error) {
+	if m.restoreTripFunc != nil {
+		return m.restoreTripFunc(ctx, tripID)
+	}
+	return nil, errors.New("RestoreTrip not implemented")
+}
+
+func (m *MockRepository) PlanTrip(ctx context.Context, tripID uuid.UUID, startDate, endDate time.Time) (*models.Trip, error) {
+	if m.planTripFunc != nil {
+		return m.planTripFunc(ctx, tripID, startDate, endDate)
+	}
+	return nil, errors.New("PlanTrip not implemented")
+}
+
+func (m *MockRepository) GetTripStartDates(ctx context.Context, userID uuid.UUID) ([]time.Time, error) {
+	if m.getTripStartDatesFunc != nil {
+		return m.getTripStartDatesFunc(ctx, userID)
+	}
+	return nil, errors.New("GetTripStartDates not implemented")
+}
+
+func (m *MockRepository) GetUpcomingTrips(ctx context.Context, userID uuid.UUID, within int) ([]*models.Trip, error) {
+	if m.getUpcomingTripsFunc != nil {
+		return m.getUpcomingTripsFunc(ctx, userID, within)
+	}
+	return nil, errors.New("GetUpcomingTrips not implemented")
+}
+
+func (m *MockRepository) GetTripsByLocation(ctx context.Context, userID uuid.UUID, location string) ([]*models.Trip, error) {
+	if m.getByLocationFunc != nil {
+		return m.getByLocationFunc(ctx, userID, location)
+	}
+	return nil, errors.New("GetTripsByLocation not implemented")
+}
+
+func (m *MockRepository) GetTripListVersion(ctx context.Context, userID uuid.UUID) (*models.TripListVersion, error) {
+	if m.getTripListVersionFunc != nil {
+		return m.getTripListVersionFunc(ctx, userID)
+	}
+	return nil, errors.New("GetTripListVersion not implemented")
+}
+
+func (m *MockRepository) BulkTagTrips(ctx context.Context, userID uuid.UUID, tripIDs []uuid.UUID, names []string, replace bool) ([]uuid.UUID, error) {
+	if m.bulkTagTripsFunc != nil {
+		return m.bulkTagTripsFunc(ctx, userID, tripIDs, names, replace)
+	}
+	return nil, errors.New("BulkTagTrips not implemented")
+}
+
+func (m *MockRepository) ShareTrip(ctx context.Context, tripID uuid.UUID) (*models.TripShare, error) {
+	if m.shareTripFunc != nil {
+		return m.shareTripFunc(ctx, tripID)
+	}
+	return nil, errors.New("ShareTrip not implemented")
+}
+
+func (m *MockRepository) UnshareTrip(ctx context.Context, tripID uuid.UUID) error {
+	if m.unshareTripFunc != nil {
+		return m.unshareTripFunc(ctx, tripID)
+	}
+	return errors.New("UnshareTrip not implemented")
+}
+
+func (m *MockRepository) GetTripByShareToken(ctx context.Context, token string) (*models.Trip, error) {
+	if m.getTripByShareFunc != nil {
+		return m.getTripByShareFunc(ctx, token)
+	}
+	return nil, errors.New("GetTripByShareToken not implemented")
+}
+
+func (m *MockRepository) ImportTrip(ctx context.Context, userID uuid.UUID, export models.TripExport) (*models.TripImportResult, error) {
+	if m.importTripFunc != nil {
+		return m.importTripFunc(ctx, userID, export)
+	}
+	return nil, errors.New("ImportTrip not implemented")
+}
+
+func (m *MockRepository) AddTripTag(ctx context.Context, tripID uuid.UUID, userID uuid.UUID, name string) (*models.Tag, error) {
+	if m.addTripTagFunc != nil {
+		return m.addTripTagFunc(ctx, tripID, userID, name)
+	}
+	return nil, errors.New("AddTripTag not implemented")
+}
+
+func (m *MockRepository) RemoveTripTag(ctx context.Context, tripID uuid.UUID, userID uuid.UUID, name string) error {
+	if m.removeTripTagFunc != nil {
+		return m.removeTripTagFunc(ctx, tripID, userID, name)
+	}
+	return errors.New("RemoveTripTag not implemented")
+}
+
+func (m *MockRepository) GetTripTags(ctx context.Context, tripID uuid.UUID) ([]*models.Tag, error) {
+	if m.getTripTagsFunc != nil {
+		return m.getTripTagsFunc(ctx, tripID)
+	}
+	return nil, errors.New("GetTripTags not implemented")
+}
+
+func (m *MockRepository) GetTripPhotos(ctx context.Context, tripID uuid.UUID) ([]*models.TripPhoto, error) {
+	if m.getTripPhotosFunc != nil {
+		return m.getTripPhotosFunc(ctx, tripID)
+	}
+	return nil, errors.New("GetTripPhotos not implemented")
+}
+
+func (m *MockRepository) CountTripPhotos(ctx context.Context, tripID uuid.UUID) (int, error) {
+	if m.countTripPhotosFunc != nil {
+		return m.countTripPhotosFunc(ctx, tripID)
+	}
+	return 0, errors.New("CountTripPhotos not implemented")
+}
+
+func (m *MockRepository) GetTripActivities(ctx context.Context, tripID uuid.UUID) ([]*models.Activity, error) {
+	if m.getActivitiesFunc != nil {
+		return m.getActivitiesFunc(ctx, tripID)
+	}
+	return nil, errors.New("GetTripActivities not implemented")
+}
+
+func (m *MockRepository) GetTripExpenses(ctx context.Context, tripID uuid.UUID) ([]*models.Expense, error) {
+	if m.getTripExpensesFunc != nil {
+		return m.getTripExpensesFunc(ctx, tripID)
+	}
+	return nil, errors.New("GetTripExpenses not implemented")
+}
+
+func (m *MockRepository) ReorderTrips(ctx context.Context, userID uuid.UUID, tripIDs []uuid.UUID) error {
+	if m.reorderTripsFunc != nil {
+		return m.reorderTripsFunc(ctx, userID, tripIDs)
+	}
+	return errors.New("ReorderTrips not implemented")
+}
+
+func (m *MockRepository) CreateTrips(ctx context.Context, userID uuid.UUID, inputs []models.CreateTripInput) ([]*models.Trip, error) {
+	if m.createTripsFunc != nil {
+		return m.createTripsFunc(ctx, userID, inputs)
+	}
+	return nil, errors.New("CreateTrips not implemented")
+}
+
 // MockViewService implements the view.ServiceInterface for testing
 type MockViewService struct {
 	getUserProfileFunc func(ctx context.Context, userID uuid.UUID) (*models.User, error)
@@ -76,6 +247,10 @@ func (m *MockViewService) GetUserProfile(ctx context.Context, userID uuid.UUID)
 	return nil, errors.New("GetUserProfile not implemented")
 }
 
+func (m *MockViewService) GetUserStats(ctx context.Context, userID uuid.UUID) (*models.UserStats, error) {
+	return nil, errors.New("GetUserStats not implemented")
+}
+
 // Helper functions for creating pointers
 func stringPtr(s string) *string {
 	return &s
@@ -85,11 +260,22 @@ func timePtr(t time.Time) *time.Time {
 	return &t
 }
 
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+// stubCoverSource implements trips.CoverSource for testing
+type stubCoverSource struct{}
+
+func (stubCoverSource) CoverURL(location string) string {
+	return "https://covers.test/" + location
+}
+
 // Helper function to setup service for testing
 func setupServiceTest() (trips.ServiceInterface, *MockRepository, *MockViewService) {
 	mockRepo := &MockRepository{}
 	mockViewService := &MockViewService{}
-	service := trips.NewService(mockRepo, mockViewService)
+	service := trips.NewService(mockRepo, mockViewService, stubCoverSource{})
 	return service, mockRepo, mockViewService
 }
 
@@ -117,8 +303,8 @@ func TestServiceCreateTrip(t *testing.T) {
 						UserID:      uid,
 						Name:        inp.Name,
 						Description: inp.Description,
-						StartDate:   inp.StartDate,
-						EndDate:     inp.EndDate,
+						StartDate:   timePtr(inp.StartDate),
+						EndDate:     timePtr(inp.EndDate),
 						Location:    inp.Location,
 						CreatedAt:   time.Now(),
 						UpdatedAt:   time.Now(),
@@ -142,6 +328,57 @@ func TestServiceCreateTrip(t *testing.T) {
 			expectedError: true,
 			errorMessage:  "end date cannot be before start date",
 		},
+		{
+			name: "WishlistWithoutDates",
+			input: models.CreateTripInput{
+				Name:       "Someday",
+				Location:   "Kyoto",
+				IsWishlist: true,
+			},
+			setupMocks: func(t *testing.T, mockRepo *MockRepository, mockViewService *MockViewService) {
+				mockRepo.createTripFunc = func(ctx context.Context, uid uuid.UUID, inp models.CreateTripInput) (*models.Trip, error) {
+					if !inp.IsWishlist {
+						t.Error("Expected the trip to be created as a wishlist trip")
+					}
+					return &models.Trip{
+						ID:         uuid.New(),
+						UserID:     uid,
+						Name:       inp.Name,
+						Location:   inp.Location,
+						IsWishlist: true,
+					}, nil
+				}
+			},
+			expectedError: false,
+		},
+		{
+			name: "WishlistExemptFromDateOrdering",
+			input: models.CreateTripInput{
+				Name:       "Someday",
+				StartDate:  time.Now().Add(7 * 24 * time.Hour),
+				EndDate:    time.Now().Add(24 * time.Hour),
+				Location:   "Kyoto",
+				IsWishlist: true,
+			},
+			setupMocks: func(t *testing.T, mockRepo *MockRepository, mockViewService *MockViewService) {
+				mockRepo.createTripFunc = func(ctx context.Context, uid uuid.UUID, inp models.CreateTripInput) (*models.Trip, error) {
+					return &models.Trip{ID: uuid.New(), UserID: uid, Name: inp.Name, IsWishlist: true}, nil
+				}
+			},
+			expectedError: false,
+		},
+		{
+			name: "PlannedTripWithoutDates",
+			input: models.CreateTripInput{
+				Name:     "Planned Trip",
+				Location: "Kyoto",
+			},
+			setupMocks: func(t *testing.T, mockRepo *MockRepository, mockViewService *MockViewService) {
+				// Repository should not be called
+			},
+			expectedError: true,
+			errorMessage:  "start and end dates are required",
+		},
 		{
 			name: "EmptyNameAutoGeneration",
 			input: models.CreateTripInput{
@@ -161,8 +398,8 @@ func TestServiceCreateTrip(t *testing.T) {
 						UserID:      uid,
 						Name:        inp.Name,
 						Description: inp.Description,
-						StartDate:   inp.StartDate,
-						EndDate:     inp.EndDate,
+						StartDate:   timePtr(inp.StartDate),
+						EndDate:     timePtr(inp.EndDate),
 						Location:    inp.Location,
 						CreatedAt:   time.Now(),
 						UpdatedAt:   time.Now(),
@@ -232,6 +469,112 @@ func TestServiceCreateTrip(t *testing.T) {
 	}
 }
 
+func TestServiceCreateTripDayPrecision(t *testing.T) {
+	start := time.Date(2025, time.June, 10, 10, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name          string
+		start         time.Time
+		end           time.Time
+		expectedError bool
+	}{
+		{name: "EndNextDay", start: start, end: start.AddDate(0, 0, 1)},
+		{name: "SameDay", start: start, end: start},
+		{name: "EarlierTimeOnStartDay", start: start, end: start.Add(-time.Hour)},
+		{name: "EndDayBeforeStart", start: start, end: start.AddDate(0, 0, -1), expectedError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			service, mockRepo, _ := setupServiceTest()
+			mockRepo.createTripFunc = func(ctx context.Context, uid uuid.UUID, inp models.CreateTripInput) (*models.Trip, error) {
+				return &models.Trip{ID: uuid.New(), UserID: uid, StartDate: timePtr(inp.StartDate), EndDate: timePtr(inp.EndDate)}, nil
+			}
+
+			// Execute
+			_, err := service.CreateTrip(context.Background(), uuid.New(), models.CreateTripInput{
+				Location:  "Lisbon",
+				StartDate: tc.start,
+				EndDate:   tc.end,
+			})
+
+			// Verify
+			if tc.expectedError {
+				if err == nil || err.Error() != "end date cannot be before start date" {
+					t.Errorf("Expected end before start error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("Expected no error, got: %v", err)
+			}
+		})
+	}
+}
+
+func TestServiceTripValidationError(t *testing.T) {
+	start := time.Date(2030, time.June, 10, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name          string
+		execute       func(trips.ServiceInterface, uuid.UUID) error
+		expectedField string
+	}{
+		{
+			name: "CreateMissingDates",
+			execute: func(service trips.ServiceInterface, userID uuid.UUID) error {
+				_, err := service.CreateTrip(context.Background(), userID, models.CreateTripInput{Location: "Lisbon"})
+				return err
+			},
+			expectedField: "start_date",
+		},
+		{
+			name: "CreateEndBeforeStart",
+			execute: func(service trips.ServiceInterface, userID uuid.UUID) error {
+				_, err := service.CreateTrip(context.Background(), userID, models.CreateTripInput{
+					Location: "Lisbon", StartDate: start, EndDate: start.AddDate(0, 0, -1),
+				})
+				return err
+			},
+			expectedField: "end_date",
+		},
+		{
+			name: "UpdateEndBeforeStoredStart",
+			execute: func(service trips.ServiceInterface, userID uuid.UUID) error {
+				_, err := service.UpdateTrip(context.Background(), uuid.New(), userID, models.UpdateTripInput{
+					EndDate: timePtr(start.AddDate(0, 0, -1)),
+				})
+				return err
+			},
+			expectedField: "end_date",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			service, mockRepo, _ := setupServiceTest()
+			userID := uuid.New()
+			mockRepo.getTripByIDFunc = func(ctx context.Context, tripID uuid.UUID) (*models.Trip, error) {
+				return &models.Trip{ID: tripID, UserID: userID, StartDate: timePtr(start), EndDate: timePtr(start.AddDate(0, 0, 3))}, nil
+			}
+
+			// Execute
+			err := tc.execute(service, userID)
+
+			// Verify
+			var validationErr *trips.ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Expected a ValidationError, got %v", err)
+			}
+			if validationErr.Field != tc.expectedField {
+				t.Errorf("Expected field %q, got %q", tc.expectedField, validationErr.Field)
+			}
+		})
+	}
+}
+
 func TestGetTripByID(t *testing.T) {
 	testCases := []struct {
 		name          string
@@ -427,7 +770,7 @@ func TestGetTripsByUserID(t *testing.T) {
 					}, nil
 				}
 
-				mockRepo.getTripsByUserIDFunc = func(ctx context.Context, id uuid.UUID, limit, offset int) ([]*models.Trip, error) {
+				mockRepo.getTripsByUserIDFunc = func(ctx context.Context, id uuid.UUID, limit, offset int, filter models.TripFilter) ([]*models.Trip, error) {
 					return []*models.Trip{
 						{
 							ID:     uuid.New(),
@@ -477,7 +820,7 @@ func TestGetTripsByUserID(t *testing.T) {
 					}, nil
 				}
 
-				mockRepo.getTripsByUserIDFunc = func(ctx context.Context, id uuid.UUID, limit, offset int) ([]*models.Trip, error) {
+				mockRepo.getTripsByUserIDFunc = func(ctx context.Context, id uuid.UUID, limit, offset int, filter models.TripFilter) ([]*models.Trip, error) {
 					return nil, errors.New("database error")
 				}
 			},
@@ -497,7 +840,7 @@ func TestGetTripsByUserID(t *testing.T) {
 			tc.setupMocks(t, mockRepo, mockViewService, userID)
 
 			// Execute
-			result, err := service.GetTripsByUserID(context.Background(), userID, 10, 0)
+			result, err := service.GetTripsByUserID(context.Background(), userID, 10, 0, models.TripFilter{})
 
 			// Verify
 			if tc.expectedError {
@@ -545,8 +888,8 @@ func TestServiceUpdateTrip(t *testing.T) {
 						ID:        tripID,
 						UserID:    userID,
 						Name:      "Original Trip",
-						StartDate: now,
-						EndDate:   now.Add(72 * time.Hour),
+						StartDate: &now,
+						EndDate:   timePtr(now.Add(72 * time.Hour)),
 					}, nil
 				}
 				mockRepo.updateTripFunc = func(ctx context.Context, id uuid.UUID, input models.UpdateTripInput) (*models.Trip, error) {
@@ -555,8 +898,8 @@ func TestServiceUpdateTrip(t *testing.T) {
 						UserID:      userID,
 						Name:        *input.Name,
 						Description: *input.Description,
-						StartDate:   *input.StartDate,
-						EndDate:     *input.EndDate,
+						StartDate:   timePtr(*input.StartDate),
+						EndDate:     timePtr(*input.EndDate),
 						Location:    *input.Location,
 					}, nil
 				}
@@ -603,8 +946,8 @@ func TestServiceUpdateTrip(t *testing.T) {
 					return &models.Trip{
 						ID:        tripID,
 						UserID:    userID,
-						StartDate: now,
-						EndDate:   now.Add(24 * time.Hour),
+						StartDate: &now,
+						EndDate:   timePtr(now.Add(24 * time.Hour)),
 					}, nil
 				}
 			},
@@ -621,8 +964,8 @@ func TestServiceUpdateTrip(t *testing.T) {
 					return &models.Trip{
 						ID:        tripID,
 						UserID:    userID,
-						StartDate: now,
-						EndDate:   now.Add(24 * time.Hour),
+						StartDate: &now,
+						EndDate:   timePtr(now.Add(24 * time.Hour)),
 					}, nil
 				}
 			},
@@ -639,8 +982,8 @@ func TestServiceUpdateTrip(t *testing.T) {
 					return &models.Trip{
 						ID:        tripID,
 						UserID:    userID,
-						StartDate: now,
-						EndDate:   now.Add(72 * time.Hour),
+						StartDate: &now,
+						EndDate:   timePtr(now.Add(72 * time.Hour)),
 					}, nil
 				}
 			},
@@ -763,92 +1106,78 @@ func TestServiceDeleteTrip(t *testing.T) {
 	}
 }
 
-func TestServiceGetUserWithTrips(t *testing.T) {
+func TestServiceRestoreTrip(t *testing.T) {
 	testCases := []struct {
 		name          string
-		setupMocks    func(*testing.T, *MockRepository, *MockViewService, uuid.UUID)
+		setupMocks    func(*testing.T, *MockRepository, uuid.UUID, uuid.UUID)
 		expectedError bool
 		errorMessage  string
-		tripCount     int
 	}{
 		{
-			name: "SuccessfulRetrieval",
-			setupMocks: func(t *testing.T, mockRepo *MockRepository, mockViewService *MockViewService, userID uuid.UUID) {
-				mockViewService.getUserProfileFunc = func(ctx context.Context, id uuid.UUID) (*models.User, error) {
-					return &models.User{
-						ID:   userID,
-						Name: "Test User",
-					}, nil
-				}
-				mockRepo.getTripsByUserIDFunc = func(ctx context.Context, id uuid.UUID, limit, offset int) ([]*models.Trip, error) {
-					return []*models.Trip{
-						{
-							ID:     uuid.New(),
-							UserID: userID,
-							Name:   "Trip 1",
-						},
-						{
-							ID:     uuid.New(),
-							UserID: userID,
-							Name:   "Trip 2",
-						},
-					}, nil
+			name: "SuccessfulRestore",
+			setupMocks: func(t *testing.T, mockRepo *MockRepository, tripID, userID uuid.UUID) {
+				deletedAt := time.Now().Add(-24 * time.Hour)
+				mockRepo.getDeletedTripFunc = func(ctx context.Context, id uuid.UUID) (*models.Trip, error) {
+					return &models.Trip{ID: tripID, UserID: userID, DeletedAt: &deletedAt}, nil
+				}
+				mockRepo.restoreTripFunc = func(ctx context.Context, id uuid.UUID) (*models.Trip, error) {
+					if id != tripID {
+						t.Errorf("Expected trip ID %s, got %s", tripID, id)
+					}
+					return &models.Trip{ID: tripID, UserID: userID}, nil
 				}
 			},
 			expectedError: false,
-			tripCount:     2,
 		},
 		{
-			name: "UserNotFound",
-			setupMocks: func(t *testing.T, mockRepo *MockRepository, mockViewService *MockViewService, userID uuid.UUID) {
-				mockViewService.getUserProfileFunc = func(ctx context.Context, id uuid.UUID) (*models.User, error) {
-					return nil, errors.New("user not found")
+			name: "TripNotFound",
+			setupMocks: func(t *testing.T, mockRepo *MockRepository, tripID, userID uuid.UUID) {
+				mockRepo.getDeletedTripFunc = func(ctx context.Context, id uuid.UUID) (*models.Trip, error) {
+					return nil, errors.New("trip not found")
 				}
 			},
 			expectedError: true,
-			errorMessage:  "user not found",
-			tripCount:     0,
+			errorMessage:  "trip not found",
 		},
 		{
-			name: "ErrorGettingTrips",
-			setupMocks: func(t *testing.T, mockRepo *MockRepository, mockViewService *MockViewService, userID uuid.UUID) {
-				mockViewService.getUserProfileFunc = func(ctx context.Context, id uuid.UUID) (*models.User, error) {
-					return &models.User{
-						ID:   userID,
-						Name: "Test User",
-					}, nil
-				}
-				mockRepo.getTripsByUserIDFunc = func(ctx context.Context, id uuid.UUID, limit, offset int) ([]*models.Trip, error) {
-					return nil, errors.New("database error")
+			name: "UnauthorizedAccess",
+			setupMocks: func(t *testing.T, mockRepo *MockRepository, tripID, userID uuid.UUID) {
+				deletedAt := time.Now().Add(-time.Hour)
+				mockRepo.getDeletedTripFunc = func(ctx context.Context, id uuid.UUID) (*models.Trip, error) {
+					return &models.Trip{ID: tripID, UserID: uuid.New(), DeletedAt: &deletedAt}, nil
 				}
 			},
 			expectedError: true,
-			errorMessage:  "database error",
-			tripCount:     0,
+			errorMessage:  "unauthorized access to trip",
 		},
 		{
-			name: "NilUserReturned",
-			setupMocks: func(t *testing.T, mockRepo *MockRepository, mockViewService *MockViewService, userID uuid.UUID) {
-				mockViewService.getUserProfileFunc = func(ctx context.Context, id uuid.UUID) (*models.User, error) {
+			name: "RestoreWindowExpired",
+			setupMocks: func(t *testing.T, mockRepo *MockRepository, tripID, userID uuid.UUID) {
+				deletedAt := time.Now().Add(-trips.TripRestoreWindow - time.Hour)
+				mockRepo.getDeletedTripFunc = func(ctx context.Context, id uuid.UUID) (*models.Trip, error) {
+					return &models.Trip{ID: tripID, UserID: userID, DeletedAt: &deletedAt}, nil
+				}
+				mockRepo.restoreTripFunc = func(ctx context.Context, id uuid.UUID) (*models.Trip, error) {
+					t.Error("RestoreTrip should not be called when the window has expired")
 					return nil, nil
 				}
 			},
-			expectedError: false,
-			tripCount:     0,
+			expectedError: true,
+			errorMessage:  "restore window has expired",
 		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			// Setup
-			service, mockRepo, mockViewService := setupServiceTest()
+			service, mockRepo, _ := setupServiceTest()
+			tripID := uuid.New()
 			userID := uuid.New()
 
-			// Setup mocks
-			tc.setupMocks(t, mockRepo, mockViewService, userID)
+			tc.setupMocks(t, mockRepo, tripID, userID)
 
 			// Execute
-			result, err := service.GetUserWithTrips(context.Background(), userID, 10, 0)
+			trip, err := service.RestoreTrip(context.Background(), tripID, userID)
 
 			// Verify
 			if tc.expectedError {
@@ -857,30 +1186,2021 @@ func TestServiceGetUserWithTrips(t *testing.T) {
 				} else if tc.errorMessage != "" && err.Error() != tc.errorMessage {
 					t.Errorf("Expected error message '%s', got '%s'", tc.errorMessage, err.Error())
 				}
-				if result != nil {
-					t.Errorf("Expected nil result, got %v", result)
-				}
 			} else {
 				if err != nil {
 					t.Errorf("Expected no error, got: %v", err)
 				}
+				if trip == nil || trip.ID != tripID {
+					t.Errorf("Expected restored trip %s, got %+v", tripID, trip)
+				}
+			}
+		})
+	}
+}
 
-				// Special case for nil user
-				if tc.name == "NilUserReturned" {
-					if result != nil {
-						t.Error("Expected nil user, got non-nil")
+func TestServicePlanTrip(t *testing.T) {
+	startDate := time.Now().Add(30 * 24 * time.Hour)
+	endDate := startDate.Add(7 * 24 * time.Hour)
+
+	testCases := []struct {
+		name          string
+		input         models.PlanTripInput
+		setupMocks    func(*testing.T, *MockRepository, uuid.UUID, uuid.UUID)
+		expectedError bool
+		errorMessage  string
+	}{
+		{
+			name:  "SuccessfulPlan",
+			input: models.PlanTripInput{StartDate: startDate, EndDate: endDate},
+			setupMocks: func(t *testing.T, mockRepo *MockRepository, tripID, userID uuid.UUID) {
+				mockRepo.getTripByIDFunc = func(ctx context.Context, id uuid.UUID) (*models.Trip, error) {
+					return &models.Trip{ID: tripID, UserID: userID, IsWishlist: true}, nil
+				}
+				mockRepo.planTripFunc = func(ctx context.Context, id uuid.UUID, start, end time.Time) (*models.Trip, error) {
+					if !start.Equal(startDate) || !end.Equal(endDate) {
+						t.Errorf("Expected dates %v - %v, got %v - %v", startDate, endDate, start, end)
 					}
-					return
+					return &models.Trip{ID: id, UserID: userID, StartDate: &start, EndDate: &end}, nil
 				}
-
-				if result == nil {
-					t.Fatal("Expected user to be returned, got nil")
+			},
+			expectedError: false,
+		},
+		{
+			name:  "ReversedDates",
+			input: models.PlanTripInput{StartDate: endDate, EndDate: startDate},
+			setupMocks: func(t *testing.T, mockRepo *MockRepository, tripID, userID uuid.UUID) {
+				mockRepo.getTripByIDFunc = func(ctx context.Context, id uuid.UUID) (*models.Trip, error) {
+					return &models.Trip{ID: tripID, UserID: userID, IsWishlist: true}, nil
 				}
-
-				if len(result.Trips) != tc.tripCount {
-					t.Errorf("Expected %d trips, got %d", tc.tripCount, len(result.Trips))
+				mockRepo.planTripFunc = func(ctx context.Context, id uuid.UUID, start, end time.Time) (*models.Trip, error) {
+					t.Error("PlanTrip should not be called with reversed dates")
+					return nil, nil
 				}
-			}
-		})
-	}
+			},
+			expectedError: true,
+			errorMessage:  "end date cannot be before start date",
+		},
+		{
+			name:  "AlreadyPlanned",
+			input: models.PlanTripInput{StartDate: startDate, EndDate: endDate},
+			setupMocks: func(t *testing.T, mockRepo *MockRepository, tripID, userID uuid.UUID) {
+				mockRepo.getTripByIDFunc = func(ctx context.Context, id uuid.UUID) (*models.Trip, error) {
+					return &models.Trip{ID: tripID, UserID: userID, StartDate: &startDate, EndDate: &endDate}, nil
+				}
+			},
+			expectedError: true,
+			errorMessage:  "trip is already planned",
+		},
+		{
+			name:  "UnauthorizedAccess",
+			input: models.PlanTripInput{StartDate: startDate, EndDate: endDate},
+			setupMocks: func(t *testing.T, mockRepo *MockRepository, tripID, userID uuid.UUID) {
+				mockRepo.getTripByIDFunc = func(ctx context.Context, id uuid.UUID) (*models.Trip, error) {
+					return &models.Trip{ID: tripID, UserID: uuid.New(), IsWishlist: true}, nil
+				}
+			},
+			expectedError: true,
+			errorMessage:  "unauthorized access to trip",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			service, mockRepo, _ := setupServiceTest()
+			tripID := uuid.New()
+			userID := uuid.New()
+
+			tc.setupMocks(t, mockRepo, tripID, userID)
+
+			// Execute
+			trip, err := service.PlanTrip(context.Background(), tripID, userID, tc.input)
+
+			// Verify
+			if tc.expectedError {
+				if err == nil {
+					t.Error("Expected error, got nil")
+				} else if tc.errorMessage != "" && err.Error() != tc.errorMessage {
+					t.Errorf("Expected error message '%s', got '%s'", tc.errorMessage, err.Error())
+				}
+			} else {
+				if err != nil {
+					t.Errorf("Expected no error, got: %v", err)
+				}
+				if trip == nil || trip.IsWishlist {
+					t.Errorf("Expected a planned trip, got %+v", trip)
+				}
+			}
+		})
+	}
+}
+
+func TestServiceExportTrip(t *testing.T) {
+	service, mockRepo, _ := setupServiceTest()
+	tripID := uuid.New()
+	userID := uuid.New()
+	startDate := time.Now().Add(24 * time.Hour)
+	endDate := startDate.Add(5 * 24 * time.Hour)
+
+	mockRepo.getTripByIDFunc = func(ctx context.Context, id uuid.UUID) (*models.Trip, error) {
+		return &models.Trip{
+			ID:          tripID,
+			UserID:      userID,
+			Name:        "Lisbon Getaway",
+			Description: "Pasteis de nata",
+			StartDate:   &startDate,
+			EndDate:     &endDate,
+			Location:    "Lisbon",
+		}, nil
+	}
+	mockRepo.getActivitiesFunc = func(ctx context.Context, id uuid.UUID) ([]*models.Activity, error) {
+		return []*models.Activity{
+			{ID: uuid.New(), TripID: id, Title: "Tram 28", StartTime: startDate, EndTime: startDate.Add(time.Hour)},
+			{ID: uuid.New(), TripID: id, Title: "Belem Tower", StartTime: startDate.Add(24 * time.Hour), EndTime: startDate.Add(26 * time.Hour)},
+		}, nil
+	}
+	mockRepo.getTripExpensesFunc = func(ctx context.Context, id uuid.UUID) ([]*models.Expense, error) {
+		return []*models.Expense{
+			{ID: uuid.New(), TripID: id, AmountCents: 450, Currency: "EUR", Category: "food", PaidBy: "Ana", SplitAmong: []string{"Ana", "Rui"}, IncurredAt: startDate},
+		}, nil
+	}
+	mockRepo.getTripTagsFunc = func(ctx context.Context, id uuid.UUID) ([]*models.Tag, error) {
+		return []*models.Tag{{ID: uuid.New(), Name: "europe"}, {ID: uuid.New(), Name: "food"}}, nil
+	}
+
+	t.Run("ExportsTripFields", func(t *testing.T) {
+		export, err := service.ExportTrip(context.Background(), tripID, userID)
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+
+		if export.Version != models.TripExportVersion {
+			t.Errorf("Expected version %d, got %d", models.TripExportVersion, export.Version)
+		}
+		if export.Trip.Name != "Lisbon Getaway" || export.Trip.Location != "Lisbon" {
+			t.Errorf("Unexpected exported trip: %+v", export.Trip)
+		}
+		if !export.Trip.StartDate.Equal(startDate) || !export.Trip.EndDate.Equal(endDate) {
+			t.Errorf("Expected exported dates to match the trip")
+		}
+	})
+
+	t.Run("ExportsActivities", func(t *testing.T) {
+		export, err := service.ExportTrip(context.Background(), tripID, userID)
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+
+		if len(export.Activities) != 2 {
+			t.Fatalf("Expected 2 exported activities, got %d", len(export.Activities))
+		}
+		if export.Activities[0].Title != "Tram 28" || export.Activities[1].Title != "Belem Tower" {
+			t.Errorf("Unexpected exported activities: %+v", export.Activities)
+		}
+	})
+
+	t.Run("ExportsExpensesAndTags", func(t *testing.T) {
+		export, err := service.ExportTrip(context.Background(), tripID, userID)
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+
+		if len(export.Expenses) != 1 {
+			t.Fatalf("Expected 1 exported expense, got %d", len(export.Expenses))
+		}
+		expense := export.Expenses[0]
+		if expense.AmountCents != 450 || expense.Currency != "EUR" || expense.PaidBy != "Ana" || len(expense.SplitAmong) != 2 {
+			t.Errorf("Unexpected exported expense: %+v", expense)
+		}
+		if !slices.Equal(export.Tags, []string{"europe", "food"}) {
+			t.Errorf("Expected tags [europe food], got %v", export.Tags)
+		}
+	})
+
+	t.Run("UnauthorizedAccess", func(t *testing.T) {
+		_, err := service.ExportTrip(context.Background(), tripID, uuid.New())
+		if err == nil || err.Error() != "unauthorized access to trip" {
+			t.Errorf("Expected unauthorized error, got: %v", err)
+		}
+	})
+}
+
+func TestServiceAttachTripPhotos(t *testing.T) {
+	service, mockRepo, _ := setupServiceTest()
+	tripID := uuid.New()
+
+	mockRepo.getTripPhotosFunc = func(ctx context.Context, id uuid.UUID) ([]*models.TripPhoto, error) {
+		return []*models.TripPhoto{
+			{ID: uuid.New(), TripID: id, URL: "https://cdn.example.com/tram.jpg"},
+			{ID: uuid.New(), TripID: id, URL: "https://cdn.example.com/tower.jpg"},
+		}, nil
+	}
+	mockRepo.countTripPhotosFunc = func(ctx context.Context, id uuid.UUID) (int, error) {
+		return 2, nil
+	}
+
+	t.Run("CountOnly", func(t *testing.T) {
+		trip := &models.Trip{ID: tripID}
+		if err := service.AttachTripPhotos(context.Background(), trip, models.TripPhotosCount); err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if trip.PhotoCount == nil || *trip.PhotoCount != 2 {
+			t.Errorf("Expected photo count 2, got %v", trip.PhotoCount)
+		}
+		if trip.Photos != nil {
+			t.Errorf("Expected no photos to be loaded, got %d", len(trip.Photos))
+		}
+	})
+
+	t.Run("List", func(t *testing.T) {
+		trip := &models.Trip{ID: tripID}
+		if err := service.AttachTripPhotos(context.Background(), trip, models.TripPhotosList); err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if len(trip.Photos) != 2 {
+			t.Fatalf("Expected 2 photos, got %d", len(trip.Photos))
+		}
+		if trip.PhotoCount == nil || *trip.PhotoCount != 2 {
+			t.Errorf("Expected photo count 2, got %v", trip.PhotoCount)
+		}
+	})
+
+	t.Run("InvalidMode", func(t *testing.T) {
+		err := service.AttachTripPhotos(context.Background(), &models.Trip{ID: tripID}, "all")
+		if err == nil || err.Error() != "invalid photos mode" {
+			t.Errorf("Expected invalid photos mode error, got: %v", err)
+		}
+	})
+}
+
+func TestServiceImportTrip(t *testing.T) {
+	t.Run("RoundTripReproducesTrip", func(t *testing.T) {
+		service, mockRepo, _ := setupServiceTest()
+		ownerID := uuid.New()
+		importerID := uuid.New()
+		original := &models.Trip{
+			ID:          uuid.New(),
+			UserID:      ownerID,
+			Name:        "Kyoto Spring",
+			Description: "Cherry blossoms",
+			StartDate:   timePtr(time.Now().Add(30 * 24 * time.Hour)),
+			EndDate:     timePtr(time.Now().Add(37 * 24 * time.Hour)),
+			Location:    "Kyoto",
+		}
+
+		originalActivities := []*models.Activity{
+			{
+				ID:        uuid.New(),
+				TripID:    original.ID,
+				Title:     "Fushimi Inari",
+				StartTime: original.StartDate.Add(2 * time.Hour),
+				EndTime:   original.StartDate.Add(5 * time.Hour),
+				Location:  "Fushimi",
+			},
+		}
+
+		mockRepo.getTripByIDFunc = func(ctx context.Context, id uuid.UUID) (*models.Trip, error) {
+			return original, nil
+		}
+		mockRepo.getActivitiesFunc = func(ctx context.Context, id uuid.UUID) ([]*models.Activity, error) {
+			return originalActivities, nil
+		}
+		mockRepo.getTripExpensesFunc = func(ctx context.Context, id uuid.UUID) ([]*models.Expense, error) {
+			return []*models.Expense{
+				{ID: uuid.New(), TripID: id, AmountCents: 12000, Currency: "JPY", Category: "lodging", IncurredAt: *original.StartDate},
+			}, nil
+		}
+		mockRepo.getTripTagsFunc = func(ctx context.Context, id uuid.UUID) ([]*models.Tag, error) {
+			return []*models.Tag{{ID: uuid.New(), Name: "japan"}}, nil
+		}
+		var importedActivities []models.CreateActivityInput
+		var importedExpenses []models.CreateExpenseInput
+		var importedTags []string
+		mockRepo.importTripFunc = func(ctx context.Context, uid uuid.UUID, export models.TripExport) (*models.TripImportResult, error) {
+			importedActivities = export.Activities
+			importedExpenses = export.Expenses
+			importedTags = export.Tags
+			return &models.TripImportResult{
+				Trip: &models.Trip{
+					ID:          uuid.New(),
+					UserID:      uid,
+					Name:        export.Trip.Name,
+					Description: export.Trip.Description,
+					StartDate:   timePtr(export.Trip.StartDate),
+					EndDate:     timePtr(export.Trip.EndDate),
+					Location:    export.Trip.Location,
+				},
+				Imported: map[string]int{"trips": 1},
+			}, nil
+		}
+
+		export, err := service.ExportTrip(context.Background(), original.ID, ownerID)
+		if err != nil {
+			t.Fatalf("Expected no export error, got: %v", err)
+		}
+
+		result, importErrors, err := service.ImportTrip(context.Background(), importerID, *export)
+		if err != nil || len(importErrors) > 0 {
+			t.Fatalf("Expected successful import, got err=%v importErrors=%v", err, importErrors)
+		}
+
+		imported := result.Trip
+		if imported.ID == original.ID {
+			t.Error("Expected imported trip to get a new ID")
+		}
+		if imported.UserID != importerID {
+			t.Errorf("Expected imported trip to belong to %s, got %s", importerID, imported.UserID)
+		}
+		if imported.Name != original.Name || imported.Description != original.Description ||
+			imported.Location != original.Location ||
+			!imported.StartDate.Equal(*original.StartDate) || !imported.EndDate.Equal(*original.EndDate) {
+			t.Errorf("Imported trip %+v does not match original %+v", imported, original)
+		}
+
+		if len(importedActivities) != 1 {
+			t.Fatalf("Expected 1 imported activity, got %d", len(importedActivities))
+		}
+		if importedActivities[0].Title != "Fushimi Inari" || importedActivities[0].Location != "Fushimi" ||
+			!importedActivities[0].StartTime.Equal(originalActivities[0].StartTime) {
+			t.Errorf("Imported activity %+v does not match original %+v", importedActivities[0], originalActivities[0])
+		}
+
+		if len(importedExpenses) != 1 || importedExpenses[0].AmountCents != 12000 || importedExpenses[0].Currency != "JPY" ||
+			importedExpenses[0].Category != "lodging" {
+			t.Errorf("Expected the expense to round trip, got %+v", importedExpenses)
+		}
+		if !slices.Equal(importedTags, []string{"japan"}) {
+			t.Errorf("Expected tags [japan], got %v", importedTags)
+		}
+	})
+
+	t.Run("InvalidExpensesAndTagsReported", func(t *testing.T) {
+		service, mockRepo, _ := setupServiceTest()
+		mockRepo.importTripFunc = func(ctx context.Context, uid uuid.UUID, export models.TripExport) (*models.TripImportResult, error) {
+			t.Error("Repository should not be called for an invalid document")
+			return nil, nil
+		}
+
+		start := time.Now().Add(24 * time.Hour)
+		export := models.TripExport{
+			Version: models.TripExportVersion,
+			Trip: models.CreateTripInput{
+				StartDate: start,
+				EndDate:   start.Add(3 * 24 * time.Hour),
+				Location:  "Kyoto",
+			},
+			Expenses: []models.CreateExpenseInput{
+				{AmountCents: 500, Currency: "jpy", Category: " Food ", IncurredAt: start},
+				{AmountCents: 0, Currency: "yens", Category: "food", IncurredAt: start},
+				{AmountCents: 500, Currency: "JPY", Category: "food", PaidBy: "Ana", IncurredAt: start},
+			},
+			Tags: []string{"Japan", " japan ", " "},
+		}
+
+		_, importErrors, err := service.ImportTrip(context.Background(), uuid.New(), export)
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+
+		var expenseErrors []int
+		var tagErrors []int
+		for _, importError := range importErrors {
+			switch importError.Section {
+			case "expenses":
+				expenseErrors = append(expenseErrors, importError.Index)
+			case "tags":
+				tagErrors = append(tagErrors, importError.Index)
+			default:
+				t.Errorf("Unexpected import error: %+v", importError)
+			}
+		}
+		// Expense 1 has a bad amount and currency, expense 2 a payer without a split
+		if !slices.Equal(expenseErrors, []int{1, 1, 2}) {
+			t.Errorf("Expected errors for expenses [1 1 2], got %v", expenseErrors)
+		}
+		if !slices.Equal(tagErrors, []int{2}) {
+			t.Errorf("Expected an error for tag 2, got %v", tagErrors)
+		}
+	})
+
+	t.Run("Version1DocumentImports", func(t *testing.T) {
+		service, mockRepo, _ := setupServiceTest()
+		mockRepo.importTripFunc = func(ctx context.Context, uid uuid.UUID, export models.TripExport) (*models.TripImportResult, error) {
+			return &models.TripImportResult{Trip: &models.Trip{ID: uuid.New(), UserID: uid}}, nil
+		}
+
+		start := time.Now().Add(24 * time.Hour)
+		export := models.TripExport{
+			Version: 1,
+			Trip:    models.CreateTripInput{StartDate: start, EndDate: start.Add(24 * time.Hour), Location: "Kyoto"},
+		}
+
+		if _, importErrors, err := service.ImportTrip(context.Background(), uuid.New(), export); err != nil || len(importErrors) > 0 {
+			t.Errorf("Expected a version 1 document to import, got err=%v importErrors=%v", err, importErrors)
+		}
+	})
+
+	t.Run("ActivityOutsideTripReported", func(t *testing.T) {
+		service, mockRepo, _ := setupServiceTest()
+		mockRepo.importTripFunc = func(ctx context.Context, uid uuid.UUID, export models.TripExport) (*models.TripImportResult, error) {
+			t.Error("Repository should not be called for an invalid document")
+			return nil, nil
+		}
+
+		start := time.Now().Add(24 * time.Hour)
+		export := models.TripExport{
+			Version: models.TripExportVersion,
+			Trip: models.CreateTripInput{
+				StartDate: start,
+				EndDate:   start.Add(3 * 24 * time.Hour),
+				Location:  "Kyoto",
+			},
+			Activities: []models.CreateActivityInput{
+				{Title: "Inside", StartTime: start.Add(time.Hour), EndTime: start.Add(2 * time.Hour)},
+				{Title: "Outside", StartTime: start.Add(10 * 24 * time.Hour), EndTime: start.Add(10*24*time.Hour + time.Hour)},
+			},
+		}
+
+		_, importErrors, err := service.ImportTrip(context.Background(), uuid.New(), export)
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if len(importErrors) != 1 || importErrors[0].Section != "activities" || importErrors[0].Index != 1 {
+			t.Errorf("Expected an error for activity 1, got %v", importErrors)
+		}
+	})
+
+	t.Run("ActivitiesWithoutTripDatesReported", func(t *testing.T) {
+		service, mockRepo, _ := setupServiceTest()
+		mockRepo.importTripFunc = func(ctx context.Context, uid uuid.UUID, export models.TripExport) (*models.TripImportResult, error) {
+			t.Error("Repository should not be called for an invalid document")
+			return nil, nil
+		}
+
+		start := time.Now().Add(24 * time.Hour)
+		export := models.TripExport{
+			Version: models.TripExportVersion,
+			Trip: models.CreateTripInput{
+				Location:   "Kyoto",
+				IsWishlist: true,
+			},
+			Activities: []models.CreateActivityInput{
+				{Title: "Temple visit", StartTime: start, EndTime: start.Add(time.Hour)},
+			},
+		}
+
+		result, importErrors, err := service.ImportTrip(context.Background(), uuid.New(), export)
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if result != nil || len(importErrors) != 1 || importErrors[0].Section != "activities" || importErrors[0].Message != "trip has no dates" {
+			t.Errorf("Expected a 'trip has no dates' error for the activity, got result=%v errors=%v", result, importErrors)
+		}
+	})
+
+	t.Run("InvalidDatesReported", func(t *testing.T) {
+		service, mockRepo, _ := setupServiceTest()
+		mockRepo.importTripFunc = func(ctx context.Context, uid uuid.UUID, export models.TripExport) (*models.TripImportResult, error) {
+			t.Error("Repository should not be called for an invalid document")
+			return nil, nil
+		}
+
+		export := models.TripExport{
+			Version: models.TripExportVersion,
+			Trip: models.CreateTripInput{
+				StartDate: time.Now().Add(7 * 24 * time.Hour),
+				EndDate:   time.Now().Add(24 * time.Hour),
+				Location:  "Kyoto",
+			},
+		}
+
+		result, importErrors, err := service.ImportTrip(context.Background(), uuid.New(), export)
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if result != nil || len(importErrors) != 1 || importErrors[0].Section != "trip" {
+			t.Errorf("Expected a single trip import error, got result=%v errors=%v", result, importErrors)
+		}
+	})
+
+	t.Run("UnsupportedVersion", func(t *testing.T) {
+		service, _, _ := setupServiceTest()
+
+		_, _, err := service.ImportTrip(context.Background(), uuid.New(), models.TripExport{Version: models.TripExportVersion + 1})
+		if err == nil || err.Error() != "unsupported export version" {
+			t.Errorf("Expected unsupported version error, got: %v", err)
+		}
+	})
+}
+
+func TestServiceGetUserWithTrips(t *testing.T) {
+	testCases := []struct {
+		name          string
+		setupMocks    func(*testing.T, *MockRepository, *MockViewService, uuid.UUID)
+		expectedError bool
+		errorMessage  string
+		tripCount     int
+	}{
+		{
+			name: "SuccessfulRetrieval",
+			setupMocks: func(t *testing.T, mockRepo *MockRepository, mockViewService *MockViewService, userID uuid.UUID) {
+				mockViewService.getUserProfileFunc = func(ctx context.Context, id uuid.UUID) (*models.User, error) {
+					return &models.User{
+						ID:   userID,
+						Name: "Test User",
+					}, nil
+				}
+				mockRepo.getTripsByUserIDFunc = func(ctx context.Context, id uuid.UUID, limit, offset int, filter models.TripFilter) ([]*models.Trip, error) {
+					return []*models.Trip{
+						{
+							ID:     uuid.New(),
+							UserID: userID,
+							Name:   "Trip 1",
+						},
+						{
+							ID:     uuid.New(),
+							UserID: userID,
+							Name:   "Trip 2",
+						},
+					}, nil
+				}
+			},
+			expectedError: false,
+			tripCount:     2,
+		},
+		{
+			name: "UserNotFound",
+			setupMocks: func(t *testing.T, mockRepo *MockRepository, mockViewService *MockViewService, userID uuid.UUID) {
+				mockViewService.getUserProfileFunc = func(ctx context.Context, id uuid.UUID) (*models.User, error) {
+					return nil, errors.New("user not found")
+				}
+			},
+			expectedError: true,
+			errorMessage:  "user not found",
+			tripCount:     0,
+		},
+		{
+			name: "ErrorGettingTrips",
+			setupMocks: func(t *testing.T, mockRepo *MockRepository, mockViewService *MockViewService, userID uuid.UUID) {
+				mockViewService.getUserProfileFunc = func(ctx context.Context, id uuid.UUID) (*models.User, error) {
+					return &models.User{
+						ID:   userID,
+						Name: "Test User",
+					}, nil
+				}
+				mockRepo.getTripsByUserIDFunc = func(ctx context.Context, id uuid.UUID, limit, offset int, filter models.TripFilter) ([]*models.Trip, error) {
+					return nil, errors.New("database error")
+				}
+			},
+			expectedError: true,
+			errorMessage:  "database error",
+			tripCount:     0,
+		},
+		{
+			name: "NilUserReturned",
+			setupMocks: func(t *testing.T, mockRepo *MockRepository, mockViewService *MockViewService, userID uuid.UUID) {
+				mockViewService.getUserProfileFunc = func(ctx context.Context, id uuid.UUID) (*models.User, error) {
+					return nil, nil
+				}
+			},
+			expectedError: false,
+			tripCount:     0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			service, mockRepo, mockViewService := setupServiceTest()
+			userID := uuid.New()
+
+			// Setup mocks
+			tc.setupMocks(t, mockRepo, mockViewService, userID)
+
+			// Execute
+			result, err := service.GetUserWithTrips(context.Background(), userID, 10, 0)
+
+			// Verify
+			if tc.expectedError {
+				if err == nil {
+					t.Error("Expected error, got nil")
+				} else if tc.errorMessage != "" && err.Error() != tc.errorMessage {
+					t.Errorf("Expected error message '%s', got '%s'", tc.errorMessage, err.Error())
+				}
+				if result != nil {
+					t.Errorf("Expected nil result, got %v", result)
+				}
+			} else {
+				if err != nil {
+					t.Errorf("Expected no error, got: %v", err)
+				}
+
+				// Special case for nil user
+				if tc.name == "NilUserReturned" {
+					if result != nil {
+						t.Error("Expected nil user, got non-nil")
+					}
+					return
+				}
+
+				if result == nil {
+					t.Fatal("Expected user to be returned, got nil")
+				}
+
+				if len(result.Trips) != tc.tripCount {
+					t.Errorf("Expected %d trips, got %d", tc.tripCount, len(result.Trips))
+				}
+			}
+		})
+	}
+}
+
+func TestServiceAddTripTag(t *testing.T) {
+	userID := uuid.New()
+	tripID := uuid.New()
+
+	testCases := []struct {
+		name          string
+		input         models.AddTripTagInput
+		tripOwner     uuid.UUID
+		expectedName  string
+		expectedError string
+	}{
+		{
+			name:         "NormalizesName",
+			input:        models.AddTripTagInput{Name: "  Business "},
+			tripOwner:    userID,
+			expectedName: "business",
+		},
+		{
+			name:          "UnauthorizedAccess",
+			input:         models.AddTripTagInput{Name: "business"},
+			tripOwner:     uuid.New(),
+			expectedError: "unauthorized access to trip",
+		},
+		{
+			name:          "BlankName",
+			input:         models.AddTripTagInput{Name: "   "},
+			tripOwner:     userID,
+			expectedError: "tag name is required",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			service, mockRepo, _ := setupServiceTest()
+
+			mockRepo.getTripByIDFunc = func(ctx context.Context, id uuid.UUID) (*models.Trip, error) {
+				return &models.Trip{ID: id, UserID: tc.tripOwner}, nil
+			}
+
+			var addedName string
+			mockRepo.addTripTagFunc = func(ctx context.Context, tid uuid.UUID, uid uuid.UUID, name string) (*models.Tag, error) {
+				addedName = name
+				return &models.Tag{ID: uuid.New(), UserID: uid, Name: name}, nil
+			}
+			mockRepo.getTripTagsFunc = func(ctx context.Context, tid uuid.UUID) ([]*models.Tag, error) {
+				return []*models.Tag{{Name: addedName, UserID: userID}}, nil
+			}
+
+			tags, err := service.AddTripTag(context.Background(), tripID, userID, tc.input)
+
+			if tc.expectedError != "" {
+				if err == nil || err.Error() != tc.expectedError {
+					t.Fatalf("Expected error '%s', got %v", tc.expectedError, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if addedName != tc.expectedName {
+				t.Errorf("Expected tag '%s' to be stored, got '%s'", tc.expectedName, addedName)
+			}
+			if len(tags) != 1 || tags[0].Name != tc.expectedName {
+				t.Errorf("Expected trip tags to include '%s', got %v", tc.expectedName, tags)
+			}
+		})
+	}
+}
+
+func TestServiceRemoveTripTag(t *testing.T) {
+	service, mockRepo, _ := setupServiceTest()
+	userID := uuid.New()
+	tripID := uuid.New()
+
+	mockRepo.getTripByIDFunc = func(ctx context.Context, id uuid.UUID) (*models.Trip, error) {
+		return &models.Trip{ID: id, UserID: userID}, nil
+	}
+	mockRepo.removeTripTagFunc = func(ctx context.Context, tid uuid.UUID, uid uuid.UUID, name string) error {
+		if uid != userID {
+			t.Errorf("Expected tags to be scoped to user %s, got %s", userID, uid)
+		}
+		if name != "family" {
+			return errors.New("tag not found")
+		}
+		return nil
+	}
+
+	if err := service.RemoveTripTag(context.Background(), tripID, userID, "Family"); err != nil {
+		t.Errorf("Expected no error, got: %v", err)
+	}
+
+	if err := service.RemoveTripTag(context.Background(), tripID, userID, "business"); err == nil || err.Error() != "tag not found" {
+		t.Errorf("Expected 'tag not found' error, got %v", err)
+	}
+}
+
+func TestServiceGetTripsByUserIDTagFilter(t *testing.T) {
+	service, mockRepo, mockViewService := setupServiceTest()
+	userID := uuid.New()
+
+	mockViewService.getUserProfileFunc = func(ctx context.Context, id uuid.UUID) (*models.User, error) {
+		return &models.User{ID: id}, nil
+	}
+
+	var receivedFilter models.TripFilter
+	mockRepo.getTripsByUserIDFunc = func(ctx context.Context, id uuid.UUID, limit, offset int, filter models.TripFilter) ([]*models.Trip, error) {
+		receivedFilter = filter
+		return []*models.Trip{}, nil
+	}
+
+	_, err := service.GetTripsByUserID(context.Background(), userID, 10, 0, models.TripFilter{Tag: " Vacation"})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if receivedFilter.Tag != "vacation" {
+		t.Errorf("Expected normalized tag filter 'vacation', got '%s'", receivedFilter.Tag)
+	}
+}
+
+func TestServiceReorderTrips(t *testing.T) {
+	userID := uuid.New()
+	first, second, third := uuid.New(), uuid.New(), uuid.New()
+
+	t.Run("ManualOrderReflectedInListing", func(t *testing.T) {
+		service, mockRepo, mockViewService := setupServiceTest()
+
+		mockViewService.getUserProfileFunc = func(ctx context.Context, id uuid.UUID) (*models.User, error) {
+			return &models.User{ID: id}, nil
+		}
+
+		// Trips by start date: first, second, third
+		byStartDate := []*models.Trip{{ID: first}, {ID: second}, {ID: third}}
+		var savedOrder []uuid.UUID
+
+		mockRepo.reorderTripsFunc = func(ctx context.Context, uid uuid.UUID, tripIDs []uuid.UUID) error {
+			savedOrder = tripIDs
+			return nil
+		}
+		mockRepo.getTripsByUserIDFunc = func(ctx context.Context, uid uuid.UUID, limit, offset int, filter models.TripFilter) ([]*models.Trip, error) {
+			if filter.Sort != models.TripSortManual {
+				return byStartDate, nil
+			}
+			ordered := make([]*models.Trip, 0, len(savedOrder))
+			for _, id := range savedOrder {
+				ordered = append(ordered, &models.Trip{ID: id})
+			}
+			return ordered, nil
+		}
+
+		err := service.ReorderTrips(context.Background(), userID, models.ReorderTripsInput{
+			TripIDs: []uuid.UUID{third, first, second},
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+
+		listed, err := service.GetTripsByUserID(context.Background(), userID, 10, 0, models.TripFilter{Sort: models.TripSortManual})
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+
+		expected := []uuid.UUID{third, first, second}
+		for i, trip := range listed {
+			if trip.ID != expected[i] {
+				t.Errorf("Expected trip %s at position %d, got %s", expected[i], i, trip.ID)
+			}
+		}
+	})
+
+	t.Run("DuplicateIDs", func(t *testing.T) {
+		service, mockRepo, _ := setupServiceTest()
+
+		mockRepo.reorderTripsFunc = func(ctx context.Context, uid uuid.UUID, tripIDs []uuid.UUID) error {
+			t.Error("Expected the repository not to be called")
+			return nil
+		}
+
+		err := service.ReorderTrips(context.Background(), userID, models.ReorderTripsInput{
+			TripIDs: []uuid.UUID{first, first},
+		})
+		if err == nil || err.Error() != "invalid trip order" {
+			t.Fatalf("Expected error 'invalid trip order', got %v", err)
+		}
+	})
+
+	t.Run("TripsOfAnotherUser", func(t *testing.T) {
+		service, mockRepo, _ := setupServiceTest()
+
+		mockRepo.reorderTripsFunc = func(ctx context.Context, uid uuid.UUID, tripIDs []uuid.UUID) error {
+			return errors.New("invalid trip order")
+		}
+
+		err := service.ReorderTrips(context.Background(), userID, models.ReorderTripsInput{
+			TripIDs: []uuid.UUID{first, uuid.New()},
+		})
+		if err == nil || err.Error() != "invalid trip order" {
+			t.Fatalf("Expected error 'invalid trip order', got %v", err)
+		}
+	})
+}
+
+func TestServiceGetTripsByUserIDInvalidSort(t *testing.T) {
+	service, _, mockViewService := setupServiceTest()
+
+	mockViewService.getUserProfileFunc = func(ctx context.Context, id uuid.UUID) (*models.User, error) {
+		return &models.User{ID: id}, nil
+	}
+
+	_, err := service.GetTripsByUserID(context.Background(), uuid.New(), 10, 0, models.TripFilter{Sort: "name"})
+	if err == nil || err.Error() != "invalid sort option" {
+		t.Fatalf("Expected error 'invalid sort option', got %v", err)
+	}
+}
+
+func TestServiceGetTripsByUserIDSortSpec(t *testing.T) {
+	testCases := []struct {
+		sort         string
+		expectedSpec *models.TripSortSpec
+		expectError  bool
+	}{
+		{sort: "", expectedSpec: nil},
+		{sort: models.TripSortManual, expectedSpec: nil},
+		{sort: "start_date:desc", expectedSpec: &models.TripSortSpec{Field: "start_date", Descending: true}},
+		{sort: "end_date:asc", expectedSpec: &models.TripSortSpec{Field: "end_date"}},
+		{sort: "created_at:desc", expectedSpec: &models.TripSortSpec{Field: "created_at", Descending: true}},
+		{sort: "name:asc", expectedSpec: &models.TripSortSpec{Field: "name"}},
+		{sort: "location:asc", expectError: true},
+		{sort: "name:sideways", expectError: true},
+		{sort: "name; DROP TABLE trips:asc", expectError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.sort, func(t *testing.T) {
+			service, mockRepo, mockViewService := setupServiceTest()
+
+			mockViewService.getUserProfileFunc = func(ctx context.Context, id uuid.UUID) (*models.User, error) {
+				return &models.User{ID: id}, nil
+			}
+
+			var received models.TripFilter
+			mockRepo.getTripsByUserIDFunc = func(ctx context.Context, uid uuid.UUID, limit, offset int, filter models.TripFilter) ([]*models.Trip, error) {
+				received = filter
+				return []*models.Trip{}, nil
+			}
+
+			_, err := service.GetTripsByUserID(context.Background(), uuid.New(), 10, 0, models.TripFilter{Sort: tc.sort})
+			if tc.expectError {
+				if err == nil || err.Error() != "invalid sort option" {
+					t.Fatalf("Expected error 'invalid sort option', got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			if tc.expectedSpec == nil {
+				if received.SortSpec != nil {
+					t.Errorf("Expected no sort spec, got %+v", *received.SortSpec)
+				}
+			} else if received.SortSpec == nil || *received.SortSpec != *tc.expectedSpec {
+				t.Errorf("Expected sort spec %+v, got %+v", *tc.expectedSpec, received.SortSpec)
+			}
+		})
+	}
+}
+
+func TestServiceCreateTrips(t *testing.T) {
+	userID := uuid.New()
+	start := time.Now().Add(24 * time.Hour)
+
+	valid := func(location string) models.CreateTripInput {
+		return models.CreateTripInput{StartDate: start, EndDate: start.Add(72 * time.Hour), Location: location}
+	}
+
+	t.Run("CreatesInOrder", func(t *testing.T) {
+		service, mockRepo, _ := setupServiceTest()
+
+		mockRepo.createTripsFunc = func(ctx context.Context, uid uuid.UUID, inputs []models.CreateTripInput) ([]*models.Trip, error) {
+			trips := make([]*models.Trip, 0, len(inputs))
+			for _, input := range inputs {
+				trips = append(trips, &models.Trip{ID: uuid.New(), UserID: uid, Name: input.Name, Location: input.Location})
+			}
+			return trips, nil
+		}
+
+		trips, batchErrors, err := service.CreateTrips(context.Background(), userID, []models.CreateTripInput{valid("Lisbon"), valid("Porto")})
+		if err != nil || len(batchErrors) > 0 {
+			t.Fatalf("Expected no errors, got: %v %v", err, batchErrors)
+		}
+
+		if len(trips) != 2 || trips[0].Name != "Trip to Lisbon" || trips[1].Name != "Trip to Porto" {
+			t.Errorf("Expected default-named trips in input order, got %v", trips)
+		}
+	})
+
+	t.Run("EchoesClientIDs", func(t *testing.T) {
+		service, mockRepo, _ := setupServiceTest()
+
+		mockRepo.createTripsFunc = func(ctx context.Context, uid uuid.UUID, inputs []models.CreateTripInput) ([]*models.Trip, error) {
+			trips := make([]*models.Trip, 0, len(inputs))
+			for range inputs {
+				trips = append(trips, &models.Trip{ID: uuid.New(), UserID: uid})
+			}
+			return trips, nil
+		}
+
+		lisbon, porto := valid("Lisbon"), valid("Porto")
+		lisbon.ClientID, porto.ClientID = "tmp-1", "tmp-2"
+
+		trips, _, err := service.CreateTrips(context.Background(), userID, []models.CreateTripInput{lisbon, porto})
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+
+		if trips[0].ClientID != "tmp-1" || trips[1].ClientID != "tmp-2" {
+			t.Errorf("Expected client IDs tmp-1 and tmp-2, got %q and %q", trips[0].ClientID, trips[1].ClientID)
+		}
+	})
+
+	t.Run("InvalidItemReportedByIndex", func(t *testing.T) {
+		service, mockRepo, _ := setupServiceTest()
+
+		mockRepo.createTripsFunc = func(ctx context.Context, uid uuid.UUID, inputs []models.CreateTripInput) ([]*models.Trip, error) {
+			t.Error("Expected nothing to be created")
+			return nil, nil
+		}
+
+		invalid := valid("Porto")
+		invalid.EndDate = start.Add(-24 * time.Hour)
+
+		_, batchErrors, err := service.CreateTrips(context.Background(), userID, []models.CreateTripInput{valid("Lisbon"), invalid})
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+
+		if len(batchErrors) != 1 || batchErrors[0].Index != 1 || batchErrors[0].Field != "end_date" {
+			t.Errorf("Expected an end_date error for index 1, got %v", batchErrors)
+		}
+	})
+
+	t.Run("TooManyTrips", func(t *testing.T) {
+		service, _, _ := setupServiceTest()
+
+		inputs := make([]models.CreateTripInput, trips.MaxBulkTrips+1)
+		for i := range inputs {
+			inputs[i] = valid("Lisbon")
+		}
+
+		_, _, err := service.CreateTrips(context.Background(), userID, inputs)
+		if err == nil || err.Error() != "too many trips in batch" {
+			t.Fatalf("Expected error 'too many trips in batch', got %v", err)
+		}
+	})
+}
+
+func TestServiceGetTripsByUserIDStatusFilter(t *testing.T) {
+	testCases := []struct {
+		status      string
+		expectError bool
+	}{
+		{status: ""},
+		{status: models.TripStatusPast},
+		{status: models.TripStatusOngoing},
+		{status: models.TripStatusUpcoming},
+		{status: "someday", expectError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.status, func(t *testing.T) {
+			service, mockRepo, mockViewService := setupServiceTest()
+
+			mockViewService.getUserProfileFunc = func(ctx context.Context, id uuid.UUID) (*models.User, error) {
+				return &models.User{ID: id}, nil
+			}
+			mockRepo.getTripsByUserIDFunc = func(ctx context.Context, uid uuid.UUID, limit, offset int, filter models.TripFilter) ([]*models.Trip, error) {
+				if tc.expectError {
+					t.Error("Expected the repository not to be called")
+				}
+				if filter.Status != tc.status {
+					t.Errorf("Expected status %q, got %q", tc.status, filter.Status)
+				}
+				return []*models.Trip{}, nil
+			}
+
+			_, err := service.GetTripsByUserID(context.Background(), uuid.New(), 10, 0, models.TripFilter{Status: tc.status})
+			if tc.expectError {
+				if err == nil || err.Error() != "invalid status filter" {
+					t.Fatalf("Expected error 'invalid status filter', got %v", err)
+				}
+			} else if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+		})
+	}
+}
+
+func TestServiceTripStatus(t *testing.T) {
+	now := time.Now()
+	day := 24 * time.Hour
+
+	testCases := []struct {
+		name           string
+		startDate      *time.Time
+		endDate        *time.Time
+		expectedStatus string
+	}{
+		{name: "Past", startDate: timePtr(now.Add(-10 * day)), endDate: timePtr(now.Add(-3 * day)), expectedStatus: models.TripStatusPast},
+		{name: "Ongoing", startDate: timePtr(now.Add(-day)), endDate: timePtr(now.Add(day)), expectedStatus: models.TripStatusOngoing},
+		{name: "Upcoming", startDate: timePtr(now.Add(3 * day)), endDate: timePtr(now.Add(10 * day)), expectedStatus: models.TripStatusUpcoming},
+		{name: "LastDayIsToday", startDate: timePtr(models.TripDay(now).Add(-2 * day)), endDate: timePtr(models.TripDay(now)), expectedStatus: models.TripStatusOngoing},
+		{name: "LastDayWasYesterday", startDate: timePtr(models.TripDay(now).Add(-2 * day)), endDate: timePtr(models.TripDay(now).Add(-day)), expectedStatus: models.TripStatusPast},
+		{name: "WishlistWithoutDates", expectedStatus: ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			service, mockRepo, mockViewService := setupServiceTest()
+			userID := uuid.New()
+			tripID := uuid.New()
+
+			trip := func() *models.Trip {
+				return &models.Trip{ID: tripID, UserID: userID, StartDate: tc.startDate, EndDate: tc.endDate, IsWishlist: tc.startDate == nil}
+			}
+
+			mockViewService.getUserProfileFunc = func(ctx context.Context, id uuid.UUID) (*models.User, error) {
+				return &models.User{ID: id}, nil
+			}
+			mockRepo.getTripByIDFunc = func(ctx context.Context, id uuid.UUID) (*models.Trip, error) {
+				return trip(), nil
+			}
+			mockRepo.getTripWithUserFunc = func(ctx context.Context, id uuid.UUID) (*models.Trip, error) {
+				withUser := trip()
+				withUser.User = &models.User{ID: userID}
+				return withUser, nil
+			}
+			mockRepo.getTripsByUserIDFunc = func(ctx context.Context, uid uuid.UUID, limit, offset int, filter models.TripFilter) ([]*models.Trip, error) {
+				return []*models.Trip{trip()}, nil
+			}
+
+			single, err := service.GetTripByID(context.Background(), tripID, userID)
+			if err != nil {
+				t.Fatalf("GetTripByID: expected no error, got: %v", err)
+			}
+			withUser, err := service.GetTripWithUser(context.Background(), tripID, userID)
+			if err != nil {
+				t.Fatalf("GetTripWithUser: expected no error, got: %v", err)
+			}
+			listed, err := service.GetTripsByUserID(context.Background(), userID, 10, 0, models.TripFilter{})
+			if err != nil {
+				t.Fatalf("GetTripsByUserID: expected no error, got: %v", err)
+			}
+
+			for source, got := range map[string]*models.Trip{"single": single, "with-user": withUser, "list": listed[0]} {
+				if got.Status != tc.expectedStatus {
+					t.Errorf("%s: expected status %q, got %q", source, tc.expectedStatus, got.Status)
+				}
+			}
+		})
+	}
+}
+
+func TestServiceBulkTagTrips(t *testing.T) {
+	userID := uuid.New()
+
+	t.Run("TagsEveryTrip", func(t *testing.T) {
+		service, mockRepo, _ := setupServiceTest()
+		tripIDs := []uuid.UUID{uuid.New(), uuid.New(), uuid.New()}
+
+		// Tags per trip, as the repository would store them
+		stored := map[uuid.UUID][]*models.Tag{}
+		mockRepo.bulkTagTripsFunc = func(ctx context.Context, uid uuid.UUID, ids []uuid.UUID, names []string, replace bool) ([]uuid.UUID, error) {
+			if replace {
+				t.Error("Expected add mode")
+			}
+			for _, id := range ids {
+				for _, name := range names {
+					stored[id] = append(stored[id], &models.Tag{UserID: uid, Name: name})
+				}
+			}
+			return ids, nil
+		}
+		mockRepo.getTripTagsFunc = func(ctx context.Context, tripID uuid.UUID) ([]*models.Tag, error) {
+			return stored[tripID], nil
+		}
+
+		results, err := service.BulkTagTrips(context.Background(), userID, models.BulkTagTripsInput{
+			IDs:  tripIDs,
+			Tags: []string{" Beach ", "summer", "beach"},
+			Mode: models.BulkTagModeAdd,
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+
+		if len(results) != len(tripIDs) {
+			t.Fatalf("Expected %d results, got %d", len(tripIDs), len(results))
+		}
+		for i, result := range results {
+			if result.ID != tripIDs[i] || result.Status != models.BulkTagStatusTagged {
+				t.Errorf("Expected trip %s to be tagged, got %+v", tripIDs[i], result)
+			}
+			var names []string
+			for _, tag := range result.Tags {
+				names = append(names, tag.Name)
+			}
+			if strings.Join(names, ",") != "beach,summer" {
+				t.Errorf("Expected tags beach,summer on trip %s, got %v", result.ID, names)
+			}
+		}
+	})
+
+	t.Run("UnownedTripsReportedNotFound", func(t *testing.T) {
+		service, mockRepo, _ := setupServiceTest()
+		owned, unowned := uuid.New(), uuid.New()
+
+		mockRepo.bulkTagTripsFunc = func(ctx context.Context, uid uuid.UUID, ids []uuid.UUID, names []string, replace bool) ([]uuid.UUID, error) {
+			if !replace {
+				t.Error("Expected replace mode")
+			}
+			return []uuid.UUID{owned}, nil
+		}
+		mockRepo.getTripTagsFunc = func(ctx context.Context, tripID uuid.UUID) ([]*models.Tag, error) {
+			return []*models.Tag{{Name: "work"}}, nil
+		}
+
+		results, err := service.BulkTagTrips(context.Background(), userID, models.BulkTagTripsInput{
+			IDs:  []uuid.UUID{owned, unowned},
+			Tags: []string{"work"},
+			Mode: models.BulkTagModeReplace,
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+
+		if results[0].Status != models.BulkTagStatusTagged {
+			t.Errorf("Expected owned trip to be tagged, got %+v", results[0])
+		}
+		if results[1].Status != models.BulkTagStatusNotFound || results[1].Tags != nil {
+			t.Errorf("Expected unowned trip to be not found without tags, got %+v", results[1])
+		}
+	})
+
+	t.Run("TooManyTrips", func(t *testing.T) {
+		service, mockRepo, _ := setupServiceTest()
+		mockRepo.bulkTagTripsFunc = func(ctx context.Context, uid uuid.UUID, ids []uuid.UUID, names []string, replace bool) ([]uuid.UUID, error) {
+			t.Error("Expected the repository not to be called")
+			return nil, nil
+		}
+
+		ids := make([]uuid.UUID, trips.MaxBulkTrips+1)
+		for i := range ids {
+			ids[i] = uuid.New()
+		}
+
+		_, err := service.BulkTagTrips(context.Background(), userID, models.BulkTagTripsInput{
+			IDs:  ids,
+			Tags: []string{"work"},
+			Mode: models.BulkTagModeAdd,
+		})
+		if err == nil || err.Error() != "too many trips in batch" {
+			t.Fatalf("Expected error 'too many trips in batch', got %v", err)
+		}
+	})
+
+	t.Run("BlankTag", func(t *testing.T) {
+		service, _, _ := setupServiceTest()
+
+		_, err := service.BulkTagTrips(context.Background(), userID, models.BulkTagTripsInput{
+			IDs:  []uuid.UUID{uuid.New()},
+			Tags: []string{"   "},
+			Mode: models.BulkTagModeAdd,
+		})
+		if err == nil || err.Error() != "tag name is required" {
+			t.Fatalf("Expected error 'tag name is required', got %v", err)
+		}
+	})
+}
+
+func TestServiceGetTripCadence(t *testing.T) {
+	day := func(year int, month time.Month, d int) time.Time {
+		return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	testCases := []struct {
+		name            string
+		starts          []time.Time
+		expectedAverage *float64
+		expectedLongest *float64
+		expectedYears   []models.TripYearCount
+	}{
+		{
+			name: "SeededSequence",
+			// Gaps of 10, 20 and 45 days
+			starts:          []time.Time{day(2024, time.December, 1), day(2024, time.December, 11), day(2024, time.December, 31), day(2025, time.February, 14)},
+			expectedAverage: floatPtr(25),
+			expectedLongest: floatPtr(45),
+			expectedYears:   []models.TripYearCount{{Year: 2024, Trips: 3}, {Year: 2025, Trips: 1}},
+		},
+		{
+			name:            "UnevenGap",
+			starts:          []time.Time{day(2025, time.March, 1), day(2025, time.March, 2), day(2025, time.March, 4), day(2025, time.March, 5)},
+			expectedAverage: floatPtr(1.3),
+			expectedLongest: floatPtr(2),
+			expectedYears:   []models.TripYearCount{{Year: 2025, Trips: 4}},
+		},
+		{
+			name:          "SingleTrip",
+			starts:        []time.Time{day(2025, time.May, 1)},
+			expectedYears: []models.TripYearCount{{Year: 2025, Trips: 1}},
+		},
+		{
+			name:          "NoTrips",
+			starts:        []time.Time{},
+			expectedYears: []models.TripYearCount{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			service, mockRepo, _ := setupServiceTest()
+			mockRepo.getTripStartDatesFunc = func(ctx context.Context, userID uuid.UUID) ([]time.Time, error) {
+				return tc.starts, nil
+			}
+
+			// Execute
+			cadence, err := service.GetTripCadence(context.Background(), uuid.New())
+
+			// Verify
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if cadence.TripCount != len(tc.starts) {
+				t.Errorf("Expected %d trips, got %d", len(tc.starts), cadence.TripCount)
+			}
+			checkFloatPtr(t, "average gap", tc.expectedAverage, cadence.AverageGapDays)
+			checkFloatPtr(t, "longest gap", tc.expectedLongest, cadence.LongestGapDays)
+			if !slices.Equal(cadence.TripsPerYear, tc.expectedYears) {
+				t.Errorf("Expected trips per year %v, got %v", tc.expectedYears, cadence.TripsPerYear)
+			}
+		})
+	}
+}
+
+func floatPtr(f float64) *float64 {
+	return &f
+}
+
+func checkFloatPtr(t *testing.T, name string, expected, got *float64) {
+	t.Helper()
+	if expected == nil || got == nil {
+		if expected != got {
+			t.Errorf("Expected %s %v, got %v", name, expected, got)
+		}
+		return
+	}
+	if *expected != *got {
+		t.Errorf("Expected %s %v, got %v", name, *expected, *got)
+	}
+}
+
+func TestServiceGetCurrentTrips(t *testing.T) {
+	service, mockRepo, _ := setupServiceTest()
+	userID := uuid.New()
+	now := time.Now()
+
+	past := &models.Trip{ID: uuid.New(), StartDate: timePtr(now.Add(-10 * 24 * time.Hour)), EndDate: timePtr(now.Add(-7 * 24 * time.Hour))}
+	spanning := &models.Trip{ID: uuid.New(), StartDate: timePtr(now.Add(-24 * time.Hour)), EndDate: timePtr(now.Add(24 * time.Hour))}
+	future := &models.Trip{ID: uuid.New(), StartDate: timePtr(now.Add(7 * 24 * time.Hour)), EndDate: timePtr(now.Add(10 * 24 * time.Hour))}
+
+	mockRepo.getTripsByUserIDFunc = func(ctx context.Context, uid uuid.UUID, limit, offset int, filter models.TripFilter) ([]*models.Trip, error) {
+		if filter.Status != models.TripStatusOngoing {
+			t.Errorf("Expected the ongoing status filter, got %q", filter.Status)
+		}
+		if filter.Wishlist == nil || *filter.Wishlist {
+			t.Error("Expected wishlist trips to be excluded")
+		}
+		if limit != trips.MaxCurrentTrips {
+			t.Errorf("Expected limit %d, got %d", trips.MaxCurrentTrips, limit)
+		}
+
+		// Hand back trips either side of now too, as a query run a moment
+		// earlier or later could, so the service's own check is exercised
+		return []*models.Trip{past, spanning, future}, nil
+	}
+
+	current, err := service.GetCurrentTrips(context.Background(), userID)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if len(current) != 1 || current[0].ID != spanning.ID {
+		t.Fatalf("Expected only the trip spanning now, got %v", current)
+	}
+	if current[0].Status != models.TripStatusOngoing {
+		t.Errorf("Expected status %q, got %q", models.TripStatusOngoing, current[0].Status)
+	}
+}
+
+func TestServiceGetUpcomingTrips(t *testing.T) {
+	testCases := []struct {
+		name          string
+		within        int
+		expectRepo    bool
+		expectedError bool
+	}{
+		{name: "Success", within: 7, expectRepo: true},
+		{name: "MaxWindow", within: trips.MaxUpcomingDays, expectRepo: true},
+		{name: "ZeroWindow", within: 0, expectedError: true},
+		{name: "WindowTooLong", within: trips.MaxUpcomingDays + 1, expectedError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			service, mockRepo, _ := setupServiceTest()
+			userID := uuid.New()
+			now := time.Now()
+			soon := &models.Trip{ID: uuid.New(), StartDate: timePtr(now.Add(48 * time.Hour)), EndDate: timePtr(now.Add(96 * time.Hour))}
+
+			repoCalled := false
+			mockRepo.getUpcomingTripsFunc = func(ctx context.Context, uid uuid.UUID, within int) ([]*models.Trip, error) {
+				repoCalled = true
+				if uid != userID || within != tc.within {
+					t.Errorf("Expected user %s within %d, got %s within %d", userID, tc.within, uid, within)
+				}
+				return []*models.Trip{soon}, nil
+			}
+
+			// Execute
+			upcoming, err := service.GetUpcomingTrips(context.Background(), userID, tc.within)
+
+			// Verify
+			if repoCalled != tc.expectRepo {
+				t.Errorf("Expected repository called=%v, got %v", tc.expectRepo, repoCalled)
+			}
+			if tc.expectedError {
+				if err == nil {
+					t.Error("Expected an error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if len(upcoming) != 1 || upcoming[0].Status != models.TripStatusUpcoming {
+				t.Errorf("Expected one upcoming trip, got %v", upcoming)
+			}
+		})
+	}
+}
+
+func TestServiceGetLocationHistory(t *testing.T) {
+	userID := uuid.New()
+	day := func(month, date int) *time.Time {
+		return timePtr(time.Date(2024, time.Month(month), date, 0, 0, 0, 0, time.UTC))
+	}
+
+	testCases := []struct {
+		name               string
+		location           string
+		trips              []*models.Trip
+		expectedLocation   string
+		expectedCount      int
+		expectedDays       int
+		expectedFirstVisit *time.Time
+		expectedLastVisit  *time.Time
+		expectedError      string
+	}{
+		{
+			name:     "GroupsSpellingsOfTheSameLocation",
+			location: "  PARIS ",
+			trips: []*models.Trip{
+				{ID: uuid.New(), Location: "Paris", StartDate: day(3, 1), EndDate: day(3, 4)},
+				{ID: uuid.New(), Location: "paris", StartDate: day(9, 10), EndDate: day(9, 10)},
+				{ID: uuid.New(), Location: " Paris  ", StartDate: day(6, 5), EndDate: day(6, 9)},
+			},
+			expectedLocation:   "paris",
+			expectedCount:      3,
+			expectedDays:       4 + 1 + 5,
+			expectedFirstVisit: day(3, 1),
+			expectedLastVisit:  day(9, 10),
+		},
+		{
+			name:               "CollapsesInnerWhitespace",
+			location:           "New   York",
+			trips:              []*models.Trip{{ID: uuid.New(), Location: "new york", StartDate: day(1, 30), EndDate: day(2, 2)}},
+			expectedLocation:   "new york",
+			expectedCount:      1,
+			expectedDays:       4,
+			expectedFirstVisit: day(1, 30),
+			expectedLastVisit:  day(1, 30),
+		},
+		{
+			name:             "NoTrips",
+			location:         "Lisbon",
+			expectedLocation: "lisbon",
+		},
+		{
+			name:          "BlankLocation",
+			location:      "   ",
+			expectedError: "invalid location",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			service, mockRepo, _ := setupServiceTest()
+			mockRepo.getByLocationFunc = func(ctx context.Context, uid uuid.UUID, location string) ([]*models.Trip, error) {
+				if uid != userID || location != tc.expectedLocation {
+					t.Errorf("Expected %q for user %s, got %q for %s", tc.expectedLocation, userID, location, uid)
+				}
+				return tc.trips, nil
+			}
+
+			// Execute
+			history, err := service.GetLocationHistory(context.Background(), userID, tc.location)
+
+			// Verify
+			if tc.expectedError != "" {
+				if err == nil || err.Error() != tc.expectedError {
+					t.Fatalf("Expected error '%s', got %v", tc.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			if history.Location != tc.expectedLocation || history.TripCount != tc.expectedCount || len(history.Trips) != tc.expectedCount {
+				t.Errorf("Expected %d trips to %q, got %d (%d listed) to %q",
+					tc.expectedCount, tc.expectedLocation, history.TripCount, len(history.Trips), history.Location)
+			}
+			if history.TotalDays != tc.expectedDays {
+				t.Errorf("Expected %d total days, got %d", tc.expectedDays, history.TotalDays)
+			}
+			if !equalTimePtr(history.FirstVisit, tc.expectedFirstVisit) || !equalTimePtr(history.LastVisit, tc.expectedLastVisit) {
+				t.Errorf("Expected visits %v to %v, got %v to %v", tc.expectedFirstVisit, tc.expectedLastVisit, history.FirstVisit, history.LastVisit)
+			}
+		})
+	}
+}
+
+func equalTimePtr(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+func TestServiceGetTripDaysBreakdown(t *testing.T) {
+	// Friday 2030-06-07 through Monday 2030-06-10
+	friday := time.Date(2030, 6, 7, 15, 0, 0, 0, time.UTC)
+	monday := time.Date(2030, 6, 10, 10, 0, 0, 0, time.UTC)
+	// Two full weeks, Monday to the Sunday after next
+	twoWeeksStart := time.Date(2030, 6, 3, 0, 0, 0, 0, time.UTC)
+	twoWeeksEnd := time.Date(2030, 6, 16, 23, 59, 59, 0, time.UTC)
+	saturday := time.Date(2030, 6, 8, 9, 0, 0, 0, time.UTC)
+	saturdayEvening := time.Date(2030, 6, 8, 20, 0, 0, 0, time.UTC)
+	// Leap day through the start of March
+	leapDay := time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)
+	marchFirst := time.Date(2028, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	otherUser := uuid.New()
+
+	testCases := []struct {
+		name          string
+		trip          func(userID uuid.UUID) *models.Trip
+		expected      models.TripDaysBreakdown
+		expectedError string
+	}{
+		{
+			name: "SpansWeekend",
+			trip: func(userID uuid.UUID) *models.Trip {
+				return &models.Trip{UserID: userID, StartDate: &friday, EndDate: &monday}
+			},
+			expected: models.TripDaysBreakdown{TotalDays: 4, Weekdays: 2, WeekendDays: 2},
+		},
+		{
+			name: "TwoWeeks",
+			trip: func(userID uuid.UUID) *models.Trip {
+				return &models.Trip{UserID: userID, StartDate: &twoWeeksStart, EndDate: &twoWeeksEnd}
+			},
+			expected: models.TripDaysBreakdown{TotalDays: 14, Weekdays: 10, WeekendDays: 4},
+		},
+		{
+			name: "SameDay",
+			trip: func(userID uuid.UUID) *models.Trip {
+				return &models.Trip{UserID: userID, StartDate: &saturday, EndDate: &saturdayEvening}
+			},
+			expected: models.TripDaysBreakdown{TotalDays: 1, WeekendDays: 1},
+		},
+		{
+			name: "AcrossLeapDay",
+			trip: func(userID uuid.UUID) *models.Trip {
+				return &models.Trip{UserID: userID, StartDate: &leapDay, EndDate: &marchFirst}
+			},
+			expected: models.TripDaysBreakdown{TotalDays: 2, Weekdays: 2},
+		},
+		{
+			name: "WishlistWithoutDates",
+			trip: func(userID uuid.UUID) *models.Trip {
+				return &models.Trip{UserID: userID, IsWishlist: true}
+			},
+			expectedError: "trip has no dates",
+		},
+		{
+			name: "NotOwner",
+			trip: func(userID uuid.UUID) *models.Trip {
+				return &models.Trip{UserID: otherUser, StartDate: &friday, EndDate: &monday}
+			},
+			expectedError: "unauthorized access to trip",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			service, mockRepo, _ := setupServiceTest()
+			userID := uuid.New()
+			tripID := uuid.New()
+
+			mockRepo.getTripByIDFunc = func(ctx context.Context, id uuid.UUID) (*models.Trip, error) {
+				trip := tc.trip(userID)
+				trip.ID = id
+				return trip, nil
+			}
+
+			breakdown, err := service.GetTripDaysBreakdown(context.Background(), tripID, userID)
+
+			if tc.expectedError != "" {
+				if err == nil || err.Error() != tc.expectedError {
+					t.Fatalf("Expected error %q, got %v", tc.expectedError, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if *breakdown != tc.expected {
+				t.Errorf("Expected %+v, got %+v", tc.expected, *breakdown)
+			}
+		})
+	}
+}
+
+func TestServiceGetTripIncludingDeleted(t *testing.T) {
+	deletedAt := time.Now().Add(-time.Hour)
+	otherUser := uuid.New()
+
+	testCases := []struct {
+		name          string
+		liveTrip      func(tripID, userID uuid.UUID) (*models.Trip, error)
+		deletedTrip   func(tripID, userID uuid.UUID) (*models.Trip, error)
+		expectDeleted bool
+		expectedError string
+	}{
+		{
+			name: "LiveTrip",
+			liveTrip: func(tripID, userID uuid.UUID) (*models.Trip, error) {
+				return &models.Trip{ID: tripID, UserID: userID}, nil
+			},
+		},
+		{
+			name: "OwnDeletedTrip",
+			liveTrip: func(tripID, userID uuid.UUID) (*models.Trip, error) {
+				return nil, errors.New("trip not found")
+			},
+			deletedTrip: func(tripID, userID uuid.UUID) (*models.Trip, error) {
+				return &models.Trip{ID: tripID, UserID: userID, DeletedAt: &deletedAt}, nil
+			},
+			expectDeleted: true,
+		},
+		{
+			name: "SomeoneElsesDeletedTrip",
+			liveTrip: func(tripID, userID uuid.UUID) (*models.Trip, error) {
+				return nil, errors.New("trip not found")
+			},
+			deletedTrip: func(tripID, userID uuid.UUID) (*models.Trip, error) {
+				return &models.Trip{ID: tripID, UserID: otherUser, DeletedAt: &deletedAt}, nil
+			},
+			expectedError: "trip not found",
+		},
+		{
+			name: "NotFoundAnywhere",
+			liveTrip: func(tripID, userID uuid.UUID) (*models.Trip, error) {
+				return nil, errors.New("trip not found")
+			},
+			deletedTrip: func(tripID, userID uuid.UUID) (*models.Trip, error) {
+				return nil, errors.New("trip not found")
+			},
+			expectedError: "trip not found",
+		},
+		{
+			name: "SomeoneElsesLiveTrip",
+			liveTrip: func(tripID, userID uuid.UUID) (*models.Trip, error) {
+				return &models.Trip{ID: tripID, UserID: otherUser}, nil
+			},
+			expectedError: "unauthorized access to trip",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			service, mockRepo, _ := setupServiceTest()
+			userID := uuid.New()
+			tripID := uuid.New()
+
+			mockRepo.getTripByIDFunc = func(ctx context.Context, id uuid.UUID) (*models.Trip, error) {
+				return tc.liveTrip(id, userID)
+			}
+			if tc.deletedTrip != nil {
+				mockRepo.getDeletedTripFunc = func(ctx context.Context, id uuid.UUID) (*models.Trip, error) {
+					return tc.deletedTrip(id, userID)
+				}
+			}
+
+			trip, err := service.GetTripIncludingDeleted(context.Background(), tripID, userID)
+
+			if tc.expectedError != "" {
+				if err == nil || err.Error() != tc.expectedError {
+					t.Fatalf("Expected error %q, got %v", tc.expectedError, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if trip.ID != tripID {
+				t.Errorf("Expected trip %s, got %s", tripID, trip.ID)
+			}
+			if (trip.DeletedAt != nil) != tc.expectDeleted {
+				t.Errorf("Expected deleted=%v, got deleted_at %v", tc.expectDeleted, trip.DeletedAt)
+			}
+		})
+	}
+}
+
+func TestServiceCreateTripEchoesClientID(t *testing.T) {
+	start := time.Now().Add(24 * time.Hour)
+
+	testCases := []struct {
+		name     string
+		clientID string
+	}{
+		{name: "WithClientID", clientID: "tmp-3f9c2a"},
+		{name: "WithoutClientID", clientID: ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			service, mockRepo, _ := setupServiceTest()
+			userID := uuid.New()
+
+			mockRepo.createTripFunc = func(ctx context.Context, uid uuid.UUID, input models.CreateTripInput) (*models.Trip, error) {
+				return &models.Trip{ID: uuid.New(), UserID: uid, Name: input.Name, Location: input.Location}, nil
+			}
+
+			trip, err := service.CreateTrip(context.Background(), userID, models.CreateTripInput{
+				StartDate: start,
+				EndDate:   start.Add(48 * time.Hour),
+				Location:  "Lisbon",
+				ClientID:  tc.clientID,
+			})
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			if trip.ClientID != tc.clientID {
+				t.Errorf("Expected client_id %q, got %q", tc.clientID, trip.ClientID)
+			}
+
+			body, _ := json.Marshal(trip)
+			if hasKey := strings.Contains(string(body), `"client_id"`); hasKey != (tc.clientID != "") {
+				t.Errorf("Expected client_id in JSON=%v, got %s", tc.clientID != "", body)
+			}
+		})
+	}
+}
+
+func TestServiceShareTrip(t *testing.T) {
+	otherUser := uuid.New()
+
+	testCases := []struct {
+		name          string
+		owner         func(userID uuid.UUID) uuid.UUID
+		expectedError string
+	}{
+		{name: "Owner", owner: func(userID uuid.UUID) uuid.UUID { return userID }},
+		{name: "NotOwner", owner: func(uuid.UUID) uuid.UUID { return otherUser }, expectedError: "unauthorized access to trip"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			service, mockRepo, _ := setupServiceTest()
+			userID := uuid.New()
+			tripID := uuid.New()
+
+			mockRepo.getTripByIDFunc = func(ctx context.Context, id uuid.UUID) (*models.Trip, error) {
+				return &models.Trip{ID: id, UserID: tc.owner(userID)}, nil
+			}
+
+			shared, unshared := false, false
+			mockRepo.shareTripFunc = func(ctx context.Context, id uuid.UUID) (*models.TripShare, error) {
+				shared = true
+				return &models.TripShare{TripID: id, Visibility: models.TripVisibilityUnlisted, ShareToken: "token"}, nil
+			}
+			mockRepo.unshareTripFunc = func(ctx context.Context, id uuid.UUID) error {
+				unshared = true
+				return nil
+			}
+
+			share, err := service.ShareTrip(context.Background(), tripID, userID)
+			unshareErr := service.UnshareTrip(context.Background(), tripID, userID)
+
+			if tc.expectedError != "" {
+				if err == nil || err.Error() != tc.expectedError || unshareErr == nil || unshareErr.Error() != tc.expectedError {
+					t.Fatalf("Expected error %q, got %v and %v", tc.expectedError, err, unshareErr)
+				}
+				if shared || unshared {
+					t.Error("Expected someone else's trip to be left alone")
+				}
+				return
+			}
+
+			if err != nil || unshareErr != nil {
+				t.Fatalf("Expected no error, got %v and %v", err, unshareErr)
+			}
+			if share.TripID != tripID || share.ShareToken == "" {
+				t.Errorf("Expected a share token for trip %s, got %+v", tripID, share)
+			}
+			if !unshared {
+				t.Error("Expected the trip to be made private")
+			}
+		})
+	}
+}
+
+func TestServiceGetSharedTrip(t *testing.T) {
+	start := time.Now().Add(24 * time.Hour)
+	end := start.Add(72 * time.Hour)
+
+	t.Run("ReturnsPublicView", func(t *testing.T) {
+		service, mockRepo, _ := setupServiceTest()
+
+		mockRepo.getTripByShareFunc = func(ctx context.Context, token string) (*models.Trip, error) {
+			if token != "share-token" {
+				return nil, errors.New("trip not found")
+			}
+			return &models.Trip{
+				ID:        uuid.New(),
+				UserID:    uuid.New(),
+				Name:      "Lisbon",
+				StartDate: &start,
+				EndDate:   &end,
+				Location:  "Lisbon",
+				Tags:      []*models.Tag{{ID: uuid.New(), UserID: uuid.New(), Name: "beach"}},
+				User:      &models.User{Name: "Owner", Email: "owner@example.com"},
+			}, nil
+		}
+
+		trip, err := service.GetSharedTrip(context.Background(), "share-token")
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+
+		if trip.Name != "Lisbon" || trip.Status != models.TripStatusUpcoming {
+			t.Errorf("Expected upcoming trip Lisbon, got %+v", trip)
+		}
+		if !slices.Equal(trip.Tags, []string{"beach"}) {
+			t.Errorf("Expected tags [beach], got %v", trip.Tags)
+		}
+
+		body, _ := json.Marshal(trip)
+		for _, leak := range []string{"owner@example.com", "user_id", `"id"`} {
+			if strings.Contains(string(body), leak) {
+				t.Errorf("Expected public trip not to contain %s, got %s", leak, body)
+			}
+		}
+	})
+
+	t.Run("UnknownToken", func(t *testing.T) {
+		service, mockRepo, _ := setupServiceTest()
+		mockRepo.getTripByShareFunc = func(ctx context.Context, token string) (*models.Trip, error) {
+			return nil, errors.New("trip not found")
+		}
+
+		if _, err := service.GetSharedTrip(context.Background(), "unknown"); err == nil || err.Error() != "trip not found" {
+			t.Errorf("Expected 'trip not found', got %v", err)
+		}
+	})
+
+	t.Run("EmptyToken", func(t *testing.T) {
+		service, _, _ := setupServiceTest()
+
+		if _, err := service.GetSharedTrip(context.Background(), ""); err == nil || err.Error() != "trip not found" {
+			t.Errorf("Expected 'trip not found', got %v", err)
+		}
+	})
+}
+
+func TestServiceGetTripPage(t *testing.T) {
+	userID := uuid.New()
+	base := time.Date(2030, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	// Five trips, newest first, two created in the same instant to exercise the id tiebreak
+	var stored []*models.Trip
+	for i := 0; i < 5; i++ {
+		createdAt := base.Add(-time.Duration(i) * time.Hour)
+		if i == 2 {
+			createdAt = stored[1].CreatedAt
+		}
+		stored = append(stored, &models.Trip{ID: uuid.New(), UserID: userID, CreatedAt: createdAt})
+	}
+	slices.SortFunc(stored, func(a, b *models.Trip) int {
+		if c := b.CreatedAt.Compare(a.CreatedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(b.ID.String(), a.ID.String())
+	})
+
+	setup := func(t *testing.T) trips.ServiceInterface {
+		service, mockRepo, mockViewService := setupServiceTest()
+		mockViewService.getUserProfileFunc = func(ctx context.Context, id uuid.UUID) (*models.User, error) {
+			return &models.User{ID: id}, nil
+		}
+
+		// Keyset the stored trips the way the repository query does
+		mockRepo.getTripsByUserIDFunc = func(ctx context.Context, id uuid.UUID, limit, offset int, filter models.TripFilter) ([]*models.Trip, error) {
+			if filter.Cursor == nil {
+				t.Fatal("Expected a cursor in the filter")
+			}
+			var page []*models.Trip
+			for _, trip := range stored {
+				if !filter.Cursor.CreatedAt.IsZero() {
+					c := trip.CreatedAt.Compare(filter.Cursor.CreatedAt)
+					if c > 0 || (c == 0 && strings.Compare(trip.ID.String(), filter.Cursor.ID.String()) >= 0) {
+						continue
+					}
+				}
+				if len(page) < limit {
+					page = append(page, trip)
+				}
+			}
+			return page, nil
+		}
+		return service
+	}
+
+	t.Run("WalksEveryTripOnce", func(t *testing.T) {
+		service := setup(t)
+
+		var seen []uuid.UUID
+		cursor := ""
+		for pages := 0; pages < 10; pages++ {
+			page, err := service.GetTripPage(context.Background(), userID, 2, cursor, models.TripFilter{})
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			for _, trip := range page.Trips {
+				seen = append(seen, trip.ID)
+			}
+			if page.NextCursor == nil {
+				break
+			}
+			cursor = *page.NextCursor
+		}
+
+		if len(seen) != len(stored) {
+			t.Fatalf("Expected %d trips, got %d", len(stored), len(seen))
+		}
+		for i, trip := range stored {
+			if seen[i] != trip.ID {
+				t.Errorf("Position %d: expected trip %s, got %s", i, trip.ID, seen[i])
+			}
+		}
+	})
+
+	t.Run("LastPageHasNoCursor", func(t *testing.T) {
+		service := setup(t)
+
+		page, err := service.GetTripPage(context.Background(), userID, 5, "", models.TripFilter{})
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if len(page.Trips) != 5 || page.NextCursor != nil {
+			t.Errorf("Expected all 5 trips and no next cursor, got %d and %v", len(page.Trips), page.NextCursor)
+		}
+	})
+
+	t.Run("InvalidCursor", func(t *testing.T) {
+		service := setup(t)
+
+		if _, err := service.GetTripPage(context.Background(), userID, 2, "not a cursor", models.TripFilter{}); err == nil || err.Error() != "invalid cursor" {
+			t.Errorf("Expected 'invalid cursor', got %v", err)
+		}
+	})
+
+	t.Run("CannotBeSorted", func(t *testing.T) {
+		service := setup(t)
+
+		_, err := service.GetTripPage(context.Background(), userID, 2, "", models.TripFilter{Sort: "name:asc"})
+		if err == nil || err.Error() != "cursor pagination cannot be sorted" {
+			t.Errorf("Expected 'cursor pagination cannot be sorted', got %v", err)
+		}
+	})
+}
+
+func TestServiceCoverImageFallback(t *testing.T) {
+	testCases := []struct {
+		name             string
+		storedCover      string
+		expectedCover    string
+		expectedFallback bool
+	}{
+		{name: "NoCoverGetsFallback", expectedCover: "https://covers.test/Lisbon", expectedFallback: true},
+		{name: "OwnCoverKept", storedCover: "https://example.com/tram.jpg", expectedCover: "https://example.com/tram.jpg"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			service, mockRepo, _ := setupServiceTest()
+			userID := uuid.New()
+			mockRepo.getTripByIDFunc = func(ctx context.Context, tripID uuid.UUID) (*models.Trip, error) {
+				return &models.Trip{ID: tripID, UserID: userID, Location: "Lisbon", CoverImageURL: tc.storedCover}, nil
+			}
+			mockRepo.getActivitiesFunc = func(ctx context.Context, tripID uuid.UUID) ([]*models.Activity, error) {
+				return nil, nil
+			}
+			mockRepo.getTripExpensesFunc = func(ctx context.Context, tripID uuid.UUID) ([]*models.Expense, error) {
+				return nil, nil
+			}
+			mockRepo.getTripTagsFunc = func(ctx context.Context, tripID uuid.UUID) ([]*models.Tag, error) {
+				return nil, nil
+			}
+
+			// Execute
+			trip, err := service.GetTripByID(context.Background(), uuid.New(), userID)
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			export, err := service.ExportTrip(context.Background(), uuid.New(), userID)
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			// Verify
+			if trip.CoverImageURL != tc.expectedCover || trip.CoverImageFallback != tc.expectedFallback {
+				t.Errorf("Expected cover %q (fallback %v), got %q (fallback %v)",
+					tc.expectedCover, tc.expectedFallback, trip.CoverImageURL, trip.CoverImageFallback)
+			}
+			if export.Trip.CoverImageURL != tc.storedCover {
+				t.Errorf("Expected export to carry only the user's cover %q, got %q", tc.storedCover, export.Trip.CoverImageURL)
+			}
+		})
+	}
+}
+
+func TestPlaceholderCoverSource(t *testing.T) {
+	source := trips.PlaceholderCoverSource{URLTemplate: trips.DefaultCoverURLTemplate}
+
+	if url := source.CoverURL("New York"); url != "https://picsum.photos/seed/new-york/1200/600" {
+		t.Errorf("Expected a cover seeded by new-york, got %q", url)
+	}
+	if source.CoverURL(" new  YORK ") != source.CoverURL("New York") {
+		t.Error("Expected the same location to give the same cover regardless of case and spacing")
+	}
+	if source.CoverURL("São Paulo") == "" || source.CoverURL("") == "" {
+		t.Error("Expected every location to get a cover")
+	}
+
+	t.Run("FromEnv", func(t *testing.T) {
+		t.Setenv("TRIP_COVER_URL_TEMPLATE", "https://img.example.com/{seed}.jpg")
+		if url := trips.CoverSourceFromEnv().CoverURL("Kyoto"); url != "https://img.example.com/kyoto.jpg" {
+			t.Errorf("Expected the configured template, got %q", url)
+		}
+
+		t.Setenv("TRIP_COVER_URL_TEMPLATE", "https://img.example.com/fixed.jpg")
+		if template := trips.CoverSourceFromEnv().URLTemplate; template != trips.DefaultCoverURLTemplate {
+			t.Errorf("Expected a template without {seed} to fall back to the default, got %q", template)
+		}
+	})
 }
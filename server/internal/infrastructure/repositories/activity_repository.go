@@ -0,0 +1,209 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"black-lotus/internal/domain/models"
+)
+
+type ActivityRepository struct {
+	db *pgxpool.Pool
+}
+
+/*
+IMPLEMENTED FOR TESTING PURPOSES
+*/
+type ActivityRepositoryInterface interface {
+	CreateActivity(ctx context.Context, tripID uuid.UUID, input models.CreateActivityInput) (*models.Activity, error)
+	GetActivityByID(ctx context.Context, activityID uuid.UUID) (*models.Activity, error)
+	GetActivitiesByTripID(ctx context.Context, tripID uuid.UUID) ([]*models.Activity, error)
+	UpdateActivity(ctx context.Context, activityID uuid.UUID, input models.UpdateActivityInput) (*models.Activity, error)
+	DeleteActivity(ctx context.Context, activityID uuid.UUID) error
+}
+
+func NewActivityRepository(db *pgxpool.Pool) *ActivityRepository {
+	return &ActivityRepository{db: db}
+}
+
+// CreateActivity adds an activity to a trip
+func (r *ActivityRepository) CreateActivity(ctx context.Context, tripID uuid.UUID, input models.CreateActivityInput) (*models.Activity, error) {
+	activity := new(models.Activity)
+
+	err := r.db.QueryRow(ctx, `
+		INSERT INTO activities (trip_id, title, description, start_time, end_time, location)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, trip_id, title, description, start_time, end_time, location, created_at, updated_at
+	`,
+		tripID,
+		input.Title,
+		input.Description,
+		input.StartTime,
+		input.EndTime,
+		input.Location).Scan(
+		&activity.ID,
+		&activity.TripID,
+		&activity.Title,
+		&activity.Description,
+		&activity.StartTime,
+		&activity.EndTime,
+		&activity.Location,
+		&activity.CreatedAt,
+		&activity.UpdatedAt,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return activity, nil
+}
+
+// GetActivityByID returns a specific activity based on ID
+func (r *ActivityRepository) GetActivityByID(ctx context.Context, activityID uuid.UUID) (*models.Activity, error) {
+	activity := new(models.Activity)
+
+	err := r.db.QueryRow(ctx, `
+		SELECT id, trip_id, title, description, start_time, end_time, location, created_at, updated_at
+		FROM activities
+		WHERE id = $1
+	`, activityID).Scan(
+		&activity.ID,
+		&activity.TripID,
+		&activity.Title,
+		&activity.Description,
+		&activity.StartTime,
+		&activity.EndTime,
+		&activity.Location,
+		&activity.CreatedAt,
+		&activity.UpdatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("activity not found")
+		}
+		return nil, err
+	}
+
+	return activity, nil
+}
+
+// GetActivitiesByTripID returns all activities for a trip ordered by start time
+func (r *ActivityRepository) GetActivitiesByTripID(ctx context.Context, tripID uuid.UUID) ([]*models.Activity, error) {
+	return queryActivitiesByTripID(ctx, r.db, tripID)
+}
+
+// UpdateActivity updates an existing activity
+func (r *ActivityRepository) UpdateActivity(ctx context.Context, activityID uuid.UUID, input models.UpdateActivityInput) (*models.Activity, error) {
+	activity := new(models.Activity)
+
+	err := r.db.QueryRow(ctx, `
+		UPDATE activities
+		SET
+		title = COALESCE($1, title),
+		description = COALESCE($2, description),
+		start_time = COALESCE($3, start_time),
+		end_time = COALESCE($4, end_time),
+		location = COALESCE($5, location),
+		updated_at = NOW()
+		WHERE id = $6
+		RETURNING id, trip_id, title, description, start_time, end_time, location, created_at, updated_at
+	`,
+		input.Title,
+		input.Description,
+		input.StartTime,
+		input.EndTime,
+		input.Location,
+		activityID).Scan(
+		&activity.ID,
+		&activity.TripID,
+		&activity.Title,
+		&activity.Description,
+		&activity.StartTime,
+		&activity.EndTime,
+		&activity.Location,
+		&activity.CreatedAt,
+		&activity.UpdatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("activity not found")
+		}
+		return nil, err
+	}
+
+	return activity, nil
+}
+
+// DeleteActivity removes an activity
+func (r *ActivityRepository) DeleteActivity(ctx context.Context, activityID uuid.UUID) error {
+	commandTag, err := r.db.Exec(ctx, `
+		DELETE FROM activities WHERE id = $1
+	`, activityID)
+
+	if err != nil {
+		return err
+	}
+
+	if commandTag.RowsAffected() == 0 {
+		return errors.New("activity not found")
+	}
+
+	return nil
+}
+
+// activityQuerier is satisfied by both the pool and a transaction
+type activityQuerier interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+}
+
+// queryActivitiesByTripID is shared with the trip repository for exports
+func queryActivitiesByTripID(ctx context.Context, q activityQuerier, tripID uuid.UUID) ([]*models.Activity, error) {
+	rows, err := q.Query(ctx, `
+		SELECT id, trip_id, title, description, start_time, end_time, location, created_at, updated_at
+		FROM activities
+		WHERE trip_id = $1
+		ORDER BY start_time ASC
+	`, tripID)
+
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	activities := []*models.Activity{}
+
+	for rows.Next() {
+		activity := new(models.Activity)
+
+		err := rows.Scan(
+			&activity.ID,
+			&activity.TripID,
+			&activity.Title,
+			&activity.Description,
+			&activity.StartTime,
+			&activity.EndTime,
+			&activity.Location,
+			&activity.CreatedAt,
+			&activity.UpdatedAt,
+		)
+
+		if err != nil {
+			return nil, err
+		}
+
+		activities = append(activities, activity)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return activities, nil
+}
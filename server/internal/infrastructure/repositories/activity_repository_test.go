@@ -0,0 +1,79 @@
+package repositories_test
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"black-lotus/internal/domain/models"
+	"black-lotus/internal/infrastructure/repositories"
+	"black-lotus/pkg/db"
+)
+
+// These tests run against a real Postgres and are skipped unless TEST_DB_HOST is set
+func setupRepositoryTest(t *testing.T) {
+	t.Helper()
+	if os.Getenv("TEST_DB_HOST") == "" {
+		t.Skip("TEST_DB_HOST not set; skipping database test")
+	}
+
+	if err := db.InitializeTestDB(); err != nil {
+		t.Fatalf("Failed to initialize test database: %v", err)
+	}
+	t.Cleanup(db.CloseTestDB)
+}
+
+func TestActivityRepositoryCreateTouchesTrip(t *testing.T) {
+	setupRepositoryTest(t)
+	ctx := context.Background()
+
+	// Setup: a trip whose updated_at is well in the past
+	stale := time.Now().Add(-24 * time.Hour).UTC()
+
+	var userID, tripID uuid.UUID
+	err := db.TestDB.QueryRow(ctx, `
+		INSERT INTO users (name, email) VALUES ('Test User', 'touch@example.com') RETURNING id
+	`).Scan(&userID)
+	if err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+
+	err = db.TestDB.QueryRow(ctx, `
+		INSERT INTO trips (user_id, name, location, is_wishlist, updated_at)
+		VALUES ($1, 'Test Trip', 'Lisbon', TRUE, $2)
+		RETURNING id
+	`, userID, stale).Scan(&tripID)
+	if err != nil {
+		t.Fatalf("Failed to create trip: %v", err)
+	}
+
+	trips := repositories.NewTripRepository(db.TestDB)
+	trip, err := trips.GetTripByID(ctx, tripID)
+	if err != nil {
+		t.Fatalf("Failed to load trip: %v", err)
+	}
+
+	// Execute
+	activities := repositories.NewActivityRepository(db.TestDB)
+	start := time.Now().Add(48 * time.Hour)
+	_, err = activities.CreateActivity(ctx, trip.ID, models.CreateActivityInput{
+		Title:     "Tram 28",
+		StartTime: start,
+		EndTime:   start.Add(time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("Failed to create activity: %v", err)
+	}
+
+	// Verify
+	touched, err := trips.GetTripByID(ctx, trip.ID)
+	if err != nil {
+		t.Fatalf("Failed to reload trip: %v", err)
+	}
+	if !touched.UpdatedAt.After(trip.UpdatedAt) {
+		t.Errorf("Expected trip updated_at to move past %v, got %v", trip.UpdatedAt, touched.UpdatedAt)
+	}
+}
@@ -0,0 +1,153 @@
+package repositories
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"black-lotus/internal/domain/models"
+	"black-lotus/internal/features/auth/apikeys"
+)
+
+// apiKeyPrefixLength is how much of a key is kept in plain text so users can
+// tell their keys apart: "bl_" plus the first 8 random characters
+const apiKeyPrefixLength = len(apikeys.KeyPrefix) + 8
+
+// APIKeyRepository handles database operations for API keys
+type APIKeyRepository struct {
+	db *pgxpool.Pool // Database connection pool
+}
+
+// Compile-time interface checks
+var (
+	_ apikeys.Repository = (*APIKeyRepository)(nil)
+)
+
+// NewAPIKeyRepository creates a new repository with the given database connection
+func NewAPIKeyRepository(db *pgxpool.Pool) *APIKeyRepository {
+	return &APIKeyRepository{db: db}
+}
+
+// hashAPIKey returns the hex SHA-256 hash a key is stored and looked up by
+func hashAPIKey(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(hash[:])
+}
+
+// CreateAPIKey generates a new key for the user and stores its hash
+func (r *APIKeyRepository) CreateAPIKey(
+	ctx context.Context,
+	userID uuid.UUID,
+	name string,
+	expiresAt *time.Time,
+) (*models.CreatedAPIKey, error) {
+	keyBytes := make([]byte, 32)
+	if _, err := rand.Read(keyBytes); err != nil {
+		return nil, fmt.Errorf("failed to generate api key: %w", err)
+	}
+	// URL-safe encoding so the key can go in a header or shell variable as-is
+	key := apikeys.KeyPrefix + base64.RawURLEncoding.EncodeToString(keyBytes)
+
+	created := &models.CreatedAPIKey{Key: key}
+	err := r.db.QueryRow(ctx, `
+        INSERT INTO api_keys (user_id, name, key_hash, prefix, expires_at)
+        VALUES ($1, $2, $3, $4, $5)
+        RETURNING id, user_id, name, prefix, expires_at, last_used_at, created_at
+    `, userID, name, hashAPIKey(key), key[:apiKeyPrefixLength], expiresAt).Scan(
+		&created.ID,
+		&created.UserID,
+		&created.Name,
+		&created.Prefix,
+		&created.ExpiresAt,
+		&created.LastUsedAt,
+		&created.CreatedAt,
+	)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert api key: %w", err)
+	}
+
+	return created, nil
+}
+
+// GetAPIKeysByUserID returns all of a user's keys, newest first
+func (r *APIKeyRepository) GetAPIKeysByUserID(ctx context.Context, userID uuid.UUID) ([]*models.APIKey, error) {
+	rows, err := r.db.Query(ctx, `
+        SELECT id, user_id, name, prefix, expires_at, last_used_at, created_at
+        FROM api_keys
+        WHERE user_id = $1
+        ORDER BY created_at DESC, id DESC
+    `, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (*models.APIKey, error) {
+		key := new(models.APIKey)
+		err := row.Scan(
+			&key.ID,
+			&key.UserID,
+			&key.Name,
+			&key.Prefix,
+			&key.ExpiresAt,
+			&key.LastUsedAt,
+			&key.CreatedAt,
+		)
+		return key, err
+	})
+}
+
+// DeleteAPIKey removes one of the user's keys
+func (r *APIKeyRepository) DeleteAPIKey(ctx context.Context, keyID uuid.UUID, userID uuid.UUID) error {
+	result, err := r.db.Exec(ctx, `
+        DELETE FROM api_keys
+        WHERE id = $1 AND user_id = $2
+    `, keyID, userID)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return errors.New("api key not found")
+	}
+
+	return nil
+}
+
+// UseAPIKey looks up an unexpired key by its raw value and records that it was used
+func (r *APIKeyRepository) UseAPIKey(ctx context.Context, key string) (*models.APIKey, error) {
+	apiKey := new(models.APIKey)
+
+	err := r.db.QueryRow(ctx, `
+        UPDATE api_keys
+        SET last_used_at = NOW()
+        WHERE key_hash = $1 AND (expires_at IS NULL OR expires_at > NOW())
+        RETURNING id, user_id, name, prefix, expires_at, last_used_at, created_at
+    `, hashAPIKey(key)).Scan(
+		&apiKey.ID,
+		&apiKey.UserID,
+		&apiKey.Name,
+		&apiKey.Prefix,
+		&apiKey.ExpiresAt,
+		&apiKey.LastUsedAt,
+		&apiKey.CreatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("api key not found")
+		}
+		return nil, err
+	}
+
+	return apiKey, nil
+}
@@ -0,0 +1,96 @@
+package repositories_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"black-lotus/internal/infrastructure/repositories"
+	"black-lotus/pkg/db"
+)
+
+func TestAPIKeyRepository(t *testing.T) {
+	setupRepositoryTest(t)
+	ctx := context.Background()
+
+	var userID uuid.UUID
+	err := db.TestDB.QueryRow(ctx, `
+		INSERT INTO users (name, email) VALUES ('Test User', 'apikeys@example.com') RETURNING id
+	`).Scan(&userID)
+	if err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+
+	keys := repositories.NewAPIKeyRepository(db.TestDB)
+
+	t.Run("StoresOnlyTheHash", func(t *testing.T) {
+		created, err := keys.CreateAPIKey(ctx, userID, "CI", nil)
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if !strings.HasPrefix(created.Key, created.Prefix) {
+			t.Errorf("Expected key %q to start with prefix %q", created.Key, created.Prefix)
+		}
+
+		var storedHash string
+		if err := db.TestDB.QueryRow(ctx, `SELECT key_hash FROM api_keys WHERE id = $1`, created.ID).Scan(&storedHash); err != nil {
+			t.Fatalf("Failed to read key: %v", err)
+		}
+		hash := sha256.Sum256([]byte(created.Key))
+		if storedHash != hex.EncodeToString(hash[:]) {
+			t.Error("Expected the SHA-256 hash of the key to be stored")
+		}
+	})
+
+	t.Run("UseRecordsLastUsed", func(t *testing.T) {
+		created, err := keys.CreateAPIKey(ctx, userID, "Script", nil)
+		if err != nil {
+			t.Fatalf("Failed to create key: %v", err)
+		}
+
+		used, err := keys.UseAPIKey(ctx, created.Key)
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if used.ID != created.ID || used.LastUsedAt == nil {
+			t.Errorf("Expected key %s with last_used_at set, got %+v", created.ID, used)
+		}
+	})
+
+	t.Run("ExpiredKeyRejected", func(t *testing.T) {
+		soon := time.Now().Add(time.Hour)
+		created, err := keys.CreateAPIKey(ctx, userID, "Expiring", &soon)
+		if err != nil {
+			t.Fatalf("Failed to create key: %v", err)
+		}
+		if _, err := db.TestDB.Exec(ctx, `UPDATE api_keys SET expires_at = NOW() - INTERVAL '1 minute' WHERE id = $1`, created.ID); err != nil {
+			t.Fatalf("Failed to expire key: %v", err)
+		}
+
+		if _, err := keys.UseAPIKey(ctx, created.Key); err == nil || err.Error() != "api key not found" {
+			t.Errorf("Expected 'api key not found', got: %v", err)
+		}
+	})
+
+	t.Run("DeleteOnlyOwnKeys", func(t *testing.T) {
+		created, err := keys.CreateAPIKey(ctx, userID, "Revoked", nil)
+		if err != nil {
+			t.Fatalf("Failed to create key: %v", err)
+		}
+
+		if err := keys.DeleteAPIKey(ctx, created.ID, uuid.New()); err == nil || err.Error() != "api key not found" {
+			t.Errorf("Expected 'api key not found' for another user, got: %v", err)
+		}
+		if err := keys.DeleteAPIKey(ctx, created.ID, userID); err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if _, err := keys.UseAPIKey(ctx, created.Key); err == nil {
+			t.Error("Expected a revoked key to be rejected")
+		}
+	})
+}
@@ -0,0 +1,246 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"black-lotus/internal/domain/models"
+)
+
+type ExpenseRepository struct {
+	db *pgxpool.Pool
+}
+
+/*
+IMPLEMENTED FOR TESTING PURPOSES
+*/
+type ExpenseRepositoryInterface interface {
+	CreateExpense(ctx context.Context, tripID uuid.UUID, input models.CreateExpenseInput) (*models.Expense, error)
+	GetExpenseByID(ctx context.Context, expenseID uuid.UUID) (*models.Expense, error)
+	GetExpensesByTripID(ctx context.Context, tripID uuid.UUID) ([]*models.Expense, error)
+	DeleteExpense(ctx context.Context, expenseID uuid.UUID) error
+	GetBudgetTotals(ctx context.Context, tripID uuid.UUID) ([]*models.BudgetTotal, error)
+	GetExpenseTimeline(ctx context.Context, tripID uuid.UUID, granularity string) ([]*models.ExpenseTimelineBucket, error)
+}
+
+func NewExpenseRepository(db *pgxpool.Pool) *ExpenseRepository {
+	return &ExpenseRepository{db: db}
+}
+
+// CreateExpense records an expense against a trip
+func (r *ExpenseRepository) CreateExpense(ctx context.Context, tripID uuid.UUID, input models.CreateExpenseInput) (*models.Expense, error) {
+	expense := new(models.Expense)
+
+	err := r.db.QueryRow(ctx, `
+		INSERT INTO expenses (trip_id, amount_cents, currency, category, note, paid_by, split_among, incurred_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id, trip_id, amount_cents, currency, category, note, paid_by, split_among, incurred_at, created_at
+	`,
+		tripID,
+		input.AmountCents,
+		input.Currency,
+		input.Category,
+		input.Note,
+		input.PaidBy,
+		splitAmong(input.SplitAmong),
+		input.IncurredAt).Scan(
+		&expense.ID,
+		&expense.TripID,
+		&expense.AmountCents,
+		&expense.Currency,
+		&expense.Category,
+		&expense.Note,
+		&expense.PaidBy,
+		&expense.SplitAmong,
+		&expense.IncurredAt,
+		&expense.CreatedAt,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return expense, nil
+}
+
+// GetExpenseByID returns a specific expense based on ID
+func (r *ExpenseRepository) GetExpenseByID(ctx context.Context, expenseID uuid.UUID) (*models.Expense, error) {
+	expense := new(models.Expense)
+
+	err := r.db.QueryRow(ctx, `
+		SELECT id, trip_id, amount_cents, currency, category, note, paid_by, split_among, incurred_at, created_at
+		FROM expenses
+		WHERE id = $1
+	`, expenseID).Scan(
+		&expense.ID,
+		&expense.TripID,
+		&expense.AmountCents,
+		&expense.Currency,
+		&expense.Category,
+		&expense.Note,
+		&expense.PaidBy,
+		&expense.SplitAmong,
+		&expense.IncurredAt,
+		&expense.CreatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("expense not found")
+		}
+		return nil, err
+	}
+
+	return expense, nil
+}
+
+// GetExpensesByTripID returns all expenses for a trip, most recent first
+func (r *ExpenseRepository) GetExpensesByTripID(ctx context.Context, tripID uuid.UUID) ([]*models.Expense, error) {
+	return queryExpensesByTripID(ctx, r.db, tripID)
+}
+
+// DeleteExpense removes an expense
+func (r *ExpenseRepository) DeleteExpense(ctx context.Context, expenseID uuid.UUID) error {
+	commandTag, err := r.db.Exec(ctx, `
+		DELETE FROM expenses WHERE id = $1
+	`, expenseID)
+
+	if err != nil {
+		return err
+	}
+
+	if commandTag.RowsAffected() == 0 {
+		return errors.New("expense not found")
+	}
+
+	return nil
+}
+
+// GetBudgetTotals sums a trip's expenses per category and currency
+func (r *ExpenseRepository) GetBudgetTotals(ctx context.Context, tripID uuid.UUID) ([]*models.BudgetTotal, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT category, currency, SUM(amount_cents)
+		FROM expenses
+		WHERE trip_id = $1
+		GROUP BY category, currency
+		ORDER BY category, currency
+	`, tripID)
+
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	totals := []*models.BudgetTotal{}
+
+	for rows.Next() {
+		total := new(models.BudgetTotal)
+
+		if err := rows.Scan(&total.Category, &total.Currency, &total.AmountCents); err != nil {
+			return nil, err
+		}
+
+		totals = append(totals, total)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return totals, nil
+}
+
+// GetExpenseTimeline sums a trip's expenses per currency and period. granularity
+// is a date_trunc field ("day" or "week"); periods are truncated in UTC.
+func (r *ExpenseRepository) GetExpenseTimeline(ctx context.Context, tripID uuid.UUID, granularity string) ([]*models.ExpenseTimelineBucket, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT date_trunc($2, incurred_at AT TIME ZONE 'UTC') AT TIME ZONE 'UTC' AS period_start,
+			currency, SUM(amount_cents)
+		FROM expenses
+		WHERE trip_id = $1
+		GROUP BY period_start, currency
+		ORDER BY period_start, currency
+	`, tripID, granularity)
+
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	buckets := []*models.ExpenseTimelineBucket{}
+
+	for rows.Next() {
+		bucket := new(models.ExpenseTimelineBucket)
+
+		if err := rows.Scan(&bucket.PeriodStart, &bucket.Currency, &bucket.AmountCents); err != nil {
+			return nil, err
+		}
+
+		bucket.PeriodStart = bucket.PeriodStart.UTC()
+		buckets = append(buckets, bucket)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return buckets, nil
+}
+
+// splitAmong stores a missing list as an empty array, never NULL
+func splitAmong(names []string) []string {
+	if names == nil {
+		return []string{}
+	}
+	return names
+}
+
+// queryExpensesByTripID is shared with the trip repository for trip exports
+func queryExpensesByTripID(ctx context.Context, db *pgxpool.Pool, tripID uuid.UUID) ([]*models.Expense, error) {
+	rows, err := db.Query(ctx, `
+		SELECT id, trip_id, amount_cents, currency, category, note, paid_by, split_among, incurred_at, created_at
+		FROM expenses
+		WHERE trip_id = $1
+		ORDER BY incurred_at DESC
+	`, tripID)
+
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	expenses := []*models.Expense{}
+
+	for rows.Next() {
+		expense := new(models.Expense)
+
+		err := rows.Scan(
+			&expense.ID,
+			&expense.TripID,
+			&expense.AmountCents,
+			&expense.Currency,
+			&expense.Category,
+			&expense.Note,
+			&expense.PaidBy,
+			&expense.SplitAmong,
+			&expense.IncurredAt,
+			&expense.CreatedAt,
+		)
+
+		if err != nil {
+			return nil, err
+		}
+
+		expenses = append(expenses, expense)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return expenses, nil
+}
@@ -0,0 +1,96 @@
+package repositories_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"black-lotus/internal/domain/models"
+	"black-lotus/internal/infrastructure/repositories"
+	"black-lotus/pkg/db"
+)
+
+func TestExpenseRepositoryTimeline(t *testing.T) {
+	setupRepositoryTest(t)
+	ctx := context.Background()
+
+	// Setup: a trip with two expenses on different days of the same week
+	var userID, tripID uuid.UUID
+	err := db.TestDB.QueryRow(ctx, `
+		INSERT INTO users (name, email) VALUES ('Test User', 'timeline@example.com') RETURNING id
+	`).Scan(&userID)
+	if err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+
+	err = db.TestDB.QueryRow(ctx, `
+		INSERT INTO trips (user_id, name, location, is_wishlist)
+		VALUES ($1, 'Test Trip', 'Lisbon', TRUE)
+		RETURNING id
+	`, userID).Scan(&tripID)
+	if err != nil {
+		t.Fatalf("Failed to create trip: %v", err)
+	}
+
+	expenses := repositories.NewExpenseRepository(db.TestDB)
+	tuesday := time.Date(2030, 6, 4, 0, 0, 0, 0, time.UTC)
+	wednesday := tuesday.AddDate(0, 0, 1)
+
+	for _, input := range []models.CreateExpenseInput{
+		{AmountCents: 1200, Currency: "EUR", Category: "food", IncurredAt: tuesday.Add(20 * time.Hour)},
+		{AmountCents: 800, Currency: "EUR", Category: "food", IncurredAt: wednesday.Add(9 * time.Hour)},
+	} {
+		if _, err := expenses.CreateExpense(ctx, tripID, input); err != nil {
+			t.Fatalf("Failed to create expense: %v", err)
+		}
+	}
+
+	t.Run("DailyBucketsDaysSeparately", func(t *testing.T) {
+		buckets, err := expenses.GetExpenseTimeline(ctx, tripID, models.ExpenseGranularityDay)
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+
+		if len(buckets) != 2 {
+			t.Fatalf("Expected 2 daily buckets, got %d", len(buckets))
+		}
+		if !buckets[0].PeriodStart.Equal(tuesday) || buckets[0].AmountCents != 1200 {
+			t.Errorf("Expected 1200 on %v, got %d on %v", tuesday, buckets[0].AmountCents, buckets[0].PeriodStart)
+		}
+		if !buckets[1].PeriodStart.Equal(wednesday) || buckets[1].AmountCents != 800 {
+			t.Errorf("Expected 800 on %v, got %d on %v", wednesday, buckets[1].AmountCents, buckets[1].PeriodStart)
+		}
+	})
+
+	t.Run("WeeklyBucketsTogether", func(t *testing.T) {
+		buckets, err := expenses.GetExpenseTimeline(ctx, tripID, models.ExpenseGranularityWeek)
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+
+		monday := tuesday.AddDate(0, 0, -1)
+		if len(buckets) != 1 || !buckets[0].PeriodStart.Equal(monday) || buckets[0].AmountCents != 2000 {
+			t.Errorf("Expected 2000 in the week of %v, got %+v", monday, buckets)
+		}
+	})
+
+	t.Run("StoresSplit", func(t *testing.T) {
+		created, err := expenses.CreateExpense(ctx, tripID, models.CreateExpenseInput{
+			AmountCents: 3000, Currency: "EUR", Category: "lodging",
+			PaidBy: "Alice", SplitAmong: []string{"Alice", "Bob"}, IncurredAt: tuesday,
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+
+		expense, err := expenses.GetExpenseByID(ctx, created.ID)
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if expense.PaidBy != "Alice" || len(expense.SplitAmong) != 2 || expense.SplitAmong[1] != "Bob" {
+			t.Errorf("Expected Alice to pay for [Alice Bob], got %q for %v", expense.PaidBy, expense.SplitAmong)
+		}
+	})
+}
@@ -0,0 +1,147 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"black-lotus/internal/domain/models"
+	"black-lotus/internal/features/photos"
+)
+
+type PhotoRepository struct {
+	db *pgxpool.Pool
+}
+
+var _ photos.Repository = (*PhotoRepository)(nil)
+
+func NewPhotoRepository(db *pgxpool.Pool) *PhotoRepository {
+	return &PhotoRepository{db: db}
+}
+
+// CreatePhoto attaches a photo to a trip, or returns "photo limit reached"
+// when the trip already has maxPhotos. The trip row is locked while counting
+// so concurrent uploads can't both slip under the limit.
+func (r *PhotoRepository) CreatePhoto(ctx context.Context, tripID uuid.UUID, input models.CreateTripPhotoInput, maxPhotos int) (*models.TripPhoto, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	var count int
+	err = tx.QueryRow(ctx, `
+		SELECT (SELECT COUNT(*) FROM trip_photos WHERE trip_id = t.id)
+		FROM trips t
+		WHERE t.id = $1
+		FOR UPDATE
+	`, tripID).Scan(&count)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("trip not found")
+		}
+		return nil, err
+	}
+
+	if count >= maxPhotos {
+		return nil, errors.New("photo limit reached")
+	}
+
+	photo := new(models.TripPhoto)
+	err = tx.QueryRow(ctx, `
+		INSERT INTO trip_photos (trip_id, url, caption)
+		VALUES ($1, $2, $3)
+		RETURNING id, trip_id, url, caption, uploaded_at
+	`, tripID, input.URL, input.Caption).Scan(
+		&photo.ID,
+		&photo.TripID,
+		&photo.URL,
+		&photo.Caption,
+		&photo.UploadedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return photo, nil
+}
+
+// GetPhotoByID returns a specific photo based on ID
+func (r *PhotoRepository) GetPhotoByID(ctx context.Context, photoID uuid.UUID) (*models.TripPhoto, error) {
+	photo := new(models.TripPhoto)
+
+	err := r.db.QueryRow(ctx, `
+		SELECT id, trip_id, url, caption, uploaded_at
+		FROM trip_photos
+		WHERE id = $1
+	`, photoID).Scan(
+		&photo.ID,
+		&photo.TripID,
+		&photo.URL,
+		&photo.Caption,
+		&photo.UploadedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("photo not found")
+		}
+		return nil, err
+	}
+
+	return photo, nil
+}
+
+// GetPhotosByTripID returns all photos for a trip, oldest first
+func (r *PhotoRepository) GetPhotosByTripID(ctx context.Context, tripID uuid.UUID) ([]*models.TripPhoto, error) {
+	return queryPhotosByTripID(ctx, r.db, tripID)
+}
+
+// DeletePhoto removes a photo's metadata
+func (r *PhotoRepository) DeletePhoto(ctx context.Context, photoID uuid.UUID) error {
+	result, err := r.db.Exec(ctx, `
+		DELETE FROM trip_photos
+		WHERE id = $1
+	`, photoID)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return errors.New("photo not found")
+	}
+
+	return nil
+}
+
+// queryPhotosByTripID is shared with the trip repository for the trip detail
+func queryPhotosByTripID(ctx context.Context, db *pgxpool.Pool, tripID uuid.UUID) ([]*models.TripPhoto, error) {
+	rows, err := db.Query(ctx, `
+		SELECT id, trip_id, url, caption, uploaded_at
+		FROM trip_photos
+		WHERE trip_id = $1
+		ORDER BY uploaded_at ASC, id ASC
+	`, tripID)
+	if err != nil {
+		return nil, err
+	}
+
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (*models.TripPhoto, error) {
+		photo := new(models.TripPhoto)
+		err := row.Scan(
+			&photo.ID,
+			&photo.TripID,
+			&photo.URL,
+			&photo.Caption,
+			&photo.UploadedAt,
+		)
+		return photo, err
+	})
+}
@@ -0,0 +1,92 @@
+package repositories_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"black-lotus/internal/domain/models"
+	"black-lotus/internal/infrastructure/repositories"
+	"black-lotus/pkg/db"
+)
+
+func TestPhotoRepository(t *testing.T) {
+	setupRepositoryTest(t)
+	ctx := context.Background()
+
+	var userID uuid.UUID
+	err := db.TestDB.QueryRow(ctx, `
+		INSERT INTO users (name, email) VALUES ('Test User', 'photos@example.com') RETURNING id
+	`).Scan(&userID)
+	if err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+
+	trip, err := repositories.NewTripRepository(db.TestDB).CreateTrip(ctx, userID, models.CreateTripInput{
+		Name:      "Photo Trip",
+		StartDate: time.Now(),
+		EndDate:   time.Now().Add(48 * time.Hour),
+		Location:  "Lisbon",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create trip: %v", err)
+	}
+
+	photos := repositories.NewPhotoRepository(db.TestDB)
+	input := models.CreateTripPhotoInput{URL: "https://cdn.example.com/beach.jpg", Caption: "Beach"}
+
+	t.Run("CreateTouchesTrip", func(t *testing.T) {
+		var before time.Time
+		if err := db.TestDB.QueryRow(ctx, `SELECT updated_at FROM trips WHERE id = $1`, trip.ID).Scan(&before); err != nil {
+			t.Fatalf("Failed to read trip: %v", err)
+		}
+
+		photo, err := photos.CreatePhoto(ctx, trip.ID, input, 2)
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if photo.TripID != trip.ID || photo.URL != input.URL {
+			t.Errorf("Expected photo %s on trip %s, got %+v", input.URL, trip.ID, photo)
+		}
+
+		var after time.Time
+		if err := db.TestDB.QueryRow(ctx, `SELECT updated_at FROM trips WHERE id = $1`, trip.ID).Scan(&after); err != nil {
+			t.Fatalf("Failed to read trip: %v", err)
+		}
+		if after.Before(before) {
+			t.Errorf("Expected trip updated_at to move forward, got %v before %v", after, before)
+		}
+	})
+
+	t.Run("LimitEnforced", func(t *testing.T) {
+		if _, err := photos.CreatePhoto(ctx, trip.ID, input, 2); err != nil {
+			t.Fatalf("Expected second photo to fit, got: %v", err)
+		}
+
+		if _, err := photos.CreatePhoto(ctx, trip.ID, input, 2); err == nil || err.Error() != "photo limit reached" {
+			t.Errorf("Expected 'photo limit reached', got: %v", err)
+		}
+
+		list, err := photos.GetPhotosByTripID(ctx, trip.ID)
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if len(list) != 2 {
+			t.Errorf("Expected 2 photos, got %d", len(list))
+		}
+	})
+
+	t.Run("UnknownTrip", func(t *testing.T) {
+		if _, err := photos.CreatePhoto(ctx, uuid.New(), input, 2); err == nil || err.Error() != "trip not found" {
+			t.Errorf("Expected 'trip not found', got: %v", err)
+		}
+	})
+
+	t.Run("DeleteMissingPhoto", func(t *testing.T) {
+		if err := photos.DeletePhoto(ctx, uuid.New()); err == nil || err.Error() != "photo not found" {
+			t.Errorf("Expected 'photo not found', got: %v", err)
+		}
+	})
+}
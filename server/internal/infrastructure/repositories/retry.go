@@ -0,0 +1,92 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const (
+	DefaultDBRetries      = 3
+	DefaultDBRetryBackoff = 50 * time.Millisecond // Doubles after every attempt
+	DefaultDBMaxBackoff   = time.Second
+)
+
+// RetryPolicy decides how often and how patiently transient database errors
+// are retried. Retries is the number of attempts after the first one.
+type RetryPolicy struct {
+	Retries    int
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+}
+
+// RetryPolicyFromEnv reads DB_RETRIES (0 disables retrying), DB_RETRY_BACKOFF
+// (e.g. "50ms") and DB_RETRY_MAX_BACKOFF, falling back to the defaults when a
+// value is unset or invalid
+func RetryPolicyFromEnv() RetryPolicy {
+	policy := RetryPolicy{
+		Retries:    DefaultDBRetries,
+		Backoff:    DefaultDBRetryBackoff,
+		MaxBackoff: DefaultDBMaxBackoff,
+	}
+
+	if retries, err := strconv.Atoi(os.Getenv("DB_RETRIES")); err == nil && retries >= 0 {
+		policy.Retries = retries
+	}
+	if backoff, err := time.ParseDuration(os.Getenv("DB_RETRY_BACKOFF")); err == nil && backoff > 0 {
+		policy.Backoff = backoff
+	}
+	if maxBackoff, err := time.ParseDuration(os.Getenv("DB_RETRY_MAX_BACKOFF")); err == nil && maxBackoff > 0 {
+		policy.MaxBackoff = maxBackoff
+	}
+
+	return policy
+}
+
+// IsTransientError reports whether err is worth retrying: a serialization
+// failure or deadlock the server rolled back, a lost connection, or any error
+// pgx guarantees happened before the query was sent. Constraint violations and
+// every other server error are permanent.
+func IsTransientError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		// serialization_failure, deadlock_detected and the connection_exception class
+		return pgErr.Code == "40001" || pgErr.Code == "40P01" || strings.HasPrefix(pgErr.Code, "08")
+	}
+
+	return pgconn.SafeToRetry(err) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED)
+}
+
+// WithRetry runs op, retrying transient errors with exponential backoff. The
+// last error is returned once retries run out or ctx is done.
+func WithRetry[T any](ctx context.Context, policy RetryPolicy, op func(ctx context.Context) (T, error)) (T, error) {
+	backoff := policy.Backoff
+	for attempt := 0; ; attempt++ {
+		result, err := op(ctx)
+		if err == nil || attempt >= policy.Retries || !IsTransientError(err) {
+			return result, err
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return result, err
+		case <-timer.C:
+		}
+
+		backoff = min(backoff*2, policy.MaxBackoff)
+	}
+}
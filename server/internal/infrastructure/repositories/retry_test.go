@@ -0,0 +1,149 @@
+package repositories_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"black-lotus/internal/infrastructure/repositories"
+)
+
+func TestWithRetry(t *testing.T) {
+	connectionReset := &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET}
+
+	testCases := []struct {
+		name             string
+		failures         []error // Returned by the first attempts, in order
+		retries          int
+		expectedAttempts int
+		expectSuccess    bool
+	}{
+		{
+			name:             "SucceedsAfterSerializationFailure",
+			failures:         []error{&pgconn.PgError{Code: "40001"}},
+			retries:          3,
+			expectedAttempts: 2,
+			expectSuccess:    true,
+		},
+		{
+			name:             "SucceedsAfterConnectionReset",
+			failures:         []error{fmt.Errorf("failed to insert session: %w", connectionReset), connectionReset},
+			retries:          3,
+			expectedAttempts: 3,
+			expectSuccess:    true,
+		},
+		{
+			name:             "NeverRetriesConstraintViolation",
+			failures:         []error{&pgconn.PgError{Code: "23505"}},
+			retries:          3,
+			expectedAttempts: 1,
+		},
+		{
+			name:             "NeverRetriesOtherErrors",
+			failures:         []error{errors.New("no rows in result set")},
+			retries:          3,
+			expectedAttempts: 1,
+		},
+		{
+			name:             "GivesUpAfterRetries",
+			failures:         []error{connectionReset, connectionReset, connectionReset},
+			retries:          2,
+			expectedAttempts: 3,
+		},
+		{
+			name:             "ZeroRetriesDisablesRetrying",
+			failures:         []error{connectionReset},
+			retries:          0,
+			expectedAttempts: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			policy := repositories.RetryPolicy{Retries: tc.retries, Backoff: time.Millisecond, MaxBackoff: 2 * time.Millisecond}
+
+			attempts := 0
+			result, err := repositories.WithRetry(context.Background(), policy, func(ctx context.Context) (string, error) {
+				attempts++
+				if attempts <= len(tc.failures) {
+					return "", tc.failures[attempts-1]
+				}
+				return "created", nil
+			})
+
+			if attempts != tc.expectedAttempts {
+				t.Errorf("Expected %d attempts, got %d", tc.expectedAttempts, attempts)
+			}
+
+			if tc.expectSuccess {
+				if err != nil || result != "created" {
+					t.Errorf("Expected success, got %q, %v", result, err)
+				}
+				return
+			}
+			if err == nil || !errors.Is(err, tc.failures[tc.expectedAttempts-1]) {
+				t.Errorf("Expected the last failure to be returned, got %v", err)
+			}
+		})
+	}
+}
+
+func TestWithRetryStopsWhenContextDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	policy := repositories.RetryPolicy{Retries: 5, Backoff: time.Hour, MaxBackoff: time.Hour}
+
+	attempts := 0
+	_, err := repositories.WithRetry(ctx, policy, func(ctx context.Context) (int, error) {
+		attempts++
+		cancel()
+		return 0, &pgconn.PgError{Code: "40P01"}
+	})
+
+	if attempts != 1 {
+		t.Errorf("Expected 1 attempt, got %d", attempts)
+	}
+	if err == nil {
+		t.Error("Expected the deadlock error to be returned")
+	}
+}
+
+func TestRetryPolicyFromEnv(t *testing.T) {
+	testCases := []struct {
+		name     string
+		env      map[string]string
+		expected repositories.RetryPolicy
+	}{
+		{
+			name:     "Defaults",
+			expected: repositories.RetryPolicy{Retries: 3, Backoff: 50 * time.Millisecond, MaxBackoff: time.Second},
+		},
+		{
+			name:     "Configured",
+			env:      map[string]string{"DB_RETRIES": "0", "DB_RETRY_BACKOFF": "10ms", "DB_RETRY_MAX_BACKOFF": "200ms"},
+			expected: repositories.RetryPolicy{Retries: 0, Backoff: 10 * time.Millisecond, MaxBackoff: 200 * time.Millisecond},
+		},
+		{
+			name:     "InvalidValuesUseDefaults",
+			env:      map[string]string{"DB_RETRIES": "-1", "DB_RETRY_BACKOFF": "soon", "DB_RETRY_MAX_BACKOFF": "0s"},
+			expected: repositories.RetryPolicy{Retries: 3, Backoff: 50 * time.Millisecond, MaxBackoff: time.Second},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, key := range []string{"DB_RETRIES", "DB_RETRY_BACKOFF", "DB_RETRY_MAX_BACKOFF"} {
+				t.Setenv(key, tc.env[key])
+			}
+
+			if policy := repositories.RetryPolicyFromEnv(); policy != tc.expected {
+				t.Errorf("Expected %+v, got %+v", tc.expected, policy)
+			}
+		})
+	}
+}
@@ -6,10 +6,12 @@ import (
 	"crypto/sha256"
 	"encoding/base64"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	"black-lotus/internal/domain/models"
@@ -18,7 +20,8 @@ import (
 
 // SessionRepository handles database operations for sessions
 type SessionRepository struct {
-	db *pgxpool.Pool // Database connection pool
+	db    *pgxpool.Pool // Database connection pool
+	retry RetryPolicy   // Applied to session creation
 }
 
 // Compile-time interface checks
@@ -28,7 +31,7 @@ var (
 
 // NewSessionRepository creates a new repository with the given database connection
 func NewSessionRepository(db *pgxpool.Pool) *SessionRepository {
-	return &SessionRepository{db: db}
+	return &SessionRepository{db: db, retry: RetryPolicyFromEnv()}
 }
 
 // CreateSession stores a new session with both access and refresh tokens
@@ -62,18 +65,20 @@ func (r *SessionRepository) CreateSession(
 	accessExpiry := time.Now().Add(accessDuration)
 	refreshExpiry := time.Now().Add(refreshDuration)
 
-	// Insert into database
-	err := r.db.QueryRow(ctx, `
-        INSERT INTO sessions (user_id, access_token_hash, refresh_token_hash, access_expires_at, refresh_expires_at)
-        VALUES ($1, $2, $3, $4, $5)
-        RETURNING id, user_id, access_expires_at, refresh_expires_at, created_at
-    `, userID, accessTokenHash, refreshTokenHash, accessExpiry, refreshExpiry).Scan(
-		&session.ID,
-		&session.UserID,
-		&session.AccessExpiry,
-		&session.RefreshExpiry,
-		&session.CreatedAt,
-	)
+	// Insert into database, retrying if the connection drops
+	_, err := WithRetry(ctx, r.retry, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, r.db.QueryRow(ctx, `
+            INSERT INTO sessions (user_id, access_token_hash, refresh_token_hash, access_expires_at, refresh_expires_at)
+            VALUES ($1, $2, $3, $4, $5)
+            RETURNING id, user_id, access_expires_at, refresh_expires_at, created_at
+        `, userID, accessTokenHash, refreshTokenHash, accessExpiry, refreshExpiry).Scan(
+			&session.ID,
+			&session.UserID,
+			&session.AccessExpiry,
+			&session.RefreshExpiry,
+			&session.CreatedAt,
+		)
+	})
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to insert session: %w", err)
@@ -124,7 +129,7 @@ func (r *SessionRepository) GetSessionByRefreshToken(ctx context.Context, token
 
 	// Query by token hash
 	err := r.db.QueryRow(ctx, `
-        SELECT id, user_id, access_expires_at, refresh_expires_at, created_at
+        SELECT id, user_id, access_expires_at, refresh_expires_at, last_refreshed_at, created_at
         FROM sessions
         WHERE refresh_token_hash = $1 AND refresh_expires_at > NOW()
     `, tokenHash).Scan(
@@ -132,6 +137,7 @@ func (r *SessionRepository) GetSessionByRefreshToken(ctx context.Context, token
 		&session.UserID,
 		&session.AccessExpiry,
 		&session.RefreshExpiry,
+		&session.LastRefreshedAt,
 		&session.CreatedAt,
 	)
 
@@ -142,9 +148,12 @@ func (r *SessionRepository) GetSessionByRefreshToken(ctx context.Context, token
 	return session, nil
 }
 
-// RefreshAccessToken generates a new access token for a session
-func (r *SessionRepository) RefreshAccessToken(ctx context.Context, sessionID uuid.UUID) (*models.Session, error) {
-	session := new(models.Session)
+// RefreshAccessToken generates a new access token for a session. The update only
+// applies when the session hasn't been refreshed within minInterval, so concurrent
+// refreshes can't both rotate the token; the loser gets session.ErrRefreshTooSoon.
+// A session that no longer exists is an invalid refresh token instead.
+func (r *SessionRepository) RefreshAccessToken(ctx context.Context, sessionID uuid.UUID, minInterval time.Duration) (*models.Session, error) {
+	refreshed := new(models.Session)
 
 	// Generate new access token
 	tokenBytes := make([]byte, 32)
@@ -159,28 +168,48 @@ func (r *SessionRepository) RefreshAccessToken(ctx context.Context, sessionID uu
 	// Set new expiration time (1 hour from now)
 	accessExpiry := time.Now().Add(1 * time.Hour)
 
-	// Update in database
+	// Update in database. The outer select reads the session as it was before
+	// the update, so it finds the row whether or not the update applied, and
+	// finds nothing once the session is gone.
+	var rotated bool
 	err := r.db.QueryRow(ctx, `
-        UPDATE sessions
-        SET access_token_hash = $1, access_expires_at = $2
-        WHERE id = $3
-        RETURNING id, user_id, access_expires_at, refresh_expires_at, created_at
-    `, tokenHash, accessExpiry, sessionID).Scan(
-		&session.ID,
-		&session.UserID,
-		&session.AccessExpiry,
-		&session.RefreshExpiry,
-		&session.CreatedAt,
+        WITH rotated AS (
+            UPDATE sessions
+            SET access_token_hash = $1, access_expires_at = $2, last_refreshed_at = NOW()
+            WHERE id = $3
+              AND (last_refreshed_at IS NULL OR last_refreshed_at <= NOW() - $4::interval)
+            RETURNING id, access_expires_at, last_refreshed_at
+        )
+        SELECT rotated.id IS NOT NULL, s.id, s.user_id,
+               COALESCE(rotated.access_expires_at, s.access_expires_at), s.refresh_expires_at,
+               COALESCE(rotated.last_refreshed_at, s.last_refreshed_at), s.created_at
+        FROM sessions s
+        LEFT JOIN rotated ON rotated.id = s.id
+        WHERE s.id = $3
+    `, tokenHash, accessExpiry, sessionID, minInterval).Scan(
+		&rotated,
+		&refreshed.ID,
+		&refreshed.UserID,
+		&refreshed.AccessExpiry,
+		&refreshed.RefreshExpiry,
+		&refreshed.LastRefreshedAt,
+		&refreshed.CreatedAt,
 	)
 
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("invalid refresh token")
+		}
 		return nil, err
 	}
+	if !rotated {
+		return nil, session.ErrRefreshTooSoon
+	}
 
 	// Set the new access token
-	session.AccessToken = accessToken
+	refreshed.AccessToken = accessToken
 
-	return session, nil
+	return refreshed, nil
 }
 
 // DeleteSessionByAccessToken removes a session using its access token
@@ -222,3 +251,13 @@ func (r *SessionRepository) DeleteUserSessions(ctx context.Context, userID uuid.
 
 	return err
 }
+
+// DeleteOtherUserSessions removes all of a user's sessions except one
+func (r *SessionRepository) DeleteOtherUserSessions(ctx context.Context, userID uuid.UUID, keepSessionID uuid.UUID) error {
+	_, err := r.db.Exec(ctx, `
+		DELETE FROM sessions
+		WHERE user_id = $1 AND id <> $2
+	`, userID, keepSessionID)
+
+	return err
+}
@@ -0,0 +1,126 @@
+package repositories_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"black-lotus/internal/features/auth/session"
+	"black-lotus/internal/infrastructure/repositories"
+	"black-lotus/pkg/db"
+)
+
+func TestSessionRepositoryDeleteOtherUserSessions(t *testing.T) {
+	setupRepositoryTest(t)
+	ctx := context.Background()
+
+	// Setup: three sessions for the user and one for someone else
+	var userID, otherUserID uuid.UUID
+	err := db.TestDB.QueryRow(ctx, `
+		INSERT INTO users (name, email) VALUES ('Test User', 'sessions@example.com') RETURNING id
+	`).Scan(&userID)
+	if err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	err = db.TestDB.QueryRow(ctx, `
+		INSERT INTO users (name, email) VALUES ('Other User', 'other-sessions@example.com') RETURNING id
+	`).Scan(&otherUserID)
+	if err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+
+	sessions := repositories.NewSessionRepository(db.TestDB)
+	current, err := sessions.CreateSession(ctx, userID, time.Hour, 24*time.Hour)
+	if err != nil {
+		t.Fatalf("Failed to create session: %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := sessions.CreateSession(ctx, userID, time.Hour, 24*time.Hour); err != nil {
+			t.Fatalf("Failed to create session: %v", err)
+		}
+	}
+	if _, err := sessions.CreateSession(ctx, otherUserID, time.Hour, 24*time.Hour); err != nil {
+		t.Fatalf("Failed to create session: %v", err)
+	}
+
+	// Execute
+	if err := sessions.DeleteOtherUserSessions(ctx, userID, current.ID); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	// Verify: only the current session is left for the user
+	rows, err := db.TestDB.Query(ctx, `SELECT id FROM sessions WHERE user_id = $1`, userID)
+	if err != nil {
+		t.Fatalf("Failed to list sessions: %v", err)
+	}
+	defer rows.Close()
+
+	var remaining []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			t.Fatalf("Failed to scan session: %v", err)
+		}
+		remaining = append(remaining, id)
+	}
+	if len(remaining) != 1 || remaining[0] != current.ID {
+		t.Errorf("Expected only session %s to remain, got %v", current.ID, remaining)
+	}
+
+	var otherCount int
+	if err := db.TestDB.QueryRow(ctx, `SELECT COUNT(*) FROM sessions WHERE user_id = $1`, otherUserID).Scan(&otherCount); err != nil {
+		t.Fatalf("Failed to count sessions: %v", err)
+	}
+	if otherCount != 1 {
+		t.Errorf("Expected the other user's session to be kept, got %d", otherCount)
+	}
+}
+
+func TestSessionRepositoryRefreshAccessToken(t *testing.T) {
+	setupRepositoryTest(t)
+	ctx := context.Background()
+
+	// Setup
+	var userID uuid.UUID
+	err := db.TestDB.QueryRow(ctx, `
+		INSERT INTO users (name, email) VALUES ('Test User', 'refresh@example.com') RETURNING id
+	`).Scan(&userID)
+	if err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+
+	sessions := repositories.NewSessionRepository(db.TestDB)
+	created, err := sessions.CreateSession(ctx, userID, time.Hour, 24*time.Hour)
+	if err != nil {
+		t.Fatalf("Failed to create session: %v", err)
+	}
+
+	// Execute & Verify: the first refresh rotates the access token
+	refreshed, err := sessions.RefreshAccessToken(ctx, created.ID, time.Minute)
+	if err != nil {
+		t.Fatalf("Expected first refresh to succeed, got: %v", err)
+	}
+	if refreshed.AccessToken == "" || refreshed.AccessToken == created.AccessToken {
+		t.Errorf("Expected a new access token, got '%s'", refreshed.AccessToken)
+	}
+	if refreshed.UserID != userID || refreshed.LastRefreshedAt == nil {
+		t.Errorf("Expected a refreshed session for %s, got %+v", userID, refreshed)
+	}
+
+	// A second refresh within the interval is throttled
+	if _, err := sessions.RefreshAccessToken(ctx, created.ID, time.Minute); !errors.Is(err, session.ErrRefreshTooSoon) {
+		t.Errorf("Expected ErrRefreshTooSoon, got: %v", err)
+	}
+
+	// A deleted session is an invalid token, not a throttled one
+	if err := sessions.DeleteUserSessions(ctx, userID); err != nil {
+		t.Fatalf("Failed to delete sessions: %v", err)
+	}
+	_, err = sessions.RefreshAccessToken(ctx, created.ID, time.Minute)
+	if err == nil || errors.Is(err, session.ErrRefreshTooSoon) || err.Error() != "invalid refresh token" {
+		t.Errorf("Expected 'invalid refresh token', got: %v", err)
+	}
+}
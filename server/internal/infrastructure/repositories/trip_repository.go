@@ -2,13 +2,25 @@ package repositories
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/base64"
 	"errors"
+	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	"black-lotus/internal/domain/models"
+	"black-lotus/internal/features/reminders"
+	"black-lotus/internal/features/trips"
+	"black-lotus/pkg/db"
+)
+
+var (
+	_ trips.ReminderRepository = (*TripRepository)(nil)
+	_ reminders.Repository     = (*TripRepository)(nil)
 )
 
 type TripRepository struct {
@@ -23,8 +35,29 @@ type TripRepositoryInterface interface {
 	GetTripByID(ctx context.Context, tripID uuid.UUID) (*models.Trip, error)
 	UpdateTrip(ctx context.Context, tripID uuid.UUID, input models.UpdateTripInput) (*models.Trip, error)
 	DeleteTrip(ctx context.Context, tripID uuid.UUID) error
-	GetTripsByUserID(ctx context.Context, userID uuid.UUID, limit int, offset int) ([]*models.Trip, error)
+	GetDeletedTripByID(ctx context.Context, tripID uuid.UUID) (*models.Trip, error)
+	RestoreTrip(ctx context.Context, tripID uuid.UUID) (*models.Trip, error)
+	GetTripsByUserID(ctx context.Context, userID uuid.UUID, limit int, offset int, filter models.TripFilter) ([]*models.Trip, error)
+	GetTripListVersion(ctx context.Context, userID uuid.UUID) (*models.TripListVersion, error)
+	GetTripStartDates(ctx context.Context, userID uuid.UUID) ([]time.Time, error)
+	GetUpcomingTrips(ctx context.Context, userID uuid.UUID, within int) ([]*models.Trip, error)
+	GetTripsByLocation(ctx context.Context, userID uuid.UUID, location string) ([]*models.Trip, error)
 	GetTripWithUser(ctx context.Context, tripID uuid.UUID) (*models.Trip, error)
+	ImportTrip(ctx context.Context, userID uuid.UUID, export models.TripExport) (*models.TripImportResult, error)
+	AddTripTag(ctx context.Context, tripID uuid.UUID, userID uuid.UUID, name string) (*models.Tag, error)
+	RemoveTripTag(ctx context.Context, tripID uuid.UUID, userID uuid.UUID, name string) error
+	GetTripTags(ctx context.Context, tripID uuid.UUID) ([]*models.Tag, error)
+	GetTripActivities(ctx context.Context, tripID uuid.UUID) ([]*models.Activity, error)
+	GetTripExpenses(ctx context.Context, tripID uuid.UUID) ([]*models.Expense, error)
+	GetTripPhotos(ctx context.Context, tripID uuid.UUID) ([]*models.TripPhoto, error)
+	CountTripPhotos(ctx context.Context, tripID uuid.UUID) (int, error)
+	ReorderTrips(ctx context.Context, userID uuid.UUID, tripIDs []uuid.UUID) error
+	CreateTrips(ctx context.Context, userID uuid.UUID, inputs []models.CreateTripInput) ([]*models.Trip, error)
+	PlanTrip(ctx context.Context, tripID uuid.UUID, startDate, endDate time.Time) (*models.Trip, error)
+	BulkTagTrips(ctx context.Context, userID uuid.UUID, tripIDs []uuid.UUID, names []string, replace bool) ([]uuid.UUID, error)
+	ShareTrip(ctx context.Context, tripID uuid.UUID) (*models.TripShare, error)
+	UnshareTrip(ctx context.Context, tripID uuid.UUID) error
+	GetTripByShareToken(ctx context.Context, token string) (*models.Trip, error)
 }
 
 func NewTripRepository(db *pgxpool.Pool) *TripRepository {
@@ -35,16 +68,18 @@ func (r *TripRepository) CreateTrip(ctx context.Context, userID uuid.UUID, input
 	trip := new(models.Trip)
 
 	err := r.db.QueryRow(ctx, `
-        INSERT INTO trips (user_id, name, description, start_date, end_date, location)
-        VALUES ($1, $2, $3, $4, $5, $6)
-        RETURNING id, user_id, name, description, start_date, end_date, location, created_at, updated_at
+        INSERT INTO trips (user_id, name, description, start_date, end_date, location, is_wishlist, cover_image_url)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+        RETURNING id, user_id, name, description, start_date, end_date, location, is_wishlist, visibility, share_token, cover_image_url, created_at, updated_at
     `,
 		userID,
 		input.Name,
 		input.Description,
-		input.StartDate,
-		input.EndDate,
-		input.Location).Scan(
+		optionalDate(input.StartDate),
+		optionalDate(input.EndDate),
+		input.Location,
+		input.IsWishlist,
+		input.CoverImageURL).Scan(
 		&trip.ID,
 		&trip.UserID,
 		&trip.Name,
@@ -52,6 +87,10 @@ func (r *TripRepository) CreateTrip(ctx context.Context, userID uuid.UUID, input
 		&trip.StartDate,
 		&trip.EndDate,
 		&trip.Location,
+		&trip.IsWishlist,
+		&trip.Visibility,
+		&trip.ShareToken,
+		&trip.CoverImageURL,
 		&trip.CreatedAt,
 		&trip.UpdatedAt,
 	)
@@ -63,6 +102,62 @@ func (r *TripRepository) CreateTrip(ctx context.Context, userID uuid.UUID, input
 	return trip, nil
 }
 
+// CreateTrips creates several trips in a single transaction, so either all of
+// them are created or none are. Trips are returned in input order.
+func (r *TripRepository) CreateTrips(ctx context.Context, userID uuid.UUID, inputs []models.CreateTripInput) ([]*models.Trip, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	trips := make([]*models.Trip, 0, len(inputs))
+
+	for _, input := range inputs {
+		trip := new(models.Trip)
+
+		err := tx.QueryRow(ctx, `
+            INSERT INTO trips (user_id, name, description, start_date, end_date, location, is_wishlist, cover_image_url)
+            VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+            RETURNING id, user_id, name, description, start_date, end_date, location, is_wishlist, visibility, share_token, cover_image_url, created_at, updated_at
+        `,
+			userID,
+			input.Name,
+			input.Description,
+			optionalDate(input.StartDate),
+			optionalDate(input.EndDate),
+			input.Location,
+			input.IsWishlist,
+			input.CoverImageURL).Scan(
+			&trip.ID,
+			&trip.UserID,
+			&trip.Name,
+			&trip.Description,
+			&trip.StartDate,
+			&trip.EndDate,
+			&trip.Location,
+			&trip.IsWishlist,
+			&trip.Visibility,
+			&trip.ShareToken,
+			&trip.CoverImageURL,
+			&trip.CreatedAt,
+			&trip.UpdatedAt,
+		)
+
+		if err != nil {
+			return nil, err
+		}
+
+		trips = append(trips, trip)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return trips, nil
+}
+
 // UpdateTrip updates an existing trip
 func (r *TripRepository) UpdateTrip(ctx context.Context, tripID uuid.UUID, input models.UpdateTripInput) (*models.Trip, error) {
 	trip := new(models.Trip)
@@ -75,15 +170,17 @@ func (r *TripRepository) UpdateTrip(ctx context.Context, tripID uuid.UUID, input
 	start_date = COALESCE($3, start_date),
 	end_date = COALESCE($4, end_date),
 	location = COALESCE($5, location),
+	cover_image_url = COALESCE($6, cover_image_url),
 	updated_at = NOW()
-	WHERE id = $6
-	RETURNING id, user_id, name, description, start_date, end_date, location, created_at, updated_at
+	WHERE id = $7 AND deleted_at IS NULL
+	RETURNING id, user_id, name, description, start_date, end_date, location, is_wishlist, visibility, share_token, cover_image_url, created_at, updated_at
 	`,
 		input.Name,
 		input.Description,
 		input.StartDate,
 		input.EndDate,
 		input.Location,
+		input.CoverImageURL,
 		tripID).Scan(
 		&trip.ID,
 		&trip.UserID,
@@ -92,6 +189,10 @@ func (r *TripRepository) UpdateTrip(ctx context.Context, tripID uuid.UUID, input
 		&trip.StartDate,
 		&trip.EndDate,
 		&trip.Location,
+		&trip.IsWishlist,
+		&trip.Visibility,
+		&trip.ShareToken,
+		&trip.CoverImageURL,
 		&trip.CreatedAt,
 		&trip.UpdatedAt,
 	)
@@ -103,15 +204,139 @@ func (r *TripRepository) UpdateTrip(ctx context.Context, tripID uuid.UUID, input
 		return nil, err
 	}
 
+	if err := r.attachTags(ctx, trip); err != nil {
+		return nil, err
+	}
+
 	return trip, nil
 }
 
-// DeleteTrip removes trip from DB.
+// DeleteTrip soft-deletes a trip by stamping deleted_at so it can be restored later.
+// The trip and the rows that hang off it change in one transaction, so a failure
+// part way through leaves the trip untouched. Activities, expenses and tags are
+// kept for RestoreTrip; the foreign keys remove them if the trip row is ever purged.
 func (r *TripRepository) DeleteTrip(ctx context.Context, tripID uuid.UUID) error {
+	return db.WithTx(ctx, r.db, func(tx pgx.Tx) error {
+		var userID uuid.UUID
+		var orderIndex *int
+
+		// Lock the trip so a concurrent reorder can't race the delete
+		err := tx.QueryRow(ctx, `
+		SELECT user_id, order_index
+		FROM trips
+		WHERE id = $1 AND deleted_at IS NULL
+		FOR UPDATE
+		`, tripID).Scan(&userID, &orderIndex)
+
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return errors.New("trip not found")
+			}
+			return err
+		}
+
+		_, err = tx.Exec(ctx, `
+		UPDATE trips
+		SET deleted_at = NOW(), order_index = NULL
+		WHERE id = $1
+		`, tripID)
+		if err != nil {
+			return err
+		}
+
+		// Close the gap the trip leaves in the user's manual order
+		if orderIndex != nil {
+			_, err = tx.Exec(ctx, `
+			UPDATE trips
+			SET order_index = order_index - 1, updated_at = NOW()
+			WHERE user_id = $1 AND order_index > $2
+			`, userID, *orderIndex)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// GetTripByID returns a specific trip based on ID
+func (r *TripRepository) GetTripByID(ctx context.Context, tripID uuid.UUID) (*models.Trip, error) {
+	trip := new(models.Trip)
+
+	err := r.db.QueryRow(ctx, `
+				SELECT id, user_id, name, description, start_date, end_date, location, is_wishlist, visibility, share_token, cover_image_url, created_at, updated_at
+				FROM trips
+				WHERE id = $1 AND deleted_at IS NULL
+		`, tripID).Scan(
+		&trip.ID,
+		&trip.UserID,
+		&trip.Name,
+		&trip.Description,
+		&trip.StartDate,
+		&trip.EndDate,
+		&trip.Location,
+		&trip.IsWishlist,
+		&trip.Visibility,
+		&trip.ShareToken,
+		&trip.CoverImageURL,
+		&trip.CreatedAt,
+		&trip.UpdatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("trip not found")
+		}
+		return nil, err
+	}
+
+	if err := r.attachTags(ctx, trip); err != nil {
+		return nil, err
+	}
+
+	return trip, nil
+}
+
+// ShareTrip makes a trip unlisted. A trip that is already shared keeps its
+// token, so sharing twice doesn't break links that were handed out.
+func (r *TripRepository) ShareTrip(ctx context.Context, tripID uuid.UUID) (*models.TripShare, error) {
+	tokenBytes := make([]byte, 32)
+	if _, err := rand.Read(tokenBytes); err != nil {
+		return nil, fmt.Errorf("failed to generate share token: %w", err)
+	}
+
+	share := new(models.TripShare)
+
+	err := r.db.QueryRow(ctx, `
+		UPDATE trips
+		SET visibility = $2, share_token = COALESCE(share_token, $3), updated_at = NOW()
+		WHERE id = $1 AND deleted_at IS NULL
+		RETURNING id, visibility, share_token
+	`, tripID, models.TripVisibilityUnlisted, base64.RawURLEncoding.EncodeToString(tokenBytes)).Scan(
+		&share.TripID,
+		&share.Visibility,
+		&share.ShareToken,
+	)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("trip not found")
+		}
+		return nil, err
+	}
+
+	return share, nil
+}
+
+// UnshareTrip makes a trip private again and forgets its token, so the old
+// link stops working even if the trip is shared again later
+func (r *TripRepository) UnshareTrip(ctx context.Context, tripID uuid.UUID) error {
 	commandTag, err := r.db.Exec(ctx, `
-	DELETE FROM trips
-	WHERE id = $1
-	`, tripID)
+		UPDATE trips
+		SET visibility = $2, share_token = NULL, updated_at = NOW()
+		WHERE id = $1 AND deleted_at IS NULL
+	`, tripID, models.TripVisibilityPrivate)
 
 	if err != nil {
 		return err
@@ -124,15 +349,16 @@ func (r *TripRepository) DeleteTrip(ctx context.Context, tripID uuid.UUID) error
 	return nil
 }
 
-// GetTripByID returns a specific trip based on ID
-func (r *TripRepository) GetTripByID(ctx context.Context, tripID uuid.UUID) (*models.Trip, error) {
+// GetTripByShareToken returns the unlisted trip a share link points at.
+// Private and deleted trips are never returned, whatever token is given.
+func (r *TripRepository) GetTripByShareToken(ctx context.Context, token string) (*models.Trip, error) {
 	trip := new(models.Trip)
 
 	err := r.db.QueryRow(ctx, `
-				SELECT id, user_id, name, description, start_date, end_date, location, created_at, updated_at
-				FROM trips
-				WHERE id = $1
-		`, tripID).Scan(
+		SELECT id, user_id, name, description, start_date, end_date, location, is_wishlist, visibility, share_token, cover_image_url, created_at, updated_at
+		FROM trips
+		WHERE share_token = $1 AND visibility = $2 AND deleted_at IS NULL
+	`, token, models.TripVisibilityUnlisted).Scan(
 		&trip.ID,
 		&trip.UserID,
 		&trip.Name,
@@ -140,6 +366,10 @@ func (r *TripRepository) GetTripByID(ctx context.Context, tripID uuid.UUID) (*mo
 		&trip.StartDate,
 		&trip.EndDate,
 		&trip.Location,
+		&trip.IsWishlist,
+		&trip.Visibility,
+		&trip.ShareToken,
+		&trip.CoverImageURL,
 		&trip.CreatedAt,
 		&trip.UpdatedAt,
 	)
@@ -151,22 +381,188 @@ func (r *TripRepository) GetTripByID(ctx context.Context, tripID uuid.UUID) (*mo
 		return nil, err
 	}
 
+	if err := r.attachTags(ctx, trip); err != nil {
+		return nil, err
+	}
+
 	return trip, nil
 }
 
-// GetTripsByUserID fetches all trips for a given user.
-func (r *TripRepository) GetTripsByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*models.Trip, error) {
+// GetDeletedTripByID returns a soft-deleted trip, including when it was deleted
+func (r *TripRepository) GetDeletedTripByID(ctx context.Context, tripID uuid.UUID) (*models.Trip, error) {
+	trip := new(models.Trip)
+
+	err := r.db.QueryRow(ctx, `
+		SELECT id, user_id, name, description, start_date, end_date, location, is_wishlist, visibility, share_token, cover_image_url, created_at, updated_at, deleted_at
+		FROM trips
+		WHERE id = $1 AND deleted_at IS NOT NULL
+	`, tripID).Scan(
+		&trip.ID,
+		&trip.UserID,
+		&trip.Name,
+		&trip.Description,
+		&trip.StartDate,
+		&trip.EndDate,
+		&trip.Location,
+		&trip.IsWishlist,
+		&trip.Visibility,
+		&trip.ShareToken,
+		&trip.CoverImageURL,
+		&trip.CreatedAt,
+		&trip.UpdatedAt,
+		&trip.DeletedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("trip not found")
+		}
+		return nil, err
+	}
+
+	return trip, nil
+}
+
+// RestoreTrip clears deleted_at on a soft-deleted trip
+func (r *TripRepository) RestoreTrip(ctx context.Context, tripID uuid.UUID) (*models.Trip, error) {
+	trip := new(models.Trip)
+
+	err := r.db.QueryRow(ctx, `
+		UPDATE trips
+		SET deleted_at = NULL, updated_at = NOW()
+		WHERE id = $1 AND deleted_at IS NOT NULL
+		RETURNING id, user_id, name, description, start_date, end_date, location, is_wishlist, visibility, share_token, cover_image_url, created_at, updated_at
+	`, tripID).Scan(
+		&trip.ID,
+		&trip.UserID,
+		&trip.Name,
+		&trip.Description,
+		&trip.StartDate,
+		&trip.EndDate,
+		&trip.Location,
+		&trip.IsWishlist,
+		&trip.Visibility,
+		&trip.ShareToken,
+		&trip.CoverImageURL,
+		&trip.CreatedAt,
+		&trip.UpdatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("trip not found")
+		}
+		return nil, err
+	}
+
+	if err := r.attachTags(ctx, trip); err != nil {
+		return nil, err
+	}
+
+	return trip, nil
+}
+
+// PlanTrip turns a wishlist trip into a planned trip with the given dates
+func (r *TripRepository) PlanTrip(ctx context.Context, tripID uuid.UUID, startDate, endDate time.Time) (*models.Trip, error) {
+	trip := new(models.Trip)
+
+	err := r.db.QueryRow(ctx, `
+		UPDATE trips
+		SET start_date = $2, end_date = $3, is_wishlist = FALSE, updated_at = NOW()
+		WHERE id = $1 AND deleted_at IS NULL AND is_wishlist
+		RETURNING id, user_id, name, description, start_date, end_date, location, is_wishlist, visibility, share_token, cover_image_url, created_at, updated_at
+	`, tripID, startDate, endDate).Scan(
+		&trip.ID,
+		&trip.UserID,
+		&trip.Name,
+		&trip.Description,
+		&trip.StartDate,
+		&trip.EndDate,
+		&trip.Location,
+		&trip.IsWishlist,
+		&trip.Visibility,
+		&trip.ShareToken,
+		&trip.CoverImageURL,
+		&trip.CreatedAt,
+		&trip.UpdatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("trip not found")
+		}
+		return nil, err
+	}
+
+	if err := r.attachTags(ctx, trip); err != nil {
+		return nil, err
+	}
+
+	return trip, nil
+}
+
+// tripSortColumns whitelists the columns a TripSortSpec may order by
+var tripSortColumns = map[string]string{
+	"start_date": "t.start_date",
+	"end_date":   "t.end_date",
+	"created_at": "t.created_at",
+	"name":       "LOWER(t.name)",
+}
+
+// GetTripsByUserID fetches all trips for a given user, optionally narrowed by filter.
+func (r *TripRepository) GetTripsByUserID(ctx context.Context, userID uuid.UUID, limit, offset int, filter models.TripFilter) ([]*models.Trip, error) {
 	if limit <= 0 {
 		limit = 10 // Default limit
 	}
 
+	// Only known sort orders reach the query
+	orderBy := "t.start_date DESC NULLS LAST"
+	if filter.Sort == models.TripSortManual {
+		orderBy = "t.order_index ASC NULLS LAST, t.start_date DESC NULLS LAST"
+	} else if filter.SortSpec != nil {
+		if column, ok := tripSortColumns[filter.SortSpec.Field]; ok {
+			direction := "ASC"
+			if filter.SortSpec.Descending {
+				direction = "DESC"
+			}
+			// Wishlist trips have no dates and always sort last; id keeps pages stable on ties
+			orderBy = column + " " + direction + " NULLS LAST, t.id"
+		}
+	}
+
+	// Keyset pagination: continue strictly after the cursor's row, so rows
+	// inserted meanwhile can't shift the page boundaries
+	var afterCreatedAt *time.Time
+	var afterID *uuid.UUID
+	if filter.Cursor != nil {
+		orderBy = "t.created_at DESC, t.id DESC"
+		offset = 0
+		if !filter.Cursor.CreatedAt.IsZero() {
+			afterCreatedAt, afterID = &filter.Cursor.CreatedAt, &filter.Cursor.ID
+		}
+	}
+
 	rows, err := r.db.Query(ctx, `
-        SELECT id, user_id, name, description, start_date, end_date, location, created_at, updated_at
-        FROM trips
-        WHERE user_id = $1
-        ORDER BY start_date DESC
+        SELECT t.id, t.user_id, t.name, t.description, t.start_date, t.end_date, t.location, t.is_wishlist, t.visibility, t.share_token, t.cover_image_url, t.created_at, t.updated_at
+        FROM trips t
+        WHERE t.user_id = $1 AND t.deleted_at IS NULL
+        AND ($5::boolean IS NULL OR t.is_wishlist = $5)
+        AND ($6::timestamptz IS NULL OR t.start_date >= $6)
+        AND ($7::timestamptz IS NULL OR t.end_date <= $7)
+        AND ($8::text = ''
+            OR ($8 = 'past' AND t.end_date + INTERVAL '1 day' <= NOW())
+            OR ($8 = 'ongoing' AND t.start_date <= NOW() AND t.end_date + INTERVAL '1 day' > NOW())
+            OR ($8 = 'upcoming' AND t.start_date > NOW()))
+        AND ($9::timestamptz IS NULL OR (t.created_at, t.id) < ($9, $10::uuid))
+        AND ($4::text = '' OR EXISTS (
+            SELECT 1
+            FROM trip_tags tt
+            JOIN tags g ON g.id = tt.tag_id
+            WHERE tt.trip_id = t.id AND g.name = $4
+        ))
+        ORDER BY `+orderBy+`
         LIMIT $2 OFFSET $3
-    `, userID, limit, offset)
+    `, userID, limit, offset, filter.Tag, filter.Wishlist, filter.From, filter.To, filter.Status, afterCreatedAt, afterID)
 
 	if err != nil {
 		return nil, err
@@ -186,6 +582,10 @@ func (r *TripRepository) GetTripsByUserID(ctx context.Context, userID uuid.UUID,
 			&trip.StartDate,
 			&trip.EndDate,
 			&trip.Location,
+			&trip.IsWishlist,
+			&trip.Visibility,
+			&trip.ShareToken,
+			&trip.CoverImageURL,
 			&trip.CreatedAt,
 			&trip.UpdatedAt,
 		)
@@ -201,9 +601,368 @@ func (r *TripRepository) GetTripsByUserID(ctx context.Context, userID uuid.UUID,
 		return nil, err
 	}
 
+	if err := r.attachTags(ctx, trips...); err != nil {
+		return nil, err
+	}
+
+	return trips, nil
+}
+
+// GetTripListVersion returns a summary of the user's active trips that changes
+// whenever their listing does. Anything that changes a listed trip moves its
+// updated_at, including tag, activity and expense changes via triggers.
+func (r *TripRepository) GetTripListVersion(ctx context.Context, userID uuid.UUID) (*models.TripListVersion, error) {
+	version := new(models.TripListVersion)
+
+	err := r.db.QueryRow(ctx, `
+        SELECT MAX(updated_at),
+               COUNT(*),
+               COUNT(*) FILTER (WHERE start_date <= NOW()),
+               COUNT(*) FILTER (WHERE end_date + INTERVAL '1 day' <= NOW())
+        FROM trips
+        WHERE user_id = $1 AND deleted_at IS NULL
+    `, userID).Scan(&version.LatestUpdate, &version.Count, &version.Started, &version.Ended)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return version, nil
+}
+
+// GetTripStartDates returns the start dates of the user's planned trips, earliest first
+func (r *TripRepository) GetTripStartDates(ctx context.Context, userID uuid.UUID) ([]time.Time, error) {
+	rows, err := r.db.Query(ctx, `
+        SELECT start_date
+        FROM trips
+        WHERE user_id = $1 AND deleted_at IS NULL AND NOT is_wishlist AND start_date IS NOT NULL
+        ORDER BY start_date
+    `, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return pgx.CollectRows(rows, pgx.RowTo[time.Time])
+}
+
+// GetUpcomingTrips returns the user's trips starting after now and at most
+// within days from now, soonest first
+func (r *TripRepository) GetUpcomingTrips(ctx context.Context, userID uuid.UUID, within int) ([]*models.Trip, error) {
+	rows, err := r.db.Query(ctx, `
+        SELECT id, user_id, name, description, start_date, end_date, location, is_wishlist, visibility, share_token, cover_image_url, created_at, updated_at
+        FROM trips
+        WHERE user_id = $1 AND deleted_at IS NULL AND NOT is_wishlist
+        AND start_date > NOW() AND start_date <= NOW() + make_interval(days => $2)
+        ORDER BY start_date, id
+    `, userID, within)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var trips []*models.Trip
+	for rows.Next() {
+		trip := new(models.Trip)
+		if err := rows.Scan(
+			&trip.ID,
+			&trip.UserID,
+			&trip.Name,
+			&trip.Description,
+			&trip.StartDate,
+			&trip.EndDate,
+			&trip.Location,
+			&trip.IsWishlist,
+			&trip.Visibility,
+			&trip.ShareToken,
+			&trip.CoverImageURL,
+			&trip.CreatedAt,
+			&trip.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		trips = append(trips, trip)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := r.attachTags(ctx, trips...); err != nil {
+		return nil, err
+	}
+
 	return trips, nil
 }
 
+// GetTripsByLocation returns the user's planned trips whose location matches
+// an already normalized one, earliest first. Locations are compared the same
+// way they are normalized: lowercased, with runs of whitespace collapsed.
+func (r *TripRepository) GetTripsByLocation(ctx context.Context, userID uuid.UUID, location string) ([]*models.Trip, error) {
+	rows, err := r.db.Query(ctx, `
+        SELECT id, user_id, name, description, start_date, end_date, location, is_wishlist, visibility, share_token, cover_image_url, created_at, updated_at
+        FROM trips
+        WHERE user_id = $1 AND deleted_at IS NULL AND NOT is_wishlist
+        AND LOWER(BTRIM(REGEXP_REPLACE(location, '\s+', ' ', 'g'))) = $2
+        ORDER BY start_date, id
+    `, userID, location)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var trips []*models.Trip
+	for rows.Next() {
+		trip := new(models.Trip)
+		if err := rows.Scan(
+			&trip.ID,
+			&trip.UserID,
+			&trip.Name,
+			&trip.Description,
+			&trip.StartDate,
+			&trip.EndDate,
+			&trip.Location,
+			&trip.IsWishlist,
+			&trip.Visibility,
+			&trip.ShareToken,
+			&trip.CoverImageURL,
+			&trip.CreatedAt,
+			&trip.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		trips = append(trips, trip)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := r.attachTags(ctx, trips...); err != nil {
+		return nil, err
+	}
+
+	return trips, nil
+}
+
+// GetTripsStartingOn returns every user's planned trips that start on day,
+// which must be a trip day (midnight UTC)
+func (r *TripRepository) GetTripsStartingOn(ctx context.Context, day time.Time) ([]*models.Trip, error) {
+	rows, err := r.db.Query(ctx, `
+        SELECT id, user_id, name, description, start_date, end_date, location, is_wishlist, visibility, share_token, cover_image_url, created_at, updated_at
+        FROM trips
+        WHERE start_date = $1 AND deleted_at IS NULL AND NOT is_wishlist
+        ORDER BY user_id, id
+    `, day)
+	if err != nil {
+		return nil, err
+	}
+
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (*models.Trip, error) {
+		trip := new(models.Trip)
+		err := row.Scan(
+			&trip.ID,
+			&trip.UserID,
+			&trip.Name,
+			&trip.Description,
+			&trip.StartDate,
+			&trip.EndDate,
+			&trip.Location,
+			&trip.IsWishlist,
+			&trip.Visibility,
+			&trip.ShareToken,
+			&trip.CoverImageURL,
+			&trip.CreatedAt,
+			&trip.UpdatedAt,
+		)
+		return trip, err
+	})
+}
+
+// tripReminderColumns are the columns scanned by scanTripReminder
+const tripReminderColumns = `r.id, r.trip_id, r.trip_start, r.token, r.remind_at, r.sent_at, r.acknowledged_at, r.created_at`
+
+func scanTripReminder(row pgx.Row, reminder *models.TripReminder, extra ...any) error {
+	return row.Scan(append([]any{
+		&reminder.ID,
+		&reminder.TripID,
+		&reminder.TripStart,
+		&reminder.Token,
+		&reminder.RemindAt,
+		&reminder.SentAt,
+		&reminder.AcknowledgedAt,
+		&reminder.CreatedAt,
+	}, extra...)...)
+}
+
+// CreateReminder adds a reminder for the trip's start on tripStart, due at
+// remindAt. A trip that already has one for that start is left alone.
+func (r *TripRepository) CreateReminder(ctx context.Context, tripID uuid.UUID, tripStart time.Time, remindAt time.Time) error {
+	tokenBytes := make([]byte, 32)
+	if _, err := rand.Read(tokenBytes); err != nil {
+		return fmt.Errorf("failed to generate reminder token: %w", err)
+	}
+
+	_, err := r.db.Exec(ctx, `
+        INSERT INTO trip_reminders (trip_id, trip_start, token, remind_at)
+        VALUES ($1, $2, $3, $4)
+        ON CONFLICT (trip_id, trip_start) DO NOTHING
+    `, tripID, tripStart, base64.RawURLEncoding.EncodeToString(tokenBytes), remindAt)
+
+	return err
+}
+
+// GetDueReminders returns the unacknowledged reminders whose remind_at has
+// passed and that haven't been sent since, oldest first, with their trips.
+// Reminders for a start date the trip no longer has are skipped.
+func (r *TripRepository) GetDueReminders(ctx context.Context, now time.Time) ([]*models.TripReminder, error) {
+	rows, err := r.db.Query(ctx, `
+        SELECT `+tripReminderColumns+`,
+            t.id, t.user_id, t.name, t.description, t.start_date, t.end_date, t.location, t.is_wishlist, t.visibility, t.share_token, t.cover_image_url, t.created_at, t.updated_at
+        FROM trip_reminders r
+        JOIN trips t ON t.id = r.trip_id
+        WHERE r.acknowledged_at IS NULL AND r.remind_at <= $1
+        AND (r.sent_at IS NULL OR r.sent_at < r.remind_at)
+        AND t.deleted_at IS NULL AND t.start_date = r.trip_start
+        ORDER BY r.remind_at, r.id
+    `, now)
+	if err != nil {
+		return nil, err
+	}
+
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (*models.TripReminder, error) {
+		reminder := &models.TripReminder{Trip: new(models.Trip)}
+		trip := reminder.Trip
+		err := scanTripReminder(row, reminder,
+			&trip.ID,
+			&trip.UserID,
+			&trip.Name,
+			&trip.Description,
+			&trip.StartDate,
+			&trip.EndDate,
+			&trip.Location,
+			&trip.IsWishlist,
+			&trip.Visibility,
+			&trip.ShareToken,
+			&trip.CoverImageURL,
+			&trip.CreatedAt,
+			&trip.UpdatedAt,
+		)
+		return reminder, err
+	})
+}
+
+// MarkReminderSent records when a reminder was last sent, so it isn't due
+// again until it is snoozed
+func (r *TripRepository) MarkReminderSent(ctx context.Context, reminderID uuid.UUID, sentAt time.Time) error {
+	_, err := r.db.Exec(ctx, `
+        UPDATE trip_reminders
+        SET sent_at = $2
+        WHERE id = $1
+    `, reminderID, sentAt)
+
+	return err
+}
+
+// GetReminderByToken finds the reminder a link was sent for
+func (r *TripRepository) GetReminderByToken(ctx context.Context, token string) (*models.TripReminder, error) {
+	reminder := new(models.TripReminder)
+
+	err := scanTripReminder(r.db.QueryRow(ctx, `
+        SELECT `+tripReminderColumns+`
+        FROM trip_reminders r
+        JOIN trips t ON t.id = r.trip_id
+        WHERE r.token = $1 AND t.deleted_at IS NULL
+    `, token), reminder)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("reminder not found")
+		}
+		return nil, err
+	}
+
+	return reminder, nil
+}
+
+// AcknowledgeReminder marks a reminder handled, keeping the time it first was
+func (r *TripRepository) AcknowledgeReminder(ctx context.Context, reminderID uuid.UUID) (*models.TripReminder, error) {
+	return r.updateReminder(ctx, `
+        UPDATE trip_reminders r
+        SET acknowledged_at = COALESCE(acknowledged_at, NOW())
+        WHERE id = $1
+        RETURNING `+tripReminderColumns, reminderID)
+}
+
+// SnoozeReminder makes a reminder due again at until
+func (r *TripRepository) SnoozeReminder(ctx context.Context, reminderID uuid.UUID, until time.Time) (*models.TripReminder, error) {
+	return r.updateReminder(ctx, `
+        UPDATE trip_reminders r
+        SET remind_at = $2
+        WHERE id = $1
+        RETURNING `+tripReminderColumns, reminderID, until)
+}
+
+// updateReminder runs an UPDATE ... RETURNING of a single reminder
+func (r *TripRepository) updateReminder(ctx context.Context, sql string, args ...any) (*models.TripReminder, error) {
+	reminder := new(models.TripReminder)
+
+	if err := scanTripReminder(r.db.QueryRow(ctx, sql, args...), reminder); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("reminder not found")
+		}
+		return nil, err
+	}
+
+	return reminder, nil
+}
+
+// ReorderTrips stores the user's manual trip order in a single transaction.
+// Trips left out of tripIDs lose their position and sort after the ordered ones.
+// Trips whose position changes are touched so the list version moves with them.
+func (r *TripRepository) ReorderTrips(ctx context.Context, userID uuid.UUID, tripIDs []uuid.UUID) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	// Every ID must be one of the user's active trips
+	var owned int
+	err = tx.QueryRow(ctx, `
+        SELECT COUNT(*)
+        FROM trips
+        WHERE user_id = $1 AND id = ANY($2) AND deleted_at IS NULL
+    `, userID, tripIDs).Scan(&owned)
+	if err != nil {
+		return err
+	}
+
+	if owned != len(tripIDs) {
+		return errors.New("invalid trip order")
+	}
+
+	_, err = tx.Exec(ctx, `
+        UPDATE trips
+        SET order_index = NULL, updated_at = NOW()
+        WHERE user_id = $1 AND NOT (id = ANY($2)) AND order_index IS NOT NULL
+    `, userID, tripIDs)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+        UPDATE trips t
+        SET order_index = o.position, updated_at = NOW()
+        FROM unnest($2::uuid[]) WITH ORDINALITY AS o(id, position)
+        WHERE t.id = o.id AND t.user_id = $1 AND t.order_index IS DISTINCT FROM o.position
+    `, userID, tripIDs)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
 // GetTripWithUser retrieves a trip and its user in a single operation
 func (r *TripRepository) GetTripWithUser(ctx context.Context, tripID uuid.UUID) (*models.Trip, error) {
 	// Get the trip first
@@ -235,3 +994,325 @@ func (r *TripRepository) GetTripWithUser(ctx context.Context, tripID uuid.UUID)
 	trip.User = user
 	return trip, nil
 }
+
+// ImportTrip recreates an exported trip for a user in a single transaction
+func (r *TripRepository) ImportTrip(ctx context.Context, userID uuid.UUID, export models.TripExport) (*models.TripImportResult, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	trip := new(models.Trip)
+	err = tx.QueryRow(ctx, `
+		INSERT INTO trips (user_id, name, description, start_date, end_date, location, is_wishlist, cover_image_url)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id, user_id, name, description, start_date, end_date, location, is_wishlist, visibility, share_token, cover_image_url, created_at, updated_at
+	`,
+		userID,
+		export.Trip.Name,
+		export.Trip.Description,
+		optionalDate(export.Trip.StartDate),
+		optionalDate(export.Trip.EndDate),
+		export.Trip.Location,
+		export.Trip.IsWishlist,
+		export.Trip.CoverImageURL).Scan(
+		&trip.ID,
+		&trip.UserID,
+		&trip.Name,
+		&trip.Description,
+		&trip.StartDate,
+		&trip.EndDate,
+		&trip.Location,
+		&trip.IsWishlist,
+		&trip.Visibility,
+		&trip.ShareToken,
+		&trip.CoverImageURL,
+		&trip.CreatedAt,
+		&trip.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Activities get fresh IDs under the new trip
+	for _, activity := range export.Activities {
+		_, err = tx.Exec(ctx, `
+			INSERT INTO activities (trip_id, title, description, start_time, end_time, location)
+			VALUES ($1, $2, $3, $4, $5, $6)
+		`,
+			trip.ID,
+			activity.Title,
+			activity.Description,
+			activity.StartTime,
+			activity.EndTime,
+			activity.Location)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, expense := range export.Expenses {
+		_, err = tx.Exec(ctx, `
+			INSERT INTO expenses (trip_id, amount_cents, currency, category, note, paid_by, split_among, incurred_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		`,
+			trip.ID,
+			expense.AmountCents,
+			expense.Currency,
+			expense.Category,
+			expense.Note,
+			expense.PaidBy,
+			splitAmong(expense.SplitAmong),
+			expense.IncurredAt)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Tags are the importing user's own, created on first use like AddTripTag
+	for _, name := range export.Tags {
+		_, err = tx.Exec(ctx, `
+			WITH tag AS (
+				INSERT INTO tags (user_id, name)
+				VALUES ($1, $2)
+				ON CONFLICT (user_id, name) DO UPDATE SET name = EXCLUDED.name
+				RETURNING id
+			)
+			INSERT INTO trip_tags (trip_id, tag_id)
+			SELECT $3, id FROM tag
+			ON CONFLICT DO NOTHING
+		`, userID, name, trip.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return &models.TripImportResult{
+		Trip: trip,
+		Imported: map[string]int{
+			"trips":      1,
+			"activities": len(export.Activities),
+			"expenses":   len(export.Expenses),
+			"tags":       len(export.Tags),
+		},
+	}, nil
+}
+
+// GetTripActivities returns a trip's activities ordered by start time
+func (r *TripRepository) GetTripActivities(ctx context.Context, tripID uuid.UUID) ([]*models.Activity, error) {
+	return queryActivitiesByTripID(ctx, r.db, tripID)
+}
+
+// GetTripExpenses returns a trip's expenses, most recent first
+func (r *TripRepository) GetTripExpenses(ctx context.Context, tripID uuid.UUID) ([]*models.Expense, error) {
+	return queryExpensesByTripID(ctx, r.db, tripID)
+}
+
+// GetTripPhotos returns a trip's photos, oldest first
+func (r *TripRepository) GetTripPhotos(ctx context.Context, tripID uuid.UUID) ([]*models.TripPhoto, error) {
+	return queryPhotosByTripID(ctx, r.db, tripID)
+}
+
+// CountTripPhotos returns how many photos a trip has
+func (r *TripRepository) CountTripPhotos(ctx context.Context, tripID uuid.UUID) (int, error) {
+	var count int
+	err := r.db.QueryRow(ctx, `
+		SELECT COUNT(*) FROM trip_photos WHERE trip_id = $1
+	`, tripID).Scan(&count)
+	return count, err
+}
+
+// AddTripTag attaches a tag to a trip, creating the user's tag on first use.
+// Attaching a tag the trip already has is a no-op.
+func (r *TripRepository) AddTripTag(ctx context.Context, tripID uuid.UUID, userID uuid.UUID, name string) (*models.Tag, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	tag := new(models.Tag)
+	err = tx.QueryRow(ctx, `
+		INSERT INTO tags (user_id, name)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id, name) DO UPDATE SET name = EXCLUDED.name
+		RETURNING id, user_id, name, created_at
+	`, userID, name).Scan(
+		&tag.ID,
+		&tag.UserID,
+		&tag.Name,
+		&tag.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO trip_tags (trip_id, tag_id)
+		VALUES ($1, $2)
+		ON CONFLICT DO NOTHING
+	`, tripID, tag.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return tag, nil
+}
+
+// BulkTagTrips applies the named tags to whichever of tripIDs the user owns, in
+// one transaction. With replace, those trips' existing tags are removed first.
+// It returns the IDs of the trips that were tagged.
+func (r *TripRepository) BulkTagTrips(ctx context.Context, userID uuid.UUID, tripIDs []uuid.UUID, names []string, replace bool) ([]uuid.UUID, error) {
+	var tagged []uuid.UUID
+
+	err := db.WithTx(ctx, r.db, func(tx pgx.Tx) error {
+		rows, err := tx.Query(ctx, `
+			SELECT id
+			FROM trips
+			WHERE user_id = $1 AND id = ANY($2) AND deleted_at IS NULL
+			FOR UPDATE
+		`, userID, tripIDs)
+		if err != nil {
+			return err
+		}
+
+		tagged, err = pgx.CollectRows(rows, pgx.RowTo[uuid.UUID])
+		if err != nil {
+			return err
+		}
+
+		if len(tagged) == 0 {
+			return nil
+		}
+
+		tagIDs := make([]uuid.UUID, 0, len(names))
+		for _, name := range names {
+			var tagID uuid.UUID
+			err := tx.QueryRow(ctx, `
+				INSERT INTO tags (user_id, name)
+				VALUES ($1, $2)
+				ON CONFLICT (user_id, name) DO UPDATE SET name = EXCLUDED.name
+				RETURNING id
+			`, userID, name).Scan(&tagID)
+			if err != nil {
+				return err
+			}
+			tagIDs = append(tagIDs, tagID)
+		}
+
+		if replace {
+			_, err = tx.Exec(ctx, `
+				DELETE FROM trip_tags WHERE trip_id = ANY($1)
+			`, tagged)
+			if err != nil {
+				return err
+			}
+		}
+
+		_, err = tx.Exec(ctx, `
+			INSERT INTO trip_tags (trip_id, tag_id)
+			SELECT trip_id, tag_id
+			FROM unnest($1::uuid[]) AS trip_id
+			CROSS JOIN unnest($2::uuid[]) AS tag_id
+			ON CONFLICT DO NOTHING
+		`, tagged, tagIDs)
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tagged, nil
+}
+
+// RemoveTripTag detaches the user's tag with the given name from a trip
+func (r *TripRepository) RemoveTripTag(ctx context.Context, tripID uuid.UUID, userID uuid.UUID, name string) error {
+	commandTag, err := r.db.Exec(ctx, `
+		DELETE FROM trip_tags tt
+		USING tags g
+		WHERE tt.tag_id = g.id
+		AND tt.trip_id = $1
+		AND g.user_id = $2
+		AND g.name = $3
+	`, tripID, userID, name)
+
+	if err != nil {
+		return err
+	}
+
+	if commandTag.RowsAffected() == 0 {
+		return errors.New("tag not found")
+	}
+
+	return nil
+}
+
+// GetTripTags lists the tags attached to a trip, ordered by name
+func (r *TripRepository) GetTripTags(ctx context.Context, tripID uuid.UUID) ([]*models.Tag, error) {
+	trip := &models.Trip{ID: tripID}
+	if err := r.attachTags(ctx, trip); err != nil {
+		return nil, err
+	}
+
+	return trip.Tags, nil
+}
+
+// attachTags loads the tags for the given trips in a single query
+func (r *TripRepository) attachTags(ctx context.Context, trips ...*models.Trip) error {
+	if len(trips) == 0 {
+		return nil
+	}
+
+	tripIDs := make([]uuid.UUID, 0, len(trips))
+	byID := make(map[uuid.UUID]*models.Trip, len(trips))
+	for _, trip := range trips {
+		trip.Tags = []*models.Tag{}
+		tripIDs = append(tripIDs, trip.ID)
+		byID[trip.ID] = trip
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT tt.trip_id, g.id, g.user_id, g.name, g.created_at
+		FROM trip_tags tt
+		JOIN tags g ON g.id = tt.tag_id
+		WHERE tt.trip_id = ANY($1)
+		ORDER BY g.name
+	`, tripIDs)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var tripID uuid.UUID
+		tag := new(models.Tag)
+
+		if err := rows.Scan(&tripID, &tag.ID, &tag.UserID, &tag.Name, &tag.CreatedAt); err != nil {
+			return err
+		}
+
+		if trip, ok := byID[tripID]; ok {
+			trip.Tags = append(trip.Tags, tag)
+		}
+	}
+
+	return rows.Err()
+}
+
+// optionalDate stores a zero date, as sent for undated wishlist trips, as NULL
+func optionalDate(date time.Time) *time.Time {
+	if date.IsZero() {
+		return nil
+	}
+	return &date
+}
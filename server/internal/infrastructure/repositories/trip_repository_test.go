@@ -0,0 +1,518 @@
+package repositories_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"black-lotus/internal/domain/models"
+	"black-lotus/internal/infrastructure/repositories"
+	"black-lotus/pkg/db"
+)
+
+func TestTripRepositoryShareLinks(t *testing.T) {
+	setupRepositoryTest(t)
+	ctx := context.Background()
+
+	// Setup: a private trip
+	var userID, tripID uuid.UUID
+	err := db.TestDB.QueryRow(ctx, `
+		INSERT INTO users (name, email) VALUES ('Test User', 'share@example.com') RETURNING id
+	`).Scan(&userID)
+	if err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+
+	err = db.TestDB.QueryRow(ctx, `
+		INSERT INTO trips (user_id, name, location, is_wishlist)
+		VALUES ($1, 'Test Trip', 'Lisbon', TRUE)
+		RETURNING id
+	`, userID).Scan(&tripID)
+	if err != nil {
+		t.Fatalf("Failed to create trip: %v", err)
+	}
+
+	trips := repositories.NewTripRepository(db.TestDB)
+
+	// Execute & verify: share, share again, read, revoke
+	share, err := trips.ShareTrip(ctx, tripID)
+	if err != nil {
+		t.Fatalf("Failed to share trip: %v", err)
+	}
+	if share.Visibility != models.TripVisibilityUnlisted || share.ShareToken == "" {
+		t.Fatalf("Expected an unlisted trip with a token, got %+v", share)
+	}
+
+	again, err := trips.ShareTrip(ctx, tripID)
+	if err != nil {
+		t.Fatalf("Failed to share trip again: %v", err)
+	}
+	if again.ShareToken != share.ShareToken {
+		t.Errorf("Expected sharing again to keep token %q, got %q", share.ShareToken, again.ShareToken)
+	}
+
+	shared, err := trips.GetTripByShareToken(ctx, share.ShareToken)
+	if err != nil || shared.ID != tripID {
+		t.Fatalf("Expected trip %s by token, got %v, %v", tripID, shared, err)
+	}
+
+	// The owner's reads carry the share state
+	owned, err := trips.GetTripByID(ctx, tripID)
+	if err != nil {
+		t.Fatalf("Failed to get trip: %v", err)
+	}
+	if owned.Visibility != models.TripVisibilityUnlisted || owned.ShareToken == nil || *owned.ShareToken != share.ShareToken {
+		t.Errorf("Expected the trip to be unlisted with token %q, got %q, %v", share.ShareToken, owned.Visibility, owned.ShareToken)
+	}
+	listed, err := trips.GetTripsByUserID(ctx, userID, 10, 0, models.TripFilter{})
+	if err != nil || len(listed) != 1 || listed[0].ShareToken == nil {
+		t.Errorf("Expected the listed trip to carry its token, got %v, %v", listed, err)
+	}
+
+	if err := trips.UnshareTrip(ctx, tripID); err != nil {
+		t.Fatalf("Failed to unshare trip: %v", err)
+	}
+	owned, err = trips.GetTripByID(ctx, tripID)
+	if err != nil {
+		t.Fatalf("Failed to get trip: %v", err)
+	}
+	if owned.Visibility != models.TripVisibilityPrivate || owned.ShareToken != nil {
+		t.Errorf("Expected a private trip without a token, got %q, %v", owned.Visibility, owned.ShareToken)
+	}
+	if _, err := trips.GetTripByShareToken(ctx, share.ShareToken); err == nil || err.Error() != "trip not found" {
+		t.Errorf("Expected the revoked token to find nothing, got %v", err)
+	}
+
+	// A private trip is never returned, even if its old token is still stored
+	if _, err := db.TestDB.Exec(ctx, `UPDATE trips SET share_token = 'stale' WHERE id = $1`, tripID); err != nil {
+		t.Fatalf("Failed to set stale token: %v", err)
+	}
+	if _, err := trips.GetTripByShareToken(ctx, "stale"); err == nil || err.Error() != "trip not found" {
+		t.Errorf("Expected a private trip to stay hidden, got %v", err)
+	}
+}
+
+func TestTripRepositoryCursorPagination(t *testing.T) {
+	setupRepositoryTest(t)
+	ctx := context.Background()
+
+	var userID uuid.UUID
+	err := db.TestDB.QueryRow(ctx, `
+		INSERT INTO users (name, email) VALUES ('Test User', 'cursor@example.com') RETURNING id
+	`).Scan(&userID)
+	if err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+
+	insertTrip := func(createdAt time.Time) uuid.UUID {
+		var tripID uuid.UUID
+		err := db.TestDB.QueryRow(ctx, `
+			INSERT INTO trips (user_id, name, location, is_wishlist, created_at)
+			VALUES ($1, 'Test Trip', 'Lisbon', TRUE, $2)
+			RETURNING id
+		`, userID, createdAt).Scan(&tripID)
+		if err != nil {
+			t.Fatalf("Failed to create trip: %v", err)
+		}
+		return tripID
+	}
+
+	// Setup: three trips an hour apart
+	base := time.Now().Add(-24 * time.Hour).UTC().Truncate(time.Microsecond)
+	for i := 0; i < 3; i++ {
+		insertTrip(base.Add(time.Duration(i) * time.Hour))
+	}
+
+	trips := repositories.NewTripRepository(db.TestDB)
+
+	first, err := trips.GetTripsByUserID(ctx, userID, 2, 0, models.TripFilter{Cursor: &models.TripCursor{}})
+	if err != nil || len(first) != 2 {
+		t.Fatalf("Expected a first page of 2, got %d, %v", len(first), err)
+	}
+
+	// A trip created after the first page was read must not shift the second page
+	insertTrip(time.Now().UTC())
+
+	last := first[1]
+	second, err := trips.GetTripsByUserID(ctx, userID, 2, 0, models.TripFilter{
+		Cursor: &models.TripCursor{CreatedAt: last.CreatedAt, ID: last.ID},
+	})
+	if err != nil {
+		t.Fatalf("Failed to get second page: %v", err)
+	}
+
+	if len(second) != 1 || !second[0].CreatedAt.Equal(base) {
+		t.Errorf("Expected only the oldest trip on the second page, got %d trips", len(second))
+	}
+	for _, trip := range second {
+		if trip.ID == first[0].ID || trip.ID == first[1].ID {
+			t.Errorf("Expected no trip from the first page to repeat, got %s", trip.ID)
+		}
+	}
+}
+
+func TestTripRepositoryCoverImage(t *testing.T) {
+	setupRepositoryTest(t)
+	ctx := context.Background()
+
+	var userID uuid.UUID
+	err := db.TestDB.QueryRow(ctx, `
+		INSERT INTO users (name, email) VALUES ('Test User', 'cover@example.com') RETURNING id
+	`).Scan(&userID)
+	if err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+
+	trips := repositories.NewTripRepository(db.TestDB)
+
+	created, err := trips.CreateTrip(ctx, userID, models.CreateTripInput{
+		Location:      "Lisbon",
+		IsWishlist:    true,
+		CoverImageURL: "https://example.com/tram.jpg",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create trip: %v", err)
+	}
+	if created.CoverImageURL != "https://example.com/tram.jpg" {
+		t.Errorf("Expected the cover to be stored, got %q", created.CoverImageURL)
+	}
+
+	// An empty cover removes it
+	updated, err := trips.UpdateTrip(ctx, created.ID, models.UpdateTripInput{CoverImageURL: new(string)})
+	if err != nil {
+		t.Fatalf("Failed to update trip: %v", err)
+	}
+	if updated.CoverImageURL != "" {
+		t.Errorf("Expected the cover to be removed, got %q", updated.CoverImageURL)
+	}
+}
+
+func TestTripRepositoryStatusFilterPagination(t *testing.T) {
+	setupRepositoryTest(t)
+	ctx := context.Background()
+
+	var userID uuid.UUID
+	err := db.TestDB.QueryRow(ctx, `
+		INSERT INTO users (name, email) VALUES ('Test User', 'status@example.com') RETURNING id
+	`).Scan(&userID)
+	if err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+
+	trips := repositories.NewTripRepository(db.TestDB)
+	today := models.TripDay(time.Now())
+	createTrip := func(start, end time.Time) uuid.UUID {
+		trip, err := trips.CreateTrip(ctx, userID, models.CreateTripInput{Name: "Test Trip", Location: "Lisbon", StartDate: start, EndDate: end})
+		if err != nil {
+			t.Fatalf("Failed to create trip: %v", err)
+		}
+		return trip.ID
+	}
+
+	// Setup: five past trips, one whose last day is today and two upcoming
+	past := make(map[uuid.UUID]bool)
+	for i := 1; i <= 5; i++ {
+		past[createTrip(today.AddDate(0, 0, -10*i), today.AddDate(0, 0, -10*i+3))] = true
+	}
+	endsToday := createTrip(today.AddDate(0, 0, -2), today)
+	createTrip(today.AddDate(0, 0, 5), today.AddDate(0, 0, 8))
+	createTrip(today.AddDate(0, 0, 20), today.AddDate(0, 0, 21))
+
+	// The filter runs in SQL, so every page is full until the matches run out
+	seen := make(map[uuid.UUID]bool)
+	for offset, expected := range []int{2, 2, 1, 0} {
+		page, err := trips.GetTripsByUserID(ctx, userID, 2, offset*2, models.TripFilter{Status: models.TripStatusPast})
+		if err != nil {
+			t.Fatalf("Failed to get page %d: %v", offset, err)
+		}
+		if len(page) != expected {
+			t.Errorf("Expected %d past trips on page %d, got %d", expected, offset, len(page))
+		}
+		for _, trip := range page {
+			if !past[trip.ID] || seen[trip.ID] {
+				t.Errorf("Expected each past trip exactly once, got %s again or out of place", trip.ID)
+			}
+			seen[trip.ID] = true
+		}
+	}
+
+	ongoing, err := trips.GetTripsByUserID(ctx, userID, 10, 0, models.TripFilter{Status: models.TripStatusOngoing})
+	if err != nil {
+		t.Fatalf("Failed to get ongoing trips: %v", err)
+	}
+	if len(ongoing) != 1 || ongoing[0].ID != endsToday {
+		t.Errorf("Expected only the trip ending today to be ongoing, got %d trips", len(ongoing))
+	}
+}
+
+func TestTripRepositoryGetUpcomingTrips(t *testing.T) {
+	setupRepositoryTest(t)
+	ctx := context.Background()
+
+	var userID uuid.UUID
+	err := db.TestDB.QueryRow(ctx, `
+		INSERT INTO users (name, email) VALUES ('Test User', 'upcoming@example.com') RETURNING id
+	`).Scan(&userID)
+	if err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+
+	trips := repositories.NewTripRepository(db.TestDB)
+	today := models.TripDay(time.Now())
+	createTrip := func(start, end time.Time) uuid.UUID {
+		trip, err := trips.CreateTrip(ctx, userID, models.CreateTripInput{Name: "Test Trip", Location: "Lisbon", StartDate: start, EndDate: end})
+		if err != nil {
+			t.Fatalf("Failed to create trip: %v", err)
+		}
+		return trip.ID
+	}
+
+	// Setup: created out of order, with trips already started and too far out
+	later := createTrip(today.AddDate(0, 0, 6), today.AddDate(0, 0, 9))
+	sooner := createTrip(today.AddDate(0, 0, 2), today.AddDate(0, 0, 4))
+	createTrip(today.AddDate(0, 0, -1), today.AddDate(0, 0, 1))
+	createTrip(today.AddDate(0, 0, 30), today.AddDate(0, 0, 31))
+
+	upcoming, err := trips.GetUpcomingTrips(ctx, userID, 7)
+	if err != nil {
+		t.Fatalf("Failed to get upcoming trips: %v", err)
+	}
+
+	if len(upcoming) != 2 || upcoming[0].ID != sooner || upcoming[1].ID != later {
+		t.Errorf("Expected the two trips starting within 7 days, soonest first, got %d trips", len(upcoming))
+	}
+}
+
+func TestTripRepositoryGetTripsByLocation(t *testing.T) {
+	setupRepositoryTest(t)
+	ctx := context.Background()
+
+	var userID uuid.UUID
+	err := db.TestDB.QueryRow(ctx, `
+		INSERT INTO users (name, email) VALUES ('Test User', 'by-location@example.com') RETURNING id
+	`).Scan(&userID)
+	if err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+
+	trips := repositories.NewTripRepository(db.TestDB)
+	start := models.TripDay(time.Now())
+	createTrip := func(location string, offset int) uuid.UUID {
+		trip, err := trips.CreateTrip(ctx, userID, models.CreateTripInput{
+			Name:      "Test Trip",
+			Location:  location,
+			StartDate: start.AddDate(0, 0, offset),
+			EndDate:   start.AddDate(0, 0, offset+2),
+		})
+		if err != nil {
+			t.Fatalf("Failed to create trip: %v", err)
+		}
+		return trip.ID
+	}
+
+	// Setup: the same city spelled three ways, created out of order, and a different city
+	second := createTrip("PARIS", 40)
+	first := createTrip("  Paris ", 10)
+	third := createTrip("paris", 70)
+	createTrip("Paris, Texas", 20)
+
+	matched, err := trips.GetTripsByLocation(ctx, userID, "paris")
+	if err != nil {
+		t.Fatalf("Failed to get trips by location: %v", err)
+	}
+
+	if len(matched) != 3 || matched[0].ID != first || matched[1].ID != second || matched[2].ID != third {
+		t.Errorf("Expected the three Paris trips, earliest first, got %d trips", len(matched))
+	}
+}
+
+func TestTripRepositoryImportTrip(t *testing.T) {
+	setupRepositoryTest(t)
+	ctx := context.Background()
+
+	var userID uuid.UUID
+	err := db.TestDB.QueryRow(ctx, `
+		INSERT INTO users (name, email) VALUES ('Test User', 'import@example.com') RETURNING id
+	`).Scan(&userID)
+	if err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+
+	trips := repositories.NewTripRepository(db.TestDB)
+	start := models.TripDay(time.Now()).AddDate(0, 0, 10)
+
+	// Setup: the user already has the "japan" tag, which the import reuses
+	existing, err := trips.CreateTrip(ctx, userID, models.CreateTripInput{Name: "Earlier", Location: "Osaka", StartDate: start, EndDate: start})
+	if err != nil {
+		t.Fatalf("Failed to create trip: %v", err)
+	}
+	if _, err := trips.AddTripTag(ctx, existing.ID, userID, "japan"); err != nil {
+		t.Fatalf("Failed to tag trip: %v", err)
+	}
+
+	result, err := trips.ImportTrip(ctx, userID, models.TripExport{
+		Version: models.TripExportVersion,
+		Trip:    models.CreateTripInput{Name: "Kyoto", Location: "Kyoto", StartDate: start, EndDate: start.AddDate(0, 0, 3)},
+		Activities: []models.CreateActivityInput{
+			{Title: "Fushimi Inari", StartTime: start.Add(9 * time.Hour), EndTime: start.Add(12 * time.Hour)},
+		},
+		Expenses: []models.CreateExpenseInput{
+			{AmountCents: 12000, Currency: "JPY", Category: "lodging", PaidBy: "Ana", SplitAmong: []string{"Ana", "Rui"}, IncurredAt: start},
+		},
+		Tags: []string{"japan", "spring"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to import trip: %v", err)
+	}
+
+	expenses, err := trips.GetTripExpenses(ctx, result.Trip.ID)
+	if err != nil {
+		t.Fatalf("Failed to get expenses: %v", err)
+	}
+	if len(expenses) != 1 || expenses[0].AmountCents != 12000 || len(expenses[0].SplitAmong) != 2 {
+		t.Errorf("Expected the imported expense, got %+v", expenses)
+	}
+
+	tags, err := trips.GetTripTags(ctx, result.Trip.ID)
+	if err != nil {
+		t.Fatalf("Failed to get tags: %v", err)
+	}
+	if len(tags) != 2 {
+		t.Fatalf("Expected 2 tags, got %d", len(tags))
+	}
+
+	var tagCount int
+	if err := db.TestDB.QueryRow(ctx, `SELECT COUNT(*) FROM tags WHERE user_id = $1`, userID).Scan(&tagCount); err != nil {
+		t.Fatalf("Failed to count tags: %v", err)
+	}
+	if tagCount != 2 {
+		t.Errorf("Expected the existing tag to be reused, got %d tags", tagCount)
+	}
+	if result.Imported["expenses"] != 1 || result.Imported["tags"] != 2 {
+		t.Errorf("Unexpected import counts: %v", result.Imported)
+	}
+}
+
+func TestTripRepositoryReminders(t *testing.T) {
+	setupRepositoryTest(t)
+	ctx := context.Background()
+
+	var userID uuid.UUID
+	err := db.TestDB.QueryRow(ctx, `
+		INSERT INTO users (name, email) VALUES ('Test User', 'reminders@example.com') RETURNING id
+	`).Scan(&userID)
+	if err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+
+	trips := repositories.NewTripRepository(db.TestDB)
+	tomorrow := models.TripDay(time.Now()).AddDate(0, 0, 1)
+	trip, err := trips.CreateTrip(ctx, userID, models.CreateTripInput{Name: "Test Trip", Location: "Lisbon", StartDate: tomorrow, EndDate: tomorrow.AddDate(0, 0, 2)})
+	if err != nil {
+		t.Fatalf("Failed to create trip: %v", err)
+	}
+
+	now := time.Now()
+	due := func(at time.Time) []*models.TripReminder {
+		t.Helper()
+		reminders, err := trips.GetDueReminders(ctx, at)
+		if err != nil {
+			t.Fatalf("Failed to get due reminders: %v", err)
+		}
+		return reminders
+	}
+
+	// Creating twice for the same start leaves a single reminder
+	for i := 0; i < 2; i++ {
+		if err := trips.CreateReminder(ctx, trip.ID, tomorrow, now); err != nil {
+			t.Fatalf("Failed to create reminder: %v", err)
+		}
+	}
+	pending := due(now)
+	if len(pending) != 1 || pending[0].TripID != trip.ID || pending[0].Trip == nil || pending[0].Trip.Name != "Test Trip" {
+		t.Fatalf("Expected one due reminder with its trip, got %d", len(pending))
+	}
+	reminder := pending[0]
+
+	// Once sent it isn't due again
+	if err := trips.MarkReminderSent(ctx, reminder.ID, now); err != nil {
+		t.Fatalf("Failed to mark reminder sent: %v", err)
+	}
+	if len(due(now.Add(time.Hour))) != 0 {
+		t.Error("Expected a sent reminder not to be due")
+	}
+
+	// Snoozing makes it due again once the snooze ends
+	found, err := trips.GetReminderByToken(ctx, reminder.Token)
+	if err != nil || found.ID != reminder.ID {
+		t.Fatalf("Expected to find reminder %s by its token, got %v", reminder.ID, err)
+	}
+	until := now.Add(48 * time.Hour)
+	if _, err := trips.SnoozeReminder(ctx, reminder.ID, until); err != nil {
+		t.Fatalf("Failed to snooze reminder: %v", err)
+	}
+	if len(due(until.Add(-time.Minute))) != 0 {
+		t.Error("Expected a snoozed reminder not to be due before the snooze ends")
+	}
+	if len(due(until)) != 1 {
+		t.Error("Expected a snoozed reminder to be due once the snooze ends")
+	}
+
+	// Acknowledging it stops it for good
+	acknowledged, err := trips.AcknowledgeReminder(ctx, reminder.ID)
+	if err != nil || acknowledged.AcknowledgedAt == nil {
+		t.Fatalf("Expected the reminder to be acknowledged, got %v", err)
+	}
+	if len(due(until.Add(time.Hour))) != 0 {
+		t.Error("Expected an acknowledged reminder not to be due")
+	}
+
+	if _, err := trips.GetReminderByToken(ctx, "unknown"); err == nil || err.Error() != "reminder not found" {
+		t.Errorf("Expected 'reminder not found', got: %v", err)
+	}
+}
+
+func TestTripRepositoryGetTripsStartingOn(t *testing.T) {
+	setupRepositoryTest(t)
+	ctx := context.Background()
+
+	// Setup: two users with trips starting tomorrow, plus ones that don't
+	tripRepo := repositories.NewTripRepository(db.TestDB)
+	tomorrow := models.TripDay(time.Now()).AddDate(0, 0, 1)
+	expected := make(map[uuid.UUID]bool)
+	for _, email := range []string{"remind-a@example.com", "remind-b@example.com"} {
+		var userID uuid.UUID
+		err := db.TestDB.QueryRow(ctx, `
+			INSERT INTO users (name, email) VALUES ('Test User', $1) RETURNING id
+		`, email).Scan(&userID)
+		if err != nil {
+			t.Fatalf("Failed to create user: %v", err)
+		}
+
+		for _, start := range []time.Time{tomorrow, tomorrow.AddDate(0, 0, 1)} {
+			trip, err := tripRepo.CreateTrip(ctx, userID, models.CreateTripInput{Name: "Test Trip", Location: "Lisbon", StartDate: start, EndDate: start.AddDate(0, 0, 2)})
+			if err != nil {
+				t.Fatalf("Failed to create trip: %v", err)
+			}
+			if start.Equal(tomorrow) {
+				expected[trip.ID] = true
+			}
+		}
+	}
+
+	trips, err := tripRepo.GetTripsStartingOn(ctx, tomorrow)
+	if err != nil {
+		t.Fatalf("Failed to get trips starting tomorrow: %v", err)
+	}
+
+	if len(trips) != len(expected) {
+		t.Fatalf("Expected %d trips starting tomorrow, got %d", len(expected), len(trips))
+	}
+	for _, trip := range trips {
+		if !expected[trip.ID] {
+			t.Errorf("Expected only trips starting tomorrow, got %s", trip.ID)
+		}
+	}
+}
@@ -2,7 +2,10 @@ package repositories
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
+	"fmt"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -10,51 +13,65 @@ import (
 	"golang.org/x/crypto/bcrypt"
 
 	"black-lotus/internal/domain/models"
+	"black-lotus/internal/features/admin"
 	"black-lotus/internal/features/auth/login"
 	"black-lotus/internal/features/auth/oauth/github"
 	"black-lotus/internal/features/auth/oauth/google"
+	"black-lotus/internal/features/auth/password"
 	"black-lotus/internal/features/auth/register"
 	"black-lotus/internal/features/auth/user"
+	"black-lotus/internal/features/auth/verification"
+	"black-lotus/internal/features/profiles/edit"
+	"black-lotus/pkg/db"
 )
 
 type UserRepository struct {
-	db *pgxpool.Pool
+	db    *pgxpool.Pool
+	retry RetryPolicy // Applied to user creation
 }
 
 var (
-	_ login.Repository      = (*UserRepository)(nil)
-	_ register.Repository   = (*UserRepository)(nil)
-	_ user.Repository       = (*UserRepository)(nil)
-	_ github.UserRepository = (*UserRepository)(nil)
-	_ google.UserRepository = (*UserRepository)(nil)
+	_ login.Repository        = (*UserRepository)(nil)
+	_ register.Repository     = (*UserRepository)(nil)
+	_ user.Repository         = (*UserRepository)(nil)
+	_ github.UserRepository   = (*UserRepository)(nil)
+	_ google.UserRepository   = (*UserRepository)(nil)
+	_ edit.Repository         = (*UserRepository)(nil)
+	_ password.Repository     = (*UserRepository)(nil)
+	_ verification.Repository = (*UserRepository)(nil)
+	_ admin.UserRepository    = (*UserRepository)(nil)
 )
 
 func NewUserRepository(db *pgxpool.Pool) *UserRepository {
-	return &UserRepository{db: db}
+	return &UserRepository{db: db, retry: RetryPolicyFromEnv()}
 }
 
 func (r *UserRepository) CreateUser(ctx context.Context, input models.CreateUserInput, hashedPassword *string) (*models.User, error) {
-	user := new(models.User)
-
-	err := r.db.QueryRow(ctx, `
-        INSERT INTO users (name, email, hashed_password)
-        VALUES ($1, $2, $3)
-        RETURNING id, name, email, hashed_password, email_verified, created_at, updated_at
-    `, input.Name, input.Email, hashedPassword).Scan(
-		&user.ID,
-		&user.Name,
-		&user.Email,
-		&user.HashedPassword,
-		&user.EmailVerified,
-		&user.CreatedAt,
-		&user.UpdatedAt,
-	)
+	// A duplicate email is a constraint violation, so it is never retried
+	return WithRetry(ctx, r.retry, func(ctx context.Context) (*models.User, error) {
+		user := new(models.User)
+
+		err := r.db.QueryRow(ctx, `
+            INSERT INTO users (name, email, hashed_password)
+            VALUES ($1, $2, $3)
+            RETURNING id, name, email, hashed_password, email_verified, role, created_at, updated_at
+        `, input.Name, input.Email, hashedPassword).Scan(
+			&user.ID,
+			&user.Name,
+			&user.Email,
+			&user.HashedPassword,
+			&user.EmailVerified,
+			&user.Role,
+			&user.CreatedAt,
+			&user.UpdatedAt,
+		)
 
-	if err != nil {
-		return nil, err
-	}
+		if err != nil {
+			return nil, err
+		}
 
-	return user, nil
+		return user, nil
+	})
 }
 
 // LoginUser verifies credentials and returns the user if valid
@@ -64,7 +81,7 @@ func (r *UserRepository) LoginUser(ctx context.Context, input models.LoginUserIn
 
 	// Retrieve user and hashed password from database
 	err := r.db.QueryRow(ctx, `
-        SELECT id, name, email, hashed_password, email_verified, created_at
+        SELECT id, name, email, hashed_password, email_verified, role, created_at
         FROM users
         WHERE email = $1 AND hashed_password IS NOT NULL
     `, input.Email).Scan(
@@ -73,6 +90,7 @@ func (r *UserRepository) LoginUser(ctx context.Context, input models.LoginUserIn
 		&user.Email,
 		&hashedPassword,
 		&user.EmailVerified,
+		&user.Role,
 		&user.CreatedAt,
 	)
 
@@ -97,7 +115,7 @@ func (r *UserRepository) GetUserByID(ctx context.Context, userID uuid.UUID) (*mo
 	user := new(models.User)
 
 	err := r.db.QueryRow(ctx, `
-        SELECT id, name, email, hashed_password, email_verified, created_at, updated_at
+        SELECT id, name, email, hashed_password, email_verified, role, created_at, updated_at
         FROM users
         WHERE id = $1
     `, userID).Scan(
@@ -106,6 +124,7 @@ func (r *UserRepository) GetUserByID(ctx context.Context, userID uuid.UUID) (*mo
 		&user.Email,
 		&user.HashedPassword,
 		&user.EmailVerified,
+		&user.Role,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
@@ -117,11 +136,48 @@ func (r *UserRepository) GetUserByID(ctx context.Context, userID uuid.UUID) (*mo
 	return user, nil
 }
 
+// GetUserStats aggregates the user's active, non-wishlist trips. Days traveled
+// sums each trip's days, counting the first and last, rounded to one decimal.
+// Ties for the most visited location go to the one visited most recently.
+func (r *UserRepository) GetUserStats(ctx context.Context, userID uuid.UUID) (*models.UserStats, error) {
+	stats := new(models.UserStats)
+
+	err := r.db.QueryRow(ctx, `
+        SELECT
+            COUNT(*),
+            COUNT(*) FILTER (WHERE start_date > NOW()),
+            COUNT(*) FILTER (WHERE end_date + INTERVAL '1 day' <= NOW()),
+            COALESCE(ROUND((EXTRACT(EPOCH FROM SUM(end_date - start_date + INTERVAL '1 day')) / 86400)::numeric, 1), 0)::float8,
+            COALESCE((
+                SELECT location
+                FROM trips
+                WHERE user_id = $1 AND deleted_at IS NULL AND NOT is_wishlist
+                GROUP BY location
+                ORDER BY COUNT(*) DESC, MAX(start_date) DESC NULLS LAST, location
+                LIMIT 1
+            ), '')
+        FROM trips
+        WHERE user_id = $1 AND deleted_at IS NULL AND NOT is_wishlist
+    `, userID).Scan(
+		&stats.TotalTrips,
+		&stats.UpcomingTrips,
+		&stats.PastTrips,
+		&stats.DaysTraveled,
+		&stats.MostVisitedLocation,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
 func (r *UserRepository) GetUserByEmail(ctx context.Context, email string) (*models.User, error) {
 	user := new(models.User)
 
 	err := r.db.QueryRow(ctx, `
-		SELECT id, name, email, hashed_password, email_verified, created_at, updated_at
+		SELECT id, name, email, hashed_password, email_verified, role, created_at, updated_at
 		FROM users
 		WHERE email = $1
 	`, email).Scan(
@@ -130,6 +186,7 @@ func (r *UserRepository) GetUserByEmail(ctx context.Context, email string) (*mod
 		&user.Email,
 		&user.HashedPassword,
 		&user.EmailVerified,
+		&user.Role,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
@@ -144,17 +201,160 @@ func (r *UserRepository) GetUserByEmail(ctx context.Context, email string) (*mod
 	return user, nil
 }
 
-// Changing verified email to true - used for oauth (will implement verification email later)
+// Changing verified email to true - used for oauth (will implement verification email later).
+// Verifying also discards any outstanding verification code.
 func (r *UserRepository) SetEmailVerified(ctx context.Context, userID uuid.UUID, verified bool) error {
 	_, err := r.db.Exec(ctx, `
-		UPDATE users 
-		SET email_verified = $1, updated_at = CURRENT_TIMESTAMP 
-		WHERE id = $2
+		WITH updated AS (
+			UPDATE users
+			SET email_verified = $1, updated_at = CURRENT_TIMESTAMP
+			WHERE id = $2
+			RETURNING id
+		)
+		DELETE FROM email_verifications
+		WHERE $1 AND user_id IN (SELECT id FROM updated)
 	`, verified, userID)
 
 	return err
 }
 
+// GetVerificationStatus returns whether the user's email is verified and when
+// their unexpired verification code, if any, runs out
+func (r *UserRepository) GetVerificationStatus(ctx context.Context, userID uuid.UUID) (*models.VerificationStatus, error) {
+	status := new(models.VerificationStatus)
+
+	err := r.db.QueryRow(ctx, `
+		SELECT u.email_verified, ev.expires_at
+		FROM users u
+		LEFT JOIN email_verifications ev ON ev.user_id = u.id AND ev.expires_at > NOW()
+		WHERE u.id = $1
+	`, userID).Scan(&status.EmailVerified, &status.PendingVerificationExpiresAt)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("user not found")
+		}
+		return nil, err
+	}
+
+	return status, nil
+}
+
+// UpdatePassword stores a new password hash for the user
+func (r *UserRepository) UpdatePassword(ctx context.Context, userID uuid.UUID, hashedPassword string) error {
+	commandTag, err := r.db.Exec(ctx, `
+		UPDATE users
+		SET hashed_password = $1, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $2
+	`, hashedPassword, userID)
+
+	if err != nil {
+		return err
+	}
+
+	if commandTag.RowsAffected() == 0 {
+		return errors.New("user not found")
+	}
+
+	return nil
+}
+
+// UpdateUser changes the fields set in input and leaves the rest untouched.
+// A new email is stored unverified and gets a fresh verification code.
+func (r *UserRepository) UpdateUser(ctx context.Context, userID uuid.UUID, input models.UpdateUserInput) (*models.User, error) {
+	user := new(models.User)
+
+	err := db.WithTx(ctx, r.db, func(tx pgx.Tx) error {
+		var emailChanged bool
+
+		err := tx.QueryRow(ctx, `
+			WITH previous AS (
+				SELECT email FROM users WHERE id = $1 FOR UPDATE
+			)
+			UPDATE users u
+			SET name = COALESCE($2::text, u.name),
+				email = COALESCE($3::text, u.email),
+				email_verified = CASE WHEN $3::text IS NOT NULL AND $3::text <> previous.email THEN FALSE ELSE u.email_verified END,
+				updated_at = CURRENT_TIMESTAMP
+			FROM previous
+			WHERE u.id = $1
+			RETURNING u.id, u.name, u.email, u.email_verified, u.role, u.created_at, u.updated_at,
+				$3::text IS NOT NULL AND $3::text <> previous.email
+		`, userID, input.Name, input.Email).Scan(
+			&user.ID,
+			&user.Name,
+			&user.Email,
+			&user.EmailVerified,
+			&user.Role,
+			&user.CreatedAt,
+			&user.UpdatedAt,
+			&emailChanged,
+		)
+
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return errors.New("user not found")
+			}
+			return err
+		}
+
+		if !emailChanged {
+			return nil
+		}
+
+		codeBytes := make([]byte, 32)
+		if _, err := rand.Read(codeBytes); err != nil {
+			return fmt.Errorf("failed to generate verification code: %w", err)
+		}
+
+		_, err = tx.Exec(ctx, `
+			INSERT INTO email_verifications (code, user_id)
+			VALUES ($1, $2)
+			ON CONFLICT (user_id) DO UPDATE
+			SET code = EXCLUDED.code,
+				expires_at = CURRENT_TIMESTAMP + INTERVAL '24 hours',
+				created_at = CURRENT_TIMESTAMP
+		`, hex.EncodeToString(codeBytes), userID)
+
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// SetUserRole changes a user's role and returns the updated user
+func (r *UserRepository) SetUserRole(ctx context.Context, userID uuid.UUID, role string) (*models.User, error) {
+	user := new(models.User)
+
+	err := r.db.QueryRow(ctx, `
+		UPDATE users
+		SET role = $2, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+		RETURNING id, name, email, email_verified, role, created_at, updated_at
+	`, userID, role).Scan(
+		&user.ID,
+		&user.Name,
+		&user.Email,
+		&user.EmailVerified,
+		&user.Role,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("user not found")
+		}
+		return nil, err
+	}
+
+	return user, nil
+}
+
 // GetUserWithTrips retrieves a user and their trips in a single operation
 func (r *UserRepository) GetUserWithTrips(ctx context.Context, userID uuid.UUID, limit int, offset int) (*models.User, error) {
 	// First get the user
@@ -170,10 +370,10 @@ func (r *UserRepository) GetUserWithTrips(ctx context.Context, userID uuid.UUID,
 
 	// Then get their trips
 	rows, err := r.db.Query(ctx, `
-        SELECT id, user_id, name, description, start_date, end_date, location, created_at, updated_at
+        SELECT id, user_id, name, description, start_date, end_date, location, is_wishlist, visibility, share_token, cover_image_url, created_at, updated_at
         FROM trips
-        WHERE user_id = $1
-        ORDER BY start_date DESC
+        WHERE user_id = $1 AND deleted_at IS NULL
+        ORDER BY start_date DESC NULLS LAST
         LIMIT $2 OFFSET $3
     `, userID, limit, offset)
 
@@ -193,6 +393,10 @@ func (r *UserRepository) GetUserWithTrips(ctx context.Context, userID uuid.UUID,
 			&trip.StartDate,
 			&trip.EndDate,
 			&trip.Location,
+			&trip.IsWishlist,
+			&trip.Visibility,
+			&trip.ShareToken,
+			&trip.CoverImageURL,
 			&trip.CreatedAt,
 			&trip.UpdatedAt,
 		)
@@ -0,0 +1,91 @@
+package repositories_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"black-lotus/internal/domain/models"
+	"black-lotus/internal/infrastructure/repositories"
+	"black-lotus/pkg/db"
+)
+
+func TestUserRepositoryVerificationStatus(t *testing.T) {
+	setupRepositoryTest(t)
+	ctx := context.Background()
+
+	users := repositories.NewUserRepository(db.TestDB)
+	user, err := users.CreateUser(ctx, models.CreateUserInput{Name: "Test User", Email: "verify@example.com"}, nil)
+	if err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+
+	status, err := users.GetVerificationStatus(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if status.EmailVerified || status.PendingVerificationExpiresAt != nil {
+		t.Errorf("Expected an unverified user with no code, got %+v", status)
+	}
+
+	// Changing the email issues a fresh verification code
+	newEmail := "verify-new@example.com"
+	if _, err := users.UpdateUser(ctx, user.ID, models.UpdateUserInput{Email: &newEmail}); err != nil {
+		t.Fatalf("Failed to update email: %v", err)
+	}
+
+	status, err = users.GetVerificationStatus(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if status.EmailVerified {
+		t.Error("Expected the new email to be unverified")
+	}
+	if status.PendingVerificationExpiresAt == nil || time.Until(*status.PendingVerificationExpiresAt) < 23*time.Hour {
+		t.Errorf("Expected a code expiring in about 24 hours, got %v", status.PendingVerificationExpiresAt)
+	}
+
+	if err := users.SetEmailVerified(ctx, user.ID, true); err != nil {
+		t.Fatalf("Failed to verify email: %v", err)
+	}
+
+	status, err = users.GetVerificationStatus(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if !status.EmailVerified || status.PendingVerificationExpiresAt != nil {
+		t.Errorf("Expected a verified email with no pending code, got %+v", status)
+	}
+}
+
+func TestUserRepositorySetUserRole(t *testing.T) {
+	setupRepositoryTest(t)
+	ctx := context.Background()
+
+	users := repositories.NewUserRepository(db.TestDB)
+	user, err := users.CreateUser(ctx, models.CreateUserInput{Name: "Test User", Email: "role@example.com"}, nil)
+	if err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	if user.Role != models.UserRoleUser {
+		t.Errorf("Expected new users to get role %q, got %q", models.UserRoleUser, user.Role)
+	}
+
+	if _, err := users.SetUserRole(ctx, user.ID, models.UserRoleAdmin); err != nil {
+		t.Fatalf("Failed to set role: %v", err)
+	}
+
+	stored, err := users.GetUserByID(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("Failed to get user: %v", err)
+	}
+	if stored.Role != models.UserRoleAdmin {
+		t.Errorf("Expected role %q, got %q", models.UserRoleAdmin, stored.Role)
+	}
+
+	if _, err := users.SetUserRole(ctx, uuid.New(), models.UserRoleAdmin); err == nil || err.Error() != "user not found" {
+		t.Errorf("Expected 'user not found' for an unknown user, got %v", err)
+	}
+}
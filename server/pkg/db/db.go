@@ -3,7 +3,7 @@ package db
 import (
 	"context"
 	"fmt"
-	"log"
+	"log/slog"
 	"os"
 	"time"
 
@@ -47,6 +47,21 @@ func Close() {
 	}
 }
 
+// Ping runs SELECT 1 against the pool and reports how long the round trip took
+func Ping(ctx context.Context) (time.Duration, error) {
+	if DB == nil {
+		return 0, fmt.Errorf("database not initialized")
+	}
+
+	start := time.Now()
+	var result int
+	if err := DB.QueryRow(ctx, "SELECT 1").Scan(&result); err != nil {
+		return time.Since(start), fmt.Errorf("database ping failed: %v", err)
+	}
+
+	return time.Since(start), nil
+}
+
 // initSchema creates database tables if they don't exist
 func initSchema() error {
 	_, err := DB.Exec(context.Background(), `
@@ -60,11 +75,17 @@ func initSchema() error {
             email VARCHAR(100) UNIQUE NOT NULL,
             hashed_password VARCHAR(255) DEFAULT NULL,
             email_verified BOOLEAN NOT NULL DEFAULT FALSE,
+            role VARCHAR(10) NOT NULL DEFAULT 'user' CHECK (role IN ('user', 'admin')),
             created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
             updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
             CONSTRAINT email_format_check 
             CHECK (email ~* '^[A-Za-z0-9._%-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,4}$')
         );
+
+        -- Roles; signup never sets one, so the first admin is promoted by hand:
+        -- UPDATE users SET role = 'admin' WHERE email = '...';
+        ALTER TABLE users ADD COLUMN IF NOT EXISTS role VARCHAR(10) NOT NULL DEFAULT 'user'
+            CHECK (role IN ('user', 'admin'));
         
         -- Trips table
         CREATE TABLE IF NOT EXISTS trips (
@@ -72,13 +93,121 @@ func initSchema() error {
             user_id UUID NOT NULL,
             name VARCHAR(100) NOT NULL,
             description TEXT,
-            start_date TIMESTAMP WITH TIME ZONE NOT NULL,
-            end_date TIMESTAMP WITH TIME ZONE NOT NULL,
+            start_date TIMESTAMP WITH TIME ZONE,
+            end_date TIMESTAMP WITH TIME ZONE,
             location VARCHAR(100) NOT NULL,
+            is_wishlist BOOLEAN NOT NULL DEFAULT FALSE,
+            cover_image_url TEXT NOT NULL DEFAULT '',
             created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
             updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+            deleted_at TIMESTAMP WITH TIME ZONE DEFAULT NULL,
+            order_index INTEGER DEFAULT NULL,
+            visibility VARCHAR(10) NOT NULL DEFAULT 'private' CHECK (visibility IN ('private', 'unlisted')),
+            share_token VARCHAR(64) UNIQUE DEFAULT NULL,
             FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
         );
+
+        -- Soft-delete support for trips created before deleted_at existed
+        ALTER TABLE trips ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP WITH TIME ZONE DEFAULT NULL;
+
+        -- Manual (pinned) ordering; trips the user hasn't ordered stay NULL
+        ALTER TABLE trips ADD COLUMN IF NOT EXISTS order_index INTEGER DEFAULT NULL;
+
+        -- Wishlist trips may be saved without dates
+        ALTER TABLE trips ADD COLUMN IF NOT EXISTS is_wishlist BOOLEAN NOT NULL DEFAULT FALSE;
+        ALTER TABLE trips ALTER COLUMN start_date DROP NOT NULL;
+        ALTER TABLE trips ALTER COLUMN end_date DROP NOT NULL;
+
+        -- Share links; unlisted trips can be read by anyone with the token
+        ALTER TABLE trips ADD COLUMN IF NOT EXISTS visibility VARCHAR(10) NOT NULL DEFAULT 'private'
+            CHECK (visibility IN ('private', 'unlisted'));
+        ALTER TABLE trips ADD COLUMN IF NOT EXISTS share_token VARCHAR(64) UNIQUE DEFAULT NULL;
+
+        -- Cover images; trips without one get a generated fallback
+        ALTER TABLE trips ADD COLUMN IF NOT EXISTS cover_image_url TEXT NOT NULL DEFAULT '';
+
+        -- Trip dates are calendar days at midnight UTC; older rows carried a time of day
+        UPDATE trips
+        SET start_date = date_trunc('day', start_date, 'UTC'), end_date = date_trunc('day', end_date, 'UTC')
+        WHERE start_date <> date_trunc('day', start_date, 'UTC') OR end_date <> date_trunc('day', end_date, 'UTC');
+        
+        -- Activities table - itinerary entries belonging to a trip
+        CREATE TABLE IF NOT EXISTS activities (
+            id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+            trip_id UUID NOT NULL,
+            title VARCHAR(100) NOT NULL,
+            description TEXT NOT NULL DEFAULT '',
+            start_time TIMESTAMP WITH TIME ZONE NOT NULL,
+            end_time TIMESTAMP WITH TIME ZONE NOT NULL,
+            location VARCHAR(100) NOT NULL DEFAULT '',
+            created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+            updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+            FOREIGN KEY (trip_id) REFERENCES trips(id) ON DELETE CASCADE
+        );
+
+        -- Expenses table - amounts are stored in minor units (cents)
+        CREATE TABLE IF NOT EXISTS expenses (
+            id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+            trip_id UUID NOT NULL,
+            amount_cents BIGINT NOT NULL CHECK (amount_cents > 0),
+            currency CHAR(3) NOT NULL,
+            category VARCHAR(50) NOT NULL,
+            note TEXT NOT NULL DEFAULT '',
+            paid_by VARCHAR(100) NOT NULL DEFAULT '',
+            split_among TEXT[] NOT NULL DEFAULT '{}',
+            incurred_at TIMESTAMP WITH TIME ZONE NOT NULL,
+            created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+            FOREIGN KEY (trip_id) REFERENCES trips(id) ON DELETE CASCADE
+        );
+
+        -- Group expenses: who paid and who the cost is shared by
+        ALTER TABLE expenses ADD COLUMN IF NOT EXISTS paid_by VARCHAR(100) NOT NULL DEFAULT '';
+        ALTER TABLE expenses ADD COLUMN IF NOT EXISTS split_among TEXT[] NOT NULL DEFAULT '{}';
+
+        -- Trip photos - metadata only, the images are hosted externally
+        CREATE TABLE IF NOT EXISTS trip_photos (
+            id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+            trip_id UUID NOT NULL,
+            url VARCHAR(2048) NOT NULL,
+            caption VARCHAR(500) NOT NULL DEFAULT '',
+            uploaded_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+            FOREIGN KEY (trip_id) REFERENCES trips(id) ON DELETE CASCADE
+        );
+
+        -- Trip reminders - one per trip start date. The token lets the owner
+        -- acknowledge or snooze a reminder from its link without logging in.
+        CREATE TABLE IF NOT EXISTS trip_reminders (
+            id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+            trip_id UUID NOT NULL,
+            trip_start TIMESTAMP WITH TIME ZONE NOT NULL,
+            token VARCHAR(64) NOT NULL UNIQUE,
+            remind_at TIMESTAMP WITH TIME ZONE NOT NULL,
+            sent_at TIMESTAMP WITH TIME ZONE DEFAULT NULL,
+            acknowledged_at TIMESTAMP WITH TIME ZONE DEFAULT NULL,
+            created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+            UNIQUE (trip_id, trip_start),
+            FOREIGN KEY (trip_id) REFERENCES trips(id) ON DELETE CASCADE
+        );
+
+        -- Tags table - names are unique per user
+        CREATE TABLE IF NOT EXISTS tags (
+            id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+            user_id UUID NOT NULL,
+            name VARCHAR(50) NOT NULL,
+            created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+            UNIQUE (user_id, name),
+            FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+        );
+
+        -- Trip tags join table
+        CREATE TABLE IF NOT EXISTS trip_tags (
+            trip_id UUID NOT NULL,
+            tag_id UUID NOT NULL,
+            created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+            PRIMARY KEY (trip_id, tag_id),
+            FOREIGN KEY (trip_id) REFERENCES trips(id) ON DELETE CASCADE,
+            FOREIGN KEY (tag_id) REFERENCES tags(id) ON DELETE CASCADE
+        );
         
         -- OAuth accounts table
         CREATE TABLE IF NOT EXISTS oauth_accounts (
@@ -102,9 +231,13 @@ func initSchema() error {
             refresh_token_hash VARCHAR(255),
             access_expires_at TIMESTAMP WITH TIME ZONE,
             refresh_expires_at TIMESTAMP WITH TIME ZONE,
+            last_refreshed_at TIMESTAMP WITH TIME ZONE DEFAULT NULL,
             created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
             FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
         );
+
+        -- Refresh throttling for sessions created before last_refreshed_at existed
+        ALTER TABLE sessions ADD COLUMN IF NOT EXISTS last_refreshed_at TIMESTAMP WITH TIME ZONE DEFAULT NULL;
         
         -- Email verification table
         CREATE TABLE IF NOT EXISTS email_verifications (
@@ -115,6 +248,19 @@ func initSchema() error {
             created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
             FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
         );
+
+        -- API keys for scripts; only a SHA-256 hash of each key is stored
+        CREATE TABLE IF NOT EXISTS api_keys (
+            id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+            user_id UUID NOT NULL,
+            name VARCHAR(100) NOT NULL,
+            key_hash VARCHAR(64) NOT NULL UNIQUE,
+            prefix VARCHAR(20) NOT NULL,
+            expires_at TIMESTAMP WITH TIME ZONE DEFAULT NULL,
+            last_used_at TIMESTAMP WITH TIME ZONE DEFAULT NULL,
+            created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+            FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+        );
         
         -- Create indexes for better performance
         CREATE INDEX IF NOT EXISTS idx_oauth_accounts_user_id ON oauth_accounts(user_id);
@@ -124,35 +270,113 @@ func initSchema() error {
         CREATE INDEX IF NOT EXISTS idx_sessions_access_token_hash ON sessions(access_token_hash);
         CREATE INDEX IF NOT EXISTS idx_sessions_refresh_token_hash ON sessions(refresh_token_hash);
         CREATE INDEX IF NOT EXISTS idx_email_verifications_expires_at ON email_verifications(expires_at);
+        CREATE INDEX IF NOT EXISTS idx_api_keys_user_id ON api_keys(user_id);
         CREATE INDEX IF NOT EXISTS idx_trips_user_id ON trips(user_id);
+        CREATE INDEX IF NOT EXISTS idx_trips_user_id_created_at_id ON trips(user_id, created_at DESC, id DESC);
+        CREATE INDEX IF NOT EXISTS idx_trips_deleted_at ON trips(deleted_at);
+        CREATE INDEX IF NOT EXISTS idx_trip_tags_tag_id ON trip_tags(tag_id);
+        CREATE INDEX IF NOT EXISTS idx_activities_trip_id_start_time ON activities(trip_id, start_time);
+        CREATE INDEX IF NOT EXISTS idx_expenses_trip_id_incurred_at ON expenses(trip_id, incurred_at);
+        CREATE INDEX IF NOT EXISTS idx_trip_photos_trip_id_uploaded_at ON trip_photos(trip_id, uploaded_at);
+        CREATE INDEX IF NOT EXISTS idx_trip_reminders_remind_at ON trip_reminders(remind_at) WHERE acknowledged_at IS NULL;
     `)
+	if err != nil {
+		return err
+	}
 
+	_, err = DB.Exec(context.Background(), touchParentTripSQL)
 	return err
 }
 
+// touchParentTripSQL installs triggers that bump a trip's updated_at whenever
+// one of its activities, expenses, photos or tags is added, changed or
+// removed. The update runs inside the statement's own transaction, so every
+// writer gets it and sync clients comparing updated_at never miss a child
+// change.
+const touchParentTripSQL = `
+        CREATE OR REPLACE FUNCTION touch_parent_trip() RETURNS TRIGGER AS $$
+        BEGIN
+            IF TG_OP IN ('UPDATE', 'DELETE') THEN
+                UPDATE trips SET updated_at = NOW() WHERE id = OLD.trip_id;
+            END IF;
+            IF TG_OP IN ('INSERT', 'UPDATE') THEN
+                UPDATE trips SET updated_at = NOW() WHERE id = NEW.trip_id;
+            END IF;
+            RETURN NULL;
+        END;
+        $$ LANGUAGE plpgsql;
+
+        DROP TRIGGER IF EXISTS activities_touch_trip ON activities;
+        CREATE TRIGGER activities_touch_trip
+            AFTER INSERT OR UPDATE OR DELETE ON activities
+            FOR EACH ROW EXECUTE FUNCTION touch_parent_trip();
+
+        DROP TRIGGER IF EXISTS expenses_touch_trip ON expenses;
+        CREATE TRIGGER expenses_touch_trip
+            AFTER INSERT OR UPDATE OR DELETE ON expenses
+            FOR EACH ROW EXECUTE FUNCTION touch_parent_trip();
+
+        DROP TRIGGER IF EXISTS trip_photos_touch_trip ON trip_photos;
+        CREATE TRIGGER trip_photos_touch_trip
+            AFTER INSERT OR UPDATE OR DELETE ON trip_photos
+            FOR EACH ROW EXECUTE FUNCTION touch_parent_trip();
+
+        DROP TRIGGER IF EXISTS trip_tags_touch_trip ON trip_tags;
+        CREATE TRIGGER trip_tags_touch_trip
+            AFTER INSERT OR UPDATE OR DELETE ON trip_tags
+            FOR EACH ROW EXECUTE FUNCTION touch_parent_trip();
+`
+
+// CleanupStats reports how many expired records a cleanup run removed per table
+type CleanupStats struct {
+	Sessions           int64 `json:"sessions"`
+	EmailVerifications int64 `json:"email_verifications"`
+}
+
+// Total returns the number of records removed across all tables
+func (s CleanupStats) Total() int64 {
+	return s.Sessions + s.EmailVerifications
+}
+
 // CleanupExpiredRecords removes all expired sessions and verification codes
-func CleanupExpiredRecords(ctx context.Context) (int64, error) {
-	// Delete expired sessions
+func CleanupExpiredRecords(ctx context.Context) (CleanupStats, error) {
+	var stats CleanupStats
+
+	// Delete sessions whose refresh token has expired - they can no longer be renewed
 	sessionResult, err := DB.Exec(ctx, `
-		DELETE FROM sessions WHERE expires_at < NOW()
+		DELETE FROM sessions WHERE refresh_expires_at < NOW()
 	`)
 	if err != nil {
-		return 0, err
+		return stats, err
 	}
+	stats.Sessions = sessionResult.RowsAffected()
 
 	// Delete expired email verifications
 	verificationResult, err := DB.Exec(ctx, `
 		DELETE FROM email_verifications WHERE expires_at < NOW()
 	`)
 	if err != nil {
-		return 0, err
+		return stats, err
+	}
+	stats.EmailVerifications = verificationResult.RowsAffected()
+
+	return stats, nil
+}
+
+// RunCleanupOnce performs a single cleanup pass and logs what it removed
+func RunCleanupOnce(ctx context.Context) (CleanupStats, error) {
+	stats, err := CleanupExpiredRecords(ctx)
+	if err != nil {
+		slog.Error("Error cleaning up expired records", "error", err)
+		return stats, err
 	}
 
-	// Return total number of deleted records
-	sessionCount := sessionResult.RowsAffected()
-	verificationCount := verificationResult.RowsAffected()
+	slog.Info("Cleaned up expired records",
+		"sessions", stats.Sessions,
+		"email_verifications", stats.EmailVerifications,
+		"total", stats.Total())
 
-	return sessionCount + verificationCount, nil
+	return stats, nil
 }
 
 // StartCleanupJob starts a background goroutine that periodically cleans up expired records
@@ -164,12 +388,7 @@ func StartCleanupJob(interval time.Duration) {
 		for {
 			select {
 			case <-ticker.C:
-				count, err := CleanupExpiredRecords(context.Background())
-				if err != nil {
-					log.Printf("Error cleaning up expired records: %v", err)
-				} else if count > 0 {
-					log.Printf("Cleaned up %d expired records", count)
-				}
+				RunCleanupOnce(context.Background())
 			}
 		}
 	}()
@@ -167,6 +167,7 @@ func initTestSchema() error {
             email VARCHAR(100) UNIQUE NOT NULL,
             hashed_password VARCHAR(255) DEFAULT NULL,
             email_verified BOOLEAN NOT NULL DEFAULT FALSE,
+            role VARCHAR(10) NOT NULL DEFAULT 'user' CHECK (role IN ('user', 'admin')),
             created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
             updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
             CONSTRAINT email_format_check 
@@ -185,11 +186,17 @@ func initTestSchema() error {
 			user_id UUID NOT NULL,
 			name VARCHAR(100) NOT NULL,
 			description TEXT,
-			start_date TIMESTAMP WITH TIME ZONE NOT NULL,
-			end_date TIMESTAMP WITH TIME ZONE NOT NULL,
+			start_date TIMESTAMP WITH TIME ZONE,
+			end_date TIMESTAMP WITH TIME ZONE,
 			location VARCHAR(100) NOT NULL,
+			is_wishlist BOOLEAN NOT NULL DEFAULT FALSE,
+			cover_image_url TEXT NOT NULL DEFAULT '',
 			created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
 			updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			deleted_at TIMESTAMP WITH TIME ZONE DEFAULT NULL,
+			order_index INTEGER DEFAULT NULL,
+			visibility VARCHAR(10) NOT NULL DEFAULT 'private' CHECK (visibility IN ('private', 'unlisted')),
+			share_token VARCHAR(64) UNIQUE DEFAULT NULL,
 			FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
 		)
   `)
@@ -197,6 +204,108 @@ func initTestSchema() error {
 		return fmt.Errorf("failed to create trips table: %v", err)
 	}
 
+	// Create activities table
+	log.Printf("Creating activities table")
+	_, err = TestDB.Exec(context.Background(), `
+		CREATE TABLE IF NOT EXISTS activities (
+			id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+			trip_id UUID NOT NULL,
+			title VARCHAR(100) NOT NULL,
+			description TEXT NOT NULL DEFAULT '',
+			start_time TIMESTAMP WITH TIME ZONE NOT NULL,
+			end_time TIMESTAMP WITH TIME ZONE NOT NULL,
+			location VARCHAR(100) NOT NULL DEFAULT '',
+			created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (trip_id) REFERENCES trips(id) ON DELETE CASCADE
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create activities table: %v", err)
+	}
+
+	// Create expenses table
+	log.Printf("Creating expenses table")
+	_, err = TestDB.Exec(context.Background(), `
+		CREATE TABLE IF NOT EXISTS expenses (
+			id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+			trip_id UUID NOT NULL,
+			amount_cents BIGINT NOT NULL CHECK (amount_cents > 0),
+			currency CHAR(3) NOT NULL,
+			category VARCHAR(50) NOT NULL,
+			note TEXT NOT NULL DEFAULT '',
+			paid_by VARCHAR(100) NOT NULL DEFAULT '',
+			split_among TEXT[] NOT NULL DEFAULT '{}',
+			incurred_at TIMESTAMP WITH TIME ZONE NOT NULL,
+			created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (trip_id) REFERENCES trips(id) ON DELETE CASCADE
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create expenses table: %v", err)
+	}
+
+	// Create trip_photos table
+	log.Printf("Creating trip_photos table")
+	_, err = TestDB.Exec(context.Background(), `
+		CREATE TABLE IF NOT EXISTS trip_photos (
+			id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+			trip_id UUID NOT NULL,
+			url VARCHAR(2048) NOT NULL,
+			caption VARCHAR(500) NOT NULL DEFAULT '',
+			uploaded_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (trip_id) REFERENCES trips(id) ON DELETE CASCADE
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create trip_photos table: %v", err)
+	}
+
+	// Create trip_reminders table
+	log.Printf("Creating trip_reminders table")
+	_, err = TestDB.Exec(context.Background(), `
+		CREATE TABLE IF NOT EXISTS trip_reminders (
+			id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+			trip_id UUID NOT NULL,
+			trip_start TIMESTAMP WITH TIME ZONE NOT NULL,
+			token VARCHAR(64) NOT NULL UNIQUE,
+			remind_at TIMESTAMP WITH TIME ZONE NOT NULL,
+			sent_at TIMESTAMP WITH TIME ZONE DEFAULT NULL,
+			acknowledged_at TIMESTAMP WITH TIME ZONE DEFAULT NULL,
+			created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			UNIQUE (trip_id, trip_start),
+			FOREIGN KEY (trip_id) REFERENCES trips(id) ON DELETE CASCADE
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create trip_reminders table: %v", err)
+	}
+
+	// Create tags tables
+	log.Printf("Creating tags and trip_tags tables")
+	_, err = TestDB.Exec(context.Background(), `
+		CREATE TABLE IF NOT EXISTS tags (
+			id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+			user_id UUID NOT NULL,
+			name VARCHAR(50) NOT NULL,
+			created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			UNIQUE (user_id, name),
+			FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+		);
+
+		CREATE TABLE IF NOT EXISTS trip_tags (
+			trip_id UUID NOT NULL,
+			tag_id UUID NOT NULL,
+			created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			PRIMARY KEY (trip_id, tag_id),
+			FOREIGN KEY (trip_id) REFERENCES trips(id) ON DELETE CASCADE,
+			FOREIGN KEY (tag_id) REFERENCES tags(id) ON DELETE CASCADE
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create tags tables: %v", err)
+	}
+
 	// Create oauth_accounts table
 	log.Printf("Creating oauth_accounts table")
 	_, err = TestDB.Exec(context.Background(), `
@@ -227,6 +336,7 @@ func initTestSchema() error {
 			refresh_token_hash VARCHAR(255),
 			access_expires_at TIMESTAMP WITH TIME ZONE,
 			refresh_expires_at TIMESTAMP WITH TIME ZONE,
+			last_refreshed_at TIMESTAMP WITH TIME ZONE DEFAULT NULL,
 			created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
 			FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
 		)
@@ -251,6 +361,25 @@ func initTestSchema() error {
 		return fmt.Errorf("failed to create email_verifications table: %v", err)
 	}
 
+	// Create api_keys table
+	log.Printf("Creating api_keys table")
+	_, err = TestDB.Exec(context.Background(), `
+		CREATE TABLE IF NOT EXISTS api_keys (
+			id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+			user_id UUID NOT NULL,
+			name VARCHAR(100) NOT NULL,
+			key_hash VARCHAR(64) NOT NULL UNIQUE,
+			prefix VARCHAR(20) NOT NULL,
+			expires_at TIMESTAMP WITH TIME ZONE DEFAULT NULL,
+			last_used_at TIMESTAMP WITH TIME ZONE DEFAULT NULL,
+			created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create api_keys table: %v", err)
+	}
+
 	// Create all indexes
 	log.Printf("Creating indexes for oauth_accounts")
 	_, err = TestDB.Exec(context.Background(),
@@ -297,6 +426,13 @@ func initTestSchema() error {
 		return fmt.Errorf("failed to create email_verifications index: %v", err)
 	}
 
+	log.Printf("Creating indexes for api_keys")
+	_, err = TestDB.Exec(context.Background(),
+		"CREATE INDEX IF NOT EXISTS idx_api_keys_user_id ON api_keys(user_id)")
+	if err != nil {
+		return fmt.Errorf("failed to create api_keys index: %v", err)
+	}
+
 	log.Printf("Creating indexes for trips")
 	_, err = TestDB.Exec(context.Background(),
 		"CREATE INDEX IF NOT EXISTS idx_trips_user_id ON trips(user_id)")
@@ -304,6 +440,13 @@ func initTestSchema() error {
 		return fmt.Errorf("failed to create trips user_id index: %v", err)
 	}
 
+	// Keyset index for cursor pagination
+	_, err = TestDB.Exec(context.Background(),
+		"CREATE INDEX IF NOT EXISTS idx_trips_user_id_created_at_id ON trips(user_id, created_at DESC, id DESC)")
+	if err != nil {
+		return fmt.Errorf("failed to create trips keyset index: %v", err)
+	}
+
 	// Create location index
 	log.Printf("Creating index on trips.location")
 	_, err = TestDB.Exec(context.Background(),
@@ -318,7 +461,50 @@ func initTestSchema() error {
 		return fmt.Errorf("failed to create trips date_range index: %v", err)
 	}
 
+	_, err = TestDB.Exec(context.Background(),
+		"CREATE INDEX IF NOT EXISTS idx_trips_deleted_at ON trips(deleted_at)")
+	if err != nil {
+		return fmt.Errorf("failed to create trips deleted_at index: %v", err)
+	}
+
+	_, err = TestDB.Exec(context.Background(),
+		"CREATE INDEX IF NOT EXISTS idx_trip_tags_tag_id ON trip_tags(tag_id)")
+	if err != nil {
+		return fmt.Errorf("failed to create trip_tags tag_id index: %v", err)
+	}
+
+	_, err = TestDB.Exec(context.Background(),
+		"CREATE INDEX IF NOT EXISTS idx_activities_trip_id_start_time ON activities(trip_id, start_time)")
+	if err != nil {
+		return fmt.Errorf("failed to create activities trip_id index: %v", err)
+	}
+
+	_, err = TestDB.Exec(context.Background(),
+		"CREATE INDEX IF NOT EXISTS idx_expenses_trip_id_incurred_at ON expenses(trip_id, incurred_at)")
+	if err != nil {
+		return fmt.Errorf("failed to create expenses trip_id index: %v", err)
+	}
+
+	_, err = TestDB.Exec(context.Background(),
+		"CREATE INDEX IF NOT EXISTS idx_trip_photos_trip_id_uploaded_at ON trip_photos(trip_id, uploaded_at)")
+	if err != nil {
+		return fmt.Errorf("failed to create trip_photos trip_id index: %v", err)
+	}
+
+	_, err = TestDB.Exec(context.Background(),
+		"CREATE INDEX IF NOT EXISTS idx_trip_reminders_remind_at ON trip_reminders(remind_at) WHERE acknowledged_at IS NULL")
+	if err != nil {
+		return fmt.Errorf("failed to create trip_reminders remind_at index: %v", err)
+	}
+
 	log.Printf("All indexes created successfully")
+
+	log.Printf("Creating trip touch triggers")
+	_, err = TestDB.Exec(context.Background(), touchParentTripSQL)
+	if err != nil {
+		return fmt.Errorf("failed to create trip touch triggers: %v", err)
+	}
+
 	return nil
 }
 
@@ -336,7 +522,14 @@ func CleanTestTables(ctx context.Context) error {
 	_, err = TestDB.Exec(ctx, `
 		TRUNCATE TABLE email_verifications, 
 		sessions, 
+		api_keys, 
 		oauth_accounts, 
+		activities, 
+		expenses, 
+		trip_photos, 
+		trip_reminders, 
+		trip_tags, 
+		tags, 
 		trips, 
 		users CASCADE;
 	`)
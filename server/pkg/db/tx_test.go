@@ -0,0 +1,147 @@
+package db_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"black-lotus/pkg/db"
+)
+
+// fakeStore stands in for the database: statements executed inside a
+// transaction are staged and only become visible on commit
+type fakeStore struct {
+	rows      map[string]bool
+	committed int
+	rolled    int
+	beginErr  error
+}
+
+func (s *fakeStore) Begin(ctx context.Context) (pgx.Tx, error) {
+	if s.beginErr != nil {
+		return nil, s.beginErr
+	}
+	return &fakeTx{store: s}, nil
+}
+
+// fakeTx implements the parts of pgx.Tx that WithTx and the tests use
+type fakeTx struct {
+	pgx.Tx
+	store   *fakeStore
+	deleted []string
+	done    bool
+}
+
+func (tx *fakeTx) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	tx.deleted = append(tx.deleted, sql)
+	return pgconn.NewCommandTag("DELETE 1"), nil
+}
+
+func (tx *fakeTx) Commit(ctx context.Context) error {
+	if tx.done {
+		return pgx.ErrTxClosed
+	}
+	tx.done = true
+	tx.store.committed++
+	for _, row := range tx.deleted {
+		delete(tx.store.rows, row)
+	}
+	return nil
+}
+
+func (tx *fakeTx) Rollback(ctx context.Context) error {
+	if tx.done {
+		return pgx.ErrTxClosed
+	}
+	tx.done = true
+	tx.store.rolled++
+	return nil
+}
+
+func newFakeStore() *fakeStore {
+	return &fakeStore{rows: map[string]bool{
+		"activities": true,
+		"expenses":   true,
+		"trip":       true,
+	}}
+}
+
+// deleteAll simulates a multi-table delete, failing before the step named failAt
+func deleteAll(ctx context.Context, tx pgx.Tx, failAt string) error {
+	for _, table := range []string{"activities", "expenses", "trip"} {
+		if table == failAt {
+			return errors.New("injected failure")
+		}
+		if _, err := tx.Exec(ctx, table); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestWithTx(t *testing.T) {
+	testCases := []struct {
+		name              string
+		failAt            string
+		beginErr          error
+		expectedError     string
+		expectedRows      int
+		expectedCommits   int
+		expectedRollbacks int
+	}{
+		{
+			name:              "CommitsWhenAllStepsSucceed",
+			expectedRows:      0,
+			expectedCommits:   1,
+			expectedRollbacks: 0,
+		},
+		{
+			name:              "RollsBackOnMidTransactionFailure",
+			failAt:            "trip",
+			expectedError:     "injected failure",
+			expectedRows:      3,
+			expectedCommits:   0,
+			expectedRollbacks: 1,
+		},
+		{
+			name:              "BeginFailure",
+			beginErr:          errors.New("connection refused"),
+			expectedError:     "connection refused",
+			expectedRows:      3,
+			expectedCommits:   0,
+			expectedRollbacks: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			store := newFakeStore()
+			store.beginErr = tc.beginErr
+
+			err := db.WithTx(context.Background(), store, func(tx pgx.Tx) error {
+				return deleteAll(context.Background(), tx, tc.failAt)
+			})
+
+			if tc.expectedError != "" {
+				if err == nil || err.Error() != tc.expectedError {
+					t.Errorf("Expected error '%s', got %v", tc.expectedError, err)
+				}
+			} else if err != nil {
+				t.Errorf("Expected no error, got: %v", err)
+			}
+
+			if len(store.rows) != tc.expectedRows {
+				t.Errorf("Expected %d rows left, got %d", tc.expectedRows, len(store.rows))
+			}
+			if store.committed != tc.expectedCommits {
+				t.Errorf("Expected %d commits, got %d", tc.expectedCommits, store.committed)
+			}
+			if store.rolled != tc.expectedRollbacks {
+				t.Errorf("Expected %d rollbacks, got %d", tc.expectedRollbacks, store.rolled)
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+// Package ics serializes events as iCalendar (RFC 5545) documents
+package ics
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ContentType is the media type of an iCalendar document
+const ContentType = "text/calendar; charset=utf-8"
+
+// dateTimeFormat is the UTC DATE-TIME form, e.g. 20250610T090000Z
+const dateTimeFormat = "20060102T150405Z"
+
+// dateFormat is the DATE form of all-day events, e.g. 20250610
+const dateFormat = "20060102"
+
+// maxLineOctets is the longest a content line may be before it must be folded
+const maxLineOctets = 75
+
+// Event is a single VEVENT. UID must be globally unique and stable so calendar
+// apps update the event instead of duplicating it on every import.
+//
+// An AllDay event is written with dates instead of times: the days Start and
+// End fall on in their own location. End is exclusive, as DTEND is, so a
+// single-day event ends on the following day.
+type Event struct {
+	UID         string
+	Summary     string
+	Description string
+	Location    string
+	Start       time.Time
+	End         time.Time
+	AllDay      bool
+	Stamp       time.Time // When the event was last changed; DTSTAMP
+}
+
+// Calendar is a VCALENDAR holding zero or more events
+type Calendar struct {
+	ProductID string // PRODID, e.g. "-//Black Lotus//Trips//EN"
+	Name      string // Optional display name for feeds
+	Events    []Event
+}
+
+// Marshal renders the calendar with CRLF line endings, escaped text values,
+// folded long lines and every time of a timed event in UTC
+func (c Calendar) Marshal() []byte {
+	var b strings.Builder
+
+	writeLine(&b, "BEGIN:VCALENDAR")
+	writeLine(&b, "VERSION:2.0")
+	writeLine(&b, "PRODID:"+c.ProductID)
+	writeLine(&b, "CALSCALE:GREGORIAN")
+	writeLine(&b, "METHOD:PUBLISH")
+	if c.Name != "" {
+		writeLine(&b, "X-WR-CALNAME:"+escapeText(c.Name))
+	}
+
+	for _, event := range c.Events {
+		writeLine(&b, "BEGIN:VEVENT")
+		writeLine(&b, "UID:"+escapeText(event.UID))
+		writeLine(&b, "DTSTAMP:"+formatTime(event.Stamp))
+		if event.AllDay {
+			writeLine(&b, "DTSTART;VALUE=DATE:"+event.Start.Format(dateFormat))
+			writeLine(&b, "DTEND;VALUE=DATE:"+event.End.Format(dateFormat))
+		} else {
+			writeLine(&b, "DTSTART:"+formatTime(event.Start))
+			writeLine(&b, "DTEND:"+formatTime(event.End))
+		}
+		writeLine(&b, "SUMMARY:"+escapeText(event.Summary))
+		if event.Location != "" {
+			writeLine(&b, "LOCATION:"+escapeText(event.Location))
+		}
+		if event.Description != "" {
+			writeLine(&b, "DESCRIPTION:"+escapeText(event.Description))
+		}
+		writeLine(&b, "END:VEVENT")
+	}
+
+	writeLine(&b, "END:VCALENDAR")
+	return []byte(b.String())
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(dateTimeFormat)
+}
+
+// escapeText escapes a TEXT value: backslashes, semicolons, commas and newlines
+func escapeText(value string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+		"\r", `\n`,
+	).Replace(value)
+}
+
+// writeLine folds the line into chunks of at most 75 octets, never splitting
+// a UTF-8 sequence. Continuation lines start with a single space.
+func writeLine(b *strings.Builder, line string) {
+	limit := maxLineOctets
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+
+		// The leading space counts towards the next line's length
+		limit = maxLineOctets - 1
+	}
+
+	b.WriteString(line)
+	b.WriteString("\r\n")
+}
@@ -0,0 +1,109 @@
+package ics_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"black-lotus/pkg/ics"
+)
+
+func TestCalendarMarshal(t *testing.T) {
+	start := time.Date(2030, 6, 1, 9, 0, 0, 0, time.FixedZone("CEST", 2*60*60))
+	calendar := ics.Calendar{
+		ProductID: "-//Test//EN",
+		Events: []ics.Event{{
+			UID:         "trip-1@example.com",
+			Summary:     "Lisbon, Porto; and back",
+			Description: "Line one\nLine two with a backslash \\",
+			Start:       start,
+			End:         start.Add(48 * time.Hour),
+			Stamp:       time.Date(2030, 1, 1, 12, 0, 0, 0, time.UTC),
+		}},
+	}
+
+	body := string(calendar.Marshal())
+
+	for _, line := range []string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//Test//EN",
+		"UID:trip-1@example.com",
+		"DTSTAMP:20300101T120000Z",
+		"DTSTART:20300601T070000Z",
+		"DTEND:20300603T070000Z",
+		`SUMMARY:Lisbon\, Porto\; and back`,
+		`DESCRIPTION:Line one\nLine two with a backslash \\`,
+		"END:VCALENDAR",
+	} {
+		if !strings.Contains(body, line+"\r\n") {
+			t.Errorf("Expected line %q, got:\n%s", line, body)
+		}
+	}
+
+	// Empty properties are left out rather than written blank
+	if strings.Contains(body, "LOCATION:") {
+		t.Errorf("Expected no LOCATION for an event without one, got:\n%s", body)
+	}
+
+	// Every line ends in CRLF
+	if strings.Count(body, "\n") != strings.Count(body, "\r\n") {
+		t.Errorf("Expected CRLF line endings, got:\n%q", body)
+	}
+}
+
+func TestCalendarMarshalAllDay(t *testing.T) {
+	calendar := ics.Calendar{
+		ProductID: "-//Test//EN",
+		Events: []ics.Event{{
+			UID:     "trip-1@example.com",
+			Summary: "Lisbon",
+			Start:   time.Date(2030, 6, 1, 0, 0, 0, 0, time.UTC),
+			End:     time.Date(2030, 6, 4, 0, 0, 0, 0, time.UTC),
+			AllDay:  true,
+			Stamp:   time.Date(2030, 1, 1, 12, 0, 0, 0, time.UTC),
+		}},
+	}
+
+	body := string(calendar.Marshal())
+
+	for _, line := range []string{
+		"DTSTAMP:20300101T120000Z",
+		"DTSTART;VALUE=DATE:20300601",
+		"DTEND;VALUE=DATE:20300604",
+	} {
+		if !strings.Contains(body, line+"\r\n") {
+			t.Errorf("Expected line %q, got:\n%s", line, body)
+		}
+	}
+
+	// All-day events carry no time of day
+	if strings.Contains(body, "DTSTART:") || strings.Contains(body, "DTEND:") {
+		t.Errorf("Expected date-only DTSTART and DTEND, got:\n%s", body)
+	}
+}
+
+func TestCalendarMarshalFoldsLongLines(t *testing.T) {
+	// Multi-byte runes must not be split across folded lines
+	summary := strings.Repeat("é", 100)
+	calendar := ics.Calendar{ProductID: "-//Test//EN", Events: []ics.Event{{UID: "1", Summary: summary}}}
+
+	body := string(calendar.Marshal())
+
+	var unfolded strings.Builder
+	for _, line := range strings.Split(strings.TrimSuffix(body, "\r\n"), "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("Expected lines of at most 75 octets, got %d: %q", len(line), line)
+		}
+		if !strings.HasPrefix(line, " ") {
+			unfolded.WriteString("\r\n")
+			unfolded.WriteString(line)
+			continue
+		}
+		unfolded.WriteString(line[1:])
+	}
+
+	if !strings.Contains(unfolded.String(), "\r\nSUMMARY:"+summary+"\r\n") {
+		t.Errorf("Expected the summary to unfold intact, got:\n%s", unfolded.String())
+	}
+}
@@ -0,0 +1,130 @@
+package mail_test
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"strings"
+	"testing"
+
+	"black-lotus/pkg/mail"
+)
+
+func TestFromEnv(t *testing.T) {
+	testCases := []struct {
+		name       string
+		env        map[string]string
+		expectSMTP bool
+	}{
+		{name: "Unset", expectSMTP: false},
+		{name: "HostWithoutFrom", env: map[string]string{"SMTP_HOST": "smtp.example.com"}, expectSMTP: false},
+		{name: "Configured", env: map[string]string{"SMTP_HOST": "smtp.example.com", "SMTP_FROM": "trips@example.com"}, expectSMTP: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, key := range []string{"SMTP_HOST", "SMTP_PORT", "SMTP_USERNAME", "SMTP_PASSWORD", "SMTP_FROM"} {
+				t.Setenv(key, tc.env[key])
+			}
+
+			mailer := mail.FromEnv()
+
+			if _, ok := mailer.(*mail.SMTPMailer); ok != tc.expectSMTP {
+				t.Errorf("Expected SMTP mailer=%v, got %T", tc.expectSMTP, mailer)
+			}
+		})
+	}
+}
+
+func TestSMTPMailerSend(t *testing.T) {
+	// Setup: a plain SMTP server that accepts one message
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	defer listener.Close()
+
+	received := make(chan []string, 1)
+	go serveOneMessage(listener, received)
+
+	port := listener.Addr().(*net.TCPAddr).Port
+	mailer := mail.NewSMTPMailer(mail.SMTPConfig{Host: "127.0.0.1", Port: port, From: "trips@example.com"})
+
+	// Execute
+	err = mailer.Send(context.Background(), "user@example.com", "Día\r\nBcc: x@example.com", "Line one\nLine two")
+
+	// Verify
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	lines := <-received
+	message := strings.Join(lines, "\n")
+	if !strings.Contains(message, "MAIL FROM:<trips@example.com>") || !strings.Contains(message, "RCPT TO:<user@example.com>") {
+		t.Errorf("Expected the envelope to name the sender and recipient, got %q", message)
+	}
+	if !strings.Contains(message, "Subject: =?utf-8?q?") {
+		t.Errorf("Expected an encoded subject, got %q", message)
+	}
+	for _, line := range lines {
+		if strings.HasPrefix(line, "Bcc:") {
+			t.Errorf("Expected the subject not to inject headers, got %q", message)
+		}
+	}
+	if !strings.Contains(message, "Line one\nLine two") {
+		t.Errorf("Expected the body, got %q", message)
+	}
+}
+
+func TestSMTPMailerRejectsHeaderInjection(t *testing.T) {
+	mailer := mail.NewSMTPMailer(mail.SMTPConfig{Host: "smtp.invalid", Port: mail.DefaultSMTPPort, From: "trips@example.com"})
+
+	if err := mailer.Send(context.Background(), "user@example.com\r\nBcc: x@example.com", "Hi", "Body"); err == nil {
+		t.Error("Expected a recipient with a newline to be rejected")
+	}
+}
+
+// serveOneMessage speaks just enough SMTP to accept a message and sends
+// every line the client wrote, without line endings
+func serveOneMessage(listener net.Listener, received chan<- []string) {
+	conn, err := listener.Accept()
+	if err != nil {
+		received <- nil
+		return
+	}
+	defer conn.Close()
+
+	reader := bufio.NewReader(conn)
+	reply := func(line string) { conn.Write([]byte(line + "\r\n")) }
+
+	var lines []string
+	inData := false
+	reply("220 localhost ESMTP")
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			break
+		}
+		line = strings.TrimRight(line, "\r\n")
+		lines = append(lines, line)
+
+		switch {
+		case inData && line == ".":
+			inData = false
+			reply("250 OK")
+		case inData:
+		case strings.HasPrefix(line, "EHLO"):
+			reply("250 localhost")
+		case strings.HasPrefix(line, "DATA"):
+			inData = true
+			reply("354 Go ahead")
+		case strings.HasPrefix(line, "QUIT"):
+			reply("221 Bye")
+			received <- lines
+			return
+		default:
+			reply("250 OK")
+		}
+	}
+	received <- lines
+}
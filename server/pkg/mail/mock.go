@@ -0,0 +1,40 @@
+package mail
+
+import (
+	"context"
+	"sync"
+)
+
+// Message is an email recorded by MockMailer
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// MockMailer records messages instead of sending them, for tests. Err, when
+// set, is returned from Send and nothing is recorded.
+type MockMailer struct {
+	mu   sync.Mutex
+	sent []Message
+	Err  error
+}
+
+func (m *MockMailer) Send(ctx context.Context, to, subject, body string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.Err != nil {
+		return m.Err
+	}
+	m.sent = append(m.sent, Message{To: to, Subject: subject, Body: body})
+	return nil
+}
+
+// Sent returns the messages sent so far, oldest first
+func (m *MockMailer) Sent() []Message {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]Message(nil), m.sent...)
+}
@@ -0,0 +1,44 @@
+package storage
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// FakeStorage presigns nothing, for tests. It returns predictable URLs under
+// BaseURL and records each key it was asked for. Err, when set, is returned
+// from PresignPut and nothing is recorded.
+type FakeStorage struct {
+	BaseURL string
+	Err     error
+
+	mu   sync.Mutex
+	keys []string
+}
+
+func (f *FakeStorage) PresignPut(ctx context.Context, key, contentType string) (*Upload, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.Err != nil {
+		return nil, f.Err
+	}
+	f.keys = append(f.keys, key)
+
+	return &Upload{
+		URL:       f.BaseURL + "/" + key + "?signature=fake",
+		Method:    "PUT",
+		Headers:   map[string]string{"Content-Type": contentType},
+		PublicURL: f.BaseURL + "/" + key,
+		ExpiresAt: time.Now().Add(DefaultUploadExpiry),
+	}, nil
+}
+
+// Keys returns the keys presigned so far, oldest first
+func (f *FakeStorage) Keys() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return append([]string(nil), f.keys...)
+}
@@ -0,0 +1,194 @@
+// Package storage hands out presigned upload URLs for a pluggable object store
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultRegion is used when S3_REGION isn't set
+const DefaultRegion = "us-east-1"
+
+// DefaultUploadExpiry is how long a presigned upload URL stays valid
+const DefaultUploadExpiry = 15 * time.Minute
+
+// ErrNotConfigured is returned by Disabled, the store used when no bucket is configured
+var ErrNotConfigured = errors.New("object storage not configured")
+
+// Upload describes a presigned PUT. The client sends the object to URL with
+// Headers set, and once that succeeds it is served from PublicURL.
+type Upload struct {
+	URL       string            `json:"upload_url"`
+	Method    string            `json:"method"`
+	Headers   map[string]string `json:"headers"`
+	PublicURL string            `json:"public_url"`
+	ExpiresAt time.Time         `json:"expires_at"`
+}
+
+// ObjectStorage presigns uploads so clients can send files straight to the
+// store without them passing through the API. Services depend on this
+// interface so tests can swap in a FakeStorage.
+type ObjectStorage interface {
+	PresignPut(ctx context.Context, key, contentType string) (*Upload, error)
+}
+
+// Disabled refuses every upload. It is the default when S3 isn't configured.
+type Disabled struct{}
+
+func (Disabled) PresignPut(ctx context.Context, key, contentType string) (*Upload, error) {
+	return nil, ErrNotConfigured
+}
+
+// S3Config describes an S3-compatible bucket. Endpoint defaults to AWS for
+// Region; set it for MinIO, R2 and the like. PublicBaseURL is where uploaded
+// objects are served from, defaulting to the bucket's own URL.
+type S3Config struct {
+	Endpoint        string
+	Region          string
+	Bucket          string
+	AccessKeyID     string
+	SecretAccessKey string
+	PublicBaseURL   string
+	Expiry          time.Duration
+}
+
+// FromEnv returns an S3Storage configured from S3_BUCKET, S3_ACCESS_KEY_ID,
+// S3_SECRET_ACCESS_KEY, S3_REGION (default us-east-1), S3_ENDPOINT and
+// S3_PUBLIC_URL, or Disabled when the bucket or credentials are unset
+func FromEnv() ObjectStorage {
+	config := S3Config{
+		Endpoint:        os.Getenv("S3_ENDPOINT"),
+		Region:          os.Getenv("S3_REGION"),
+		Bucket:          os.Getenv("S3_BUCKET"),
+		AccessKeyID:     os.Getenv("S3_ACCESS_KEY_ID"),
+		SecretAccessKey: os.Getenv("S3_SECRET_ACCESS_KEY"),
+		PublicBaseURL:   os.Getenv("S3_PUBLIC_URL"),
+	}
+	if config.Bucket == "" || config.AccessKeyID == "" || config.SecretAccessKey == "" {
+		return Disabled{}
+	}
+
+	return NewS3Storage(config)
+}
+
+// S3Storage presigns uploads with AWS Signature Version 4 query parameters.
+// Objects are addressed path-style, which every S3-compatible store accepts.
+type S3Storage struct {
+	config S3Config
+	now    func() time.Time
+}
+
+func NewS3Storage(config S3Config) *S3Storage {
+	if config.Region == "" {
+		config.Region = DefaultRegion
+	}
+	if config.Endpoint == "" {
+		config.Endpoint = "https://s3." + config.Region + ".amazonaws.com"
+	}
+	config.Endpoint = strings.TrimRight(config.Endpoint, "/")
+	if config.PublicBaseURL == "" {
+		config.PublicBaseURL = config.Endpoint + "/" + config.Bucket
+	}
+	config.PublicBaseURL = strings.TrimRight(config.PublicBaseURL, "/")
+	if config.Expiry <= 0 {
+		config.Expiry = DefaultUploadExpiry
+	}
+
+	return &S3Storage{config: config, now: time.Now}
+}
+
+// PresignPut signs a PUT of key that must carry the given Content-Type, so
+// the client can't store a different kind of file under the URL
+func (s *S3Storage) PresignPut(ctx context.Context, key, contentType string) (*Upload, error) {
+	endpoint, err := url.Parse(s.config.Endpoint)
+	if err != nil || endpoint.Host == "" {
+		return nil, fmt.Errorf("invalid S3 endpoint %q", s.config.Endpoint)
+	}
+
+	now := s.now().UTC()
+	date := now.Format("20060102")
+	amzDate := now.Format("20060102T150405Z")
+	scope := date + "/" + s.config.Region + "/s3/aws4_request"
+	path := endpoint.Path + "/" + uriEncode(s.config.Bucket, false) + "/" + uriEncode(key, true)
+
+	query := map[string]string{
+		"X-Amz-Algorithm":     "AWS4-HMAC-SHA256",
+		"X-Amz-Credential":    s.config.AccessKeyID + "/" + scope,
+		"X-Amz-Date":          amzDate,
+		"X-Amz-Expires":       fmt.Sprint(int(s.config.Expiry.Seconds())),
+		"X-Amz-SignedHeaders": "content-type;host",
+	}
+	canonicalQuery := canonicalQueryString(query)
+
+	canonicalRequest := strings.Join([]string{
+		"PUT",
+		path,
+		canonicalQuery,
+		"content-type:" + contentType + "\nhost:" + endpoint.Host + "\n",
+		"content-type;host",
+		"UNSIGNED-PAYLOAD",
+	}, "\n")
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(requestHash[:])
+
+	signingKey := hmacSHA256([]byte("AWS4"+s.config.SecretAccessKey), date)
+	for _, part := range []string{s.config.Region, "s3", "aws4_request"} {
+		signingKey = hmacSHA256(signingKey, part)
+	}
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	return &Upload{
+		URL:       endpoint.Scheme + "://" + endpoint.Host + path + "?" + canonicalQuery + "&X-Amz-Signature=" + signature,
+		Method:    "PUT",
+		Headers:   map[string]string{"Content-Type": contentType},
+		PublicURL: s.config.PublicBaseURL + "/" + uriEncode(key, true),
+		ExpiresAt: now.Add(s.config.Expiry),
+	}, nil
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// canonicalQueryString sorts and encodes the parameters the way SigV4 expects
+func canonicalQueryString(params map[string]string) string {
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, len(keys))
+	for i, key := range keys {
+		pairs[i] = uriEncode(key, false) + "=" + uriEncode(params[key], false)
+	}
+	return strings.Join(pairs, "&")
+}
+
+// uriEncode percent-encodes everything but RFC 3986 unreserved characters,
+// and slashes too when keepSlash is set. url.QueryEscape isn't used since it
+// turns spaces into '+', which SigV4 doesn't accept.
+func uriEncode(s string, keepSlash bool) string {
+	var b strings.Builder
+	for _, c := range []byte(s) {
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9',
+			c == '-', c == '_', c == '.', c == '~', keepSlash && c == '/':
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,85 @@
+package weather
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultCacheTTL is how long CachedProvider keeps results. Forecasts only
+// change every few hours, so this mostly absorbs repeated page loads.
+const DefaultCacheTTL = 15 * time.Minute
+
+// CachedProvider remembers another provider's places and forecasts for a
+// while, so the same trip viewed again doesn't call the API again. Errors
+// aren't cached.
+type CachedProvider struct {
+	provider WeatherProvider
+	ttl      time.Duration
+
+	mu        sync.Mutex
+	places    map[string]cacheEntry[*Place]
+	forecasts map[string]cacheEntry[[]Day]
+}
+
+type cacheEntry[T any] struct {
+	value   T
+	expires time.Time
+}
+
+func NewCachedProvider(provider WeatherProvider, ttl time.Duration) *CachedProvider {
+	return &CachedProvider{
+		provider:  provider,
+		ttl:       ttl,
+		places:    make(map[string]cacheEntry[*Place]),
+		forecasts: make(map[string]cacheEntry[[]Day]),
+	}
+}
+
+// Geocode is case- and whitespace-insensitive in the query
+func (c *CachedProvider) Geocode(ctx context.Context, query string) (*Place, error) {
+	key := strings.Join(strings.Fields(strings.ToLower(query)), " ")
+	return cached(c, c.places, key, func() (*Place, error) {
+		return c.provider.Geocode(ctx, query)
+	})
+}
+
+// DailyForecast shares results between places within about a kilometre
+func (c *CachedProvider) DailyForecast(ctx context.Context, place Place) ([]Day, error) {
+	key := fmt.Sprintf("%.2f,%.2f", place.Latitude, place.Longitude)
+	return cached(c, c.forecasts, key, func() ([]Day, error) {
+		return c.provider.DailyForecast(ctx, place)
+	})
+}
+
+// cached returns the unexpired entry for key, or calls fetch and stores its
+// result. The lock isn't held during fetch, so concurrent misses for the same
+// key may each call the provider; the last one wins.
+func cached[T any](c *CachedProvider, entries map[string]cacheEntry[T], key string, fetch func() (T, error)) (T, error) {
+	c.mu.Lock()
+	entry, ok := entries[key]
+	c.mu.Unlock()
+	if ok && time.Now().Before(entry.expires) {
+		return entry.value, nil
+	}
+
+	value, err := fetch()
+	if err != nil {
+		return value, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	// Drop expired entries as new ones arrive, so the cache can't grow without bound
+	for k, e := range entries {
+		if !now.Before(e.expires) {
+			delete(entries, k)
+		}
+	}
+	entries[key] = cacheEntry[T]{value: value, expires: now.Add(c.ttl)}
+
+	return value, nil
+}
@@ -0,0 +1,53 @@
+package weather
+
+import (
+	"context"
+	"strings"
+	"sync"
+)
+
+// MockProvider serves fixed places and forecasts, for tests. Places is keyed
+// by lowercase query; unknown queries aren't found. Every place gets Days.
+// Err, when set, is returned from both methods.
+type MockProvider struct {
+	Places map[string]Place
+	Days   []Day
+	Err    error
+
+	mu    sync.Mutex
+	calls int
+}
+
+func (m *MockProvider) Geocode(ctx context.Context, query string) (*Place, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.calls++
+	if m.Err != nil {
+		return nil, m.Err
+	}
+	place, ok := m.Places[strings.ToLower(strings.TrimSpace(query))]
+	if !ok {
+		return nil, ErrLocationNotFound
+	}
+	return &place, nil
+}
+
+func (m *MockProvider) DailyForecast(ctx context.Context, place Place) ([]Day, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.calls++
+	if m.Err != nil {
+		return nil, m.Err
+	}
+	return append([]Day(nil), m.Days...), nil
+}
+
+// Calls returns how many times either method has been called
+func (m *MockProvider) Calls() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.calls
+}
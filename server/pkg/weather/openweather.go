@@ -0,0 +1,153 @@
+package weather
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// DefaultOpenWeatherURL is the OpenWeatherMap API the provider calls
+const DefaultOpenWeatherURL = "https://api.openweathermap.org"
+
+// OpenWeatherProvider uses OpenWeatherMap's geocoding API and its free
+// 5 day / 3 hour forecast, folded into daily figures
+type OpenWeatherProvider struct {
+	apiKey     string
+	BaseURL    string // Overridable for tests
+	httpClient *http.Client
+}
+
+func NewOpenWeatherProvider(apiKey string) *OpenWeatherProvider {
+	return &OpenWeatherProvider{
+		apiKey:     apiKey,
+		BaseURL:    DefaultOpenWeatherURL,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Geocode returns the best match for query
+func (p *OpenWeatherProvider) Geocode(ctx context.Context, query string) (*Place, error) {
+	var matches []struct {
+		Name    string  `json:"name"`
+		Country string  `json:"country"`
+		Lat     float64 `json:"lat"`
+		Lon     float64 `json:"lon"`
+	}
+	params := url.Values{"q": {query}, "limit": {"1"}}
+	if err := p.get(ctx, "/geo/1.0/direct", params, &matches); err != nil {
+		return nil, err
+	}
+
+	if len(matches) == 0 {
+		return nil, ErrLocationNotFound
+	}
+
+	return &Place{
+		Name:      matches[0].Name,
+		Country:   matches[0].Country,
+		Latitude:  matches[0].Lat,
+		Longitude: matches[0].Lon,
+	}, nil
+}
+
+// DailyForecast groups the 3-hourly forecast into the place's local days.
+// Each day takes the lowest and highest temperatures and the highest chance
+// of rain of its entries, and the summary of the one closest to midday.
+func (p *OpenWeatherProvider) DailyForecast(ctx context.Context, place Place) ([]Day, error) {
+	var forecast struct {
+		List []struct {
+			Dt   int64 `json:"dt"`
+			Main struct {
+				TempMin float64 `json:"temp_min"`
+				TempMax float64 `json:"temp_max"`
+			} `json:"main"`
+			Weather []struct {
+				Description string `json:"description"`
+			} `json:"weather"`
+			Pop float64 `json:"pop"`
+		} `json:"list"`
+		City struct {
+			Timezone int `json:"timezone"` // Offset from UTC in seconds
+		} `json:"city"`
+	}
+	params := url.Values{
+		"lat":   {fmt.Sprint(place.Latitude)},
+		"lon":   {fmt.Sprint(place.Longitude)},
+		"units": {"metric"},
+	}
+	if err := p.get(ctx, "/data/2.5/forecast", params, &forecast); err != nil {
+		return nil, err
+	}
+
+	zone := time.FixedZone("", forecast.City.Timezone)
+	var days []Day
+	var middayDistance []float64
+	for _, entry := range forecast.List {
+		local := time.Unix(entry.Dt, 0).In(zone)
+		date := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC)
+		fromMidday := math.Abs(float64(local.Hour()) + float64(local.Minute())/60 - 12)
+
+		summary := ""
+		if len(entry.Weather) > 0 {
+			summary = entry.Weather[0].Description
+		}
+
+		last := len(days) - 1
+		if last < 0 || !days[last].Date.Equal(date) {
+			days = append(days, Day{
+				Date:                date,
+				Summary:             summary,
+				TempMinC:            entry.Main.TempMin,
+				TempMaxC:            entry.Main.TempMax,
+				PrecipitationChance: entry.Pop,
+			})
+			middayDistance = append(middayDistance, fromMidday)
+			continue
+		}
+
+		day := &days[last]
+		day.TempMinC = math.Min(day.TempMinC, entry.Main.TempMin)
+		day.TempMaxC = math.Max(day.TempMaxC, entry.Main.TempMax)
+		day.PrecipitationChance = math.Max(day.PrecipitationChance, entry.Pop)
+		if fromMidday < middayDistance[last] {
+			day.Summary = summary
+			middayDistance[last] = fromMidday
+		}
+	}
+
+	return days, nil
+}
+
+// get calls an OpenWeatherMap endpoint with the API key and decodes the JSON response
+func (p *OpenWeatherProvider) get(ctx context.Context, path string, params url.Values, out any) error {
+	params.Set("appid", p.apiKey)
+	endpoint := strings.TrimRight(p.BaseURL, "/") + path + "?" + params.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create weather request: %w", err)
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		// The URL carries the API key, so don't let it into the error
+		return fmt.Errorf("weather request to %s failed: %w", path, errors.Unwrap(err))
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("weather request to %s failed: status %d", path, resp.StatusCode)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to parse weather response: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,75 @@
+// Package weather geocodes places and fetches daily forecasts through a
+// pluggable WeatherProvider
+package weather
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"time"
+)
+
+var (
+	// ErrNotConfigured is returned by Disabled, the provider used when no API key is set
+	ErrNotConfigured = errors.New("weather provider not configured")
+	// ErrLocationNotFound is returned when a place can't be geocoded
+	ErrLocationNotFound = errors.New("location not found")
+)
+
+// Place is where a location name was geocoded to
+type Place struct {
+	Name      string  `json:"name"`
+	Country   string  `json:"country"`
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// Day is the forecast for one calendar day at a place. Date is that day at
+// midnight UTC, the same way trip dates are stored.
+type Day struct {
+	Date                time.Time `json:"date" format:"date"`
+	Summary             string    `json:"summary"`
+	TempMinC            float64   `json:"temp_min_c"`
+	TempMaxC            float64   `json:"temp_max_c"`
+	PrecipitationChance float64   `json:"precipitation_chance"` // 0 to 1
+}
+
+// MarshalJSON writes Date as a plain YYYY-MM-DD day, like trip dates
+func (d Day) MarshalJSON() ([]byte, error) {
+	type plain Day
+	return json.Marshal(struct {
+		plain
+		Date string `json:"date"`
+	}{plain(d), d.Date.Format(time.DateOnly)})
+}
+
+// WeatherProvider looks up places and their forecasts. Services depend on
+// this interface so tests can swap in a MockProvider.
+type WeatherProvider interface {
+	Geocode(ctx context.Context, query string) (*Place, error)
+	// DailyForecast returns the days the provider forecasts, earliest first
+	DailyForecast(ctx context.Context, place Place) ([]Day, error)
+}
+
+// Disabled has no forecasts. It is the default when no API key is configured.
+type Disabled struct{}
+
+func (Disabled) Geocode(ctx context.Context, query string) (*Place, error) {
+	return nil, ErrNotConfigured
+}
+
+func (Disabled) DailyForecast(ctx context.Context, place Place) ([]Day, error) {
+	return nil, ErrNotConfigured
+}
+
+// FromEnv returns an OpenWeatherProvider using OPENWEATHER_API_KEY, or
+// Disabled when it is unset
+func FromEnv() WeatherProvider {
+	apiKey := os.Getenv("OPENWEATHER_API_KEY")
+	if apiKey == "" {
+		return Disabled{}
+	}
+
+	return NewOpenWeatherProvider(apiKey)
+}
@@ -0,0 +1,208 @@
+package weather_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"black-lotus/pkg/weather"
+)
+
+func TestFromEnv(t *testing.T) {
+	testCases := []struct {
+		name              string
+		apiKey            string
+		expectOpenWeather bool
+	}{
+		{name: "Unset", expectOpenWeather: false},
+		{name: "Configured", apiKey: "key", expectOpenWeather: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("OPENWEATHER_API_KEY", tc.apiKey)
+
+			provider := weather.FromEnv()
+
+			if _, ok := provider.(*weather.OpenWeatherProvider); ok != tc.expectOpenWeather {
+				t.Errorf("Expected OpenWeather provider=%v, got %T", tc.expectOpenWeather, provider)
+			}
+		})
+	}
+}
+
+func TestDisabledProvider(t *testing.T) {
+	if _, err := (weather.Disabled{}).Geocode(context.Background(), "Lisbon"); err != weather.ErrNotConfigured {
+		t.Errorf("Expected ErrNotConfigured, got: %v", err)
+	}
+	if _, err := (weather.Disabled{}).DailyForecast(context.Background(), weather.Place{}); err != weather.ErrNotConfigured {
+		t.Errorf("Expected ErrNotConfigured, got: %v", err)
+	}
+}
+
+// newOpenWeatherServer fakes the geocoding and forecast endpoints for Lisbon,
+// an hour ahead of UTC
+func newOpenWeatherServer(t *testing.T) *httptest.Server {
+	forecastEntry := func(at time.Time, tempMin, tempMax, pop float64, description string) string {
+		return fmt.Sprintf(`{"dt": %d, "main": {"temp_min": %g, "temp_max": %g}, "weather": [{"description": %q}], "pop": %g}`,
+			at.Unix(), tempMin, tempMax, description, pop)
+	}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("appid") != "secret-key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/geo/1.0/direct":
+			if query.Get("q") != "Lisbon" {
+				fmt.Fprint(w, `[]`)
+				return
+			}
+			fmt.Fprint(w, `[{"name": "Lisbon", "country": "PT", "lat": 38.7077, "lon": -9.1365}]`)
+		case "/data/2.5/forecast":
+			if query.Get("lat") != "38.7077" || query.Get("lon") != "-9.1365" || query.Get("units") != "metric" {
+				t.Errorf("Unexpected forecast query: %s", r.URL.RawQuery)
+			}
+			entries := []string{
+				forecastEntry(time.Date(2026, 6, 1, 22, 0, 0, 0, time.UTC), 17, 18, 0, "clear sky"),
+				forecastEntry(time.Date(2026, 6, 2, 5, 0, 0, 0, time.UTC), 14, 15, 0.1, "few clouds"),
+				forecastEntry(time.Date(2026, 6, 2, 11, 0, 0, 0, time.UTC), 22, 24, 0.2, "scattered clouds"),
+				forecastEntry(time.Date(2026, 6, 2, 20, 0, 0, 0, time.UTC), 18, 19, 0.6, "light rain"),
+				forecastEntry(time.Date(2026, 6, 2, 23, 0, 0, 0, time.UTC), 16, 16, 0.3, "overcast clouds"),
+			}
+			fmt.Fprintf(w, `{"list": [%s], "city": {"timezone": 3600}}`, strings.Join(entries, ","))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestOpenWeatherProvider(t *testing.T) {
+	server := newOpenWeatherServer(t)
+	defer server.Close()
+
+	provider := weather.NewOpenWeatherProvider("secret-key")
+	provider.BaseURL = server.URL
+	ctx := context.Background()
+
+	place, err := provider.Geocode(ctx, "Lisbon")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if place.Name != "Lisbon" || place.Country != "PT" || place.Latitude != 38.7077 || place.Longitude != -9.1365 {
+		t.Errorf("Unexpected place: %+v", place)
+	}
+
+	if _, err := provider.Geocode(ctx, "Atlantis"); err != weather.ErrLocationNotFound {
+		t.Errorf("Expected ErrLocationNotFound, got: %v", err)
+	}
+
+	days, err := provider.DailyForecast(ctx, *place)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	// Entries are grouped by Lisbon's local day, so 22:00 and 23:00 UTC start new days
+	expected := []weather.Day{
+		{Date: time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC), Summary: "clear sky", TempMinC: 17, TempMaxC: 18, PrecipitationChance: 0},
+		{Date: time.Date(2026, 6, 2, 0, 0, 0, 0, time.UTC), Summary: "scattered clouds", TempMinC: 14, TempMaxC: 24, PrecipitationChance: 0.6},
+		{Date: time.Date(2026, 6, 3, 0, 0, 0, 0, time.UTC), Summary: "overcast clouds", TempMinC: 16, TempMaxC: 16, PrecipitationChance: 0.3},
+	}
+	if len(days) != len(expected) {
+		t.Fatalf("Expected %d days, got %d: %+v", len(expected), len(days), days)
+	}
+	for i := range expected {
+		if days[i] != expected[i] {
+			t.Errorf("Day %d: expected %+v, got %+v", i, expected[i], days[i])
+		}
+	}
+}
+
+func TestOpenWeatherProviderErrorHidesAPIKey(t *testing.T) {
+	server := newOpenWeatherServer(t)
+	defer server.Close()
+
+	provider := weather.NewOpenWeatherProvider("wrong-key")
+	provider.BaseURL = server.URL
+
+	_, err := provider.Geocode(context.Background(), "Lisbon")
+	if err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+	if strings.Contains(err.Error(), "wrong-key") {
+		t.Errorf("Expected error without the API key, got: %v", err)
+	}
+
+	// Unreachable servers fail with the request URL, which carries the key
+	server.Close()
+	_, err = provider.Geocode(context.Background(), "Lisbon")
+	if err == nil || strings.Contains(err.Error(), "wrong-key") {
+		t.Errorf("Expected error without the API key, got: %v", err)
+	}
+}
+
+func TestCachedProvider(t *testing.T) {
+	mock := &weather.MockProvider{
+		Places: map[string]weather.Place{"lisbon": {Name: "Lisbon", Latitude: 38.7077, Longitude: -9.1365}},
+		Days:   []weather.Day{{Date: time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC), Summary: "clear sky"}},
+	}
+	cache := weather.NewCachedProvider(mock, time.Minute)
+	ctx := context.Background()
+
+	for _, query := range []string{"Lisbon", " lisbon", "LISBON "} {
+		place, err := cache.Geocode(ctx, query)
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if _, err := cache.DailyForecast(ctx, *place); err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+	}
+	if mock.Calls() != 2 {
+		t.Errorf("Expected one geocode and one forecast call, got %d calls", mock.Calls())
+	}
+
+	// Nearby coordinates share the cached forecast
+	if _, err := cache.DailyForecast(ctx, weather.Place{Latitude: 38.7081, Longitude: -9.1362}); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if mock.Calls() != 2 {
+		t.Errorf("Expected nearby forecast to be cached, got %d calls", mock.Calls())
+	}
+
+	// Failures aren't cached
+	for i := 0; i < 2; i++ {
+		if _, err := cache.Geocode(ctx, "Atlantis"); !errors.Is(err, weather.ErrLocationNotFound) {
+			t.Errorf("Expected ErrLocationNotFound, got: %v", err)
+		}
+	}
+	if mock.Calls() != 4 {
+		t.Errorf("Expected failed lookups to reach the provider, got %d calls", mock.Calls())
+	}
+}
+
+func TestCachedProviderExpires(t *testing.T) {
+	mock := &weather.MockProvider{Places: map[string]weather.Place{"lisbon": {Name: "Lisbon"}}}
+	cache := weather.NewCachedProvider(mock, time.Millisecond)
+	ctx := context.Background()
+
+	if _, err := cache.Geocode(ctx, "Lisbon"); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	time.Sleep(5 * time.Millisecond)
+	if _, err := cache.Geocode(ctx, "Lisbon"); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if mock.Calls() != 2 {
+		t.Errorf("Expected expired entry to be fetched again, got %d calls", mock.Calls())
+	}
+}